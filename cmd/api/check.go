@@ -0,0 +1,122 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/shoelfikar/voucher-management-system/internal/config"
+	"github.com/shoelfikar/voucher-management-system/internal/domain/entity"
+	"github.com/shoelfikar/voucher-management-system/pkg/database"
+	"github.com/shoelfikar/voucher-management-system/pkg/fieldcrypt"
+	"github.com/shoelfikar/voucher-management-system/pkg/jwt"
+	"github.com/shoelfikar/voucher-management-system/pkg/password"
+	"github.com/shoelfikar/voucher-management-system/pkg/preflight"
+	"gorm.io/gorm"
+)
+
+// minJWTSecretLength is the shortest HS256 secret accepted, matching the hash size
+const minJWTSecretLength = 32
+
+// exampleJWTSecret is the placeholder shipped in .env.example
+const exampleJWTSecret = "your-super-secret-key-change-this"
+
+const databaseCheckTimeout = 5 * time.Second
+
+// runChecks validates what the service needs to start without starting it or
+// changing the database, writes a report to w and returns the exit code.
+// The service has no Redis or queue dependency, so there is nothing to check there.
+func runChecks(w io.Writer) int {
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		preflight.Run(w, []preflight.Check{{Name: "config", Run: func() error { return err }}})
+		return 1
+	}
+
+	var db *gorm.DB
+	checks := []preflight.Check{
+		{Name: "config", Run: func() error { return nil }},
+		{Name: "database connection", Run: func() error {
+			db, err = connectDatabase(&cfg.Database)
+			return err
+		}},
+		{Name: "database schema", Run: func() error {
+			if db == nil {
+				return errors.New("skipped, no database connection")
+			}
+			pending, err := database.PendingSchemaChanges(db, models...)
+			if err != nil {
+				return err
+			}
+			if len(pending) > 0 {
+				return fmt.Errorf("%d pending migration(s): %s", len(pending), strings.Join(pending, ", "))
+			}
+			return nil
+		}},
+		{Name: "jwt key material", Run: func() error { return checkJWT(cfg.JWT) }},
+		{Name: "metadata encryption key", Run: func() error {
+			if len(cfg.Encryption.SensitiveMetadataKeys) == 0 {
+				return nil
+			}
+			_, err := fieldcrypt.NewCipherFromBase64(cfg.Encryption.MetadataKey)
+			return err
+		}},
+		{Name: "password hashing", Run: func() error {
+			_, err := password.NewHasher(passwordHasherConfig(cfg))
+			return err
+		}},
+	}
+
+	if !preflight.Run(w, checks) {
+		return 1
+	}
+	return 0
+}
+
+// connectDatabase opens the database and confirms it answers within the check timeout
+func connectDatabase(cfg *config.DatabaseConfig) (*gorm.DB, error) {
+	db, err := database.NewPostgresDatabase(cfg)
+	if err != nil {
+		return nil, err
+	}
+	sqlDB, err := db.DB()
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), databaseCheckTimeout)
+	defer cancel()
+	if err := sqlDB.PingContext(ctx); err != nil {
+		return nil, fmt.Errorf("database did not answer: %w", err)
+	}
+	return db, nil
+}
+
+// checkJWT rejects missing, short or placeholder secrets and confirms a token round-trips
+func checkJWT(cfg config.JWTConfig) error {
+	switch {
+	case cfg.Secret == "":
+		return errors.New("JWT_SECRET is not set")
+	case cfg.Secret == exampleJWTSecret:
+		return errors.New("JWT_SECRET is still the example value")
+	case len(cfg.Secret) < minJWTSecretLength:
+		return fmt.Errorf("JWT_SECRET must be at least %d bytes, got %d", minJWTSecretLength, len(cfg.Secret))
+	}
+
+	jwtService := jwt.NewJWTService(cfg.Secret, cfg.Expiration,
+		jwt.WithLeeway(cfg.Leeway),
+		jwt.WithIssuer(cfg.Issuer),
+		jwt.WithAudience(cfg.Audience),
+	)
+	token, err := jwtService.GenerateToken("preflight@example.com", entity.UserRoleViewer)
+	if err != nil {
+		return fmt.Errorf("failed to sign a token: %w", err)
+	}
+	if _, err := jwtService.ValidateToken(token); err != nil {
+		return fmt.Errorf("failed to validate a signed token: %w", err)
+	}
+	return nil
+}
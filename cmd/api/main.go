@@ -1,71 +1,517 @@
 package main
 
 import (
-	"log"
+	"context"
+	"flag"
+	nethttp "net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
 
+	"github.com/gin-gonic/gin"
 	"github.com/shoelfikar/voucher-management-system/internal/config"
 	"github.com/shoelfikar/voucher-management-system/internal/delivery/http"
 	"github.com/shoelfikar/voucher-management-system/internal/delivery/http/handler"
 	"github.com/shoelfikar/voucher-management-system/internal/delivery/http/middleware"
 	"github.com/shoelfikar/voucher-management-system/internal/domain/entity"
+	domainRepository "github.com/shoelfikar/voucher-management-system/internal/domain/repository"
+	domainService "github.com/shoelfikar/voucher-management-system/internal/domain/service"
 	"github.com/shoelfikar/voucher-management-system/internal/repository"
 	"github.com/shoelfikar/voucher-management-system/internal/service"
+	"github.com/shoelfikar/voucher-management-system/pkg/buildinfo"
+	"github.com/shoelfikar/voucher-management-system/pkg/captcha"
 	"github.com/shoelfikar/voucher-management-system/pkg/database"
+	"github.com/shoelfikar/voucher-management-system/pkg/events"
+	"github.com/shoelfikar/voucher-management-system/pkg/fieldcrypt"
 	"github.com/shoelfikar/voucher-management-system/pkg/jwt"
+	"github.com/shoelfikar/voucher-management-system/pkg/lock"
+	"github.com/shoelfikar/voucher-management-system/pkg/logger"
+	"github.com/shoelfikar/voucher-management-system/pkg/mailer"
+	"github.com/shoelfikar/voucher-management-system/pkg/metrics"
+	"github.com/shoelfikar/voucher-management-system/pkg/password"
+	"github.com/shoelfikar/voucher-management-system/pkg/scheduler"
+	"github.com/shoelfikar/voucher-management-system/pkg/segments"
+	"github.com/shoelfikar/voucher-management-system/pkg/sheets"
+	"github.com/shoelfikar/voucher-management-system/pkg/slo"
+	"github.com/shoelfikar/voucher-management-system/pkg/sms"
+	"github.com/shoelfikar/voucher-management-system/pkg/throttle"
+	"github.com/shoelfikar/voucher-management-system/pkg/timing"
 )
 
+// models are the entities whose tables are migrated on startup
+var models = []interface{}{
+	&entity.User{}, &entity.Voucher{}, &entity.ImportRule{}, &entity.RetentionPolicy{}, &entity.Segment{}, &entity.SegmentMember{}, &entity.Partner{}, &entity.VoucherTemplate{},
+	&entity.VoucherAssignment{}, &entity.ClaimLink{}, &entity.SMSDelivery{}, &entity.DiscountLimit{}, &entity.AuditEntry{},
+	&entity.Redemption{},
+	&entity.EmailTemplate{},
+	&entity.Customer{},
+}
+
 func main() {
-	log.Println("Loading configuration...")
+	check := flag.Bool("check", false, "validate configuration, database and key material, print a report and exit")
+	flag.Parse()
+	if *check {
+		os.Exit(runChecks(os.Stdout))
+	}
+
+	// SIGINT and SIGTERM cancel ctx, which stops the scheduled jobs and starts a graceful shutdown
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	startedAt := time.Now()
+	build := buildinfo.Get()
+	logger.FromContext(ctx).Info("starting voucher service", "version", build.Version, "commit", build.Commit, "built", build.BuildDate, "go", build.GoVersion)
+
+	logger.FromContext(ctx).Info("loading configuration")
 	cfg, err := config.LoadConfig()
 	if err != nil {
-		log.Fatal("Failed to load config:", err)
+		fatal(ctx, "failed to load config", err)
 	}
+	if err := logger.Setup(os.Stderr, cfg.Log.Format, cfg.Log.Level); err != nil {
+		fatal(ctx, "failed to configure logging", err)
+	}
+	// CORS origins, throttle limits and open registration are read from live
+	// on every request, so a reload applies them without a restart
+	live := config.NewLive(cfg, config.LoadConfig)
 
-	log.Println("Connecting to database...")
+	logger.FromContext(ctx).Info("connecting to database")
 	db, err := database.NewPostgresDatabase(&cfg.Database)
 	if err != nil {
-		log.Fatal("Failed to connect to database:", err)
+		fatal(ctx, "failed to connect to database", err)
 	}
 
-	log.Println("Running database migrations...")
-	err = db.AutoMigrate(&entity.User{}, &entity.Voucher{})
+	logger.FromContext(ctx).Info("running database migrations")
+	err = db.AutoMigrate(models...)
 	if err != nil {
-		log.Fatal("Failed to migrate database:", err)
+		fatal(ctx, "failed to migrate database", err)
 	}
 
-	log.Println("Initializing JWT service...")
-	jwtService := jwt.NewJWTService(cfg.JWT.Secret, cfg.JWT.Expiration)
+	// Warn when Go-side validation and database CHECK constraints have drifted apart
+	warnings, err := database.ValidateConstraints(db, repository.VoucherConstraints)
+	if err != nil {
+		logger.FromContext(ctx).Warn("failed to validate database constraints", "error", err)
+	}
+	for _, warning := range warnings {
+		logger.FromContext(ctx).Warn("constraint mismatch", "warning", warning)
+	}
 
-	log.Println("Initializing repositories...")
+	if cfg.Server.ServerTiming {
+		if err := db.Use(timing.GormPlugin{}); err != nil {
+			fatal(ctx, "failed to register server timing plugin", err)
+		}
+	}
+
+	logger.FromContext(ctx).Info("initializing JWT service")
+	jwtService := jwt.NewJWTService(cfg.JWT.Secret, cfg.JWT.Expiration,
+		jwt.WithLeeway(cfg.JWT.Leeway),
+		jwt.WithIssuer(cfg.JWT.Issuer),
+		jwt.WithAudience(cfg.JWT.Audience),
+	)
+
+	// Without an SMTP host, emails are written to the log
+	emailSender := mailer.NewLogMailer()
+	if cfg.SMTP.Host != "" {
+		emailSender = mailer.NewSMTPMailer(cfg.SMTP.Host, cfg.SMTP.Port, cfg.SMTP.Username, cfg.SMTP.Password, cfg.SMTP.From)
+	}
+
+	smsSender, err := sms.NewSender(sms.Config{
+		Provider:   cfg.SMS.Provider,
+		From:       cfg.SMS.From,
+		AccountSID: cfg.SMS.TwilioAccountSID,
+		AuthToken:  cfg.SMS.TwilioAuthToken,
+		APIKey:     cfg.SMS.VonageAPIKey,
+		APISecret:  cfg.SMS.VonageAPISecret,
+		Timeout:    cfg.SMS.Timeout,
+	})
+	if err != nil {
+		fatal(ctx, "failed to initialize SMS delivery", err)
+	}
+
+	logger.FromContext(ctx).Info("initializing repositories")
 	userRepo := repository.NewUserRepository(db)
+	importRuleRepo := repository.NewImportRuleRepository(db)
+	voucherTemplateRepo := repository.NewVoucherTemplateRepository(db)
+	voucherAssignmentRepo := repository.NewVoucherAssignmentRepository(db)
+	claimLinkRepo := repository.NewClaimLinkRepository(db)
+	redemptionRepo := repository.NewRedemptionRepository(db)
+	smsDeliveryRepo := repository.NewSMSDeliveryRepository(db)
+	discountLimitRepo := repository.NewDiscountLimitRepository(db)
+	customerRepo := repository.NewCustomerRepository(db)
+	emailTemplateRepo := repository.NewEmailTemplateRepository(db)
+	auditRepo := repository.NewAuditRepository(db)
+	retentionPolicyRepo := repository.NewRetentionPolicyRepository(db)
+	segmentRepo := repository.NewSegmentRepository(db)
+	reportRepo := repository.NewReportRepository(db)
+	partnerRepo := repository.NewPartnerRepository(db)
+	systemRepo := repository.NewSystemRepository(db, models...)
 	voucherRepo := repository.NewVoucherRepository(db)
+	// Imports, assignments and redemptions ride out brief failovers instead of failing the request
+	if cfg.Database.RetryMaxAttempts > 1 {
+		retryPolicy := database.RetryPolicy{
+			MaxAttempts: cfg.Database.RetryMaxAttempts,
+			BaseDelay:   cfg.Database.RetryBaseDelay,
+			MaxDelay:    cfg.Database.RetryMaxDelay,
+		}
+		voucherRepo = repository.NewRetryingVoucherRepository(voucherRepo, retryPolicy)
+		voucherAssignmentRepo = repository.NewRetryingVoucherAssignmentRepository(voucherAssignmentRepo, retryPolicy)
+		redemptionRepo = repository.NewRetryingRedemptionRepository(redemptionRepo, retryPolicy)
+	}
+	if len(cfg.Encryption.SensitiveMetadataKeys) > 0 {
+		metadataCipher, err := fieldcrypt.NewCipherFromBase64(cfg.Encryption.MetadataKey)
+		if err != nil {
+			fatal(ctx, "failed to initialize metadata encryption", err)
+		}
+		voucherRepo = repository.NewEncryptedVoucherRepository(voucherRepo, metadataCipher, cfg.Encryption.SensitiveMetadataKeys)
+	}
+	if cfg.Import.DuplicateCacheTTL > 0 {
+		voucherRepo = repository.NewCachedVoucherRepository(voucherRepo, cfg.Import.DuplicateCacheTTL)
+	}
 
-	log.Println("Initializing services...")
-	authService := service.NewAuthService(userRepo, jwtService)
-	voucherService := service.NewVoucherService(voucherRepo)
+	passwordHasher, err := password.NewHasher(passwordHasherConfig(cfg))
+	if err != nil {
+		fatal(ctx, "failed to initialize password hashing", err)
+	}
+
+	logger.FromContext(ctx).Info("initializing services")
+	emailTemplateService := service.NewEmailTemplateService(emailTemplateRepo, emailSender)
+	customerService := service.NewCustomerService(customerRepo)
+	accounts := newAccounts(userRepo, cfg, jwtService, passwordHasher, emailSender, emailTemplateService,
+		func() bool { return live.Get().Registration.Open })
+	userService, authService := accounts.userService, accounts.authService
+	var segmentProvider segments.Provider
+	if cfg.Segments.URL != "" {
+		segmentProvider = segments.NewHTTPProvider(cfg.Segments.URL, cfg.Segments.Token, cfg.Segments.Timeout)
+	}
+	segmentService := service.NewSegmentService(segmentRepo, segmentProvider)
+	eventBroker := events.NewBroker()
+	voucherServiceOptions := []service.VoucherServiceOption{
+		service.WithImportRules(importRuleRepo),
+		service.WithTemplates(voucherTemplateRepo),
+		service.WithAssignments(voucherAssignmentRepo, customerService),
+		service.WithDiscountLimits(discountLimitRepo),
+		service.WithAudit(auditRepo),
+		service.WithEvents(eventBroker),
+		service.WithSegments(segmentService),
+		service.WithApprovalAmountThresholds(cfg.Approval.AmountThresholds),
+	}
+	if cfg.Masking.Logs {
+		voucherServiceOptions = append(voucherServiceOptions, service.WithMaskedLogCodes())
+	}
+	voucherService := service.NewVoucherService(voucherRepo, cfg.Approval.DiscountThreshold, voucherServiceOptions...)
+	claimService := service.NewClaimService(claimLinkRepo, voucherService, customerService, jwtService, service.ClaimLinks{
+		URL:        cfg.Claim.URL,
+		Expiration: cfg.Claim.Expiration,
+	})
+	redemptionService := service.NewRedemptionService(redemptionRepo, voucherService, customerService)
+	reportService := service.NewReportService(reportRepo)
+	smsService := service.NewSMSService(smsDeliveryRepo, voucherService, claimService, smsSender, cfg.SMS.MaxAttempts)
+	discountLimitService := service.NewDiscountLimitService(discountLimitRepo)
+	deadLetterService := service.NewDeadLetterService(smsDeliveryRepo, voucherRepo)
+	importRuleService := service.NewImportRuleService(importRuleRepo)
+	voucherTemplateService := service.NewVoucherTemplateService(voucherTemplateRepo)
+	retentionService := service.NewRetentionService(retentionPolicyRepo, voucherRepo, service.WithAssignmentCleanup(voucherAssignmentRepo))
+	partnerService := service.NewPartnerService(partnerRepo, voucherRepo, voucherService)
+	jobs := scheduler.NewMonitor()
+	systemService := service.NewSystemService(systemRepo, func() map[string]interface{} { return live.Get().Summary() }, live, startedAt, dependencyChecks(cfg, jobs)...)
 
-	log.Println("Initializing handlers...")
+	logger.FromContext(ctx).Info("initializing handlers")
 	authHandler := handler.NewAuthHandler(authService)
-	voucherHandler := handler.NewVoucherHandler(voucherService)
+	var voucherHandlerOptions []handler.VoucherHandlerOption
+	if cfg.Masking.ViewerResponses {
+		voucherHandlerOptions = append(voucherHandlerOptions, handler.WithViewerCodeMasking())
+	}
+	voucherHandler := handler.NewVoucherHandler(voucherService, voucherHandlerOptions...)
+	userHandler := handler.NewUserHandler(userService)
+	importRuleHandler := handler.NewImportRuleHandler(importRuleService)
+	voucherTemplateHandler := handler.NewVoucherTemplateHandler(voucherTemplateService)
+	retentionHandler := handler.NewRetentionHandler(retentionService)
+	segmentHandler := handler.NewSegmentHandler(segmentService)
+	partnerHandler := handler.NewPartnerHandler(partnerService)
+	systemHandler := handler.NewSystemHandler(systemService)
+	streamHandler := handler.NewStreamHandler(eventBroker)
+	claimHandler := handler.NewClaimHandler(claimService)
+	redemptionHandler := handler.NewRedemptionHandler(redemptionService)
+	smsHandler := handler.NewSMSHandler(smsService)
+	discountLimitHandler := handler.NewDiscountLimitHandler(discountLimitService)
+	deadLetterHandler := handler.NewDeadLetterHandler(deadLetterService)
+	emailTemplateHandler := handler.NewEmailTemplateHandler(emailTemplateService)
+	customerHandler := handler.NewCustomerHandler(customerService)
+	reportHandler := handler.NewReportHandler(reportService)
+
+	var sheetImportHandler *handler.SheetImportHandler
+	if cfg.GoogleSheets.CredentialsFile != "" {
+		sheetsClient, err := sheets.NewClient(
+			cfg.GoogleSheets.CredentialsFile,
+			cfg.GoogleSheets.SpreadsheetID,
+			cfg.GoogleSheets.Range,
+		)
+		if err != nil {
+			fatal(ctx, "failed to initialize Google Sheets client", err)
+		}
+		sheetImportHandler = handler.NewSheetImportHandler(voucherService, sheetsClient)
+	}
+
+	logger.FromContext(ctx).Info("initializing middleware")
+	authMiddleware := accounts.authMiddleware
+	partnerAuthMiddleware := middleware.PartnerAuthMiddleware(partnerService)
+	corsMiddleware := middleware.CORSMiddleware(func() []string { return live.Get().CORS.AllowedOrigins })
+
+	var serverTimingMiddleware gin.HandlerFunc
+	if cfg.Server.ServerTiming {
+		serverTimingMiddleware = middleware.ServerTimingMiddleware()
+	}
+
+	var compressionMiddleware gin.HandlerFunc
+	if cfg.Server.Compression.Enabled {
+		compressionMiddleware = middleware.CompressionMiddleware(
+			cfg.Server.Compression.MinSize,
+			cfg.Server.Compression.ContentTypes,
+		)
+	}
+
+	// Pool saturation during imports and exports shows up in the wait gauges
+	// before requests start timing out
+	var metricsHandler gin.HandlerFunc
+	if cfg.Metrics.Enabled {
+		registry := metrics.NewRegistry()
+		sqlDB, err := db.DB()
+		if err != nil {
+			fatal(ctx, "failed to get database instance", err)
+		}
+		go database.NewPoolMetrics(sqlDB, registry).Run(ctx, cfg.Metrics.PoolSampleInterval)
+		metricsHandler = gin.WrapH(registry)
+	}
+
+	// Every endpoint's success rate and latency are checked against the
+	// objectives, so compliance can be alerted on without an external APM
+	sloTracker, err := slo.NewTracker(slo.Config{
+		Windows:        cfg.SLO.Windows,
+		Objective:      slo.Objective{Latency: cfg.SLO.LatencyP95, SuccessRate: cfg.SLO.SuccessRate},
+		LatencyTargets: cfg.SLO.LatencyTargets,
+	})
+	if err != nil {
+		fatal(ctx, "failed to configure service level objectives", err)
+	}
+	sloHandler := handler.NewSLOHandler(sloTracker)
 
-	log.Println("Initializing middleware...")
-	authMiddleware := middleware.AuthMiddleware(jwtService)
-	corsMiddleware := middleware.CORSMiddleware(cfg.CORS.AllowedOrigins)
+	var heavyOperationMiddleware gin.HandlerFunc
+	if cfg.Server.HeavyOps.Limit > 0 {
+		heavyOperationMiddleware = middleware.ConcurrencyLimitMiddleware(cfg.Server.HeavyOps.Limit, cfg.Server.HeavyOps.RetryAfter)
+	}
+
+	// Claims are counted per client IP and partner API calls per partner,
+	// each partner's own rate limit overriding the default. A limit of 0
+	// disables throttling, so both are installed and a reload can enable them.
+	limiter := throttle.NewLimiter(cfg.Throttle.Window)
+	claimThrottleMiddleware := middleware.ThrottleMiddleware(limiter, middleware.ClientIdentity(func() int { return live.Get().Throttle.ClaimLimit }))
+	partnerThrottleMiddleware := middleware.ThrottleMiddleware(limiter, middleware.PartnerIdentity(func() int { return live.Get().Throttle.PartnerLimit }))
+
+	// Sign-ins and other public auth routes are limited per client IP, and
+	// signed-in users per email, with voucher uploads drawing from a smaller
+	// bucket of their own. Token buckets let a user burst up to the limit
+	// and then continue at the refill rate.
+	authThrottleMiddleware := middleware.ThrottleMiddleware(throttle.NewTokenBucket(cfg.Throttle.Window), middleware.ClientIdentity(func() int { return live.Get().Throttle.AuthLimit }))
+	userThrottleMiddleware := middleware.ThrottleMiddleware(throttle.NewTokenBucket(cfg.Throttle.Window), middleware.UserIdentity(func() int { return live.Get().Throttle.UserLimit }))
+	uploadThrottleMiddleware := middleware.ThrottleMiddleware(throttle.NewTokenBucket(cfg.Throttle.Window), middleware.UserIdentity(func() int { return live.Get().Throttle.UploadLimit }))
 
-	log.Println("Setting up router...")
-	router := http.SetupRouter(
+	captchaVerifier, err := captcha.NewVerifier(captcha.Config{
+		Provider: cfg.Captcha.Provider,
+		Secret:   cfg.Captcha.Secret,
+		MinScore: cfg.Captcha.MinScore,
+		Timeout:  cfg.Captcha.Timeout,
+	})
+	if err != nil {
+		fatal(ctx, "failed to configure CAPTCHA verification", err)
+	}
+	var captchaMiddleware gin.HandlerFunc
+	if captchaVerifier != nil {
+		captchaMiddleware = middleware.CaptchaMiddleware(captchaVerifier)
+	}
+
+	logger.FromContext(ctx).Info("setting up router")
+	router, err := http.SetupRouter(
 		authHandler,
 		voucherHandler,
+		userHandler,
+		sheetImportHandler,
+		importRuleHandler,
+		voucherTemplateHandler,
+		retentionHandler,
+		segmentHandler,
+		partnerHandler,
+		systemHandler,
+		streamHandler,
+		claimHandler,
+		smsHandler,
+		discountLimitHandler,
+		deadLetterHandler,
+		redemptionHandler,
+		emailTemplateHandler,
+		customerHandler,
+		reportHandler,
+		sloHandler,
 		authMiddleware,
+		partnerAuthMiddleware,
 		corsMiddleware,
+		serverTimingMiddleware,
+		compressionMiddleware,
+		heavyOperationMiddleware,
+		claimThrottleMiddleware,
+		captchaMiddleware,
+		partnerThrottleMiddleware,
+		authThrottleMiddleware,
+		userThrottleMiddleware,
+		uploadThrottleMiddleware,
+		middleware.SLOMiddleware(sloTracker),
+		metricsHandler,
+		cfg.Server.TrustedProxies,
+		cfg.Server.BasePath,
 	)
+	if err != nil {
+		fatal(ctx, "failed to set up router", err)
+	}
+
+	// Scheduled jobs run on one replica at a time, guarded by advisory locks
+	sqlDB, err := db.DB()
+	if err != nil {
+		fatal(ctx, "failed to get database instance", err)
+	}
+	jobLocker := lock.NewPostgresLocker(sqlDB)
+
+	if cfg.Cleanup.Interval > 0 {
+		go jobs.Every(ctx, cfg.Cleanup.Interval, jobLocker, cleanupJobName,
+			func(ctx context.Context) error {
+				result, err := retentionService.RunCleanup(ctx)
+				if err != nil {
+					return err
+				}
+				if result.Affected > 0 || result.AssignmentsAffected > 0 {
+					eventBroker.Publish(domainService.EventCleanupCompleted, result)
+				}
+				return nil
+			})
+	}
+
+	if cfg.SMS.DispatchInterval > 0 {
+		go jobs.Every(ctx, cfg.SMS.DispatchInterval, jobLocker, smsDispatchJobName,
+			func(ctx context.Context) error {
+				_, err := smsService.Dispatch(ctx)
+				return err
+			})
+	}
+
+	go reloadOnHangup(systemService)
 
 	serverAddr := ":" + cfg.Server.Port
-	log.Printf("Server starting on port %s (mode: %s)", cfg.Server.Port, cfg.Server.Mode)
-	log.Printf("Health check: http://localhost%s/health", serverAddr)
-	log.Printf("API endpoint: http://localhost%s/api/v1", serverAddr)
+	logger.FromContext(ctx).Info("server starting", "port", cfg.Server.Port, "mode", cfg.Server.Mode,
+		"health_check", "http://localhost"+serverAddr+cfg.Server.BasePath+"/health",
+		"api", "http://localhost"+serverAddr+cfg.Server.BasePath+"/api/v1")
+
+	server := &nethttp.Server{Addr: serverAddr, Handler: router}
+	// Event streams never go idle, so they are ended for the shutdown to complete
+	server.RegisterOnShutdown(eventBroker.Close)
+	if err := serve(ctx, server, cfg.Server.ShutdownTimeout); err != nil {
+		fatal(ctx, "failed to start server", err)
+	}
+
+	if err := sqlDB.Close(); err != nil {
+		logger.FromContext(ctx).Error("failed to close database connections", "error", err)
+	}
+	logger.FromContext(ctx).Info("server stopped")
+}
+
+// fatal logs msg with err and exits; like log.Fatal, deferred calls do not run
+func fatal(ctx context.Context, msg string, err error) {
+	logger.FromContext(ctx).Error(msg, "error", err)
+	os.Exit(1)
+}
+
+// serve runs server until ctx is cancelled, then stops accepting connections
+// and waits up to timeout for in-flight requests before closing the rest
+func serve(ctx context.Context, server *nethttp.Server, timeout time.Duration) error {
+	serveErr := make(chan error, 1)
+	go func() {
+		serveErr <- server.ListenAndServe()
+	}()
+
+	select {
+	case err := <-serveErr:
+		return err
+	case <-ctx.Done():
+	}
+
+	logger.FromContext(ctx).Info("shutting down, waiting for in-flight requests", "timeout", timeout)
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	if err := server.Shutdown(shutdownCtx); err != nil {
+		logger.FromContext(ctx).Warn("requests still running at the shutdown timeout were cut off", "timeout", timeout, "error", err)
+		_ = server.Close()
+	}
+	return nil
+}
+
+// reloadOnHangup reloads the configuration each time the process receives SIGHUP
+func reloadOnHangup(systemService domainService.SystemService) {
+	hangups := make(chan os.Signal, 1)
+	signal.Notify(hangups, syscall.SIGHUP)
+	for range hangups {
+		if _, err := systemService.ReloadConfig(context.Background(), "SIGHUP"); err != nil {
+			logger.FromContext(context.Background()).Error("configuration reload failed, keeping the current settings", "error", err)
+		}
+	}
+}
+
+// accounts are the services that manage users and the middleware that
+// authenticates their requests
+type accounts struct {
+	userService    domainService.UserService
+	authService    domainService.AuthService
+	authMiddleware gin.HandlerFunc
+}
+
+// newAccounts wires the user and auth services and the auth middleware onto
+// one user repository. The middleware caches the users behind tokens, so the
+// services change users through the same cache: a verified email or a new
+// password is seen by the next request rather than once the entry expires.
+func newAccounts(
+	userRepo domainRepository.UserRepository,
+	cfg *config.Config,
+	jwtService jwt.JWTService,
+	hasher password.Hasher,
+	emailSender mailer.Mailer,
+	emails service.EmailRenderer,
+	registrationOpen func() bool,
+) accounts {
+	if cfg.JWT.PrincipalCacheTTL > 0 {
+		userRepo = repository.NewCachedUserRepository(userRepo, cfg.JWT.PrincipalCacheTTL)
+	}
+
+	userService := service.NewUserService(userRepo, jwtService, hasher, emailSender, emails, service.EmailLinks{
+		InviteURL:              cfg.Invite.URL,
+		InviteExpiration:       cfg.Invite.Expiration,
+		VerificationURL:        cfg.Verification.URL,
+		VerificationExpiration: cfg.Verification.Expiration,
+	})
+	authService := service.NewAuthService(userRepo, jwtService, hasher, cfg.Verification.Required, service.RegistrationPolicy{
+		Open: registrationOpen,
+		Role: cfg.Registration.Role,
+	}, userService)
+
+	return accounts{
+		userService:    userService,
+		authService:    authService,
+		authMiddleware: middleware.AuthMiddleware(jwtService, userRepo),
+	}
+}
 
-	if err := router.Run(serverAddr); err != nil {
-		log.Fatal("Failed to start server:", err)
+// passwordHasherConfig maps the password settings onto the hasher config
+func passwordHasherConfig(cfg *config.Config) password.Config {
+	return password.Config{
+		Algorithm:         cfg.Password.Algorithm,
+		BcryptCost:        cfg.Password.BcryptCost,
+		Argon2Memory:      cfg.Password.Argon2Memory,
+		Argon2Iterations:  cfg.Password.Argon2Iterations,
+		Argon2Parallelism: cfg.Password.Argon2Parallelism,
 	}
 }
@@ -0,0 +1,85 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/shoelfikar/voucher-management-system/internal/config"
+	"github.com/shoelfikar/voucher-management-system/internal/delivery/http/middleware"
+	"github.com/shoelfikar/voucher-management-system/internal/domain/entity"
+	domainService "github.com/shoelfikar/voucher-management-system/internal/domain/service"
+	"github.com/shoelfikar/voucher-management-system/internal/repository"
+	"github.com/shoelfikar/voucher-management-system/pkg/jwt"
+	"github.com/shoelfikar/voucher-management-system/pkg/password"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+// capturingMailer keeps the bodies of the emails sent
+type capturingMailer struct {
+	bodies []string
+}
+
+func (m *capturingMailer) Send(to, subject, body string) error {
+	m.bodies = append(m.bodies, body)
+	return nil
+}
+
+func TestNewAccounts_ServicesShareThePrincipalCache(t *testing.T) {
+	// Arrange
+	gin.SetMode(gin.TestMode)
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(&entity.User{}))
+
+	cfg := &config.Config{}
+	cfg.JWT.PrincipalCacheTTL = time.Hour
+	cfg.Verification.Required = true
+	cfg.Verification.URL = "https://app.example.com/verify"
+	cfg.Verification.Expiration = time.Hour
+	cfg.Registration.Role = entity.UserRoleViewer
+	jwtService := jwt.NewJWTService("secret", time.Hour)
+	hasher, err := password.NewHasher(password.Config{Algorithm: password.AlgorithmBcrypt, BcryptCost: 4})
+	require.NoError(t, err)
+	mail := &capturingMailer{}
+
+	accounts := newAccounts(repository.NewUserRepository(db), cfg, jwtService, hasher, mail, nil, func() bool { return false })
+
+	ctx := context.Background()
+	_, _, err = accounts.authService.Register(ctx, "admin@example.com", "password123")
+	require.NoError(t, err)
+	require.Len(t, mail.bodies, 1)
+	link := regexp.MustCompile(`https://app\.example\.com/verify\?token=[^\s"]+`).FindString(mail.bodies[0])
+	parsed, err := url.Parse(link)
+	require.NoError(t, err)
+
+	// The failed login caches the unverified user
+	_, _, unverifiedErr := accounts.authService.Login(ctx, "admin@example.com", "password123")
+
+	// Act
+	verifyErr := accounts.userService.VerifyEmail(ctx, parsed.Query().Get("token"))
+	token, _, loginErr := accounts.authService.Login(ctx, "admin@example.com", "password123")
+
+	router := gin.New()
+	router.GET("/admin", accounts.authMiddleware, middleware.RequireRole(entity.UserRoleAdmin), func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/admin", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.ErrorIs(t, unverifiedErr, domainService.ErrEmailNotVerified)
+	assert.NoError(t, verifyErr)
+	assert.NoError(t, loginErr)
+	assert.Equal(t, http.StatusOK, w.Code)
+}
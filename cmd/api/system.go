@@ -0,0 +1,70 @@
+package main
+
+import (
+	"context"
+	"net"
+	"net/url"
+
+	"github.com/shoelfikar/voucher-management-system/internal/config"
+	"github.com/shoelfikar/voucher-management-system/internal/service"
+	"github.com/shoelfikar/voucher-management-system/pkg/scheduler"
+)
+
+// Scheduled job names, which also name their locks
+const (
+	cleanupJobName     = "expired-voucher-cleanup"
+	smsDispatchJobName = "sms-dispatch"
+)
+
+// dependencyChecks lists the optional dependencies reported by GET /system/info
+// and, under their component names, by GET /status.json. They have no health
+// endpoint of their own, so each is checked by opening a TCP connection;
+// unconfigured ones are reported as disabled. Scheduled jobs are checked by
+// how their last run on this instance ended.
+func dependencyChecks(cfg *config.Config, jobs *scheduler.Monitor) []service.DependencyCheck {
+	smtp := service.DependencyCheck{Name: "smtp", Component: "Email delivery"}
+	if cfg.SMTP.Host != "" {
+		smtp.Run = tcpCheck(net.JoinHostPort(cfg.SMTP.Host, cfg.SMTP.Port))
+	}
+
+	segmentProvider := service.DependencyCheck{Name: "segment provider", Component: "Customer segments"}
+	if u, err := url.Parse(cfg.Segments.URL); err == nil && u.Host != "" {
+		segmentProvider.Run = tcpCheck(hostPort(u))
+	}
+
+	cleanupJob := service.DependencyCheck{Name: "expired-voucher-cleanup job", Component: "Voucher expiry"}
+	if cfg.Cleanup.Interval > 0 {
+		cleanupJob.Run = jobs.Check(cleanupJobName)
+	}
+
+	smsJob := service.DependencyCheck{Name: "sms-dispatch job", Component: "SMS delivery"}
+	if cfg.SMS.DispatchInterval > 0 {
+		smsJob.Run = jobs.Check(smsDispatchJobName)
+	}
+
+	return []service.DependencyCheck{smtp, segmentProvider, cleanupJob, smsJob}
+}
+
+// tcpCheck returns a check that succeeds when address accepts a TCP connection
+func tcpCheck(address string) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		var dialer net.Dialer
+		conn, err := dialer.DialContext(ctx, "tcp", address)
+		if err != nil {
+			return err
+		}
+		return conn.Close()
+	}
+}
+
+// hostPort returns the host and port of u, using the scheme's default port when none is given
+func hostPort(u *url.URL) string {
+	if u.Port() != "" {
+		return u.Host
+	}
+	port := "80"
+	if u.Scheme == "https" {
+		port = "443"
+	}
+	return net.JoinHostPort(u.Hostname(), port)
+}
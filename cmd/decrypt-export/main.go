@@ -0,0 +1,37 @@
+// Command decrypt-export decrypts a voucher export downloaded with an
+// X-Export-Passphrase header. The passphrase is read from EXPORT_PASSPHRASE.
+//
+//	EXPORT_PASSPHRASE=... go run ./cmd/decrypt-export vouchers.csv.enc > vouchers.csv
+package main
+
+import (
+	"log"
+	"os"
+
+	"github.com/shoelfikar/voucher-management-system/pkg/exportcrypt"
+)
+
+func main() {
+	if len(os.Args) != 2 {
+		log.Fatal("usage: decrypt-export <file>")
+	}
+
+	passphrase := os.Getenv("EXPORT_PASSPHRASE")
+	if passphrase == "" {
+		log.Fatal("EXPORT_PASSPHRASE is not set")
+	}
+
+	data, err := os.ReadFile(os.Args[1])
+	if err != nil {
+		log.Fatal("Failed to read file:", err)
+	}
+
+	plaintext, err := exportcrypt.Decrypt(data, passphrase)
+	if err != nil {
+		log.Fatal("Failed to decrypt export:", err)
+	}
+
+	if _, err := os.Stdout.Write(plaintext); err != nil {
+		log.Fatal("Failed to write output:", err)
+	}
+}
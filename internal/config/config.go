@@ -1,22 +1,66 @@
 package config
 
 import (
+	"fmt"
+	"slices"
+	"strconv"
 	"strings"
 	"time"
 
+	"github.com/shoelfikar/voucher-management-system/internal/domain/entity"
+	"github.com/shoelfikar/voucher-management-system/pkg/password"
 	"github.com/spf13/viper"
 )
 
 type Config struct {
-	Server   ServerConfig
-	Database DatabaseConfig
-	JWT      JWTConfig
-	CORS     CORSConfig
+	Server       ServerConfig
+	Database     DatabaseConfig
+	JWT          JWTConfig
+	CORS         CORSConfig
+	Approval     ApprovalConfig
+	GoogleSheets GoogleSheetsConfig
+	Import       ImportConfig
+	SMTP         SMTPConfig
+	SMS          SMSConfig
+	Invite       InviteConfig
+	Claim        ClaimConfig
+	Verification EmailVerificationConfig
+	Encryption   EncryptionConfig
+	Cleanup      CleanupConfig
+	Password     PasswordConfig
+	Masking      MaskingConfig
+	Segments     SegmentProviderConfig
+	Metrics      MetricsConfig
+	Registration RegistrationConfig
+	Throttle     ThrottleConfig
+	Captcha      CaptchaConfig
+	Log          LogConfig
+	SLO          SLOConfig
 }
 
 type ServerConfig struct {
-	Port string
-	Mode string
+	Port           string
+	Mode           string
+	TrustedProxies []string
+	BasePath       string
+	ServerTiming   bool
+	Compression    CompressionConfig
+	HeavyOps       HeavyOperationConfig
+	// ShutdownTimeout is how long in-flight requests may finish after SIGINT or SIGTERM
+	ShutdownTimeout time.Duration
+}
+
+// HeavyOperationConfig limits how many imports and exports run at once;
+// a Limit of 0 disables the limit
+type HeavyOperationConfig struct {
+	Limit      int
+	RetryAfter time.Duration
+}
+
+type CompressionConfig struct {
+	Enabled      bool
+	MinSize      int
+	ContentTypes []string
 }
 
 type DatabaseConfig struct {
@@ -26,17 +70,182 @@ type DatabaseConfig struct {
 	Password string
 	DBName   string
 	SSLMode  string
+	// PrepareStmt caches prepared statements per connection so repeated
+	// queries skip parsing and planning
+	PrepareStmt bool
+	// StatementCacheSize bounds the prepared statements kept per pool (0 is unbounded)
+	StatementCacheSize int
+	// StatementCacheTTL evicts prepared statements unused for this long
+	StatementCacheTTL time.Duration
+	// SkipDefaultTransaction runs single creates, updates and deletes without
+	// wrapping them in a transaction
+	SkipDefaultTransaction bool
+	// RetryMaxAttempts is how often voucher and assignment inserts are tried
+	// when they fail with a transient error (1 disables retries)
+	RetryMaxAttempts int
+	// RetryBaseDelay and RetryMaxDelay bound the jittered wait between attempts
+	RetryBaseDelay time.Duration
+	RetryMaxDelay  time.Duration
 }
 
 type JWTConfig struct {
-	Secret     string
-	Expiration time.Duration
+	Secret            string
+	Expiration        time.Duration
+	Leeway            time.Duration
+	Issuer            string
+	Audience          string
+	PrincipalCacheTTL time.Duration
 }
 
 type CORSConfig struct {
 	AllowedOrigins []string
 }
 
+type ApprovalConfig struct {
+	DiscountThreshold float64
+	// AmountThresholds are the fixed discount amounts, per currency in minor
+	// units, above which vouchers require approval
+	AmountThresholds map[string]int64
+}
+
+type GoogleSheetsConfig struct {
+	CredentialsFile string
+	SpreadsheetID   string
+	Range           string
+}
+
+type ImportConfig struct {
+	DuplicateCacheTTL time.Duration
+}
+
+type SMTPConfig struct {
+	Host     string
+	Port     string
+	Username string
+	Password string
+	From     string
+}
+
+// SMSConfig selects the SMS provider and its credentials. Queued messages are
+// sent every DispatchInterval (0 disables sending) and given up on after
+// MaxAttempts failures.
+type SMSConfig struct {
+	Provider         string
+	From             string
+	TwilioAccountSID string
+	TwilioAuthToken  string
+	VonageAPIKey     string
+	VonageAPISecret  string
+	Timeout          time.Duration
+	DispatchInterval time.Duration
+	MaxAttempts      int
+}
+
+type InviteConfig struct {
+	URL        string
+	Expiration time.Duration
+}
+
+// ClaimConfig configures the one-time links customers claim vouchers with
+type ClaimConfig struct {
+	URL        string
+	Expiration time.Duration
+}
+
+// RegistrationConfig controls self-registration: the first account can always
+// register, later ones only while Open, and they are given Role
+type RegistrationConfig struct {
+	Open bool
+	Role string
+}
+
+type EmailVerificationConfig struct {
+	Required   bool
+	URL        string
+	Expiration time.Duration
+}
+
+type EncryptionConfig struct {
+	MetadataKey           string
+	SensitiveMetadataKeys []string
+}
+
+// SegmentProviderConfig points at the external service asked about external
+// segment membership; an empty URL allows static segments only
+type SegmentProviderConfig struct {
+	URL     string
+	Token   string
+	Timeout time.Duration
+}
+
+// MaskingConfig controls where voucher codes are partially redacted
+type MaskingConfig struct {
+	Logs            bool
+	ViewerResponses bool
+}
+
+// MetricsConfig exposes Prometheus metrics at /metrics when Enabled; the
+// database pool is sampled every PoolSampleInterval
+type MetricsConfig struct {
+	Enabled            bool
+	PoolSampleInterval time.Duration
+}
+
+// ThrottleConfig limits requests per identity in each Window: ClaimLimit per
+// client IP on the public claim endpoint and PartnerLimit per partner on the
+// partner API, unless the partner has its own rate limit. AuthLimit per
+// client IP on the public auth routes, UserLimit per user on authenticated
+// routes and UploadLimit per user on voucher uploads are token buckets
+// refilled over Window. A limit of 0 disables that throttle.
+type ThrottleConfig struct {
+	Window       time.Duration
+	ClaimLimit   int
+	PartnerLimit int
+	AuthLimit    int
+	UserLimit    int
+	UploadLimit  int
+}
+
+// CaptchaConfig selects the CAPTCHA provider required on the public claim
+// endpoint; an empty provider requires none
+type CaptchaConfig struct {
+	Provider string
+	Secret   string
+	MinScore float64
+	Timeout  time.Duration
+}
+
+// LogConfig selects how log lines are written: Format is json or text, and
+// lines below Level (debug, info, warn or error) are dropped
+type LogConfig struct {
+	Format string
+	Level  string
+}
+
+// SLOConfig sets the service level objectives reported on by
+// GET /system/slo: over each of Windows, every endpoint should answer at
+// least SuccessRate percent of requests without a 5xx and 95% of them
+// within LatencyP95. LatencyTargets overrides LatencyP95 per endpoint,
+// keyed by method and route such as "POST /api/v1/vouchers/:id/redeem".
+type SLOConfig struct {
+	Windows        []time.Duration
+	LatencyP95     time.Duration
+	SuccessRate    float64
+	LatencyTargets map[string]time.Duration
+}
+
+type CleanupConfig struct {
+	Interval time.Duration
+}
+
+type PasswordConfig struct {
+	Algorithm         string
+	BcryptCost        int
+	Argon2Memory      uint32
+	Argon2Iterations  uint32
+	Argon2Parallelism uint8
+}
+
 // LoadConfig loads configuration from environment variables
 func LoadConfig() (*Config, error) {
 	viper.SetConfigFile(".env")
@@ -57,6 +266,322 @@ func LoadConfig() (*Config, error) {
 		return nil, err
 	}
 
+	// Parse database statement settings; prepared statements are on by default
+	prepareStmt := true
+	if viper.IsSet("DB_PREPARE_STMT") {
+		prepareStmt = viper.GetBool("DB_PREPARE_STMT")
+	}
+	statementCacheSize := 500
+	if viper.IsSet("DB_STATEMENT_CACHE_SIZE") {
+		statementCacheSize = viper.GetInt("DB_STATEMENT_CACHE_SIZE")
+	}
+	statementCacheTTLStr := viper.GetString("DB_STATEMENT_CACHE_TTL")
+	if statementCacheTTLStr == "" {
+		statementCacheTTLStr = "1h"
+	}
+	statementCacheTTL, err := time.ParseDuration(statementCacheTTLStr)
+	if err != nil {
+		return nil, err
+	}
+
+	// Parse transient error retry settings
+	retryMaxAttempts := 3
+	if viper.IsSet("DB_RETRY_MAX_ATTEMPTS") {
+		retryMaxAttempts = viper.GetInt("DB_RETRY_MAX_ATTEMPTS")
+	}
+	retryBaseDelayStr := viper.GetString("DB_RETRY_BASE_DELAY")
+	if retryBaseDelayStr == "" {
+		retryBaseDelayStr = "50ms"
+	}
+	retryBaseDelay, err := time.ParseDuration(retryBaseDelayStr)
+	if err != nil {
+		return nil, err
+	}
+	retryMaxDelayStr := viper.GetString("DB_RETRY_MAX_DELAY")
+	if retryMaxDelayStr == "" {
+		retryMaxDelayStr = "1s"
+	}
+	retryMaxDelay, err := time.ParseDuration(retryMaxDelayStr)
+	if err != nil {
+		return nil, err
+	}
+
+	// Parse JWT clock skew leeway
+	jwtLeewayStr := viper.GetString("JWT_LEEWAY")
+	if jwtLeewayStr == "" {
+		jwtLeewayStr = "30s"
+	}
+	jwtLeeway, err := time.ParseDuration(jwtLeewayStr)
+	if err != nil {
+		return nil, err
+	}
+
+	// Parse principal cache TTL (0 disables the cache)
+	principalCacheTTLStr := viper.GetString("PRINCIPAL_CACHE_TTL")
+	if principalCacheTTLStr == "" {
+		principalCacheTTLStr = "1m"
+	}
+	principalCacheTTL, err := time.ParseDuration(principalCacheTTLStr)
+	if err != nil {
+		return nil, err
+	}
+
+	// Parse duplicate-code cache TTL (0 disables the cache)
+	duplicateCacheTTLStr := viper.GetString("DUPLICATE_CODE_CACHE_TTL")
+	if duplicateCacheTTLStr == "" {
+		duplicateCacheTTLStr = "30s"
+	}
+	duplicateCacheTTL, err := time.ParseDuration(duplicateCacheTTLStr)
+	if err != nil {
+		return nil, err
+	}
+
+	// Parse expired voucher cleanup interval (0 disables the job)
+	cleanupIntervalStr := viper.GetString("CLEANUP_INTERVAL")
+	if cleanupIntervalStr == "" {
+		cleanupIntervalStr = "1h"
+	}
+	cleanupInterval, err := time.ParseDuration(cleanupIntervalStr)
+	if err != nil {
+		return nil, err
+	}
+
+	// Parse metrics settings
+	poolSampleIntervalStr := viper.GetString("METRICS_POOL_SAMPLE_INTERVAL")
+	if poolSampleIntervalStr == "" {
+		poolSampleIntervalStr = "15s"
+	}
+	poolSampleInterval, err := time.ParseDuration(poolSampleIntervalStr)
+	if err != nil {
+		return nil, err
+	}
+
+	// Parse the heavy operation concurrency limit (0 disables it)
+	heavyOpsLimit := 4
+	if viper.IsSet("HEAVY_OPERATION_CONCURRENCY") {
+		heavyOpsLimit = viper.GetInt("HEAVY_OPERATION_CONCURRENCY")
+	}
+	heavyOpsRetryAfterStr := viper.GetString("HEAVY_OPERATION_RETRY_AFTER")
+	if heavyOpsRetryAfterStr == "" {
+		heavyOpsRetryAfterStr = "10s"
+	}
+	heavyOpsRetryAfter, err := time.ParseDuration(heavyOpsRetryAfterStr)
+	if err != nil {
+		return nil, err
+	}
+
+	shutdownTimeoutStr := viper.GetString("SHUTDOWN_TIMEOUT")
+	if shutdownTimeoutStr == "" {
+		shutdownTimeoutStr = "15s"
+	}
+	shutdownTimeout, err := time.ParseDuration(shutdownTimeoutStr)
+	if err != nil {
+		return nil, err
+	}
+
+	// Parse per-identity request throttles (a limit of 0 disables one)
+	throttleWindowStr := viper.GetString("THROTTLE_WINDOW")
+	if throttleWindowStr == "" {
+		throttleWindowStr = "1m"
+	}
+	throttleWindow, err := time.ParseDuration(throttleWindowStr)
+	if err != nil {
+		return nil, err
+	}
+	throttleClaimLimit := 10
+	if viper.IsSet("THROTTLE_CLAIM_LIMIT") {
+		throttleClaimLimit = viper.GetInt("THROTTLE_CLAIM_LIMIT")
+	}
+	throttlePartnerLimit := 600
+	if viper.IsSet("THROTTLE_PARTNER_LIMIT") {
+		throttlePartnerLimit = viper.GetInt("THROTTLE_PARTNER_LIMIT")
+	}
+	throttleAuthLimit := 10
+	if viper.IsSet("THROTTLE_AUTH_LIMIT") {
+		throttleAuthLimit = viper.GetInt("THROTTLE_AUTH_LIMIT")
+	}
+	throttleUserLimit := 600
+	if viper.IsSet("THROTTLE_USER_LIMIT") {
+		throttleUserLimit = viper.GetInt("THROTTLE_USER_LIMIT")
+	}
+	throttleUploadLimit := 10
+	if viper.IsSet("THROTTLE_UPLOAD_LIMIT") {
+		throttleUploadLimit = viper.GetInt("THROTTLE_UPLOAD_LIMIT")
+	}
+
+	// Parse service level objectives
+	sloWindowsStr := viper.GetString("SLO_WINDOWS")
+	if sloWindowsStr == "" {
+		sloWindowsStr = "5m,1h"
+	}
+	var sloWindows []time.Duration
+	for _, windowStr := range strings.Split(sloWindowsStr, ",") {
+		window, err := time.ParseDuration(strings.TrimSpace(windowStr))
+		if err != nil {
+			return nil, err
+		}
+		sloWindows = append(sloWindows, window)
+	}
+	sloLatencyStr := viper.GetString("SLO_LATENCY_P95")
+	if sloLatencyStr == "" {
+		sloLatencyStr = "500ms"
+	}
+	sloLatency, err := time.ParseDuration(sloLatencyStr)
+	if err != nil {
+		return nil, err
+	}
+	sloSuccessRate := 99.9
+	if viper.IsSet("SLO_SUCCESS_RATE") {
+		sloSuccessRate = viper.GetFloat64("SLO_SUCCESS_RATE")
+	}
+	approvalAmountThresholds := map[string]int64{}
+	if thresholdsStr := viper.GetString("APPROVAL_AMOUNT_THRESHOLDS"); thresholdsStr != "" {
+		for _, entry := range strings.Split(thresholdsStr, ",") {
+			currency, amountStr, ok := strings.Cut(entry, "=")
+			currency = strings.ToUpper(strings.TrimSpace(currency))
+			if !ok || len(currency) != 3 {
+				return nil, fmt.Errorf("invalid APPROVAL_AMOUNT_THRESHOLDS entry %q, expected \"CURRENCY=amount\"", entry)
+			}
+			amount, err := strconv.ParseFloat(strings.TrimSpace(amountStr), 64)
+			if err != nil || amount < 0 {
+				return nil, fmt.Errorf("invalid APPROVAL_AMOUNT_THRESHOLDS amount %q for %s", amountStr, currency)
+			}
+			minor, err := entity.ToMinorUnits(amount, currency)
+			if err != nil {
+				return nil, fmt.Errorf("invalid APPROVAL_AMOUNT_THRESHOLDS amount for %s: %w", currency, err)
+			}
+			approvalAmountThresholds[currency] = minor
+		}
+	}
+
+	sloLatencyTargets := map[string]time.Duration{}
+	if targetsStr := viper.GetString("SLO_LATENCY_TARGETS"); targetsStr != "" {
+		for _, entry := range strings.Split(targetsStr, ",") {
+			endpoint, targetStr, ok := strings.Cut(entry, "=")
+			if !ok {
+				return nil, fmt.Errorf("invalid SLO_LATENCY_TARGETS entry %q, expected \"METHOD /route=duration\"", entry)
+			}
+			target, err := time.ParseDuration(strings.TrimSpace(targetStr))
+			if err != nil {
+				return nil, err
+			}
+			method, route, _ := strings.Cut(strings.TrimSpace(endpoint), " ")
+			sloLatencyTargets[strings.ToUpper(method)+" "+strings.TrimSpace(route)] = target
+		}
+	}
+
+	// Parse CAPTCHA settings
+	captchaMinScore := 0.5
+	if viper.IsSet("CAPTCHA_MIN_SCORE") {
+		captchaMinScore = viper.GetFloat64("CAPTCHA_MIN_SCORE")
+	}
+	captchaTimeoutStr := viper.GetString("CAPTCHA_TIMEOUT")
+	if captchaTimeoutStr == "" {
+		captchaTimeoutStr = "5s"
+	}
+	captchaTimeout, err := time.ParseDuration(captchaTimeoutStr)
+	if err != nil {
+		return nil, err
+	}
+
+	// Parse external segment provider timeout
+	segmentTimeoutStr := viper.GetString("SEGMENT_PROVIDER_TIMEOUT")
+	if segmentTimeoutStr == "" {
+		segmentTimeoutStr = "5s"
+	}
+	segmentTimeout, err := time.ParseDuration(segmentTimeoutStr)
+	if err != nil {
+		return nil, err
+	}
+
+	// Parse SMS delivery settings
+	smsProvider := viper.GetString("SMS_PROVIDER")
+	if smsProvider == "" {
+		smsProvider = "log"
+	}
+	smsTimeoutStr := viper.GetString("SMS_TIMEOUT")
+	if smsTimeoutStr == "" {
+		smsTimeoutStr = "10s"
+	}
+	smsTimeout, err := time.ParseDuration(smsTimeoutStr)
+	if err != nil {
+		return nil, err
+	}
+	smsDispatchIntervalStr := viper.GetString("SMS_DISPATCH_INTERVAL")
+	if smsDispatchIntervalStr == "" {
+		smsDispatchIntervalStr = "30s"
+	}
+	smsDispatchInterval, err := time.ParseDuration(smsDispatchIntervalStr)
+	if err != nil {
+		return nil, err
+	}
+	smsMaxAttempts := viper.GetInt("SMS_MAX_ATTEMPTS")
+	if smsMaxAttempts <= 0 {
+		smsMaxAttempts = 5
+	}
+
+	// Parse invitation settings
+	inviteURL := viper.GetString("INVITE_URL")
+	if inviteURL == "" {
+		inviteURL = "http://localhost:5173/accept-invite"
+	}
+	inviteExpStr := viper.GetString("INVITE_EXPIRATION")
+	if inviteExpStr == "" {
+		inviteExpStr = "72h"
+	}
+	inviteExpiration, err := time.ParseDuration(inviteExpStr)
+	if err != nil {
+		return nil, err
+	}
+
+	// Parse voucher claim link settings
+	claimURL := viper.GetString("CLAIM_URL")
+	if claimURL == "" {
+		claimURL = "http://localhost:5173/claim"
+	}
+	claimExpStr := viper.GetString("CLAIM_EXPIRATION")
+	if claimExpStr == "" {
+		claimExpStr = "720h"
+	}
+	claimExpiration, err := time.ParseDuration(claimExpStr)
+	if err != nil {
+		return nil, err
+	}
+
+	// Parse email verification settings
+	verificationURL := viper.GetString("EMAIL_VERIFICATION_URL")
+	if verificationURL == "" {
+		verificationURL = "http://localhost:8080/api/v1/auth/verify-email"
+	}
+	verificationExpStr := viper.GetString("EMAIL_VERIFICATION_EXPIRATION")
+	if verificationExpStr == "" {
+		verificationExpStr = "24h"
+	}
+	verificationExpiration, err := time.ParseDuration(verificationExpStr)
+	if err != nil {
+		return nil, err
+	}
+
+	// Parse log settings
+	logFormat := strings.ToLower(viper.GetString("LOG_FORMAT"))
+	if logFormat == "" {
+		logFormat = "json"
+	}
+	logLevel := strings.ToLower(viper.GetString("LOG_LEVEL"))
+	if logLevel == "" {
+		logLevel = "info"
+	}
+
+	// Self-registered accounts are viewers unless configured otherwise. Anyone
+	// may register while registration is open, so they can never be admins.
+	registrationRole := strings.ToLower(viper.GetString("REGISTRATION_ROLE"))
+	if registrationRole == "" {
+		registrationRole = entity.UserRoleViewer
+	}
+	if registrationRole == entity.UserRoleAdmin || !slices.Contains(entity.UserRoles, registrationRole) {
+		return nil, fmt.Errorf("invalid REGISTRATION_ROLE %q, expected marketing or viewer", registrationRole)
+	}
+
 	// Parse allowed origins
 	allowedOriginsStr := viper.GetString("ALLOWED_ORIGINS")
 	if allowedOriginsStr == "" {
@@ -64,26 +589,214 @@ func LoadConfig() (*Config, error) {
 	}
 	allowedOrigins := strings.Split(allowedOriginsStr, ",")
 
+	// Parse Google Sheets range
+	sheetsRange := viper.GetString("GOOGLE_SHEETS_RANGE")
+	if sheetsRange == "" {
+		sheetsRange = "Sheet1"
+	}
+
+	// Parse response compression settings
+	compressionMinSize := 1024
+	if viper.IsSet("COMPRESSION_MIN_SIZE") {
+		compressionMinSize = viper.GetInt("COMPRESSION_MIN_SIZE")
+	}
+	compressionTypesStr := viper.GetString("COMPRESSION_CONTENT_TYPES")
+	if compressionTypesStr == "" {
+		compressionTypesStr = "application/json,text/csv,text/plain"
+	}
+	compressionTypes := strings.Split(compressionTypesStr, ",")
+
+	// Parse trusted proxies (empty means no proxy is trusted)
+	var trustedProxies []string
+	if trustedProxiesStr := viper.GetString("TRUSTED_PROXIES"); trustedProxiesStr != "" {
+		for _, proxy := range strings.Split(trustedProxiesStr, ",") {
+			trustedProxies = append(trustedProxies, strings.TrimSpace(proxy))
+		}
+	}
+
+	// Parse metadata keys whose values are encrypted at rest
+	var sensitiveMetadataKeys []string
+	if sensitiveKeysStr := viper.GetString("SENSITIVE_METADATA_KEYS"); sensitiveKeysStr != "" {
+		for _, key := range strings.Split(sensitiveKeysStr, ",") {
+			if key = strings.TrimSpace(key); key != "" {
+				sensitiveMetadataKeys = append(sensitiveMetadataKeys, key)
+			}
+		}
+	}
+
+	// Parse password hashing settings; unset values keep the hasher defaults
+	passwordDefaults := password.DefaultConfig()
+	passwordConfig := PasswordConfig{
+		Algorithm:         strings.ToLower(viper.GetString("PASSWORD_HASH_ALGORITHM")),
+		BcryptCost:        passwordDefaults.BcryptCost,
+		Argon2Memory:      passwordDefaults.Argon2Memory,
+		Argon2Iterations:  passwordDefaults.Argon2Iterations,
+		Argon2Parallelism: passwordDefaults.Argon2Parallelism,
+	}
+	if passwordConfig.Algorithm == "" {
+		passwordConfig.Algorithm = passwordDefaults.Algorithm
+	}
+	if viper.IsSet("PASSWORD_BCRYPT_COST") {
+		passwordConfig.BcryptCost = viper.GetInt("PASSWORD_BCRYPT_COST")
+	}
+	if viper.IsSet("PASSWORD_ARGON2_MEMORY") {
+		passwordConfig.Argon2Memory = viper.GetUint32("PASSWORD_ARGON2_MEMORY")
+	}
+	if viper.IsSet("PASSWORD_ARGON2_ITERATIONS") {
+		passwordConfig.Argon2Iterations = viper.GetUint32("PASSWORD_ARGON2_ITERATIONS")
+	}
+	if viper.IsSet("PASSWORD_ARGON2_PARALLELISM") {
+		passwordConfig.Argon2Parallelism = uint8(viper.GetUint("PASSWORD_ARGON2_PARALLELISM"))
+	}
+
+	// Voucher codes are masked in logs unless explicitly disabled
+	maskLogs := true
+	if viper.IsSet("MASK_CODES_IN_LOGS") {
+		maskLogs = viper.GetBool("MASK_CODES_IN_LOGS")
+	}
+
+	// Parse API base path, normalized to "/prefix" without a trailing slash
+	basePath := strings.Trim(strings.TrimSpace(viper.GetString("BASE_PATH")), "/")
+	if basePath != "" {
+		basePath = "/" + basePath
+	}
+
 	config := &Config{
 		Server: ServerConfig{
-			Port: viper.GetString("PORT"),
-			Mode: viper.GetString("GIN_MODE"),
+			Port:           viper.GetString("PORT"),
+			Mode:           viper.GetString("GIN_MODE"),
+			TrustedProxies: trustedProxies,
+			BasePath:       basePath,
+			ServerTiming:   viper.GetBool("SERVER_TIMING_ENABLED"),
+			Compression: CompressionConfig{
+				Enabled:      viper.GetBool("COMPRESSION_ENABLED"),
+				MinSize:      compressionMinSize,
+				ContentTypes: compressionTypes,
+			},
+			HeavyOps: HeavyOperationConfig{
+				Limit:      heavyOpsLimit,
+				RetryAfter: heavyOpsRetryAfter,
+			},
+			ShutdownTimeout: shutdownTimeout,
 		},
 		Database: DatabaseConfig{
-			Host:     viper.GetString("DB_HOST"),
-			Port:     viper.GetString("DB_PORT"),
-			User:     viper.GetString("DB_USER"),
-			Password: viper.GetString("DB_PASSWORD"),
-			DBName:   viper.GetString("DB_NAME"),
-			SSLMode:  viper.GetString("DB_SSLMODE"),
+			Host:                   viper.GetString("DB_HOST"),
+			Port:                   viper.GetString("DB_PORT"),
+			User:                   viper.GetString("DB_USER"),
+			Password:               viper.GetString("DB_PASSWORD"),
+			DBName:                 viper.GetString("DB_NAME"),
+			SSLMode:                viper.GetString("DB_SSLMODE"),
+			PrepareStmt:            prepareStmt,
+			StatementCacheSize:     statementCacheSize,
+			StatementCacheTTL:      statementCacheTTL,
+			SkipDefaultTransaction: viper.GetBool("DB_SKIP_DEFAULT_TRANSACTION"),
+			RetryMaxAttempts:       retryMaxAttempts,
+			RetryBaseDelay:         retryBaseDelay,
+			RetryMaxDelay:          retryMaxDelay,
 		},
 		JWT: JWTConfig{
-			Secret:     viper.GetString("JWT_SECRET"),
-			Expiration: jwtExpiration,
+			Secret:            viper.GetString("JWT_SECRET"),
+			Expiration:        jwtExpiration,
+			Leeway:            jwtLeeway,
+			Issuer:            viper.GetString("JWT_ISSUER"),
+			Audience:          viper.GetString("JWT_AUDIENCE"),
+			PrincipalCacheTTL: principalCacheTTL,
 		},
 		CORS: CORSConfig{
 			AllowedOrigins: allowedOrigins,
 		},
+		Approval: ApprovalConfig{
+			DiscountThreshold: viper.GetFloat64("APPROVAL_DISCOUNT_THRESHOLD"),
+			AmountThresholds:  approvalAmountThresholds,
+		},
+		GoogleSheets: GoogleSheetsConfig{
+			CredentialsFile: viper.GetString("GOOGLE_SHEETS_CREDENTIALS_FILE"),
+			SpreadsheetID:   viper.GetString("GOOGLE_SHEETS_SPREADSHEET_ID"),
+			Range:           sheetsRange,
+		},
+		Import: ImportConfig{
+			DuplicateCacheTTL: duplicateCacheTTL,
+		},
+		SMTP: SMTPConfig{
+			Host:     viper.GetString("SMTP_HOST"),
+			Port:     viper.GetString("SMTP_PORT"),
+			Username: viper.GetString("SMTP_USERNAME"),
+			Password: viper.GetString("SMTP_PASSWORD"),
+			From:     viper.GetString("SMTP_FROM"),
+		},
+		SMS: SMSConfig{
+			Provider:         smsProvider,
+			From:             viper.GetString("SMS_FROM"),
+			TwilioAccountSID: viper.GetString("TWILIO_ACCOUNT_SID"),
+			TwilioAuthToken:  viper.GetString("TWILIO_AUTH_TOKEN"),
+			VonageAPIKey:     viper.GetString("VONAGE_API_KEY"),
+			VonageAPISecret:  viper.GetString("VONAGE_API_SECRET"),
+			Timeout:          smsTimeout,
+			DispatchInterval: smsDispatchInterval,
+			MaxAttempts:      smsMaxAttempts,
+		},
+		Invite: InviteConfig{
+			URL:        inviteURL,
+			Expiration: inviteExpiration,
+		},
+		Claim: ClaimConfig{
+			URL:        claimURL,
+			Expiration: claimExpiration,
+		},
+		Verification: EmailVerificationConfig{
+			Required:   viper.GetBool("REQUIRE_EMAIL_VERIFICATION"),
+			URL:        verificationURL,
+			Expiration: verificationExpiration,
+		},
+		Encryption: EncryptionConfig{
+			MetadataKey:           viper.GetString("METADATA_ENCRYPTION_KEY"),
+			SensitiveMetadataKeys: sensitiveMetadataKeys,
+		},
+		Cleanup: CleanupConfig{
+			Interval: cleanupInterval,
+		},
+		Password: passwordConfig,
+		Masking: MaskingConfig{
+			Logs:            maskLogs,
+			ViewerResponses: viper.GetBool("MASK_CODES_FOR_VIEWERS"),
+		},
+		Segments: SegmentProviderConfig{
+			URL:     viper.GetString("SEGMENT_PROVIDER_URL"),
+			Token:   viper.GetString("SEGMENT_PROVIDER_TOKEN"),
+			Timeout: segmentTimeout,
+		},
+		Metrics: MetricsConfig{
+			Enabled:            viper.GetBool("METRICS_ENABLED"),
+			PoolSampleInterval: poolSampleInterval,
+		},
+		Registration: RegistrationConfig{
+			Open: viper.GetBool("REGISTRATION_OPEN"),
+			Role: registrationRole,
+		},
+		Throttle: ThrottleConfig{
+			Window:       throttleWindow,
+			ClaimLimit:   throttleClaimLimit,
+			PartnerLimit: throttlePartnerLimit,
+			AuthLimit:    throttleAuthLimit,
+			UserLimit:    throttleUserLimit,
+			UploadLimit:  throttleUploadLimit,
+		},
+		Captcha: CaptchaConfig{
+			Provider: strings.ToLower(viper.GetString("CAPTCHA_PROVIDER")),
+			Secret:   viper.GetString("CAPTCHA_SECRET"),
+			MinScore: captchaMinScore,
+			Timeout:  captchaTimeout,
+		},
+		Log: LogConfig{
+			Format: logFormat,
+			Level:  logLevel,
+		},
+		SLO: SLOConfig{
+			Windows:        sloWindows,
+			LatencyP95:     sloLatency,
+			SuccessRate:    sloSuccessRate,
+			LatencyTargets: sloLatencyTargets,
+		},
 	}
 
 	return config, nil
@@ -0,0 +1,40 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/shoelfikar/voucher-management-system/internal/domain/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadConfig_RegistrationRole(t *testing.T) {
+	tests := []struct {
+		name        string
+		role        string
+		expected    string
+		expectedErr string
+	}{
+		{name: "default", expected: entity.UserRoleViewer},
+		{name: "marketing", role: "Marketing", expected: entity.UserRoleMarketing},
+		{name: "admin", role: "admin", expectedErr: `invalid REGISTRATION_ROLE "admin", expected marketing or viewer`},
+		{name: "unknown role", role: "owner", expectedErr: `invalid REGISTRATION_ROLE "owner", expected marketing or viewer`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			t.Setenv("REGISTRATION_ROLE", tt.role)
+
+			// Act
+			cfg, err := LoadConfig()
+
+			// Assert
+			if tt.expectedErr != "" {
+				assert.EqualError(t, err, tt.expectedErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, cfg.Registration.Role)
+		})
+	}
+}
@@ -0,0 +1,83 @@
+package config
+
+import (
+	"slices"
+	"sync"
+	"sync/atomic"
+)
+
+// Live holds the configuration snapshot consulted by middleware and services
+// for the settings that can change without a restart: the CORS origins, the
+// throttle limits and whether registration is open. Every other setting
+// keeps the value it had at startup.
+//
+// Values set in the process environment take precedence over the .env file,
+// so only settings read from .env can be changed by a reload.
+type Live struct {
+	current atomic.Pointer[Config]
+	load    func() (*Config, error)
+	mu      sync.Mutex
+}
+
+// NewLive creates a live configuration starting from cfg; load reads the
+// configuration again on Reload, normally LoadConfig
+func NewLive(cfg *Config, load func() (*Config, error)) *Live {
+	l := &Live{load: load}
+	l.current.Store(cfg)
+	return l
+}
+
+// Get returns the current snapshot; callers must not modify it
+func (l *Live) Get() *Config {
+	return l.current.Load()
+}
+
+// Reload reads the configuration again and swaps in a snapshot with the
+// reloadable settings updated, returning the environment names of those that
+// changed. When the configuration cannot be read the current snapshot stays.
+func (l *Live) Reload() ([]string, error) {
+	fresh, err := l.load()
+	if err != nil {
+		return nil, err
+	}
+
+	// Concurrent reloads must not both start from the same old snapshot
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	old := l.current.Load()
+	next := *old
+	next.CORS.AllowedOrigins = fresh.CORS.AllowedOrigins
+	next.Throttle.ClaimLimit = fresh.Throttle.ClaimLimit
+	next.Throttle.PartnerLimit = fresh.Throttle.PartnerLimit
+	next.Throttle.AuthLimit = fresh.Throttle.AuthLimit
+	next.Throttle.UserLimit = fresh.Throttle.UserLimit
+	next.Throttle.UploadLimit = fresh.Throttle.UploadLimit
+	next.Registration.Open = fresh.Registration.Open
+
+	var changed []string
+	if !slices.Equal(old.CORS.AllowedOrigins, next.CORS.AllowedOrigins) {
+		changed = append(changed, "ALLOWED_ORIGINS")
+	}
+	if old.Throttle.ClaimLimit != next.Throttle.ClaimLimit {
+		changed = append(changed, "THROTTLE_CLAIM_LIMIT")
+	}
+	if old.Throttle.PartnerLimit != next.Throttle.PartnerLimit {
+		changed = append(changed, "THROTTLE_PARTNER_LIMIT")
+	}
+	if old.Throttle.AuthLimit != next.Throttle.AuthLimit {
+		changed = append(changed, "THROTTLE_AUTH_LIMIT")
+	}
+	if old.Throttle.UserLimit != next.Throttle.UserLimit {
+		changed = append(changed, "THROTTLE_USER_LIMIT")
+	}
+	if old.Throttle.UploadLimit != next.Throttle.UploadLimit {
+		changed = append(changed, "THROTTLE_UPLOAD_LIMIT")
+	}
+	if old.Registration.Open != next.Registration.Open {
+		changed = append(changed, "REGISTRATION_OPEN")
+	}
+
+	l.current.Store(&next)
+	return changed, nil
+}
@@ -0,0 +1,52 @@
+package config
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLive_Reload(t *testing.T) {
+	// Arrange
+	initial := &Config{
+		Server:   ServerConfig{Port: "8080"},
+		CORS:     CORSConfig{AllowedOrigins: []string{"https://admin.example.com"}},
+		Throttle: ThrottleConfig{ClaimLimit: 10, PartnerLimit: 600, UploadLimit: 10},
+	}
+	fresh := &Config{
+		Server:       ServerConfig{Port: "9090"},
+		CORS:         CORSConfig{AllowedOrigins: []string{"https://admin.example.com", "https://staff.example.com"}},
+		Throttle:     ThrottleConfig{ClaimLimit: 5, PartnerLimit: 600, UploadLimit: 2},
+		Registration: RegistrationConfig{Open: true},
+	}
+	live := NewLive(initial, func() (*Config, error) { return fresh, nil })
+
+	// Act
+	changed, err := live.Reload()
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"ALLOWED_ORIGINS", "THROTTLE_CLAIM_LIMIT", "THROTTLE_UPLOAD_LIMIT", "REGISTRATION_OPEN"}, changed)
+	current := live.Get()
+	assert.Equal(t, fresh.CORS.AllowedOrigins, current.CORS.AllowedOrigins)
+	assert.Equal(t, 5, current.Throttle.ClaimLimit)
+	assert.Equal(t, 2, current.Throttle.UploadLimit)
+	assert.True(t, current.Registration.Open)
+	assert.Equal(t, "8080", current.Server.Port, "settings that need a restart keep their startup value")
+	assert.Equal(t, 10, initial.Throttle.ClaimLimit, "the previous snapshot is left untouched")
+}
+
+func TestLive_Reload_KeepsSnapshotOnError(t *testing.T) {
+	// Arrange
+	initial := &Config{Throttle: ThrottleConfig{ClaimLimit: 10}}
+	live := NewLive(initial, func() (*Config, error) { return nil, errors.New("invalid duration") })
+
+	// Act
+	changed, err := live.Reload()
+
+	// Assert
+	assert.Error(t, err)
+	assert.Nil(t, changed)
+	assert.Same(t, initial, live.Get())
+}
@@ -0,0 +1,203 @@
+package config
+
+import (
+	"net/url"
+	"strings"
+	"time"
+)
+
+// redacted replaces secret values in the config summary
+const redacted = "[REDACTED]"
+
+// Summary returns the effective configuration for support triage. Secrets
+// are replaced by a marker showing only whether they are set, and URLs lose
+// any credentials they carry.
+func (c *Config) Summary() map[string]interface{} {
+	return map[string]interface{}{
+		"server": map[string]interface{}{
+			"port":                      c.Server.Port,
+			"mode":                      c.Server.Mode,
+			"trusted_proxies":           c.Server.TrustedProxies,
+			"base_path":                 c.Server.BasePath,
+			"server_timing":             c.Server.ServerTiming,
+			"compression_enabled":       c.Server.Compression.Enabled,
+			"compression_min_size":      c.Server.Compression.MinSize,
+			"compression_content_types": c.Server.Compression.ContentTypes,
+			"heavy_operation_limit":     c.Server.HeavyOps.Limit,
+			"heavy_operation_retry":     c.Server.HeavyOps.RetryAfter.String(),
+			"shutdown_timeout":          c.Server.ShutdownTimeout.String(),
+		},
+		"database": map[string]interface{}{
+			"host":                     c.Database.Host,
+			"port":                     c.Database.Port,
+			"user":                     c.Database.User,
+			"password":                 secret(c.Database.Password),
+			"name":                     c.Database.DBName,
+			"ssl_mode":                 c.Database.SSLMode,
+			"prepare_stmt":             c.Database.PrepareStmt,
+			"statement_cache_size":     c.Database.StatementCacheSize,
+			"statement_cache_ttl":      c.Database.StatementCacheTTL.String(),
+			"skip_default_transaction": c.Database.SkipDefaultTransaction,
+			"retry_max_attempts":       c.Database.RetryMaxAttempts,
+			"retry_base_delay":         c.Database.RetryBaseDelay.String(),
+			"retry_max_delay":          c.Database.RetryMaxDelay.String(),
+		},
+		"jwt": map[string]interface{}{
+			"secret":              secret(c.JWT.Secret),
+			"expiration":          c.JWT.Expiration.String(),
+			"leeway":              c.JWT.Leeway.String(),
+			"issuer":              c.JWT.Issuer,
+			"audience":            c.JWT.Audience,
+			"principal_cache_ttl": c.JWT.PrincipalCacheTTL.String(),
+		},
+		"cors": map[string]interface{}{
+			"allowed_origins": c.CORS.AllowedOrigins,
+		},
+		"approval": map[string]interface{}{
+			"discount_threshold": c.Approval.DiscountThreshold,
+			"amount_thresholds":  c.Approval.AmountThresholds,
+		},
+		"google_sheets": map[string]interface{}{
+			"enabled":        c.GoogleSheets.CredentialsFile != "",
+			"spreadsheet_id": c.GoogleSheets.SpreadsheetID,
+			"range":          c.GoogleSheets.Range,
+		},
+		"import": map[string]interface{}{
+			"duplicate_cache_ttl": c.Import.DuplicateCacheTTL.String(),
+		},
+		"smtp": map[string]interface{}{
+			"enabled":  c.SMTP.Host != "",
+			"host":     c.SMTP.Host,
+			"port":     c.SMTP.Port,
+			"username": c.SMTP.Username,
+			"password": secret(c.SMTP.Password),
+			"from":     c.SMTP.From,
+		},
+		"sms": map[string]interface{}{
+			"provider":           c.SMS.Provider,
+			"from":               c.SMS.From,
+			"twilio_account_sid": c.SMS.TwilioAccountSID,
+			"twilio_auth_token":  secret(c.SMS.TwilioAuthToken),
+			"vonage_api_key":     c.SMS.VonageAPIKey,
+			"vonage_api_secret":  secret(c.SMS.VonageAPISecret),
+			"timeout":            c.SMS.Timeout.String(),
+			"dispatch_interval":  c.SMS.DispatchInterval.String(),
+			"max_attempts":       c.SMS.MaxAttempts,
+		},
+		"invite": map[string]interface{}{
+			"url":        redactURL(c.Invite.URL),
+			"expiration": c.Invite.Expiration.String(),
+		},
+		"claim": map[string]interface{}{
+			"url":        redactURL(c.Claim.URL),
+			"expiration": c.Claim.Expiration.String(),
+		},
+		"verification": map[string]interface{}{
+			"required":   c.Verification.Required,
+			"url":        redactURL(c.Verification.URL),
+			"expiration": c.Verification.Expiration.String(),
+		},
+		"encryption": map[string]interface{}{
+			"metadata_key":            secret(c.Encryption.MetadataKey),
+			"sensitive_metadata_keys": c.Encryption.SensitiveMetadataKeys,
+		},
+		"cleanup": map[string]interface{}{
+			"interval": c.Cleanup.Interval.String(),
+		},
+		"password": map[string]interface{}{
+			"algorithm":          c.Password.Algorithm,
+			"bcrypt_cost":        c.Password.BcryptCost,
+			"argon2_memory":      c.Password.Argon2Memory,
+			"argon2_iterations":  c.Password.Argon2Iterations,
+			"argon2_parallelism": c.Password.Argon2Parallelism,
+		},
+		"masking": map[string]interface{}{
+			"logs":             c.Masking.Logs,
+			"viewer_responses": c.Masking.ViewerResponses,
+		},
+		"segments": map[string]interface{}{
+			"provider_url": redactURL(c.Segments.URL),
+			"token":        secret(c.Segments.Token),
+			"timeout":      c.Segments.Timeout.String(),
+		},
+		"metrics": map[string]interface{}{
+			"enabled":              c.Metrics.Enabled,
+			"pool_sample_interval": c.Metrics.PoolSampleInterval.String(),
+		},
+		"registration": map[string]interface{}{
+			"open": c.Registration.Open,
+			"role": c.Registration.Role,
+		},
+		"throttle": map[string]interface{}{
+			"window":        c.Throttle.Window.String(),
+			"claim_limit":   c.Throttle.ClaimLimit,
+			"partner_limit": c.Throttle.PartnerLimit,
+			"auth_limit":    c.Throttle.AuthLimit,
+			"user_limit":    c.Throttle.UserLimit,
+			"upload_limit":  c.Throttle.UploadLimit,
+		},
+		"captcha": map[string]interface{}{
+			"provider":  c.Captcha.Provider,
+			"secret":    secret(c.Captcha.Secret),
+			"min_score": c.Captcha.MinScore,
+			"timeout":   c.Captcha.Timeout.String(),
+		},
+		"log": map[string]interface{}{
+			"format": c.Log.Format,
+			"level":  c.Log.Level,
+		},
+		"slo": map[string]interface{}{
+			"windows":         durations(c.SLO.Windows),
+			"latency_p95":     c.SLO.LatencyP95.String(),
+			"success_rate":    c.SLO.SuccessRate,
+			"latency_targets": durationMap(c.SLO.LatencyTargets),
+		},
+	}
+}
+
+// durations formats each duration as a string
+func durations(values []time.Duration) []string {
+	formatted := make([]string, len(values))
+	for i, value := range values {
+		formatted[i] = value.String()
+	}
+	return formatted
+}
+
+// durationMap formats each duration in values as a string
+func durationMap(values map[string]time.Duration) map[string]string {
+	formatted := make(map[string]string, len(values))
+	for key, value := range values {
+		formatted[key] = value.String()
+	}
+	return formatted
+}
+
+// secret hides a secret value, keeping only whether it is set
+func secret(value string) string {
+	if value == "" {
+		return ""
+	}
+	return redacted
+}
+
+// redactURL hides the password and secret-looking query parameters of a URL
+func redactURL(raw string) string {
+	if raw == "" {
+		return ""
+	}
+	u, err := url.Parse(raw)
+	if err != nil {
+		return redacted
+	}
+
+	query := u.Query()
+	for key := range query {
+		lower := strings.ToLower(key)
+		if strings.Contains(lower, "token") || strings.Contains(lower, "key") || strings.Contains(lower, "secret") {
+			query.Set(key, redacted)
+		}
+	}
+	u.RawQuery = query.Encode()
+	return u.Redacted()
+}
@@ -1,12 +1,15 @@
 package handler
 
 import (
+	"errors"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
+	"github.com/shoelfikar/voucher-management-system/internal/delivery/http/middleware"
 	"github.com/shoelfikar/voucher-management-system/internal/delivery/http/request"
 	"github.com/shoelfikar/voucher-management-system/internal/delivery/http/response"
 	"github.com/shoelfikar/voucher-management-system/internal/domain/service"
+	"github.com/shoelfikar/voucher-management-system/pkg/logger"
 )
 
 type AuthHandler struct {
@@ -19,9 +22,9 @@ func NewAuthHandler(authService service.AuthService) *AuthHandler {
 	}
 }
 
-// Login handles POST /api/login
+// Login handles POST /api/v1/auth/login
 // @Summary User login
-// @Description Authenticate user with email and password (dummy validation)
+// @Description Authenticate user with email and password
 // @Tags Authentication
 // @Accept json
 // @Produce json
@@ -29,7 +32,8 @@ func NewAuthHandler(authService service.AuthService) *AuthHandler {
 // @Success 200 {object} response.Response{data=response.LoginResponse}
 // @Failure 400 {object} response.Response
 // @Failure 401 {object} response.Response
-// @Router /api/login [post]
+// @Failure 403 {object} response.Response
+// @Router /api/v1/auth/login [post]
 func (h *AuthHandler) Login(c *gin.Context) {
 	var req request.LoginRequest
 
@@ -38,9 +42,21 @@ func (h *AuthHandler) Login(c *gin.Context) {
 		return
 	}
 
-	token, user, err := h.authService.Login(req.Email, req.Password)
+	token, user, err := h.authService.Login(c.Request.Context(), req.Email, req.Password)
 	if err != nil {
-		c.JSON(http.StatusUnauthorized, response.ErrorResponse("Invalid credentials"))
+		switch {
+		case errors.Is(err, service.ErrInvalidCredentials):
+			logger.FromContext(c.Request.Context()).Warn("failed login attempt", "email", req.Email, "client_ip", middleware.GetClientIP(c))
+			c.JSON(http.StatusUnauthorized, response.ErrorResponse("Invalid credentials"))
+		case errors.Is(err, service.ErrAccountLocked):
+			logger.FromContext(c.Request.Context()).Warn("login attempt for locked account", "email", req.Email, "client_ip", middleware.GetClientIP(c))
+			c.JSON(http.StatusForbidden, response.ErrorResponse("Account is locked"))
+		case errors.Is(err, service.ErrEmailNotVerified):
+			c.JSON(http.StatusForbidden, response.ErrorResponse("Email address has not been verified"))
+		default:
+			logger.FromContext(c.Request.Context()).Error("failed to log in", "email", req.Email, "error", err)
+			c.JSON(http.StatusInternalServerError, response.ErrorResponse("Failed to log in"))
+		}
 		return
 	}
 
@@ -53,3 +69,51 @@ func (h *AuthHandler) Login(c *gin.Context) {
 
 	c.JSON(http.StatusOK, response.SuccessResponse(loginResponse))
 }
+
+// Register handles POST /api/v1/auth/register
+// @Summary Register an account
+// @Description Create an account with email and password. The first account becomes an admin; later ones need open registration.
+// @Tags Authentication
+// @Accept json
+// @Produce json
+// @Param request body request.RegisterRequest true "Account credentials"
+// @Success 201 {object} response.Response{data=response.RegisterResponse}
+// @Failure 400 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Failure 409 {object} response.Response
+// @Router /api/v1/auth/register [post]
+func (h *AuthHandler) Register(c *gin.Context) {
+	var req request.RegisterRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse(err.Error()))
+		return
+	}
+
+	token, user, err := h.authService.Register(c.Request.Context(), req.Email, req.Password)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrEmailRequired):
+			c.JSON(http.StatusBadRequest, response.ErrorResponse("Email is required"))
+		case errors.Is(err, service.ErrEmailAlreadyRegistered):
+			c.JSON(http.StatusConflict, response.ErrorResponse("Email is already registered"))
+		case errors.Is(err, service.ErrRegistrationClosed):
+			c.JSON(http.StatusForbidden, response.ErrorResponse("Registration is closed"))
+		default:
+			logger.FromContext(c.Request.Context()).Error("failed to register", "email", req.Email, "error", err)
+			c.JSON(http.StatusInternalServerError, response.ErrorResponse("Failed to register"))
+		}
+		return
+	}
+
+	message := "Registered successfully"
+	if token == "" {
+		message = "Registered successfully; check your email to verify the address before logging in"
+	}
+	c.JSON(http.StatusCreated, response.SuccessResponseWithMessage(message, response.RegisterResponse{
+		Token: token,
+		User: response.UserInfo{
+			Email: user.Email,
+			Role:  user.Role,
+		},
+	}))
+}
@@ -2,6 +2,7 @@ package handler
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"net/http"
@@ -11,16 +12,17 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/shoelfikar/voucher-management-system/internal/delivery/http/request"
 	"github.com/shoelfikar/voucher-management-system/internal/domain/entity"
+	"github.com/shoelfikar/voucher-management-system/internal/domain/service"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
 
-// MockAuthService is a mock implementation of AuthService
+// MockAuthService is a mock implementation of AuthService that ignores the context argument
 type MockAuthService struct {
 	mock.Mock
 }
 
-func (m *MockAuthService) Login(email, password string) (string, *entity.User, error) {
+func (m *MockAuthService) Login(ctx context.Context, email, password string) (string, *entity.User, error) {
 	args := m.Called(email, password)
 	if args.Get(1) == nil {
 		return args.String(0), nil, args.Error(2)
@@ -28,9 +30,12 @@ func (m *MockAuthService) Login(email, password string) (string, *entity.User, e
 	return args.String(0), args.Get(1).(*entity.User), args.Error(2)
 }
 
-func (m *MockAuthService) Register(email, password string) (string, error) {
+func (m *MockAuthService) Register(ctx context.Context, email, password string) (string, *entity.User, error) {
 	args := m.Called(email, password)
-	return args.String(0), args.Error(1)
+	if args.Get(1) == nil {
+		return args.String(0), nil, args.Error(2)
+	}
+	return args.String(0), args.Get(1).(*entity.User), args.Error(2)
 }
 
 func setupAuthTestRouter() *gin.Engine {
@@ -188,7 +193,7 @@ func TestAuthHandler_Login_ServiceError(t *testing.T) {
 	router.ServeHTTP(w, req)
 
 	// Assert
-	assert.Equal(t, http.StatusUnauthorized, w.Code)
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
 
 	var response map[string]interface{}
 	err := json.Unmarshal(w.Body.Bytes(), &response)
@@ -198,6 +203,47 @@ func TestAuthHandler_Login_ServiceError(t *testing.T) {
 	mockAuthService.AssertExpectations(t)
 }
 
+func TestAuthHandler_Login_RejectedCredentials(t *testing.T) {
+	tests := []struct {
+		name           string
+		err            error
+		expectedStatus int
+		expectedError  string
+	}{
+		{"invalid credentials", service.ErrInvalidCredentials, http.StatusUnauthorized, "Invalid credentials"},
+		{"locked account", service.ErrAccountLocked, http.StatusForbidden, "Account is locked"},
+		{"unverified email", service.ErrEmailNotVerified, http.StatusForbidden, "Email address has not been verified"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			mockAuthService := new(MockAuthService)
+			authHandler := NewAuthHandler(mockAuthService)
+			router := setupAuthTestRouter()
+			router.POST("/login", authHandler.Login)
+
+			mockAuthService.On("Login", "test@example.com", "password123").Return("", nil, tt.err)
+
+			requestBody, _ := json.Marshal(request.LoginRequest{Email: "test@example.com", Password: "password123"})
+			req, _ := http.NewRequest("POST", "/login", bytes.NewBuffer(requestBody))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+
+			// Act
+			router.ServeHTTP(w, req)
+
+			// Assert
+			assert.Equal(t, tt.expectedStatus, w.Code)
+
+			var response map[string]interface{}
+			err := json.Unmarshal(w.Body.Bytes(), &response)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedError, response["message"])
+		})
+	}
+}
+
 func TestAuthHandler_Login_InvalidEmailFormat(t *testing.T) {
 	// Arrange
 	mockAuthService := new(MockAuthService)
@@ -226,3 +272,123 @@ func TestAuthHandler_Login_InvalidEmailFormat(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, "error", response["status"])
 }
+
+func TestAuthHandler_Register_Success(t *testing.T) {
+	// Arrange
+	mockAuthService := new(MockAuthService)
+	authHandler := NewAuthHandler(mockAuthService)
+	router := setupAuthTestRouter()
+	router.POST("/register", authHandler.Register)
+
+	user := &entity.User{ID: 1, Email: "owner@example.com", Role: entity.UserRoleAdmin}
+	mockAuthService.On("Register", "owner@example.com", "password123").Return("mock.jwt.token", user, nil)
+
+	requestBody, _ := json.Marshal(request.RegisterRequest{Email: "owner@example.com", Password: "password123"})
+	req, _ := http.NewRequest("POST", "/register", bytes.NewBuffer(requestBody))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusCreated, w.Code)
+
+	var response map[string]interface{}
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	data := response["data"].(map[string]interface{})
+	assert.Equal(t, "mock.jwt.token", data["token"])
+	assert.Equal(t, "owner@example.com", data["user"].(map[string]interface{})["email"])
+	assert.Equal(t, entity.UserRoleAdmin, data["user"].(map[string]interface{})["role"])
+}
+
+func TestAuthHandler_Register_VerificationPending(t *testing.T) {
+	// Arrange
+	mockAuthService := new(MockAuthService)
+	authHandler := NewAuthHandler(mockAuthService)
+	router := setupAuthTestRouter()
+	router.POST("/register", authHandler.Register)
+
+	user := &entity.User{ID: 2, Email: "new@example.com", Role: entity.UserRoleViewer}
+	mockAuthService.On("Register", "new@example.com", "password123").Return("", user, nil)
+
+	requestBody, _ := json.Marshal(request.RegisterRequest{Email: "new@example.com", Password: "password123"})
+	req, _ := http.NewRequest("POST", "/register", bytes.NewBuffer(requestBody))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusCreated, w.Code)
+
+	var response map[string]interface{}
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.NotContains(t, response["data"], "token")
+	assert.Contains(t, response["message"], "verify")
+}
+
+func TestAuthHandler_Register_Rejected(t *testing.T) {
+	tests := []struct {
+		name           string
+		err            error
+		expectedStatus int
+		expectedError  string
+	}{
+		{"blank email", service.ErrEmailRequired, http.StatusBadRequest, "Email is required"},
+		{"duplicate email", service.ErrEmailAlreadyRegistered, http.StatusConflict, "Email is already registered"},
+		{"registration closed", service.ErrRegistrationClosed, http.StatusForbidden, "Registration is closed"},
+		{"service error", errors.New("database unavailable"), http.StatusInternalServerError, "Failed to register"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			mockAuthService := new(MockAuthService)
+			authHandler := NewAuthHandler(mockAuthService)
+			router := setupAuthTestRouter()
+			router.POST("/register", authHandler.Register)
+
+			mockAuthService.On("Register", "new@example.com", "password123").Return("", nil, tt.err)
+
+			requestBody, _ := json.Marshal(request.RegisterRequest{Email: "new@example.com", Password: "password123"})
+			req, _ := http.NewRequest("POST", "/register", bytes.NewBuffer(requestBody))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+
+			// Act
+			router.ServeHTTP(w, req)
+
+			// Assert
+			assert.Equal(t, tt.expectedStatus, w.Code)
+
+			var response map[string]interface{}
+			err := json.Unmarshal(w.Body.Bytes(), &response)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedError, response["message"])
+		})
+	}
+}
+
+func TestAuthHandler_Register_InvalidRequest(t *testing.T) {
+	// Arrange
+	mockAuthService := new(MockAuthService)
+	authHandler := NewAuthHandler(mockAuthService)
+	router := setupAuthTestRouter()
+	router.POST("/register", authHandler.Register)
+
+	requestBody, _ := json.Marshal(request.RegisterRequest{Email: "invalid-email", Password: "123"})
+	req, _ := http.NewRequest("POST", "/register", bytes.NewBuffer(requestBody))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	mockAuthService.AssertNotCalled(t, "Register", mock.Anything, mock.Anything)
+}
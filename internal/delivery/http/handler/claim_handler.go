@@ -0,0 +1,108 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/shoelfikar/voucher-management-system/internal/delivery/http/request"
+	"github.com/shoelfikar/voucher-management-system/internal/delivery/http/response"
+	"github.com/shoelfikar/voucher-management-system/internal/domain/service"
+)
+
+type ClaimHandler struct {
+	claimService service.ClaimService
+}
+
+func NewClaimHandler(claimService service.ClaimService) *ClaimHandler {
+	return &ClaimHandler{
+		claimService: claimService,
+	}
+}
+
+// CreateLinks handles POST /api/vouchers/:id/claim-links
+// @Summary Generate voucher claim links
+// @Description Generate up to 1000 signed one-time links that each assign the voucher to the first customer to claim it, for distribution by email or SMS. The links are only returned once.
+// @Tags Vouchers
+// @Accept json
+// @Produce json
+// @Param id path int true "Voucher ID"
+// @Param request body request.CreateClaimLinksRequest false "Number of links"
+// @Security BearerAuth
+// @Success 201 {object} response.Response{data=service.ClaimLinkBatch}
+// @Failure 400 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 409 {object} response.Response
+// @Router /api/vouchers/{id}/claim-links [post]
+func (h *ClaimHandler) CreateLinks(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse("Invalid voucher ID"))
+		return
+	}
+
+	var req request.CreateClaimLinksRequest
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, response.ErrorResponse(err.Error()))
+			return
+		}
+	}
+	if req.Count == 0 {
+		req.Count = 1
+	}
+
+	batch, err := h.claimService.CreateLinks(c.Request.Context(), &service.CreateClaimLinksCommand{
+		VoucherID: uint(id),
+		Count:     req.Count,
+		CreatedBy: c.GetString("email"),
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrVoucherNotFound):
+			c.JSON(http.StatusNotFound, response.ErrorResponse(err.Error()))
+		case errors.Is(err, service.ErrVoucherNotAssignable):
+			c.JSON(http.StatusConflict, response.ErrorResponse(err.Error()))
+		default:
+			c.JSON(http.StatusBadRequest, response.ErrorResponse(err.Error()))
+		}
+		return
+	}
+
+	c.JSON(http.StatusCreated, response.SuccessResponseWithMessage("Claim links created successfully", batch))
+}
+
+// Claim handles POST /api/claim
+// @Summary Claim a voucher
+// @Description Assign the voucher behind a claim link to the presenting customer; each link works once
+// @Tags Vouchers
+// @Accept json
+// @Produce json
+// @Param request body request.ClaimVoucherRequest true "Claim token and customer"
+// @Success 201 {object} response.Response{data=entity.VoucherAssignment}
+// @Failure 400 {object} response.Response
+// @Failure 409 {object} response.Response
+// @Router /api/claim [post]
+func (h *ClaimHandler) Claim(c *gin.Context) {
+	var req request.ClaimVoucherRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse(err.Error()))
+		return
+	}
+
+	assignment, err := h.claimService.Claim(c.Request.Context(), req.Token, req.CustomerID)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrClaimLinkUsed),
+			errors.Is(err, service.ErrVoucherAlreadyAssigned),
+			errors.Is(err, service.ErrVoucherNotAssignable):
+			c.JSON(http.StatusConflict, response.ErrorResponse(err.Error()))
+		default:
+			c.JSON(http.StatusBadRequest, response.ErrorResponse(err.Error()))
+		}
+		return
+	}
+
+	c.JSON(http.StatusCreated, response.SuccessResponseWithMessage("Voucher claimed successfully", assignment))
+}
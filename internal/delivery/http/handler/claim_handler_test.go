@@ -0,0 +1,118 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/shoelfikar/voucher-management-system/internal/domain/entity"
+	"github.com/shoelfikar/voucher-management-system/internal/domain/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockClaimService is a mock implementation of ClaimService
+type MockClaimService struct {
+	mock.Mock
+}
+
+func (m *MockClaimService) CreateLinks(ctx context.Context, cmd *service.CreateClaimLinksCommand) (*service.ClaimLinkBatch, error) {
+	args := m.Called(cmd)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*service.ClaimLinkBatch), args.Error(1)
+}
+
+func (m *MockClaimService) Claim(ctx context.Context, token, customerID string) (*entity.VoucherAssignment, error) {
+	args := m.Called(token, customerID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.VoucherAssignment), args.Error(1)
+}
+
+func setupClaimTestRouter(claimHandler *ClaimHandler) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.POST("/vouchers/:id/claim-links", claimHandler.CreateLinks)
+	router.POST("/claim", claimHandler.Claim)
+	return router
+}
+
+func TestClaimHandler_CreateLinks_DefaultsToOne(t *testing.T) {
+	// Arrange
+	mockService := new(MockClaimService)
+	router := setupClaimTestRouter(NewClaimHandler(mockService))
+
+	mockService.On("CreateLinks", &service.CreateClaimLinksCommand{VoucherID: 7, Count: 1}).
+		Return(&service.ClaimLinkBatch{VoucherID: 7, ExpiresAt: time.Now(), URLs: []string{"https://shop.example.com/claim?token=abc"}}, nil)
+
+	req, _ := http.NewRequest("POST", "/vouchers/7/claim-links", nil)
+	w := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.Contains(t, w.Body.String(), "token=abc")
+	mockService.AssertExpectations(t)
+}
+
+func TestClaimHandler_CreateLinks_RejectsTooMany(t *testing.T) {
+	// Arrange
+	mockService := new(MockClaimService)
+	router := setupClaimTestRouter(NewClaimHandler(mockService))
+
+	req, _ := http.NewRequest("POST", "/vouchers/7/claim-links", bytes.NewBufferString(`{"count":1001}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	mockService.AssertNotCalled(t, "CreateLinks", mock.Anything)
+}
+
+func TestClaimHandler_Claim(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		code int
+	}{
+		{"claimed", nil, http.StatusCreated},
+		{"invalid", service.ErrInvalidClaimLink, http.StatusBadRequest},
+		{"used", service.ErrClaimLinkUsed, http.StatusConflict},
+		{"already assigned", service.ErrVoucherAlreadyAssigned, http.StatusConflict},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			mockService := new(MockClaimService)
+			router := setupClaimTestRouter(NewClaimHandler(mockService))
+
+			if tt.err != nil {
+				mockService.On("Claim", "abc", "cust-1").Return(nil, tt.err)
+			} else {
+				mockService.On("Claim", "abc", "cust-1").Return(&entity.VoucherAssignment{VoucherID: 7, CustomerID: 4, Customer: &entity.Customer{ID: 4, ExternalID: "cust-1"}}, nil)
+			}
+
+			req, _ := http.NewRequest("POST", "/claim", bytes.NewBufferString(`{"token":"abc","customer_id":"cust-1"}`))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+
+			// Act
+			router.ServeHTTP(w, req)
+
+			// Assert
+			assert.Equal(t, tt.code, w.Code)
+		})
+	}
+}
@@ -0,0 +1,296 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/shoelfikar/voucher-management-system/internal/delivery/http/request"
+	"github.com/shoelfikar/voucher-management-system/internal/delivery/http/response"
+	"github.com/shoelfikar/voucher-management-system/internal/domain/service"
+	"github.com/shoelfikar/voucher-management-system/pkg/utils"
+)
+
+// customerSortFields are the columns GET /customers may be sorted by
+var customerSortFields = []string{"id", "external_id", "email", "created_at", "updated_at"}
+
+type CustomerHandler struct {
+	customerService service.CustomerService
+}
+
+func NewCustomerHandler(customerService service.CustomerService) *CustomerHandler {
+	return &CustomerHandler{
+		customerService: customerService,
+	}
+}
+
+// GetAll handles GET /api/customers
+// @Summary Get all customers
+// @Description List customers, leaving out duplicates merged into another customer
+// @Tags Customers
+// @Produce json
+// @Param page query int false "Page number"
+// @Param limit query int false "Items per page"
+// @Param search query string false "Search by external ID, email or phone"
+// @Param sort query string false "Comma-separated field:direction list, e.g. created_at:desc"
+// @Security BearerAuth
+// @Success 200 {object} response.Response{data=response.CustomerListResponse}
+// @Failure 400 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /api/customers [get]
+func (h *CustomerHandler) GetAll(c *gin.Context) {
+	params, err := utils.ParseListParams(c.Request.URL.Query(), customerSortFields, "id:asc")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse(err.Error()))
+		return
+	}
+
+	customers, total, err := h.customerService.GetAll(c.Request.Context(), params.Page, params.Limit, params.Search, params.Sort)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.ErrorResponse(err.Error()))
+		return
+	}
+
+	c.Header("X-Total-Count", strconv.FormatInt(total, 10))
+	c.JSON(http.StatusOK, response.SuccessResponse(response.BuildCustomerListResponse(customers, params.Page, params.Limit, total)))
+}
+
+// GetByID handles GET /api/customers/:id
+// @Summary Get customer by ID
+// @Description Get a customer; a merged duplicate has merged_into_id set to the customer it was merged into
+// @Tags Customers
+// @Produce json
+// @Param id path int true "Customer ID"
+// @Security BearerAuth
+// @Success 200 {object} response.Response{data=entity.Customer}
+// @Failure 400 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Router /api/customers/{id} [get]
+func (h *CustomerHandler) GetByID(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse("Invalid customer ID"))
+		return
+	}
+
+	customer, err := h.customerService.GetByID(c.Request.Context(), uint(id))
+	if err != nil {
+		c.JSON(customerErrorStatus(err, http.StatusInternalServerError), response.ErrorResponse(err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, response.SuccessResponse(customer))
+}
+
+// Create handles POST /api/customers
+// @Summary Create a customer
+// @Description Add a customer ahead of its first assignment, claim or redemption
+// @Tags Customers
+// @Accept json
+// @Produce json
+// @Param request body request.CustomerRequest true "Customer"
+// @Security BearerAuth
+// @Success 201 {object} response.Response{data=entity.Customer}
+// @Failure 400 {object} response.Response
+// @Failure 409 {object} response.Response
+// @Router /api/customers [post]
+func (h *CustomerHandler) Create(c *gin.Context) {
+	var req request.CustomerRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse(err.Error()))
+		return
+	}
+
+	customer, err := h.customerService.Create(c.Request.Context(), req.ToCommand())
+	if err != nil {
+		c.JSON(customerErrorStatus(err, http.StatusBadRequest), response.ErrorResponse(err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusCreated, response.SuccessResponseWithMessage("Customer created successfully", customer))
+}
+
+// Update handles PUT /api/customers/:id
+// @Summary Update a customer
+// @Description Replace a customer's external ID and contact details
+// @Tags Customers
+// @Accept json
+// @Produce json
+// @Param id path int true "Customer ID"
+// @Param request body request.CustomerRequest true "Customer"
+// @Security BearerAuth
+// @Success 200 {object} response.Response{data=entity.Customer}
+// @Failure 400 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 409 {object} response.Response
+// @Router /api/customers/{id} [put]
+func (h *CustomerHandler) Update(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse("Invalid customer ID"))
+		return
+	}
+
+	var req request.CustomerRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse(err.Error()))
+		return
+	}
+
+	customer, err := h.customerService.Update(c.Request.Context(), uint(id), req.ToCommand())
+	if err != nil {
+		c.JSON(customerErrorStatus(err, http.StatusBadRequest), response.ErrorResponse(err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, response.SuccessResponseWithMessage("Customer updated successfully", customer))
+}
+
+// Delete handles DELETE /api/customers/:id
+// @Summary Delete a customer
+// @Description Delete a customer nothing was assigned to, claimed or redeemed by; merge duplicates instead
+// @Tags Customers
+// @Produce json
+// @Param id path int true "Customer ID"
+// @Security BearerAuth
+// @Success 200 {object} response.Response
+// @Failure 400 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 409 {object} response.Response
+// @Router /api/customers/{id} [delete]
+func (h *CustomerHandler) Delete(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse("Invalid customer ID"))
+		return
+	}
+
+	if err := h.customerService.Delete(c.Request.Context(), uint(id)); err != nil {
+		c.JSON(customerErrorStatus(err, http.StatusInternalServerError), response.ErrorResponse(err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, response.SuccessResponseWithMessage("Customer deleted successfully", nil))
+}
+
+// Import handles POST /api/customers/import
+// @Summary Import customers from CSV
+// @Description Upload a CSV file with an external_id column and optional email and phone columns. Rows for existing external IDs update those customers; blank cells leave stored details alone.
+// @Tags Customers
+// @Accept multipart/form-data
+// @Produce json
+// @Param file formData file true "CSV file"
+// @Security BearerAuth
+// @Success 200 {object} response.Response{data=service.CustomerImportResult}
+// @Failure 400 {object} response.Response
+// @Router /api/customers/import [post]
+func (h *CustomerHandler) Import(c *gin.Context) {
+	file, header, err := c.Request.FormFile("file")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse("File is required"))
+		return
+	}
+	defer file.Close()
+
+	if !strings.HasSuffix(header.Filename, ".csv") {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse("Only CSV files are allowed"))
+		return
+	}
+
+	// Validate file size (max 5MB)
+	if header.Size > 5*1024*1024 {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse("File size exceeds 5MB"))
+		return
+	}
+
+	result, err := h.customerService.Import(c.Request.Context(), file)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse(err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, response.SuccessResponseWithMessage("Customer import completed", result))
+}
+
+// Merge handles POST /api/customers/:id/merge
+// @Summary Merge a duplicate customer
+// @Description Move the duplicate's assignments, redemptions and claims to this customer and resolve its external ID to this customer from now on. Where both have the same voucher assigned, this customer's assignment is kept.
+// @Tags Customers
+// @Accept json
+// @Produce json
+// @Param id path int true "ID of the customer to keep"
+// @Param request body request.MergeCustomerRequest true "Duplicate to merge"
+// @Security BearerAuth
+// @Success 200 {object} response.Response{data=entity.Customer}
+// @Failure 400 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 409 {object} response.Response
+// @Router /api/customers/{id}/merge [post]
+func (h *CustomerHandler) Merge(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse("Invalid customer ID"))
+		return
+	}
+
+	var req request.MergeCustomerRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse(err.Error()))
+		return
+	}
+
+	customer, err := h.customerService.Merge(c.Request.Context(), uint(id), req.DuplicateID)
+	if err != nil {
+		c.JSON(customerErrorStatus(err, http.StatusBadRequest), response.ErrorResponse(err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, response.SuccessResponseWithMessage("Customers merged successfully", customer))
+}
+
+// Erase handles POST /api/customers/:id/erase
+// @Summary Erase a customer's personal data
+// @Description Anonymize a customer for a data deletion request. Its external ID is replaced with erased:<id> and its email and phone cleared, as are those of duplicates merged into it; order IDs are cleared from its redemptions, phone numbers and message bodies from its SMS deliveries (unsent ones are discarded), and it is removed from static segments. Assignments, redemptions and claims are kept, so counts and reports stay intact. An audit entry records who erased which customer ID. Erasure cannot be undone; admins only.
+// @Tags Customers
+// @Produce json
+// @Param id path int true "Customer ID"
+// @Security BearerAuth
+// @Success 200 {object} response.Response{data=service.CustomerErasure}
+// @Failure 400 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 409 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /api/customers/{id}/erase [post]
+func (h *CustomerHandler) Erase(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse("Invalid customer ID"))
+		return
+	}
+
+	erasure, err := h.customerService.Erase(c.Request.Context(), uint(id), c.GetString("email"))
+	if err != nil {
+		c.JSON(customerErrorStatus(err, http.StatusInternalServerError), response.ErrorResponse(err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, response.SuccessResponseWithMessage("Customer data erased successfully", erasure))
+}
+
+// customerErrorStatus maps a customer failure to its HTTP status, or to
+// fallback when it is none of the customer errors
+func customerErrorStatus(err error, fallback int) int {
+	switch {
+	case errors.Is(err, service.ErrCustomerNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, service.ErrDuplicateCustomer), errors.Is(err, service.ErrCustomerInUse), errors.Is(err, service.ErrCustomerMerged),
+		errors.Is(err, service.ErrCustomerErased):
+		return http.StatusConflict
+	default:
+		return fallback
+	}
+}
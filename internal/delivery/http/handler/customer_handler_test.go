@@ -0,0 +1,276 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/shoelfikar/voucher-management-system/internal/domain/entity"
+	"github.com/shoelfikar/voucher-management-system/internal/domain/service"
+	"github.com/shoelfikar/voucher-management-system/pkg/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockCustomerService is a mock implementation of CustomerService
+type MockCustomerService struct {
+	mock.Mock
+}
+
+func (m *MockCustomerService) GetAll(ctx context.Context, page, limit int, search string, sort []utils.SortField) ([]*entity.Customer, int64, error) {
+	args := m.Called(page, limit, search, sort)
+	if args.Get(0) == nil {
+		return nil, args.Get(1).(int64), args.Error(2)
+	}
+	return args.Get(0).([]*entity.Customer), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockCustomerService) GetByID(ctx context.Context, id uint) (*entity.Customer, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Customer), args.Error(1)
+}
+
+func (m *MockCustomerService) Create(ctx context.Context, cmd *service.CustomerCommand) (*entity.Customer, error) {
+	args := m.Called(cmd)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Customer), args.Error(1)
+}
+
+func (m *MockCustomerService) Update(ctx context.Context, id uint, cmd *service.CustomerCommand) (*entity.Customer, error) {
+	args := m.Called(id, cmd)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Customer), args.Error(1)
+}
+
+func (m *MockCustomerService) Delete(ctx context.Context, id uint) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func (m *MockCustomerService) Import(ctx context.Context, file io.Reader) (*service.CustomerImportResult, error) {
+	args := m.Called(file)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*service.CustomerImportResult), args.Error(1)
+}
+
+func (m *MockCustomerService) Merge(ctx context.Context, survivorID, duplicateID uint) (*entity.Customer, error) {
+	args := m.Called(survivorID, duplicateID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Customer), args.Error(1)
+}
+
+func (m *MockCustomerService) Erase(ctx context.Context, id uint, actor string) (*service.CustomerErasure, error) {
+	args := m.Called(id, actor)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*service.CustomerErasure), args.Error(1)
+}
+
+func (m *MockCustomerService) Resolve(ctx context.Context, externalID string) (*entity.Customer, error) {
+	args := m.Called(externalID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Customer), args.Error(1)
+}
+
+func (m *MockCustomerService) Find(ctx context.Context, externalID string) (*entity.Customer, error) {
+	args := m.Called(externalID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Customer), args.Error(1)
+}
+
+func setupCustomerTestRouter(customerHandler *CustomerHandler) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/customers/:id", customerHandler.GetByID)
+	router.POST("/customers", customerHandler.Create)
+	router.DELETE("/customers/:id", customerHandler.Delete)
+	router.POST("/customers/import", customerHandler.Import)
+	router.POST("/customers/:id/merge", customerHandler.Merge)
+	router.POST("/customers/:id/erase", func(c *gin.Context) { c.Set("email", "admin@example.com") }, customerHandler.Erase)
+	return router
+}
+
+func TestCustomerHandler_Create(t *testing.T) {
+	tests := []struct {
+		name       string
+		body       string
+		serviceErr error
+		wantStatus int
+	}{
+		{name: "created", body: `{"external_id":"crm-1","email":"ana@example.com"}`, wantStatus: http.StatusCreated},
+		{name: "missing external id", body: `{"email":"ana@example.com"}`, wantStatus: http.StatusBadRequest},
+		{name: "invalid email", body: `{"external_id":"crm-1","email":"ana"}`, wantStatus: http.StatusBadRequest},
+		{name: "taken external id", body: `{"external_id":"crm-1","email":"ana@example.com"}`, serviceErr: service.ErrDuplicateCustomer, wantStatus: http.StatusConflict},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			mockService := new(MockCustomerService)
+			router := setupCustomerTestRouter(NewCustomerHandler(mockService))
+			if tt.serviceErr != nil {
+				mockService.On("Create", mock.AnythingOfType("*service.CustomerCommand")).Return(nil, tt.serviceErr)
+			} else {
+				mockService.On("Create", &service.CustomerCommand{ExternalID: "crm-1", Email: "ana@example.com"}).
+					Return(&entity.Customer{ID: 1, ExternalID: "crm-1", Email: "ana@example.com"}, nil)
+			}
+
+			req, _ := http.NewRequest("POST", "/customers", bytes.NewBufferString(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+
+			// Act
+			router.ServeHTTP(w, req)
+
+			// Assert
+			assert.Equal(t, tt.wantStatus, w.Code)
+		})
+	}
+}
+
+func TestCustomerHandler_Delete(t *testing.T) {
+	tests := []struct {
+		name       string
+		serviceErr error
+		wantStatus int
+	}{
+		{name: "deleted", wantStatus: http.StatusOK},
+		{name: "not found", serviceErr: service.ErrCustomerNotFound, wantStatus: http.StatusNotFound},
+		{name: "in use", serviceErr: service.ErrCustomerInUse, wantStatus: http.StatusConflict},
+		{name: "database down", serviceErr: errors.New("connection refused"), wantStatus: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			mockService := new(MockCustomerService)
+			router := setupCustomerTestRouter(NewCustomerHandler(mockService))
+			mockService.On("Delete", uint(3)).Return(tt.serviceErr)
+
+			req, _ := http.NewRequest("DELETE", "/customers/3", nil)
+			w := httptest.NewRecorder()
+
+			// Act
+			router.ServeHTTP(w, req)
+
+			// Assert
+			assert.Equal(t, tt.wantStatus, w.Code)
+		})
+	}
+}
+
+func TestCustomerHandler_Import(t *testing.T) {
+	// Arrange
+	mockService := new(MockCustomerService)
+	router := setupCustomerTestRouter(NewCustomerHandler(mockService))
+	mockService.On("Import", mock.Anything).Return(&service.CustomerImportResult{TotalRows: 1, Created: 1}, nil)
+
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	part, _ := writer.CreateFormFile("file", "customers.csv")
+	part.Write([]byte("external_id,email\ncrm-1,ana@example.com\n"))
+	writer.Close()
+
+	req, _ := http.NewRequest("POST", "/customers/import", body)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	w := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"created":1`)
+	mockService.AssertExpectations(t)
+}
+
+func TestCustomerHandler_Merge(t *testing.T) {
+	// Arrange
+	mockService := new(MockCustomerService)
+	router := setupCustomerTestRouter(NewCustomerHandler(mockService))
+	mockService.On("Merge", uint(1), uint(2)).Return(&entity.Customer{ID: 1, ExternalID: "crm-1"}, nil)
+	mockService.On("Merge", uint(1), uint(3)).Return(nil, service.ErrCustomerMerged)
+
+	mergedReq, _ := http.NewRequest("POST", "/customers/1/merge", bytes.NewBufferString(`{"duplicate_id":2}`))
+	mergedReq.Header.Set("Content-Type", "application/json")
+	mergedW := httptest.NewRecorder()
+	conflictReq, _ := http.NewRequest("POST", "/customers/1/merge", bytes.NewBufferString(`{"duplicate_id":3}`))
+	conflictReq.Header.Set("Content-Type", "application/json")
+	conflictW := httptest.NewRecorder()
+	invalidReq, _ := http.NewRequest("POST", "/customers/1/merge", bytes.NewBufferString(`{}`))
+	invalidReq.Header.Set("Content-Type", "application/json")
+	invalidW := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(mergedW, mergedReq)
+	router.ServeHTTP(conflictW, conflictReq)
+	router.ServeHTTP(invalidW, invalidReq)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, mergedW.Code)
+	assert.Equal(t, http.StatusConflict, conflictW.Code)
+	assert.Equal(t, http.StatusBadRequest, invalidW.Code)
+	mockService.AssertExpectations(t)
+}
+
+func TestCustomerHandler_Erase(t *testing.T) {
+	tests := []struct {
+		name       string
+		id         string
+		serviceErr error
+		wantStatus int
+	}{
+		{name: "erased", id: "1", wantStatus: http.StatusOK},
+		{name: "already erased", id: "1", serviceErr: service.ErrCustomerErased, wantStatus: http.StatusConflict},
+		{name: "merged duplicate", id: "1", serviceErr: service.ErrCustomerMerged, wantStatus: http.StatusConflict},
+		{name: "missing", id: "1", serviceErr: service.ErrCustomerNotFound, wantStatus: http.StatusNotFound},
+		{name: "invalid id", id: "abc", wantStatus: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			mockService := new(MockCustomerService)
+			router := setupCustomerTestRouter(NewCustomerHandler(mockService))
+			if tt.serviceErr != nil {
+				mockService.On("Erase", uint(1), "admin@example.com").Return(nil, tt.serviceErr)
+			} else {
+				mockService.On("Erase", uint(1), "admin@example.com").Return(&service.CustomerErasure{CustomerID: 1, Redemptions: 2}, nil)
+			}
+
+			req, _ := http.NewRequest("POST", "/customers/"+tt.id+"/erase", nil)
+			w := httptest.NewRecorder()
+
+			// Act
+			router.ServeHTTP(w, req)
+
+			// Assert
+			assert.Equal(t, tt.wantStatus, w.Code)
+			if tt.wantStatus == http.StatusOK {
+				assert.Contains(t, w.Body.String(), `"redemptions":2`)
+			}
+		})
+	}
+}
@@ -0,0 +1,96 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/shoelfikar/voucher-management-system/internal/delivery/http/response"
+	"github.com/shoelfikar/voucher-management-system/internal/domain/service"
+	"github.com/shoelfikar/voucher-management-system/pkg/utils"
+)
+
+type DeadLetterHandler struct {
+	deadLetterService service.DeadLetterService
+}
+
+func NewDeadLetterHandler(deadLetterService service.DeadLetterService) *DeadLetterHandler {
+	return &DeadLetterHandler{
+		deadLetterService: deadLetterService,
+	}
+}
+
+// GetAll handles GET /api/system/dead-letters
+// @Summary List dead letters
+// @Description Get background jobs that ran out of attempts, newest first, with a preview of their payload in which claim link tokens and voucher codes are redacted. Admins only.
+// @Tags System
+// @Produce json
+// @Param kind query string false "Only dead letters of this kind (sms)"
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Items per page" default(10)
+// @Security BearerAuth
+// @Success 200 {object} response.Response{data=response.DeadLetterListResponse}
+// @Failure 400 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Router /api/system/dead-letters [get]
+func (h *DeadLetterHandler) GetAll(c *gin.Context) {
+	params := utils.ParsePaginationParams(c.Query("page"), c.Query("limit"), "", "")
+
+	letters, total, err := h.deadLetterService.GetAll(c.Request.Context(), c.Query("kind"), params.Page, params.Limit)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse(err.Error()))
+		return
+	}
+
+	c.Header("X-Total-Count", strconv.FormatInt(total, 10))
+	c.JSON(http.StatusOK, response.SuccessResponse(response.BuildDeadLetterListResponse(letters, params.Page, params.Limit, total)))
+}
+
+// Retry handles POST /api/system/dead-letters/:id/retry
+// @Summary Retry a dead letter
+// @Description Queue the failed job again with a fresh set of attempts. Admins only.
+// @Tags System
+// @Produce json
+// @Param id path string true "Dead letter ID, e.g. sms-42"
+// @Security BearerAuth
+// @Success 200 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Router /api/system/dead-letters/{id}/retry [post]
+func (h *DeadLetterHandler) Retry(c *gin.Context) {
+	if err := h.deadLetterService.Retry(c.Request.Context(), c.Param("id"), c.GetString("email")); err != nil {
+		if errors.Is(err, service.ErrDeadLetterNotFound) {
+			c.JSON(http.StatusNotFound, response.ErrorResponse(err.Error()))
+			return
+		}
+		c.JSON(http.StatusInternalServerError, response.ErrorResponse(err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, response.SuccessResponseWithMessage("Dead letter queued for retry", nil))
+}
+
+// Discard handles POST /api/system/dead-letters/:id/discard
+// @Summary Discard a dead letter
+// @Description Give up on the failed job. It stays recorded, e.g. as a discarded SMS delivery, but is no longer listed. Admins only.
+// @Tags System
+// @Produce json
+// @Param id path string true "Dead letter ID, e.g. sms-42"
+// @Security BearerAuth
+// @Success 200 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Router /api/system/dead-letters/{id}/discard [post]
+func (h *DeadLetterHandler) Discard(c *gin.Context) {
+	if err := h.deadLetterService.Discard(c.Request.Context(), c.Param("id"), c.GetString("email")); err != nil {
+		if errors.Is(err, service.ErrDeadLetterNotFound) {
+			c.JSON(http.StatusNotFound, response.ErrorResponse(err.Error()))
+			return
+		}
+		c.JSON(http.StatusInternalServerError, response.ErrorResponse(err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, response.SuccessResponseWithMessage("Dead letter discarded", nil))
+}
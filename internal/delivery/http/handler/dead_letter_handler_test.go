@@ -0,0 +1,119 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/shoelfikar/voucher-management-system/internal/domain/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockDeadLetterService is a mock implementation of DeadLetterService
+type MockDeadLetterService struct {
+	mock.Mock
+}
+
+func (m *MockDeadLetterService) GetAll(ctx context.Context, kind string, page, limit int) ([]*service.DeadLetter, int64, error) {
+	args := m.Called(kind, page, limit)
+	if args.Get(0) == nil {
+		return nil, 0, args.Error(2)
+	}
+	return args.Get(0).([]*service.DeadLetter), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockDeadLetterService) Retry(ctx context.Context, id, actor string) error {
+	args := m.Called(id, actor)
+	return args.Error(0)
+}
+
+func (m *MockDeadLetterService) Discard(ctx context.Context, id, actor string) error {
+	args := m.Called(id, actor)
+	return args.Error(0)
+}
+
+func setupDeadLetterTestRouter(deadLetterHandler *DeadLetterHandler) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		c.Set("email", "admin@example.com")
+		c.Next()
+	})
+	router.GET("/system/dead-letters", deadLetterHandler.GetAll)
+	router.POST("/system/dead-letters/:id/retry", deadLetterHandler.Retry)
+	router.POST("/system/dead-letters/:id/discard", deadLetterHandler.Discard)
+	return router
+}
+
+func TestDeadLetterHandler_GetAll(t *testing.T) {
+	// Arrange
+	mockService := new(MockDeadLetterService)
+	router := setupDeadLetterTestRouter(NewDeadLetterHandler(mockService))
+
+	mockService.On("GetAll", "sms", 2, 5).Return([]*service.DeadLetter{
+		{ID: "sms-7", Kind: service.DeadLetterKindSMS, Attempts: 5, LastError: "carrier rejected", Preview: map[string]interface{}{"message": "code SUMM****24"}},
+	}, int64(6), nil)
+	w := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/system/dead-letters?kind=sms&page=2&limit=5", nil))
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "6", w.Header().Get("X-Total-Count"))
+	assert.Contains(t, w.Body.String(), `"id":"sms-7"`)
+	assert.Contains(t, w.Body.String(), "SUMM****24")
+	mockService.AssertExpectations(t)
+}
+
+func TestDeadLetterHandler_GetAll_InvalidKind(t *testing.T) {
+	// Arrange
+	mockService := new(MockDeadLetterService)
+	router := setupDeadLetterTestRouter(NewDeadLetterHandler(mockService))
+
+	mockService.On("GetAll", "webhook", 1, 10).Return(nil, int64(0), errors.New("kind must be one of sms"))
+	w := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/system/dead-letters?kind=webhook", nil))
+
+	// Assert
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestDeadLetterHandler_RetryAndDiscard(t *testing.T) {
+	tests := []struct {
+		name           string
+		method         string
+		path           string
+		id             string
+		err            error
+		expectedStatus int
+	}{
+		{"retry", "Retry", "/system/dead-letters/sms-7/retry", "sms-7", nil, http.StatusOK},
+		{"retry unknown", "Retry", "/system/dead-letters/sms-8/retry", "sms-8", service.ErrDeadLetterNotFound, http.StatusNotFound},
+		{"discard", "Discard", "/system/dead-letters/sms-7/discard", "sms-7", nil, http.StatusOK},
+		{"discard failure", "Discard", "/system/dead-letters/sms-7/discard", "sms-7", errors.New("connection refused"), http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			mockService := new(MockDeadLetterService)
+			router := setupDeadLetterTestRouter(NewDeadLetterHandler(mockService))
+			mockService.On(tt.method, tt.id, "admin@example.com").Return(tt.err)
+			w := httptest.NewRecorder()
+
+			// Act
+			router.ServeHTTP(w, httptest.NewRequest("POST", tt.path, nil))
+
+			// Assert
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			mockService.AssertExpectations(t)
+		})
+	}
+}
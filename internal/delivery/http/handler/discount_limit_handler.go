@@ -0,0 +1,99 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/shoelfikar/voucher-management-system/internal/delivery/http/request"
+	"github.com/shoelfikar/voucher-management-system/internal/delivery/http/response"
+	"github.com/shoelfikar/voucher-management-system/internal/domain/service"
+)
+
+type DiscountLimitHandler struct {
+	limitService service.DiscountLimitService
+}
+
+func NewDiscountLimitHandler(limitService service.DiscountLimitService) *DiscountLimitHandler {
+	return &DiscountLimitHandler{
+		limitService: limitService,
+	}
+}
+
+// GetAll handles GET /api/discount-limits
+// @Summary List discount limits
+// @Description Get the largest discount each limited role may give the vouchers it creates or edits
+// @Tags Discount Limits
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} response.Response{data=[]response.DiscountLimitResponse}
+// @Failure 403 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /api/discount-limits [get]
+func (h *DiscountLimitHandler) GetAll(c *gin.Context) {
+	limits, err := h.limitService.GetAll(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.ErrorResponse(err.Error()))
+		return
+	}
+	c.JSON(http.StatusOK, response.SuccessResponse(response.ToDiscountLimitResponses(limits)))
+}
+
+// Set handles PUT /api/discount-limits/:role
+// @Summary Set a role's discount limit
+// @Description Cap the discount percent and, per currency, the fixed amount users with the role may give; admins cannot be limited
+// @Tags Discount Limits
+// @Accept json
+// @Produce json
+// @Param role path string true "Role, e.g. marketing"
+// @Param request body request.DiscountLimitRequest true "Discount limit"
+// @Security BearerAuth
+// @Success 200 {object} response.Response{data=response.DiscountLimitResponse}
+// @Failure 400 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Router /api/discount-limits/{role} [put]
+func (h *DiscountLimitHandler) Set(c *gin.Context) {
+	var req request.DiscountLimitRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse(err.Error()))
+		return
+	}
+
+	limit, err := h.limitService.Set(c.Request.Context(), &service.SetDiscountLimitCommand{
+		Role:               c.Param("role"),
+		MaxDiscountPercent: req.MaxDiscountPercent,
+		MaxDiscountAmounts: req.MaxDiscountAmounts,
+		UpdatedBy:          c.GetString("email"),
+	})
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse(err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, response.SuccessResponseWithMessage("Discount limit updated successfully", response.ToDiscountLimitResponse(limit)))
+}
+
+// Delete handles DELETE /api/discount-limits/:role
+// @Summary Remove a role's discount limit
+// @Description Let users with the role give any discount again
+// @Tags Discount Limits
+// @Produce json
+// @Param role path string true "Role"
+// @Security BearerAuth
+// @Success 200 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /api/discount-limits/{role} [delete]
+func (h *DiscountLimitHandler) Delete(c *gin.Context) {
+	if err := h.limitService.Delete(c.Request.Context(), c.Param("role")); err != nil {
+		if errors.Is(err, service.ErrDiscountLimitNotFound) {
+			c.JSON(http.StatusNotFound, response.ErrorResponse(err.Error()))
+			return
+		}
+		c.JSON(http.StatusInternalServerError, response.ErrorResponse(err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, response.SuccessResponseWithMessage("Discount limit removed successfully", nil))
+}
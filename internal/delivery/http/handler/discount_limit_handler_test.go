@@ -0,0 +1,114 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/shoelfikar/voucher-management-system/internal/domain/entity"
+	"github.com/shoelfikar/voucher-management-system/internal/domain/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockDiscountLimitService is a mock implementation of DiscountLimitService
+type MockDiscountLimitService struct {
+	mock.Mock
+}
+
+func (m *MockDiscountLimitService) GetAll(ctx context.Context) ([]*entity.DiscountLimit, error) {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.DiscountLimit), args.Error(1)
+}
+
+func (m *MockDiscountLimitService) Set(ctx context.Context, cmd *service.SetDiscountLimitCommand) (*entity.DiscountLimit, error) {
+	args := m.Called(cmd)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.DiscountLimit), args.Error(1)
+}
+
+func (m *MockDiscountLimitService) Delete(ctx context.Context, role string) error {
+	args := m.Called(role)
+	return args.Error(0)
+}
+
+func setupDiscountLimitTestRouter(limitHandler *DiscountLimitHandler) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/discount-limits", limitHandler.GetAll)
+	router.PUT("/discount-limits/:role", func(c *gin.Context) {
+		c.Set("email", "owner@example.com")
+		limitHandler.Set(c)
+	})
+	router.DELETE("/discount-limits/:role", limitHandler.Delete)
+	return router
+}
+
+func TestDiscountLimitHandler_Set(t *testing.T) {
+	// Arrange
+	mockService := new(MockDiscountLimitService)
+	router := setupDiscountLimitTestRouter(NewDiscountLimitHandler(mockService))
+
+	mockService.On("Set", &service.SetDiscountLimitCommand{Role: "marketing", MaxDiscountPercent: 30, MaxDiscountAmounts: map[string]float64{"IDR": 50000}, UpdatedBy: "owner@example.com"}).
+		Return(&entity.DiscountLimit{Role: "marketing", MaxDiscountPercent: 30, MaxDiscountAmounts: map[string]int64{"IDR": 5000000}}, nil)
+
+	req, _ := http.NewRequest("PUT", "/discount-limits/marketing", bytes.NewBufferString(`{"max_discount_percent":30,"max_discount_amounts":{"IDR":50000}}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"max_discount_percent":30,"max_discount_amounts":{"IDR":50000}`)
+	mockService.AssertExpectations(t)
+}
+
+func TestDiscountLimitHandler_Set_InvalidPercent(t *testing.T) {
+	// Arrange
+	mockService := new(MockDiscountLimitService)
+	router := setupDiscountLimitTestRouter(NewDiscountLimitHandler(mockService))
+
+	req, _ := http.NewRequest("PUT", "/discount-limits/marketing", bytes.NewBufferString(`{"max_discount_percent":150}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	mockService.AssertNotCalled(t, "Set", mock.Anything)
+}
+
+func TestDiscountLimitHandler_GetAllAndDelete(t *testing.T) {
+	// Arrange
+	mockService := new(MockDiscountLimitService)
+	router := setupDiscountLimitTestRouter(NewDiscountLimitHandler(mockService))
+
+	mockService.On("GetAll").Return(nil, nil)
+	mockService.On("Delete", "viewer").Return(service.ErrDiscountLimitNotFound)
+
+	listReq, _ := http.NewRequest("GET", "/discount-limits", nil)
+	listW := httptest.NewRecorder()
+	deleteReq, _ := http.NewRequest("DELETE", "/discount-limits/viewer", nil)
+	deleteW := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(listW, listReq)
+	router.ServeHTTP(deleteW, deleteReq)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, listW.Code)
+	assert.Contains(t, listW.Body.String(), `"data":[]`)
+	assert.Equal(t, http.StatusNotFound, deleteW.Code)
+}
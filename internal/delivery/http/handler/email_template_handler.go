@@ -0,0 +1,193 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/shoelfikar/voucher-management-system/internal/delivery/http/request"
+	"github.com/shoelfikar/voucher-management-system/internal/delivery/http/response"
+	"github.com/shoelfikar/voucher-management-system/internal/domain/entity"
+	"github.com/shoelfikar/voucher-management-system/internal/domain/service"
+)
+
+type EmailTemplateHandler struct {
+	templateService service.EmailTemplateService
+}
+
+func NewEmailTemplateHandler(templateService service.EmailTemplateService) *EmailTemplateHandler {
+	return &EmailTemplateHandler{
+		templateService: templateService,
+	}
+}
+
+// GetAll handles GET /api/email-templates
+// @Summary List email templates
+// @Description Get the current wording of every editable email and the variables it may use
+// @Tags Email Templates
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} response.Response{data=[]service.EmailTemplateSummary}
+// @Failure 403 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /api/email-templates [get]
+func (h *EmailTemplateHandler) GetAll(c *gin.Context) {
+	templates, err := h.templateService.GetAll(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.ErrorResponse(err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, response.SuccessResponse(templates))
+}
+
+// GetVersions handles GET /api/email-templates/:key/versions
+// @Summary List email template versions
+// @Description Get the saved versions of an email, newest first
+// @Tags Email Templates
+// @Produce json
+// @Param key path string true "Email key, e.g. invite"
+// @Security BearerAuth
+// @Success 200 {object} response.Response{data=[]entity.EmailTemplate}
+// @Failure 403 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /api/email-templates/{key}/versions [get]
+func (h *EmailTemplateHandler) GetVersions(c *gin.Context) {
+	versions, err := h.templateService.GetVersions(c.Request.Context(), c.Param("key"))
+	if err != nil {
+		c.JSON(emailTemplateErrorStatus(err), response.ErrorResponse(err.Error()))
+		return
+	}
+	if versions == nil {
+		versions = []*entity.EmailTemplate{}
+	}
+
+	c.JSON(http.StatusOK, response.SuccessResponse(versions))
+}
+
+// Save handles PUT /api/email-templates/:key
+// @Summary Save an email template
+// @Description Store a new version of an email's subject and body; earlier versions are kept
+// @Tags Email Templates
+// @Accept json
+// @Produce json
+// @Param key path string true "Email key"
+// @Param request body request.SaveEmailTemplateRequest true "Email template"
+// @Security BearerAuth
+// @Success 200 {object} response.Response{data=entity.EmailTemplate}
+// @Failure 400 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Router /api/email-templates/{key} [put]
+func (h *EmailTemplateHandler) Save(c *gin.Context) {
+	var req request.SaveEmailTemplateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse(err.Error()))
+		return
+	}
+
+	saved, err := h.templateService.Save(c.Request.Context(), &service.SaveEmailTemplateCommand{
+		Key:       c.Param("key"),
+		Subject:   req.Subject,
+		Body:      req.Body,
+		CreatedBy: c.GetString("email"),
+	})
+	if err != nil {
+		c.JSON(emailTemplateErrorStatus(err), response.ErrorResponse(err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, response.SuccessResponseWithMessage("Email template saved successfully", saved))
+}
+
+// Preview handles POST /api/email-templates/:key/preview
+// @Summary Preview an email template
+// @Description Render a draft, or the current wording, with sample values
+// @Tags Email Templates
+// @Accept json
+// @Produce json
+// @Param key path string true "Email key"
+// @Param request body request.PreviewEmailTemplateRequest false "Draft and variables"
+// @Security BearerAuth
+// @Success 200 {object} response.Response{data=service.RenderedEmail}
+// @Failure 400 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Router /api/email-templates/{key}/preview [post]
+func (h *EmailTemplateHandler) Preview(c *gin.Context) {
+	var req request.PreviewEmailTemplateRequest
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, response.ErrorResponse(err.Error()))
+			return
+		}
+	}
+
+	email, err := h.templateService.Preview(c.Request.Context(), previewCommand(c.Param("key"), req))
+	if err != nil {
+		c.JSON(emailTemplateErrorStatus(err), response.ErrorResponse(err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, response.SuccessResponse(email))
+}
+
+// SendTest handles POST /api/email-templates/:key/test
+// @Summary Send a test email
+// @Description Render an email as the preview does and send it, to the signed-in admin unless another address is given
+// @Tags Email Templates
+// @Accept json
+// @Produce json
+// @Param key path string true "Email key"
+// @Param request body request.TestEmailTemplateRequest false "Draft, variables and recipient"
+// @Security BearerAuth
+// @Success 200 {object} response.Response{data=service.RenderedEmail}
+// @Failure 400 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /api/email-templates/{key}/test [post]
+func (h *EmailTemplateHandler) SendTest(c *gin.Context) {
+	var req request.TestEmailTemplateRequest
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, response.ErrorResponse(err.Error()))
+			return
+		}
+	}
+	to := req.To
+	if to == "" {
+		to = c.GetString("email")
+	}
+
+	email, err := h.templateService.SendTest(c.Request.Context(), previewCommand(c.Param("key"), req.PreviewEmailTemplateRequest), to)
+	if err != nil {
+		c.JSON(emailTemplateErrorStatus(err), response.ErrorResponse(err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, response.SuccessResponseWithMessage("Test email sent to "+to, email))
+}
+
+// emailTemplateErrorStatus maps an email template failure to its HTTP status
+func emailTemplateErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, service.ErrEmailTemplateNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, service.ErrInvalidEmailTemplate):
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// previewCommand builds the command rendering an email from a preview request
+func previewCommand(key string, req request.PreviewEmailTemplateRequest) *service.PreviewEmailTemplateCommand {
+	return &service.PreviewEmailTemplateCommand{
+		Key:       key,
+		Subject:   req.Subject,
+		Body:      req.Body,
+		Variables: req.Variables,
+	}
+}
@@ -0,0 +1,173 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/shoelfikar/voucher-management-system/internal/domain/entity"
+	"github.com/shoelfikar/voucher-management-system/internal/domain/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockEmailTemplateService is a mock implementation of EmailTemplateService
+type MockEmailTemplateService struct {
+	mock.Mock
+}
+
+func (m *MockEmailTemplateService) GetAll(ctx context.Context) ([]*service.EmailTemplateSummary, error) {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*service.EmailTemplateSummary), args.Error(1)
+}
+
+func (m *MockEmailTemplateService) GetVersions(ctx context.Context, key string) ([]*entity.EmailTemplate, error) {
+	args := m.Called(key)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.EmailTemplate), args.Error(1)
+}
+
+func (m *MockEmailTemplateService) Save(ctx context.Context, cmd *service.SaveEmailTemplateCommand) (*entity.EmailTemplate, error) {
+	args := m.Called(cmd)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.EmailTemplate), args.Error(1)
+}
+
+func (m *MockEmailTemplateService) Preview(ctx context.Context, cmd *service.PreviewEmailTemplateCommand) (*service.RenderedEmail, error) {
+	args := m.Called(cmd)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*service.RenderedEmail), args.Error(1)
+}
+
+func (m *MockEmailTemplateService) SendTest(ctx context.Context, cmd *service.PreviewEmailTemplateCommand, to string) (*service.RenderedEmail, error) {
+	args := m.Called(cmd, to)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*service.RenderedEmail), args.Error(1)
+}
+
+func (m *MockEmailTemplateService) Render(ctx context.Context, key string, vars map[string]string) (*service.RenderedEmail, error) {
+	args := m.Called(key, vars)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*service.RenderedEmail), args.Error(1)
+}
+
+func setupEmailTemplateTestRouter(templateHandler *EmailTemplateHandler) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		c.Set("email", "admin@example.com")
+		c.Next()
+	})
+	router.GET("/email-templates", templateHandler.GetAll)
+	router.GET("/email-templates/:key/versions", templateHandler.GetVersions)
+	router.PUT("/email-templates/:key", templateHandler.Save)
+	router.POST("/email-templates/:key/preview", templateHandler.Preview)
+	router.POST("/email-templates/:key/test", templateHandler.SendTest)
+	return router
+}
+
+func TestEmailTemplateHandler_Save(t *testing.T) {
+	tests := []struct {
+		name       string
+		key        string
+		body       string
+		serviceErr error
+		wantStatus int
+	}{
+		{name: "saved", key: "invite", body: `{"subject":"Welcome","body":"{{.link}}"}`, wantStatus: http.StatusOK},
+		{name: "missing body", key: "invite", body: `{"subject":"Welcome"}`, wantStatus: http.StatusBadRequest},
+		{name: "unknown key", key: "newsletter", body: `{"subject":"Hi","body":"Hi"}`, serviceErr: service.ErrEmailTemplateNotFound, wantStatus: http.StatusNotFound},
+		{name: "unknown variable", key: "invite", body: `{"subject":"Hi","body":"{{.code}}"}`, serviceErr: fmt.Errorf("%w: map has no entry for key \"code\"", service.ErrInvalidEmailTemplate), wantStatus: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			mockService := new(MockEmailTemplateService)
+			router := setupEmailTemplateTestRouter(NewEmailTemplateHandler(mockService))
+			if tt.serviceErr != nil {
+				mockService.On("Save", mock.AnythingOfType("*service.SaveEmailTemplateCommand")).Return(nil, tt.serviceErr)
+			} else {
+				mockService.On("Save", &service.SaveEmailTemplateCommand{Key: "invite", Subject: "Welcome", Body: "{{.link}}", CreatedBy: "admin@example.com"}).
+					Return(&entity.EmailTemplate{Key: "invite", Version: 2}, nil)
+			}
+
+			req, _ := http.NewRequest("PUT", "/email-templates/"+tt.key, bytes.NewBufferString(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+
+			// Act
+			router.ServeHTTP(w, req)
+
+			// Assert
+			assert.Equal(t, tt.wantStatus, w.Code)
+		})
+	}
+}
+
+func TestEmailTemplateHandler_PreviewWithoutBody(t *testing.T) {
+	// Arrange
+	mockService := new(MockEmailTemplateService)
+	router := setupEmailTemplateTestRouter(NewEmailTemplateHandler(mockService))
+	mockService.On("Preview", &service.PreviewEmailTemplateCommand{Key: "verify_email"}).
+		Return(&service.RenderedEmail{Subject: "Verify your email address", Body: "Confirm"}, nil)
+
+	req, _ := http.NewRequest("POST", "/email-templates/verify_email/preview", nil)
+	w := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"subject":"Verify your email address"`)
+	mockService.AssertExpectations(t)
+}
+
+func TestEmailTemplateHandler_SendTest(t *testing.T) {
+	// Arrange
+	mockService := new(MockEmailTemplateService)
+	router := setupEmailTemplateTestRouter(NewEmailTemplateHandler(mockService))
+	mockService.On("SendTest", &service.PreviewEmailTemplateCommand{Key: "invite"}, "admin@example.com").
+		Return(&service.RenderedEmail{Subject: "[Test] Welcome"}, nil)
+	mockService.On("SendTest", &service.PreviewEmailTemplateCommand{Key: "invite", Body: "Hi {{.role}}"}, "qa@example.com").
+		Return(&service.RenderedEmail{Subject: "[Test] Welcome"}, nil)
+
+	selfReq, _ := http.NewRequest("POST", "/email-templates/invite/test", nil)
+	selfW := httptest.NewRecorder()
+	otherReq, _ := http.NewRequest("POST", "/email-templates/invite/test", bytes.NewBufferString(`{"body":"Hi {{.role}}","to":"qa@example.com"}`))
+	otherReq.Header.Set("Content-Type", "application/json")
+	otherW := httptest.NewRecorder()
+	invalidReq, _ := http.NewRequest("POST", "/email-templates/invite/test", bytes.NewBufferString(`{"to":"not-an-email"}`))
+	invalidReq.Header.Set("Content-Type", "application/json")
+	invalidW := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(selfW, selfReq)
+	router.ServeHTTP(otherW, otherReq)
+	router.ServeHTTP(invalidW, invalidReq)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, selfW.Code)
+	assert.Contains(t, selfW.Body.String(), "Test email sent to admin@example.com")
+	assert.Equal(t, http.StatusOK, otherW.Code)
+	assert.Equal(t, http.StatusBadRequest, invalidW.Code)
+	mockService.AssertExpectations(t)
+}
@@ -0,0 +1,181 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/shoelfikar/voucher-management-system/internal/delivery/http/request"
+	"github.com/shoelfikar/voucher-management-system/internal/delivery/http/response"
+	"github.com/shoelfikar/voucher-management-system/internal/domain/service"
+	"github.com/shoelfikar/voucher-management-system/pkg/utils"
+)
+
+// importRuleSortFields are the columns GET /import-rules may be sorted by
+var importRuleSortFields = []string{"id", "name", "type", "enabled", "created_at", "updated_at"}
+
+type ImportRuleHandler struct {
+	ruleService service.ImportRuleService
+}
+
+func NewImportRuleHandler(ruleService service.ImportRuleService) *ImportRuleHandler {
+	return &ImportRuleHandler{
+		ruleService: ruleService,
+	}
+}
+
+// GetAll handles GET /api/import-rules
+// @Summary Get all import rules
+// @Description List the validation rules applied to voucher imports, enabled or not
+// @Tags Import Rules
+// @Produce json
+// @Param page query int false "Page number"
+// @Param limit query int false "Items per page"
+// @Param search query string false "Search by rule name"
+// @Param sort query string false "Comma-separated field:direction list, e.g. name:asc"
+// @Security BearerAuth
+// @Success 200 {object} response.Response{data=response.ImportRuleListResponse}
+// @Failure 400 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /api/import-rules [get]
+func (h *ImportRuleHandler) GetAll(c *gin.Context) {
+	params, err := utils.ParseListParams(c.Request.URL.Query(), importRuleSortFields, "id:asc")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse(err.Error()))
+		return
+	}
+
+	rules, total, err := h.ruleService.GetAll(c.Request.Context(), params.Page, params.Limit, params.Search, params.Sort)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.ErrorResponse(err.Error()))
+		return
+	}
+
+	c.Header("X-Total-Count", strconv.FormatInt(total, 10))
+	if c.Request.Method == http.MethodHead {
+		c.Status(http.StatusOK)
+		return
+	}
+
+	c.JSON(http.StatusOK, response.SuccessResponse(response.BuildImportRuleListResponse(rules, params.Page, params.Limit, total)))
+}
+
+// GetByID handles GET /api/import-rules/:id
+// @Summary Get import rule by ID
+// @Tags Import Rules
+// @Produce json
+// @Param id path int true "Import rule ID"
+// @Security BearerAuth
+// @Success 200 {object} response.Response{data=entity.ImportRule}
+// @Failure 400 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Router /api/import-rules/{id} [get]
+func (h *ImportRuleHandler) GetByID(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse("Invalid import rule ID"))
+		return
+	}
+
+	rule, err := h.ruleService.GetByID(c.Request.Context(), uint(id))
+	if err != nil {
+		c.JSON(http.StatusNotFound, response.ErrorResponse(err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, response.SuccessResponse(rule))
+}
+
+// Create handles POST /api/import-rules
+// @Summary Create an import rule
+// @Description Add a check every imported voucher must pass, e.g. a code prefix or a maximum discount
+// @Tags Import Rules
+// @Accept json
+// @Produce json
+// @Param request body request.ImportRuleRequest true "Rule definition"
+// @Security BearerAuth
+// @Success 201 {object} response.Response{data=entity.ImportRule}
+// @Failure 400 {object} response.Response
+// @Router /api/import-rules [post]
+func (h *ImportRuleHandler) Create(c *gin.Context) {
+	var req request.ImportRuleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse(err.Error()))
+		return
+	}
+
+	cmd := req.ToCommand()
+	cmd.CreatedBy = c.GetString("email")
+
+	rule, err := h.ruleService.Create(c.Request.Context(), cmd)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse(err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusCreated, response.SuccessResponseWithMessage("Import rule created successfully", rule))
+}
+
+// Update handles PUT /api/import-rules/:id
+// @Summary Update an import rule
+// @Tags Import Rules
+// @Accept json
+// @Produce json
+// @Param id path int true "Import rule ID"
+// @Param request body request.ImportRuleRequest true "Rule definition"
+// @Security BearerAuth
+// @Success 200 {object} response.Response{data=entity.ImportRule}
+// @Failure 400 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Router /api/import-rules/{id} [put]
+func (h *ImportRuleHandler) Update(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse("Invalid import rule ID"))
+		return
+	}
+
+	var req request.ImportRuleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse(err.Error()))
+		return
+	}
+
+	rule, err := h.ruleService.Update(c.Request.Context(), uint(id), req.ToCommand())
+	if err != nil {
+		status := http.StatusBadRequest
+		if errors.Is(err, service.ErrImportRuleNotFound) {
+			status = http.StatusNotFound
+		}
+		c.JSON(status, response.ErrorResponse(err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, response.SuccessResponseWithMessage("Import rule updated successfully", rule))
+}
+
+// Delete handles DELETE /api/import-rules/:id
+// @Summary Delete an import rule
+// @Tags Import Rules
+// @Produce json
+// @Param id path int true "Import rule ID"
+// @Security BearerAuth
+// @Success 200 {object} response.Response
+// @Failure 400 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Router /api/import-rules/{id} [delete]
+func (h *ImportRuleHandler) Delete(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse("Invalid import rule ID"))
+		return
+	}
+
+	if err := h.ruleService.Delete(c.Request.Context(), uint(id)); err != nil {
+		c.JSON(http.StatusNotFound, response.ErrorResponse(err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, response.SuccessResponseWithMessage("Import rule deleted successfully", nil))
+}
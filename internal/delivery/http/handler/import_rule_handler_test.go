@@ -0,0 +1,184 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/shoelfikar/voucher-management-system/internal/delivery/http/request"
+	"github.com/shoelfikar/voucher-management-system/internal/domain/entity"
+	"github.com/shoelfikar/voucher-management-system/internal/domain/service"
+	"github.com/shoelfikar/voucher-management-system/pkg/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockImportRuleService is a mock implementation of ImportRuleService
+type MockImportRuleService struct {
+	mock.Mock
+}
+
+func (m *MockImportRuleService) GetAll(ctx context.Context, page, limit int, search string, sort []utils.SortField) ([]*entity.ImportRule, int64, error) {
+	args := m.Called(page, limit, search, sort)
+	if args.Get(0) == nil {
+		return nil, args.Get(1).(int64), args.Error(2)
+	}
+	return args.Get(0).([]*entity.ImportRule), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockImportRuleService) GetByID(ctx context.Context, id uint) (*entity.ImportRule, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.ImportRule), args.Error(1)
+}
+
+func (m *MockImportRuleService) Create(ctx context.Context, cmd *service.ImportRuleCommand) (*entity.ImportRule, error) {
+	args := m.Called(cmd)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.ImportRule), args.Error(1)
+}
+
+func (m *MockImportRuleService) Update(ctx context.Context, id uint, cmd *service.ImportRuleCommand) (*entity.ImportRule, error) {
+	args := m.Called(id, cmd)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.ImportRule), args.Error(1)
+}
+
+func (m *MockImportRuleService) Delete(ctx context.Context, id uint) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func TestImportRuleHandler_GetAll_Paginated(t *testing.T) {
+	// Arrange
+	mockRuleService := new(MockImportRuleService)
+	ruleHandler := NewImportRuleHandler(mockRuleService)
+	router := setupAuthTestRouter()
+	router.GET("/import-rules", ruleHandler.GetAll)
+
+	rules := []*entity.ImportRule{{ID: 3, Name: "Cap", Type: entity.ImportRuleMaxDiscount, Value: "40", Enabled: true}}
+	mockRuleService.On("GetAll", 2, 2, "cap", []utils.SortField{{Field: "name"}}).Return(rules, int64(3), nil)
+
+	req, _ := http.NewRequest("GET", "/import-rules?page=2&limit=2&search=cap&sort=name:asc", nil)
+	w := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "3", w.Header().Get("X-Total-Count"))
+
+	var body struct {
+		Data struct {
+			ImportRules []entity.ImportRule `json:"import_rules"`
+			Pagination  struct {
+				Page       int   `json:"page"`
+				Total      int64 `json:"total"`
+				TotalPages int   `json:"total_pages"`
+			} `json:"pagination"`
+		} `json:"data"`
+	}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+	assert.Len(t, body.Data.ImportRules, 1)
+	assert.Equal(t, 2, body.Data.Pagination.Page)
+	assert.Equal(t, int64(3), body.Data.Pagination.Total)
+	assert.Equal(t, 2, body.Data.Pagination.TotalPages)
+	mockRuleService.AssertExpectations(t)
+}
+
+func TestImportRuleHandler_GetAll_InvalidSortField(t *testing.T) {
+	// Arrange
+	mockRuleService := new(MockImportRuleService)
+	ruleHandler := NewImportRuleHandler(mockRuleService)
+	router := setupAuthTestRouter()
+	router.GET("/import-rules", ruleHandler.GetAll)
+
+	req, _ := http.NewRequest("GET", "/import-rules?sort=value:asc", nil)
+	w := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	mockRuleService.AssertNotCalled(t, "GetAll", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestImportRuleHandler_Create_EnabledByDefault(t *testing.T) {
+	// Arrange
+	mockRuleService := new(MockImportRuleService)
+	ruleHandler := NewImportRuleHandler(mockRuleService)
+	router := setupAuthTestRouter()
+	router.POST("/import-rules", func(c *gin.Context) {
+		c.Set("email", "admin@example.com")
+		ruleHandler.Create(c)
+	})
+
+	mockRuleService.On("Create", mock.MatchedBy(func(cmd *service.ImportRuleCommand) bool {
+		return cmd.Enabled && cmd.CreatedBy == "admin@example.com"
+	})).Return(&entity.ImportRule{ID: 1, Name: "Cap", Type: entity.ImportRuleMaxDiscount, Value: "40", Enabled: true}, nil)
+
+	body, _ := json.Marshal(request.ImportRuleRequest{Name: "Cap", Type: entity.ImportRuleMaxDiscount, Value: "40"})
+	req, _ := http.NewRequest("POST", "/import-rules", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusCreated, w.Code)
+	mockRuleService.AssertExpectations(t)
+}
+
+func TestImportRuleHandler_Create_UnknownType(t *testing.T) {
+	// Arrange
+	mockRuleService := new(MockImportRuleService)
+	ruleHandler := NewImportRuleHandler(mockRuleService)
+	router := setupAuthTestRouter()
+	router.POST("/import-rules", ruleHandler.Create)
+
+	body, _ := json.Marshal(request.ImportRuleRequest{Name: "Suffix", Type: "code_suffix", Value: "-X"})
+	req, _ := http.NewRequest("POST", "/import-rules", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	mockRuleService.AssertNotCalled(t, "Create", mock.Anything)
+}
+
+func TestImportRuleHandler_Update_NotFound(t *testing.T) {
+	// Arrange
+	mockRuleService := new(MockImportRuleService)
+	ruleHandler := NewImportRuleHandler(mockRuleService)
+	router := setupAuthTestRouter()
+	router.PUT("/import-rules/:id", ruleHandler.Update)
+
+	mockRuleService.On("Update", uint(99), mock.Anything).Return(nil, service.ErrImportRuleNotFound)
+
+	body, _ := json.Marshal(request.ImportRuleRequest{Name: "Cap", Type: entity.ImportRuleMaxDiscount, Value: "40"})
+	req, _ := http.NewRequest("PUT", "/import-rules/99", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
@@ -0,0 +1,277 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/shoelfikar/voucher-management-system/internal/delivery/http/middleware"
+	"github.com/shoelfikar/voucher-management-system/internal/delivery/http/request"
+	"github.com/shoelfikar/voucher-management-system/internal/delivery/http/response"
+	"github.com/shoelfikar/voucher-management-system/internal/domain/service"
+	"github.com/shoelfikar/voucher-management-system/pkg/utils"
+)
+
+// partnerSortFields are the columns GET /partners may be sorted by
+var partnerSortFields = []string{"id", "name", "quota", "issued_count", "created_at", "updated_at"}
+
+type PartnerHandler struct {
+	partnerService service.PartnerService
+}
+
+func NewPartnerHandler(partnerService service.PartnerService) *PartnerHandler {
+	return &PartnerHandler{
+		partnerService: partnerService,
+	}
+}
+
+// GetAll handles GET /api/partners
+// @Summary Get all partners
+// @Description List the resellers allowed to issue vouchers with an API key
+// @Tags Partners
+// @Produce json
+// @Param page query int false "Page number"
+// @Param limit query int false "Items per page"
+// @Param search query string false "Search by partner name"
+// @Param sort query string false "Comma-separated field:direction list, e.g. name:asc"
+// @Security BearerAuth
+// @Success 200 {object} response.Response{data=response.PartnerListResponse}
+// @Failure 400 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /api/partners [get]
+func (h *PartnerHandler) GetAll(c *gin.Context) {
+	params, err := utils.ParseListParams(c.Request.URL.Query(), partnerSortFields, "id:asc")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse(err.Error()))
+		return
+	}
+
+	partners, total, err := h.partnerService.GetAll(c.Request.Context(), params.Page, params.Limit, params.Search, params.Sort)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.ErrorResponse(err.Error()))
+		return
+	}
+
+	c.Header("X-Total-Count", strconv.FormatInt(total, 10))
+	c.JSON(http.StatusOK, response.SuccessResponse(response.BuildPartnerListResponse(partners, params.Page, params.Limit, total)))
+}
+
+// GetByID handles GET /api/partners/:id
+// @Summary Get partner by ID
+// @Description Get a partner with its quota and the number of vouchers it issued
+// @Tags Partners
+// @Produce json
+// @Param id path int true "Partner ID"
+// @Security BearerAuth
+// @Success 200 {object} response.Response{data=entity.Partner}
+// @Failure 400 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Router /api/partners/{id} [get]
+func (h *PartnerHandler) GetByID(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse("Invalid partner ID"))
+		return
+	}
+
+	partner, err := h.partnerService.GetByID(c.Request.Context(), uint(id))
+	if err != nil {
+		c.JSON(http.StatusNotFound, response.ErrorResponse(err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, response.SuccessResponse(partner))
+}
+
+// Create handles POST /api/partners
+// @Summary Create a partner
+// @Description Add a partner and generate its API key; the key is only shown in this response
+// @Tags Partners
+// @Accept json
+// @Produce json
+// @Param request body request.PartnerRequest true "Partner details"
+// @Security BearerAuth
+// @Success 201 {object} response.Response{data=service.PartnerCredentials}
+// @Failure 400 {object} response.Response
+// @Failure 409 {object} response.Response
+// @Router /api/partners [post]
+func (h *PartnerHandler) Create(c *gin.Context) {
+	var req request.PartnerRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse(err.Error()))
+		return
+	}
+
+	cmd := req.ToCommand()
+	cmd.CreatedBy = c.GetString("email")
+
+	credentials, err := h.partnerService.Create(c.Request.Context(), cmd)
+	if err != nil {
+		status := http.StatusBadRequest
+		if errors.Is(err, service.ErrDuplicatePartnerName) {
+			status = http.StatusConflict
+		}
+		c.JSON(status, response.ErrorResponse(err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusCreated, response.SuccessResponseWithMessage("Partner created successfully", credentials))
+}
+
+// Update handles PUT /api/partners/:id
+// @Summary Update a partner
+// @Description Change a partner's name, quota or active flag
+// @Tags Partners
+// @Accept json
+// @Produce json
+// @Param id path int true "Partner ID"
+// @Param request body request.PartnerRequest true "Partner details"
+// @Security BearerAuth
+// @Success 200 {object} response.Response{data=entity.Partner}
+// @Failure 400 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 409 {object} response.Response
+// @Router /api/partners/{id} [put]
+func (h *PartnerHandler) Update(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse("Invalid partner ID"))
+		return
+	}
+
+	var req request.PartnerRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse(err.Error()))
+		return
+	}
+
+	partner, err := h.partnerService.Update(c.Request.Context(), uint(id), req.ToCommand())
+	if err != nil {
+		status := http.StatusBadRequest
+		switch {
+		case errors.Is(err, service.ErrPartnerNotFound):
+			status = http.StatusNotFound
+		case errors.Is(err, service.ErrDuplicatePartnerName):
+			status = http.StatusConflict
+		}
+		c.JSON(status, response.ErrorResponse(err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, response.SuccessResponseWithMessage("Partner updated successfully", partner))
+}
+
+// RotateKey handles POST /api/partners/:id/rotate-key
+// @Summary Rotate a partner's API key
+// @Description Generate a new API key for a partner; the previous key stops working immediately
+// @Tags Partners
+// @Produce json
+// @Param id path int true "Partner ID"
+// @Security BearerAuth
+// @Success 200 {object} response.Response{data=service.PartnerCredentials}
+// @Failure 400 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Router /api/partners/{id}/rotate-key [post]
+func (h *PartnerHandler) RotateKey(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse("Invalid partner ID"))
+		return
+	}
+
+	credentials, err := h.partnerService.RotateKey(c.Request.Context(), uint(id))
+	if err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, service.ErrPartnerNotFound) {
+			status = http.StatusNotFound
+		}
+		c.JSON(status, response.ErrorResponse(err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, response.SuccessResponseWithMessage("Partner API key rotated successfully", credentials))
+}
+
+// GetVouchers handles GET /api/partner/vouchers
+// @Summary List the partner's vouchers
+// @Description List the vouchers issued by the partner owning the API key
+// @Tags Partner API
+// @Produce json
+// @Param page query int false "Page number"
+// @Param limit query int false "Items per page"
+// @Param search query string false "Search by voucher code"
+// @Param sort query string false "Comma-separated field:direction list, e.g. expiry_date:asc"
+// @Security PartnerAPIKey
+// @Success 200 {object} response.Response{data=response.VoucherListResponse}
+// @Failure 400 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Router /api/partner/vouchers [get]
+func (h *PartnerHandler) GetVouchers(c *gin.Context) {
+	partner, ok := middleware.GetPartner(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, response.ErrorResponse("Missing API key"))
+		return
+	}
+
+	params, err := utils.ParseListParams(c.Request.URL.Query(), voucherSortFields, "created_at:desc")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse(err.Error()))
+		return
+	}
+
+	vouchers, total, err := h.partnerService.GetVouchers(c.Request.Context(), partner.ID, params.Page, params.Limit, params.Search, params.Sort)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.ErrorResponse(err.Error()))
+		return
+	}
+
+	c.Header("X-Total-Count", strconv.FormatInt(total, 10))
+	c.JSON(http.StatusOK, response.SuccessResponse(response.BuildVoucherListResponse(vouchers, params.Page, params.Limit, total)))
+}
+
+// IssueVoucher handles POST /api/partner/vouchers
+// @Summary Issue a voucher as a partner
+// @Description Create a voucher owned by the partner owning the API key, counted against its quota
+// @Tags Partner API
+// @Accept json
+// @Produce json
+// @Param request body request.CreateVoucherRequest true "Voucher details"
+// @Security PartnerAPIKey
+// @Success 201 {object} response.Response{data=response.VoucherResponse}
+// @Failure 400 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Failure 409 {object} response.Response
+// @Router /api/partner/vouchers [post]
+func (h *PartnerHandler) IssueVoucher(c *gin.Context) {
+	partner, ok := middleware.GetPartner(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, response.ErrorResponse("Missing API key"))
+		return
+	}
+
+	var req request.CreateVoucherRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse(err.Error()))
+		return
+	}
+
+	cmd := req.ToCommand()
+	cmd.CreatedBy = "partner:" + partner.Name
+
+	voucher, err := h.partnerService.IssueVoucher(c.Request.Context(), partner.ID, cmd)
+	if err != nil {
+		status := http.StatusBadRequest
+		switch {
+		case errors.Is(err, service.ErrPartnerQuotaExceeded):
+			status = http.StatusForbidden
+		case errors.Is(err, service.ErrDuplicateExternalID):
+			status = http.StatusConflict
+		}
+		c.JSON(status, response.ErrorResponse(err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusCreated, response.SuccessResponseWithMessage("Voucher created successfully", response.ToVoucherResponse(voucher)))
+}
@@ -0,0 +1,274 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/shoelfikar/voucher-management-system/internal/delivery/http/middleware"
+	"github.com/shoelfikar/voucher-management-system/internal/delivery/http/request"
+	"github.com/shoelfikar/voucher-management-system/internal/domain/entity"
+	"github.com/shoelfikar/voucher-management-system/internal/domain/service"
+	"github.com/shoelfikar/voucher-management-system/pkg/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockPartnerService is a mock implementation of PartnerService
+type MockPartnerService struct {
+	mock.Mock
+}
+
+func (m *MockPartnerService) GetAll(ctx context.Context, page, limit int, search string, sort []utils.SortField) ([]*entity.Partner, int64, error) {
+	args := m.Called(page, limit, search, sort)
+	if args.Get(0) == nil {
+		return nil, args.Get(1).(int64), args.Error(2)
+	}
+	return args.Get(0).([]*entity.Partner), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockPartnerService) GetByID(ctx context.Context, id uint) (*entity.Partner, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Partner), args.Error(1)
+}
+
+func (m *MockPartnerService) Create(ctx context.Context, cmd *service.PartnerCommand) (*service.PartnerCredentials, error) {
+	args := m.Called(cmd)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*service.PartnerCredentials), args.Error(1)
+}
+
+func (m *MockPartnerService) Update(ctx context.Context, id uint, cmd *service.PartnerCommand) (*entity.Partner, error) {
+	args := m.Called(id, cmd)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Partner), args.Error(1)
+}
+
+func (m *MockPartnerService) RotateKey(ctx context.Context, id uint) (*service.PartnerCredentials, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*service.PartnerCredentials), args.Error(1)
+}
+
+func (m *MockPartnerService) Authenticate(ctx context.Context, apiKey string) (*entity.Partner, error) {
+	args := m.Called(apiKey)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Partner), args.Error(1)
+}
+
+func (m *MockPartnerService) IssueVoucher(ctx context.Context, partnerID uint, cmd *service.CreateVoucherCommand) (*entity.Voucher, error) {
+	args := m.Called(partnerID, cmd)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Voucher), args.Error(1)
+}
+
+func (m *MockPartnerService) GetVouchers(ctx context.Context, partnerID uint, page, limit int, search string, sort []utils.SortField) ([]*entity.Voucher, int64, error) {
+	args := m.Called(partnerID, page, limit, search, sort)
+	if args.Get(0) == nil {
+		return nil, args.Get(1).(int64), args.Error(2)
+	}
+	return args.Get(0).([]*entity.Voucher), args.Get(1).(int64), args.Error(2)
+}
+
+// setupPartnerAPITestRouter mounts the partner routes behind the partner API key middleware
+func setupPartnerAPITestRouter(mockPartnerService *MockPartnerService) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	partnerHandler := NewPartnerHandler(mockPartnerService)
+	partner := router.Group("/partner", middleware.PartnerAuthMiddleware(mockPartnerService))
+	partner.GET("/vouchers", partnerHandler.GetVouchers)
+	partner.POST("/vouchers", partnerHandler.IssueVoucher)
+	return router
+}
+
+func TestPartnerHandler_Create_ReturnsKey(t *testing.T) {
+	// Arrange
+	mockPartnerService := new(MockPartnerService)
+	partnerHandler := NewPartnerHandler(mockPartnerService)
+	router := setupAuthTestRouter()
+	router.POST("/partners", partnerHandler.Create)
+
+	quota := int64(100)
+	expectedCmd := &service.PartnerCommand{Name: "Acme", Quota: 100, Active: true}
+	created := &service.PartnerCredentials{
+		Partner: &entity.Partner{ID: 1, Name: "Acme", APIKeyHash: "secret-hash", KeyPrefix: "vpk_12345678", Quota: 100, Active: true},
+		APIKey:  "vpk_1234567890",
+	}
+	mockPartnerService.On("Create", expectedCmd).Return(created, nil)
+
+	reqBody, _ := json.Marshal(request.PartnerRequest{Name: "Acme", Quota: &quota})
+	req, _ := http.NewRequest("POST", "/partners", bytes.NewBuffer(reqBody))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.Contains(t, w.Body.String(), `"api_key":"vpk_1234567890"`)
+	assert.NotContains(t, w.Body.String(), "secret-hash")
+	mockPartnerService.AssertExpectations(t)
+}
+
+func TestPartnerHandler_Create_MissingQuota(t *testing.T) {
+	// Arrange
+	mockPartnerService := new(MockPartnerService)
+	partnerHandler := NewPartnerHandler(mockPartnerService)
+	router := setupAuthTestRouter()
+	router.POST("/partners", partnerHandler.Create)
+
+	req, _ := http.NewRequest("POST", "/partners", bytes.NewBufferString(`{"name":"Acme"}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	mockPartnerService.AssertNotCalled(t, "Create", mock.Anything)
+}
+
+func TestPartnerHandler_RotateKey_NotFound(t *testing.T) {
+	// Arrange
+	mockPartnerService := new(MockPartnerService)
+	partnerHandler := NewPartnerHandler(mockPartnerService)
+	router := setupAuthTestRouter()
+	router.POST("/partners/:id/rotate-key", partnerHandler.RotateKey)
+
+	mockPartnerService.On("RotateKey", uint(9)).Return(nil, service.ErrPartnerNotFound)
+
+	req, _ := http.NewRequest("POST", "/partners/9/rotate-key", nil)
+	w := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestPartnerHandler_GetVouchers_ScopedToPartner(t *testing.T) {
+	// Arrange
+	mockPartnerService := new(MockPartnerService)
+	router := setupPartnerAPITestRouter(mockPartnerService)
+
+	partnerID := uint(7)
+	mockPartnerService.On("Authenticate", "vpk_acme").Return(&entity.Partner{ID: partnerID, Name: "Acme", Active: true}, nil)
+	vouchers := []*entity.Voucher{{ID: 1, VoucherCode: "ACME10", DiscountPercent: 10, ExpiryDate: time.Now(), PartnerID: &partnerID}}
+	mockPartnerService.On("GetVouchers", partnerID, 1, 10, "", []utils.SortField{{Field: "created_at", Desc: true}}).Return(vouchers, int64(1), nil)
+
+	req, _ := http.NewRequest("GET", "/partner/vouchers", nil)
+	req.Header.Set(middleware.PartnerAPIKeyHeader, "vpk_acme")
+	w := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"partner_id":7`)
+	mockPartnerService.AssertExpectations(t)
+}
+
+func TestPartnerHandler_IssueVoucher(t *testing.T) {
+	// Arrange
+	mockPartnerService := new(MockPartnerService)
+	router := setupPartnerAPITestRouter(mockPartnerService)
+
+	partnerID := uint(7)
+	mockPartnerService.On("Authenticate", "vpk_acme").Return(&entity.Partner{ID: partnerID, Name: "Acme", Active: true}, nil)
+	mockPartnerService.On("IssueVoucher", partnerID, mock.MatchedBy(func(cmd *service.CreateVoucherCommand) bool {
+		return cmd.VoucherCode == "ACME10" && cmd.CreatedBy == "partner:Acme"
+	})).Return(&entity.Voucher{ID: 1, VoucherCode: "ACME10", DiscountPercent: 10, ExpiryDate: time.Now(), PartnerID: &partnerID}, nil)
+
+	reqBody, _ := json.Marshal(request.CreateVoucherRequest{VoucherCode: "ACME10", DiscountPercent: 10, ExpiryDate: "2099-01-01"})
+	req, _ := http.NewRequest("POST", "/partner/vouchers", bytes.NewBuffer(reqBody))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(middleware.PartnerAPIKeyHeader, "vpk_acme")
+	w := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusCreated, w.Code)
+	mockPartnerService.AssertExpectations(t)
+}
+
+func TestPartnerHandler_IssueVoucher_QuotaExceeded(t *testing.T) {
+	// Arrange
+	mockPartnerService := new(MockPartnerService)
+	router := setupPartnerAPITestRouter(mockPartnerService)
+
+	mockPartnerService.On("Authenticate", "vpk_acme").Return(&entity.Partner{ID: 7, Name: "Acme", Active: true}, nil)
+	mockPartnerService.On("IssueVoucher", uint(7), mock.Anything).Return(nil, service.ErrPartnerQuotaExceeded)
+
+	reqBody, _ := json.Marshal(request.CreateVoucherRequest{VoucherCode: "ACME10", DiscountPercent: 10, ExpiryDate: "2099-01-01"})
+	req, _ := http.NewRequest("POST", "/partner/vouchers", bytes.NewBuffer(reqBody))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(middleware.PartnerAPIKeyHeader, "vpk_acme")
+	w := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusForbidden, w.Code)
+}
+
+func TestPartnerHandler_PartnerRoutes_RejectBadKeys(t *testing.T) {
+	testCases := []struct {
+		name       string
+		apiKey     string
+		authErr    error
+		wantStatus int
+	}{
+		{"missing key", "", nil, http.StatusUnauthorized},
+		{"invalid key", "vpk_wrong", service.ErrInvalidAPIKey, http.StatusUnauthorized},
+		{"inactive partner", "vpk_dormant", service.ErrPartnerInactive, http.StatusForbidden},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			mockPartnerService := new(MockPartnerService)
+			router := setupPartnerAPITestRouter(mockPartnerService)
+			if tc.authErr != nil {
+				mockPartnerService.On("Authenticate", tc.apiKey).Return(nil, tc.authErr)
+			}
+
+			req, _ := http.NewRequest("GET", "/partner/vouchers", nil)
+			if tc.apiKey != "" {
+				req.Header.Set(middleware.PartnerAPIKeyHeader, tc.apiKey)
+			}
+			w := httptest.NewRecorder()
+
+			// Act
+			router.ServeHTTP(w, req)
+
+			// Assert
+			assert.Equal(t, tc.wantStatus, w.Code)
+			mockPartnerService.AssertNotCalled(t, "GetVouchers", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
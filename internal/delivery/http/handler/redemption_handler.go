@@ -0,0 +1,64 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/shoelfikar/voucher-management-system/internal/delivery/http/request"
+	"github.com/shoelfikar/voucher-management-system/internal/delivery/http/response"
+	"github.com/shoelfikar/voucher-management-system/internal/domain/service"
+)
+
+type RedemptionHandler struct {
+	redemptionService service.RedemptionService
+}
+
+func NewRedemptionHandler(redemptionService service.RedemptionService) *RedemptionHandler {
+	return &RedemptionHandler{
+		redemptionService: redemptionService,
+	}
+}
+
+// Redeem handles POST /api/vouchers/:code/redeem
+// @Summary Redeem a voucher
+// @Description Validate a voucher against a purchase and record its use by the customer, returning the discount to apply, within the voucher's total and per-customer redemption limits.
+// @Tags Vouchers
+// @Accept json
+// @Produce json
+// @Param code path string true "Voucher code"
+// @Param request body request.RedeemVoucherRequest true "Order reference and purchase context"
+// @Security BearerAuth
+// @Success 201 {object} response.Response{data=response.RedemptionResponse}
+// @Failure 400 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 409 {object} response.Response
+// @Router /api/vouchers/{code}/redeem [post]
+func (h *RedemptionHandler) Redeem(c *gin.Context) {
+	var req request.RedeemVoucherRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse("Invalid request: "+err.Error()))
+		return
+	}
+
+	// The route shares its wildcard with the /vouchers/:id routes, so the code arrives as "id"
+	redemption, err := h.redemptionService.Redeem(c.Request.Context(), &service.RedeemVoucherCommand{
+		VoucherCode: c.Param("id"),
+		OrderID:     req.OrderID,
+		Context:     req.Context,
+		RedeemedBy:  c.GetString("email"),
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrVoucherNotFound):
+			c.JSON(http.StatusNotFound, response.ErrorResponse(err.Error()))
+		case errors.Is(err, service.ErrVoucherAlreadyRedeemed), errors.Is(err, service.ErrVoucherFullyRedeemed), errors.Is(err, service.ErrVoucherNotRedeemable):
+			c.JSON(http.StatusConflict, response.ErrorResponse(err.Error()))
+		default:
+			c.JSON(http.StatusBadRequest, response.ErrorResponse(err.Error()))
+		}
+		return
+	}
+
+	c.JSON(http.StatusCreated, response.SuccessResponseWithMessage("Voucher redeemed successfully", response.ToRedemptionResponse(redemption)))
+}
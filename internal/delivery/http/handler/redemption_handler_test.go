@@ -0,0 +1,115 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/shoelfikar/voucher-management-system/internal/domain/entity"
+	"github.com/shoelfikar/voucher-management-system/internal/domain/service"
+	"github.com/shoelfikar/voucher-management-system/pkg/rules"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockRedemptionService is a mock implementation of RedemptionService
+type MockRedemptionService struct {
+	mock.Mock
+}
+
+func (m *MockRedemptionService) Redeem(ctx context.Context, cmd *service.RedeemVoucherCommand) (*entity.Redemption, error) {
+	args := m.Called(cmd)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Redemption), args.Error(1)
+}
+
+func setupRedemptionTestRouter(redemptionHandler *RedemptionHandler) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.POST("/vouchers/:id/redeem", redemptionHandler.Redeem)
+	return router
+}
+
+func TestRedemptionHandler_Redeem(t *testing.T) {
+	// Arrange
+	mockService := new(MockRedemptionService)
+	router := setupRedemptionTestRouter(NewRedemptionHandler(mockService))
+
+	mockService.On("Redeem", &service.RedeemVoucherCommand{
+		VoucherCode: "SUMMER24",
+		OrderID:     "order-9",
+		Context:     rules.Context{CustomerID: "c-1", ItemCount: 2},
+	}).Return(&entity.Redemption{ID: 1, VoucherID: 3, CustomerID: 5, Customer: &entity.Customer{ID: 5, ExternalID: "c-1"}, OrderID: "order-9", DiscountPercent: 15}, nil)
+
+	body := `{"order_id":"order-9","context":{"customer_id":"c-1","item_count":2}}`
+	req, _ := http.NewRequest("POST", "/vouchers/SUMMER24/redeem", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.Contains(t, w.Body.String(), `"discount_percent":15`)
+	mockService.AssertExpectations(t)
+}
+
+func TestRedemptionHandler_Redeem_FixedAmount(t *testing.T) {
+	// Arrange
+	mockService := new(MockRedemptionService)
+	router := setupRedemptionTestRouter(NewRedemptionHandler(mockService))
+
+	mockService.On("Redeem", mock.Anything).Return(&entity.Redemption{ID: 1, VoucherID: 3, CustomerID: 5, DiscountType: entity.DiscountTypeFixedAmount, DiscountAmount: 1050, Currency: "USD"}, nil)
+
+	body := `{"context":{"customer_id":"c-1"}}`
+	req, _ := http.NewRequest("POST", "/vouchers/TENOFF/redeem", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.Contains(t, w.Body.String(), `"discount_amount":10.5,"currency":"USD"`)
+}
+
+func TestRedemptionHandler_Redeem_Errors(t *testing.T) {
+	tests := []struct {
+		name           string
+		err            error
+		expectedStatus int
+	}{
+		{"unknown voucher", service.ErrVoucherNotFound, http.StatusNotFound},
+		{"already redeemed", service.ErrVoucherAlreadyRedeemed, http.StatusConflict},
+		{"fully redeemed", service.ErrVoucherFullyRedeemed, http.StatusConflict},
+		{"not applicable", fmt.Errorf("%w: voucher has expired", service.ErrVoucherNotRedeemable), http.StatusConflict},
+		{"missing customer", fmt.Errorf("customer id is required"), http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			mockService := new(MockRedemptionService)
+			router := setupRedemptionTestRouter(NewRedemptionHandler(mockService))
+			mockService.On("Redeem", mock.Anything).Return(nil, tt.err)
+
+			req, _ := http.NewRequest("POST", "/vouchers/SUMMER24/redeem", bytes.NewBufferString(`{"context":{}}`))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+
+			// Act
+			router.ServeHTTP(w, req)
+
+			// Assert
+			assert.Equal(t, tt.expectedStatus, w.Code)
+		})
+	}
+}
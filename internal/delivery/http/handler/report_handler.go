@@ -0,0 +1,86 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/shoelfikar/voucher-management-system/internal/delivery/http/response"
+	"github.com/shoelfikar/voucher-management-system/internal/domain/service"
+)
+
+// defaultReportDays is the period a report covers when none is given, ending today
+const defaultReportDays = 30
+
+type ReportHandler struct {
+	reportService service.ReportService
+	now           func() time.Time
+}
+
+func NewReportHandler(reportService service.ReportService) *ReportHandler {
+	return &ReportHandler{
+		reportService: reportService,
+		now:           time.Now,
+	}
+}
+
+// SummaryPDF handles GET /api/reports/summary.pdf
+// @Summary Download a summary report
+// @Description Render a one-page PDF of voucher activity in a period: vouchers created, active vouchers, redemptions and redeeming customers, the five most redeemed vouchers and a chart of redemptions per day, or per week for periods over 62 days. from and to are UTC dates and both are included; they default to the last 30 days and may be at most 366 days apart.
+// @Tags Reports
+// @Produce application/pdf
+// @Param from query string false "First day, YYYY-MM-DD"
+// @Param to query string false "Last day, YYYY-MM-DD"
+// @Security BearerAuth
+// @Success 200 {file} file
+// @Failure 400 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /api/reports/summary.pdf [get]
+func (h *ReportHandler) SummaryPDF(c *gin.Context) {
+	from, to, err := h.reportPeriod(c.Query("from"), c.Query("to"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse(err.Error()))
+		return
+	}
+
+	data, err := h.reportService.RenderSummaryPDF(c.Request.Context(), from, to)
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidReportPeriod) {
+			c.JSON(http.StatusBadRequest, response.ErrorResponse(err.Error()))
+			return
+		}
+		c.JSON(http.StatusInternalServerError, response.ErrorResponse("Failed to render report"))
+		return
+	}
+
+	filename := "summary-" + from.Format("2006-01-02") + "-to-" + to.AddDate(0, 0, -1).Format("2006-01-02") + ".pdf"
+	c.Header("Content-Disposition", "attachment; filename="+filename)
+	c.Data(http.StatusOK, "application/pdf", data)
+}
+
+// reportPeriod turns the inclusive from and to dates of a request into the
+// start of from and the start of the day after to; a missing to is today and
+// a missing from is the default number of days before to
+func (h *ReportHandler) reportPeriod(fromParam, toParam string) (time.Time, time.Time, error) {
+	today := h.now().UTC()
+	last := time.Date(today.Year(), today.Month(), today.Day(), 0, 0, 0, 0, time.UTC)
+	if toParam != "" {
+		parsed, err := time.Parse("2006-01-02", toParam)
+		if err != nil {
+			return time.Time{}, time.Time{}, errors.New("to must be a date in YYYY-MM-DD format")
+		}
+		last = parsed
+	}
+
+	first := last.AddDate(0, 0, 1-defaultReportDays)
+	if fromParam != "" {
+		parsed, err := time.Parse("2006-01-02", fromParam)
+		if err != nil {
+			return time.Time{}, time.Time{}, errors.New("from must be a date in YYYY-MM-DD format")
+		}
+		first = parsed
+	}
+
+	return first, last.AddDate(0, 0, 1), nil
+}
@@ -0,0 +1,89 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/shoelfikar/voucher-management-system/internal/domain/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockReportService is a mock implementation of ReportService; the context
+// is not matched on
+type MockReportService struct {
+	mock.Mock
+}
+
+func (m *MockReportService) Summary(ctx context.Context, from, to time.Time) (*service.ReportSummary, error) {
+	args := m.Called(from, to)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*service.ReportSummary), args.Error(1)
+}
+
+func (m *MockReportService) RenderSummaryPDF(ctx context.Context, from, to time.Time) ([]byte, error) {
+	args := m.Called(from, to)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]byte), args.Error(1)
+}
+
+func TestReportHandler_SummaryPDF(t *testing.T) {
+	day := func(month time.Month, d int) time.Time { return time.Date(2026, month, d, 0, 0, 0, 0, time.UTC) }
+	tests := []struct {
+		name             string
+		query            string
+		from, to         time.Time
+		err              error
+		expectedStatus   int
+		expectedFilename string
+	}{
+		{name: "default period", from: day(2, 14), to: day(3, 16), expectedStatus: http.StatusOK, expectedFilename: "summary-2026-02-14-to-2026-03-15.pdf"},
+		{name: "given period", query: "?from=2026-01-01&to=2026-01-31", from: day(1, 1), to: day(2, 1), expectedStatus: http.StatusOK, expectedFilename: "summary-2026-01-01-to-2026-01-31.pdf"},
+		{name: "from only", query: "?from=2026-03-01", from: day(3, 1), to: day(3, 16), expectedStatus: http.StatusOK, expectedFilename: "summary-2026-03-01-to-2026-03-15.pdf"},
+		{name: "invalid date", query: "?from=01-03-2026", expectedStatus: http.StatusBadRequest},
+		{name: "invalid period", query: "?from=2026-03-10&to=2026-03-01", from: day(3, 10), to: day(3, 2), err: service.ErrInvalidReportPeriod, expectedStatus: http.StatusBadRequest},
+		{name: "rendering fails", query: "?from=2026-03-01&to=2026-03-07", from: day(3, 1), to: day(3, 8), err: errors.New("connection refused"), expectedStatus: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			mockReportService := new(MockReportService)
+			reportHandler := NewReportHandler(mockReportService)
+			reportHandler.now = func() time.Time { return time.Date(2026, 3, 15, 18, 30, 0, 0, time.UTC) }
+			router := setupAuthTestRouter()
+			router.GET("/reports/summary.pdf", reportHandler.SummaryPDF)
+
+			if !tt.from.IsZero() {
+				if tt.err != nil {
+					mockReportService.On("RenderSummaryPDF", tt.from, tt.to).Return(nil, tt.err)
+				} else {
+					mockReportService.On("RenderSummaryPDF", tt.from, tt.to).Return([]byte("%PDF-1.4"), nil)
+				}
+			}
+
+			req, _ := http.NewRequest("GET", "/reports/summary.pdf"+tt.query, nil)
+			w := httptest.NewRecorder()
+
+			// Act
+			router.ServeHTTP(w, req)
+
+			// Assert
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedFilename != "" {
+				assert.Equal(t, "application/pdf", w.Header().Get("Content-Type"))
+				assert.Equal(t, "attachment; filename="+tt.expectedFilename, w.Header().Get("Content-Disposition"))
+				assert.Equal(t, "%PDF-1.4", w.Body.String())
+			}
+			mockReportService.AssertExpectations(t)
+		})
+	}
+}
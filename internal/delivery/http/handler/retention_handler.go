@@ -0,0 +1,89 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/shoelfikar/voucher-management-system/internal/delivery/http/request"
+	"github.com/shoelfikar/voucher-management-system/internal/delivery/http/response"
+	"github.com/shoelfikar/voucher-management-system/internal/domain/service"
+)
+
+type RetentionHandler struct {
+	retentionService service.RetentionService
+}
+
+func NewRetentionHandler(retentionService service.RetentionService) *RetentionHandler {
+	return &RetentionHandler{
+		retentionService: retentionService,
+	}
+}
+
+// GetPolicy handles GET /api/retention-policy
+// @Summary Get the retention policy
+// @Description Get how long expired vouchers are kept and whether they are then archived or purged
+// @Tags Retention
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} response.Response{data=entity.RetentionPolicy}
+// @Failure 500 {object} response.Response
+// @Router /api/retention-policy [get]
+func (h *RetentionHandler) GetPolicy(c *gin.Context) {
+	policy, err := h.retentionService.GetPolicy(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.ErrorResponse(err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, response.SuccessResponse(policy))
+}
+
+// UpdatePolicy handles PUT /api/retention-policy
+// @Summary Update the retention policy
+// @Description Replace the retention policy applied by the scheduled cleanup job (admins only)
+// @Tags Retention
+// @Accept json
+// @Produce json
+// @Param request body request.RetentionPolicyRequest true "Retention policy"
+// @Security BearerAuth
+// @Success 200 {object} response.Response{data=entity.RetentionPolicy}
+// @Failure 400 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Router /api/retention-policy [put]
+func (h *RetentionHandler) UpdatePolicy(c *gin.Context) {
+	var req request.RetentionPolicyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse(err.Error()))
+		return
+	}
+
+	cmd := req.ToCommand()
+	cmd.UpdatedBy = c.GetString("email")
+
+	policy, err := h.retentionService.UpdatePolicy(c.Request.Context(), cmd)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse(err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, response.SuccessResponseWithMessage("Retention policy updated successfully", policy))
+}
+
+// Preview handles GET /api/retention-policy/preview
+// @Summary Preview the next cleanup run
+// @Description Count the vouchers the next cleanup run would archive or purge, with sample codes
+// @Tags Retention
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} response.Response{data=service.CleanupPreview}
+// @Failure 500 {object} response.Response
+// @Router /api/retention-policy/preview [get]
+func (h *RetentionHandler) Preview(c *gin.Context) {
+	preview, err := h.retentionService.Preview(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.ErrorResponse(err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, response.SuccessResponse(preview))
+}
@@ -0,0 +1,99 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/shoelfikar/voucher-management-system/internal/domain/entity"
+	"github.com/shoelfikar/voucher-management-system/internal/domain/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockRetentionService is a mock implementation of RetentionService
+type MockRetentionService struct {
+	mock.Mock
+}
+
+func (m *MockRetentionService) GetPolicy(ctx context.Context) (*entity.RetentionPolicy, error) {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.RetentionPolicy), args.Error(1)
+}
+
+func (m *MockRetentionService) UpdatePolicy(ctx context.Context, cmd *service.RetentionPolicyCommand) (*entity.RetentionPolicy, error) {
+	args := m.Called(cmd)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.RetentionPolicy), args.Error(1)
+}
+
+func (m *MockRetentionService) Preview(ctx context.Context) (*service.CleanupPreview, error) {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*service.CleanupPreview), args.Error(1)
+}
+
+func (m *MockRetentionService) RunCleanup(ctx context.Context) (*service.CleanupResult, error) {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*service.CleanupResult), args.Error(1)
+}
+
+func TestRetentionHandler_UpdatePolicy_Success(t *testing.T) {
+	// Arrange
+	mockRetentionService := new(MockRetentionService)
+	retentionHandler := NewRetentionHandler(mockRetentionService)
+	router := setupAuthTestRouter()
+	router.PUT("/retention-policy", func(c *gin.Context) {
+		c.Set("email", "admin@example.com")
+		retentionHandler.UpdatePolicy(c)
+	})
+
+	mockRetentionService.On("UpdatePolicy", &service.RetentionPolicyCommand{
+		KeepDays: 0, Action: entity.RetentionActionPurge, Enabled: true, UpdatedBy: "admin@example.com",
+	}).Return(&entity.RetentionPolicy{KeepDays: 0, Action: entity.RetentionActionPurge, Enabled: true}, nil)
+
+	body := []byte(`{"keep_days": 0, "action": "purge", "enabled": true}`)
+	req, _ := http.NewRequest("PUT", "/retention-policy", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	mockRetentionService.AssertExpectations(t)
+}
+
+func TestRetentionHandler_UpdatePolicy_MissingKeepDays(t *testing.T) {
+	// Arrange
+	mockRetentionService := new(MockRetentionService)
+	retentionHandler := NewRetentionHandler(mockRetentionService)
+	router := setupAuthTestRouter()
+	router.PUT("/retention-policy", retentionHandler.UpdatePolicy)
+
+	body := []byte(`{"action": "archive", "enabled": true}`)
+	req, _ := http.NewRequest("PUT", "/retention-policy", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	mockRetentionService.AssertNotCalled(t, "UpdatePolicy", mock.Anything)
+}
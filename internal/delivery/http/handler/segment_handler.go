@@ -0,0 +1,196 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/shoelfikar/voucher-management-system/internal/delivery/http/request"
+	"github.com/shoelfikar/voucher-management-system/internal/delivery/http/response"
+	"github.com/shoelfikar/voucher-management-system/internal/domain/service"
+	"github.com/shoelfikar/voucher-management-system/pkg/utils"
+)
+
+// segmentSortFields are the columns GET /segments may be sorted by
+var segmentSortFields = []string{"id", "name", "type", "created_at", "updated_at"}
+
+type SegmentHandler struct {
+	segmentService service.SegmentService
+}
+
+func NewSegmentHandler(segmentService service.SegmentService) *SegmentHandler {
+	return &SegmentHandler{
+		segmentService: segmentService,
+	}
+}
+
+// GetAll handles GET /api/segments
+// @Summary Get all segments
+// @Description List the customer segments vouchers can target
+// @Tags Segments
+// @Produce json
+// @Param page query int false "Page number"
+// @Param limit query int false "Items per page"
+// @Param search query string false "Search by segment name"
+// @Param sort query string false "Comma-separated field:direction list, e.g. name:asc"
+// @Security BearerAuth
+// @Success 200 {object} response.Response{data=response.SegmentListResponse}
+// @Failure 400 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /api/segments [get]
+func (h *SegmentHandler) GetAll(c *gin.Context) {
+	params, err := utils.ParseListParams(c.Request.URL.Query(), segmentSortFields, "id:asc")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse(err.Error()))
+		return
+	}
+
+	segments, total, err := h.segmentService.GetAll(c.Request.Context(), params.Page, params.Limit, params.Search, params.Sort)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.ErrorResponse(err.Error()))
+		return
+	}
+
+	c.Header("X-Total-Count", strconv.FormatInt(total, 10))
+	c.JSON(http.StatusOK, response.SuccessResponse(response.BuildSegmentListResponse(segments, params.Page, params.Limit, total)))
+}
+
+// GetByID handles GET /api/segments/:id
+// @Summary Get segment by ID
+// @Description Get a segment with the number of customers it lists
+// @Tags Segments
+// @Produce json
+// @Param id path int true "Segment ID"
+// @Security BearerAuth
+// @Success 200 {object} response.Response{data=service.SegmentDetail}
+// @Failure 400 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Router /api/segments/{id} [get]
+func (h *SegmentHandler) GetByID(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse("Invalid segment ID"))
+		return
+	}
+
+	segment, err := h.segmentService.GetByID(c.Request.Context(), uint(id))
+	if err != nil {
+		c.JSON(http.StatusNotFound, response.ErrorResponse(err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, response.SuccessResponse(segment))
+}
+
+// Create handles POST /api/segments
+// @Summary Create a segment
+// @Description Add a static list of customer IDs, or a reference to a segment kept by the external segment provider
+// @Tags Segments
+// @Accept json
+// @Produce json
+// @Param request body request.SegmentRequest true "Segment definition"
+// @Security BearerAuth
+// @Success 201 {object} response.Response{data=service.SegmentDetail}
+// @Failure 400 {object} response.Response
+// @Failure 409 {object} response.Response
+// @Router /api/segments [post]
+func (h *SegmentHandler) Create(c *gin.Context) {
+	var req request.SegmentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse(err.Error()))
+		return
+	}
+
+	cmd := req.ToCommand()
+	cmd.CreatedBy = c.GetString("email")
+
+	segment, err := h.segmentService.Create(c.Request.Context(), cmd)
+	if err != nil {
+		status := http.StatusBadRequest
+		if errors.Is(err, service.ErrDuplicateSegmentName) {
+			status = http.StatusConflict
+		}
+		c.JSON(status, response.ErrorResponse(err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusCreated, response.SuccessResponseWithMessage("Segment created successfully", segment))
+}
+
+// Update handles PUT /api/segments/:id
+// @Summary Update a segment
+// @Description Replace a segment; the customer IDs sent replace the stored list
+// @Tags Segments
+// @Accept json
+// @Produce json
+// @Param id path int true "Segment ID"
+// @Param request body request.SegmentRequest true "Segment definition"
+// @Security BearerAuth
+// @Success 200 {object} response.Response{data=service.SegmentDetail}
+// @Failure 400 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 409 {object} response.Response
+// @Router /api/segments/{id} [put]
+func (h *SegmentHandler) Update(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse("Invalid segment ID"))
+		return
+	}
+
+	var req request.SegmentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse(err.Error()))
+		return
+	}
+
+	segment, err := h.segmentService.Update(c.Request.Context(), uint(id), req.ToCommand())
+	if err != nil {
+		status := http.StatusBadRequest
+		switch {
+		case errors.Is(err, service.ErrSegmentNotFound):
+			status = http.StatusNotFound
+		case errors.Is(err, service.ErrDuplicateSegmentName):
+			status = http.StatusConflict
+		}
+		c.JSON(status, response.ErrorResponse(err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, response.SuccessResponseWithMessage("Segment updated successfully", segment))
+}
+
+// Delete handles DELETE /api/segments/:id
+// @Summary Delete a segment
+// @Description Delete a segment and its member list; segments targeted by vouchers cannot be deleted
+// @Tags Segments
+// @Produce json
+// @Param id path int true "Segment ID"
+// @Security BearerAuth
+// @Success 200 {object} response.Response
+// @Failure 400 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 409 {object} response.Response
+// @Router /api/segments/{id} [delete]
+func (h *SegmentHandler) Delete(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse("Invalid segment ID"))
+		return
+	}
+
+	if err := h.segmentService.Delete(c.Request.Context(), uint(id)); err != nil {
+		status := http.StatusInternalServerError
+		switch {
+		case errors.Is(err, service.ErrSegmentNotFound):
+			status = http.StatusNotFound
+		case errors.Is(err, service.ErrSegmentInUse):
+			status = http.StatusConflict
+		}
+		c.JSON(status, response.ErrorResponse(err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, response.SuccessResponseWithMessage("Segment deleted successfully", nil))
+}
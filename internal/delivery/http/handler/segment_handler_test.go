@@ -0,0 +1,173 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/shoelfikar/voucher-management-system/internal/delivery/http/request"
+	"github.com/shoelfikar/voucher-management-system/internal/domain/entity"
+	"github.com/shoelfikar/voucher-management-system/internal/domain/service"
+	"github.com/shoelfikar/voucher-management-system/pkg/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockSegmentService is a mock implementation of SegmentService
+type MockSegmentService struct {
+	mock.Mock
+}
+
+func (m *MockSegmentService) GetAll(ctx context.Context, page, limit int, search string, sort []utils.SortField) ([]*entity.Segment, int64, error) {
+	args := m.Called(page, limit, search, sort)
+	if args.Get(0) == nil {
+		return nil, args.Get(1).(int64), args.Error(2)
+	}
+	return args.Get(0).([]*entity.Segment), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockSegmentService) GetByID(ctx context.Context, id uint) (*service.SegmentDetail, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*service.SegmentDetail), args.Error(1)
+}
+
+func (m *MockSegmentService) Create(ctx context.Context, cmd *service.SegmentCommand) (*service.SegmentDetail, error) {
+	args := m.Called(cmd)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*service.SegmentDetail), args.Error(1)
+}
+
+func (m *MockSegmentService) Update(ctx context.Context, id uint, cmd *service.SegmentCommand) (*service.SegmentDetail, error) {
+	args := m.Called(id, cmd)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*service.SegmentDetail), args.Error(1)
+}
+
+func (m *MockSegmentService) Delete(ctx context.Context, id uint) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func (m *MockSegmentService) IsMember(ctx context.Context, segmentID uint, customerID string) (bool, error) {
+	args := m.Called(segmentID, customerID)
+	return args.Bool(0), args.Error(1)
+}
+
+func TestSegmentHandler_GetAll(t *testing.T) {
+	// Arrange
+	mockSegmentService := new(MockSegmentService)
+	segmentHandler := NewSegmentHandler(mockSegmentService)
+	router := setupAuthTestRouter()
+	router.GET("/segments", segmentHandler.GetAll)
+
+	segments := []*entity.Segment{{ID: 1, Name: "VIP", Type: entity.SegmentTypeStatic}}
+	mockSegmentService.On("GetAll", 1, 10, "vip", []utils.SortField{{Field: "id"}}).Return(segments, int64(1), nil)
+
+	req, _ := http.NewRequest("GET", "/segments?search=vip", nil)
+	w := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "1", w.Header().Get("X-Total-Count"))
+	assert.Contains(t, w.Body.String(), `"segments":[{"id":1,"name":"VIP"`)
+	mockSegmentService.AssertExpectations(t)
+}
+
+func TestSegmentHandler_Create(t *testing.T) {
+	// Arrange
+	mockSegmentService := new(MockSegmentService)
+	segmentHandler := NewSegmentHandler(mockSegmentService)
+	router := setupAuthTestRouter()
+	router.POST("/segments", segmentHandler.Create)
+
+	expectedCmd := &service.SegmentCommand{Name: "VIP", Type: entity.SegmentTypeStatic, CustomerIDs: []string{"cust-1"}}
+	created := &service.SegmentDetail{Segment: &entity.Segment{ID: 1, Name: "VIP", Type: entity.SegmentTypeStatic}, MemberCount: 1}
+	mockSegmentService.On("Create", expectedCmd).Return(created, nil)
+
+	reqBody, _ := json.Marshal(request.SegmentRequest{Name: "VIP", Type: entity.SegmentTypeStatic, CustomerIDs: []string{"cust-1"}})
+	req, _ := http.NewRequest("POST", "/segments", bytes.NewBuffer(reqBody))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.Contains(t, w.Body.String(), `"member_count":1`)
+	mockSegmentService.AssertExpectations(t)
+}
+
+func TestSegmentHandler_Create_DuplicateName(t *testing.T) {
+	// Arrange
+	mockSegmentService := new(MockSegmentService)
+	segmentHandler := NewSegmentHandler(mockSegmentService)
+	router := setupAuthTestRouter()
+	router.POST("/segments", segmentHandler.Create)
+
+	mockSegmentService.On("Create", mock.Anything).Return(nil, service.ErrDuplicateSegmentName)
+
+	reqBody, _ := json.Marshal(request.SegmentRequest{Name: "VIP", Type: entity.SegmentTypeExternal, ExternalRef: "crm-vip"})
+	req, _ := http.NewRequest("POST", "/segments", bytes.NewBuffer(reqBody))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusConflict, w.Code)
+}
+
+func TestSegmentHandler_Create_InvalidType(t *testing.T) {
+	// Arrange
+	mockSegmentService := new(MockSegmentService)
+	segmentHandler := NewSegmentHandler(mockSegmentService)
+	router := setupAuthTestRouter()
+	router.POST("/segments", segmentHandler.Create)
+
+	reqBody, _ := json.Marshal(request.SegmentRequest{Name: "VIP", Type: "dynamic"})
+	req, _ := http.NewRequest("POST", "/segments", bytes.NewBuffer(reqBody))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	mockSegmentService.AssertNotCalled(t, "Create", mock.Anything)
+}
+
+func TestSegmentHandler_Delete_InUse(t *testing.T) {
+	// Arrange
+	mockSegmentService := new(MockSegmentService)
+	segmentHandler := NewSegmentHandler(mockSegmentService)
+	router := setupAuthTestRouter()
+	router.DELETE("/segments/:id", segmentHandler.Delete)
+
+	mockSegmentService.On("Delete", uint(1)).Return(service.ErrSegmentInUse)
+
+	req, _ := http.NewRequest("DELETE", "/segments/1", nil)
+	w := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusConflict, w.Code)
+	mockSegmentService.AssertExpectations(t)
+}
@@ -0,0 +1,48 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/shoelfikar/voucher-management-system/internal/delivery/http/response"
+	"github.com/shoelfikar/voucher-management-system/internal/domain/service"
+	"github.com/shoelfikar/voucher-management-system/pkg/sheets"
+)
+
+type SheetImportHandler struct {
+	voucherService service.VoucherService
+	sheetsClient   sheets.Client
+}
+
+func NewSheetImportHandler(voucherService service.VoucherService, sheetsClient sheets.Client) *SheetImportHandler {
+	return &SheetImportHandler{
+		voucherService: voucherService,
+		sheetsClient:   sheetsClient,
+	}
+}
+
+// ImportGoogleSheet handles POST /api/vouchers/import-google-sheet
+// @Summary Import vouchers from Google Sheets
+// @Description Pull voucher rows from the configured Google Sheet and import them like a CSV upload
+// @Tags Vouchers
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} response.Response{data=service.ImportResult}
+// @Failure 400 {object} response.Response
+// @Failure 502 {object} response.Response
+// @Router /api/vouchers/import-google-sheet [post]
+func (h *SheetImportHandler) ImportGoogleSheet(c *gin.Context) {
+	records, err := h.sheetsClient.FetchRows()
+	if err != nil {
+		c.JSON(http.StatusBadGateway, response.ErrorResponse(err.Error()))
+		return
+	}
+
+	result, err := h.voucherService.ImportRecords(c.Request.Context(), records, c.GetString("email"), principalRole(c))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse(err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, response.SuccessResponseWithMessage("Google Sheet import completed", result))
+}
@@ -0,0 +1,82 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/shoelfikar/voucher-management-system/internal/domain/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockSheetsClient is a mock implementation of sheets.Client
+type MockSheetsClient struct {
+	mock.Mock
+}
+
+func (m *MockSheetsClient) FetchRows() ([][]string, error) {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([][]string), args.Error(1)
+}
+
+func TestSheetImportHandler_ImportGoogleSheet_Success(t *testing.T) {
+	// Arrange
+	mockService := new(MockVoucherService)
+	mockClient := new(MockSheetsClient)
+	sheetImportHandler := NewSheetImportHandler(mockService, mockClient)
+	router := setupVoucherTestRouter()
+	router.POST("/vouchers/import-google-sheet", sheetImportHandler.ImportGoogleSheet)
+
+	records := [][]string{
+		{"voucher_code", "discount_percent", "expiry_date"},
+		{"SHEET1", "10", "2099-12-31"},
+	}
+	importResult := &service.ImportResult{TotalRows: 1, Success: 1}
+
+	mockClient.On("FetchRows").Return(records, nil)
+	mockService.On("ImportRecords", records, "", "").Return(importResult, nil)
+
+	req, _ := http.NewRequest("POST", "/vouchers/import-google-sheet", nil)
+	w := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response map[string]interface{}
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.Equal(t, "success", response["status"])
+
+	mockClient.AssertExpectations(t)
+	mockService.AssertExpectations(t)
+}
+
+func TestSheetImportHandler_ImportGoogleSheet_FetchError(t *testing.T) {
+	// Arrange
+	mockService := new(MockVoucherService)
+	mockClient := new(MockSheetsClient)
+	sheetImportHandler := NewSheetImportHandler(mockService, mockClient)
+	router := setupVoucherTestRouter()
+	router.POST("/vouchers/import-google-sheet", sheetImportHandler.ImportGoogleSheet)
+
+	mockClient.On("FetchRows").Return(nil, errors.New("failed to fetch sheet: unexpected status 403"))
+
+	req, _ := http.NewRequest("POST", "/vouchers/import-google-sheet", nil)
+	w := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusBadGateway, w.Code)
+	mockService.AssertNotCalled(t, "ImportRecords", mock.Anything, mock.Anything)
+}
@@ -0,0 +1,33 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/shoelfikar/voucher-management-system/internal/delivery/http/response"
+	"github.com/shoelfikar/voucher-management-system/pkg/slo"
+)
+
+type SLOHandler struct {
+	tracker *slo.Tracker
+}
+
+func NewSLOHandler(tracker *slo.Tracker) *SLOHandler {
+	return &SLOHandler{
+		tracker: tracker,
+	}
+}
+
+// Report handles GET /api/system/slo
+// @Summary Get service level objective compliance
+// @Description Success rate and p95 latency of every endpoint over each configured rolling window (SLO_WINDOWS), checked against SLO_SUCCESS_RATE and SLO_LATENCY_P95 or the endpoint's SLO_LATENCY_TARGETS entry. Responses with a 5xx status count as failures. Counts are kept per instance and reset on restart. Admins only.
+// @Tags System
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} response.Response{data=slo.Report}
+// @Failure 401 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Router /api/system/slo [get]
+func (h *SLOHandler) Report(c *gin.Context) {
+	c.JSON(http.StatusOK, response.SuccessResponse(h.tracker.Report()))
+}
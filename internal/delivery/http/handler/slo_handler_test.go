@@ -0,0 +1,39 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/shoelfikar/voucher-management-system/pkg/slo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSLOHandler_Report(t *testing.T) {
+	// Arrange
+	tracker, err := slo.NewTracker(slo.Config{
+		Windows:   []time.Duration{5 * time.Minute, time.Hour},
+		Objective: slo.Objective{Latency: 300 * time.Millisecond, SuccessRate: 99.9},
+	})
+	require.NoError(t, err)
+	tracker.Record("POST", "/api/v1/vouchers/:id/redeem", http.StatusCreated, 120*time.Millisecond)
+	tracker.Record("POST", "/api/v1/vouchers/:id/redeem", http.StatusInternalServerError, 80*time.Millisecond)
+
+	router := setupAuthTestRouter()
+	router.GET("/system/slo", NewSLOHandler(tracker).Report)
+	req, _ := http.NewRequest("GET", "/system/slo", nil)
+	w := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"compliant":false`)
+	assert.Contains(t, w.Body.String(), `"window":"5m0s"`)
+	assert.Contains(t, w.Body.String(), `"route":"/api/v1/vouchers/:id/redeem"`)
+	assert.Contains(t, w.Body.String(), `"success_rate":50`)
+	assert.Contains(t, w.Body.String(), `"latency_target_ms":300`)
+}
@@ -0,0 +1,112 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/shoelfikar/voucher-management-system/internal/delivery/http/request"
+	"github.com/shoelfikar/voucher-management-system/internal/delivery/http/response"
+	"github.com/shoelfikar/voucher-management-system/internal/domain/service"
+	"github.com/shoelfikar/voucher-management-system/pkg/utils"
+)
+
+type SMSHandler struct {
+	smsService service.SMSService
+}
+
+func NewSMSHandler(smsService service.SMSService) *SMSHandler {
+	return &SMSHandler{
+		smsService: smsService,
+	}
+}
+
+// Send handles POST /api/vouchers/:id/sms
+// @Summary Text a voucher to customers
+// @Description Queue an SMS with the voucher code, or with a one-time claim link per recipient, to up to 1000 phone numbers in E.164 format. Messages are sent in the background and retried on failure; track them with GET /api/sms-deliveries.
+// @Tags Vouchers
+// @Accept json
+// @Produce json
+// @Param id path int true "Voucher ID"
+// @Param request body request.SendSMSRequest true "Content and recipients"
+// @Security BearerAuth
+// @Success 202 {object} response.Response{data=[]entity.SMSDelivery}
+// @Failure 400 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 409 {object} response.Response
+// @Router /api/vouchers/{id}/sms [post]
+func (h *SMSHandler) Send(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse("Invalid voucher ID"))
+		return
+	}
+
+	var req request.SendSMSRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse(err.Error()))
+		return
+	}
+
+	recipients := make([]service.SMSRecipient, 0, len(req.Recipients))
+	for _, recipient := range req.Recipients {
+		recipients = append(recipients, service.SMSRecipient{PhoneNumber: recipient.PhoneNumber, CustomerID: recipient.CustomerID})
+	}
+
+	deliveries, err := h.smsService.Send(c.Request.Context(), &service.SendSMSCommand{
+		VoucherID:  uint(id),
+		Recipients: recipients,
+		Content:    req.Content,
+		CreatedBy:  c.GetString("email"),
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrVoucherNotFound):
+			c.JSON(http.StatusNotFound, response.ErrorResponse(err.Error()))
+		case errors.Is(err, service.ErrVoucherNotAssignable):
+			c.JSON(http.StatusConflict, response.ErrorResponse(err.Error()))
+		default:
+			c.JSON(http.StatusBadRequest, response.ErrorResponse(err.Error()))
+		}
+		return
+	}
+
+	c.JSON(http.StatusAccepted, response.SuccessResponseWithMessage("SMS deliveries queued successfully", deliveries))
+}
+
+// GetDeliveries handles GET /api/sms-deliveries
+// @Summary List SMS deliveries
+// @Description Get SMS deliveries with their status, attempts and last error, newest first
+// @Tags Vouchers
+// @Produce json
+// @Param voucher_id query int false "Only deliveries of this voucher"
+// @Param status query string false "pending, sent or failed"
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Items per page" default(10)
+// @Security BearerAuth
+// @Success 200 {object} response.Response{data=response.SMSDeliveryListResponse}
+// @Failure 400 {object} response.Response
+// @Router /api/sms-deliveries [get]
+func (h *SMSHandler) GetDeliveries(c *gin.Context) {
+	var voucherID uint64
+	if raw := c.Query("voucher_id"); raw != "" {
+		var err error
+		voucherID, err = strconv.ParseUint(raw, 10, 32)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, response.ErrorResponse("Invalid voucher ID"))
+			return
+		}
+	}
+
+	params := utils.ParsePaginationParams(c.Query("page"), c.Query("limit"), "", "")
+
+	deliveries, total, err := h.smsService.GetDeliveries(c.Request.Context(), uint(voucherID), c.Query("status"), params.Page, params.Limit)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse(err.Error()))
+		return
+	}
+
+	c.Header("X-Total-Count", strconv.FormatInt(total, 10))
+	c.JSON(http.StatusOK, response.SuccessResponse(response.BuildSMSDeliveryListResponse(deliveries, params.Page, params.Limit, total)))
+}
@@ -0,0 +1,136 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/shoelfikar/voucher-management-system/internal/domain/entity"
+	"github.com/shoelfikar/voucher-management-system/internal/domain/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockSMSService is a mock implementation of SMSService
+type MockSMSService struct {
+	mock.Mock
+}
+
+func (m *MockSMSService) Send(ctx context.Context, cmd *service.SendSMSCommand) ([]*entity.SMSDelivery, error) {
+	args := m.Called(cmd)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.SMSDelivery), args.Error(1)
+}
+
+func (m *MockSMSService) Dispatch(ctx context.Context) (*service.SMSDispatchResult, error) {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*service.SMSDispatchResult), args.Error(1)
+}
+
+func (m *MockSMSService) GetDeliveries(ctx context.Context, voucherID uint, status string, page, limit int) ([]*entity.SMSDelivery, int64, error) {
+	args := m.Called(voucherID, status, page, limit)
+	if args.Get(0) == nil {
+		return nil, 0, args.Error(2)
+	}
+	return args.Get(0).([]*entity.SMSDelivery), args.Get(1).(int64), args.Error(2)
+}
+
+func setupSMSTestRouter(smsHandler *SMSHandler) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.POST("/vouchers/:id/sms", smsHandler.Send)
+	router.GET("/sms-deliveries", smsHandler.GetDeliveries)
+	return router
+}
+
+func TestSMSHandler_Send(t *testing.T) {
+	// Arrange
+	mockService := new(MockSMSService)
+	router := setupSMSTestRouter(NewSMSHandler(mockService))
+
+	mockService.On("Send", &service.SendSMSCommand{
+		VoucherID:  7,
+		Recipients: []service.SMSRecipient{{PhoneNumber: "+14155550123", CustomerID: "cust-1"}},
+		Content:    entity.SMSContentClaimLink,
+	}).Return([]*entity.SMSDelivery{{ID: 1, VoucherID: 7, PhoneNumber: "+14155550123", Status: entity.SMSDeliveryStatusPending, Body: "secret link"}}, nil)
+
+	body := `{"content":"claim_link","recipients":[{"phone_number":"+14155550123","customer_id":"cust-1"}]}`
+	req, _ := http.NewRequest("POST", "/vouchers/7/sms", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusAccepted, w.Code)
+	assert.Contains(t, w.Body.String(), `"status":"pending"`)
+	assert.NotContains(t, w.Body.String(), "secret link")
+	mockService.AssertExpectations(t)
+}
+
+func TestSMSHandler_Send_Errors(t *testing.T) {
+	tests := []struct {
+		name     string
+		body     string
+		err      error
+		expected int
+	}{
+		{"invalid content", `{"content":"pdf","recipients":[{"phone_number":"+14155550123"}]}`, nil, http.StatusBadRequest},
+		{"no recipients", `{"content":"code","recipients":[]}`, nil, http.StatusBadRequest},
+		{"not found", `{"content":"code","recipients":[{"phone_number":"+14155550123"}]}`, service.ErrVoucherNotFound, http.StatusNotFound},
+		{"not assignable", `{"content":"code","recipients":[{"phone_number":"+14155550123"}]}`, fmt.Errorf("%w: OLD has expired", service.ErrVoucherNotAssignable), http.StatusConflict},
+		{"bad phone", `{"content":"code","recipients":[{"phone_number":"0812"}]}`, service.ErrInvalidPhoneNumber, http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			mockService := new(MockSMSService)
+			router := setupSMSTestRouter(NewSMSHandler(mockService))
+			if tt.err != nil {
+				mockService.On("Send", mock.Anything).Return(nil, tt.err)
+			}
+
+			req, _ := http.NewRequest("POST", "/vouchers/7/sms", bytes.NewBufferString(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+
+			// Act
+			router.ServeHTTP(w, req)
+
+			// Assert
+			assert.Equal(t, tt.expected, w.Code)
+		})
+	}
+}
+
+func TestSMSHandler_GetDeliveries(t *testing.T) {
+	// Arrange
+	mockService := new(MockSMSService)
+	router := setupSMSTestRouter(NewSMSHandler(mockService))
+
+	mockService.On("GetDeliveries", uint(7), entity.SMSDeliveryStatusFailed, 1, 10).
+		Return([]*entity.SMSDelivery{{ID: 1, VoucherID: 7, Status: entity.SMSDeliveryStatusFailed, LastError: "carrier unavailable"}}, int64(1), nil)
+
+	req, _ := http.NewRequest("GET", "/sms-deliveries?voucher_id=7&status=failed", nil)
+	w := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "1", w.Header().Get("X-Total-Count"))
+	assert.Contains(t, w.Body.String(), "carrier unavailable")
+	mockService.AssertExpectations(t)
+}
@@ -0,0 +1,68 @@
+package handler
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/shoelfikar/voucher-management-system/pkg/events"
+)
+
+// streamHeartbeatInterval keeps idle connections from being closed by proxies
+const streamHeartbeatInterval = 25 * time.Second
+
+type StreamHandler struct {
+	subscriber events.Subscriber
+	heartbeat  time.Duration
+}
+
+func NewStreamHandler(subscriber events.Subscriber) *StreamHandler {
+	return &StreamHandler{
+		subscriber: subscriber,
+		heartbeat:  streamHeartbeatInterval,
+	}
+}
+
+// Stream handles GET /api/stream
+// @Summary Stream events
+// @Description Server-sent events for the admin dashboard: import.completed, batch_import.completed and cleanup.completed.
+// @Description EventSource cannot set headers, so the token may be passed as the access_token query parameter.
+// @Tags Events
+// @Produce text/event-stream
+// @Param access_token query string false "JWT token, when the Authorization header cannot be sent"
+// @Security BearerAuth
+// @Success 200 {string} string "event stream"
+// @Router /api/stream [get]
+func (h *StreamHandler) Stream(c *gin.Context) {
+	received, cancel := h.subscriber.Subscribe()
+	defer cancel()
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	// Stops nginx from buffering the stream
+	c.Header("X-Accel-Buffering", "no")
+	c.Status(http.StatusOK)
+
+	// A comment line confirms the subscription before the first event
+	_, _ = c.Writer.WriteString(": connected\n\n")
+	c.Writer.Flush()
+
+	heartbeat := time.NewTicker(h.heartbeat)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-c.Request.Context().Done():
+			return
+		case event, ok := <-received:
+			if !ok {
+				return
+			}
+			c.SSEvent(event.Type, event)
+		case <-heartbeat.C:
+			_, _ = c.Writer.WriteString(": heartbeat\n\n")
+		}
+		c.Writer.Flush()
+	}
+}
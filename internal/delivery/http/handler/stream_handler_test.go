@@ -0,0 +1,84 @@
+package handler
+
+import (
+	"bufio"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/shoelfikar/voucher-management-system/pkg/events"
+	"github.com/stretchr/testify/assert"
+)
+
+// readEventLines reads lines from an SSE body until a blank line ends the next message
+func readEventLines(t *testing.T, reader *bufio.Reader) []string {
+	var lines []string
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			t.Fatalf("Failed to read stream: %v", err)
+		}
+		line = strings.TrimRight(line, "\n")
+		if line == "" {
+			return lines
+		}
+		lines = append(lines, line)
+	}
+}
+
+func TestStreamHandler_Stream_DeliversEvents(t *testing.T) {
+	// Arrange
+	gin.SetMode(gin.TestMode)
+	broker := events.NewBroker()
+	streamHandler := NewStreamHandler(broker)
+	router := gin.New()
+	router.GET("/stream", streamHandler.Stream)
+
+	server := httptest.NewServer(router)
+	defer server.Close()
+
+	// Act
+	resp, err := http.Get(server.URL + "/stream")
+	assert.NoError(t, err)
+	defer resp.Body.Close()
+	reader := bufio.NewReader(resp.Body)
+	connected := readEventLines(t, reader)
+
+	broker.Publish("import.completed", map[string]int{"success": 2})
+	message := readEventLines(t, reader)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+	assert.Equal(t, []string{": connected"}, connected)
+	if assert.Len(t, message, 2) {
+		assert.Equal(t, "event:import.completed", message[0])
+		assert.Contains(t, message[1], `"data":{"success":2}`)
+	}
+}
+
+func TestStreamHandler_Stream_Heartbeat(t *testing.T) {
+	// Arrange
+	gin.SetMode(gin.TestMode)
+	streamHandler := NewStreamHandler(events.NewBroker())
+	streamHandler.heartbeat = 10 * time.Millisecond
+	router := gin.New()
+	router.GET("/stream", streamHandler.Stream)
+
+	server := httptest.NewServer(router)
+	defer server.Close()
+
+	// Act
+	resp, err := http.Get(server.URL + "/stream")
+	assert.NoError(t, err)
+	defer resp.Body.Close()
+	reader := bufio.NewReader(resp.Body)
+	readEventLines(t, reader)
+	heartbeat := readEventLines(t, reader)
+
+	// Assert
+	assert.Equal(t, []string{": heartbeat"}, heartbeat)
+}
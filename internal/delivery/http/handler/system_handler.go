@@ -0,0 +1,73 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/shoelfikar/voucher-management-system/internal/delivery/http/response"
+	"github.com/shoelfikar/voucher-management-system/internal/domain/service"
+)
+
+type SystemHandler struct {
+	systemService service.SystemService
+}
+
+func NewSystemHandler(systemService service.SystemService) *SystemHandler {
+	return &SystemHandler{
+		systemService: systemService,
+	}
+}
+
+// Info handles GET /api/system/info
+// @Summary Get an operational snapshot
+// @Description Build version and commit, uptime, configuration with secrets redacted, database migration version and dependency health, for support triage. Admins only.
+// @Tags System
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} response.Response{data=service.SystemInfo}
+// @Failure 401 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Router /api/system/info [get]
+func (h *SystemHandler) Info(c *gin.Context) {
+	c.JSON(http.StatusOK, response.SuccessResponse(h.systemService.Info(c.Request.Context())))
+}
+
+// Status handles GET /status.json
+// @Summary Get the public service status
+// @Description Overall status (operational, degraded or outage), version and the status of each customer-facing component, for embedding in a status page. Components are named generically and no error details are included. Refreshed at most every 30 seconds.
+// @Tags System
+// @Produce json
+// @Success 200 {object} service.PublicStatus
+// @Router /status.json [get]
+func (h *SystemHandler) Status(c *gin.Context) {
+	c.Header("Cache-Control", fmt.Sprintf("public, max-age=%d", int(service.StatusCacheTTL.Seconds())))
+	c.JSON(http.StatusOK, h.systemService.Status(c.Request.Context()))
+}
+
+// ReloadConfig handles POST /api/system/reload-config
+// @Summary Reload configuration
+// @Description Re-read the settings that can change without a restart (ALLOWED_ORIGINS, the THROTTLE_*_LIMIT settings and REGISTRATION_OPEN) and report which changed. Sending the process SIGHUP does the same. Admins only.
+// @Tags System
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} response.Response{data=service.ConfigReload}
+// @Failure 401 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Failure 501 {object} response.Response
+// @Router /api/system/reload-config [post]
+func (h *SystemHandler) ReloadConfig(c *gin.Context) {
+	reload, err := h.systemService.ReloadConfig(c.Request.Context(), c.GetString("email"))
+	if err != nil {
+		if errors.Is(err, service.ErrConfigReloadDisabled) {
+			c.JSON(http.StatusNotImplemented, response.ErrorResponse(err.Error()))
+			return
+		}
+		c.JSON(http.StatusInternalServerError, response.ErrorResponse(err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, response.SuccessResponseWithMessage("Configuration reloaded", reload))
+}
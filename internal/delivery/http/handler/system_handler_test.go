@@ -0,0 +1,134 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/shoelfikar/voucher-management-system/internal/domain/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockSystemService is a mock implementation of SystemService
+type MockSystemService struct {
+	mock.Mock
+}
+
+func (m *MockSystemService) Info(ctx context.Context) *service.SystemInfo {
+	args := m.Called()
+	return args.Get(0).(*service.SystemInfo)
+}
+
+func (m *MockSystemService) Status(ctx context.Context) *service.PublicStatus {
+	args := m.Called()
+	return args.Get(0).(*service.PublicStatus)
+}
+
+func (m *MockSystemService) ReloadConfig(ctx context.Context, actor string) (*service.ConfigReload, error) {
+	args := m.Called(actor)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*service.ConfigReload), args.Error(1)
+}
+
+func TestSystemHandler_Info(t *testing.T) {
+	// Arrange
+	mockSystemService := new(MockSystemService)
+	systemHandler := NewSystemHandler(mockSystemService)
+	router := setupAuthTestRouter()
+	router.GET("/system/info", systemHandler.Info)
+
+	version := uint(17)
+	mockSystemService.On("Info").Return(&service.SystemInfo{
+		StartedAt:     time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+		Uptime:        "1h0m0s",
+		UptimeSeconds: 3600,
+		Config:        map[string]interface{}{"jwt": map[string]interface{}{"secret": "[REDACTED]"}},
+		Migration:     service.MigrationStatus{Version: &version, PendingSchemaChanges: []string{}},
+		Dependencies:  []service.DependencyHealth{{Name: "database", Status: service.DependencyStatusUp}},
+	})
+
+	req, _ := http.NewRequest("GET", "/system/info", nil)
+	w := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"uptime_seconds":3600`)
+	assert.Contains(t, w.Body.String(), `"version":17`)
+	assert.Contains(t, w.Body.String(), `"name":"database","status":"up"`)
+	mockSystemService.AssertExpectations(t)
+}
+
+func TestSystemHandler_Status(t *testing.T) {
+	// Arrange
+	mockSystemService := new(MockSystemService)
+	systemHandler := NewSystemHandler(mockSystemService)
+	router := setupAuthTestRouter()
+	router.GET("/status.json", systemHandler.Status)
+
+	mockSystemService.On("Status").Return(&service.PublicStatus{
+		Status:  service.StatusDegraded,
+		Version: "v1.2.0",
+		Components: []service.ComponentStatus{
+			{Name: "Voucher API", Status: service.StatusOperational},
+			{Name: "SMS delivery", Status: service.StatusDegraded},
+		},
+	})
+
+	req, _ := http.NewRequest("GET", "/status.json", nil)
+	w := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "public, max-age=30", w.Header().Get("Cache-Control"))
+	assert.Contains(t, w.Body.String(), `"status":"degraded","version":"v1.2.0"`)
+	assert.Contains(t, w.Body.String(), `{"name":"SMS delivery","status":"degraded"}`)
+	mockSystemService.AssertExpectations(t)
+}
+
+func TestSystemHandler_ReloadConfig(t *testing.T) {
+	tests := []struct {
+		name           string
+		reload         *service.ConfigReload
+		err            error
+		expectedStatus int
+		expectedBody   string
+	}{
+		{name: "reloaded", reload: &service.ConfigReload{Changed: []string{"ALLOWED_ORIGINS"}}, expectedStatus: http.StatusOK, expectedBody: `"changed":["ALLOWED_ORIGINS"]`},
+		{name: "unreadable configuration", err: errors.New("failed to reload configuration: invalid duration"), expectedStatus: http.StatusInternalServerError, expectedBody: "invalid duration"},
+		{name: "disabled", err: service.ErrConfigReloadDisabled, expectedStatus: http.StatusNotImplemented},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			mockSystemService := new(MockSystemService)
+			systemHandler := NewSystemHandler(mockSystemService)
+			router := setupAuthTestRouter()
+			router.POST("/system/reload-config", systemHandler.ReloadConfig)
+			mockSystemService.On("ReloadConfig", "").Return(tt.reload, tt.err)
+
+			req, _ := http.NewRequest("POST", "/system/reload-config", nil)
+			w := httptest.NewRecorder()
+
+			// Act
+			router.ServeHTTP(w, req)
+
+			// Assert
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			assert.Contains(t, w.Body.String(), tt.expectedBody)
+			mockSystemService.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,104 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/shoelfikar/voucher-management-system/internal/delivery/http/request"
+	"github.com/shoelfikar/voucher-management-system/internal/delivery/http/response"
+	"github.com/shoelfikar/voucher-management-system/internal/domain/service"
+)
+
+type UserHandler struct {
+	userService service.UserService
+}
+
+func NewUserHandler(userService service.UserService) *UserHandler {
+	return &UserHandler{
+		userService: userService,
+	}
+}
+
+// Invite handles POST /api/users/invite
+// @Summary Invite a user
+// @Description Email a signed invitation link for a new account with a pre-assigned role
+// @Tags Users
+// @Accept json
+// @Produce json
+// @Param request body request.InviteUserRequest true "Invitee email and role"
+// @Security BearerAuth
+// @Success 200 {object} response.Response
+// @Failure 400 {object} response.Response
+// @Router /api/users/invite [post]
+func (h *UserHandler) Invite(c *gin.Context) {
+	var req request.InviteUserRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse("Invalid request: "+err.Error()))
+		return
+	}
+
+	if err := h.userService.Invite(c.Request.Context(), req.Email, req.Role, c.GetString("email")); err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse(err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, response.SuccessResponseWithMessage("Invitation sent successfully", nil))
+}
+
+// AcceptInvite handles POST /api/v1/auth/accept-invite
+// @Summary Accept an invitation
+// @Description Create the invited account with a password and log in
+// @Tags Authentication
+// @Accept json
+// @Produce json
+// @Param request body request.AcceptInviteRequest true "Invitation token and password"
+// @Success 200 {object} response.Response{data=response.LoginResponse}
+// @Failure 400 {object} response.Response
+// @Router /api/v1/auth/accept-invite [post]
+func (h *UserHandler) AcceptInvite(c *gin.Context) {
+	var req request.AcceptInviteRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse("Invalid request: "+err.Error()))
+		return
+	}
+
+	token, user, err := h.userService.AcceptInvite(c.Request.Context(), req.Token, req.Password)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse(err.Error()))
+		return
+	}
+
+	loginResponse := response.LoginResponse{
+		Token: token,
+		User: response.UserInfo{
+			Email: user.Email,
+			Role:  user.Role,
+		},
+	}
+
+	c.JSON(http.StatusOK, response.SuccessResponseWithMessage("Invitation accepted successfully", loginResponse))
+}
+
+// VerifyEmail handles GET /api/v1/auth/verify-email
+// @Summary Verify an email address
+// @Description Mark the account in an emailed verification link as verified
+// @Tags Authentication
+// @Produce json
+// @Param token query string true "Verification token"
+// @Success 200 {object} response.Response
+// @Failure 400 {object} response.Response
+// @Router /api/v1/auth/verify-email [get]
+func (h *UserHandler) VerifyEmail(c *gin.Context) {
+	token := c.Query("token")
+	if token == "" {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse("Missing verification token"))
+		return
+	}
+
+	if err := h.userService.VerifyEmail(c.Request.Context(), token); err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse(err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, response.SuccessResponseWithMessage("Email verified successfully", nil))
+}
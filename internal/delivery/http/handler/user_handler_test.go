@@ -0,0 +1,181 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/shoelfikar/voucher-management-system/internal/delivery/http/request"
+	"github.com/shoelfikar/voucher-management-system/internal/domain/entity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockUserService is a mock implementation of UserService
+type MockUserService struct {
+	mock.Mock
+}
+
+func (m *MockUserService) Invite(ctx context.Context, email, role, invitedBy string) error {
+	args := m.Called(email, role, invitedBy)
+	return args.Error(0)
+}
+
+func (m *MockUserService) AcceptInvite(ctx context.Context, token, password string) (string, *entity.User, error) {
+	args := m.Called(token, password)
+	if args.Get(1) == nil {
+		return args.String(0), nil, args.Error(2)
+	}
+	return args.String(0), args.Get(1).(*entity.User), args.Error(2)
+}
+
+func (m *MockUserService) SendVerificationEmail(ctx context.Context, email string) error {
+	args := m.Called(email)
+	return args.Error(0)
+}
+
+func (m *MockUserService) VerifyEmail(ctx context.Context, token string) error {
+	args := m.Called(token)
+	return args.Error(0)
+}
+
+func TestUserHandler_Invite_Success(t *testing.T) {
+	// Arrange
+	mockUserService := new(MockUserService)
+	userHandler := NewUserHandler(mockUserService)
+	router := setupAuthTestRouter()
+	router.POST("/users/invite", func(c *gin.Context) {
+		c.Set("email", "admin@example.com")
+		userHandler.Invite(c)
+	})
+
+	mockUserService.On("Invite", "new@example.com", "viewer", "admin@example.com").Return(nil)
+
+	body, _ := json.Marshal(request.InviteUserRequest{Email: "new@example.com", Role: "viewer"})
+	req, _ := http.NewRequest("POST", "/users/invite", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	mockUserService.AssertExpectations(t)
+}
+
+func TestUserHandler_Invite_InvalidRole(t *testing.T) {
+	// Arrange
+	mockUserService := new(MockUserService)
+	userHandler := NewUserHandler(mockUserService)
+	router := setupAuthTestRouter()
+	router.POST("/users/invite", userHandler.Invite)
+
+	body, _ := json.Marshal(request.InviteUserRequest{Email: "new@example.com", Role: "owner"})
+	req, _ := http.NewRequest("POST", "/users/invite", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	mockUserService.AssertNotCalled(t, "Invite", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestUserHandler_AcceptInvite_Success(t *testing.T) {
+	// Arrange
+	mockUserService := new(MockUserService)
+	userHandler := NewUserHandler(mockUserService)
+	router := setupAuthTestRouter()
+	router.POST("/auth/accept-invite", userHandler.AcceptInvite)
+
+	user := &entity.User{Email: "new@example.com", Role: entity.UserRoleViewer}
+	mockUserService.On("AcceptInvite", "invite.token", "secret123").Return("access.token", user, nil)
+
+	body, _ := json.Marshal(request.AcceptInviteRequest{Token: "invite.token", Password: "secret123"})
+	req, _ := http.NewRequest("POST", "/auth/accept-invite", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response map[string]interface{}
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	data := response["data"].(map[string]interface{})
+	assert.Equal(t, "access.token", data["token"])
+	assert.Equal(t, "viewer", data["user"].(map[string]interface{})["role"])
+
+	mockUserService.AssertExpectations(t)
+}
+
+func TestUserHandler_AcceptInvite_InvalidToken(t *testing.T) {
+	// Arrange
+	mockUserService := new(MockUserService)
+	userHandler := NewUserHandler(mockUserService)
+	router := setupAuthTestRouter()
+	router.POST("/auth/accept-invite", userHandler.AcceptInvite)
+
+	mockUserService.On("AcceptInvite", "bad.token", "secret123").Return("", nil, errors.New("invalid or expired invitation"))
+
+	body, _ := json.Marshal(request.AcceptInviteRequest{Token: "bad.token", Password: "secret123"})
+	req, _ := http.NewRequest("POST", "/auth/accept-invite", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	mockUserService.AssertExpectations(t)
+}
+
+func TestUserHandler_VerifyEmail_Success(t *testing.T) {
+	// Arrange
+	mockUserService := new(MockUserService)
+	userHandler := NewUserHandler(mockUserService)
+	router := setupAuthTestRouter()
+	router.GET("/auth/verify-email", userHandler.VerifyEmail)
+
+	mockUserService.On("VerifyEmail", "verify.token").Return(nil)
+
+	req, _ := http.NewRequest("GET", "/auth/verify-email?token=verify.token", nil)
+	w := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	mockUserService.AssertExpectations(t)
+}
+
+func TestUserHandler_VerifyEmail_MissingToken(t *testing.T) {
+	// Arrange
+	mockUserService := new(MockUserService)
+	userHandler := NewUserHandler(mockUserService)
+	router := setupAuthTestRouter()
+	router.GET("/auth/verify-email", userHandler.VerifyEmail)
+
+	req, _ := http.NewRequest("GET", "/auth/verify-email", nil)
+	w := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	mockUserService.AssertNotCalled(t, "VerifyEmail", mock.Anything)
+}
@@ -1,24 +1,112 @@
 package handler
 
 import (
+	"archive/zip"
+	"bytes"
+	"crypto/sha256"
+	"encoding/csv"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
 	"net/http"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/shoelfikar/voucher-management-system/internal/delivery/http/middleware"
 	"github.com/shoelfikar/voucher-management-system/internal/delivery/http/request"
 	"github.com/shoelfikar/voucher-management-system/internal/delivery/http/response"
+	"github.com/shoelfikar/voucher-management-system/internal/domain/entity"
 	"github.com/shoelfikar/voucher-management-system/internal/domain/service"
+	"github.com/shoelfikar/voucher-management-system/pkg/exportcrypt"
+	"github.com/shoelfikar/voucher-management-system/pkg/logger"
+	"github.com/shoelfikar/voucher-management-system/pkg/utils"
+	"github.com/shoelfikar/voucher-management-system/pkg/xlsx"
 )
 
+// Limits for splitting an export into a ZIP of CSV files
+const (
+	defaultExportRowsPerFile = 100000
+	maxExportRowsPerFile     = 1000000
+)
+
+// maxVoucherCodeLength matches the voucher_code limit of create requests
+const maxVoucherCodeLength = 50
+
+// voucherSortFields are the columns GET /vouchers may be sorted by
+var voucherSortFields = []string{"id", "voucher_code", "discount_percent", "expiry_date", "status", "created_at", "updated_at"}
+
 type VoucherHandler struct {
-	voucherService service.VoucherService
+	voucherService  service.VoucherService
+	maskViewerCodes bool
+}
+
+// VoucherHandlerOption configures optional voucher handler behaviour
+type VoucherHandlerOption func(*VoucherHandler)
+
+// WithViewerCodeMasking partially redacts voucher codes in list responses sent to viewer-role users
+// and refuses to print vouchers for them
+func WithViewerCodeMasking() VoucherHandlerOption {
+	return func(h *VoucherHandler) {
+		h.maskViewerCodes = true
+	}
 }
 
-func NewVoucherHandler(voucherService service.VoucherService) *VoucherHandler {
-	return &VoucherHandler{
+func NewVoucherHandler(voucherService service.VoucherService, opts ...VoucherHandlerOption) *VoucherHandler {
+	h := &VoucherHandler{
 		voucherService: voucherService,
 	}
+	for _, opt := range opts {
+		opt(h)
+	}
+	return h
+}
+
+// maskCodesForViewer partially redacts the codes of a list response sent to a
+// viewer, when enabled. A user that cannot be loaded is treated as a viewer.
+func (h *VoucherHandler) maskCodesForViewer(c *gin.Context, responses []response.VoucherResponse) {
+	if !h.hidesCodes(c) {
+		return
+	}
+	for i := range responses {
+		responses[i].VoucherCode = utils.MaskCode(responses[i].VoucherCode)
+	}
+}
+
+// respondWithVoucher answers with a single voucher, its code masked for
+// viewers when enabled, or 304 when the client's copy is still current
+func (h *VoucherHandler) respondWithVoucher(c *gin.Context, voucher *entity.Voucher) {
+	masked := h.hidesCodes(c)
+	if notModified(c, voucher, masked) {
+		return
+	}
+
+	voucherResponse := response.ToVoucherResponse(voucher)
+	if masked {
+		voucherResponse.VoucherCode = utils.MaskCode(voucherResponse.VoucherCode)
+	}
+
+	c.JSON(http.StatusOK, response.SuccessResponse(voucherResponse))
+}
+
+// hidesCodes reports whether codes are masked for the caller
+func (h *VoucherHandler) hidesCodes(c *gin.Context) bool {
+	if !h.maskViewerCodes {
+		return false
+	}
+	principal, err := middleware.GetPrincipal(c)
+	return err != nil || principal.Role == entity.UserRoleViewer
+}
+
+// principalRole returns the caller's role, or "" when the user cannot be loaded
+func principalRole(c *gin.Context) string {
+	principal, err := middleware.GetPrincipal(c)
+	if err != nil {
+		return ""
+	}
+	return principal.Role
 }
 
 // GetAll handles GET /api/vouchers
@@ -30,30 +118,116 @@ func NewVoucherHandler(voucherService service.VoucherService) *VoucherHandler {
 // @Param page query int false "Page number" default(1)
 // @Param limit query int false "Items per page" default(10)
 // @Param search query string false "Search by voucher code"
-// @Param sort_by query string false "Sort by field" default(created_at)
+// @Param sort query string false "Comma-separated field:direction list, e.g. expiry_date:asc,discount_percent:desc"
+// @Param sort_by query string false "Sort by field (ignored when sort is set)" default(created_at)
 // @Param sort_order query string false "Sort order (asc/desc)" default(desc)
+// @Param ids query string false "Comma-separated voucher IDs to fetch in one query instead of listing; other parameters are ignored"
 // @Security BearerAuth
 // @Success 200 {object} response.Response{data=response.VoucherListResponse}
+// @Failure 400 {object} response.Response
 // @Failure 500 {object} response.Response
 // @Router /api/vouchers [get]
 func (h *VoucherHandler) GetAll(c *gin.Context) {
-	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
-	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
-	search := c.Query("search")
-	sortBy := c.DefaultQuery("sort_by", "created_at")
-	sortOrder := c.DefaultQuery("sort_order", "desc")
+	if ids := c.Query("ids"); ids != "" {
+		h.getByIDs(c, ids)
+		return
+	}
+
+	params, err := utils.ParseListParams(c.Request.URL.Query(), voucherSortFields, "created_at:desc")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse(err.Error()))
+		return
+	}
 
-	vouchers, total, err := h.voucherService.GetAll(page, limit, search, sortBy, sortOrder)
+	vouchers, total, err := h.voucherService.GetAll(c.Request.Context(), params.Page, params.Limit, params.Search, params.Sort)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, response.ErrorResponse(err.Error()))
 		return
 	}
 
-	voucherListResponse := response.BuildVoucherListResponse(vouchers, page, limit, total)
+	// HEAD answers with the count alone, for gateways probing the endpoint
+	c.Header("X-Total-Count", strconv.FormatInt(total, 10))
+	if c.Request.Method == http.MethodHead {
+		c.Status(http.StatusOK)
+		return
+	}
+
+	voucherListResponse := response.BuildVoucherListResponse(vouchers, params.Page, params.Limit, total)
+	h.maskCodesForViewer(c, voucherListResponse.Vouchers)
 
 	c.JSON(http.StatusOK, response.SuccessResponse(voucherListResponse))
 }
 
+// getByIDs answers GET /api/vouchers?ids=1,2,3 with the vouchers that exist, ordered by ID
+func (h *VoucherHandler) getByIDs(c *gin.Context, raw string) {
+	ids, err := parseVoucherIDs(raw)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse(err.Error()))
+		return
+	}
+
+	vouchers, err := h.voucherService.GetByIDs(c.Request.Context(), ids)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse(err.Error()))
+		return
+	}
+
+	c.Header("X-Total-Count", strconv.Itoa(len(vouchers)))
+	if c.Request.Method == http.MethodHead {
+		c.Status(http.StatusOK)
+		return
+	}
+
+	batchResponse := response.VoucherBatchResponse{Vouchers: response.ToVoucherListResponse(vouchers)}
+	h.maskCodesForViewer(c, batchResponse.Vouchers)
+
+	c.JSON(http.StatusOK, response.SuccessResponse(batchResponse))
+}
+
+// parseVoucherIDs parses a comma-separated list of voucher IDs
+func parseVoucherIDs(raw string) ([]uint, error) {
+	parts := strings.Split(raw, ",")
+	ids := make([]uint, 0, len(parts))
+	for _, part := range parts {
+		id, err := strconv.ParseUint(strings.TrimSpace(part), 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid voucher ID '%s'", part)
+		}
+		ids = append(ids, uint(id))
+	}
+	return ids, nil
+}
+
+// Lookup handles POST /api/vouchers/lookup
+// @Summary Look up vouchers by code
+// @Description Fetch up to 100 vouchers by code, ignoring case, in one query; unknown codes are left out
+// @Tags Vouchers
+// @Accept json
+// @Produce json
+// @Param request body request.VoucherLookupRequest true "Voucher codes"
+// @Security BearerAuth
+// @Success 200 {object} response.Response{data=response.VoucherBatchResponse}
+// @Failure 400 {object} response.Response
+// @Router /api/vouchers/lookup [post]
+func (h *VoucherHandler) Lookup(c *gin.Context) {
+	var req request.VoucherLookupRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse("Invalid request: "+err.Error()))
+		return
+	}
+
+	vouchers, err := h.voucherService.GetByCodes(c.Request.Context(), req.Codes)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse(err.Error()))
+		return
+	}
+
+	batchResponse := response.VoucherBatchResponse{Vouchers: response.ToVoucherListResponse(vouchers)}
+	h.maskCodesForViewer(c, batchResponse.Vouchers)
+
+	c.JSON(http.StatusOK, response.SuccessResponse(batchResponse))
+}
+
 // GetByID handles GET /api/vouchers/:id
 // @Summary Get voucher by ID
 // @Description Get a single voucher by its ID
@@ -62,7 +236,9 @@ func (h *VoucherHandler) GetAll(c *gin.Context) {
 // @Produce json
 // @Param id path int true "Voucher ID"
 // @Security BearerAuth
+// @Param If-None-Match header string false "ETag from an earlier response"
 // @Success 200 {object} response.Response{data=response.VoucherResponse}
+// @Success 304 "Voucher unchanged since the ETag was issued"
 // @Failure 400 {object} response.Response
 // @Failure 404 {object} response.Response
 // @Router /api/vouchers/{id} [get]
@@ -73,15 +249,94 @@ func (h *VoucherHandler) GetByID(c *gin.Context) {
 		return
 	}
 
-	voucher, err := h.voucherService.GetByID(uint(id))
+	voucher, err := h.voucherService.GetByID(c.Request.Context(), uint(id))
 	if err != nil {
 		c.JSON(http.StatusNotFound, response.ErrorResponse(err.Error()))
 		return
 	}
 
-	voucherResponse := response.ToVoucherResponse(voucher)
+	h.respondWithVoucher(c, voucher)
+}
 
-	c.JSON(http.StatusOK, response.SuccessResponse(voucherResponse))
+// Suggest handles GET /api/vouchers/suggest
+// @Summary Suggest voucher codes
+// @Description Autocomplete voucher codes starting with q, ignoring case (up to 10)
+// @Tags Vouchers
+// @Accept json
+// @Produce json
+// @Param q query string true "Code prefix"
+// @Security BearerAuth
+// @Success 200 {object} response.Response{data=[]string}
+// @Failure 403 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /api/vouchers/suggest [get]
+func (h *VoucherHandler) Suggest(c *gin.Context) {
+	// Suggestions are full codes, so viewers whose codes are masked get none
+	if h.hidesCodes(c) {
+		c.JSON(http.StatusForbidden, response.ErrorResponse("Voucher codes are masked for your role"))
+		return
+	}
+
+	codes, err := h.voucherService.Suggest(c.Request.Context(), c.Query("q"))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.ErrorResponse(err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, response.SuccessResponse(codes))
+}
+
+// CodeAvailable handles GET /api/vouchers/code-available
+// @Summary Check whether a voucher code is available
+// @Description Report whether no voucher uses code yet, ignoring case as creating a voucher does, so forms can warn while the code is typed
+// @Tags Vouchers
+// @Produce json
+// @Param code query string true "Voucher code (at most 50 characters)"
+// @Security BearerAuth
+// @Success 200 {object} response.Response{data=response.CodeAvailabilityResponse}
+// @Failure 400 {object} response.Response
+// @Failure 429 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /api/vouchers/code-available [get]
+func (h *VoucherHandler) CodeAvailable(c *gin.Context) {
+	code := c.Query("code")
+	if code == "" || len(code) > maxVoucherCodeLength {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse(fmt.Sprintf("code is required and may be at most %d characters", maxVoucherCodeLength)))
+		return
+	}
+
+	available, err := h.voucherService.CodeAvailable(c.Request.Context(), code)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.ErrorResponse(err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, response.SuccessResponse(response.CodeAvailabilityResponse{Code: code, Available: available}))
+}
+
+// GetByExternalID handles GET /api/vouchers/by-external-id/:ext_id
+// @Summary Get voucher by external ID
+// @Description Get a voucher by the external ID an integrating system assigned to it
+// @Tags Vouchers
+// @Accept json
+// @Produce json
+// @Param ext_id path string true "External ID"
+// @Security BearerAuth
+// @Success 200 {object} response.Response{data=response.VoucherResponse}
+// @Failure 404 {object} response.Response
+// @Router /api/vouchers/by-external-id/{ext_id} [get]
+func (h *VoucherHandler) GetByExternalID(c *gin.Context) {
+	voucher, err := h.voucherService.GetByExternalID(c.Request.Context(), c.Param("ext_id"))
+	if err != nil {
+		if errors.Is(err, service.ErrVoucherNotFound) {
+			c.JSON(http.StatusNotFound, response.ErrorResponse(err.Error()))
+			return
+		}
+		c.JSON(http.StatusInternalServerError, response.ErrorResponse(err.Error()))
+		return
+	}
+
+	h.respondWithVoucher(c, voucher)
 }
 
 // Create handles POST /api/vouchers
@@ -94,6 +349,8 @@ func (h *VoucherHandler) GetByID(c *gin.Context) {
 // @Security BearerAuth
 // @Success 201 {object} response.Response{data=response.VoucherResponse}
 // @Failure 400 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Failure 409 {object} response.Response
 // @Failure 500 {object} response.Response
 // @Router /api/vouchers [post]
 func (h *VoucherHandler) Create(c *gin.Context) {
@@ -104,9 +361,20 @@ func (h *VoucherHandler) Create(c *gin.Context) {
 		return
 	}
 
-	voucher, err := h.voucherService.Create(&req)
+	cmd := req.ToCommand()
+	cmd.CreatedBy = c.GetString("email")
+	cmd.Role = principalRole(c)
+
+	voucher, err := h.voucherService.Create(c.Request.Context(), cmd)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, response.ErrorResponse(err.Error()))
+		status := http.StatusBadRequest
+		switch {
+		case errors.Is(err, service.ErrDuplicateExternalID):
+			status = http.StatusConflict
+		case errors.Is(err, service.ErrDiscountLimitExceeded):
+			status = http.StatusForbidden
+		}
+		c.JSON(status, response.ErrorResponse(err.Error()))
 		return
 	}
 
@@ -126,6 +394,7 @@ func (h *VoucherHandler) Create(c *gin.Context) {
 // @Security BearerAuth
 // @Success 200 {object} response.Response{data=response.VoucherResponse}
 // @Failure 400 {object} response.Response
+// @Failure 403 {object} response.Response
 // @Failure 404 {object} response.Response
 // @Router /api/vouchers/{id} [put]
 func (h *VoucherHandler) Update(c *gin.Context) {
@@ -142,9 +411,17 @@ func (h *VoucherHandler) Update(c *gin.Context) {
 		return
 	}
 
-	voucher, err := h.voucherService.Update(uint(id), &req)
+	cmd := req.ToCommand()
+	cmd.UpdatedBy = c.GetString("email")
+	cmd.Role = principalRole(c)
+
+	voucher, err := h.voucherService.Update(c.Request.Context(), uint(id), cmd)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, response.ErrorResponse(err.Error()))
+		status := http.StatusBadRequest
+		if errors.Is(err, service.ErrDiscountLimitExceeded) {
+			status = http.StatusForbidden
+		}
+		c.JSON(status, response.ErrorResponse(err.Error()))
 		return
 	}
 
@@ -172,7 +449,7 @@ func (h *VoucherHandler) Delete(c *gin.Context) {
 		return
 	}
 
-	err = h.voucherService.Delete(uint(id))
+	err = h.voucherService.Delete(c.Request.Context(), uint(id))
 	if err != nil {
 		c.JSON(http.StatusNotFound, response.ErrorResponse(err.Error()))
 		return
@@ -181,6 +458,393 @@ func (h *VoucherHandler) Delete(c *gin.Context) {
 	c.JSON(http.StatusOK, response.SuccessResponseWithMessage("Voucher deleted successfully", nil))
 }
 
+// Approve handles POST /api/vouchers/:id/approve
+// @Summary Approve a voucher
+// @Description Activate a high-value voucher pending approval; the approver must differ from whoever created or last changed it
+// @Tags Vouchers
+// @Produce json
+// @Param id path int true "Voucher ID"
+// @Security BearerAuth
+// @Success 200 {object} response.Response{data=response.VoucherResponse}
+// @Failure 400 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Router /api/vouchers/{id}/approve [post]
+func (h *VoucherHandler) Approve(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse("Invalid voucher ID"))
+		return
+	}
+
+	voucher, err := h.voucherService.Approve(c.Request.Context(), uint(id), c.GetString("email"))
+	if err != nil {
+		if errors.Is(err, service.ErrVoucherNotFound) {
+			c.JSON(http.StatusNotFound, response.ErrorResponse(err.Error()))
+			return
+		}
+		if errors.Is(err, service.ErrSelfApproval) {
+			c.JSON(http.StatusForbidden, response.ErrorResponse(err.Error()))
+			return
+		}
+		c.JSON(http.StatusBadRequest, response.ErrorResponse(err.Error()))
+		return
+	}
+
+	voucherResponse := response.ToVoucherResponse(voucher)
+
+	c.JSON(http.StatusOK, response.SuccessResponseWithMessage("Voucher approved successfully", voucherResponse))
+}
+
+// Assign handles POST /api/vouchers/:id/assignments
+// @Summary Assign a voucher to a customer
+// @Description Give an active, unexpired voucher to a customer. A voucher with validity_days expires for them that many days later, capped at its expiry date.
+// @Tags Vouchers
+// @Accept json
+// @Produce json
+// @Param id path int true "Voucher ID"
+// @Param request body request.AssignVoucherRequest true "Customer to assign the voucher to"
+// @Security BearerAuth
+// @Success 201 {object} response.Response{data=entity.VoucherAssignment}
+// @Failure 400 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 409 {object} response.Response
+// @Router /api/vouchers/{id}/assignments [post]
+func (h *VoucherHandler) Assign(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse("Invalid voucher ID"))
+		return
+	}
+
+	var req request.AssignVoucherRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse(err.Error()))
+		return
+	}
+
+	assignment, err := h.voucherService.Assign(c.Request.Context(), uint(id), req.CustomerID, c.GetString("email"))
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrVoucherNotFound):
+			c.JSON(http.StatusNotFound, response.ErrorResponse(err.Error()))
+		case errors.Is(err, service.ErrVoucherAlreadyAssigned), errors.Is(err, service.ErrVoucherNotAssignable):
+			c.JSON(http.StatusConflict, response.ErrorResponse(err.Error()))
+		default:
+			c.JSON(http.StatusBadRequest, response.ErrorResponse(err.Error()))
+		}
+		return
+	}
+
+	c.JSON(http.StatusCreated, response.SuccessResponseWithMessage("Voucher assigned successfully", assignment))
+}
+
+// GetAssignments handles GET /api/vouchers/:id/assignments
+// @Summary Get a voucher's assignments
+// @Description List the customers a voucher was assigned to and when each assignment expires, newest first
+// @Tags Vouchers
+// @Produce json
+// @Param id path int true "Voucher ID"
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Items per page" default(10)
+// @Security BearerAuth
+// @Success 200 {object} response.Response{data=response.VoucherAssignmentListResponse}
+// @Failure 400 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Router /api/vouchers/{id}/assignments [get]
+func (h *VoucherHandler) GetAssignments(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse("Invalid voucher ID"))
+		return
+	}
+
+	params := utils.ParsePaginationParams(c.Query("page"), c.Query("limit"), "", "")
+
+	assignments, total, err := h.voucherService.GetAssignments(c.Request.Context(), uint(id), params.Page, params.Limit)
+	if err != nil {
+		if errors.Is(err, service.ErrVoucherNotFound) {
+			c.JSON(http.StatusNotFound, response.ErrorResponse(err.Error()))
+			return
+		}
+		c.JSON(http.StatusBadRequest, response.ErrorResponse(err.Error()))
+		return
+	}
+
+	c.Header("X-Total-Count", strconv.FormatInt(total, 10))
+	c.JSON(http.StatusOK, response.SuccessResponse(response.BuildVoucherAssignmentListResponse(assignments, params.Page, params.Limit, total)))
+}
+
+// PrintPDF handles GET /api/vouchers/:id/pdf
+// @Summary Print a voucher
+// @Description Render an active, unexpired voucher as a printable A6 PDF with its code, QR code, discount, expiry and terms
+// @Tags Vouchers
+// @Produce application/pdf
+// @Param id path int true "Voucher ID"
+// @Security BearerAuth
+// @Success 200 {file} file
+// @Failure 400 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 409 {object} response.Response
+// @Router /api/vouchers/{id}/pdf [get]
+func (h *VoucherHandler) PrintPDF(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse("Invalid voucher ID"))
+		return
+	}
+
+	h.renderPDF(c, []uint{uint(id)}, fmt.Sprintf("voucher-%d.pdf", id))
+}
+
+// PrintPDFBatch handles GET /api/vouchers/pdf
+// @Summary Print vouchers
+// @Description Render up to 100 active, unexpired vouchers as one printable PDF, one A6 page per voucher in the order given
+// @Tags Vouchers
+// @Produce application/pdf
+// @Param ids query string true "Comma-separated voucher IDs"
+// @Security BearerAuth
+// @Success 200 {file} file
+// @Failure 400 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 409 {object} response.Response
+// @Router /api/vouchers/pdf [get]
+func (h *VoucherHandler) PrintPDFBatch(c *gin.Context) {
+	ids, err := parseVoucherIDs(c.Query("ids"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse(err.Error()))
+		return
+	}
+
+	h.renderPDF(c, ids, "vouchers.pdf")
+}
+
+// renderPDF answers with the vouchers as a PDF attachment
+func (h *VoucherHandler) renderPDF(c *gin.Context, ids []uint, filename string) {
+	// A printed voucher shows its full code, so viewers whose codes are masked may not print
+	if h.hidesCodes(c) {
+		c.JSON(http.StatusForbidden, response.ErrorResponse("Voucher codes are masked for your role"))
+		return
+	}
+
+	data, err := h.voucherService.RenderPDF(c.Request.Context(), ids)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrVoucherNotFound):
+			c.JSON(http.StatusNotFound, response.ErrorResponse(err.Error()))
+		case errors.Is(err, service.ErrVoucherNotPrintable):
+			c.JSON(http.StatusConflict, response.ErrorResponse(err.Error()))
+		default:
+			c.JSON(http.StatusBadRequest, response.ErrorResponse(err.Error()))
+		}
+		return
+	}
+
+	c.Header("Content-Disposition", "attachment; filename="+filename)
+	c.Data(http.StatusOK, "application/pdf", data)
+}
+
+// PreviewImage handles GET /api/vouchers/:id/og-image
+// @Summary Voucher preview image
+// @Description Render a voucher's title, discount and expiry as a 1200x630 PNG for Open Graph and social sharing previews; code=true adds the code, masked for roles whose codes are masked
+// @Tags Vouchers
+// @Produce image/png
+// @Param id path int true "Voucher ID"
+// @Param code query bool false "Show the voucher code"
+// @Param If-None-Match header string false "ETag from an earlier response"
+// @Security BearerAuth
+// @Success 200 {file} file
+// @Success 304 "Image unchanged since the ETag was issued"
+// @Failure 400 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Router /api/vouchers/{id}/og-image [get]
+func (h *VoucherHandler) PreviewImage(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse("Invalid voucher ID"))
+		return
+	}
+
+	options := service.PreviewImageOptions{MaskCode: h.hidesCodes(c)}
+	if raw := c.Query("code"); raw != "" {
+		if options.ShowCode, err = strconv.ParseBool(raw); err != nil {
+			c.JSON(http.StatusBadRequest, response.ErrorResponse("Invalid code parameter: must be true or false"))
+			return
+		}
+	}
+
+	data, err := h.voucherService.RenderPreviewImage(c.Request.Context(), uint(id), options)
+	if err != nil {
+		if errors.Is(err, service.ErrVoucherNotFound) {
+			c.JSON(http.StatusNotFound, response.ErrorResponse(err.Error()))
+			return
+		}
+		c.JSON(http.StatusInternalServerError, response.ErrorResponse(err.Error()))
+		return
+	}
+
+	// The tag covers everything drawn, including the code option and whether
+	// the voucher has expired; an hour of caching bounds how long an edit
+	// takes to show on pages that embed the image
+	sum := sha256.Sum256(data)
+	etag := `"` + hex.EncodeToString(sum[:16]) + `"`
+	c.Header("ETag", etag)
+	c.Header("Cache-Control", "private, max-age=3600")
+	for _, candidate := range strings.Split(c.GetHeader("If-None-Match"), ",") {
+		if strings.TrimPrefix(strings.TrimSpace(candidate), "W/") == etag {
+			c.Status(http.StatusNotModified)
+			return
+		}
+	}
+
+	c.Data(http.StatusOK, "image/png", data)
+}
+
+// Validate handles POST /api/vouchers/validate
+// @Summary Validate a voucher
+// @Description Check whether a voucher can be applied to a purchase, evaluating its eligibility rules
+// @Tags Vouchers
+// @Accept json
+// @Produce json
+// @Param request body request.ValidateVoucherRequest true "Voucher code and purchase context"
+// @Security BearerAuth
+// @Success 200 {object} response.Response{data=service.ValidationResult}
+// @Failure 400 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Router /api/vouchers/validate [post]
+func (h *VoucherHandler) Validate(c *gin.Context) {
+	var req request.ValidateVoucherRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse("Invalid request: "+err.Error()))
+		return
+	}
+
+	result, err := h.voucherService.Validate(c.Request.Context(), req.VoucherCode, req.Context)
+	if err != nil {
+		if errors.Is(err, service.ErrVoucherNotFound) {
+			c.JSON(http.StatusNotFound, response.ErrorResponse(err.Error()))
+			return
+		}
+		c.JSON(http.StatusBadRequest, response.ErrorResponse(err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, response.SuccessResponse(result))
+}
+
+// BulkDeactivate handles POST /api/vouchers/bulk-deactivate
+// @Summary Bulk deactivate vouchers
+// @Description Immediately deactivate vouchers by code prefix (JSON) or by an uploaded CSV of codes, e.g. after a leak
+// @Tags Vouchers
+// @Accept json,multipart/form-data
+// @Produce json
+// @Param request body request.BulkDeactivateRequest false "Code prefix or list of codes"
+// @Param file formData file false "CSV file with one voucher code per row"
+// @Security BearerAuth
+// @Success 200 {object} response.Response{data=service.BulkDeactivateResult}
+// @Failure 400 {object} response.Response
+// @Router /api/vouchers/bulk-deactivate [post]
+func (h *VoucherHandler) BulkDeactivate(c *gin.Context) {
+	var req request.BulkDeactivateRequest
+
+	if strings.HasPrefix(c.ContentType(), "multipart/") {
+		file, header, err := c.Request.FormFile("file")
+		if err != nil {
+			c.JSON(http.StatusBadRequest, response.ErrorResponse("File is required"))
+			return
+		}
+		defer file.Close()
+
+		if !strings.HasSuffix(header.Filename, ".csv") {
+			c.JSON(http.StatusBadRequest, response.ErrorResponse("Only CSV files are allowed"))
+			return
+		}
+		if header.Size > 5*1024*1024 {
+			c.JSON(http.StatusBadRequest, response.ErrorResponse("File size exceeds 5MB"))
+			return
+		}
+
+		codes, err := readCodesCSV(file)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, response.ErrorResponse(err.Error()))
+			return
+		}
+		req.Codes = codes
+	} else if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse("Invalid request: "+err.Error()))
+		return
+	}
+
+	result, err := h.voucherService.BulkDeactivate(c.Request.Context(), req.Prefix, req.Codes)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse(err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, response.SuccessResponseWithMessage("Vouchers deactivated", result))
+}
+
+// BulkEdit handles PATCH /api/vouchers
+// @Summary Bulk edit vouchers
+// @Description Apply a JSON merge patch or JSON Patch document to every voucher matching the filter, in chunks with an audit entry per chunk
+// @Tags Vouchers
+// @Accept json
+// @Produce json
+// @Param request body request.BulkEditRequest true "Filter and patch document"
+// @Security BearerAuth
+// @Success 200 {object} response.Response{data=service.BulkEditResult}
+// @Failure 400 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Router /api/vouchers [patch]
+func (h *VoucherHandler) BulkEdit(c *gin.Context) {
+	var req request.BulkEditRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse("Invalid request: "+err.Error()))
+		return
+	}
+
+	cmd := req.ToCommand()
+	cmd.EditedBy = c.GetString("email")
+	cmd.Role = principalRole(c)
+
+	result, err := h.voucherService.BulkEdit(c.Request.Context(), cmd)
+	if err != nil {
+		status := http.StatusBadRequest
+		if errors.Is(err, service.ErrDiscountLimitExceeded) {
+			status = http.StatusForbidden
+		}
+		c.JSON(status, response.ErrorResponse(err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, response.SuccessResponseWithMessage("Vouchers updated", result))
+}
+
+// readCodesCSV reads voucher codes from the first column of a CSV file,
+// skipping an optional voucher_code header and blank rows
+func readCodesCSV(file io.Reader) ([]string, error) {
+	reader := csv.NewReader(file)
+	reader.FieldsPerRecord = -1
+
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CSV: %w", err)
+	}
+
+	var codes []string
+	for i, record := range records {
+		code := strings.TrimSpace(record[0])
+		if code == "" || (i == 0 && strings.EqualFold(code, "voucher_code")) {
+			continue
+		}
+		codes = append(codes, code)
+	}
+	return codes, nil
+}
+
 // ImportCSV handles POST /api/vouchers/upload-csv
 // @Summary Import vouchers from CSV
 // @Description Upload a CSV file to bulk import vouchers
@@ -188,12 +852,24 @@ func (h *VoucherHandler) Delete(c *gin.Context) {
 // @Accept multipart/form-data
 // @Produce json
 // @Param file formData file true "CSV file"
+// @Param schema_version formData string false "CSV schema version of the file (1, 2 or 3); detected from its header row when omitted"
+// @Param X-CSV-Schema-Version header string false "Same as the schema_version form field"
 // @Security BearerAuth
 // @Success 200 {object} response.Response{data=service.ImportResult}
 // @Failure 400 {object} response.Response
 // @Failure 500 {object} response.Response
 // @Router /api/vouchers/upload-csv [post]
 func (h *VoucherHandler) ImportCSV(c *gin.Context) {
+	declared := c.PostForm("schema_version")
+	if declared == "" {
+		declared = c.GetHeader("X-CSV-Schema-Version")
+	}
+	schemaVersion, err := parseCSVSchemaVersion(declared)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse(err.Error()))
+		return
+	}
+
 	file, header, err := c.Request.FormFile("file")
 	if err != nil {
 		c.JSON(http.StatusBadRequest, response.ErrorResponse("File is required"))
@@ -212,7 +888,7 @@ func (h *VoucherHandler) ImportCSV(c *gin.Context) {
 		return
 	}
 
-	result, err := h.voucherService.ImportVouchers(file)
+	result, err := h.voucherService.ImportVouchers(c.Request.Context(), file, schemaVersion, c.GetString("email"), principalRole(c))
 	if err != nil {
 		c.JSON(http.StatusBadRequest, response.ErrorResponse(err.Error()))
 		return
@@ -221,6 +897,44 @@ func (h *VoucherHandler) ImportCSV(c *gin.Context) {
 	c.JSON(http.StatusOK, response.SuccessResponseWithMessage("CSV import completed", result))
 }
 
+// ImportTemplate handles GET /api/vouchers/import-template
+// @Summary Download the voucher import template
+// @Description Download the header row of the current CSV schema version and an example row, as CSV or as an Excel workbook to fill in and save as CSV
+// @Tags Vouchers
+// @Produce text/csv,application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Param format query string false "csv (default) or xlsx"
+// @Security BearerAuth
+// @Success 200 {file} file
+// @Failure 400 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /api/vouchers/import-template [get]
+func (h *VoucherHandler) ImportTemplate(c *gin.Context) {
+	rows := h.voucherService.ImportTemplate()
+	c.Header("X-CSV-Schema-Version", strconv.Itoa(service.CurrentCSVSchemaVersion))
+
+	switch format := c.DefaultQuery("format", "csv"); format {
+	case "csv":
+		var buf bytes.Buffer
+		writer := csv.NewWriter(&buf)
+		if err := writer.WriteAll(rows); err != nil {
+			c.JSON(http.StatusInternalServerError, response.ErrorResponse(err.Error()))
+			return
+		}
+		c.Header("Content-Disposition", "attachment; filename=voucher-import-template.csv")
+		c.Data(http.StatusOK, "text/csv", buf.Bytes())
+	case "xlsx":
+		data, err := xlsx.Bytes("Vouchers", rows)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, response.ErrorResponse(err.Error()))
+			return
+		}
+		c.Header("Content-Disposition", "attachment; filename=voucher-import-template.xlsx")
+		c.Data(http.StatusOK, xlsx.ContentType, data)
+	default:
+		c.JSON(http.StatusBadRequest, response.ErrorResponse(fmt.Sprintf("Unsupported format '%s': use csv or xlsx", format)))
+	}
+}
+
 // UploadBatch handles POST /api/vouchers/upload-batch
 // @Summary Upload batch of vouchers
 // @Description Upload a batch of vouchers with duplicate checking
@@ -246,7 +960,14 @@ func (h *VoucherHandler) UploadBatch(c *gin.Context) {
 		return
 	}
 
-	result, err := h.voucherService.ImportBatch(req.Vouchers)
+	commands := req.ToCommands()
+	role := principalRole(c)
+	for i := range commands {
+		commands[i].CreatedBy = c.GetString("email")
+		commands[i].Role = role
+	}
+
+	result, err := h.voucherService.ImportBatch(c.Request.Context(), commands)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, response.ErrorResponse(err.Error()))
 		return
@@ -257,21 +978,209 @@ func (h *VoucherHandler) UploadBatch(c *gin.Context) {
 
 // ExportCSV handles GET /api/vouchers/export
 // @Summary Export vouchers to CSV
-// @Description Download all vouchers as a CSV file
+// @Description Download all vouchers as a CSV file, optionally encrypted with AES-256-GCM.
+// @Description With split_rows or Accept: application/zip the export is streamed as a ZIP of CSV files.
+// @Description With updated_since only vouchers changed after that instant are exported, deleted ones as tombstones.
 // @Tags Vouchers
-// @Produce text/csv
+// @Produce text/csv,application/zip
+// @Param X-Export-Passphrase header string false "Encrypt the file with this passphrase (min 8 characters)"
+// @Param split_rows query int false "Split into CSV files of at most this many rows and return a ZIP"
+// @Param updated_since query string false "Only export vouchers created, updated or deleted after this RFC 3339 instant"
+// @Param schema_version query string false "CSV schema version to export (1, 2 or 3, default 3); delta exports are always the latest"
 // @Security BearerAuth
 // @Success 200 {file} file
+// @Failure 400 {object} response.Response
+// @Failure 403 {object} response.Response
 // @Failure 500 {object} response.Response
 // @Router /api/vouchers/export [get]
 func (h *VoucherHandler) ExportCSV(c *gin.Context) {
-	data, err := h.voucherService.ExportVouchers()
+	// Exports carry full codes so they can be imported again, so viewers whose codes are masked may not export
+	if h.hidesCodes(c) {
+		c.JSON(http.StatusForbidden, response.ErrorResponse("Voucher codes are masked for your role"))
+		return
+	}
+
+	passphrase := c.GetHeader("X-Export-Passphrase")
+	if passphrase != "" && len(passphrase) < exportcrypt.MinPassphraseLength {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse("Export passphrase is too short"))
+		return
+	}
+
+	var since *time.Time
+	if updatedSince := c.Query("updated_since"); updatedSince != "" {
+		parsed, err := time.Parse(time.RFC3339, updatedSince)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, response.ErrorResponse("updated_since must be an RFC 3339 timestamp"))
+			return
+		}
+		since = &parsed
+	}
+
+	schemaVersion, err := parseCSVSchemaVersion(c.Query("schema_version"))
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, response.ErrorResponse(err.Error()))
+		c.JSON(http.StatusBadRequest, response.ErrorResponse(err.Error()))
+		return
+	}
+	if schemaVersion == 0 {
+		schemaVersion = service.CurrentCSVSchemaVersion
+	}
+	if since != nil && schemaVersion != service.CurrentCSVSchemaVersion {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse(
+			fmt.Sprintf("Delta exports are only available in CSV schema version %d", service.CurrentCSVSchemaVersion)))
+		return
+	}
+	c.Header("X-CSV-Schema-Version", strconv.Itoa(schemaVersion))
+
+	splitRows := c.Query("split_rows")
+	if splitRows != "" || strings.Contains(c.GetHeader("Accept"), "application/zip") {
+		if since != nil {
+			c.JSON(http.StatusBadRequest, response.ErrorResponse("Delta exports cannot be split into a ZIP"))
+			return
+		}
+		if passphrase != "" {
+			c.JSON(http.StatusBadRequest, response.ErrorResponse("Encrypted exports cannot be split into a ZIP"))
+			return
+		}
+
+		rowsPerFile := defaultExportRowsPerFile
+		if splitRows != "" {
+			rows, err := strconv.Atoi(splitRows)
+			if err != nil || rows < 1 || rows > maxExportRowsPerFile {
+				c.JSON(http.StatusBadRequest, response.ErrorResponse(
+					fmt.Sprintf("split_rows must be between 1 and %d", maxExportRowsPerFile)))
+				return
+			}
+			rowsPerFile = rows
+		}
+
+		h.exportZip(c, rowsPerFile, schemaVersion)
+		return
+	}
+
+	filename := "vouchers.csv"
+	var data []byte
+	if since != nil {
+		// Taken before the query, so passing it as the next updated_since
+		// repeats rather than misses changes made while this export runs
+		asOf := time.Now().UTC()
+		data, err = h.voucherService.ExportVoucherChanges(c.Request.Context(), *since)
+		c.Header("X-Export-As-Of", asOf.Format(time.RFC3339Nano))
+		filename = "vouchers-changes.csv"
+	} else {
+		data, err = h.voucherService.ExportVouchers(c.Request.Context(), schemaVersion)
+	}
+	if err != nil {
+		c.JSON(exportErrorStatus(err), response.ErrorResponse(err.Error()))
+		return
+	}
+
+	// Voucher codes are worth money, so exports can be encrypted before they leave the server
+	if passphrase != "" {
+		encrypted, err := exportcrypt.Encrypt(data, passphrase)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, response.ErrorResponse(err.Error()))
+			return
+		}
+
+		c.Header("Content-Disposition", "attachment; filename="+filename+".enc")
+		c.Data(http.StatusOK, "application/octet-stream", encrypted)
 		return
 	}
 
 	c.Header("Content-Type", "text/csv")
-	c.Header("Content-Disposition", "attachment; filename=vouchers.csv")
+	c.Header("Content-Disposition", "attachment; filename="+filename)
 	c.Data(http.StatusOK, "text/csv", data)
 }
+
+// exportZip streams the export as a ZIP archive of numbered CSV files.
+// Headers are sent with the first file, so later failures can only be logged
+// and surface to the client as a truncated archive.
+func (h *VoucherHandler) exportZip(c *gin.Context, rowsPerFile, schemaVersion int) {
+	var archive *zip.Writer
+	part := 0
+
+	err := h.voucherService.ExportVoucherParts(c.Request.Context(), rowsPerFile, schemaVersion, func(data []byte) error {
+		if archive == nil {
+			c.Header("Content-Type", "application/zip")
+			c.Header("Content-Disposition", "attachment; filename=vouchers.zip")
+			c.Status(http.StatusOK)
+			archive = zip.NewWriter(c.Writer)
+		}
+
+		part++
+		file, err := archive.Create(fmt.Sprintf("vouchers-%04d.csv", part))
+		if err != nil {
+			return err
+		}
+		if _, err := file.Write(data); err != nil {
+			return err
+		}
+		return archive.Flush()
+	})
+
+	if archive == nil {
+		if err == nil {
+			err = errors.New("export produced no files")
+		}
+		c.JSON(exportErrorStatus(err), response.ErrorResponse(err.Error()))
+		return
+	}
+	if err != nil {
+		logger.FromContext(c.Request.Context()).Error("voucher ZIP export aborted", "files", part, "error", err)
+		return
+	}
+	if err := archive.Close(); err != nil {
+		logger.FromContext(c.Request.Context()).Error("failed to finish voucher ZIP export", "error", err)
+	}
+}
+
+// exportErrorStatus maps an export failure to its HTTP status
+func exportErrorStatus(err error) int {
+	if errors.Is(err, service.ErrUnsupportedCSVSchema) {
+		return http.StatusBadRequest
+	}
+	return http.StatusInternalServerError
+}
+
+// parseCSVSchemaVersion reads a declared CSV schema version such as "2" or
+// "v2"; an empty value means none was declared and returns 0
+func parseCSVSchemaVersion(raw string) (int, error) {
+	raw = strings.TrimPrefix(strings.ToLower(strings.TrimSpace(raw)), "v")
+	if raw == "" {
+		return 0, nil
+	}
+	version, err := strconv.Atoi(raw)
+	if err != nil || version < 1 {
+		return 0, fmt.Errorf("invalid CSV schema version '%s'", raw)
+	}
+	return version, nil
+}
+
+// voucherETag identifies a version of a voucher. Every write bumps UpdatedAt,
+// so the tag changes whenever the voucher does. A copy with a masked code is
+// tagged apart, so a cache shared across roles never serves one for the other.
+func voucherETag(voucher *entity.Voucher, masked bool) string {
+	if masked {
+		return fmt.Sprintf(`W/"%d-%d-masked"`, voucher.ID, voucher.UpdatedAt.UnixNano())
+	}
+	return fmt.Sprintf(`W/"%d-%d"`, voucher.ID, voucher.UpdatedAt.UnixNano())
+}
+
+// notModified sets the caching headers for a single voucher and answers 304
+// when the client's If-None-Match still matches. Responses are private since
+// they require authentication, and no-cache makes clients revalidate each
+// time, so an edit is never hidden behind a stale copy.
+func notModified(c *gin.Context, voucher *entity.Voucher, masked bool) bool {
+	etag := voucherETag(voucher, masked)
+	c.Header("ETag", etag)
+	c.Header("Cache-Control", "private, no-cache")
+
+	for _, candidate := range strings.Split(c.GetHeader("If-None-Match"), ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == strings.TrimPrefix(etag, "W/") {
+			c.Status(http.StatusNotModified)
+			return true
+		}
+	}
+	return false
+}
@@ -1,12 +1,17 @@
 package handler
 
 import (
+	"archive/zip"
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"mime/multipart"
 	"net/http"
 	"net/http/httptest"
+	"strconv"
+	"strings"
 	"testing"
 	"time"
 
@@ -14,24 +19,28 @@ import (
 	"github.com/shoelfikar/voucher-management-system/internal/delivery/http/request"
 	"github.com/shoelfikar/voucher-management-system/internal/domain/entity"
 	"github.com/shoelfikar/voucher-management-system/internal/domain/service"
+	"github.com/shoelfikar/voucher-management-system/pkg/exportcrypt"
+	"github.com/shoelfikar/voucher-management-system/pkg/rules"
+	"github.com/shoelfikar/voucher-management-system/pkg/utils"
+	"github.com/shoelfikar/voucher-management-system/pkg/xlsx"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
 
-// MockVoucherService is a mock implementation of VoucherService
+// MockVoucherService is a mock implementation of VoucherService that ignores the context argument
 type MockVoucherService struct {
 	mock.Mock
 }
 
-func (m *MockVoucherService) GetAll(page, limit int, search, sortBy, sortOrder string) ([]*entity.Voucher, int64, error) {
-	args := m.Called(page, limit, search, sortBy, sortOrder)
+func (m *MockVoucherService) GetAll(ctx context.Context, page, limit int, search string, sort []utils.SortField) ([]*entity.Voucher, int64, error) {
+	args := m.Called(page, limit, search, sort)
 	if args.Get(0) == nil {
 		return nil, args.Get(1).(int64), args.Error(2)
 	}
 	return args.Get(0).([]*entity.Voucher), args.Get(1).(int64), args.Error(2)
 }
 
-func (m *MockVoucherService) GetByID(id uint) (*entity.Voucher, error) {
+func (m *MockVoucherService) GetByID(ctx context.Context, id uint) (*entity.Voucher, error) {
 	args := m.Called(id)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
@@ -39,36 +48,133 @@ func (m *MockVoucherService) GetByID(id uint) (*entity.Voucher, error) {
 	return args.Get(0).(*entity.Voucher), args.Error(1)
 }
 
-func (m *MockVoucherService) Create(req *request.CreateVoucherRequest) (*entity.Voucher, error) {
-	args := m.Called(req)
+func (m *MockVoucherService) GetByIDs(ctx context.Context, ids []uint) ([]*entity.Voucher, error) {
+	args := m.Called(ids)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.Voucher), args.Error(1)
+}
+
+func (m *MockVoucherService) GetByCodes(ctx context.Context, codes []string) ([]*entity.Voucher, error) {
+	args := m.Called(codes)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.Voucher), args.Error(1)
+}
+
+func (m *MockVoucherService) Create(ctx context.Context, cmd *service.CreateVoucherCommand) (*entity.Voucher, error) {
+	args := m.Called(cmd)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).(*entity.Voucher), args.Error(1)
 }
 
-func (m *MockVoucherService) Update(id uint, req *request.UpdateVoucherRequest) (*entity.Voucher, error) {
-	args := m.Called(id, req)
+func (m *MockVoucherService) Update(ctx context.Context, id uint, cmd *service.UpdateVoucherCommand) (*entity.Voucher, error) {
+	args := m.Called(id, cmd)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).(*entity.Voucher), args.Error(1)
 }
 
-func (m *MockVoucherService) Delete(id uint) error {
+func (m *MockVoucherService) Delete(ctx context.Context, id uint) error {
 	args := m.Called(id)
 	return args.Error(0)
 }
 
-func (m *MockVoucherService) ImportVouchers(file multipart.File) (*service.ImportResult, error) {
-	args := m.Called(file)
+func (m *MockVoucherService) Approve(ctx context.Context, id uint, approvedBy string) (*entity.Voucher, error) {
+	args := m.Called(id, approvedBy)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Voucher), args.Error(1)
+}
+
+func (m *MockVoucherService) Validate(ctx context.Context, code string, purchase rules.Context) (*service.ValidationResult, error) {
+	args := m.Called(code, purchase)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*service.ValidationResult), args.Error(1)
+}
+
+func (m *MockVoucherService) Suggest(ctx context.Context, query string) ([]string, error) {
+	args := m.Called(query)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (m *MockVoucherService) CodeAvailable(ctx context.Context, code string) (bool, error) {
+	args := m.Called(code)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockVoucherService) GetByExternalID(ctx context.Context, externalID string) (*entity.Voucher, error) {
+	args := m.Called(externalID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Voucher), args.Error(1)
+}
+
+func (m *MockVoucherService) ExportVoucherChanges(ctx context.Context, since time.Time) ([]byte, error) {
+	args := m.Called(since)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]byte), args.Error(1)
+}
+
+func (m *MockVoucherService) ExportVoucherParts(ctx context.Context, rowsPerFile, schemaVersion int, emit func(data []byte) error) error {
+	args := m.Called(rowsPerFile, schemaVersion, emit)
+	if parts, ok := args.Get(0).([][]byte); ok {
+		for _, part := range parts {
+			if err := emit(part); err != nil {
+				return err
+			}
+		}
+	}
+	return args.Error(1)
+}
+
+func (m *MockVoucherService) BulkEdit(ctx context.Context, cmd *service.BulkEditCommand) (*service.BulkEditResult, error) {
+	args := m.Called(cmd)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*service.BulkEditResult), args.Error(1)
+}
+
+func (m *MockVoucherService) BulkDeactivate(ctx context.Context, prefix string, codes []string) (*service.BulkDeactivateResult, error) {
+	args := m.Called(prefix, codes)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*service.BulkDeactivateResult), args.Error(1)
+}
+
+func (m *MockVoucherService) ImportVouchers(ctx context.Context, file multipart.File, schemaVersion int, importedBy, role string) (*service.ImportResult, error) {
+	args := m.Called(file, schemaVersion, importedBy, role)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*service.ImportResult), args.Error(1)
+}
+
+func (m *MockVoucherService) ImportRecords(ctx context.Context, records [][]string, importedBy, role string) (*service.ImportResult, error) {
+	args := m.Called(records, importedBy, role)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).(*service.ImportResult), args.Error(1)
 }
 
-func (m *MockVoucherService) ImportBatch(vouchers []request.CreateVoucherRequest) (*service.BatchImportResult, error) {
+func (m *MockVoucherService) ImportBatch(ctx context.Context, vouchers []service.CreateVoucherCommand) (*service.BatchImportResult, error) {
 	args := m.Called(vouchers)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
@@ -76,8 +182,45 @@ func (m *MockVoucherService) ImportBatch(vouchers []request.CreateVoucherRequest
 	return args.Get(0).(*service.BatchImportResult), args.Error(1)
 }
 
-func (m *MockVoucherService) ExportVouchers() ([]byte, error) {
+func (m *MockVoucherService) ImportTemplate() [][]string {
 	args := m.Called()
+	return args.Get(0).([][]string)
+}
+
+func (m *MockVoucherService) ExportVouchers(ctx context.Context, schemaVersion int) ([]byte, error) {
+	args := m.Called(schemaVersion)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]byte), args.Error(1)
+}
+
+func (m *MockVoucherService) Assign(ctx context.Context, id uint, customerID, assignedBy string) (*entity.VoucherAssignment, error) {
+	args := m.Called(id, customerID, assignedBy)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.VoucherAssignment), args.Error(1)
+}
+
+func (m *MockVoucherService) GetAssignments(ctx context.Context, id uint, page, limit int) ([]*entity.VoucherAssignment, int64, error) {
+	args := m.Called(id, page, limit)
+	if args.Get(0) == nil {
+		return nil, args.Get(1).(int64), args.Error(2)
+	}
+	return args.Get(0).([]*entity.VoucherAssignment), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockVoucherService) RenderPDF(ctx context.Context, ids []uint) ([]byte, error) {
+	args := m.Called(ids)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]byte), args.Error(1)
+}
+
+func (m *MockVoucherService) RenderPreviewImage(ctx context.Context, id uint, options service.PreviewImageOptions) ([]byte, error) {
+	args := m.Called(id, options)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
@@ -104,7 +247,7 @@ func TestVoucherHandler_GetAll_Success(t *testing.T) {
 	}
 	total := int64(2)
 
-	mockService.On("GetAll", 1, 10, "", "created_at", "desc").Return(vouchers, total, nil)
+	mockService.On("GetAll", 1, 10, "", []utils.SortField{{Field: "created_at", Desc: true}}).Return(vouchers, total, nil)
 
 	req, _ := http.NewRequest("GET", "/vouchers?page=1&limit=10&sort_by=created_at&sort_order=desc", nil)
 	w := httptest.NewRecorder()
@@ -136,7 +279,7 @@ func TestVoucherHandler_GetAll_WithSearch(t *testing.T) {
 	}
 	total := int64(1)
 
-	mockService.On("GetAll", 1, 10, "TEST", "created_at", "desc").Return(vouchers, total, nil)
+	mockService.On("GetAll", 1, 10, "TEST", []utils.SortField{{Field: "created_at", Desc: true}}).Return(vouchers, total, nil)
 
 	req, _ := http.NewRequest("GET", "/vouchers?page=1&limit=10&search=TEST&sort_by=created_at&sort_order=desc", nil)
 	w := httptest.NewRecorder()
@@ -149,6 +292,45 @@ func TestVoucherHandler_GetAll_WithSearch(t *testing.T) {
 	mockService.AssertExpectations(t)
 }
 
+func TestVoucherHandler_GetAll_MultiColumnSort(t *testing.T) {
+	// Arrange
+	mockService := new(MockVoucherService)
+	voucherHandler := NewVoucherHandler(mockService)
+	router := setupVoucherTestRouter()
+	router.GET("/vouchers", voucherHandler.GetAll)
+
+	sort := []utils.SortField{{Field: "expiry_date"}, {Field: "discount_percent", Desc: true}}
+	mockService.On("GetAll", 1, 10, "", sort).Return([]*entity.Voucher{}, int64(0), nil)
+
+	req, _ := http.NewRequest("GET", "/vouchers?sort=expiry_date:asc,discount_percent:desc", nil)
+	w := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	mockService.AssertExpectations(t)
+}
+
+func TestVoucherHandler_GetAll_InvalidSortField(t *testing.T) {
+	// Arrange
+	mockService := new(MockVoucherService)
+	voucherHandler := NewVoucherHandler(mockService)
+	router := setupVoucherTestRouter()
+	router.GET("/vouchers", voucherHandler.GetAll)
+
+	req, _ := http.NewRequest("GET", "/vouchers?sort=password:asc", nil)
+	w := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	mockService.AssertNotCalled(t, "GetAll", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
 func TestVoucherHandler_GetAll_ServiceError(t *testing.T) {
 	// Arrange
 	mockService := new(MockVoucherService)
@@ -157,7 +339,7 @@ func TestVoucherHandler_GetAll_ServiceError(t *testing.T) {
 	router.GET("/vouchers", voucherHandler.GetAll)
 
 	serviceError := errors.New("database error")
-	mockService.On("GetAll", 1, 10, "", "created_at", "desc").Return(nil, int64(0), serviceError)
+	mockService.On("GetAll", 1, 10, "", []utils.SortField{{Field: "created_at", Desc: true}}).Return(nil, int64(0), serviceError)
 
 	req, _ := http.NewRequest("GET", "/vouchers", nil)
 	w := httptest.NewRecorder()
@@ -176,23 +358,16 @@ func TestVoucherHandler_GetAll_ServiceError(t *testing.T) {
 	mockService.AssertExpectations(t)
 }
 
-// Test GetByID
-func TestVoucherHandler_GetByID_Success(t *testing.T) {
+func TestVoucherHandler_GetAll_ClampsPagination(t *testing.T) {
 	// Arrange
 	mockService := new(MockVoucherService)
 	voucherHandler := NewVoucherHandler(mockService)
 	router := setupVoucherTestRouter()
-	router.GET("/vouchers/:id", voucherHandler.GetByID)
-
-	voucher := &entity.Voucher{
-		ID:              1,
-		VoucherCode:     "TEST123",
-		DiscountPercent: 10.0,
-	}
+	router.GET("/vouchers", voucherHandler.GetAll)
 
-	mockService.On("GetByID", uint(1)).Return(voucher, nil)
+	mockService.On("GetAll", utils.DefaultPage, utils.DefaultLimit, "", mock.Anything).Return([]*entity.Voucher{}, int64(0), nil)
 
-	req, _ := http.NewRequest("GET", "/vouchers/1", nil)
+	req, _ := http.NewRequest("GET", "/vouchers?page=0&limit=0", nil)
 	w := httptest.NewRecorder()
 
 	// Act
@@ -200,148 +375,120 @@ func TestVoucherHandler_GetByID_Success(t *testing.T) {
 
 	// Assert
 	assert.Equal(t, http.StatusOK, w.Code)
-
-	var response map[string]interface{}
-	err := json.Unmarshal(w.Body.Bytes(), &response)
-	assert.NoError(t, err)
-	assert.Equal(t, "success", response["status"])
-	assert.NotNil(t, response["data"])
-
 	mockService.AssertExpectations(t)
 }
 
-func TestVoucherHandler_GetByID_InvalidID(t *testing.T) {
+// Test GetByID
+func TestVoucherHandler_GetAll_HeadReturnsCountOnly(t *testing.T) {
 	// Arrange
 	mockService := new(MockVoucherService)
 	voucherHandler := NewVoucherHandler(mockService)
 	router := setupVoucherTestRouter()
-	router.GET("/vouchers/:id", voucherHandler.GetByID)
+	router.HEAD("/vouchers", voucherHandler.GetAll)
 
-	req, _ := http.NewRequest("GET", "/vouchers/invalid", nil)
+	vouchers := []*entity.Voucher{{ID: 1, VoucherCode: "TEST1"}}
+	mockService.On("GetAll", 1, 10, "", mock.Anything).Return(vouchers, int64(37), nil)
+
+	req, _ := http.NewRequest("HEAD", "/vouchers", nil)
 	w := httptest.NewRecorder()
 
 	// Act
 	router.ServeHTTP(w, req)
 
 	// Assert
-	assert.Equal(t, http.StatusBadRequest, w.Code)
-
-	var response map[string]interface{}
-	err := json.Unmarshal(w.Body.Bytes(), &response)
-	assert.NoError(t, err)
-	assert.Equal(t, "error", response["status"])
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "37", w.Header().Get("X-Total-Count"))
+	assert.Empty(t, w.Body.String())
 }
 
-func TestVoucherHandler_GetByID_NotFound(t *testing.T) {
+func TestVoucherHandler_GetAll_MasksCodesWithoutKnownRole(t *testing.T) {
 	// Arrange
 	mockService := new(MockVoucherService)
-	voucherHandler := NewVoucherHandler(mockService)
+	voucherHandler := NewVoucherHandler(mockService, WithViewerCodeMasking())
 	router := setupVoucherTestRouter()
-	router.GET("/vouchers/:id", voucherHandler.GetByID)
+	router.GET("/vouchers", voucherHandler.GetAll)
 
-	notFoundError := errors.New("voucher not found")
-	mockService.On("GetByID", uint(999)).Return(nil, notFoundError)
+	vouchers := []*entity.Voucher{{ID: 1, VoucherCode: "SUMMER2024"}}
+	mockService.On("GetAll", 1, 10, "", mock.Anything).Return(vouchers, int64(1), nil)
 
-	req, _ := http.NewRequest("GET", "/vouchers/999", nil)
+	req, _ := http.NewRequest("GET", "/vouchers", nil)
 	w := httptest.NewRecorder()
 
 	// Act
 	router.ServeHTTP(w, req)
 
 	// Assert
-	assert.Equal(t, http.StatusNotFound, w.Code)
-
-	var response map[string]interface{}
-	err := json.Unmarshal(w.Body.Bytes(), &response)
-	assert.NoError(t, err)
-	assert.Equal(t, "error", response["status"])
-
-	mockService.AssertExpectations(t)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"voucher_code":"SUMM****24"`)
+	assert.Equal(t, "SUMMER2024", vouchers[0].VoucherCode)
 }
 
-// Test Create Voucher
-func TestVoucherHandler_Create_Success(t *testing.T) {
+func TestVoucherHandler_GetAll_MaskingDisabledByDefault(t *testing.T) {
 	// Arrange
 	mockService := new(MockVoucherService)
 	voucherHandler := NewVoucherHandler(mockService)
 	router := setupVoucherTestRouter()
-	router.POST("/vouchers", voucherHandler.Create)
-
-	tomorrow := time.Now().Add(24 * time.Hour).Format("2006-01-02")
-	createReq := request.CreateVoucherRequest{
-		VoucherCode:     "TEST123",
-		DiscountPercent: 10.0,
-		ExpiryDate:      tomorrow,
-	}
-
-	createdVoucher := &entity.Voucher{
-		ID:              1,
-		VoucherCode:     createReq.VoucherCode,
-		DiscountPercent: createReq.DiscountPercent,
-	}
+	router.GET("/vouchers", voucherHandler.GetAll)
 
-	mockService.On("Create", mock.AnythingOfType("*request.CreateVoucherRequest")).Return(createdVoucher, nil)
+	vouchers := []*entity.Voucher{{ID: 1, VoucherCode: "SUMMER2024"}}
+	mockService.On("GetAll", 1, 10, "", mock.Anything).Return(vouchers, int64(1), nil)
 
-	requestBody, _ := json.Marshal(createReq)
-	req, _ := http.NewRequest("POST", "/vouchers", bytes.NewBuffer(requestBody))
-	req.Header.Set("Content-Type", "application/json")
+	req, _ := http.NewRequest("GET", "/vouchers", nil)
 	w := httptest.NewRecorder()
 
 	// Act
 	router.ServeHTTP(w, req)
 
 	// Assert
-	assert.Equal(t, http.StatusCreated, w.Code)
-
-	var response map[string]interface{}
-	err := json.Unmarshal(w.Body.Bytes(), &response)
-	assert.NoError(t, err)
-	assert.Equal(t, "success", response["status"])
-	assert.NotNil(t, response["data"])
-
-	mockService.AssertExpectations(t)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"voucher_code":"SUMMER2024"`)
 }
 
-func TestVoucherHandler_Create_InvalidJSON(t *testing.T) {
+func TestVoucherHandler_GetAll_ByIDs(t *testing.T) {
 	// Arrange
 	mockService := new(MockVoucherService)
 	voucherHandler := NewVoucherHandler(mockService)
 	router := setupVoucherTestRouter()
-	router.POST("/vouchers", voucherHandler.Create)
+	router.GET("/vouchers", voucherHandler.GetAll)
 
-	invalidJSON := []byte(`{"voucher_code": "TEST123", "discount_percent":}`)
-	req, _ := http.NewRequest("POST", "/vouchers", bytes.NewBuffer(invalidJSON))
-	req.Header.Set("Content-Type", "application/json")
+	vouchers := []*entity.Voucher{
+		{ID: 1, VoucherCode: "TEST1"},
+		{ID: 3, VoucherCode: "TEST3"},
+	}
+	mockService.On("GetByIDs", []uint{1, 2, 3}).Return(vouchers, nil)
+
+	req, _ := http.NewRequest("GET", "/vouchers?ids=1,2,3&page=5", nil)
 	w := httptest.NewRecorder()
 
 	// Act
 	router.ServeHTTP(w, req)
 
 	// Assert
-	assert.Equal(t, http.StatusBadRequest, w.Code)
-
-	var response map[string]interface{}
-	err := json.Unmarshal(w.Body.Bytes(), &response)
-	assert.NoError(t, err)
-	assert.Equal(t, "error", response["status"])
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "2", w.Header().Get("X-Total-Count"))
+
+	var body struct {
+		Data struct {
+			Vouchers []struct {
+				ID uint `json:"id"`
+			} `json:"vouchers"`
+		} `json:"data"`
+	}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+	if assert.Len(t, body.Data.Vouchers, 2) {
+		assert.Equal(t, uint(3), body.Data.Vouchers[1].ID)
+	}
+	mockService.AssertNotCalled(t, "GetAll", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
 }
 
-func TestVoucherHandler_Create_ValidationError(t *testing.T) {
+func TestVoucherHandler_GetAll_InvalidIDs(t *testing.T) {
 	// Arrange
 	mockService := new(MockVoucherService)
 	voucherHandler := NewVoucherHandler(mockService)
 	router := setupVoucherTestRouter()
-	router.POST("/vouchers", voucherHandler.Create)
-
-	createReq := request.CreateVoucherRequest{
-		VoucherCode:     "",
-		DiscountPercent: 10.0,
-		ExpiryDate:      "2025-12-31",
-	}
+	router.GET("/vouchers", voucherHandler.GetAll)
 
-	requestBody, _ := json.Marshal(createReq)
-	req, _ := http.NewRequest("POST", "/vouchers", bytes.NewBuffer(requestBody))
-	req.Header.Set("Content-Type", "application/json")
+	req, _ := http.NewRequest("GET", "/vouchers?ids=1,abc", nil)
 	w := httptest.NewRecorder()
 
 	// Act
@@ -349,32 +496,22 @@ func TestVoucherHandler_Create_ValidationError(t *testing.T) {
 
 	// Assert
 	assert.Equal(t, http.StatusBadRequest, w.Code)
-
-	var response map[string]interface{}
-	err := json.Unmarshal(w.Body.Bytes(), &response)
-	assert.NoError(t, err)
-	assert.Equal(t, "error", response["status"])
+	assert.Contains(t, w.Body.String(), "Invalid voucher ID 'abc'")
+	mockService.AssertNotCalled(t, "GetByIDs", mock.Anything)
 }
 
-func TestVoucherHandler_Create_ServiceError(t *testing.T) {
+func TestVoucherHandler_Lookup_Success(t *testing.T) {
 	// Arrange
 	mockService := new(MockVoucherService)
 	voucherHandler := NewVoucherHandler(mockService)
 	router := setupVoucherTestRouter()
-	router.POST("/vouchers", voucherHandler.Create)
-
-	tomorrow := time.Now().Add(24 * time.Hour).Format("2006-01-02")
-	createReq := request.CreateVoucherRequest{
-		VoucherCode:     "TEST123",
-		DiscountPercent: 10.0,
-		ExpiryDate:      tomorrow,
-	}
+	router.POST("/vouchers/lookup", voucherHandler.Lookup)
 
-	serviceError := errors.New("voucher code already exists")
-	mockService.On("Create", mock.AnythingOfType("*request.CreateVoucherRequest")).Return(nil, serviceError)
+	codes := []string{"TEST1", "missing"}
+	mockService.On("GetByCodes", codes).Return([]*entity.Voucher{{ID: 1, VoucherCode: "TEST1"}}, nil)
 
-	requestBody, _ := json.Marshal(createReq)
-	req, _ := http.NewRequest("POST", "/vouchers", bytes.NewBuffer(requestBody))
+	reqBody, _ := json.Marshal(request.VoucherLookupRequest{Codes: codes})
+	req, _ := http.NewRequest("POST", "/vouchers/lookup", bytes.NewBuffer(reqBody))
 	req.Header.Set("Content-Type", "application/json")
 	w := httptest.NewRecorder()
 
@@ -382,42 +519,49 @@ func TestVoucherHandler_Create_ServiceError(t *testing.T) {
 	router.ServeHTTP(w, req)
 
 	// Assert
-	assert.Equal(t, http.StatusBadRequest, w.Code)
-
-	var response map[string]interface{}
-	err := json.Unmarshal(w.Body.Bytes(), &response)
-	assert.NoError(t, err)
-	assert.Equal(t, "error", response["status"])
-
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"voucher_code":"TEST1"`)
 	mockService.AssertExpectations(t)
 }
 
-// Test Update Voucher
-func TestVoucherHandler_Update_Success(t *testing.T) {
+func TestVoucherHandler_Lookup_TooManyCodes(t *testing.T) {
 	// Arrange
 	mockService := new(MockVoucherService)
 	voucherHandler := NewVoucherHandler(mockService)
 	router := setupVoucherTestRouter()
-	router.PUT("/vouchers/:id", voucherHandler.Update)
+	router.POST("/vouchers/lookup", voucherHandler.Lookup)
 
-	tomorrow := time.Now().Add(24 * time.Hour).Format("2006-01-02")
-	updateReq := request.UpdateVoucherRequest{
-		VoucherCode:     "UPDATED123",
-		DiscountPercent: 15.0,
-		ExpiryDate:      tomorrow,
-	}
+	mockService.On("GetByCodes", mock.Anything).Return(nil, errors.New("at most 100 vouchers can be requested at once"))
 
-	updatedVoucher := &entity.Voucher{
+	reqBody, _ := json.Marshal(request.VoucherLookupRequest{Codes: []string{"A", "B"}})
+	req, _ := http.NewRequest("POST", "/vouchers/lookup", bytes.NewBuffer(reqBody))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "at most 100")
+}
+
+func TestVoucherHandler_GetByID_Success(t *testing.T) {
+	// Arrange
+	mockService := new(MockVoucherService)
+	voucherHandler := NewVoucherHandler(mockService)
+	router := setupVoucherTestRouter()
+	router.GET("/vouchers/:id", voucherHandler.GetByID)
+
+	voucher := &entity.Voucher{
 		ID:              1,
-		VoucherCode:     updateReq.VoucherCode,
-		DiscountPercent: updateReq.DiscountPercent,
+		VoucherCode:     "TEST123",
+		DiscountPercent: 10.0,
 	}
 
-	mockService.On("Update", uint(1), mock.AnythingOfType("*request.UpdateVoucherRequest")).Return(updatedVoucher, nil)
+	mockService.On("GetByID", uint(1)).Return(voucher, nil)
 
-	requestBody, _ := json.Marshal(updateReq)
-	req, _ := http.NewRequest("PUT", "/vouchers/1", bytes.NewBuffer(requestBody))
-	req.Header.Set("Content-Type", "application/json")
+	req, _ := http.NewRequest("GET", "/vouchers/1", nil)
 	w := httptest.NewRecorder()
 
 	// Act
@@ -435,22 +579,105 @@ func TestVoucherHandler_Update_Success(t *testing.T) {
 	mockService.AssertExpectations(t)
 }
 
-func TestVoucherHandler_Update_InvalidID(t *testing.T) {
+func TestVoucherHandler_GetByID_SetsETag(t *testing.T) {
 	// Arrange
 	mockService := new(MockVoucherService)
 	voucherHandler := NewVoucherHandler(mockService)
 	router := setupVoucherTestRouter()
-	router.PUT("/vouchers/:id", voucherHandler.Update)
+	router.GET("/vouchers/:id", voucherHandler.GetByID)
 
-	updateReq := request.UpdateVoucherRequest{
-		VoucherCode:     "UPDATED123",
-		DiscountPercent: 15.0,
-		ExpiryDate:      "2025-12-31",
-	}
+	voucher := &entity.Voucher{ID: 1, VoucherCode: "TEST123", UpdatedAt: time.Unix(1700000000, 0)}
+	mockService.On("GetByID", uint(1)).Return(voucher, nil)
 
-	requestBody, _ := json.Marshal(updateReq)
-	req, _ := http.NewRequest("PUT", "/vouchers/invalid", bytes.NewBuffer(requestBody))
-	req.Header.Set("Content-Type", "application/json")
+	req, _ := http.NewRequest("GET", "/vouchers/1", nil)
+	w := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, `W/"1-1700000000000000000"`, w.Header().Get("ETag"))
+	assert.Equal(t, "private, no-cache", w.Header().Get("Cache-Control"))
+}
+
+func TestVoucherHandler_GetByID_NotModified(t *testing.T) {
+	// Arrange
+	mockService := new(MockVoucherService)
+	voucherHandler := NewVoucherHandler(mockService)
+	router := setupVoucherTestRouter()
+	router.GET("/vouchers/:id", voucherHandler.GetByID)
+
+	voucher := &entity.Voucher{ID: 1, VoucherCode: "TEST123", UpdatedAt: time.Unix(1700000000, 0)}
+	mockService.On("GetByID", uint(1)).Return(voucher, nil)
+
+	req, _ := http.NewRequest("GET", "/vouchers/1", nil)
+	req.Header.Set("If-None-Match", `"other", W/"1-1700000000000000000"`)
+	w := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusNotModified, w.Code)
+	assert.Empty(t, w.Body.String())
+}
+
+func TestVoucherHandler_GetByID_ChangedVoucherIsResent(t *testing.T) {
+	// Arrange
+	mockService := new(MockVoucherService)
+	voucherHandler := NewVoucherHandler(mockService)
+	router := setupVoucherTestRouter()
+	router.GET("/vouchers/:id", voucherHandler.GetByID)
+
+	voucher := &entity.Voucher{ID: 1, VoucherCode: "TEST123", UpdatedAt: time.Unix(1700000500, 0)}
+	mockService.On("GetByID", uint(1)).Return(voucher, nil)
+
+	req, _ := http.NewRequest("GET", "/vouchers/1", nil)
+	req.Header.Set("If-None-Match", `W/"1-1700000000000000000"`)
+	w := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.NotEmpty(t, w.Body.String())
+}
+
+func TestVoucherHandler_GetByID_MasksCodeWhenCodesMasked(t *testing.T) {
+	// Arrange
+	mockService := new(MockVoucherService)
+	voucherHandler := NewVoucherHandler(mockService, WithViewerCodeMasking())
+	router := setupVoucherTestRouter()
+	router.GET("/vouchers/:id", voucherHandler.GetByID)
+
+	voucher := &entity.Voucher{ID: 1, VoucherCode: "SUMMER2024", UpdatedAt: time.Unix(1700000000, 0)}
+	mockService.On("GetByID", uint(1)).Return(voucher, nil)
+
+	req, _ := http.NewRequest("GET", "/vouchers/1", nil)
+	// An unmasked copy cached earlier must not be confirmed as current
+	req.Header.Set("If-None-Match", `W/"1-1700000000000000000"`)
+	w := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"voucher_code":"SUMM****24"`)
+	assert.Equal(t, `W/"1-1700000000000000000-masked"`, w.Header().Get("ETag"))
+	assert.Equal(t, "SUMMER2024", voucher.VoucherCode)
+}
+
+func TestVoucherHandler_GetByID_InvalidID(t *testing.T) {
+	// Arrange
+	mockService := new(MockVoucherService)
+	voucherHandler := NewVoucherHandler(mockService)
+	router := setupVoucherTestRouter()
+	router.GET("/vouchers/:id", voucherHandler.GetByID)
+
+	req, _ := http.NewRequest("GET", "/vouchers/invalid", nil)
 	w := httptest.NewRecorder()
 
 	// Act
@@ -465,41 +692,86 @@ func TestVoucherHandler_Update_InvalidID(t *testing.T) {
 	assert.Equal(t, "error", response["status"])
 }
 
-// Test Delete Voucher
-func TestVoucherHandler_Delete_Success(t *testing.T) {
+func TestVoucherHandler_GetByID_NotFound(t *testing.T) {
 	// Arrange
 	mockService := new(MockVoucherService)
 	voucherHandler := NewVoucherHandler(mockService)
 	router := setupVoucherTestRouter()
-	router.DELETE("/vouchers/:id", voucherHandler.Delete)
+	router.GET("/vouchers/:id", voucherHandler.GetByID)
 
-	mockService.On("Delete", uint(1)).Return(nil)
+	notFoundError := errors.New("voucher not found")
+	mockService.On("GetByID", uint(999)).Return(nil, notFoundError)
 
-	req, _ := http.NewRequest("DELETE", "/vouchers/1", nil)
+	req, _ := http.NewRequest("GET", "/vouchers/999", nil)
 	w := httptest.NewRecorder()
 
 	// Act
 	router.ServeHTTP(w, req)
 
 	// Assert
-	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	var response map[string]interface{}
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.Equal(t, "error", response["status"])
+
+	mockService.AssertExpectations(t)
+}
+
+// Test Create Voucher
+func TestVoucherHandler_Create_Success(t *testing.T) {
+	// Arrange
+	mockService := new(MockVoucherService)
+	voucherHandler := NewVoucherHandler(mockService)
+	router := setupVoucherTestRouter()
+	router.POST("/vouchers", voucherHandler.Create)
+
+	tomorrow := time.Now().Add(24 * time.Hour).Format("2006-01-02")
+	createReq := request.CreateVoucherRequest{
+		VoucherCode:     "TEST123",
+		DiscountPercent: 10.0,
+		ExpiryDate:      tomorrow,
+	}
+
+	createdVoucher := &entity.Voucher{
+		ID:              1,
+		VoucherCode:     createReq.VoucherCode,
+		DiscountPercent: createReq.DiscountPercent,
+	}
+
+	mockService.On("Create", mock.AnythingOfType("*service.CreateVoucherCommand")).Return(createdVoucher, nil)
+
+	requestBody, _ := json.Marshal(createReq)
+	req, _ := http.NewRequest("POST", "/vouchers", bytes.NewBuffer(requestBody))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusCreated, w.Code)
 
 	var response map[string]interface{}
 	err := json.Unmarshal(w.Body.Bytes(), &response)
 	assert.NoError(t, err)
 	assert.Equal(t, "success", response["status"])
+	assert.NotNil(t, response["data"])
 
 	mockService.AssertExpectations(t)
 }
 
-func TestVoucherHandler_Delete_InvalidID(t *testing.T) {
+func TestVoucherHandler_Create_InvalidJSON(t *testing.T) {
 	// Arrange
 	mockService := new(MockVoucherService)
 	voucherHandler := NewVoucherHandler(mockService)
 	router := setupVoucherTestRouter()
-	router.DELETE("/vouchers/:id", voucherHandler.Delete)
+	router.POST("/vouchers", voucherHandler.Create)
 
-	req, _ := http.NewRequest("DELETE", "/vouchers/invalid", nil)
+	invalidJSON := []byte(`{"voucher_code": "TEST123", "discount_percent":}`)
+	req, _ := http.NewRequest("POST", "/vouchers", bytes.NewBuffer(invalidJSON))
+	req.Header.Set("Content-Type", "application/json")
 	w := httptest.NewRecorder()
 
 	// Act
@@ -514,29 +786,1393 @@ func TestVoucherHandler_Delete_InvalidID(t *testing.T) {
 	assert.Equal(t, "error", response["status"])
 }
 
-func TestVoucherHandler_Delete_NotFound(t *testing.T) {
+func TestVoucherHandler_Create_ValidationError(t *testing.T) {
 	// Arrange
 	mockService := new(MockVoucherService)
 	voucherHandler := NewVoucherHandler(mockService)
 	router := setupVoucherTestRouter()
-	router.DELETE("/vouchers/:id", voucherHandler.Delete)
+	router.POST("/vouchers", voucherHandler.Create)
 
-	notFoundError := errors.New("voucher not found")
-	mockService.On("Delete", uint(999)).Return(notFoundError)
+	createReq := request.CreateVoucherRequest{
+		VoucherCode:     "",
+		DiscountPercent: 10.0,
+		ExpiryDate:      "2025-12-31",
+	}
 
-	req, _ := http.NewRequest("DELETE", "/vouchers/999", nil)
+	requestBody, _ := json.Marshal(createReq)
+	req, _ := http.NewRequest("POST", "/vouchers", bytes.NewBuffer(requestBody))
+	req.Header.Set("Content-Type", "application/json")
 	w := httptest.NewRecorder()
 
 	// Act
 	router.ServeHTTP(w, req)
 
 	// Assert
-	assert.Equal(t, http.StatusNotFound, w.Code)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
 
 	var response map[string]interface{}
 	err := json.Unmarshal(w.Body.Bytes(), &response)
 	assert.NoError(t, err)
 	assert.Equal(t, "error", response["status"])
+}
+
+func TestVoucherHandler_Create_FromTemplateOnly(t *testing.T) {
+	// Arrange
+	mockService := new(MockVoucherService)
+	voucherHandler := NewVoucherHandler(mockService)
+	router := setupVoucherTestRouter()
+	router.POST("/vouchers", voucherHandler.Create)
+
+	mockService.On("Create", mock.MatchedBy(func(cmd *service.CreateVoucherCommand) bool {
+		return cmd.TemplateID != nil && *cmd.TemplateID == 3 && cmd.VoucherCode == "" && cmd.DiscountPercent == 0
+	})).Return(&entity.Voucher{ID: 1, VoucherCode: "STORE-ABCD2345", DiscountPercent: 15}, nil)
+
+	req, _ := http.NewRequest("POST", "/vouchers", bytes.NewBufferString(`{"template_id":3}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusCreated, w.Code)
+	mockService.AssertExpectations(t)
+}
+
+func TestVoucherHandler_Create_FixedAmount(t *testing.T) {
+	// Arrange
+	mockService := new(MockVoucherService)
+	voucherHandler := NewVoucherHandler(mockService)
+	router := setupVoucherTestRouter()
+	router.POST("/vouchers", voucherHandler.Create)
+
+	mockService.On("Create", mock.MatchedBy(func(cmd *service.CreateVoucherCommand) bool {
+		return cmd.DiscountType == entity.DiscountTypeFixedAmount && cmd.DiscountAmount == 50000 && cmd.Currency == "IDR" && cmd.DiscountPercent == 0
+	})).Return(&entity.Voucher{ID: 1, VoucherCode: "RP50K", DiscountType: entity.DiscountTypeFixedAmount, DiscountAmount: 5000000, Currency: "IDR"}, nil)
+
+	tomorrow := time.Now().Add(24 * time.Hour).Format("2006-01-02")
+	body := `{"voucher_code":"RP50K","discount_type":"fixed_amount","discount_amount":50000,"currency":"IDR","expiry_date":"` + tomorrow + `"}`
+	req, _ := http.NewRequest("POST", "/vouchers", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.Contains(t, w.Body.String(), `"discount_type":"fixed_amount","discount_percent":0,"discount_amount":50000,"currency":"IDR"`)
+	mockService.AssertExpectations(t)
+}
+
+func TestVoucherHandler_Create_ServiceError(t *testing.T) {
+	// Arrange
+	mockService := new(MockVoucherService)
+	voucherHandler := NewVoucherHandler(mockService)
+	router := setupVoucherTestRouter()
+	router.POST("/vouchers", voucherHandler.Create)
+
+	tomorrow := time.Now().Add(24 * time.Hour).Format("2006-01-02")
+	createReq := request.CreateVoucherRequest{
+		VoucherCode:     "TEST123",
+		DiscountPercent: 10.0,
+		ExpiryDate:      tomorrow,
+	}
+
+	serviceError := errors.New("voucher code already exists")
+	mockService.On("Create", mock.AnythingOfType("*service.CreateVoucherCommand")).Return(nil, serviceError)
+
+	requestBody, _ := json.Marshal(createReq)
+	req, _ := http.NewRequest("POST", "/vouchers", bytes.NewBuffer(requestBody))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusBadRequest, w.Code)
 
+	var response map[string]interface{}
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.Equal(t, "error", response["status"])
+
+	mockService.AssertExpectations(t)
+}
+
+// Test Update Voucher
+func TestVoucherHandler_Update_Success(t *testing.T) {
+	// Arrange
+	mockService := new(MockVoucherService)
+	voucherHandler := NewVoucherHandler(mockService)
+	router := setupVoucherTestRouter()
+	router.PUT("/vouchers/:id", voucherHandler.Update)
+
+	tomorrow := time.Now().Add(24 * time.Hour).Format("2006-01-02")
+	updateReq := request.UpdateVoucherRequest{
+		VoucherCode:     "UPDATED123",
+		DiscountPercent: 15.0,
+		ExpiryDate:      tomorrow,
+	}
+
+	updatedVoucher := &entity.Voucher{
+		ID:              1,
+		VoucherCode:     updateReq.VoucherCode,
+		DiscountPercent: updateReq.DiscountPercent,
+	}
+
+	mockService.On("Update", uint(1), mock.AnythingOfType("*service.UpdateVoucherCommand")).Return(updatedVoucher, nil)
+
+	requestBody, _ := json.Marshal(updateReq)
+	req, _ := http.NewRequest("PUT", "/vouchers/1", bytes.NewBuffer(requestBody))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response map[string]interface{}
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.Equal(t, "success", response["status"])
+	assert.NotNil(t, response["data"])
+
+	mockService.AssertExpectations(t)
+}
+
+func TestVoucherHandler_Update_InvalidID(t *testing.T) {
+	// Arrange
+	mockService := new(MockVoucherService)
+	voucherHandler := NewVoucherHandler(mockService)
+	router := setupVoucherTestRouter()
+	router.PUT("/vouchers/:id", voucherHandler.Update)
+
+	updateReq := request.UpdateVoucherRequest{
+		VoucherCode:     "UPDATED123",
+		DiscountPercent: 15.0,
+		ExpiryDate:      "2025-12-31",
+	}
+
+	requestBody, _ := json.Marshal(updateReq)
+	req, _ := http.NewRequest("PUT", "/vouchers/invalid", bytes.NewBuffer(requestBody))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	var response map[string]interface{}
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.Equal(t, "error", response["status"])
+}
+
+// Test Delete Voucher
+func TestVoucherHandler_Delete_Success(t *testing.T) {
+	// Arrange
+	mockService := new(MockVoucherService)
+	voucherHandler := NewVoucherHandler(mockService)
+	router := setupVoucherTestRouter()
+	router.DELETE("/vouchers/:id", voucherHandler.Delete)
+
+	mockService.On("Delete", uint(1)).Return(nil)
+
+	req, _ := http.NewRequest("DELETE", "/vouchers/1", nil)
+	w := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response map[string]interface{}
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.Equal(t, "success", response["status"])
+
+	mockService.AssertExpectations(t)
+}
+
+func TestVoucherHandler_Delete_InvalidID(t *testing.T) {
+	// Arrange
+	mockService := new(MockVoucherService)
+	voucherHandler := NewVoucherHandler(mockService)
+	router := setupVoucherTestRouter()
+	router.DELETE("/vouchers/:id", voucherHandler.Delete)
+
+	req, _ := http.NewRequest("DELETE", "/vouchers/invalid", nil)
+	w := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	var response map[string]interface{}
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.Equal(t, "error", response["status"])
+}
+
+func TestVoucherHandler_Delete_NotFound(t *testing.T) {
+	// Arrange
+	mockService := new(MockVoucherService)
+	voucherHandler := NewVoucherHandler(mockService)
+	router := setupVoucherTestRouter()
+	router.DELETE("/vouchers/:id", voucherHandler.Delete)
+
+	notFoundError := errors.New("voucher not found")
+	mockService.On("Delete", uint(999)).Return(notFoundError)
+
+	req, _ := http.NewRequest("DELETE", "/vouchers/999", nil)
+	w := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	var response map[string]interface{}
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.Equal(t, "error", response["status"])
+
+	mockService.AssertExpectations(t)
+}
+
+// Test Approve Voucher
+func TestVoucherHandler_Approve_Success(t *testing.T) {
+	// Arrange
+	mockService := new(MockVoucherService)
+	voucherHandler := NewVoucherHandler(mockService)
+	router := setupVoucherTestRouter()
+	router.POST("/vouchers/:id/approve", func(c *gin.Context) {
+		c.Set("email", "approver@example.com")
+		voucherHandler.Approve(c)
+	})
+
+	approvedVoucher := &entity.Voucher{
+		ID:          1,
+		VoucherCode: "BIG75",
+		Status:      entity.VoucherStatusActive,
+		ApprovedBy:  "approver@example.com",
+	}
+
+	mockService.On("Approve", uint(1), "approver@example.com").Return(approvedVoucher, nil)
+
+	req, _ := http.NewRequest("POST", "/vouchers/1/approve", nil)
+	w := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response map[string]interface{}
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.Equal(t, "success", response["status"])
+
+	mockService.AssertExpectations(t)
+}
+
+func TestVoucherHandler_Approve_NotFound(t *testing.T) {
+	// Arrange
+	mockService := new(MockVoucherService)
+	voucherHandler := NewVoucherHandler(mockService)
+	router := setupVoucherTestRouter()
+	router.POST("/vouchers/:id/approve", voucherHandler.Approve)
+
+	mockService.On("Approve", uint(999), "").Return(nil, service.ErrVoucherNotFound)
+
+	req, _ := http.NewRequest("POST", "/vouchers/999/approve", nil)
+	w := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	mockService.AssertExpectations(t)
+}
+
+func TestVoucherHandler_Approve_SelfApproval(t *testing.T) {
+	// Arrange
+	mockService := new(MockVoucherService)
+	voucherHandler := NewVoucherHandler(mockService)
+	router := setupVoucherTestRouter()
+	router.POST("/vouchers/:id/approve", voucherHandler.Approve)
+
+	mockService.On("Approve", uint(1), "").Return(nil, service.ErrSelfApproval)
+
+	req, _ := http.NewRequest("POST", "/vouchers/1/approve", nil)
+	w := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusForbidden, w.Code)
+
+	mockService.AssertExpectations(t)
+}
+
+// Test Validate Voucher
+func TestVoucherHandler_Validate_Success(t *testing.T) {
+	// Arrange
+	mockService := new(MockVoucherService)
+	voucherHandler := NewVoucherHandler(mockService)
+	router := setupVoucherTestRouter()
+	router.POST("/vouchers/validate", voucherHandler.Validate)
+
+	reqBody := request.ValidateVoucherRequest{
+		VoucherCode: "VIP10",
+		Context:     rules.Context{CustomerSegments: []string{"vip"}, ItemCount: 2},
+	}
+
+	result := &service.ValidationResult{VoucherCode: "VIP10", Valid: true, DiscountPercent: 10}
+	mockService.On("Validate", "VIP10", reqBody.Context).Return(result, nil)
+
+	body, _ := json.Marshal(reqBody)
+	req, _ := http.NewRequest("POST", "/vouchers/validate", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response map[string]interface{}
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.Equal(t, true, response["data"].(map[string]interface{})["valid"])
+
+	mockService.AssertExpectations(t)
+}
+
+func TestVoucherHandler_Validate_NotFound(t *testing.T) {
+	// Arrange
+	mockService := new(MockVoucherService)
+	voucherHandler := NewVoucherHandler(mockService)
+	router := setupVoucherTestRouter()
+	router.POST("/vouchers/validate", voucherHandler.Validate)
+
+	mockService.On("Validate", "UNKNOWN", rules.Context{}).Return(nil, service.ErrVoucherNotFound)
+
+	req, _ := http.NewRequest("POST", "/vouchers/validate", bytes.NewBufferString(`{"voucher_code":"UNKNOWN"}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	mockService.AssertExpectations(t)
+}
+
+// Test Import CSV
+func TestVoucherHandler_ImportCSV_DeclaredSchemaVersion(t *testing.T) {
+	// Arrange
+	mockService := new(MockVoucherService)
+	voucherHandler := NewVoucherHandler(mockService)
+	router := setupVoucherTestRouter()
+	router.POST("/vouchers/upload-csv", voucherHandler.ImportCSV)
+
+	result := &service.ImportResult{SchemaVersion: 1, TotalRows: 1, Success: 1}
+	mockService.On("ImportVouchers", mock.Anything, service.CSVSchemaV1, "", "").Return(result, nil)
+
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	writer.WriteField("schema_version", "v1")
+	part, _ := writer.CreateFormFile("file", "vouchers.csv")
+	part.Write([]byte("voucher_code,discount_percent,expiry_date\nOLD1,10,2030-01-01\n"))
+	writer.Close()
+
+	req, _ := http.NewRequest("POST", "/vouchers/upload-csv", body)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	w := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"schema_version":1`)
+	mockService.AssertExpectations(t)
+}
+
+func TestVoucherHandler_ImportCSV_InvalidSchemaVersionHeader(t *testing.T) {
+	// Arrange
+	mockService := new(MockVoucherService)
+	voucherHandler := NewVoucherHandler(mockService)
+	router := setupVoucherTestRouter()
+	router.POST("/vouchers/upload-csv", voucherHandler.ImportCSV)
+
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	part, _ := writer.CreateFormFile("file", "vouchers.csv")
+	part.Write([]byte("voucher_code,discount_percent,expiry_date\nOLD1,10,2030-01-01\n"))
+	writer.Close()
+
+	req, _ := http.NewRequest("POST", "/vouchers/upload-csv", body)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	req.Header.Set("X-CSV-Schema-Version", "latest")
+	w := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	mockService.AssertNotCalled(t, "ImportVouchers", mock.Anything, mock.Anything, mock.Anything)
+}
+
+// Test ImportTemplate
+func TestVoucherHandler_ImportTemplate_CSV(t *testing.T) {
+	// Arrange
+	mockService := new(MockVoucherService)
+	voucherHandler := NewVoucherHandler(mockService)
+	router := setupVoucherTestRouter()
+	router.GET("/vouchers/import-template", voucherHandler.ImportTemplate)
+
+	mockService.On("ImportTemplate").Return([][]string{
+		{"voucher_code", "discount_percent", "expiry_date"},
+		{"SUMMER25", "10.00", "2030-01-01"},
+	})
+
+	req, _ := http.NewRequest("GET", "/vouchers/import-template", nil)
+	w := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "text/csv", w.Header().Get("Content-Type"))
+	assert.Contains(t, w.Header().Get("Content-Disposition"), "voucher-import-template.csv")
+	assert.Equal(t, strconv.Itoa(service.CurrentCSVSchemaVersion), w.Header().Get("X-CSV-Schema-Version"))
+	assert.Equal(t, "voucher_code,discount_percent,expiry_date\nSUMMER25,10.00,2030-01-01\n", w.Body.String())
+	mockService.AssertExpectations(t)
+}
+
+func TestVoucherHandler_ImportTemplate_XLSX(t *testing.T) {
+	// Arrange
+	mockService := new(MockVoucherService)
+	voucherHandler := NewVoucherHandler(mockService)
+	router := setupVoucherTestRouter()
+	router.GET("/vouchers/import-template", voucherHandler.ImportTemplate)
+
+	mockService.On("ImportTemplate").Return([][]string{{"voucher_code"}, {"SUMMER25"}})
+
+	req, _ := http.NewRequest("GET", "/vouchers/import-template?format=xlsx", nil)
+	w := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, xlsx.ContentType, w.Header().Get("Content-Type"))
+	_, err := zip.NewReader(bytes.NewReader(w.Body.Bytes()), int64(w.Body.Len()))
+	assert.NoError(t, err)
+}
+
+func TestVoucherHandler_ImportTemplate_UnsupportedFormat(t *testing.T) {
+	// Arrange
+	mockService := new(MockVoucherService)
+	voucherHandler := NewVoucherHandler(mockService)
+	router := setupVoucherTestRouter()
+	router.GET("/vouchers/import-template", voucherHandler.ImportTemplate)
+
+	mockService.On("ImportTemplate").Return([][]string{{"voucher_code"}})
+
+	req, _ := http.NewRequest("GET", "/vouchers/import-template?format=ods", nil)
+	w := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+// Test Export Vouchers
+func TestVoucherHandler_ExportCSV_Encrypted(t *testing.T) {
+	// Arrange
+	mockService := new(MockVoucherService)
+	voucherHandler := NewVoucherHandler(mockService)
+	router := setupVoucherTestRouter()
+	router.GET("/vouchers/export", voucherHandler.ExportCSV)
+
+	csvData := []byte("voucher_code,discount_percent,expiry_date\nSUMMER24,10.00,2030-01-01\n")
+	mockService.On("ExportVouchers", service.CurrentCSVSchemaVersion).Return(csvData, nil)
+
+	req, _ := http.NewRequest("GET", "/vouchers/export", nil)
+	req.Header.Set("X-Export-Passphrase", "correct horse battery")
+	w := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "application/octet-stream", w.Header().Get("Content-Type"))
+	assert.Contains(t, w.Header().Get("Content-Disposition"), "vouchers.csv.enc")
+
+	decrypted, err := exportcrypt.Decrypt(w.Body.Bytes(), "correct horse battery")
+	assert.NoError(t, err)
+	assert.Equal(t, csvData, decrypted)
+
+	mockService.AssertExpectations(t)
+}
+
+func TestVoucherHandler_ExportCSV_SchemaVersion(t *testing.T) {
+	// Arrange
+	mockService := new(MockVoucherService)
+	voucherHandler := NewVoucherHandler(mockService)
+	router := setupVoucherTestRouter()
+	router.GET("/vouchers/export", voucherHandler.ExportCSV)
+
+	csvData := []byte("voucher_code,discount_percent,expiry_date\nSUMMER24,10.00,2030-01-01\n")
+	mockService.On("ExportVouchers", service.CSVSchemaV1).Return(csvData, nil)
+
+	req, _ := http.NewRequest("GET", "/vouchers/export?schema_version=1", nil)
+	w := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "1", w.Header().Get("X-CSV-Schema-Version"))
+	assert.Equal(t, csvData, w.Body.Bytes())
+	mockService.AssertExpectations(t)
+}
+
+func TestVoucherHandler_ExportCSV_UnsupportedSchemaVersion(t *testing.T) {
+	// Arrange
+	mockService := new(MockVoucherService)
+	voucherHandler := NewVoucherHandler(mockService)
+	router := setupVoucherTestRouter()
+	router.GET("/vouchers/export", voucherHandler.ExportCSV)
+
+	mockService.On("ExportVouchers", 7).Return(nil, fmt.Errorf("%w: 7", service.ErrUnsupportedCSVSchema))
+
+	req, _ := http.NewRequest("GET", "/vouchers/export?schema_version=7", nil)
+	w := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	mockService.AssertExpectations(t)
+}
+
+func TestVoucherHandler_ExportCSV_DeltaRequiresLatestSchema(t *testing.T) {
+	// Arrange
+	mockService := new(MockVoucherService)
+	voucherHandler := NewVoucherHandler(mockService)
+	router := setupVoucherTestRouter()
+	router.GET("/vouchers/export", voucherHandler.ExportCSV)
+
+	req, _ := http.NewRequest("GET", "/vouchers/export?schema_version=1&updated_since=2030-01-01T00:00:00Z", nil)
+	w := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	mockService.AssertNotCalled(t, "ExportVoucherChanges", mock.Anything)
+}
+
+func TestVoucherHandler_ExportCSV_ShortPassphrase(t *testing.T) {
+	// Arrange
+	mockService := new(MockVoucherService)
+	voucherHandler := NewVoucherHandler(mockService)
+	router := setupVoucherTestRouter()
+	router.GET("/vouchers/export", voucherHandler.ExportCSV)
+
+	req, _ := http.NewRequest("GET", "/vouchers/export", nil)
+	req.Header.Set("X-Export-Passphrase", "short")
+	w := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	mockService.AssertNotCalled(t, "ExportVouchers", mock.Anything)
+}
+
+func TestVoucherHandler_ExportCSV_UpdatedSince(t *testing.T) {
+	// Arrange
+	mockService := new(MockVoucherService)
+	voucherHandler := NewVoucherHandler(mockService)
+	router := setupVoucherTestRouter()
+	router.GET("/vouchers/export", voucherHandler.ExportCSV)
+
+	since := time.Date(2030, 1, 1, 12, 0, 0, 0, time.UTC)
+	csvData := []byte("voucher_code,changed_at,deleted\nSUMMER24,2030-01-02T00:00:00Z,true\n")
+	mockService.On("ExportVoucherChanges", mock.MatchedBy(since.Equal)).Return(csvData, nil)
+
+	req, _ := http.NewRequest("GET", "/vouchers/export?updated_since=2030-01-01T12:00:00Z", nil)
+	w := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, csvData, w.Body.Bytes())
+	assert.Contains(t, w.Header().Get("Content-Disposition"), "vouchers-changes.csv")
+	_, err := time.Parse(time.RFC3339Nano, w.Header().Get("X-Export-As-Of"))
+	assert.NoError(t, err)
+	mockService.AssertNotCalled(t, "ExportVouchers", mock.Anything)
+	mockService.AssertExpectations(t)
+}
+
+func TestVoucherHandler_ExportCSV_InvalidUpdatedSince(t *testing.T) {
+	// Arrange
+	mockService := new(MockVoucherService)
+	voucherHandler := NewVoucherHandler(mockService)
+	router := setupVoucherTestRouter()
+	router.GET("/vouchers/export", voucherHandler.ExportCSV)
+
+	req, _ := http.NewRequest("GET", "/vouchers/export?updated_since=yesterday", nil)
+	w := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	mockService.AssertNotCalled(t, "ExportVoucherChanges", mock.Anything)
+}
+
+func TestVoucherHandler_ExportCSV_UpdatedSinceRejectsZip(t *testing.T) {
+	// Arrange
+	mockService := new(MockVoucherService)
+	voucherHandler := NewVoucherHandler(mockService)
+	router := setupVoucherTestRouter()
+	router.GET("/vouchers/export", voucherHandler.ExportCSV)
+
+	req, _ := http.NewRequest("GET", "/vouchers/export?updated_since=2030-01-01T12:00:00Z&split_rows=10", nil)
+	w := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	mockService.AssertNotCalled(t, "ExportVoucherParts", mock.Anything, mock.Anything, mock.Anything)
+}
+
+// Test BulkEdit
+func TestVoucherHandler_BulkEdit_Success(t *testing.T) {
+	// Arrange
+	mockService := new(MockVoucherService)
+	voucherHandler := NewVoucherHandler(mockService)
+	router := setupVoucherTestRouter()
+	router.PATCH("/vouchers", voucherHandler.BulkEdit)
+
+	result := &service.BulkEditResult{Fields: []string{"status"}, Updated: 12, Chunks: 1}
+	mockService.On("BulkEdit", mock.MatchedBy(func(cmd *service.BulkEditCommand) bool {
+		return cmd.Filter.PartnerID != nil && *cmd.Filter.PartnerID == 7 && string(cmd.Patch) == `{"status":"inactive"}`
+	})).Return(result, nil)
+
+	body := `{"filter":{"partner_id":7},"patch":{"status":"inactive"}}`
+	req, _ := http.NewRequest("PATCH", "/vouchers", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"updated":12`)
+	mockService.AssertExpectations(t)
+}
+
+func TestVoucherHandler_BulkEdit_MissingPatch(t *testing.T) {
+	// Arrange
+	mockService := new(MockVoucherService)
+	voucherHandler := NewVoucherHandler(mockService)
+	router := setupVoucherTestRouter()
+	router.PATCH("/vouchers", voucherHandler.BulkEdit)
+
+	req, _ := http.NewRequest("PATCH", "/vouchers", bytes.NewBufferString(`{"filter":{"ids":[1]}}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	mockService.AssertNotCalled(t, "BulkEdit", mock.Anything)
+}
+
+func TestVoucherHandler_BulkEdit_DiscountLimitExceeded(t *testing.T) {
+	// Arrange
+	mockService := new(MockVoucherService)
+	voucherHandler := NewVoucherHandler(mockService)
+	router := setupVoucherTestRouter()
+	router.PATCH("/vouchers", voucherHandler.BulkEdit)
+
+	mockService.On("BulkEdit", mock.AnythingOfType("*service.BulkEditCommand")).
+		Return(nil, fmt.Errorf("%w: marketing users can give up to 30%% off, not 90%%", service.ErrDiscountLimitExceeded))
+
+	body := `{"filter":{"ids":[1]},"patch":[{"op":"replace","path":"/discount_percent","value":90}]}`
+	req, _ := http.NewRequest("PATCH", "/vouchers", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	mockService.AssertExpectations(t)
+}
+
+// Test BulkDeactivate
+func TestVoucherHandler_BulkDeactivate_Prefix(t *testing.T) {
+	// Arrange
+	mockService := new(MockVoucherService)
+	voucherHandler := NewVoucherHandler(mockService)
+	router := setupVoucherTestRouter()
+	router.POST("/vouchers/bulk-deactivate", voucherHandler.BulkDeactivate)
+
+	result := &service.BulkDeactivateResult{Matched: 3, Deactivated: 3, MissingCodes: []string{}}
+	mockService.On("BulkDeactivate", "LEAK", []string(nil)).Return(result, nil)
+
+	req, _ := http.NewRequest("POST", "/vouchers/bulk-deactivate", bytes.NewBufferString(`{"prefix":"LEAK"}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	mockService.AssertExpectations(t)
+}
+
+func TestVoucherHandler_BulkDeactivate_CSVFile(t *testing.T) {
+	// Arrange
+	mockService := new(MockVoucherService)
+	voucherHandler := NewVoucherHandler(mockService)
+	router := setupVoucherTestRouter()
+	router.POST("/vouchers/bulk-deactivate", voucherHandler.BulkDeactivate)
+
+	result := &service.BulkDeactivateResult{Matched: 1, Deactivated: 1, MissingCodes: []string{"GONE"}}
+	mockService.On("BulkDeactivate", "", []string{"CODE1", "GONE"}).Return(result, nil)
+
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	part, _ := writer.CreateFormFile("file", "codes.csv")
+	part.Write([]byte("voucher_code\nCODE1\n\nGONE\n"))
+	writer.Close()
+
+	req, _ := http.NewRequest("POST", "/vouchers/bulk-deactivate", body)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	w := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	mockService.AssertExpectations(t)
+}
+
+func TestVoucherHandler_BulkDeactivate_ServiceError(t *testing.T) {
+	// Arrange
+	mockService := new(MockVoucherService)
+	voucherHandler := NewVoucherHandler(mockService)
+	router := setupVoucherTestRouter()
+	router.POST("/vouchers/bulk-deactivate", voucherHandler.BulkDeactivate)
+
+	mockService.On("BulkDeactivate", "", []string(nil)).
+		Return(nil, errors.New("provide either a code prefix or a list of codes"))
+
+	req, _ := http.NewRequest("POST", "/vouchers/bulk-deactivate", bytes.NewBufferString(`{}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	mockService.AssertExpectations(t)
+}
+
+// Test GetByExternalID
+func TestVoucherHandler_GetByExternalID_Success(t *testing.T) {
+	// Arrange
+	mockService := new(MockVoucherService)
+	voucherHandler := NewVoucherHandler(mockService)
+	router := setupVoucherTestRouter()
+	router.GET("/vouchers/by-external-id/:ext_id", voucherHandler.GetByExternalID)
+
+	externalID := "crm-42"
+	voucher := &entity.Voucher{ID: 1, VoucherCode: "EXT1", ExternalID: &externalID, ExpiryDate: time.Now()}
+	mockService.On("GetByExternalID", "crm-42").Return(voucher, nil)
+
+	req, _ := http.NewRequest("GET", "/vouchers/by-external-id/crm-42", nil)
+	w := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response map[string]interface{}
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.Equal(t, "crm-42", response["data"].(map[string]interface{})["external_id"])
+	mockService.AssertExpectations(t)
+}
+
+func TestVoucherHandler_GetByExternalID_NotFound(t *testing.T) {
+	// Arrange
+	mockService := new(MockVoucherService)
+	voucherHandler := NewVoucherHandler(mockService)
+	router := setupVoucherTestRouter()
+	router.GET("/vouchers/by-external-id/:ext_id", voucherHandler.GetByExternalID)
+
+	mockService.On("GetByExternalID", "crm-404").Return(nil, service.ErrVoucherNotFound)
+
+	req, _ := http.NewRequest("GET", "/vouchers/by-external-id/crm-404", nil)
+	w := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	mockService.AssertExpectations(t)
+}
+
+func TestVoucherHandler_Create_DuplicateExternalID(t *testing.T) {
+	// Arrange
+	mockService := new(MockVoucherService)
+	voucherHandler := NewVoucherHandler(mockService)
+	router := setupVoucherTestRouter()
+	router.POST("/vouchers", voucherHandler.Create)
+
+	mockService.On("Create", mock.AnythingOfType("*service.CreateVoucherCommand")).
+		Return(nil, service.ErrDuplicateExternalID)
+
+	body := `{"voucher_code":"EXT1","external_id":"crm-42","discount_percent":10,"expiry_date":"2030-01-01"}`
+	req, _ := http.NewRequest("POST", "/vouchers", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusConflict, w.Code)
+	mockService.AssertExpectations(t)
+}
+
+func TestVoucherHandler_Create_DiscountLimitExceeded(t *testing.T) {
+	// Arrange
+	mockService := new(MockVoucherService)
+	voucherHandler := NewVoucherHandler(mockService)
+	router := setupVoucherTestRouter()
+	router.POST("/vouchers", voucherHandler.Create)
+
+	mockService.On("Create", mock.AnythingOfType("*service.CreateVoucherCommand")).
+		Return(nil, fmt.Errorf("%w: marketing users can give up to 30%% off, not 90%%", service.ErrDiscountLimitExceeded))
+
+	body := `{"voucher_code":"FAT90","discount_percent":90,"expiry_date":"2030-01-01"}`
+	req, _ := http.NewRequest("POST", "/vouchers", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	assert.Contains(t, w.Body.String(), "up to 30% off")
+}
+
+// Test Suggest
+func TestVoucherHandler_Suggest_Success(t *testing.T) {
+	// Arrange
+	mockService := new(MockVoucherService)
+	voucherHandler := NewVoucherHandler(mockService)
+	router := setupVoucherTestRouter()
+	router.GET("/vouchers/suggest", voucherHandler.Suggest)
+
+	mockService.On("Suggest", "SUM").Return([]string{"SUMMER24", "SUMMIT10"}, nil)
+
+	req, _ := http.NewRequest("GET", "/vouchers/suggest?q=SUM", nil)
+	w := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response map[string]interface{}
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.Len(t, response["data"], 2)
+	mockService.AssertExpectations(t)
+}
+
+// Test CodeAvailable
+func TestVoucherHandler_CodeAvailable(t *testing.T) {
+	tests := []struct {
+		name         string
+		query        string
+		available    bool
+		serviceErr   error
+		wantStatus   int
+		wantContains string
+	}{
+		{name: "available", query: "?code=SUMMER24", available: true, wantStatus: http.StatusOK, wantContains: `"available":true`},
+		{name: "taken", query: "?code=SUMMER24", wantStatus: http.StatusOK, wantContains: `"code":"SUMMER24","available":false`},
+		{name: "missing code", query: "", wantStatus: http.StatusBadRequest},
+		{name: "code too long", query: "?code=" + strings.Repeat("A", 51), wantStatus: http.StatusBadRequest},
+		{name: "database down", query: "?code=SUMMER24", serviceErr: errors.New("connection refused"), wantStatus: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			mockService := new(MockVoucherService)
+			voucherHandler := NewVoucherHandler(mockService)
+			router := setupVoucherTestRouter()
+			router.GET("/vouchers/code-available", voucherHandler.CodeAvailable)
+			mockService.On("CodeAvailable", "SUMMER24").Return(tt.available, tt.serviceErr)
+
+			req, _ := http.NewRequest("GET", "/vouchers/code-available"+tt.query, nil)
+			w := httptest.NewRecorder()
+
+			// Act
+			router.ServeHTTP(w, req)
+
+			// Assert
+			assert.Equal(t, tt.wantStatus, w.Code)
+			assert.Contains(t, w.Body.String(), tt.wantContains)
+			if tt.wantStatus == http.StatusBadRequest {
+				mockService.AssertNotCalled(t, "CodeAvailable", mock.Anything)
+			}
+		})
+	}
+}
+
+// Test ExportCSV as ZIP
+func TestVoucherHandler_ExportCSV_SplitZip(t *testing.T) {
+	// Arrange
+	mockService := new(MockVoucherService)
+	voucherHandler := NewVoucherHandler(mockService)
+	router := setupVoucherTestRouter()
+	router.GET("/vouchers/export", voucherHandler.ExportCSV)
+
+	parts := [][]byte{
+		[]byte("voucher_code,discount_percent,expiry_date\nA,10.00,2030-01-01\n"),
+		[]byte("voucher_code,discount_percent,expiry_date\nB,10.00,2030-01-01\n"),
+	}
+	mockService.On("ExportVoucherParts", 1, service.CurrentCSVSchemaVersion, mock.Anything).Return(parts, nil)
+
+	req, _ := http.NewRequest("GET", "/vouchers/export?split_rows=1", nil)
+	w := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "application/zip", w.Header().Get("Content-Type"))
+
+	archive, err := zip.NewReader(bytes.NewReader(w.Body.Bytes()), int64(w.Body.Len()))
+	assert.NoError(t, err)
+	if assert.Len(t, archive.File, 2) {
+		assert.Equal(t, "vouchers-0001.csv", archive.File[0].Name)
+		assert.Equal(t, "vouchers-0002.csv", archive.File[1].Name)
+	}
+	mockService.AssertExpectations(t)
+}
+
+func TestVoucherHandler_ExportCSV_AcceptZipUsesDefaultSplit(t *testing.T) {
+	// Arrange
+	mockService := new(MockVoucherService)
+	voucherHandler := NewVoucherHandler(mockService)
+	router := setupVoucherTestRouter()
+	router.GET("/vouchers/export", voucherHandler.ExportCSV)
+
+	parts := [][]byte{[]byte("voucher_code,discount_percent,expiry_date\n")}
+	mockService.On("ExportVoucherParts", defaultExportRowsPerFile, service.CurrentCSVSchemaVersion, mock.Anything).Return(parts, nil)
+
+	req, _ := http.NewRequest("GET", "/vouchers/export", nil)
+	req.Header.Set("Accept", "application/zip")
+	w := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "application/zip", w.Header().Get("Content-Type"))
+	mockService.AssertExpectations(t)
+}
+
+func TestVoucherHandler_ExportCSV_InvalidSplitRows(t *testing.T) {
+	// Arrange
+	mockService := new(MockVoucherService)
+	voucherHandler := NewVoucherHandler(mockService)
+	router := setupVoucherTestRouter()
+	router.GET("/vouchers/export", voucherHandler.ExportCSV)
+
+	req, _ := http.NewRequest("GET", "/vouchers/export?split_rows=0", nil)
+	w := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	mockService.AssertNotCalled(t, "ExportVoucherParts", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestVoucherHandler_ExportCSV_SplitWithPassphrase(t *testing.T) {
+	// Arrange
+	mockService := new(MockVoucherService)
+	voucherHandler := NewVoucherHandler(mockService)
+	router := setupVoucherTestRouter()
+	router.GET("/vouchers/export", voucherHandler.ExportCSV)
+
+	req, _ := http.NewRequest("GET", "/vouchers/export?split_rows=100", nil)
+	req.Header.Set("X-Export-Passphrase", "correct horse battery")
+	w := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	mockService.AssertNotCalled(t, "ExportVoucherParts", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestVoucherHandler_PrintPDF_Success(t *testing.T) {
+	// Arrange
+	mockService := new(MockVoucherService)
+	voucherHandler := NewVoucherHandler(mockService)
+	router := setupVoucherTestRouter()
+	router.GET("/vouchers/:id/pdf", voucherHandler.PrintPDF)
+
+	mockService.On("RenderPDF", []uint{7}).Return([]byte("%PDF-1.4"), nil)
+
+	req, _ := http.NewRequest("GET", "/vouchers/7/pdf", nil)
+	w := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "application/pdf", w.Header().Get("Content-Type"))
+	assert.Contains(t, w.Header().Get("Content-Disposition"), "voucher-7.pdf")
+	assert.Equal(t, "%PDF-1.4", w.Body.String())
+	mockService.AssertExpectations(t)
+}
+
+func TestVoucherHandler_PrintPDF_Errors(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		code int
+	}{
+		{"not found", fmt.Errorf("%w: 7", service.ErrVoucherNotFound), http.StatusNotFound},
+		{"not printable", fmt.Errorf("%w: SUMMER2024 is inactive", service.ErrVoucherNotPrintable), http.StatusConflict},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			mockService := new(MockVoucherService)
+			voucherHandler := NewVoucherHandler(mockService)
+			router := setupVoucherTestRouter()
+			router.GET("/vouchers/:id/pdf", voucherHandler.PrintPDF)
+
+			mockService.On("RenderPDF", []uint{7}).Return(nil, tt.err)
+
+			req, _ := http.NewRequest("GET", "/vouchers/7/pdf", nil)
+			w := httptest.NewRecorder()
+
+			// Act
+			router.ServeHTTP(w, req)
+
+			// Assert
+			assert.Equal(t, tt.code, w.Code)
+		})
+	}
+}
+
+func TestVoucherHandler_PrintPDFBatch(t *testing.T) {
+	// Arrange
+	mockService := new(MockVoucherService)
+	voucherHandler := NewVoucherHandler(mockService)
+	router := setupVoucherTestRouter()
+	router.GET("/vouchers/pdf", voucherHandler.PrintPDFBatch)
+
+	mockService.On("RenderPDF", []uint{3, 1, 2}).Return([]byte("%PDF-1.4"), nil)
+
+	req, _ := http.NewRequest("GET", "/vouchers/pdf?ids=3,1,2", nil)
+	badReq, _ := http.NewRequest("GET", "/vouchers/pdf?ids=3,x", nil)
+	w := httptest.NewRecorder()
+	badW := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(w, req)
+	router.ServeHTTP(badW, badReq)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Header().Get("Content-Disposition"), "vouchers.pdf")
+	assert.Equal(t, http.StatusBadRequest, badW.Code)
+	mockService.AssertNumberOfCalls(t, "RenderPDF", 1)
+}
+
+func TestVoucherHandler_PrintPDF_ForbiddenWhenCodesMasked(t *testing.T) {
+	// Arrange
+	mockService := new(MockVoucherService)
+	voucherHandler := NewVoucherHandler(mockService, WithViewerCodeMasking())
+	router := setupVoucherTestRouter()
+	router.GET("/vouchers/:id/pdf", voucherHandler.PrintPDF)
+
+	req, _ := http.NewRequest("GET", "/vouchers/7/pdf", nil)
+	w := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	mockService.AssertNotCalled(t, "RenderPDF", mock.Anything)
+}
+
+func TestVoucherHandler_SuggestAndExport_ForbiddenWhenCodesMasked(t *testing.T) {
+	// Arrange
+	mockService := new(MockVoucherService)
+	voucherHandler := NewVoucherHandler(mockService, WithViewerCodeMasking())
+	router := setupVoucherTestRouter()
+	router.GET("/vouchers/suggest", voucherHandler.Suggest)
+	router.GET("/vouchers/export", voucherHandler.ExportCSV)
+
+	suggestW := httptest.NewRecorder()
+	exportW := httptest.NewRecorder()
+	suggestReq, _ := http.NewRequest("GET", "/vouchers/suggest?q=SUM", nil)
+	exportReq, _ := http.NewRequest("GET", "/vouchers/export", nil)
+
+	// Act
+	router.ServeHTTP(suggestW, suggestReq)
+	router.ServeHTTP(exportW, exportReq)
+
+	// Assert
+	assert.Equal(t, http.StatusForbidden, suggestW.Code)
+	assert.Equal(t, http.StatusForbidden, exportW.Code)
+	mockService.AssertNotCalled(t, "Suggest", mock.Anything)
+	mockService.AssertNotCalled(t, "ExportVouchers", mock.Anything)
+}
+
+func TestVoucherHandler_PreviewImage_Success(t *testing.T) {
+	// Arrange
+	mockService := new(MockVoucherService)
+	voucherHandler := NewVoucherHandler(mockService)
+	router := setupVoucherTestRouter()
+	router.GET("/vouchers/:id/og-image", voucherHandler.PreviewImage)
+
+	mockService.On("RenderPreviewImage", uint(7), service.PreviewImageOptions{ShowCode: true}).Return([]byte("\x89PNG"), nil)
+
+	req, _ := http.NewRequest("GET", "/vouchers/7/og-image?code=true", nil)
+	w := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "image/png", w.Header().Get("Content-Type"))
+	assert.Equal(t, "private, max-age=3600", w.Header().Get("Cache-Control"))
+	assert.NotEmpty(t, w.Header().Get("ETag"))
+	assert.Equal(t, "\x89PNG", w.Body.String())
+}
+
+func TestVoucherHandler_PreviewImage_NotModified(t *testing.T) {
+	// Arrange
+	mockService := new(MockVoucherService)
+	voucherHandler := NewVoucherHandler(mockService)
+	router := setupVoucherTestRouter()
+	router.GET("/vouchers/:id/og-image", voucherHandler.PreviewImage)
+
+	mockService.On("RenderPreviewImage", uint(7), service.PreviewImageOptions{}).Return([]byte("\x89PNG"), nil)
+
+	first := httptest.NewRecorder()
+	router.ServeHTTP(first, httptest.NewRequest("GET", "/vouchers/7/og-image", nil))
+
+	req := httptest.NewRequest("GET", "/vouchers/7/og-image", nil)
+	req.Header.Set("If-None-Match", first.Header().Get("ETag"))
+	w := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusNotModified, w.Code)
+	assert.Empty(t, w.Body.String())
+}
+
+func TestVoucherHandler_PreviewImage_MasksCodeWhenCodesMasked(t *testing.T) {
+	// Arrange
+	mockService := new(MockVoucherService)
+	voucherHandler := NewVoucherHandler(mockService, WithViewerCodeMasking())
+	router := setupVoucherTestRouter()
+	router.GET("/vouchers/:id/og-image", voucherHandler.PreviewImage)
+
+	mockService.On("RenderPreviewImage", uint(7), service.PreviewImageOptions{ShowCode: true, MaskCode: true}).Return([]byte("\x89PNG"), nil)
+
+	req, _ := http.NewRequest("GET", "/vouchers/7/og-image?code=true", nil)
+	w := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	mockService.AssertExpectations(t)
+}
+
+func TestVoucherHandler_PreviewImage_Errors(t *testing.T) {
+	// Arrange
+	mockService := new(MockVoucherService)
+	voucherHandler := NewVoucherHandler(mockService)
+	router := setupVoucherTestRouter()
+	router.GET("/vouchers/:id/og-image", voucherHandler.PreviewImage)
+
+	mockService.On("RenderPreviewImage", uint(9), service.PreviewImageOptions{}).Return(nil, service.ErrVoucherNotFound)
+
+	missing := httptest.NewRecorder()
+	badID := httptest.NewRecorder()
+	badCode := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(missing, httptest.NewRequest("GET", "/vouchers/9/og-image", nil))
+	router.ServeHTTP(badID, httptest.NewRequest("GET", "/vouchers/x/og-image", nil))
+	router.ServeHTTP(badCode, httptest.NewRequest("GET", "/vouchers/9/og-image?code=maybe", nil))
+
+	// Assert
+	assert.Equal(t, http.StatusNotFound, missing.Code)
+	assert.Equal(t, http.StatusBadRequest, badID.Code)
+	assert.Equal(t, http.StatusBadRequest, badCode.Code)
+	mockService.AssertNumberOfCalls(t, "RenderPreviewImage", 1)
+}
+
+func TestVoucherHandler_Assign_Success(t *testing.T) {
+	// Arrange
+	mockService := new(MockVoucherService)
+	voucherHandler := NewVoucherHandler(mockService)
+	router := setupVoucherTestRouter()
+	router.POST("/vouchers/:id/assignments", voucherHandler.Assign)
+
+	expiresAt := time.Date(2026, 11, 15, 0, 0, 0, 0, time.UTC)
+	mockService.On("Assign", uint(7), "c-1", "").Return(&entity.VoucherAssignment{ID: 1, VoucherID: 7, CustomerID: 5, Customer: &entity.Customer{ID: 5, ExternalID: "c-1"}, ExpiresAt: expiresAt}, nil)
+
+	req, _ := http.NewRequest("POST", "/vouchers/7/assignments", bytes.NewBufferString(`{"customer_id":"c-1"}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.Contains(t, w.Body.String(), `"expires_at":"2026-11-15T00:00:00Z"`)
+	mockService.AssertExpectations(t)
+}
+
+func TestVoucherHandler_Assign_Errors(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		code int
+	}{
+		{"not found", service.ErrVoucherNotFound, http.StatusNotFound},
+		{"already assigned", service.ErrVoucherAlreadyAssigned, http.StatusConflict},
+		{"not assignable", fmt.Errorf("%w: SUMMER2024 has expired", service.ErrVoucherNotAssignable), http.StatusConflict},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			mockService := new(MockVoucherService)
+			voucherHandler := NewVoucherHandler(mockService)
+			router := setupVoucherTestRouter()
+			router.POST("/vouchers/:id/assignments", voucherHandler.Assign)
+
+			mockService.On("Assign", uint(7), "c-1", "").Return(nil, tt.err)
+
+			req, _ := http.NewRequest("POST", "/vouchers/7/assignments", bytes.NewBufferString(`{"customer_id":"c-1"}`))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+
+			// Act
+			router.ServeHTTP(w, req)
+
+			// Assert
+			assert.Equal(t, tt.code, w.Code)
+		})
+	}
+}
+
+func TestVoucherHandler_GetAssignments(t *testing.T) {
+	// Arrange
+	mockService := new(MockVoucherService)
+	voucherHandler := NewVoucherHandler(mockService)
+	router := setupVoucherTestRouter()
+	router.GET("/vouchers/:id/assignments", voucherHandler.GetAssignments)
+
+	assignments := []*entity.VoucherAssignment{
+		{ID: 2, VoucherID: 7, CustomerID: 6, Customer: &entity.Customer{ID: 6, ExternalID: "c-2"}},
+		{ID: 1, VoucherID: 7, CustomerID: 5, Customer: &entity.Customer{ID: 5, ExternalID: "c-1"}},
+	}
+	mockService.On("GetAssignments", uint(7), 1, 2).Return(assignments, int64(3), nil)
+
+	req, _ := http.NewRequest("GET", "/vouchers/7/assignments?limit=2", nil)
+	w := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "3", w.Header().Get("X-Total-Count"))
+	assert.Contains(t, w.Body.String(), `"external_id":"c-2"`)
 	mockService.AssertExpectations(t)
 }
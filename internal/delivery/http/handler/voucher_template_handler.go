@@ -0,0 +1,187 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/shoelfikar/voucher-management-system/internal/delivery/http/request"
+	"github.com/shoelfikar/voucher-management-system/internal/delivery/http/response"
+	"github.com/shoelfikar/voucher-management-system/internal/domain/service"
+	"github.com/shoelfikar/voucher-management-system/pkg/utils"
+)
+
+// voucherTemplateSortFields are the columns GET /voucher-templates may be sorted by
+var voucherTemplateSortFields = []string{"id", "name", "created_at", "updated_at"}
+
+type VoucherTemplateHandler struct {
+	templateService service.VoucherTemplateService
+}
+
+func NewVoucherTemplateHandler(templateService service.VoucherTemplateService) *VoucherTemplateHandler {
+	return &VoucherTemplateHandler{
+		templateService: templateService,
+	}
+}
+
+// GetAll handles GET /api/voucher-templates
+// @Summary Get all voucher templates
+// @Description List the presets voucher creates can start from
+// @Tags Voucher Templates
+// @Produce json
+// @Param page query int false "Page number"
+// @Param limit query int false "Items per page"
+// @Param search query string false "Search by template name"
+// @Param sort query string false "Comma-separated field:direction list, e.g. name:asc"
+// @Security BearerAuth
+// @Success 200 {object} response.Response{data=response.VoucherTemplateListResponse}
+// @Failure 400 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /api/voucher-templates [get]
+func (h *VoucherTemplateHandler) GetAll(c *gin.Context) {
+	params, err := utils.ParseListParams(c.Request.URL.Query(), voucherTemplateSortFields, "id:asc")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse(err.Error()))
+		return
+	}
+
+	templates, total, err := h.templateService.GetAll(c.Request.Context(), params.Page, params.Limit, params.Search, params.Sort)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.ErrorResponse(err.Error()))
+		return
+	}
+
+	c.Header("X-Total-Count", strconv.FormatInt(total, 10))
+	c.JSON(http.StatusOK, response.SuccessResponse(response.BuildVoucherTemplateListResponse(templates, params.Page, params.Limit, total)))
+}
+
+// GetByID handles GET /api/voucher-templates/:id
+// @Summary Get voucher template by ID
+// @Tags Voucher Templates
+// @Produce json
+// @Param id path int true "Voucher template ID"
+// @Security BearerAuth
+// @Success 200 {object} response.Response{data=entity.VoucherTemplate}
+// @Failure 400 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Router /api/voucher-templates/{id} [get]
+func (h *VoucherTemplateHandler) GetByID(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse("Invalid voucher template ID"))
+		return
+	}
+
+	template, err := h.templateService.GetByID(c.Request.Context(), uint(id))
+	if err != nil {
+		c.JSON(http.StatusNotFound, response.ErrorResponse(err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, response.SuccessResponse(template))
+}
+
+// Create handles POST /api/voucher-templates
+// @Summary Create a voucher template
+// @Description Add a named preset of default discount, expiry offset, code prefix and channels
+// @Tags Voucher Templates
+// @Accept json
+// @Produce json
+// @Param request body request.VoucherTemplateRequest true "Template definition"
+// @Security BearerAuth
+// @Success 201 {object} response.Response{data=entity.VoucherTemplate}
+// @Failure 400 {object} response.Response
+// @Failure 409 {object} response.Response
+// @Router /api/voucher-templates [post]
+func (h *VoucherTemplateHandler) Create(c *gin.Context) {
+	var req request.VoucherTemplateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse(err.Error()))
+		return
+	}
+
+	cmd := req.ToCommand()
+	cmd.CreatedBy = c.GetString("email")
+
+	template, err := h.templateService.Create(c.Request.Context(), cmd)
+	if err != nil {
+		status := http.StatusBadRequest
+		if errors.Is(err, service.ErrDuplicateVoucherTemplateName) {
+			status = http.StatusConflict
+		}
+		c.JSON(status, response.ErrorResponse(err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusCreated, response.SuccessResponseWithMessage("Voucher template created successfully", template))
+}
+
+// Update handles PUT /api/voucher-templates/:id
+// @Summary Update a voucher template
+// @Description Replace a template; vouchers already created from it are unchanged
+// @Tags Voucher Templates
+// @Accept json
+// @Produce json
+// @Param id path int true "Voucher template ID"
+// @Param request body request.VoucherTemplateRequest true "Template definition"
+// @Security BearerAuth
+// @Success 200 {object} response.Response{data=entity.VoucherTemplate}
+// @Failure 400 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 409 {object} response.Response
+// @Router /api/voucher-templates/{id} [put]
+func (h *VoucherTemplateHandler) Update(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse("Invalid voucher template ID"))
+		return
+	}
+
+	var req request.VoucherTemplateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse(err.Error()))
+		return
+	}
+
+	template, err := h.templateService.Update(c.Request.Context(), uint(id), req.ToCommand())
+	if err != nil {
+		status := http.StatusBadRequest
+		switch {
+		case errors.Is(err, service.ErrVoucherTemplateNotFound):
+			status = http.StatusNotFound
+		case errors.Is(err, service.ErrDuplicateVoucherTemplateName):
+			status = http.StatusConflict
+		}
+		c.JSON(status, response.ErrorResponse(err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, response.SuccessResponseWithMessage("Voucher template updated successfully", template))
+}
+
+// Delete handles DELETE /api/voucher-templates/:id
+// @Summary Delete a voucher template
+// @Description Remove a template; vouchers already created from it are unchanged
+// @Tags Voucher Templates
+// @Produce json
+// @Param id path int true "Voucher template ID"
+// @Security BearerAuth
+// @Success 200 {object} response.Response
+// @Failure 400 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Router /api/voucher-templates/{id} [delete]
+func (h *VoucherTemplateHandler) Delete(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse("Invalid voucher template ID"))
+		return
+	}
+
+	if err := h.templateService.Delete(c.Request.Context(), uint(id)); err != nil {
+		c.JSON(http.StatusNotFound, response.ErrorResponse(err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, response.SuccessResponseWithMessage("Voucher template deleted successfully", nil))
+}
@@ -0,0 +1,182 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/shoelfikar/voucher-management-system/internal/delivery/http/request"
+	"github.com/shoelfikar/voucher-management-system/internal/domain/entity"
+	"github.com/shoelfikar/voucher-management-system/internal/domain/service"
+	"github.com/shoelfikar/voucher-management-system/pkg/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockVoucherTemplateService is a mock implementation of VoucherTemplateService
+type MockVoucherTemplateService struct {
+	mock.Mock
+}
+
+func (m *MockVoucherTemplateService) GetAll(ctx context.Context, page, limit int, search string, sort []utils.SortField) ([]*entity.VoucherTemplate, int64, error) {
+	args := m.Called(page, limit, search, sort)
+	if args.Get(0) == nil {
+		return nil, args.Get(1).(int64), args.Error(2)
+	}
+	return args.Get(0).([]*entity.VoucherTemplate), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockVoucherTemplateService) GetByID(ctx context.Context, id uint) (*entity.VoucherTemplate, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.VoucherTemplate), args.Error(1)
+}
+
+func (m *MockVoucherTemplateService) Create(ctx context.Context, cmd *service.VoucherTemplateCommand) (*entity.VoucherTemplate, error) {
+	args := m.Called(cmd)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.VoucherTemplate), args.Error(1)
+}
+
+func (m *MockVoucherTemplateService) Update(ctx context.Context, id uint, cmd *service.VoucherTemplateCommand) (*entity.VoucherTemplate, error) {
+	args := m.Called(id, cmd)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.VoucherTemplate), args.Error(1)
+}
+
+func (m *MockVoucherTemplateService) Delete(ctx context.Context, id uint) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func TestVoucherTemplateHandler_GetAll(t *testing.T) {
+	// Arrange
+	mockTemplateService := new(MockVoucherTemplateService)
+	templateHandler := NewVoucherTemplateHandler(mockTemplateService)
+	router := setupAuthTestRouter()
+	router.GET("/voucher-templates", templateHandler.GetAll)
+
+	mockTemplateService.On("GetAll", 1, 10, "", mock.Anything).Return([]*entity.VoucherTemplate{
+		{ID: 1, Name: "Store promo", CodePrefix: "STORE-", Channels: []string{"pos"}},
+	}, int64(1), nil)
+
+	req, _ := http.NewRequest("GET", "/voucher-templates", nil)
+	w := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "1", w.Header().Get("X-Total-Count"))
+	assert.Contains(t, w.Body.String(), `"voucher_templates":[{"id":1,"name":"Store promo","code_prefix":"STORE-","channels":["pos"]`)
+}
+
+func TestVoucherTemplateHandler_Create(t *testing.T) {
+	// Arrange
+	mockTemplateService := new(MockVoucherTemplateService)
+	templateHandler := NewVoucherTemplateHandler(mockTemplateService)
+	router := setupAuthTestRouter()
+	router.POST("/voucher-templates", func(c *gin.Context) {
+		c.Set("email", "admin@example.com")
+		templateHandler.Create(c)
+	})
+
+	discount := 15.0
+	mockTemplateService.On("Create", mock.MatchedBy(func(cmd *service.VoucherTemplateCommand) bool {
+		return cmd.Name == "Store promo" && *cmd.DiscountPercent == 15 && cmd.ExpiryOffset == "+30d" && cmd.CreatedBy == "admin@example.com"
+	})).Return(&entity.VoucherTemplate{ID: 1, Name: "Store promo"}, nil)
+
+	body, _ := json.Marshal(request.VoucherTemplateRequest{Name: "Store promo", DiscountPercent: &discount, ExpiryOffset: "+30d"})
+	req, _ := http.NewRequest("POST", "/voucher-templates", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusCreated, w.Code)
+	mockTemplateService.AssertExpectations(t)
+}
+
+func TestVoucherTemplateHandler_Create_DiscountOutOfRange(t *testing.T) {
+	// Arrange
+	mockTemplateService := new(MockVoucherTemplateService)
+	templateHandler := NewVoucherTemplateHandler(mockTemplateService)
+	router := setupAuthTestRouter()
+	router.POST("/voucher-templates", templateHandler.Create)
+
+	req, _ := http.NewRequest("POST", "/voucher-templates", bytes.NewBufferString(`{"name":"Too generous","discount_percent":150}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	mockTemplateService.AssertNotCalled(t, "Create", mock.Anything)
+}
+
+func TestVoucherTemplateHandler_Update_Errors(t *testing.T) {
+	testCases := []struct {
+		name string
+		err  error
+		code int
+	}{
+		{"not found", service.ErrVoucherTemplateNotFound, http.StatusNotFound},
+		{"duplicate name", service.ErrDuplicateVoucherTemplateName, http.StatusConflict},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			mockTemplateService := new(MockVoucherTemplateService)
+			templateHandler := NewVoucherTemplateHandler(mockTemplateService)
+			router := setupAuthTestRouter()
+			router.PUT("/voucher-templates/:id", templateHandler.Update)
+
+			mockTemplateService.On("Update", uint(7), mock.Anything).Return(nil, tc.err)
+
+			req, _ := http.NewRequest("PUT", "/voucher-templates/7", bytes.NewBufferString(`{"name":"Web promo"}`))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+
+			// Act
+			router.ServeHTTP(w, req)
+
+			// Assert
+			assert.Equal(t, tc.code, w.Code)
+		})
+	}
+}
+
+func TestVoucherTemplateHandler_Delete_NotFound(t *testing.T) {
+	// Arrange
+	mockTemplateService := new(MockVoucherTemplateService)
+	templateHandler := NewVoucherTemplateHandler(mockTemplateService)
+	router := setupAuthTestRouter()
+	router.DELETE("/voucher-templates/:id", templateHandler.Delete)
+
+	mockTemplateService.On("Delete", uint(7)).Return(service.ErrVoucherTemplateNotFound)
+
+	req, _ := http.NewRequest("DELETE", "/voucher-templates/7", nil)
+	w := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
@@ -1,16 +1,21 @@
 package middleware
 
 import (
+	"errors"
 	"net/http"
 	"strings"
 
 	"github.com/gin-gonic/gin"
 	"github.com/shoelfikar/voucher-management-system/internal/delivery/http/response"
+	"github.com/shoelfikar/voucher-management-system/internal/domain/repository"
 	"github.com/shoelfikar/voucher-management-system/pkg/jwt"
 )
 
-// AuthMiddleware creates a middleware that validates JWT tokens
-func AuthMiddleware(jwtService jwt.JWTService) gin.HandlerFunc {
+// AuthMiddleware creates a middleware that validates JWT tokens. It sets the
+// token's email and the principal GetPrincipal returns, loaded from userRepo,
+// and rejects tokens of users locked since they were issued on every route;
+// pass a cached repository so repeated requests do not each query the database.
+func AuthMiddleware(jwtService jwt.JWTService, userRepo repository.UserRepository) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		authHeader := c.GetHeader("Authorization")
 
@@ -35,7 +40,19 @@ func AuthMiddleware(jwtService jwt.JWTService) gin.HandlerFunc {
 			return
 		}
 
+		principal, err := loadPrincipal(c.Request.Context(), userRepo, claims.Email)
+		if err != nil {
+			message := "Unable to identify the current user"
+			if errors.Is(err, ErrPrincipalLocked) {
+				message = "Account is locked"
+			}
+			c.JSON(http.StatusUnauthorized, response.ErrorResponse(message))
+			c.Abort()
+			return
+		}
+
 		c.Set("email", claims.Email)
+		c.Set(principalKey, principal)
 		c.Next()
 	}
 }
@@ -0,0 +1,43 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/shoelfikar/voucher-management-system/internal/delivery/http/response"
+	"github.com/shoelfikar/voucher-management-system/pkg/captcha"
+	"github.com/shoelfikar/voucher-management-system/pkg/logger"
+)
+
+// CaptchaTokenHeader is the header clients send the CAPTCHA widget's token in
+const CaptchaTokenHeader = "X-Captcha-Token"
+
+// CaptchaMiddleware creates a middleware that rejects requests without a
+// valid CAPTCHA token. When the provider cannot be reached the request is
+// refused rather than let through, so an outage does not open the endpoint
+// to bots.
+func CaptchaMiddleware(verifier captcha.Verifier) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		token := c.GetHeader(CaptchaTokenHeader)
+		if token == "" {
+			c.JSON(http.StatusBadRequest, response.ErrorResponse("Missing CAPTCHA token"))
+			c.Abort()
+			return
+		}
+
+		ok, err := verifier.Verify(token, GetClientIP(c))
+		if err != nil {
+			logger.FromContext(c.Request.Context()).Warn("captcha verification failed", "error", err)
+			c.JSON(http.StatusServiceUnavailable, response.ErrorResponse("CAPTCHA verification is unavailable, please retry later"))
+			c.Abort()
+			return
+		}
+		if !ok {
+			c.JSON(http.StatusForbidden, response.ErrorResponse("CAPTCHA verification failed"))
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
@@ -0,0 +1,24 @@
+package middleware
+
+import "github.com/gin-gonic/gin"
+
+// ClientIPKey is the context key holding the resolved client IP
+const ClientIPKey = "client_ip"
+
+// ClientIPMiddleware resolves the real client IP once per request.
+// Forwarded headers are only honored when the request comes from one of
+// the engine's trusted proxies, so the value cannot be spoofed by clients.
+func ClientIPMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Set(ClientIPKey, c.ClientIP())
+		c.Next()
+	}
+}
+
+// GetClientIP returns the real client IP for the request
+func GetClientIP(c *gin.Context) string {
+	if ip := c.GetString(ClientIPKey); ip != "" {
+		return ip
+	}
+	return c.ClientIP()
+}
@@ -0,0 +1,134 @@
+package middleware
+
+import (
+	"bytes"
+	"compress/gzip"
+	"mime"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CompressionMiddleware gzips responses of the allowed content types once
+// they reach minSize bytes. Smaller responses and other content types (such
+// as already-compressed images or archives) are sent as-is.
+func CompressionMiddleware(minSize int, contentTypes []string) gin.HandlerFunc {
+	allowed := make(map[string]bool, len(contentTypes))
+	for _, contentType := range contentTypes {
+		allowed[strings.ToLower(strings.TrimSpace(contentType))] = true
+	}
+
+	return func(c *gin.Context) {
+		if !strings.Contains(c.GetHeader("Accept-Encoding"), "gzip") {
+			c.Next()
+			return
+		}
+
+		writer := &compressionWriter{
+			ResponseWriter: c.Writer,
+			minSize:        minSize,
+			allowed:        allowed,
+		}
+		c.Writer = writer
+		c.Header("Vary", "Accept-Encoding")
+
+		c.Next()
+
+		writer.finish()
+	}
+}
+
+// compressionWriter buffers the start of the body until it knows whether the
+// response is worth compressing, then streams either gzip or plain output
+type compressionWriter struct {
+	gin.ResponseWriter
+	minSize int
+	allowed map[string]bool
+
+	buffer  bytes.Buffer
+	decided bool
+	gzip    *gzip.Writer
+}
+
+func (w *compressionWriter) Write(data []byte) (int, error) {
+	if w.decided {
+		if w.gzip != nil {
+			return w.gzip.Write(data)
+		}
+		return w.ResponseWriter.Write(data)
+	}
+
+	w.buffer.Write(data)
+	if w.buffer.Len() >= w.minSize {
+		if err := w.decide(true); err != nil {
+			return 0, err
+		}
+	}
+	return len(data), nil
+}
+
+func (w *compressionWriter) WriteString(s string) (int, error) {
+	return w.Write([]byte(s))
+}
+
+func (w *compressionWriter) Flush() {
+	if !w.decided {
+		_ = w.decide(w.buffer.Len() >= w.minSize)
+	}
+	if w.gzip != nil {
+		_ = w.gzip.Flush()
+	}
+	w.ResponseWriter.Flush()
+}
+
+// decide picks plain or gzip output and writes out the buffered bytes
+func (w *compressionWriter) decide(largeEnough bool) error {
+	w.decided = true
+
+	if largeEnough && w.compressible() {
+		w.Header().Set("Content-Encoding", "gzip")
+		w.Header().Del("Content-Length")
+		w.gzip = gzip.NewWriter(w.ResponseWriter)
+	}
+
+	if w.buffer.Len() == 0 {
+		return nil
+	}
+
+	var err error
+	if w.gzip != nil {
+		_, err = w.gzip.Write(w.buffer.Bytes())
+	} else {
+		_, err = w.ResponseWriter.Write(w.buffer.Bytes())
+	}
+	w.buffer.Reset()
+	return err
+}
+
+// compressible reports whether the response may be gzipped
+func (w *compressionWriter) compressible() bool {
+	status := w.Status()
+	if status == http.StatusNoContent || status == http.StatusNotModified {
+		return false
+	}
+	if w.Header().Get("Content-Encoding") != "" {
+		return false
+	}
+
+	mediaType, _, err := mime.ParseMediaType(w.Header().Get("Content-Type"))
+	if err != nil {
+		return false
+	}
+	return w.allowed[strings.ToLower(mediaType)]
+}
+
+// finish sends whatever is still buffered and closes the gzip stream
+func (w *compressionWriter) finish() {
+	if !w.decided {
+		_ = w.decide(false)
+	}
+	if w.gzip != nil {
+		_ = w.gzip.Close()
+	}
+}
@@ -0,0 +1,32 @@
+package middleware
+
+import (
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/shoelfikar/voucher-management-system/internal/delivery/http/response"
+)
+
+// ConcurrencyLimitMiddleware creates a middleware that lets at most limit
+// requests through at once across every route it is attached to. Requests
+// beyond the limit are not queued; they get 429 with a Retry-After header,
+// so heavy operations cannot pile up and starve interactive traffic.
+func ConcurrencyLimitMiddleware(limit int, retryAfter time.Duration) gin.HandlerFunc {
+	slots := make(chan struct{}, limit)
+	retryAfterSeconds := strconv.Itoa(int(math.Ceil(retryAfter.Seconds())))
+
+	return func(c *gin.Context) {
+		select {
+		case slots <- struct{}{}:
+			defer func() { <-slots }()
+			c.Next()
+		default:
+			c.Header("Retry-After", retryAfterSeconds)
+			c.JSON(http.StatusTooManyRequests, response.ErrorResponse("Too many heavy operations in progress, please retry later"))
+			c.Abort()
+		}
+	}
+}
@@ -1,17 +1,27 @@
 package middleware
 
 import (
+	"slices"
+	"strings"
+
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
 )
 
-// CORSMiddleware creates a CORS middleware with custom configuration
-func CORSMiddleware(allowedOrigins []string) gin.HandlerFunc {
+// CORSMiddleware creates a CORS middleware with custom configuration. The
+// allowed origins are asked for on every request, so they can be reloaded
+// without a restart; "*" allows any origin.
+func CORSMiddleware(allowedOrigins func() []string) gin.HandlerFunc {
 	config := cors.Config{
-		AllowOrigins:     allowedOrigins,
-		AllowMethods:     []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"},
-		AllowHeaders:     []string{"Origin", "Content-Type", "Accept", "Authorization"},
-		ExposeHeaders:    []string{"Content-Length"},
+		AllowOriginFunc: func(origin string) bool {
+			return slices.ContainsFunc(allowedOrigins(), func(allowed string) bool {
+				allowed = strings.TrimSpace(allowed)
+				return allowed == "*" || allowed == origin
+			})
+		},
+		AllowMethods:     []string{"GET", "HEAD", "POST", "PUT", "DELETE", "OPTIONS"},
+		AllowHeaders:     []string{"Origin", "Content-Type", "Accept", "Authorization", "If-None-Match", "X-Request-ID"},
+		ExposeHeaders:    []string{"Content-Length", "X-Total-Count", "ETag", "X-Export-As-Of", "X-Request-ID"},
 		AllowCredentials: true,
 	}
 
@@ -0,0 +1,90 @@
+package middleware
+
+import (
+	"net"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ExternalBaseURLKey is the context key holding the public base URL of the API
+const ExternalBaseURLKey = "external_base_url"
+
+// ForwardedMiddleware resolves the URL clients use to reach the service, so
+// generated links keep working behind ingress path routing. X-Forwarded-Proto,
+// X-Forwarded-Host and X-Forwarded-Prefix are only honored when the request
+// comes from one of the trusted proxies; basePath is the prefix the routes
+// are mounted under.
+func ForwardedMiddleware(basePath string, trustedProxies []string) gin.HandlerFunc {
+	trusted := parseProxies(trustedProxies)
+
+	return func(c *gin.Context) {
+		scheme := "http"
+		if c.Request.TLS != nil {
+			scheme = "https"
+		}
+		host := c.Request.Host
+		prefix := ""
+
+		if isTrusted(trusted, c.RemoteIP()) {
+			if proto := firstHeaderValue(c.GetHeader("X-Forwarded-Proto")); proto != "" {
+				scheme = proto
+			}
+			if forwardedHost := firstHeaderValue(c.GetHeader("X-Forwarded-Host")); forwardedHost != "" {
+				host = forwardedHost
+			}
+			prefix = strings.TrimRight(firstHeaderValue(c.GetHeader("X-Forwarded-Prefix")), "/")
+		}
+
+		c.Set(ExternalBaseURLKey, scheme+"://"+host+prefix+basePath)
+		c.Next()
+	}
+}
+
+// GetExternalBaseURL returns the public base URL for the request, including
+// any proxy prefix and the configured base path
+func GetExternalBaseURL(c *gin.Context) string {
+	return c.GetString(ExternalBaseURLKey)
+}
+
+// parseProxies turns proxy IPs and CIDRs into networks; invalid entries are
+// skipped since gin already rejects them when the router is set up
+func parseProxies(proxies []string) []*net.IPNet {
+	var networks []*net.IPNet
+	for _, proxy := range proxies {
+		if !strings.Contains(proxy, "/") {
+			if ip := net.ParseIP(proxy); ip != nil {
+				bits := 8 * net.IPv6len
+				if ip.To4() != nil {
+					ip = ip.To4()
+					bits = 8 * net.IPv4len
+				}
+				networks = append(networks, &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)})
+			}
+			continue
+		}
+		if _, network, err := net.ParseCIDR(proxy); err == nil {
+			networks = append(networks, network)
+		}
+	}
+	return networks
+}
+
+func isTrusted(networks []*net.IPNet, remoteIP string) bool {
+	ip := net.ParseIP(remoteIP)
+	if ip == nil {
+		return false
+	}
+	for _, network := range networks {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// firstHeaderValue returns the first entry of a comma-separated header added by chained proxies
+func firstHeaderValue(value string) string {
+	first, _, _ := strings.Cut(value, ",")
+	return strings.TrimSpace(first)
+}
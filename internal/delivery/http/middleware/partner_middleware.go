@@ -0,0 +1,57 @@
+package middleware
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/shoelfikar/voucher-management-system/internal/delivery/http/response"
+	"github.com/shoelfikar/voucher-management-system/internal/domain/entity"
+	"github.com/shoelfikar/voucher-management-system/internal/domain/service"
+)
+
+// partnerKey is the context key holding the partner a request was made by
+const partnerKey = "partner"
+
+// PartnerAPIKeyHeader is the header partners send their API key in
+const PartnerAPIKeyHeader = "X-API-Key"
+
+// PartnerAuthMiddleware creates a middleware that authenticates partners by
+// API key and scopes the request to the partner, which GetPartner returns
+func PartnerAuthMiddleware(partnerService service.PartnerService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		apiKey := c.GetHeader(PartnerAPIKeyHeader)
+		if apiKey == "" {
+			c.JSON(http.StatusUnauthorized, response.ErrorResponse("Missing API key"))
+			c.Abort()
+			return
+		}
+
+		partner, err := partnerService.Authenticate(c.Request.Context(), apiKey)
+		if err != nil {
+			switch {
+			case errors.Is(err, service.ErrInvalidAPIKey):
+				c.JSON(http.StatusUnauthorized, response.ErrorResponse("Invalid API key"))
+			case errors.Is(err, service.ErrPartnerInactive):
+				c.JSON(http.StatusForbidden, response.ErrorResponse("Partner is inactive"))
+			default:
+				c.JSON(http.StatusInternalServerError, response.ErrorResponse(err.Error()))
+			}
+			c.Abort()
+			return
+		}
+
+		c.Set(partnerKey, partner)
+		c.Next()
+	}
+}
+
+// GetPartner returns the partner of a request authenticated by PartnerAuthMiddleware
+func GetPartner(c *gin.Context) (*entity.Partner, bool) {
+	value, ok := c.Get(partnerKey)
+	if !ok {
+		return nil, false
+	}
+	partner, ok := value.(*entity.Partner)
+	return partner, ok
+}
@@ -0,0 +1,49 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+
+	"github.com/gin-gonic/gin"
+	"github.com/shoelfikar/voucher-management-system/internal/domain/repository"
+)
+
+// principalKey is the context key holding the request's principal
+const principalKey = "principal"
+
+// ErrNoPrincipal is returned when a request did not pass through AuthMiddleware
+var ErrNoPrincipal = errors.New("request is not authenticated")
+
+// ErrPrincipalLocked is returned when the token's user has been locked since
+// it was issued
+var ErrPrincipalLocked = errors.New("account is locked")
+
+// Principal is the authenticated user behind a request
+type Principal struct {
+	UserID uint
+	Email  string
+	Role   string
+}
+
+// loadPrincipal looks up the user a token was issued to. A locked user has no
+// principal, so tokens issued before the lock stop working.
+func loadPrincipal(ctx context.Context, userRepo repository.UserRepository, email string) (*Principal, error) {
+	user, err := userRepo.FindByEmail(ctx, email)
+	if err != nil {
+		return nil, err
+	}
+	if user.LockedAt != nil {
+		return nil, ErrPrincipalLocked
+	}
+	return &Principal{UserID: user.ID, Email: user.Email, Role: user.Role}, nil
+}
+
+// GetPrincipal returns the authenticated user of the request, as loaded by
+// AuthMiddleware
+func GetPrincipal(c *gin.Context) (*Principal, error) {
+	value, ok := c.Get(principalKey)
+	if !ok {
+		return nil, ErrNoPrincipal
+	}
+	return value.(*Principal), nil
+}
@@ -0,0 +1,19 @@
+package middleware
+
+import (
+	"github.com/gin-gonic/gin"
+)
+
+// QueryTokenMiddleware lets clients that cannot set headers, such as the
+// browser EventSource API, pass the JWT as the access_token query parameter.
+// It only fills in a missing Authorization header, so it must run before
+// AuthMiddleware, and should only be used on routes that need it because the
+// token then appears in access logs.
+func QueryTokenMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if token := c.Query("access_token"); token != "" && c.GetHeader("Authorization") == "" {
+			c.Request.Header.Set("Authorization", "Bearer "+token)
+		}
+		c.Next()
+	}
+}
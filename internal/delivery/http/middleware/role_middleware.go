@@ -0,0 +1,59 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/shoelfikar/voucher-management-system/internal/delivery/http/response"
+)
+
+// RequireRole creates a middleware that only lets through users holding one
+// of roles. It must run after AuthMiddleware.
+func RequireRole(roles ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		principal, err := GetPrincipal(c)
+		if err != nil {
+			c.JSON(http.StatusUnauthorized, response.ErrorResponse("Unable to identify the current user"))
+			c.Abort()
+			return
+		}
+
+		for _, role := range roles {
+			if principal.Role == role {
+				c.Next()
+				return
+			}
+		}
+
+		c.JSON(http.StatusForbidden, response.ErrorResponse("Insufficient permissions"))
+		c.Abort()
+	}
+}
+
+// ReadOnlyRoles creates a middleware that only lets users holding one of
+// roles make GET and HEAD requests. It must run after AuthMiddleware.
+func ReadOnlyRoles(roles ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.Request.Method == http.MethodGet || c.Request.Method == http.MethodHead {
+			c.Next()
+			return
+		}
+
+		principal, err := GetPrincipal(c)
+		if err != nil {
+			c.JSON(http.StatusUnauthorized, response.ErrorResponse("Unable to identify the current user"))
+			c.Abort()
+			return
+		}
+
+		for _, role := range roles {
+			if principal.Role == role {
+				c.JSON(http.StatusForbidden, response.ErrorResponse("Your role has read-only access"))
+				c.Abort()
+				return
+			}
+		}
+
+		c.Next()
+	}
+}
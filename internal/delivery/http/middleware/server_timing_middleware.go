@@ -0,0 +1,45 @@
+package middleware
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/shoelfikar/voucher-management-system/pkg/timing"
+)
+
+// ServerTimingMiddleware emits a Server-Timing header with the time spent in
+// the database and the total request duration
+func ServerTimingMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		recorder := timing.NewRecorder()
+		c.Request = c.Request.WithContext(timing.WithRecorder(c.Request.Context(), recorder))
+		c.Writer = &serverTimingWriter{ResponseWriter: c.Writer, recorder: recorder}
+		c.Next()
+	}
+}
+
+// serverTimingWriter sets the Server-Timing header right before the response
+// headers are flushed, since they cannot be changed once the body is written
+type serverTimingWriter struct {
+	gin.ResponseWriter
+	recorder *timing.Recorder
+}
+
+func (w *serverTimingWriter) setHeader() {
+	if !w.Written() {
+		w.Header().Set("Server-Timing", w.recorder.Header())
+	}
+}
+
+func (w *serverTimingWriter) WriteHeaderNow() {
+	w.setHeader()
+	w.ResponseWriter.WriteHeaderNow()
+}
+
+func (w *serverTimingWriter) Write(data []byte) (int, error) {
+	w.setHeader()
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *serverTimingWriter) WriteString(s string) (int, error) {
+	w.setHeader()
+	return w.ResponseWriter.WriteString(s)
+}
@@ -0,0 +1,26 @@
+package middleware
+
+import (
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/shoelfikar/voucher-management-system/pkg/slo"
+)
+
+// SLOMiddleware records the status and latency of every request to a
+// registered route in tracker, by route pattern so /vouchers/1 and
+// /vouchers/2 count as one endpoint. Event streams stay open for as long as
+// the client listens, so their latency says nothing and they are left out.
+func SLOMiddleware(tracker *slo.Tracker) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		c.Next()
+
+		route := c.FullPath()
+		if route == "" || strings.HasPrefix(c.Writer.Header().Get("Content-Type"), "text/event-stream") {
+			return
+		}
+		tracker.Record(c.Request.Method, route, c.Writer.Status(), time.Since(start))
+	}
+}
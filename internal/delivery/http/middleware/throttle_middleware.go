@@ -0,0 +1,69 @@
+package middleware
+
+import (
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/shoelfikar/voucher-management-system/internal/delivery/http/response"
+	"github.com/shoelfikar/voucher-management-system/pkg/throttle"
+)
+
+// ThrottleIdentity names who a request is counted against and how many
+// requests they may make per window; a limit of 0 leaves them unthrottled
+type ThrottleIdentity func(c *gin.Context) (key string, limit int)
+
+// ThrottleMiddleware creates a middleware that counts requests per identity
+// and answers 429 with a Retry-After header once limiter refuses one
+func ThrottleMiddleware(limiter throttle.RateLimiter, identify ThrottleIdentity) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		key, limit := identify(c)
+		allowed, retryAfter := limiter.Allow(key, limit)
+		if !allowed {
+			c.Header("Retry-After", strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+			c.JSON(http.StatusTooManyRequests, response.ErrorResponse("Too many requests, please retry later"))
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
+
+// ClientIdentity counts requests per client IP, for public endpoints. The
+// limit is asked for on every request, so it can be reloaded without a restart.
+func ClientIdentity(limit func() int) ThrottleIdentity {
+	return func(c *gin.Context) (string, int) {
+		return "ip:" + GetClientIP(c), limit()
+	}
+}
+
+// UserIdentity counts requests per signed-in user, by the email in their
+// JWT, and per client IP when there is none. It must run after
+// AuthMiddleware.
+func UserIdentity(limit func() int) ThrottleIdentity {
+	return func(c *gin.Context) (string, int) {
+		if email := c.GetString("email"); email != "" {
+			return "user:" + strings.ToLower(email), limit()
+		}
+		return "ip:" + GetClientIP(c), limit()
+	}
+}
+
+// PartnerIdentity counts requests per partner, using the partner's own rate
+// limit when it has one and defaultLimit otherwise. It must run after
+// PartnerAuthMiddleware.
+func PartnerIdentity(defaultLimit func() int) ThrottleIdentity {
+	return func(c *gin.Context) (string, int) {
+		partner, ok := GetPartner(c)
+		if !ok {
+			return "ip:" + GetClientIP(c), defaultLimit()
+		}
+		limit := defaultLimit()
+		if partner.RateLimit > 0 {
+			limit = partner.RateLimit
+		}
+		return "partner:" + strconv.FormatUint(uint64(partner.ID), 10), limit
+	}
+}
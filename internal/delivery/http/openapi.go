@@ -0,0 +1,496 @@
+package http
+
+import (
+	"github.com/shoelfikar/voucher-management-system/internal/delivery/http/request"
+	"github.com/shoelfikar/voucher-management-system/internal/delivery/http/response"
+	"github.com/shoelfikar/voucher-management-system/internal/domain/entity"
+	"github.com/shoelfikar/voucher-management-system/internal/domain/service"
+	"github.com/shoelfikar/voucher-management-system/pkg/openapi"
+	"github.com/shoelfikar/voucher-management-system/pkg/slo"
+)
+
+// apiOperations documents every route registered by SetupRouter, plus the
+// OPTIONS operation each path answers. The router contract test fails when a
+// route is added without an entry here.
+func apiOperations() []openapi.Operation {
+	return withOptionsOperations(documentedOperations())
+}
+
+// withOptionsOperations adds the OPTIONS operation registered for every documented path
+func withOptionsOperations(ops []openapi.Operation) []openapi.Operation {
+	seen := map[string]bool{}
+	result := ops
+	for _, op := range ops {
+		if seen[op.Path] {
+			continue
+		}
+		seen[op.Path] = true
+		result = append(result, openapi.Operation{
+			Method: "OPTIONS", Path: op.Path, Summary: "List allowed methods in the Allow header",
+			Tag: "System", NoBody: true,
+		})
+	}
+	return result
+}
+
+func documentedOperations() []openapi.Operation {
+	return []openapi.Operation{
+		{Method: "GET", Path: "/health", Summary: "Health check", Tag: "System"},
+		{Method: "GET", Path: "/version", Summary: "Build version, commit and date of the running backend", Tag: "System"},
+		{Method: "GET", Path: "/status.json", Summary: "Public service status and degraded components, for a status page", Tag: "System"},
+		{Method: "GET", Path: "/metrics", Summary: "Prometheus metrics, including database connection pool usage (only when enabled)", Tag: "System"},
+		{Method: "GET", Path: "/openapi.json", Summary: "OpenAPI specification", Tag: "System"},
+		{
+			Method: "POST", Path: "/api/v1/auth/login", Summary: "User login", Tag: "Authentication",
+			RequestBody: request.LoginRequest{}, Response: response.LoginResponse{},
+		},
+		{
+			Method: "POST", Path: "/api/v1/auth/register", Summary: "Register an account; the first becomes an admin, later ones need open registration",
+			Tag: "Authentication", RequestBody: request.RegisterRequest{}, Response: response.RegisterResponse{},
+		},
+		{
+			Method: "POST", Path: "/api/v1/auth/accept-invite", Summary: "Accept an invitation", Tag: "Authentication",
+			RequestBody: request.AcceptInviteRequest{}, Response: response.LoginResponse{},
+		},
+		{
+			Method: "GET", Path: "/api/v1/auth/verify-email", Summary: "Verify an email address", Tag: "Authentication",
+			Params: []openapi.Param{
+				{Name: "token", In: "query", Required: true, Description: "Verification token"},
+			},
+		},
+		{
+			Method: "POST", Path: "/api/v1/claim", Summary: "Claim a voucher with a one-time claim link token; throttled per client", Tag: "Vouchers",
+			Params: []openapi.Param{
+				{Name: "X-Captcha-Token", In: "header", Description: "Token from the Turnstile or reCAPTCHA widget; required when a CAPTCHA provider is configured"},
+			},
+			RequestBody: request.ClaimVoucherRequest{}, Response: entity.VoucherAssignment{},
+		},
+		{
+			Method: "POST", Path: "/api/v1/users/invite", Summary: "Invite a user", Tag: "Users", Secured: true,
+			RequestBody: request.InviteUserRequest{},
+		},
+		{
+			Method: "GET", Path: "/api/v1/vouchers", Summary: "Get all vouchers", Tag: "Vouchers", Secured: true,
+			Params: []openapi.Param{
+				{Name: "page", In: "query", Type: "integer", Description: "Page number"},
+				{Name: "limit", In: "query", Type: "integer", Description: "Items per page"},
+				{Name: "search", In: "query", Description: "Search by voucher code"},
+				{Name: "sort", In: "query", Description: "Comma-separated field:direction list, e.g. expiry_date:asc,discount_percent:desc"},
+				{Name: "sort_by", In: "query", Description: "Sort by field (ignored when sort is set)"},
+				{Name: "sort_order", In: "query", Description: "Sort order (asc/desc)"},
+				{Name: "ids", In: "query", Description: "Comma-separated voucher IDs (at most 100) to fetch in one query instead of listing; answers with a VoucherBatchResponse"},
+			},
+			Response: response.VoucherListResponse{},
+		},
+		{
+			Method: "HEAD", Path: "/api/v1/vouchers", Summary: "Count vouchers; the total is returned in X-Total-Count",
+			Tag: "Vouchers", Secured: true, NoBody: true,
+			Params: []openapi.Param{
+				{Name: "search", In: "query", Description: "Search by voucher code"},
+			},
+		},
+		{
+			Method: "GET", Path: "/api/v1/vouchers/:id", Summary: "Get voucher by ID", Tag: "Vouchers", Secured: true,
+			Response: response.VoucherResponse{},
+			Params: []openapi.Param{
+				{Name: "If-None-Match", In: "header", Description: "ETag from an earlier response; 304 Not Modified if the voucher is unchanged"},
+			},
+		},
+		{
+			Method: "GET", Path: "/api/v1/vouchers/code-available", Summary: "Check whether a voucher code is still free",
+			Tag: "Vouchers", Secured: true, Response: response.CodeAvailabilityResponse{},
+			Params: []openapi.Param{
+				{Name: "code", In: "query", Required: true, Description: "Voucher code, compared ignoring case as creating a voucher does; at most 50 characters"},
+			},
+		},
+		{
+			Method: "GET", Path: "/api/v1/vouchers/suggest", Summary: "Autocomplete voucher codes by prefix",
+			Tag: "Vouchers", Secured: true, Response: []string{},
+			Params: []openapi.Param{
+				{Name: "q", In: "query", Required: true, Description: "Code prefix, matched ignoring case; up to 10 codes are returned"},
+			},
+		},
+		{
+			Method: "GET", Path: "/api/v1/vouchers/by-external-id/:ext_id", Summary: "Get voucher by external ID",
+			Tag: "Vouchers", Secured: true, Response: response.VoucherResponse{},
+			Params: []openapi.Param{
+				{Name: "If-None-Match", In: "header", Description: "ETag from an earlier response; 304 Not Modified if the voucher is unchanged"},
+			},
+		},
+		{
+			Method: "POST", Path: "/api/v1/vouchers", Summary: "Create a new voucher", Tag: "Vouchers", Secured: true,
+			RequestBody: request.CreateVoucherRequest{}, Response: response.VoucherResponse{},
+		},
+		{
+			Method: "PUT", Path: "/api/v1/vouchers/:id", Summary: "Update a voucher", Tag: "Vouchers", Secured: true,
+			RequestBody: request.UpdateVoucherRequest{}, Response: response.VoucherResponse{},
+		},
+		{Method: "DELETE", Path: "/api/v1/vouchers/:id", Summary: "Delete a voucher", Tag: "Vouchers", Secured: true},
+		{
+			Method: "POST", Path: "/api/v1/vouchers/:id/approve", Summary: "Approve a voucher pending approval",
+			Tag: "Vouchers", Secured: true, Response: response.VoucherResponse{},
+		},
+		{
+			Method: "GET", Path: "/api/v1/vouchers/:id/assignments", Summary: "List the customers a voucher was assigned to, newest first",
+			Tag: "Vouchers", Secured: true, Response: response.VoucherAssignmentListResponse{},
+			Params: []openapi.Param{
+				{Name: "page", In: "query", Type: "integer", Description: "Page number"},
+				{Name: "limit", In: "query", Type: "integer", Description: "Items per page"},
+			},
+		},
+		{
+			Method: "POST", Path: "/api/v1/vouchers/:id/assignments", Summary: "Assign a voucher to a customer; with validity_days it expires for them that many days later",
+			Tag: "Vouchers", Secured: true, RequestBody: request.AssignVoucherRequest{}, Response: entity.VoucherAssignment{},
+		},
+		{
+			Method: "POST", Path: "/api/v1/vouchers/:id/claim-links", Summary: "Generate signed one-time links that assign the voucher to whoever claims them",
+			Tag: "Vouchers", Secured: true, RequestBody: request.CreateClaimLinksRequest{}, Response: service.ClaimLinkBatch{},
+		},
+		{
+			Method: "POST", Path: "/api/v1/vouchers/:id/sms", Summary: "Queue SMS messages with the voucher code or a claim link per recipient",
+			Tag: "Vouchers", Secured: true, RequestBody: request.SendSMSRequest{}, Response: []entity.SMSDelivery{},
+		},
+		{
+			Method: "GET", Path: "/api/v1/sms-deliveries", Summary: "List SMS deliveries and their status, newest first",
+			Tag: "Vouchers", Secured: true, Response: response.SMSDeliveryListResponse{},
+			Params: []openapi.Param{
+				{Name: "voucher_id", In: "query", Type: "integer", Description: "Only deliveries of this voucher"},
+				{Name: "status", In: "query", Description: "pending, sent or failed"},
+				{Name: "page", In: "query", Type: "integer", Description: "Page number"},
+				{Name: "limit", In: "query", Type: "integer", Description: "Items per page"},
+			},
+		},
+		{
+			Method: "POST", Path: "/api/v1/vouchers/validate", Summary: "Validate a voucher against a purchase",
+			Tag: "Vouchers", Secured: true, RequestBody: request.ValidateVoucherRequest{}, Response: service.ValidationResult{},
+		},
+		{
+			Method: "POST", Path: "/api/v1/vouchers/:id/redeem", Summary: "Redeem a voucher on a purchase and get the discount to apply; the path segment is the voucher code",
+			Tag: "Vouchers", Secured: true, RequestBody: request.RedeemVoucherRequest{}, Response: response.RedemptionResponse{},
+		},
+		{
+			Method: "POST", Path: "/api/v1/vouchers/lookup", Summary: "Look up vouchers by code",
+			Tag: "Vouchers", Secured: true, RequestBody: request.VoucherLookupRequest{}, Response: response.VoucherBatchResponse{},
+		},
+		{
+			Method: "POST", Path: "/api/v1/vouchers/bulk-deactivate", Summary: "Bulk deactivate vouchers by prefix or code list",
+			Tag: "Vouchers", Secured: true, RequestBody: request.BulkDeactivateRequest{}, Response: service.BulkDeactivateResult{},
+		},
+		{
+			Method: "PATCH", Path: "/api/v1/vouchers", Summary: "Bulk edit vouchers matching a filter with a JSON merge patch or JSON Patch",
+			Tag: "Vouchers", Secured: true, RequestBody: request.BulkEditRequest{}, Response: service.BulkEditResult{},
+		},
+		{
+			Method: "GET", Path: "/api/v1/vouchers/import-template", Summary: "Download the import template for the current CSV schema",
+			Tag: "Vouchers", Secured: true, Produces: "text/csv",
+			Params: []openapi.Param{
+				{Name: "format", In: "query", Description: "csv (default) or xlsx"},
+			},
+		},
+		{
+			Method: "POST", Path: "/api/v1/vouchers/upload-csv", Summary: "Import vouchers from CSV", Tag: "Vouchers",
+			Secured: true, Multipart: true, Response: service.ImportResult{},
+			Params: []openapi.Param{
+				{Name: "X-CSV-Schema-Version", In: "header", Description: "CSV schema version of the file (1, 2 or 3), also accepted as the schema_version form field; detected from the header row when omitted"},
+			},
+		},
+		{
+			Method: "POST", Path: "/api/v1/vouchers/upload-batch", Summary: "Upload batch of vouchers", Tag: "Vouchers",
+			Secured: true, RequestBody: request.BatchUploadRequest{}, Response: service.BatchImportResult{},
+		},
+		{
+			Method: "GET", Path: "/api/v1/vouchers/export", Summary: "Export vouchers to CSV", Tag: "Vouchers",
+			Secured: true, Produces: "text/csv",
+			Params: []openapi.Param{
+				{Name: "X-Export-Passphrase", In: "header", Description: "Encrypt the file with this passphrase (AES-256-GCM, min 8 characters)"},
+				{Name: "split_rows", In: "query", Type: "integer", Description: "Stream a ZIP of CSV files with at most this many rows each; Accept: application/zip does the same with 100000 rows per file"},
+				{Name: "updated_since", In: "query", Description: "Only export vouchers created, updated or deleted after this RFC 3339 instant; adds changed_at and deleted columns and an X-Export-As-Of header to pass next time"},
+				{Name: "schema_version", In: "query", Type: "integer", Description: "CSV schema version to export: 1 for the three original columns, 2 for seven, 3 (default) for all ten; echoed in X-CSV-Schema-Version"},
+			},
+		},
+		{
+			Method: "GET", Path: "/api/v1/vouchers/pdf", Summary: "Print vouchers as one PDF, a page each", Tag: "Vouchers",
+			Secured: true, Produces: "application/pdf",
+			Params: []openapi.Param{
+				{Name: "ids", In: "query", Required: true, Description: "Comma-separated IDs of up to 100 active, unexpired vouchers"},
+			},
+		},
+		{
+			Method: "GET", Path: "/api/v1/vouchers/:id/pdf", Summary: "Print a voucher as PDF", Tag: "Vouchers",
+			Secured: true, Produces: "application/pdf",
+		},
+		{
+			Method: "GET", Path: "/api/v1/vouchers/:id/og-image", Summary: "Render a voucher as a PNG preview image for social sharing", Tag: "Vouchers",
+			Secured: true, Produces: "image/png",
+			Params: []openapi.Param{
+				{Name: "code", In: "query", Type: "boolean", Description: "Show the voucher code, masked for roles whose codes are masked"},
+			},
+		},
+		{
+			Method: "POST", Path: "/api/v1/vouchers/import-google-sheet", Summary: "Import vouchers from Google Sheets",
+			Tag: "Vouchers", Secured: true, Response: service.ImportResult{},
+		},
+		{
+			Method: "GET", Path: "/api/v1/import-rules", Summary: "Get all import rules", Tag: "Import Rules",
+			Secured: true,
+			Params: []openapi.Param{
+				{Name: "page", In: "query", Type: "integer", Description: "Page number"},
+				{Name: "limit", In: "query", Type: "integer", Description: "Items per page"},
+				{Name: "search", In: "query", Description: "Search by rule name"},
+				{Name: "sort", In: "query", Description: "Comma-separated field:direction list, e.g. name:asc"},
+			},
+			Response: response.ImportRuleListResponse{},
+		},
+		{
+			Method: "HEAD", Path: "/api/v1/import-rules", Summary: "Count import rules; the total is returned in X-Total-Count",
+			Tag: "Import Rules", Secured: true, NoBody: true,
+			Params: []openapi.Param{
+				{Name: "search", In: "query", Description: "Search by rule name"},
+			},
+		},
+		{
+			Method: "GET", Path: "/api/v1/import-rules/:id", Summary: "Get import rule by ID", Tag: "Import Rules",
+			Secured: true, Response: entity.ImportRule{},
+		},
+		{
+			Method: "POST", Path: "/api/v1/import-rules", Summary: "Create an import rule checked on every voucher import",
+			Tag: "Import Rules", Secured: true, RequestBody: request.ImportRuleRequest{}, Response: entity.ImportRule{},
+		},
+		{
+			Method: "PUT", Path: "/api/v1/import-rules/:id", Summary: "Update an import rule", Tag: "Import Rules",
+			Secured: true, RequestBody: request.ImportRuleRequest{}, Response: entity.ImportRule{},
+		},
+		{Method: "DELETE", Path: "/api/v1/import-rules/:id", Summary: "Delete an import rule", Tag: "Import Rules", Secured: true},
+		{
+			Method: "GET", Path: "/api/v1/voucher-templates", Summary: "Get all voucher templates", Tag: "Voucher Templates",
+			Secured: true,
+			Params: []openapi.Param{
+				{Name: "page", In: "query", Type: "integer", Description: "Page number"},
+				{Name: "limit", In: "query", Type: "integer", Description: "Items per page"},
+				{Name: "search", In: "query", Description: "Search by template name"},
+				{Name: "sort", In: "query", Description: "Comma-separated field:direction list, e.g. name:asc"},
+			},
+			Response: response.VoucherTemplateListResponse{},
+		},
+		{
+			Method: "GET", Path: "/api/v1/voucher-templates/:id", Summary: "Get voucher template by ID", Tag: "Voucher Templates",
+			Secured: true, Response: entity.VoucherTemplate{},
+		},
+		{
+			Method: "POST", Path: "/api/v1/voucher-templates", Summary: "Create a voucher template that creates can reference by template_id",
+			Tag: "Voucher Templates", Secured: true, RequestBody: request.VoucherTemplateRequest{}, Response: entity.VoucherTemplate{},
+		},
+		{
+			Method: "PUT", Path: "/api/v1/voucher-templates/:id", Summary: "Update a voucher template", Tag: "Voucher Templates",
+			Secured: true, RequestBody: request.VoucherTemplateRequest{}, Response: entity.VoucherTemplate{},
+		},
+		{Method: "DELETE", Path: "/api/v1/voucher-templates/:id", Summary: "Delete a voucher template", Tag: "Voucher Templates", Secured: true},
+		{
+			Method: "GET", Path: "/api/v1/segments", Summary: "Get all customer segments", Tag: "Segments",
+			Secured: true,
+			Params: []openapi.Param{
+				{Name: "page", In: "query", Type: "integer", Description: "Page number"},
+				{Name: "limit", In: "query", Type: "integer", Description: "Items per page"},
+				{Name: "search", In: "query", Description: "Search by segment name"},
+				{Name: "sort", In: "query", Description: "Comma-separated field:direction list, e.g. name:asc"},
+			},
+			Response: response.SegmentListResponse{},
+		},
+		{
+			Method: "GET", Path: "/api/v1/segments/:id", Summary: "Get a segment with its member count", Tag: "Segments",
+			Secured: true, Response: service.SegmentDetail{},
+		},
+		{
+			Method: "POST", Path: "/api/v1/segments", Summary: "Create a static or external customer segment",
+			Tag: "Segments", Secured: true, RequestBody: request.SegmentRequest{}, Response: service.SegmentDetail{},
+		},
+		{
+			Method: "PUT", Path: "/api/v1/segments/:id", Summary: "Replace a segment and its customer list", Tag: "Segments",
+			Secured: true, RequestBody: request.SegmentRequest{}, Response: service.SegmentDetail{},
+		},
+		{Method: "DELETE", Path: "/api/v1/segments/:id", Summary: "Delete a segment no voucher targets", Tag: "Segments", Secured: true},
+		{
+			Method: "GET", Path: "/api/v1/partners", Summary: "Get all partners", Tag: "Partners",
+			Secured: true,
+			Params: []openapi.Param{
+				{Name: "page", In: "query", Type: "integer", Description: "Page number"},
+				{Name: "limit", In: "query", Type: "integer", Description: "Items per page"},
+				{Name: "search", In: "query", Description: "Search by partner name"},
+				{Name: "sort", In: "query", Description: "Comma-separated field:direction list, e.g. name:asc"},
+			},
+			Response: response.PartnerListResponse{},
+		},
+		{
+			Method: "GET", Path: "/api/v1/partners/:id", Summary: "Get a partner with its quota usage", Tag: "Partners",
+			Secured: true, Response: entity.Partner{},
+		},
+		{
+			Method: "POST", Path: "/api/v1/partners", Summary: "Create a partner; its API key is only returned here",
+			Tag: "Partners", Secured: true, RequestBody: request.PartnerRequest{}, Response: service.PartnerCredentials{},
+		},
+		{
+			Method: "PUT", Path: "/api/v1/partners/:id", Summary: "Update a partner's name, quota, rate limit or active flag", Tag: "Partners",
+			Secured: true, RequestBody: request.PartnerRequest{}, Response: entity.Partner{},
+		},
+		{
+			Method: "POST", Path: "/api/v1/partners/:id/rotate-key", Summary: "Replace a partner's API key",
+			Tag: "Partners", Secured: true, Response: service.PartnerCredentials{},
+		},
+		{
+			Method: "GET", Path: "/api/v1/partner/vouchers", Summary: "List the vouchers issued by the calling partner",
+			Tag: "Partner API", Secured: true, Security: "PartnerAPIKey",
+			Params: []openapi.Param{
+				{Name: "page", In: "query", Type: "integer", Description: "Page number"},
+				{Name: "limit", In: "query", Type: "integer", Description: "Items per page"},
+				{Name: "search", In: "query", Description: "Search by voucher code"},
+				{Name: "sort", In: "query", Description: "Comma-separated field:direction list, e.g. expiry_date:asc"},
+			},
+			Response: response.VoucherListResponse{},
+		},
+		{
+			Method: "POST", Path: "/api/v1/partner/vouchers", Summary: "Issue a voucher against the calling partner's quota",
+			Tag: "Partner API", Secured: true, Security: "PartnerAPIKey",
+			RequestBody: request.CreateVoucherRequest{}, Response: response.VoucherResponse{},
+		},
+		{
+			Method: "GET", Path: "/api/v1/customers", Summary: "List customers, leaving out merged duplicates",
+			Tag: "Customers", Secured: true,
+			Params: []openapi.Param{
+				{Name: "page", In: "query", Type: "integer", Description: "Page number"},
+				{Name: "limit", In: "query", Type: "integer", Description: "Items per page"},
+				{Name: "search", In: "query", Description: "Search by external ID, email or phone"},
+				{Name: "sort", In: "query", Description: "Comma-separated field:direction list, e.g. created_at:desc"},
+			},
+			Response: response.CustomerListResponse{},
+		},
+		{Method: "GET", Path: "/api/v1/customers/:id", Summary: "Get a customer", Tag: "Customers", Secured: true, Response: entity.Customer{}},
+		{
+			Method: "POST", Path: "/api/v1/customers", Summary: "Create a customer",
+			Tag: "Customers", Secured: true, RequestBody: request.CustomerRequest{}, Response: entity.Customer{},
+		},
+		{
+			Method: "PUT", Path: "/api/v1/customers/:id", Summary: "Update a customer's external ID and contact details",
+			Tag: "Customers", Secured: true, RequestBody: request.CustomerRequest{}, Response: entity.Customer{},
+		},
+		{Method: "DELETE", Path: "/api/v1/customers/:id", Summary: "Delete a customer nothing was recorded against", Tag: "Customers", Secured: true},
+		{
+			Method: "POST", Path: "/api/v1/customers/import", Summary: "Create or update customers from a CSV file (external_id, email, phone)",
+			Tag: "Customers", Secured: true, Multipart: true, Response: service.CustomerImportResult{},
+		},
+		{
+			Method: "POST", Path: "/api/v1/customers/:id/merge", Summary: "Merge a duplicate customer into this one (admins only)",
+			Tag: "Customers", Secured: true, RequestBody: request.MergeCustomerRequest{}, Response: entity.Customer{},
+		},
+		{
+			Method: "POST", Path: "/api/v1/customers/:id/erase", Summary: "Erase a customer's personal data, keeping its records anonymized (admins only)",
+			Tag: "Customers", Secured: true, Response: service.CustomerErasure{},
+		},
+		{
+			Method: "GET", Path: "/api/v1/discount-limits", Summary: "List the discount limits of each role (admins only)",
+			Tag: "Discount Limits", Secured: true, Response: []response.DiscountLimitResponse{},
+		},
+		{
+			Method: "PUT", Path: "/api/v1/discount-limits/:role", Summary: "Cap the discount users with a role may give (admins only)",
+			Tag: "Discount Limits", Secured: true, RequestBody: request.DiscountLimitRequest{}, Response: response.DiscountLimitResponse{},
+		},
+		{Method: "DELETE", Path: "/api/v1/discount-limits/:role", Summary: "Remove a role's discount limit (admins only)", Tag: "Discount Limits", Secured: true},
+		{
+			Method: "GET", Path: "/api/v1/email-templates", Summary: "List the current wording of each email and the variables it may use (admins only)",
+			Tag: "Email Templates", Secured: true, Response: []service.EmailTemplateSummary{},
+		},
+		{
+			Method: "GET", Path: "/api/v1/email-templates/:key/versions", Summary: "List the saved versions of an email, newest first (admins only)",
+			Tag: "Email Templates", Secured: true, Response: []entity.EmailTemplate{},
+		},
+		{
+			Method: "PUT", Path: "/api/v1/email-templates/:key", Summary: "Save a new version of an email's subject and body (admins only)",
+			Tag: "Email Templates", Secured: true, RequestBody: request.SaveEmailTemplateRequest{}, Response: entity.EmailTemplate{},
+		},
+		{
+			Method: "POST", Path: "/api/v1/email-templates/:key/preview", Summary: "Render a draft or the current wording with sample values (admins only)",
+			Tag: "Email Templates", Secured: true, RequestBody: request.PreviewEmailTemplateRequest{}, Response: service.RenderedEmail{},
+		},
+		{
+			Method: "POST", Path: "/api/v1/email-templates/:key/test", Summary: "Render an email and send it to the caller or a given address (admins only)",
+			Tag: "Email Templates", Secured: true, RequestBody: request.TestEmailTemplateRequest{}, Response: service.RenderedEmail{},
+		},
+		{
+			Method: "GET", Path: "/api/v1/system/info", Summary: "Get an operational snapshot for support (admins only)",
+			Tag: "System", Secured: true, Response: service.SystemInfo{},
+		},
+		{
+			Method: "POST", Path: "/api/v1/system/reload-config", Summary: "Reload the settings that can change without a restart (admins only)",
+			Tag: "System", Secured: true, Response: service.ConfigReload{},
+		},
+		{
+			Method: "GET", Path: "/api/v1/system/slo", Summary: "Get each endpoint's success rate and p95 latency per rolling window, checked against the configured objectives (admins only)",
+			Tag: "System", Secured: true, Response: slo.Report{},
+		},
+		{
+			Method: "GET", Path: "/api/v1/system/dead-letters", Summary: "List background jobs that ran out of attempts, with a redacted payload preview (admins only)",
+			Tag: "System", Secured: true,
+			Params: []openapi.Param{
+				{Name: "kind", In: "query", Description: "Only dead letters of this kind (sms)"},
+				{Name: "page", In: "query", Type: "integer", Description: "Page number"},
+				{Name: "limit", In: "query", Type: "integer", Description: "Items per page"},
+			},
+			Response: response.DeadLetterListResponse{},
+		},
+		{
+			Method: "POST", Path: "/api/v1/system/dead-letters/:id/retry", Summary: "Queue a dead letter again with a fresh set of attempts (admins only)",
+			Tag: "System", Secured: true,
+		},
+		{
+			Method: "POST", Path: "/api/v1/system/dead-letters/:id/discard", Summary: "Give up on a dead letter (admins only)",
+			Tag: "System", Secured: true,
+		},
+		{
+			Method: "GET", Path: "/api/v1/reports/summary.pdf", Summary: "Download a one-page PDF summary of voucher activity",
+			Tag: "Reports", Secured: true, Produces: "application/pdf",
+			Params: []openapi.Param{
+				{Name: "from", In: "query", Description: "First day of the period, YYYY-MM-DD (UTC); defaults to 29 days before to"},
+				{Name: "to", In: "query", Description: "Last day of the period, YYYY-MM-DD (UTC), at most 366 days after from; defaults to today"},
+			},
+		},
+		{
+			Method: "GET", Path: "/api/v1/retention-policy", Summary: "Get the expired voucher retention policy",
+			Tag: "Retention", Secured: true, Response: entity.RetentionPolicy{},
+		},
+		{
+			Method: "PUT", Path: "/api/v1/retention-policy", Summary: "Update the expired voucher retention policy (admins only)",
+			Tag: "Retention", Secured: true, RequestBody: request.RetentionPolicyRequest{}, Response: entity.RetentionPolicy{},
+		},
+		{
+			Method: "GET", Path: "/api/v1/retention-policy/preview", Summary: "Preview what the next cleanup run would archive or purge",
+			Tag: "Retention", Secured: true, Response: service.CleanupPreview{},
+		},
+		{
+			Method: "GET", Path: "/api/v1/stream", Summary: "Stream import and cleanup events (server-sent events)",
+			Tag: "Events", Secured: true, Produces: "text/event-stream",
+			Params: []openapi.Param{
+				{Name: "access_token", In: "query", Description: "JWT token for clients that cannot send the Authorization header, such as EventSource"},
+			},
+		},
+	}
+}
+
+// BuildOpenAPISpec generates the OpenAPI document served at /openapi.json
+func BuildOpenAPISpec() map[string]interface{} {
+	return openapi.Build(openapi.Info{
+		Title:       "Voucher Management System API",
+		Version:     "1.0.0",
+		Description: "Errors use the standard envelope with status \"error\" and a message.",
+	}, response.Response{}, apiOperations())
+}
+
+// withServerURL returns a shallow copy of the spec listing serverURL as its only server
+func withServerURL(spec map[string]interface{}, serverURL string) map[string]interface{} {
+	result := make(map[string]interface{}, len(spec)+1)
+	for key, value := range spec {
+		result[key] = value
+	}
+	result["servers"] = []interface{}{
+		map[string]interface{}{"url": serverURL},
+	}
+	return result
+}
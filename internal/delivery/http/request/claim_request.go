@@ -0,0 +1,13 @@
+package request
+
+// CreateClaimLinksRequest represents the request to generate claim links for a voucher
+type CreateClaimLinksRequest struct {
+	// Count defaults to one link when omitted
+	Count int `json:"count" binding:"omitempty,min=1,max=1000"`
+}
+
+// ClaimVoucherRequest represents a customer presenting a claim link's token
+type ClaimVoucherRequest struct {
+	Token      string `json:"token" binding:"required"`
+	CustomerID string `json:"customer_id" binding:"required,max=255"`
+}
@@ -0,0 +1,25 @@
+package request
+
+import "github.com/shoelfikar/voucher-management-system/internal/domain/service"
+
+// CustomerRequest represents the request to create or update a customer
+type CustomerRequest struct {
+	ExternalID string `json:"external_id" binding:"required,max=255"`
+	Email      string `json:"email,omitempty" binding:"omitempty,email,max=255"`
+	// Phone is in E.164 format, e.g. +14155550123
+	Phone string `json:"phone,omitempty" binding:"max=20"`
+}
+
+// ToCommand maps the request to a domain customer command
+func (r *CustomerRequest) ToCommand() *service.CustomerCommand {
+	return &service.CustomerCommand{
+		ExternalID: r.ExternalID,
+		Email:      r.Email,
+		Phone:      r.Phone,
+	}
+}
+
+// MergeCustomerRequest represents the request to merge a duplicate into a customer
+type MergeCustomerRequest struct {
+	DuplicateID uint `json:"duplicate_id" binding:"required"`
+}
@@ -0,0 +1,10 @@
+package request
+
+// DiscountLimitRequest represents the request to set a role's discount limit.
+// MaxDiscountAmounts caps fixed amounts per ISO 4217 currency, in the major
+// unit, e.g. {"IDR": 50000}; limited users cannot give fixed amounts in other
+// currencies.
+type DiscountLimitRequest struct {
+	MaxDiscountPercent float64            `json:"max_discount_percent" binding:"required,min=1,max=100"`
+	MaxDiscountAmounts map[string]float64 `json:"max_discount_amounts"`
+}
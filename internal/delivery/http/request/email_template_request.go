@@ -0,0 +1,23 @@
+package request
+
+// SaveEmailTemplateRequest represents the request to save a new version of an email
+type SaveEmailTemplateRequest struct {
+	Subject string `json:"subject" binding:"required,max=255"`
+	Body    string `json:"body" binding:"required"`
+}
+
+// PreviewEmailTemplateRequest represents the request to render an email. An
+// empty subject or body renders the current one; variables override the
+// sample values.
+type PreviewEmailTemplateRequest struct {
+	Subject   string            `json:"subject" binding:"max=255"`
+	Body      string            `json:"body"`
+	Variables map[string]string `json:"variables"`
+}
+
+// TestEmailTemplateRequest represents the request to send a rendered email to
+// an address; without one it goes to the signed-in admin
+type TestEmailTemplateRequest struct {
+	PreviewEmailTemplateRequest
+	To string `json:"to" binding:"omitempty,email"`
+}
@@ -0,0 +1,28 @@
+package request
+
+import "github.com/shoelfikar/voucher-management-system/internal/domain/service"
+
+// ImportRuleRequest represents the request to create or replace an import rule
+type ImportRuleRequest struct {
+	Name    string `json:"name" binding:"required,max=100"`
+	Type    string `json:"type" binding:"required,oneof=code_prefix code_pattern min_discount max_discount max_validity_days"`
+	Value   string `json:"value" binding:"required,max=255"`
+	Message string `json:"message,omitempty" binding:"max=255"`
+	Enabled *bool  `json:"enabled,omitempty"`
+}
+
+// ToCommand maps the request to a domain import rule command; rules are enabled unless stated otherwise
+func (r *ImportRuleRequest) ToCommand() *service.ImportRuleCommand {
+	enabled := true
+	if r.Enabled != nil {
+		enabled = *r.Enabled
+	}
+
+	return &service.ImportRuleCommand{
+		Name:    r.Name,
+		Type:    r.Type,
+		Value:   r.Value,
+		Message: r.Message,
+		Enabled: enabled,
+	}
+}
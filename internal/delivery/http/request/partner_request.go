@@ -0,0 +1,27 @@
+package request
+
+import "github.com/shoelfikar/voucher-management-system/internal/domain/service"
+
+// PartnerRequest represents the request to create or update a partner
+type PartnerRequest struct {
+	Name  string `json:"name" binding:"required,max=100"`
+	Quota *int64 `json:"quota" binding:"required,min=0"`
+	// RateLimit caps API requests per throttle window; 0 or omitted uses the default
+	RateLimit int `json:"rate_limit" binding:"omitempty,min=0"`
+	// Active defaults to true when omitted
+	Active *bool `json:"active,omitempty"`
+}
+
+// ToCommand maps the request to a domain partner command
+func (r *PartnerRequest) ToCommand() *service.PartnerCommand {
+	cmd := &service.PartnerCommand{
+		Name:      r.Name,
+		Quota:     *r.Quota,
+		RateLimit: r.RateLimit,
+		Active:    true,
+	}
+	if r.Active != nil {
+		cmd.Active = *r.Active
+	}
+	return cmd
+}
@@ -0,0 +1,10 @@
+package request
+
+import "github.com/shoelfikar/voucher-management-system/pkg/rules"
+
+// RedeemVoucherRequest represents the request to redeem a voucher on a purchase;
+// context.customer_id is required
+type RedeemVoucherRequest struct {
+	OrderID string        `json:"order_id" binding:"max=100"`
+	Context rules.Context `json:"context"`
+}
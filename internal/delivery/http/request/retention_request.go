@@ -0,0 +1,19 @@
+package request
+
+import "github.com/shoelfikar/voucher-management-system/internal/domain/service"
+
+// RetentionPolicyRequest represents the request to replace the expired voucher retention policy
+type RetentionPolicyRequest struct {
+	KeepDays *int   `json:"keep_days" binding:"required,min=0,max=3650"`
+	Action   string `json:"action" binding:"required,oneof=archive purge"`
+	Enabled  bool   `json:"enabled"`
+}
+
+// ToCommand maps the request to a domain retention policy command
+func (r *RetentionPolicyRequest) ToCommand() *service.RetentionPolicyCommand {
+	return &service.RetentionPolicyCommand{
+		KeepDays: *r.KeepDays,
+		Action:   r.Action,
+		Enabled:  r.Enabled,
+	}
+}
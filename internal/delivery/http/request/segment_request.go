@@ -0,0 +1,21 @@
+package request
+
+import "github.com/shoelfikar/voucher-management-system/internal/domain/service"
+
+// SegmentRequest represents the request to create or replace a customer segment
+type SegmentRequest struct {
+	Name        string   `json:"name" binding:"required,max=100"`
+	Type        string   `json:"type" binding:"required,oneof=static external"`
+	ExternalRef string   `json:"external_ref,omitempty" binding:"max=255"`
+	CustomerIDs []string `json:"customer_ids,omitempty"`
+}
+
+// ToCommand maps the request to a domain segment command
+func (r *SegmentRequest) ToCommand() *service.SegmentCommand {
+	return &service.SegmentCommand{
+		Name:        r.Name,
+		Type:        r.Type,
+		ExternalRef: r.ExternalRef,
+		CustomerIDs: r.CustomerIDs,
+	}
+}
@@ -0,0 +1,14 @@
+package request
+
+// SendSMSRequest represents the request to text a voucher to customers
+type SendSMSRequest struct {
+	// Content is "code" to send the voucher code, or "claim_link" to send each recipient a claim link
+	Content    string         `json:"content" binding:"required,oneof=code claim_link"`
+	Recipients []SMSRecipient `json:"recipients" binding:"required,min=1,max=1000,dive"`
+}
+
+// SMSRecipient is a customer's phone number in E.164 format
+type SMSRecipient struct {
+	PhoneNumber string `json:"phone_number" binding:"required,max=20"`
+	CustomerID  string `json:"customer_id" binding:"max=255"`
+}
@@ -0,0 +1,13 @@
+package request
+
+// InviteUserRequest represents the request to invite a new user
+type InviteUserRequest struct {
+	Email string `json:"email" binding:"required,email"`
+	Role  string `json:"role" binding:"required,oneof=admin marketing viewer"`
+}
+
+// AcceptInviteRequest represents the request to accept an invitation
+type AcceptInviteRequest struct {
+	Token    string `json:"token" binding:"required"`
+	Password string `json:"password" binding:"required,min=6"`
+}
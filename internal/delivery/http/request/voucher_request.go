@@ -1,20 +1,162 @@
 package request
 
-// CreateVoucherRequest represents the request to create a new voucher
+import (
+	"encoding/json"
+
+	"github.com/shoelfikar/voucher-management-system/internal/domain/service"
+	"github.com/shoelfikar/voucher-management-system/pkg/rules"
+)
+
+// CreateVoucherRequest represents the request to create a new voucher. With a
+// template_id, the code, discount and expiry date may come from the template.
+// Fixed-amount vouchers send discount_amount and currency instead of discount_percent.
 type CreateVoucherRequest struct {
-	VoucherCode     string  `json:"voucher_code" binding:"required,max=50"`
-	DiscountPercent float64 `json:"discount_percent" binding:"required,min=1,max=100"`
-	ExpiryDate      string  `json:"expiry_date" binding:"required"`
+	VoucherCode           string            `json:"voucher_code" binding:"required_without=TemplateID,max=50"`
+	ExternalID            string            `json:"external_id,omitempty" binding:"max=100"`
+	DiscountType          string            `json:"discount_type,omitempty" binding:"omitempty,oneof=percent fixed_amount"`
+	DiscountPercent       float64           `json:"discount_percent" binding:"required_without_all=TemplateID DiscountAmount,omitempty,min=1,max=100"`
+	DiscountAmount        float64           `json:"discount_amount,omitempty" binding:"omitempty,gt=0"`
+	Currency              string            `json:"currency,omitempty" binding:"omitempty,len=3"`
+	ExpiryDate            string            `json:"expiry_date" binding:"required_without=TemplateID"`
+	ValidityDays          *int              `json:"validity_days,omitempty" binding:"omitempty,min=1,max=3650"`
+	MaxRedemptions        *int              `json:"max_redemptions,omitempty" binding:"omitempty,min=1"`
+	MaxRedemptionsPerUser int               `json:"max_redemptions_per_user,omitempty" binding:"omitempty,min=1"`
+	Rules                 json.RawMessage   `json:"rules,omitempty"`
+	DisplayName           string            `json:"display_name" binding:"max=100"`
+	Description           string            `json:"description" binding:"max=1000"`
+	TermsURL              string            `json:"terms_url" binding:"omitempty,url,max=500"`
+	Metadata              map[string]string `json:"metadata,omitempty"`
+	SegmentID             *uint             `json:"segment_id,omitempty"`
+	TemplateID            *uint             `json:"template_id,omitempty"`
+}
+
+// ToCommand maps the request to a domain create command
+func (r *CreateVoucherRequest) ToCommand() *service.CreateVoucherCommand {
+	return &service.CreateVoucherCommand{
+		VoucherCode:           r.VoucherCode,
+		ExternalID:            r.ExternalID,
+		DiscountType:          r.DiscountType,
+		DiscountPercent:       r.DiscountPercent,
+		DiscountAmount:        r.DiscountAmount,
+		Currency:              r.Currency,
+		ExpiryDate:            r.ExpiryDate,
+		ValidityDays:          r.ValidityDays,
+		MaxRedemptions:        r.MaxRedemptions,
+		MaxRedemptionsPerUser: r.MaxRedemptionsPerUser,
+		Rules:                 string(r.Rules),
+		DisplayName:           r.DisplayName,
+		Description:           r.Description,
+		TermsURL:              r.TermsURL,
+		Metadata:              r.Metadata,
+		SegmentID:             r.SegmentID,
+		TemplateID:            r.TemplateID,
+	}
 }
 
 // UpdateVoucherRequest represents the request to update an existing voucher
 type UpdateVoucherRequest struct {
-	VoucherCode     string  `json:"voucher_code" binding:"required,max=50"`
-	DiscountPercent float64 `json:"discount_percent" binding:"required,min=1,max=100"`
-	ExpiryDate      string  `json:"expiry_date" binding:"required"`
+	VoucherCode           string            `json:"voucher_code" binding:"required,max=50"`
+	DiscountType          string            `json:"discount_type,omitempty" binding:"omitempty,oneof=percent fixed_amount"`
+	DiscountPercent       float64           `json:"discount_percent" binding:"required_without=DiscountAmount,omitempty,min=1,max=100"`
+	DiscountAmount        float64           `json:"discount_amount,omitempty" binding:"omitempty,gt=0"`
+	Currency              string            `json:"currency,omitempty" binding:"omitempty,len=3"`
+	ExpiryDate            string            `json:"expiry_date" binding:"required"`
+	ValidityDays          *int              `json:"validity_days,omitempty" binding:"omitempty,min=1,max=3650"`
+	MaxRedemptions        *int              `json:"max_redemptions,omitempty" binding:"omitempty,min=1"`
+	MaxRedemptionsPerUser int               `json:"max_redemptions_per_user,omitempty" binding:"omitempty,min=1"`
+	Rules                 json.RawMessage   `json:"rules,omitempty"`
+	DisplayName           string            `json:"display_name" binding:"max=100"`
+	Description           string            `json:"description" binding:"max=1000"`
+	TermsURL              string            `json:"terms_url" binding:"omitempty,url,max=500"`
+	Metadata              map[string]string `json:"metadata,omitempty"`
+	SegmentID             *uint             `json:"segment_id,omitempty"`
+}
+
+// ToCommand maps the request to a domain update command
+func (r *UpdateVoucherRequest) ToCommand() *service.UpdateVoucherCommand {
+	return &service.UpdateVoucherCommand{
+		VoucherCode:           r.VoucherCode,
+		DiscountType:          r.DiscountType,
+		DiscountPercent:       r.DiscountPercent,
+		DiscountAmount:        r.DiscountAmount,
+		Currency:              r.Currency,
+		ExpiryDate:            r.ExpiryDate,
+		ValidityDays:          r.ValidityDays,
+		MaxRedemptions:        r.MaxRedemptions,
+		MaxRedemptionsPerUser: r.MaxRedemptionsPerUser,
+		Rules:                 string(r.Rules),
+		DisplayName:           r.DisplayName,
+		Description:           r.Description,
+		TermsURL:              r.TermsURL,
+		Metadata:              r.Metadata,
+		SegmentID:             r.SegmentID,
+	}
 }
 
 // BatchUploadRequest represents the request to upload a batch of vouchers
 type BatchUploadRequest struct {
 	Vouchers []CreateVoucherRequest `json:"vouchers" binding:"required"`
 }
+
+// ToCommands maps the batch request to domain create commands
+func (r *BatchUploadRequest) ToCommands() []service.CreateVoucherCommand {
+	commands := make([]service.CreateVoucherCommand, len(r.Vouchers))
+	for i := range r.Vouchers {
+		commands[i] = *r.Vouchers[i].ToCommand()
+	}
+	return commands
+}
+
+// ValidateVoucherRequest represents the request to check a voucher against a purchase
+type ValidateVoucherRequest struct {
+	VoucherCode string        `json:"voucher_code" binding:"required,max=50"`
+	Context     rules.Context `json:"context"`
+}
+
+// VoucherLookupRequest represents the request to fetch several vouchers by code
+type VoucherLookupRequest struct {
+	Codes []string `json:"codes" binding:"required"`
+}
+
+// BulkDeactivateRequest represents the request to deactivate vouchers by prefix or code list
+type BulkDeactivateRequest struct {
+	Prefix string   `json:"prefix"`
+	Codes  []string `json:"codes"`
+}
+
+// BulkEditFilterRequest selects the vouchers a bulk edit applies to
+type BulkEditFilterRequest struct {
+	IDs       []uint   `json:"ids"`
+	Codes     []string `json:"codes"`
+	Prefix    string   `json:"prefix"`
+	Status    string   `json:"status"`
+	SegmentID *uint    `json:"segment_id"`
+	PartnerID *uint    `json:"partner_id"`
+}
+
+// BulkEditRequest represents the request to patch every voucher matching a filter.
+// Patch is a JSON merge patch object or a JSON Patch array.
+type BulkEditRequest struct {
+	Filter BulkEditFilterRequest `json:"filter"`
+	Patch  json.RawMessage       `json:"patch" binding:"required" swaggertype:"object"`
+}
+
+// ToCommand converts BulkEditRequest to service.BulkEditCommand
+func (r *BulkEditRequest) ToCommand() *service.BulkEditCommand {
+	return &service.BulkEditCommand{
+		Filter: service.BulkEditFilter{
+			IDs:        r.Filter.IDs,
+			Codes:      r.Filter.Codes,
+			CodePrefix: r.Filter.Prefix,
+			Status:     r.Filter.Status,
+			SegmentID:  r.Filter.SegmentID,
+			PartnerID:  r.Filter.PartnerID,
+		},
+		Patch: r.Patch,
+	}
+}
+
+// AssignVoucherRequest represents the request to assign a voucher to a customer
+type AssignVoucherRequest struct {
+	CustomerID string `json:"customer_id" binding:"required,max=255"`
+}
@@ -0,0 +1,23 @@
+package request
+
+import "github.com/shoelfikar/voucher-management-system/internal/domain/service"
+
+// VoucherTemplateRequest represents the request to create or replace a voucher template
+type VoucherTemplateRequest struct {
+	Name            string   `json:"name" binding:"required,max=100"`
+	DiscountPercent *float64 `json:"discount_percent,omitempty" binding:"omitempty,min=1,max=100"`
+	ExpiryOffset    string   `json:"expiry_offset,omitempty" binding:"max=10"`
+	CodePrefix      string   `json:"code_prefix,omitempty" binding:"max=20"`
+	Channels        []string `json:"channels,omitempty" binding:"max=20,dive,max=50"`
+}
+
+// ToCommand maps the request to a domain voucher template command
+func (r *VoucherTemplateRequest) ToCommand() *service.VoucherTemplateCommand {
+	return &service.VoucherTemplateCommand{
+		Name:            r.Name,
+		DiscountPercent: r.DiscountPercent,
+		ExpiryOffset:    r.ExpiryOffset,
+		CodePrefix:      r.CodePrefix,
+		Channels:        r.Channels,
+	}
+}
@@ -6,7 +6,15 @@ type LoginResponse struct {
 	User  UserInfo `json:"user"`
 }
 
+// RegisterResponse represents the registration response. Token is omitted
+// when the email address must be verified before logging in.
+type RegisterResponse struct {
+	Token string   `json:"token,omitempty"`
+	User  UserInfo `json:"user"`
+}
+
 // UserInfo represents user information in response
 type UserInfo struct {
 	Email string `json:"email"`
+	Role  string `json:"role,omitempty"`
 }
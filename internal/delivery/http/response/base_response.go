@@ -32,3 +32,21 @@ func ValidationErrorResponse(errors interface{}) Response {
 func PaginatedResponse(data interface{}, page, limit int, total int64) PaginationResponse {
 	return utils.PaginatedResponse(data, page, limit, total)
 }
+
+// PaginationMeta represents pagination metadata, shared by every list endpoint
+type PaginationMeta struct {
+	Page       int   `json:"page"`
+	Limit      int   `json:"limit"`
+	Total      int64 `json:"total"`
+	TotalPages int   `json:"total_pages"`
+}
+
+// NewPaginationMeta builds pagination metadata for a page of a list
+func NewPaginationMeta(page, limit int, total int64) PaginationMeta {
+	return PaginationMeta{
+		Page:       page,
+		Limit:      limit,
+		Total:      total,
+		TotalPages: utils.CalculateTotalPages(total, limit),
+	}
+}
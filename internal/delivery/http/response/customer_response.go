@@ -0,0 +1,20 @@
+package response
+
+import "github.com/shoelfikar/voucher-management-system/internal/domain/entity"
+
+// CustomerListResponse represents a list of customers with pagination
+type CustomerListResponse struct {
+	Customers  []*entity.Customer `json:"customers"`
+	Pagination PaginationMeta     `json:"pagination"`
+}
+
+// BuildCustomerListResponse builds a customer list response with pagination
+func BuildCustomerListResponse(customers []*entity.Customer, page, limit int, total int64) CustomerListResponse {
+	if customers == nil {
+		customers = []*entity.Customer{}
+	}
+	return CustomerListResponse{
+		Customers:  customers,
+		Pagination: NewPaginationMeta(page, limit, total),
+	}
+}
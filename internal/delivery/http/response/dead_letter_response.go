@@ -0,0 +1,20 @@
+package response
+
+import "github.com/shoelfikar/voucher-management-system/internal/domain/service"
+
+// DeadLetterListResponse represents a list of dead letters with pagination
+type DeadLetterListResponse struct {
+	DeadLetters []*service.DeadLetter `json:"dead_letters"`
+	Pagination  PaginationMeta        `json:"pagination"`
+}
+
+// BuildDeadLetterListResponse builds a dead letter list response with pagination
+func BuildDeadLetterListResponse(letters []*service.DeadLetter, page, limit int, total int64) DeadLetterListResponse {
+	if letters == nil {
+		letters = []*service.DeadLetter{}
+	}
+	return DeadLetterListResponse{
+		DeadLetters: letters,
+		Pagination:  NewPaginationMeta(page, limit, total),
+	}
+}
@@ -0,0 +1,43 @@
+package response
+
+import (
+	"time"
+
+	"github.com/shoelfikar/voucher-management-system/internal/domain/entity"
+)
+
+// DiscountLimitResponse represents a role's discount limit in response;
+// max_discount_amounts are in the major unit of each currency, e.g. 50000
+type DiscountLimitResponse struct {
+	Role               string             `json:"role"`
+	MaxDiscountPercent float64            `json:"max_discount_percent"`
+	MaxDiscountAmounts map[string]float64 `json:"max_discount_amounts,omitempty"`
+	UpdatedBy          string             `json:"updated_by,omitempty"`
+	UpdatedAt          time.Time          `json:"updated_at"`
+}
+
+// ToDiscountLimitResponse converts entity.DiscountLimit to DiscountLimitResponse
+func ToDiscountLimitResponse(limit *entity.DiscountLimit) DiscountLimitResponse {
+	limitResponse := DiscountLimitResponse{
+		Role:               limit.Role,
+		MaxDiscountPercent: limit.MaxDiscountPercent,
+		UpdatedBy:          limit.UpdatedBy,
+		UpdatedAt:          limit.UpdatedAt,
+	}
+	if len(limit.MaxDiscountAmounts) > 0 {
+		limitResponse.MaxDiscountAmounts = make(map[string]float64, len(limit.MaxDiscountAmounts))
+		for currency, amount := range limit.MaxDiscountAmounts {
+			limitResponse.MaxDiscountAmounts[currency] = entity.FromMinorUnits(amount, currency)
+		}
+	}
+	return limitResponse
+}
+
+// ToDiscountLimitResponses converts a list of limits
+func ToDiscountLimitResponses(limits []*entity.DiscountLimit) []DiscountLimitResponse {
+	responses := make([]DiscountLimitResponse, len(limits))
+	for i, limit := range limits {
+		responses[i] = ToDiscountLimitResponse(limit)
+	}
+	return responses
+}
@@ -0,0 +1,20 @@
+package response
+
+import "github.com/shoelfikar/voucher-management-system/internal/domain/entity"
+
+// ImportRuleListResponse represents a list of import rules with pagination
+type ImportRuleListResponse struct {
+	ImportRules []*entity.ImportRule `json:"import_rules"`
+	Pagination  PaginationMeta       `json:"pagination"`
+}
+
+// BuildImportRuleListResponse builds an import rule list response with pagination
+func BuildImportRuleListResponse(rules []*entity.ImportRule, page, limit int, total int64) ImportRuleListResponse {
+	if rules == nil {
+		rules = []*entity.ImportRule{}
+	}
+	return ImportRuleListResponse{
+		ImportRules: rules,
+		Pagination:  NewPaginationMeta(page, limit, total),
+	}
+}
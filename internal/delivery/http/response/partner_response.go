@@ -0,0 +1,20 @@
+package response
+
+import "github.com/shoelfikar/voucher-management-system/internal/domain/entity"
+
+// PartnerListResponse represents a list of partners with pagination
+type PartnerListResponse struct {
+	Partners   []*entity.Partner `json:"partners"`
+	Pagination PaginationMeta    `json:"pagination"`
+}
+
+// BuildPartnerListResponse builds a partner list response with pagination
+func BuildPartnerListResponse(partners []*entity.Partner, page, limit int, total int64) PartnerListResponse {
+	if partners == nil {
+		partners = []*entity.Partner{}
+	}
+	return PartnerListResponse{
+		Partners:   partners,
+		Pagination: NewPaginationMeta(page, limit, total),
+	}
+}
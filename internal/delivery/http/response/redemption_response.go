@@ -0,0 +1,40 @@
+package response
+
+import (
+	"time"
+
+	"github.com/shoelfikar/voucher-management-system/internal/domain/entity"
+)
+
+// RedemptionResponse represents a redemption in response; discount_amount is
+// in the major unit of currency, e.g. 10.5 rather than 1050 cents
+type RedemptionResponse struct {
+	ID              uint             `json:"id"`
+	VoucherID       uint             `json:"voucher_id"`
+	CustomerID      uint             `json:"customer_id"`
+	Customer        *entity.Customer `json:"customer,omitempty"`
+	OrderID         string           `json:"order_id,omitempty"`
+	DiscountType    string           `json:"discount_type"`
+	DiscountPercent float64          `json:"discount_percent"`
+	DiscountAmount  float64          `json:"discount_amount,omitempty"`
+	Currency        string           `json:"currency,omitempty"`
+	RedeemedBy      string           `json:"redeemed_by,omitempty"`
+	RedeemedAt      time.Time        `json:"redeemed_at"`
+}
+
+// ToRedemptionResponse converts entity.Redemption to RedemptionResponse
+func ToRedemptionResponse(redemption *entity.Redemption) RedemptionResponse {
+	return RedemptionResponse{
+		ID:              redemption.ID,
+		VoucherID:       redemption.VoucherID,
+		CustomerID:      redemption.CustomerID,
+		Customer:        redemption.Customer,
+		OrderID:         redemption.OrderID,
+		DiscountType:    redemption.DiscountType,
+		DiscountPercent: redemption.DiscountPercent,
+		DiscountAmount:  entity.FromMinorUnits(redemption.DiscountAmount, redemption.Currency),
+		Currency:        redemption.Currency,
+		RedeemedBy:      redemption.RedeemedBy,
+		RedeemedAt:      redemption.RedeemedAt,
+	}
+}
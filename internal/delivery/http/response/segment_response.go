@@ -0,0 +1,20 @@
+package response
+
+import "github.com/shoelfikar/voucher-management-system/internal/domain/entity"
+
+// SegmentListResponse represents a list of segments with pagination
+type SegmentListResponse struct {
+	Segments   []*entity.Segment `json:"segments"`
+	Pagination PaginationMeta    `json:"pagination"`
+}
+
+// BuildSegmentListResponse builds a segment list response with pagination
+func BuildSegmentListResponse(segments []*entity.Segment, page, limit int, total int64) SegmentListResponse {
+	if segments == nil {
+		segments = []*entity.Segment{}
+	}
+	return SegmentListResponse{
+		Segments:   segments,
+		Pagination: NewPaginationMeta(page, limit, total),
+	}
+}
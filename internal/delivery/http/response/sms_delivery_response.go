@@ -0,0 +1,20 @@
+package response
+
+import "github.com/shoelfikar/voucher-management-system/internal/domain/entity"
+
+// SMSDeliveryListResponse represents a list of SMS deliveries with pagination
+type SMSDeliveryListResponse struct {
+	Deliveries []*entity.SMSDelivery `json:"deliveries"`
+	Pagination PaginationMeta        `json:"pagination"`
+}
+
+// BuildSMSDeliveryListResponse builds an SMS delivery list response with pagination
+func BuildSMSDeliveryListResponse(deliveries []*entity.SMSDelivery, page, limit int, total int64) SMSDeliveryListResponse {
+	if deliveries == nil {
+		deliveries = []*entity.SMSDelivery{}
+	}
+	return SMSDeliveryListResponse{
+		Deliveries: deliveries,
+		Pagination: NewPaginationMeta(page, limit, total),
+	}
+}
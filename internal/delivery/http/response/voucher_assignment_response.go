@@ -0,0 +1,20 @@
+package response
+
+import "github.com/shoelfikar/voucher-management-system/internal/domain/entity"
+
+// VoucherAssignmentListResponse represents a list of a voucher's assignments with pagination
+type VoucherAssignmentListResponse struct {
+	Assignments []*entity.VoucherAssignment `json:"assignments"`
+	Pagination  PaginationMeta              `json:"pagination"`
+}
+
+// BuildVoucherAssignmentListResponse builds a voucher assignment list response with pagination
+func BuildVoucherAssignmentListResponse(assignments []*entity.VoucherAssignment, page, limit int, total int64) VoucherAssignmentListResponse {
+	if assignments == nil {
+		assignments = []*entity.VoucherAssignment{}
+	}
+	return VoucherAssignmentListResponse{
+		Assignments: assignments,
+		Pagination:  NewPaginationMeta(page, limit, total),
+	}
+}
@@ -1,19 +1,43 @@
 package response
 
 import (
+	"encoding/json"
 	"time"
 
 	"github.com/shoelfikar/voucher-management-system/internal/domain/entity"
 )
 
-// VoucherResponse represents a single voucher in response
+// VoucherResponse represents a single voucher in response; discount_amount and
+// currency are only set on fixed-amount vouchers, and remaining_redemptions is
+// left out when the voucher's redemptions are not capped
 type VoucherResponse struct {
-	ID              uint    `json:"id"`
-	VoucherCode     string  `json:"voucher_code"`
-	DiscountPercent float64 `json:"discount_percent"`
-	ExpiryDate      string  `json:"expiry_date"`
-	CreatedAt       string  `json:"created_at"`
-	UpdatedAt       string  `json:"updated_at"`
+	ID                    uint              `json:"id"`
+	VoucherCode           string            `json:"voucher_code"`
+	ExternalID            string            `json:"external_id,omitempty"`
+	DiscountType          string            `json:"discount_type"`
+	DiscountPercent       float64           `json:"discount_percent"`
+	DiscountAmount        float64           `json:"discount_amount,omitempty"`
+	Currency              string            `json:"currency,omitempty"`
+	ExpiryDate            string            `json:"expiry_date"`
+	ValidityDays          *int              `json:"validity_days,omitempty"`
+	MaxRedemptions        *int              `json:"max_redemptions,omitempty"`
+	MaxRedemptionsPerUser int               `json:"max_redemptions_per_user"`
+	RedemptionCount       int               `json:"redemption_count"`
+	RemainingRedemptions  *int              `json:"remaining_redemptions,omitempty"`
+	Status                string            `json:"status"`
+	Rules                 json.RawMessage   `json:"rules,omitempty"`
+	DisplayName           string            `json:"display_name,omitempty"`
+	Description           string            `json:"description,omitempty"`
+	TermsURL              string            `json:"terms_url,omitempty"`
+	Metadata              map[string]string `json:"metadata,omitempty"`
+	SegmentID             *uint             `json:"segment_id,omitempty"`
+	PartnerID             *uint             `json:"partner_id,omitempty"`
+	CreatedBy             string            `json:"created_by,omitempty"`
+	UpdatedBy             string            `json:"updated_by,omitempty"`
+	ApprovedBy            string            `json:"approved_by,omitempty"`
+	ApprovedAt            string            `json:"approved_at,omitempty"`
+	CreatedAt             string            `json:"created_at"`
+	UpdatedAt             string            `json:"updated_at"`
 }
 
 // VoucherListResponse represents a list of vouchers with pagination
@@ -22,24 +46,57 @@ type VoucherListResponse struct {
 	Pagination PaginationMeta    `json:"pagination"`
 }
 
-// PaginationMeta represents pagination metadata
-type PaginationMeta struct {
-	Page       int   `json:"page"`
-	Limit      int   `json:"limit"`
-	Total      int64 `json:"total"`
-	TotalPages int   `json:"total_pages"`
+// VoucherBatchResponse represents the vouchers found by a batch lookup
+type VoucherBatchResponse struct {
+	Vouchers []VoucherResponse `json:"vouchers"`
+}
+
+// CodeAvailabilityResponse reports whether a voucher code is still free
+type CodeAvailabilityResponse struct {
+	Code      string `json:"code"`
+	Available bool   `json:"available"`
 }
 
 // ToVoucherResponse converts entity.Voucher to VoucherResponse
 func ToVoucherResponse(voucher *entity.Voucher) VoucherResponse {
-	return VoucherResponse{
-		ID:              voucher.ID,
-		VoucherCode:     voucher.VoucherCode,
-		DiscountPercent: voucher.DiscountPercent,
-		ExpiryDate:      voucher.ExpiryDate.Format("2006-01-02"),
-		CreatedAt:       voucher.CreatedAt.Format(time.RFC3339),
-		UpdatedAt:       voucher.UpdatedAt.Format(time.RFC3339),
+	voucherResponse := VoucherResponse{
+		ID:                    voucher.ID,
+		VoucherCode:           voucher.VoucherCode,
+		DiscountType:          voucher.DiscountType,
+		DiscountPercent:       voucher.DiscountPercent,
+		DiscountAmount:        entity.FromMinorUnits(voucher.DiscountAmount, voucher.Currency),
+		Currency:              voucher.Currency,
+		ExpiryDate:            voucher.ExpiryDate.Format("2006-01-02"),
+		ValidityDays:          voucher.ValidityDays,
+		MaxRedemptions:        voucher.MaxRedemptions,
+		MaxRedemptionsPerUser: voucher.MaxRedemptionsPerUser,
+		RedemptionCount:       voucher.RedemptionCount,
+		RemainingRedemptions:  voucher.RemainingRedemptions(),
+		Status:                voucher.Status,
+		DisplayName:           voucher.DisplayName,
+		Description:           voucher.Description,
+		TermsURL:              voucher.TermsURL,
+		SegmentID:             voucher.SegmentID,
+		PartnerID:             voucher.PartnerID,
+		CreatedBy:             voucher.CreatedBy,
+		UpdatedBy:             voucher.UpdatedBy,
+		ApprovedBy:            voucher.ApprovedBy,
+		CreatedAt:             voucher.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:             voucher.UpdatedAt.Format(time.RFC3339),
+	}
+	if voucher.ExternalID != nil {
+		voucherResponse.ExternalID = *voucher.ExternalID
+	}
+	if voucher.Metadata != "" {
+		_ = json.Unmarshal([]byte(voucher.Metadata), &voucherResponse.Metadata)
 	}
+	if voucher.Rules != "" {
+		voucherResponse.Rules = json.RawMessage(voucher.Rules)
+	}
+	if voucher.ApprovedAt != nil {
+		voucherResponse.ApprovedAt = voucher.ApprovedAt.Format(time.RFC3339)
+	}
+	return voucherResponse
 }
 
 // ToVoucherListResponse converts a list of vouchers to VoucherListResponse
@@ -53,18 +110,8 @@ func ToVoucherListResponse(vouchers []*entity.Voucher) []VoucherResponse {
 
 // BuildVoucherListResponse builds a complete voucher list response with pagination
 func BuildVoucherListResponse(vouchers []*entity.Voucher, page, limit int, total int64) VoucherListResponse {
-	totalPages := int(total / int64(limit))
-	if total%int64(limit) > 0 {
-		totalPages++
-	}
-
 	return VoucherListResponse{
-		Vouchers: ToVoucherListResponse(vouchers),
-		Pagination: PaginationMeta{
-			Page:       page,
-			Limit:      limit,
-			Total:      total,
-			TotalPages: totalPages,
-		},
+		Vouchers:   ToVoucherListResponse(vouchers),
+		Pagination: NewPaginationMeta(page, limit, total),
 	}
 }
@@ -0,0 +1,43 @@
+package response
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/shoelfikar/voucher-management-system/internal/domain/entity"
+	"github.com/shoelfikar/voucher-management-system/pkg/utils"
+)
+
+// BenchmarkBuildVoucherListResponse measures converting and encoding a full
+// page of the voucher list, as GET /vouchers does with the largest limit
+func BenchmarkBuildVoucherListResponse(b *testing.B) {
+	now := time.Now()
+	vouchers := make([]*entity.Voucher, utils.MaxLimit)
+	for i := range vouchers {
+		vouchers[i] = &entity.Voucher{
+			ID:              uint(i + 1),
+			VoucherCode:     fmt.Sprintf("LIST%05d", i),
+			DiscountPercent: 12.5,
+			ExpiryDate:      now.AddDate(0, 3, 0),
+			Status:          entity.VoucherStatusActive,
+			Rules:           `[{"type":"channel","values":["pos","web"]}]`,
+			DisplayName:     "Summer sale",
+			Description:     "15% off everything in store",
+			Metadata:        `{"campaign":"summer","region":"west"}`,
+			CreatedBy:       "admin@example.com",
+			CreatedAt:       now,
+			UpdatedAt:       now,
+		}
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		body := SuccessResponse(BuildVoucherListResponse(vouchers, 1, len(vouchers), 5000))
+		if _, err := json.Marshal(body); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,20 @@
+package response
+
+import "github.com/shoelfikar/voucher-management-system/internal/domain/entity"
+
+// VoucherTemplateListResponse represents a list of voucher templates with pagination
+type VoucherTemplateListResponse struct {
+	VoucherTemplates []*entity.VoucherTemplate `json:"voucher_templates"`
+	Pagination       PaginationMeta            `json:"pagination"`
+}
+
+// BuildVoucherTemplateListResponse builds a voucher template list response with pagination
+func BuildVoucherTemplateListResponse(templates []*entity.VoucherTemplate, page, limit int, total int64) VoucherTemplateListResponse {
+	if templates == nil {
+		templates = []*entity.VoucherTemplate{}
+	}
+	return VoucherTemplateListResponse{
+		VoucherTemplates: templates,
+		Pagination:       NewPaginationMeta(page, limit, total),
+	}
+}
@@ -1,52 +1,358 @@
 package http
 
 import (
+	"net/http"
+	"sort"
+	"strings"
+
 	"github.com/gin-gonic/gin"
 	"github.com/shoelfikar/voucher-management-system/internal/delivery/http/handler"
+	"github.com/shoelfikar/voucher-management-system/internal/delivery/http/middleware"
+	"github.com/shoelfikar/voucher-management-system/internal/domain/entity"
+	"github.com/shoelfikar/voucher-management-system/pkg/buildinfo"
 )
 
 // SetupRouter configures and returns the Gin router with all routes
 func SetupRouter(
 	authHandler *handler.AuthHandler,
 	voucherHandler *handler.VoucherHandler,
+	userHandler *handler.UserHandler,
+	sheetImportHandler *handler.SheetImportHandler,
+	importRuleHandler *handler.ImportRuleHandler,
+	voucherTemplateHandler *handler.VoucherTemplateHandler,
+	retentionHandler *handler.RetentionHandler,
+	segmentHandler *handler.SegmentHandler,
+	partnerHandler *handler.PartnerHandler,
+	systemHandler *handler.SystemHandler,
+	streamHandler *handler.StreamHandler,
+	claimHandler *handler.ClaimHandler,
+	smsHandler *handler.SMSHandler,
+	discountLimitHandler *handler.DiscountLimitHandler,
+	deadLetterHandler *handler.DeadLetterHandler,
+	redemptionHandler *handler.RedemptionHandler,
+	emailTemplateHandler *handler.EmailTemplateHandler,
+	customerHandler *handler.CustomerHandler,
+	reportHandler *handler.ReportHandler,
+	sloHandler *handler.SLOHandler,
 	authMiddleware gin.HandlerFunc,
+	partnerAuthMiddleware gin.HandlerFunc,
 	corsMiddleware gin.HandlerFunc,
-) *gin.Engine {
-	r := gin.Default()
+	serverTimingMiddleware gin.HandlerFunc,
+	compressionMiddleware gin.HandlerFunc,
+	heavyOperationMiddleware gin.HandlerFunc,
+	claimThrottleMiddleware gin.HandlerFunc,
+	captchaMiddleware gin.HandlerFunc,
+	partnerThrottleMiddleware gin.HandlerFunc,
+	authThrottleMiddleware gin.HandlerFunc,
+	userThrottleMiddleware gin.HandlerFunc,
+	uploadThrottleMiddleware gin.HandlerFunc,
+	sloMiddleware gin.HandlerFunc,
+	metricsHandler gin.HandlerFunc,
+	trustedProxies []string,
+	basePath string,
+) (*gin.Engine, error) {
+	// Requests are logged with their correlation ID, so the ID comes first
+	// and panics are recovered inside the request log and SLO tracking,
+	// where they show up as 500s
+	r := gin.New()
+	r.Use(middleware.RequestIDMiddleware(), middleware.RequestLogMiddleware())
+	if sloMiddleware != nil {
+		r.Use(sloMiddleware)
+	}
+	r.Use(gin.Recovery())
+
+	// Only honor X-Forwarded-For/X-Real-IP when sent by a trusted proxy
+	if err := r.SetTrustedProxies(trustedProxies); err != nil {
+		return nil, err
+	}
+
+	// Server-Timing is opt-in and wraps everything after it
+	if serverTimingMiddleware != nil {
+		r.Use(serverTimingMiddleware)
+	}
+
+	// Compression is opt-in; it sits inside Server-Timing so the header is set before the body is encoded
+	if compressionMiddleware != nil {
+		r.Use(compressionMiddleware)
+	}
 
 	r.Use(corsMiddleware)
+	r.Use(middleware.ClientIPMiddleware())
+	r.Use(middleware.ForwardedMiddleware(basePath, trustedProxies))
+
+	// Everything is mounted under the configured base path (empty by default)
+	root := r.Group(basePath)
 
 	// Health check endpoint (public)
-	r.GET("/health", func(c *gin.Context) {
+	root.GET("/health", func(c *gin.Context) {
 		c.JSON(200, gin.H{
 			"status":  "ok",
 			"message": "Voucher Management System API is running",
 		})
 	})
 
-	api := r.Group("/api/v1")
+	// Build version (public) so clients can detect a backend version mismatch
+	root.GET("/version", func(c *gin.Context) {
+		build := buildinfo.Get()
+		c.JSON(200, gin.H{
+			"version":    build.Version,
+			"commit":     build.Commit,
+			"build_date": build.BuildDate,
+		})
+	})
+
+	// Service status (public) for embedding into a status page
+	root.GET("/status.json", systemHandler.Status)
+
+	// Prometheus metrics (public) when enabled; restrict access at the network level
+	if metricsHandler != nil {
+		root.GET("/metrics", metricsHandler)
+	}
+
+	// OpenAPI specification (public) for client SDK generation
+	// The servers entry points at the URL the client used, so it is set per request
+	spec := BuildOpenAPISpec()
+	root.GET("/openapi.json", func(c *gin.Context) {
+		c.JSON(200, withServerURL(spec, middleware.GetExternalBaseURL(c)))
+	})
+
+	// Imports and exports share a concurrency limit when one is configured
+	heavy := func(handlers ...gin.HandlerFunc) []gin.HandlerFunc {
+		if heavyOperationMiddleware == nil {
+			return handlers
+		}
+		return append([]gin.HandlerFunc{heavyOperationMiddleware}, handlers...)
+	}
+
+	// Uploads are throttled per user before they take a heavy operation slot
+	upload := func(handlers ...gin.HandlerFunc) []gin.HandlerFunc {
+		if uploadThrottleMiddleware == nil {
+			return heavy(handlers...)
+		}
+		return append([]gin.HandlerFunc{uploadThrottleMiddleware}, heavy(handlers...)...)
+	}
+
+	// Viewers may read everything but change nothing. Marketing users run
+	// campaigns, so they keep creating, editing, deleting and importing
+	// vouchers: discount limits cap what they may give, and vouchers above the
+	// approval threshold wait for an admin. Approving, inviting users and
+	// changing how vouchers are issued is for admins only.
+	readOnlyViewers := middleware.ReadOnlyRoles(entity.UserRoleViewer)
+	adminOnly := middleware.RequireRole(entity.UserRoleAdmin)
+
+	// Public claims are throttled per client before the CAPTCHA is checked,
+	// so a flood does not turn into calls to the CAPTCHA provider
+	var claimHandlers []gin.HandlerFunc
+	for _, m := range []gin.HandlerFunc{claimThrottleMiddleware, captchaMiddleware} {
+		if m != nil {
+			claimHandlers = append(claimHandlers, m)
+		}
+	}
+	claimHandlers = append(claimHandlers, claimHandler.Claim)
+
+	api := root.Group("/api/v1")
 	{
-		// Auth routes (public)
-		api.POST("/auth/login", authHandler.Login)
+		// Auth routes (public), throttled per client IP against password and token guessing
+		auth := api.Group("/auth")
+		if authThrottleMiddleware != nil {
+			auth.Use(authThrottleMiddleware)
+		}
+		{
+			auth.POST("/login", authHandler.Login)
+			auth.POST("/register", authHandler.Register)
+			auth.POST("/accept-invite", userHandler.AcceptInvite)
+			auth.GET("/verify-email", userHandler.VerifyEmail)
+		}
+
+		// Customers claim vouchers from one-time claim links (public; the signed token is the credential)
+		api.POST("/claim", claimHandlers...)
+
+		// Event stream for the admin dashboard; EventSource can only send the token in the query
+		api.GET("/stream", middleware.QueryTokenMiddleware(), authMiddleware, streamHandler.Stream)
+
+		// Partner routes, authenticated by API key and scoped to the calling partner
+		partner := api.Group("/partner")
+		partner.Use(partnerAuthMiddleware)
+		if partnerThrottleMiddleware != nil {
+			partner.Use(partnerThrottleMiddleware)
+		}
+		{
+			partner.GET("/vouchers", partnerHandler.GetVouchers)
+			partner.POST("/vouchers", partnerHandler.IssueVoucher)
+		}
 
 		protected := api.Group("")
 		protected.Use(authMiddleware)
+		if userThrottleMiddleware != nil {
+			protected.Use(userThrottleMiddleware)
+		}
 		{
+			// User routes
+			protected.POST("/users/invite", adminOnly, userHandler.Invite)
+
 			// Voucher routes
 			vouchers := protected.Group("/vouchers")
 			{
 				vouchers.GET("", voucherHandler.GetAll)
+				vouchers.HEAD("", voucherHandler.GetAll)
+				vouchers.GET("/suggest", voucherHandler.Suggest)
+				vouchers.GET("/code-available", voucherHandler.CodeAvailable)
+				vouchers.GET("/import-template", voucherHandler.ImportTemplate)
 				vouchers.GET("/:id", voucherHandler.GetByID)
-				vouchers.POST("", voucherHandler.Create)
-				vouchers.PUT("/:id", voucherHandler.Update)
-				vouchers.DELETE("/:id", voucherHandler.Delete)
+				vouchers.GET("/by-external-id/:ext_id", voucherHandler.GetByExternalID)
+				vouchers.POST("", readOnlyViewers, voucherHandler.Create)
+				vouchers.PUT("/:id", readOnlyViewers, voucherHandler.Update)
+				vouchers.DELETE("/:id", readOnlyViewers, voucherHandler.Delete)
+				vouchers.POST("/:id/approve", adminOnly, voucherHandler.Approve)
+				vouchers.GET("/:id/assignments", voucherHandler.GetAssignments)
+				vouchers.POST("/:id/assignments", readOnlyViewers, voucherHandler.Assign)
+				vouchers.POST("/:id/claim-links", readOnlyViewers, claimHandler.CreateLinks)
+				vouchers.POST("/:id/sms", readOnlyViewers, smsHandler.Send)
+				vouchers.POST("/validate", voucherHandler.Validate)
+				// Gin needs one wildcard name per segment, so the code is bound as :id here
+				vouchers.POST("/:id/redeem", readOnlyViewers, redemptionHandler.Redeem)
+				vouchers.POST("/lookup", voucherHandler.Lookup)
+				vouchers.POST("/bulk-deactivate", readOnlyViewers, voucherHandler.BulkDeactivate)
+				vouchers.PATCH("", readOnlyViewers, voucherHandler.BulkEdit)
+
+				vouchers.POST("/upload-csv", upload(readOnlyViewers, voucherHandler.ImportCSV)...)
+				vouchers.POST("/upload-batch", upload(readOnlyViewers, voucherHandler.UploadBatch)...)
+				vouchers.GET("/export", heavy(voucherHandler.ExportCSV)...)
+				vouchers.GET("/pdf", heavy(voucherHandler.PrintPDFBatch)...)
+				vouchers.GET("/:id/pdf", voucherHandler.PrintPDF)
+				vouchers.GET("/:id/og-image", voucherHandler.PreviewImage)
+
+				// Only available when Google Sheets credentials are configured
+				if sheetImportHandler != nil {
+					vouchers.POST("/import-google-sheet", heavy(readOnlyViewers, sheetImportHandler.ImportGoogleSheet)...)
+				}
+			}
+
+			// SMS delivery status tracking
+			protected.GET("/sms-deliveries", smsHandler.GetDeliveries)
+
+			// Import validation rule routes
+			importRules := protected.Group("/import-rules")
+			{
+				importRules.GET("", importRuleHandler.GetAll)
+				importRules.HEAD("", importRuleHandler.GetAll)
+				importRules.GET("/:id", importRuleHandler.GetByID)
+				importRules.POST("", adminOnly, importRuleHandler.Create)
+				importRules.PUT("/:id", adminOnly, importRuleHandler.Update)
+				importRules.DELETE("/:id", adminOnly, importRuleHandler.Delete)
+			}
+
+			// Voucher templates
+			voucherTemplates := protected.Group("/voucher-templates")
+			{
+				voucherTemplates.GET("", voucherTemplateHandler.GetAll)
+				voucherTemplates.GET("/:id", voucherTemplateHandler.GetByID)
+				voucherTemplates.POST("", adminOnly, voucherTemplateHandler.Create)
+				voucherTemplates.PUT("/:id", adminOnly, voucherTemplateHandler.Update)
+				voucherTemplates.DELETE("/:id", adminOnly, voucherTemplateHandler.Delete)
+			}
+
+			// Customer segment routes
+			segments := protected.Group("/segments")
+			{
+				segments.GET("", segmentHandler.GetAll)
+				segments.GET("/:id", segmentHandler.GetByID)
+				segments.POST("", adminOnly, segmentHandler.Create)
+				segments.PUT("/:id", adminOnly, segmentHandler.Update)
+				segments.DELETE("/:id", adminOnly, segmentHandler.Delete)
+			}
+
+			// Customers vouchers are assigned to, claimed by and redeemed by
+			customers := protected.Group("/customers")
+			customers.Use(readOnlyViewers)
+			{
+				customers.GET("", customerHandler.GetAll)
+				customers.GET("/:id", customerHandler.GetByID)
+				customers.POST("", customerHandler.Create)
+				customers.PUT("/:id", customerHandler.Update)
+				customers.DELETE("/:id", customerHandler.Delete)
+				customers.POST("/import", heavy(customerHandler.Import)...)
+				// Merges and erasures cannot be undone
+				customers.POST("/:id/merge", adminOnly, customerHandler.Merge)
+				customers.POST("/:id/erase", adminOnly, customerHandler.Erase)
+			}
+
+			// Partner management routes; partners issue vouchers with their own keys
+			partners := protected.Group("/partners")
+			{
+				partners.GET("", partnerHandler.GetAll)
+				partners.GET("/:id", partnerHandler.GetByID)
+				partners.POST("", adminOnly, partnerHandler.Create)
+				partners.PUT("/:id", adminOnly, partnerHandler.Update)
+				partners.POST("/:id/rotate-key", adminOnly, partnerHandler.RotateKey)
+			}
+
+			// Per-role discount limits, managed by admins
+			discountLimits := protected.Group("/discount-limits")
+			discountLimits.Use(adminOnly)
+			{
+				discountLimits.GET("", discountLimitHandler.GetAll)
+				discountLimits.PUT("/:role", discountLimitHandler.Set)
+				discountLimits.DELETE("/:role", discountLimitHandler.Delete)
+			}
+
+			// Wording of the emails the system sends, managed by admins
+			emailTemplates := protected.Group("/email-templates")
+			emailTemplates.Use(adminOnly)
+			{
+				emailTemplates.GET("", emailTemplateHandler.GetAll)
+				emailTemplates.GET("/:key/versions", emailTemplateHandler.GetVersions)
+				emailTemplates.PUT("/:key", emailTemplateHandler.Save)
+				emailTemplates.POST("/:key/preview", emailTemplateHandler.Preview)
+				emailTemplates.POST("/:key/test", emailTemplateHandler.SendTest)
+			}
+
+			// Operational snapshot for support triage
+			protected.GET("/system/info", adminOnly, systemHandler.Info)
+			protected.POST("/system/reload-config", adminOnly, systemHandler.ReloadConfig)
+			protected.GET("/system/slo", adminOnly, sloHandler.Report)
 
-				vouchers.POST("/upload-csv", voucherHandler.ImportCSV)
-				vouchers.POST("/upload-batch", voucherHandler.UploadBatch)
-				vouchers.GET("/export", voucherHandler.ExportCSV)
+			// Background jobs that ran out of attempts, for admins to retry or discard
+			deadLetters := protected.Group("/system/dead-letters")
+			deadLetters.Use(adminOnly)
+			{
+				deadLetters.GET("", deadLetterHandler.GetAll)
+				deadLetters.POST("/:id/retry", deadLetterHandler.Retry)
+				deadLetters.POST("/:id/discard", deadLetterHandler.Discard)
 			}
+
+			// Reports on voucher activity for stakeholders
+			protected.GET("/reports/summary.pdf", heavy(reportHandler.SummaryPDF)...)
+
+			// Expired voucher retention routes
+			protected.GET("/retention-policy", retentionHandler.GetPolicy)
+			protected.PUT("/retention-policy", adminOnly, retentionHandler.UpdatePolicy)
+			protected.GET("/retention-policy/preview", retentionHandler.Preview)
 		}
 	}
 
-	return r
+	registerOptionsRoutes(r)
+
+	return r, nil
+}
+
+// registerOptionsRoutes answers OPTIONS on every route path with an Allow
+// header listing its methods. It must run after all other routes are added.
+// CORS preflight requests never get here; the CORS middleware answers them.
+func registerOptionsRoutes(r *gin.Engine) {
+	methodsByPath := map[string][]string{}
+	for _, route := range r.Routes() {
+		methodsByPath[route.Path] = append(methodsByPath[route.Path], route.Method)
+	}
+
+	for path, methods := range methodsByPath {
+		methods = append(methods, http.MethodOptions)
+		sort.Strings(methods)
+		allow := strings.Join(methods, ", ")
+
+		r.OPTIONS(path, func(c *gin.Context) {
+			c.Header("Allow", allow)
+			c.Status(http.StatusOK)
+		})
+	}
 }
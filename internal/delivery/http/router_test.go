@@ -0,0 +1,810 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/shoelfikar/voucher-management-system/internal/delivery/http/handler"
+	"github.com/shoelfikar/voucher-management-system/internal/delivery/http/middleware"
+	"github.com/shoelfikar/voucher-management-system/internal/domain/entity"
+	"github.com/shoelfikar/voucher-management-system/internal/domain/repository"
+	"github.com/shoelfikar/voucher-management-system/pkg/buildinfo"
+	"github.com/shoelfikar/voucher-management-system/pkg/jwt"
+	"github.com/shoelfikar/voucher-management-system/pkg/metrics"
+	"github.com/shoelfikar/voucher-management-system/pkg/openapi"
+	"github.com/shoelfikar/voucher-management-system/pkg/slo"
+	"github.com/shoelfikar/voucher-management-system/pkg/throttle"
+	"github.com/stretchr/testify/assert"
+)
+
+func setupContractTestRouter(t *testing.T) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	noop := func(c *gin.Context) { c.Next() }
+
+	router, err := SetupRouter(
+		handler.NewAuthHandler(nil),
+		handler.NewVoucherHandler(nil),
+		handler.NewUserHandler(nil),
+		handler.NewSheetImportHandler(nil, nil),
+		handler.NewImportRuleHandler(nil),
+		handler.NewVoucherTemplateHandler(nil),
+		handler.NewRetentionHandler(nil),
+		handler.NewSegmentHandler(nil),
+		handler.NewPartnerHandler(nil),
+		handler.NewSystemHandler(nil),
+		handler.NewStreamHandler(nil),
+		handler.NewClaimHandler(nil),
+		handler.NewSMSHandler(nil),
+		handler.NewDiscountLimitHandler(nil),
+		handler.NewDeadLetterHandler(nil),
+		handler.NewRedemptionHandler(nil),
+		handler.NewEmailTemplateHandler(nil),
+		handler.NewCustomerHandler(nil),
+		handler.NewReportHandler(nil),
+		handler.NewSLOHandler(nil),
+		noop,
+		noop,
+		noop,
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		gin.WrapH(metrics.NewRegistry()),
+		nil,
+		"",
+	)
+	if err != nil {
+		t.Fatalf("Failed to set up router: %v", err)
+	}
+	return router
+}
+
+func TestOpenAPISpec_CoversAllRoutes(t *testing.T) {
+	// Arrange
+	router := setupContractTestRouter(t)
+	paths := BuildOpenAPISpec()["paths"].(map[string]interface{})
+
+	// Assert every registered route is documented
+	for _, route := range router.Routes() {
+		item, ok := paths[openapi.ToOpenAPIPath(route.Path)].(map[string]interface{})
+		if assert.True(t, ok, "route %s %s is missing from the spec", route.Method, route.Path) {
+			assert.Contains(t, item, strings.ToLower(route.Method), "route %s %s is missing from the spec", route.Method, route.Path)
+		}
+	}
+}
+
+func TestOpenAPISpec_HasNoStaleOperations(t *testing.T) {
+	// Arrange
+	router := setupContractTestRouter(t)
+	registered := map[string]bool{}
+	for _, route := range router.Routes() {
+		registered[route.Method+" "+openapi.ToOpenAPIPath(route.Path)] = true
+	}
+
+	// Assert every documented operation is registered
+	for _, op := range apiOperations() {
+		key := op.Method + " " + openapi.ToOpenAPIPath(op.Path)
+		assert.True(t, registered[key], "spec documents %s which has no route", key)
+	}
+}
+
+func TestOpenAPISpec_Endpoint(t *testing.T) {
+	// Arrange
+	router := setupContractTestRouter(t)
+	req, _ := http.NewRequest("GET", "/openapi.json", nil)
+	w := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var spec map[string]interface{}
+	err := json.Unmarshal(w.Body.Bytes(), &spec)
+	assert.NoError(t, err)
+	assert.Equal(t, "3.0.3", spec["openapi"])
+
+	schemas := spec["components"].(map[string]interface{})["schemas"].(map[string]interface{})
+	assert.Contains(t, schemas, "VoucherResponse")
+	assert.Contains(t, schemas, "CreateVoucherRequest")
+}
+
+func TestSetupRouter_BasePath(t *testing.T) {
+	// Arrange
+	gin.SetMode(gin.TestMode)
+	noop := func(c *gin.Context) { c.Next() }
+	router, err := SetupRouter(
+		handler.NewAuthHandler(nil),
+		handler.NewVoucherHandler(nil),
+		handler.NewUserHandler(nil),
+		handler.NewSheetImportHandler(nil, nil),
+		handler.NewImportRuleHandler(nil),
+		handler.NewVoucherTemplateHandler(nil),
+		handler.NewRetentionHandler(nil),
+		handler.NewSegmentHandler(nil),
+		handler.NewPartnerHandler(nil),
+		handler.NewSystemHandler(nil),
+		handler.NewStreamHandler(nil),
+		handler.NewClaimHandler(nil),
+		handler.NewSMSHandler(nil),
+		handler.NewDiscountLimitHandler(nil),
+		handler.NewDeadLetterHandler(nil),
+		handler.NewRedemptionHandler(nil),
+		handler.NewEmailTemplateHandler(nil),
+		handler.NewCustomerHandler(nil),
+		handler.NewReportHandler(nil),
+		handler.NewSLOHandler(nil),
+		noop,
+		noop,
+		noop,
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		"/voucher-service",
+	)
+	assert.NoError(t, err)
+
+	prefixed := httptest.NewRecorder()
+	unprefixed := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(prefixed, httptest.NewRequest("GET", "/voucher-service/health", nil))
+	router.ServeHTTP(unprefixed, httptest.NewRequest("GET", "/health", nil))
+
+	// Assert
+	assert.Equal(t, http.StatusOK, prefixed.Code)
+	assert.Equal(t, http.StatusNotFound, unprefixed.Code)
+}
+
+func TestOpenAPISpec_ServerURLHonorsTrustedProxy(t *testing.T) {
+	// Arrange
+	gin.SetMode(gin.TestMode)
+	noop := func(c *gin.Context) { c.Next() }
+	router, err := SetupRouter(
+		handler.NewAuthHandler(nil),
+		handler.NewVoucherHandler(nil),
+		handler.NewUserHandler(nil),
+		handler.NewSheetImportHandler(nil, nil),
+		handler.NewImportRuleHandler(nil),
+		handler.NewVoucherTemplateHandler(nil),
+		handler.NewRetentionHandler(nil),
+		handler.NewSegmentHandler(nil),
+		handler.NewPartnerHandler(nil),
+		handler.NewSystemHandler(nil),
+		handler.NewStreamHandler(nil),
+		handler.NewClaimHandler(nil),
+		handler.NewSMSHandler(nil),
+		handler.NewDiscountLimitHandler(nil),
+		handler.NewDeadLetterHandler(nil),
+		handler.NewRedemptionHandler(nil),
+		handler.NewEmailTemplateHandler(nil),
+		handler.NewCustomerHandler(nil),
+		handler.NewReportHandler(nil),
+		handler.NewSLOHandler(nil),
+		noop,
+		noop,
+		noop,
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		[]string{"10.0.0.0/8"},
+		"/voucher-service",
+	)
+	assert.NoError(t, err)
+
+	serverURL := func(remoteAddr string) string {
+		req := httptest.NewRequest("GET", "/voucher-service/openapi.json", nil)
+		req.Host = "internal:8080"
+		req.RemoteAddr = remoteAddr
+		req.Header.Set("X-Forwarded-Proto", "https")
+		req.Header.Set("X-Forwarded-Host", "api.example.com")
+		req.Header.Set("X-Forwarded-Prefix", "/edge/")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		var spec map[string]interface{}
+		_ = json.Unmarshal(w.Body.Bytes(), &spec)
+		servers := spec["servers"].([]interface{})
+		return servers[0].(map[string]interface{})["url"].(string)
+	}
+
+	// Act
+	trusted := serverURL("10.1.2.3:4567")
+	untrusted := serverURL("203.0.113.9:4567")
+
+	// Assert
+	assert.Equal(t, "https://api.example.com/edge/voucher-service", trusted)
+	assert.Equal(t, "http://internal:8080/voucher-service", untrusted)
+}
+
+func TestSetupRouter_OptionsListsAllowedMethods(t *testing.T) {
+	// Arrange
+	router := setupContractTestRouter(t)
+	w := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(w, httptest.NewRequest("OPTIONS", "/api/v1/vouchers/42", nil))
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "DELETE, GET, OPTIONS, PUT", w.Header().Get("Allow"))
+	assert.Empty(t, w.Body.String())
+}
+
+func TestSetupRouter_Version(t *testing.T) {
+	// Arrange
+	router := setupContractTestRouter(t)
+	w := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/version", nil))
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	var body map[string]string
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+	assert.Equal(t, buildinfo.Get().Version, body["version"])
+	assert.NotEmpty(t, body["commit"])
+	assert.NotEmpty(t, body["build_date"])
+}
+
+func TestSetupRouter_RequestID(t *testing.T) {
+	// Arrange
+	router := setupContractTestRouter(t)
+	var out bytes.Buffer
+	defaultLogger := slog.Default()
+	slog.SetDefault(slog.New(slog.NewJSONHandler(&out, nil)))
+	t.Cleanup(func() { slog.SetDefault(defaultLogger) })
+
+	forwarded := httptest.NewRequest("GET", "/health", nil)
+	forwarded.Header.Set(middleware.RequestIDHeader, "edge-7f3a")
+	forwardedW := httptest.NewRecorder()
+	invalid := httptest.NewRequest("GET", "/version?access_token=secret", nil)
+	invalid.Header.Set(middleware.RequestIDHeader, "not a valid id")
+	invalidW := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(forwardedW, forwarded)
+	router.ServeHTTP(invalidW, invalid)
+
+	// Assert
+	assert.Equal(t, "edge-7f3a", forwardedW.Header().Get(middleware.RequestIDHeader))
+	generated := invalidW.Header().Get(middleware.RequestIDHeader)
+	assert.Len(t, generated, 32)
+
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	if assert.Len(t, lines, 2) {
+		var first, second map[string]interface{}
+		assert.NoError(t, json.Unmarshal([]byte(lines[0]), &first))
+		assert.NoError(t, json.Unmarshal([]byte(lines[1]), &second))
+		assert.Equal(t, "edge-7f3a", first["request_id"])
+		assert.Equal(t, "GET", first["method"])
+		assert.Equal(t, "/health", first["path"])
+		assert.Equal(t, float64(http.StatusOK), first["status"])
+		assert.Contains(t, first, "latency_ms")
+		assert.Equal(t, generated, second["request_id"])
+		assert.Equal(t, "/version", second["path"])
+	}
+	assert.NotContains(t, out.String(), "secret")
+}
+
+func TestSetupRouter_SLOTracksRoutePatterns(t *testing.T) {
+	// Arrange
+	gin.SetMode(gin.TestMode)
+	noop := func(c *gin.Context) { c.Next() }
+	tracker, err := slo.NewTracker(slo.Config{
+		Windows:   []time.Duration{5 * time.Minute},
+		Objective: slo.Objective{Latency: time.Second, SuccessRate: 99},
+	})
+	assert.NoError(t, err)
+	router, err := SetupRouter(
+		handler.NewAuthHandler(nil),
+		handler.NewVoucherHandler(nil),
+		handler.NewUserHandler(nil),
+		handler.NewSheetImportHandler(nil, nil),
+		handler.NewImportRuleHandler(nil),
+		handler.NewVoucherTemplateHandler(nil),
+		handler.NewRetentionHandler(nil),
+		handler.NewSegmentHandler(nil),
+		handler.NewPartnerHandler(nil),
+		handler.NewSystemHandler(nil),
+		handler.NewStreamHandler(nil),
+		handler.NewClaimHandler(nil),
+		handler.NewSMSHandler(nil),
+		handler.NewDiscountLimitHandler(nil),
+		handler.NewDeadLetterHandler(nil),
+		handler.NewRedemptionHandler(nil),
+		handler.NewEmailTemplateHandler(nil),
+		handler.NewCustomerHandler(nil),
+		handler.NewReportHandler(nil),
+		handler.NewSLOHandler(tracker),
+		noop,
+		noop,
+		noop,
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		middleware.SLOMiddleware(tracker),
+		nil,
+		nil,
+		"",
+	)
+	if err != nil {
+		t.Fatalf("Failed to set up router: %v", err)
+	}
+	router.GET("/items/:id", func(c *gin.Context) {
+		if c.Param("id") == "panic" {
+			panic("boom")
+		}
+		c.Status(http.StatusOK)
+	})
+
+	// Act
+	for _, path := range []string{"/health", "/items/1", "/items/2", "/items/panic", "/unknown"} {
+		router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", path, nil))
+	}
+
+	// Assert
+	report := tracker.Report()
+	assert.False(t, report.Compliant)
+	if assert.Len(t, report.Windows, 1) && assert.Len(t, report.Windows[0].Endpoints, 2) {
+		health, items := report.Windows[0].Endpoints[0], report.Windows[0].Endpoints[1]
+		assert.Equal(t, "/health", health.Route)
+		assert.Equal(t, int64(1), health.Requests)
+		assert.Equal(t, "/items/:id", items.Route)
+		assert.Equal(t, int64(3), items.Requests)
+		assert.Equal(t, int64(1), items.Failures)
+		assert.False(t, items.SuccessRateMet)
+	}
+}
+
+func TestSetupRouter_HeavyOperationLimit(t *testing.T) {
+	// Arrange
+	gin.SetMode(gin.TestMode)
+	noop := func(c *gin.Context) { c.Next() }
+	// A limit of zero slots rejects every request, standing in for a saturated limit
+	saturated := middleware.ConcurrencyLimitMiddleware(0, 1500*time.Millisecond)
+	router, err := SetupRouter(
+		handler.NewAuthHandler(nil),
+		handler.NewVoucherHandler(nil),
+		handler.NewUserHandler(nil),
+		handler.NewSheetImportHandler(nil, nil),
+		handler.NewImportRuleHandler(nil),
+		handler.NewVoucherTemplateHandler(nil),
+		handler.NewRetentionHandler(nil),
+		handler.NewSegmentHandler(nil),
+		handler.NewPartnerHandler(nil),
+		handler.NewSystemHandler(nil),
+		handler.NewStreamHandler(nil),
+		handler.NewClaimHandler(nil),
+		handler.NewSMSHandler(nil),
+		handler.NewDiscountLimitHandler(nil),
+		handler.NewDeadLetterHandler(nil),
+		handler.NewRedemptionHandler(nil),
+		handler.NewEmailTemplateHandler(nil),
+		handler.NewCustomerHandler(nil),
+		handler.NewReportHandler(nil),
+		handler.NewSLOHandler(nil),
+		noop,
+		noop,
+		noop,
+		nil,
+		nil,
+		saturated,
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		"",
+	)
+	assert.NoError(t, err)
+
+	export := httptest.NewRecorder()
+	upload := httptest.NewRecorder()
+	health := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(export, httptest.NewRequest("GET", "/api/v1/vouchers/export", nil))
+	router.ServeHTTP(upload, httptest.NewRequest("POST", "/api/v1/vouchers/upload-batch", nil))
+	router.ServeHTTP(health, httptest.NewRequest("GET", "/health", nil))
+
+	// Assert
+	assert.Equal(t, http.StatusTooManyRequests, export.Code)
+	assert.Equal(t, "2", export.Header().Get("Retry-After"))
+	assert.Equal(t, http.StatusTooManyRequests, upload.Code)
+	assert.Equal(t, http.StatusOK, health.Code)
+}
+
+func TestSetupRouter_Metrics(t *testing.T) {
+	// Arrange
+	router := setupContractTestRouter(t)
+	w := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/metrics", nil))
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, metrics.ContentType, w.Header().Get("Content-Type"))
+}
+
+// stubCaptchaVerifier accepts the token "solved" and fails on "outage"
+type stubCaptchaVerifier struct{}
+
+func (stubCaptchaVerifier) Verify(token, remoteIP string) (bool, error) {
+	if token == "outage" {
+		return false, errors.New("provider unreachable")
+	}
+	return token == "solved", nil
+}
+
+// abuseGuards are the middleware setupAbuseTestRouter installs; nil ones are left out
+type abuseGuards struct {
+	auth            gin.HandlerFunc
+	claimThrottle   gin.HandlerFunc
+	captcha         gin.HandlerFunc
+	partnerAuth     gin.HandlerFunc
+	partnerThrottle gin.HandlerFunc
+	authThrottle    gin.HandlerFunc
+	userThrottle    gin.HandlerFunc
+	uploadThrottle  gin.HandlerFunc
+}
+
+// setupAbuseTestRouter builds a router with the claim, partner and user
+// guards. Missing auth and partner auth middleware let every request through.
+func setupAbuseTestRouter(t *testing.T, guards abuseGuards) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	noop := func(c *gin.Context) { c.Next() }
+	if guards.auth == nil {
+		guards.auth = noop
+	}
+	if guards.partnerAuth == nil {
+		guards.partnerAuth = noop
+	}
+	router, err := SetupRouter(
+		handler.NewAuthHandler(nil),
+		handler.NewVoucherHandler(nil),
+		handler.NewUserHandler(nil),
+		handler.NewSheetImportHandler(nil, nil),
+		handler.NewImportRuleHandler(nil),
+		handler.NewVoucherTemplateHandler(nil),
+		handler.NewRetentionHandler(nil),
+		handler.NewSegmentHandler(nil),
+		handler.NewPartnerHandler(nil),
+		handler.NewSystemHandler(nil),
+		handler.NewStreamHandler(nil),
+		handler.NewClaimHandler(nil),
+		handler.NewSMSHandler(nil),
+		handler.NewDiscountLimitHandler(nil),
+		handler.NewDeadLetterHandler(nil),
+		handler.NewRedemptionHandler(nil),
+		handler.NewEmailTemplateHandler(nil),
+		handler.NewCustomerHandler(nil),
+		handler.NewReportHandler(nil),
+		handler.NewSLOHandler(nil),
+		guards.auth,
+		guards.partnerAuth,
+		noop,
+		nil,
+		nil,
+		nil,
+		guards.claimThrottle,
+		guards.captcha,
+		guards.partnerThrottle,
+		guards.authThrottle,
+		guards.userThrottle,
+		guards.uploadThrottle,
+		nil,
+		nil,
+		nil,
+		"",
+	)
+	if err != nil {
+		t.Fatalf("Failed to set up router: %v", err)
+	}
+	return router
+}
+
+func TestSetupRouter_ClaimCaptcha(t *testing.T) {
+	// Arrange
+	router := setupAbuseTestRouter(t, abuseGuards{captcha: middleware.CaptchaMiddleware(stubCaptchaVerifier{})})
+	claim := func(token string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest("POST", "/api/v1/claim", strings.NewReader("{}"))
+		if token != "" {
+			req.Header.Set(middleware.CaptchaTokenHeader, token)
+		}
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	// Act
+	missing := claim("")
+	rejected := claim("guessed")
+	outage := claim("outage")
+	solved := claim("solved")
+
+	// Assert
+	assert.Equal(t, http.StatusBadRequest, missing.Code)
+	assert.Contains(t, missing.Body.String(), "Missing CAPTCHA token")
+	assert.Equal(t, http.StatusForbidden, rejected.Code)
+	assert.Equal(t, http.StatusServiceUnavailable, outage.Code)
+	// The CAPTCHA passed, so the handler rejects the empty claim itself
+	assert.Equal(t, http.StatusBadRequest, solved.Code)
+	assert.NotContains(t, solved.Body.String(), "CAPTCHA")
+}
+
+func TestSetupRouter_ClaimThrottlePerClient(t *testing.T) {
+	// Arrange
+	limiter := throttle.NewLimiter(time.Minute)
+	router := setupAbuseTestRouter(t, abuseGuards{claimThrottle: middleware.ThrottleMiddleware(limiter, middleware.ClientIdentity(func() int { return 1 }))})
+	claim := func(remoteAddr string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest("POST", "/api/v1/claim", strings.NewReader("{}"))
+		req.RemoteAddr = remoteAddr
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	// Act
+	first := claim("203.0.113.1:1234")
+	second := claim("203.0.113.1:1234")
+	otherClient := claim("203.0.113.2:1234")
+
+	// Assert
+	assert.Equal(t, http.StatusBadRequest, first.Code)
+	assert.Equal(t, http.StatusTooManyRequests, second.Code)
+	assert.Equal(t, "60", second.Header().Get("Retry-After"))
+	assert.Equal(t, http.StatusBadRequest, otherClient.Code)
+}
+
+func TestSetupRouter_PartnerThrottleUsesPartnerRateLimit(t *testing.T) {
+	// Arrange
+	limiter := throttle.NewLimiter(time.Minute)
+	partners := map[string]*entity.Partner{
+		"key-1": {ID: 1, RateLimit: 1},
+		"key-2": {ID: 2},
+	}
+	// Stands in for PartnerAuthMiddleware, which stores the partner under "partner"
+	partnerAuth := func(c *gin.Context) {
+		c.Set("partner", partners[c.GetHeader(middleware.PartnerAPIKeyHeader)])
+		c.Next()
+	}
+	router := setupAbuseTestRouter(t, abuseGuards{partnerAuth: partnerAuth, partnerThrottle: middleware.ThrottleMiddleware(limiter, middleware.PartnerIdentity(func() int { return 2 }))})
+	list := func(apiKey string) int {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest("GET", "/api/v1/partner/vouchers?sort=unknown:asc", nil)
+		req.Header.Set(middleware.PartnerAPIKeyHeader, apiKey)
+		router.ServeHTTP(w, req)
+		return w.Code
+	}
+
+	// Act
+	limited := []int{list("key-1"), list("key-1")}
+	defaulted := []int{list("key-2"), list("key-2"), list("key-2")}
+
+	// Assert: requests that get through fail on the bad sort field
+	assert.Equal(t, []int{http.StatusBadRequest, http.StatusTooManyRequests}, limited)
+	assert.Equal(t, []int{http.StatusBadRequest, http.StatusBadRequest, http.StatusTooManyRequests}, defaulted)
+}
+
+// stubUserRepository finds users by email; other methods are not used by these tests
+type stubUserRepository struct {
+	repository.UserRepository
+	users map[string]*entity.User
+}
+
+func (r stubUserRepository) FindByEmail(_ context.Context, email string) (*entity.User, error) {
+	if user, ok := r.users[email]; ok {
+		return user, nil
+	}
+	return nil, errors.New("record not found")
+}
+
+func TestSetupRouter_AuthThrottlePerClient(t *testing.T) {
+	// Arrange
+	limiter := throttle.NewTokenBucket(time.Minute)
+	router := setupAbuseTestRouter(t, abuseGuards{authThrottle: middleware.ThrottleMiddleware(limiter, middleware.ClientIdentity(func() int { return 2 }))})
+	login := func(remoteAddr string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest("POST", "/api/v1/auth/login", strings.NewReader("{}"))
+		req.RemoteAddr = remoteAddr
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	// Act
+	allowed := []int{login("203.0.113.1:1234").Code, login("203.0.113.1:1234").Code}
+	limited := login("203.0.113.1:1234")
+	otherClient := login("203.0.113.2:1234")
+
+	// Assert: requests that get through fail on the missing credentials
+	assert.Equal(t, []int{http.StatusBadRequest, http.StatusBadRequest}, allowed)
+	assert.Equal(t, http.StatusTooManyRequests, limited.Code)
+	assert.Equal(t, "30", limited.Header().Get("Retry-After"))
+	assert.JSONEq(t, `{"status":"error","message":"Too many requests, please retry later"}`, limited.Body.String())
+	assert.Equal(t, http.StatusBadRequest, otherClient.Code)
+}
+
+func TestSetupRouter_UserThrottles(t *testing.T) {
+	// Arrange
+	// Stands in for AuthMiddleware, which stores the JWT's email under "email"
+	auth := func(c *gin.Context) {
+		c.Set("email", c.GetHeader("X-Test-User"))
+		c.Next()
+	}
+	router := setupAbuseTestRouter(t, abuseGuards{
+		auth:           auth,
+		userThrottle:   middleware.ThrottleMiddleware(throttle.NewTokenBucket(time.Minute), middleware.UserIdentity(func() int { return 3 })),
+		uploadThrottle: middleware.ThrottleMiddleware(throttle.NewTokenBucket(time.Minute), middleware.UserIdentity(func() int { return 1 })),
+	})
+	send := func(method, path, user string) int {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(method, path, nil)
+		req.Header.Set("X-Test-User", user)
+		router.ServeHTTP(w, req)
+		return w.Code
+	}
+
+	// Act
+	uploads := []int{send("POST", "/api/v1/vouchers/upload-csv", "ana@example.com"), send("POST", "/api/v1/vouchers/upload-batch", "Ana@example.com")}
+	afterUploads := send("GET", "/api/v1/vouchers?sort=unknown:asc", "ana@example.com")
+	overall := send("GET", "/api/v1/vouchers?sort=unknown:asc", "ana@example.com")
+	otherUser := send("GET", "/api/v1/vouchers?sort=unknown:asc", "ben@example.com")
+
+	// Assert: one upload per minute, and three requests per minute in total
+	assert.NotEqual(t, http.StatusTooManyRequests, uploads[0])
+	assert.Equal(t, http.StatusTooManyRequests, uploads[1])
+	assert.Equal(t, http.StatusBadRequest, afterUploads)
+	assert.Equal(t, http.StatusTooManyRequests, overall)
+	assert.Equal(t, http.StatusBadRequest, otherUser)
+}
+
+func TestSetupRouter_ViewersAreReadOnly(t *testing.T) {
+	// Arrange
+	gin.SetMode(gin.TestMode)
+	noop := func(c *gin.Context) { c.Next() }
+	jwtService := jwt.NewJWTService("secret", time.Hour)
+	lockedAt := time.Now()
+	users := stubUserRepository{users: map[string]*entity.User{
+		"admin@example.com":     {ID: 1, Email: "admin@example.com", Role: entity.UserRoleAdmin},
+		"viewer@example.com":    {ID: 2, Email: "viewer@example.com", Role: entity.UserRoleViewer},
+		"marketing@example.com": {ID: 3, Email: "marketing@example.com", Role: entity.UserRoleMarketing},
+		"locked@example.com":    {ID: 4, Email: "locked@example.com", Role: entity.UserRoleAdmin, LockedAt: &lockedAt},
+	}}
+	router, err := SetupRouter(
+		handler.NewAuthHandler(nil),
+		handler.NewVoucherHandler(nil),
+		handler.NewUserHandler(nil),
+		handler.NewSheetImportHandler(nil, nil),
+		handler.NewImportRuleHandler(nil),
+		handler.NewVoucherTemplateHandler(nil),
+		handler.NewRetentionHandler(nil),
+		handler.NewSegmentHandler(nil),
+		handler.NewPartnerHandler(nil),
+		handler.NewSystemHandler(nil),
+		handler.NewStreamHandler(nil),
+		handler.NewClaimHandler(nil),
+		handler.NewSMSHandler(nil),
+		handler.NewDiscountLimitHandler(nil),
+		handler.NewDeadLetterHandler(nil),
+		handler.NewRedemptionHandler(nil),
+		handler.NewEmailTemplateHandler(nil),
+		handler.NewCustomerHandler(nil),
+		handler.NewReportHandler(nil),
+		handler.NewSLOHandler(nil),
+		middleware.AuthMiddleware(jwtService, users),
+		noop,
+		noop,
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		"",
+	)
+	assert.NoError(t, err)
+	call := func(email, method, path string) *httptest.ResponseRecorder {
+		token, _ := jwtService.GenerateToken(email, users.users[email].Role)
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(method, path, strings.NewReader("{}"))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer "+token)
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	// Act
+	viewerCreate := call("viewer@example.com", "POST", "/api/v1/vouchers")
+	viewerDelete := call("viewer@example.com", "DELETE", "/api/v1/vouchers/1")
+	viewerSegment := call("viewer@example.com", "POST", "/api/v1/segments")
+	viewerLookup := call("viewer@example.com", "POST", "/api/v1/vouchers/lookup")
+	adminCreate := call("admin@example.com", "POST", "/api/v1/vouchers")
+	marketingCreate := call("marketing@example.com", "POST", "/api/v1/vouchers")
+	var marketingAdminOnly []int
+	for _, route := range [][2]string{
+		{"POST", "/api/v1/vouchers/1/approve"},
+		{"POST", "/api/v1/users/invite"},
+		{"POST", "/api/v1/partners"},
+		{"PUT", "/api/v1/partners/1"},
+		{"POST", "/api/v1/partners/1/rotate-key"},
+		{"POST", "/api/v1/import-rules"},
+		{"PUT", "/api/v1/voucher-templates/1"},
+		{"DELETE", "/api/v1/segments/1"},
+		{"PUT", "/api/v1/retention-policy"},
+	} {
+		marketingAdminOnly = append(marketingAdminOnly, call("marketing@example.com", route[0], route[1]).Code)
+	}
+	adminSegment := call("admin@example.com", "POST", "/api/v1/segments")
+	lockedCreate := call("locked@example.com", "POST", "/api/v1/vouchers")
+	lockedSegment := call("locked@example.com", "POST", "/api/v1/segments")
+	lockedList := call("locked@example.com", "GET", "/api/v1/vouchers")
+
+	// Assert: requests that get through fail on the empty body
+	assert.Equal(t, http.StatusForbidden, viewerCreate.Code)
+	assert.Contains(t, viewerCreate.Body.String(), "read-only")
+	assert.Equal(t, http.StatusForbidden, viewerDelete.Code)
+	assert.Equal(t, http.StatusForbidden, viewerSegment.Code)
+	assert.Equal(t, http.StatusBadRequest, viewerLookup.Code)
+	assert.Equal(t, http.StatusBadRequest, adminCreate.Code)
+	assert.Equal(t, http.StatusBadRequest, marketingCreate.Code)
+	for _, code := range marketingAdminOnly {
+		assert.Equal(t, http.StatusForbidden, code)
+	}
+	assert.Equal(t, http.StatusBadRequest, adminSegment.Code)
+	assert.Equal(t, http.StatusUnauthorized, lockedCreate.Code)
+	assert.Equal(t, http.StatusUnauthorized, lockedSegment.Code)
+	assert.Equal(t, http.StatusUnauthorized, lockedList.Code)
+	assert.Contains(t, lockedList.Body.String(), "Account is locked")
+}
@@ -0,0 +1,26 @@
+package entity
+
+import "time"
+
+// Audited actions
+const (
+	AuditActionVoucherBulkEdit        = "vouchers.bulk_edit"
+	AuditActionVoucherApprovalRequest = "vouchers.approval_requested"
+	AuditActionVoucherApprove         = "vouchers.approve"
+	AuditActionCustomerErase          = "customers.erase"
+)
+
+// AuditEntry records a change made on behalf of a user. Details holds a JSON
+// document describing the change, shaped per action.
+type AuditEntry struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	Action    string    `gorm:"size:50;not null;index" json:"action"`
+	Actor     string    `gorm:"size:255" json:"actor"`
+	Details   string    `gorm:"type:text" json:"details"`
+	CreatedAt time.Time `gorm:"index" json:"created_at"`
+}
+
+// TableName specifies the table name for AuditEntry entity
+func (AuditEntry) TableName() string {
+	return "audit_entries"
+}
@@ -0,0 +1,23 @@
+package entity
+
+import "time"
+
+// ClaimLink is a one-time link that assigns a voucher to whichever customer
+// claims it first. The link carries a signed token whose jti is TokenID; the
+// token itself is never stored.
+type ClaimLink struct {
+	ID        uint       `gorm:"primaryKey" json:"id"`
+	VoucherID uint       `gorm:"not null;index" json:"voucher_id"`
+	TokenID   string     `gorm:"size:32;not null;uniqueIndex" json:"-"`
+	ExpiresAt time.Time  `gorm:"not null" json:"expires_at"`
+	ClaimedAt *time.Time `json:"claimed_at,omitempty"`
+	// CustomerID is the customer who claimed the link
+	CustomerID *uint     `gorm:"index" json:"customer_id,omitempty"`
+	CreatedBy  string    `gorm:"size:255" json:"created_by,omitempty"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// TableName specifies the table name for ClaimLink entity
+func (ClaimLink) TableName() string {
+	return "claim_links"
+}
@@ -0,0 +1,83 @@
+package entity
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// defaultCurrencyScale is the number of decimal places of most currencies'
+// minor unit, e.g. cents
+const defaultCurrencyScale = 2
+
+// currencyScales lists the ISO 4217 currencies whose minor unit is not a
+// hundredth of the major unit
+var currencyScales = map[string]int{
+	"BIF": 0, "CLP": 0, "DJF": 0, "GNF": 0, "ISK": 0, "JPY": 0, "KMF": 0, "KRW": 0,
+	"PYG": 0, "RWF": 0, "UGX": 0, "VND": 0, "VUV": 0, "XAF": 0, "XOF": 0, "XPF": 0,
+	"BHD": 3, "IQD": 3, "JOD": 3, "KWD": 3, "LYD": 3, "OMR": 3, "TND": 3,
+}
+
+// CurrencyScale returns the number of decimal places amounts in currency
+// have, i.e. how many digits its minor unit takes
+func CurrencyScale(currency string) int {
+	if scale, ok := currencyScales[currency]; ok {
+		return scale
+	}
+	return defaultCurrencyScale
+}
+
+// currencyUnit returns the number of minor units in one unit of currency
+func currencyUnit(currency string) int64 {
+	unit := int64(1)
+	for range CurrencyScale(currency) {
+		unit *= 10
+	}
+	return unit
+}
+
+// ToMinorUnits converts an amount in currency to whole minor units. It fails
+// when the amount has more decimal places than the currency's scale, so
+// amounts are never silently rounded. The amount is read as the shortest
+// decimal that represents it, so e.g. 0.1 is 10 cents rather than a float
+// just off it.
+func ToMinorUnits(amount float64, currency string) (int64, error) {
+	digits := strconv.FormatFloat(amount, 'f', -1, 64)
+	negative := strings.HasPrefix(digits, "-")
+	whole, fraction, _ := strings.Cut(strings.TrimPrefix(digits, "-"), ".")
+
+	scale := CurrencyScale(currency)
+	if len(fraction) > scale {
+		return 0, fmt.Errorf("amount %s has more than %d decimal places for %s", digits, scale, currency)
+	}
+	minor, err := strconv.ParseInt(whole+fraction+strings.Repeat("0", scale-len(fraction)), 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("amount %s is too large", digits)
+	}
+	if negative {
+		minor = -minor
+	}
+	return minor, nil
+}
+
+// FromMinorUnits converts an amount in minor units of currency back to an
+// amount in the major unit, e.g. 1050 cents to 10.5
+func FromMinorUnits(minor int64, currency string) float64 {
+	return float64(minor) / float64(currencyUnit(currency))
+}
+
+// FormatMinorUnits renders an amount in minor units of currency as a decimal
+// with the currency's number of decimal places, e.g. "10.50" or "5000" for JPY
+func FormatMinorUnits(minor int64, currency string) string {
+	sign := ""
+	if minor < 0 {
+		sign, minor = "-", -minor
+	}
+	unit := currencyUnit(currency)
+	whole := strconv.FormatInt(minor/unit, 10)
+	if unit == 1 {
+		return sign + whole
+	}
+	fraction := strconv.FormatInt(minor%unit, 10)
+	return sign + whole + "." + strings.Repeat("0", CurrencyScale(currency)-len(fraction)) + fraction
+}
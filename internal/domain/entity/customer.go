@@ -0,0 +1,34 @@
+package entity
+
+import (
+	"fmt"
+	"time"
+)
+
+// Customer is a person vouchers are assigned to, claimed by and redeemed by.
+// ExternalID is the identifier the calling system knows the customer by;
+// requests keep sending it, and it is matched to a customer, or creates one,
+// on first use. A duplicate merged into another customer keeps its row with
+// MergedIntoID set, so its external ID still resolves to the survivor. An
+// erased customer keeps its row, so what was recorded against it still
+// counts, but its external ID is replaced and its contact details cleared.
+type Customer struct {
+	ID           uint       `gorm:"primaryKey" json:"id"`
+	ExternalID   string     `gorm:"size:255;not null;uniqueIndex" json:"external_id"`
+	Email        string     `gorm:"size:255;index" json:"email,omitempty"`
+	Phone        string     `gorm:"size:20" json:"phone,omitempty"`
+	MergedIntoID *uint      `gorm:"index" json:"merged_into_id,omitempty"`
+	ErasedAt     *time.Time `json:"erased_at,omitempty"`
+	CreatedAt    time.Time  `json:"created_at"`
+	UpdatedAt    time.Time  `json:"updated_at"`
+}
+
+// ErasedExternalID is the external ID an erased customer is left with
+func ErasedExternalID(id uint) string {
+	return fmt.Sprintf("erased:%d", id)
+}
+
+// TableName specifies the table name for Customer entity
+func (Customer) TableName() string {
+	return "customers"
+}
@@ -0,0 +1,22 @@
+package entity
+
+import "time"
+
+// DiscountLimit caps the discount users with Role can give a voucher they
+// create, edit or import. Percent discounts are capped at MaxDiscountPercent
+// and fixed amounts at MaxDiscountAmounts, in minor units per ISO 4217
+// currency; fixed amounts in a currency without a cap cannot be given at
+// all. Admins are never limited, so they can approve a larger discount by
+// making the change themselves.
+type DiscountLimit struct {
+	Role               string           `gorm:"primaryKey;size:20" json:"role"`
+	MaxDiscountPercent float64          `gorm:"type:decimal(5,2);not null;check:chk_discount_limits_max_discount_percent,max_discount_percent >= 1 AND max_discount_percent <= 100" json:"max_discount_percent"`
+	MaxDiscountAmounts map[string]int64 `gorm:"type:text;serializer:json" json:"-"`
+	UpdatedBy          string           `gorm:"size:255" json:"updated_by,omitempty"`
+	UpdatedAt          time.Time        `json:"updated_at"`
+}
+
+// TableName specifies the table name for DiscountLimit entity
+func (DiscountLimit) TableName() string {
+	return "discount_limits"
+}
@@ -0,0 +1,40 @@
+package entity
+
+import "time"
+
+// Keys of the emails whose wording admins can edit
+const (
+	EmailTemplateInvite        = "invite"
+	EmailTemplateVerifyEmail   = "verify_email"
+	EmailTemplatePasswordReset = "password_reset"
+	EmailTemplateImportReport  = "import_report"
+	EmailTemplateExpiryDigest  = "expiry_digest"
+)
+
+// EmailTemplateKeys lists every editable email
+var EmailTemplateKeys = []string{
+	EmailTemplateInvite,
+	EmailTemplateVerifyEmail,
+	EmailTemplatePasswordReset,
+	EmailTemplateImportReport,
+	EmailTemplateExpiryDigest,
+}
+
+// EmailTemplate is one saved version of an email's subject and body. Saving
+// adds a new version rather than changing the old one, so earlier wording
+// stays on record; the highest version of a key is the one sent.
+type EmailTemplate struct {
+	ID      uint   `gorm:"primaryKey" json:"id"`
+	Key     string `gorm:"size:50;not null;uniqueIndex:idx_email_templates_key_version" json:"key"`
+	Version int    `gorm:"not null;uniqueIndex:idx_email_templates_key_version" json:"version"`
+	// Subject and Body are Go text/template sources, e.g. "Hi {{.email}}"
+	Subject   string    `gorm:"size:255;not null" json:"subject"`
+	Body      string    `gorm:"type:text;not null" json:"body"`
+	CreatedBy string    `gorm:"size:255" json:"created_by,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// TableName specifies the table name for EmailTemplate entity
+func (EmailTemplate) TableName() string {
+	return "email_templates"
+}
@@ -0,0 +1,32 @@
+package entity
+
+import "time"
+
+// Import rule types
+const (
+	ImportRuleCodePrefix      = "code_prefix"
+	ImportRuleCodePattern     = "code_pattern"
+	ImportRuleMinDiscount     = "min_discount"
+	ImportRuleMaxDiscount     = "max_discount"
+	ImportRuleMaxValidityDays = "max_validity_days"
+)
+
+// ImportRule is an admin-defined check every imported voucher must pass.
+// Value holds the rule parameter as text: a prefix, a regular expression,
+// a discount percent or a number of days, depending on Type.
+type ImportRule struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	Name      string    `gorm:"size:100;not null" json:"name"`
+	Type      string    `gorm:"size:30;not null" json:"type"`
+	Value     string    `gorm:"size:255;not null" json:"value"`
+	Message   string    `gorm:"size:255" json:"message,omitempty"`
+	Enabled   bool      `gorm:"not null;default:true" json:"enabled"`
+	CreatedBy string    `gorm:"size:255" json:"created_by,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// TableName specifies the table name for ImportRule entity
+func (ImportRule) TableName() string {
+	return "import_rules"
+}
@@ -0,0 +1,27 @@
+package entity
+
+import "time"
+
+// Partner is a reseller that issues vouchers under its own brand through an
+// API key. It may issue at most Quota vouchers; IssuedCount is reserved before
+// each voucher is created so the quota holds under concurrent requests.
+// RateLimit caps the partner's API requests per throttle window; 0 uses the
+// default limit.
+type Partner struct {
+	ID          uint      `gorm:"primaryKey" json:"id"`
+	Name        string    `gorm:"size:100;not null;uniqueIndex" json:"name"`
+	APIKeyHash  string    `gorm:"size:64;not null;uniqueIndex" json:"-"`
+	KeyPrefix   string    `gorm:"size:16;not null" json:"key_prefix"`
+	Quota       int64     `gorm:"not null;check:quota >= 0" json:"quota"`
+	IssuedCount int64     `gorm:"not null;default:0" json:"issued_count"`
+	RateLimit   int       `gorm:"not null;default:0;check:rate_limit >= 0" json:"rate_limit"`
+	Active      bool      `gorm:"not null;default:true" json:"active"`
+	CreatedBy   string    `gorm:"size:255" json:"created_by,omitempty"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// TableName specifies the table name for Partner entity
+func (Partner) TableName() string {
+	return "partners"
+}
@@ -0,0 +1,26 @@
+package entity
+
+import "time"
+
+// Redemption records that a customer used a voucher on a purchase. The
+// discount fields are the discount that was applied, kept as it was at the
+// time so later edits to the voucher do not rewrite history. DiscountAmount is
+// in whole minor units of Currency.
+type Redemption struct {
+	ID              uint      `gorm:"primaryKey" json:"id"`
+	VoucherID       uint      `gorm:"not null;index:idx_redemptions_voucher_customer" json:"voucher_id"`
+	CustomerID      uint      `gorm:"not null;index:idx_redemptions_voucher_customer" json:"customer_id"`
+	Customer        *Customer `json:"customer,omitempty"`
+	OrderID         string    `gorm:"size:100" json:"order_id,omitempty"`
+	DiscountType    string    `gorm:"size:20;not null;default:percent" json:"discount_type"`
+	DiscountPercent float64   `gorm:"type:decimal(5,2);not null" json:"discount_percent"`
+	DiscountAmount  int64     `gorm:"not null;default:0" json:"discount_amount_minor,omitempty"`
+	Currency        string    `gorm:"size:3;not null;default:''" json:"currency,omitempty"`
+	RedeemedBy      string    `gorm:"size:255" json:"redeemed_by,omitempty"`
+	RedeemedAt      time.Time `gorm:"not null" json:"redeemed_at"`
+}
+
+// TableName specifies the table name for Redemption entity
+func (Redemption) TableName() string {
+	return "redemptions"
+}
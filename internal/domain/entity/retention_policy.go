@@ -0,0 +1,32 @@
+package entity
+
+import "time"
+
+// Retention actions applied to vouchers past their retention period
+const (
+	RetentionActionArchive = "archive"
+	RetentionActionPurge   = "purge"
+)
+
+// Retention policy defaults used until an admin saves a policy
+const (
+	DefaultRetentionKeepDays = 90
+	MaxRetentionKeepDays     = 3650
+)
+
+// RetentionPolicy controls how long expired vouchers are kept. Once a voucher
+// has been expired for KeepDays, the cleanup job archives it (soft delete)
+// or purges it permanently. There is a single policy row.
+type RetentionPolicy struct {
+	ID        uint      `gorm:"primaryKey" json:"-"`
+	KeepDays  int       `gorm:"not null" json:"keep_days"`
+	Action    string    `gorm:"size:20;not null" json:"action"`
+	Enabled   bool      `gorm:"not null;default:false" json:"enabled"`
+	UpdatedBy string    `gorm:"size:255" json:"updated_by,omitempty"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// TableName specifies the table name for RetentionPolicy entity
+func (RetentionPolicy) TableName() string {
+	return "retention_policies"
+}
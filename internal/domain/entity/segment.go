@@ -0,0 +1,38 @@
+package entity
+
+import "time"
+
+// Segment types
+const (
+	SegmentTypeStatic   = "static"
+	SegmentTypeExternal = "external"
+)
+
+// Segment is a group of customers a voucher can be restricted to. A static
+// segment lists its customer IDs in segment_members; an external segment
+// names a segment kept by another system, asked through a segment provider.
+type Segment struct {
+	ID          uint      `gorm:"primaryKey" json:"id"`
+	Name        string    `gorm:"size:100;not null;uniqueIndex" json:"name"`
+	Type        string    `gorm:"size:20;not null" json:"type"`
+	ExternalRef string    `gorm:"size:255" json:"external_ref,omitempty"`
+	CreatedBy   string    `gorm:"size:255" json:"created_by,omitempty"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// TableName specifies the table name for Segment entity
+func (Segment) TableName() string {
+	return "segments"
+}
+
+// SegmentMember is a customer listed in a static segment
+type SegmentMember struct {
+	SegmentID  uint   `gorm:"primaryKey;autoIncrement:false"`
+	CustomerID string `gorm:"primaryKey;size:255"`
+}
+
+// TableName specifies the table name for SegmentMember entity
+func (SegmentMember) TableName() string {
+	return "segment_members"
+}
@@ -0,0 +1,49 @@
+package entity
+
+import "time"
+
+// SMS delivery statuses
+const (
+	SMSDeliveryStatusPending = "pending"
+	SMSDeliveryStatusSent    = "sent"
+	SMSDeliveryStatusFailed  = "failed"
+	// SMSDeliveryStatusDiscarded marks a failed delivery an admin gave up on
+	SMSDeliveryStatusDiscarded = "discarded"
+)
+
+// SMSDeliveryStatuses lists every valid SMS delivery status
+var SMSDeliveryStatuses = []string{SMSDeliveryStatusPending, SMSDeliveryStatusSent, SMSDeliveryStatusFailed, SMSDeliveryStatusDiscarded}
+
+// What an SMS delivery carries to the customer
+const (
+	SMSContentCode      = "code"
+	SMSContentClaimLink = "claim_link"
+)
+
+// SMSDelivery is a text message carrying a voucher code or claim link to a
+// customer's phone. Pending deliveries are sent by the dispatch job and retried
+// until they are sent or run out of attempts; failed deliveries can be requeued
+// or discarded from the dead-letter API. The message body may contain a
+// claim link, so it is never serialized.
+type SMSDelivery struct {
+	ID                uint       `gorm:"primaryKey" json:"id"`
+	VoucherID         uint       `gorm:"not null;index" json:"voucher_id"`
+	PhoneNumber       string     `gorm:"size:20;not null" json:"phone_number"`
+	CustomerID        string     `gorm:"size:255" json:"customer_id,omitempty"`
+	Content           string     `gorm:"size:20;not null" json:"content"`
+	Body              string     `gorm:"type:text;not null" json:"-"`
+	Status            string     `gorm:"size:20;not null;default:pending;check:chk_sms_deliveries_status,status IN ('pending','sent','failed','discarded')" json:"status"`
+	Attempts          int        `gorm:"not null;default:0" json:"attempts"`
+	LastError         string     `gorm:"type:text" json:"last_error,omitempty"`
+	ProviderMessageID string     `gorm:"size:100" json:"provider_message_id,omitempty"`
+	NextAttemptAt     time.Time  `gorm:"not null;index" json:"next_attempt_at"`
+	SentAt            *time.Time `json:"sent_at,omitempty"`
+	CreatedBy         string     `gorm:"size:255" json:"created_by,omitempty"`
+	CreatedAt         time.Time  `json:"created_at"`
+	UpdatedAt         time.Time  `json:"updated_at"`
+}
+
+// TableName specifies the table name for SMSDelivery entity
+func (SMSDelivery) TableName() string {
+	return "sms_deliveries"
+}
@@ -2,13 +2,27 @@ package entity
 
 import "time"
 
-// User represents a user in the system
+// User roles. Marketing users create and edit vouchers within the discount
+// limits admins set for their role.
+const (
+	UserRoleAdmin     = "admin"
+	UserRoleMarketing = "marketing"
+	UserRoleViewer    = "viewer"
+)
+
+// UserRoles lists every valid user role
+var UserRoles = []string{UserRoleAdmin, UserRoleMarketing, UserRoleViewer}
+
+// User represents a user in the system. A user with LockedAt set cannot log in.
 type User struct {
-	ID        uint      `gorm:"primaryKey" json:"id"`
-	Email     string    `gorm:"uniqueIndex;not null" json:"email"`
-	Password  string    `gorm:"not null" json:"-"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
+	ID              uint       `gorm:"primaryKey" json:"id"`
+	Email           string     `gorm:"uniqueIndex;not null" json:"email"`
+	Password        string     `gorm:"not null" json:"-"`
+	Role            string     `gorm:"not null;size:20;default:admin" json:"role"`
+	EmailVerifiedAt *time.Time `json:"email_verified_at"`
+	LockedAt        *time.Time `json:"locked_at"`
+	CreatedAt       time.Time  `json:"created_at"`
+	UpdatedAt       time.Time  `json:"updated_at"`
 }
 
 // TableName specifies the table name for User entity
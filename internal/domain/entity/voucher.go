@@ -1,20 +1,130 @@
 package entity
 
 import (
+	"math"
 	"time"
 
 	"gorm.io/gorm"
 )
 
-// Voucher represents a voucher in the system
+// Voucher statuses
+const (
+	VoucherStatusActive          = "active"
+	VoucherStatusPendingApproval = "pending_approval"
+	VoucherStatusInactive        = "inactive"
+)
+
+// VoucherStatuses lists every valid voucher status
+var VoucherStatuses = []string{VoucherStatusActive, VoucherStatusPendingApproval, VoucherStatusInactive}
+
+// Discount types: a percentage off, or a fixed amount off in a currency
+const (
+	DiscountTypePercent     = "percent"
+	DiscountTypeFixedAmount = "fixed_amount"
+)
+
+// DiscountTypes lists every valid discount type
+var DiscountTypes = []string{DiscountTypePercent, DiscountTypeFixedAmount}
+
+// MaxDiscountAmount is the largest fixed discount in the major unit of its
+// currency; in minor units it fits the BIGINT discount_amount column for
+// every currency scale
+const MaxDiscountAmount = 1_000_000_000_000
+
+// Discount percent limits, enforced in Go and by a database CHECK constraint
+const (
+	MinDiscountPercent = 1
+	MaxDiscountPercent = 100
+)
+
+// DiscountPercentScale is the number of decimal places a discount percent is
+// stored with, matching the DECIMAL(5,2) discount_percent column
+const DiscountPercentScale = 2
+
+// discountPercentUnit is the number of stored units in one percent
+const discountPercentUnit = 100
+
+// DiscountHundredths converts a discount percent to whole hundredths of a
+// percent, so discounts are compared exactly rather than as floats
+func DiscountHundredths(percent float64) int64 {
+	return int64(math.Round(percent * discountPercentUnit))
+}
+
+// HasDiscountPercentScale reports whether percent has no more than
+// DiscountPercentScale decimal places, i.e. is stored without rounding
+func HasDiscountPercentScale(percent float64) bool {
+	scaled := percent * discountPercentUnit
+	return math.Abs(scaled-math.Round(scaled)) < 1e-6
+}
+
+// Length limits of client-provided voucher fields
+const (
+	MaxExternalIDLength  = 100
+	MaxDisplayNameLength = 100
+	MaxDescriptionLength = 1000
+	MaxTermsURLLength    = 500
+	MaxMetadataKeys      = 50
+)
+
+// MaxValidityDays caps how long after assignment a voucher with relative expiry stays valid
+const MaxValidityDays = 3650
+
+// DefaultMaxRedemptionsPerUser is how often each customer can redeem a voucher
+// created without a per-customer limit
+const DefaultMaxRedemptionsPerUser = 1
+
+// RemainingRedemptions returns how many more times the voucher can be
+// redeemed, or nil when its redemptions are not capped
+func (v *Voucher) RemainingRedemptions() *int {
+	if v.MaxRedemptions == nil {
+		return nil
+	}
+	remaining := max(*v.MaxRedemptions-v.RedemptionCount, 0)
+	return &remaining
+}
+
+// IsFixedAmount reports whether the voucher takes a fixed amount off rather than a percentage
+func (v *Voucher) IsFixedAmount() bool {
+	return v.DiscountType == DiscountTypeFixedAmount
+}
+
+// Voucher represents a voucher in the system. Percent vouchers take
+// DiscountPercent off; fixed-amount vouchers take DiscountAmount off in
+// Currency, an ISO 4217 code, and keep DiscountPercent at 0. DiscountAmount is
+// kept in whole minor units of Currency (see CurrencyScale). MaxRedemptions
+// caps how often it can be redeemed in total (nil for no cap) and
+// MaxRedemptionsPerUser how often by each customer; RedemptionCount is
+// incremented with every redemption, so the total cap is checked without
+// counting rows. UpdatedBy is whoever created, imported or last edited it,
+// who may not approve it.
 type Voucher struct {
-	ID              uint           `gorm:"primaryKey" json:"id"`
-	VoucherCode     string         `gorm:"uniqueIndex;not null;size:50" json:"voucher_code"`
-	DiscountPercent float64        `gorm:"not null;check:discount_percent >= 1 AND discount_percent <= 100" json:"discount_percent"`
-	ExpiryDate      time.Time      `gorm:"not null;type:date" json:"expiry_date"`
-	CreatedAt       time.Time      `json:"created_at"`
-	UpdatedAt       time.Time      `json:"updated_at"`
-	DeletedAt       gorm.DeletedAt `gorm:"index" json:"deleted_at,omitempty"`
+	ID                    uint           `gorm:"primaryKey" json:"id"`
+	VoucherCode           string         `gorm:"uniqueIndex;index:idx_vouchers_voucher_code_lower,unique,expression:LOWER(voucher_code);not null;size:50" json:"voucher_code"`
+	ExternalID            *string        `gorm:"uniqueIndex;size:100" json:"external_id,omitempty"`
+	DiscountType          string         `gorm:"not null;size:20;default:percent;check:chk_vouchers_discount_type,discount_type IN ('percent','fixed_amount')" json:"discount_type"`
+	DiscountPercent       float64        `gorm:"type:decimal(5,2);not null;check:chk_vouchers_discount_percent,discount_type <> 'percent' OR (discount_percent >= 1 AND discount_percent <= 100)" json:"discount_percent"`
+	DiscountAmount        int64          `gorm:"not null;default:0;check:chk_vouchers_discount_amount,discount_type <> 'fixed_amount' OR (discount_amount > 0 AND currency <> '')" json:"discount_amount_minor,omitempty"`
+	Currency              string         `gorm:"size:3;not null;default:''" json:"currency,omitempty"`
+	ExpiryDate            time.Time      `gorm:"not null;type:date" json:"expiry_date"`
+	ValidityDays          *int           `gorm:"check:chk_vouchers_validity_days,validity_days IS NULL OR validity_days > 0" json:"validity_days,omitempty"`
+	MaxRedemptions        *int           `gorm:"check:chk_vouchers_max_redemptions,max_redemptions IS NULL OR max_redemptions > 0" json:"max_redemptions,omitempty"`
+	MaxRedemptionsPerUser int            `gorm:"not null;default:1;check:chk_vouchers_max_redemptions_per_user,max_redemptions_per_user > 0" json:"max_redemptions_per_user"`
+	RedemptionCount       int            `gorm:"not null;default:0" json:"redemption_count"`
+	Status                string         `gorm:"not null;size:20;default:active;index;check:chk_vouchers_status,status IN ('active','pending_approval','inactive')" json:"status"`
+	Rules                 string         `gorm:"type:text" json:"rules,omitempty"`
+	SegmentID             *uint          `gorm:"index" json:"segment_id,omitempty"`
+	PartnerID             *uint          `gorm:"index" json:"partner_id,omitempty"`
+	DisplayName           string         `gorm:"size:100" json:"display_name"`
+	Description           string         `gorm:"type:text" json:"description"`
+	TermsURL              string         `gorm:"size:500" json:"terms_url"`
+	Metadata              string         `gorm:"type:text" json:"metadata,omitempty"`
+	CreatedBy             string         `gorm:"size:255" json:"created_by"`
+	UpdatedBy             string         `gorm:"size:255;not null;default:''" json:"updated_by"`
+	ApprovedBy            string         `gorm:"size:255" json:"approved_by"`
+	ApprovedAt            *time.Time     `json:"approved_at"`
+	CreatedAt             time.Time      `json:"created_at"`
+	UpdatedAt             time.Time      `json:"updated_at"`
+	DeletedAt             gorm.DeletedAt `gorm:"index" json:"deleted_at,omitempty"`
 }
 
 // TableName specifies the table name for Voucher entity
@@ -0,0 +1,22 @@
+package entity
+
+import "time"
+
+// VoucherAssignment records that a voucher was given to a customer. ExpiresAt
+// is materialized when the voucher is assigned: the voucher's validity days
+// after assignment, capped at its expiry date, or the expiry date itself for
+// vouchers without relative expiry. Later edits to the voucher leave it alone.
+type VoucherAssignment struct {
+	ID         uint      `gorm:"primaryKey" json:"id"`
+	VoucherID  uint      `gorm:"not null;uniqueIndex:idx_voucher_assignments_voucher_customer" json:"voucher_id"`
+	CustomerID uint      `gorm:"not null;uniqueIndex:idx_voucher_assignments_voucher_customer" json:"customer_id"`
+	Customer   *Customer `json:"customer,omitempty"`
+	AssignedAt time.Time `gorm:"not null" json:"assigned_at"`
+	ExpiresAt  time.Time `gorm:"not null;type:date;index" json:"expires_at"`
+	AssignedBy string    `gorm:"size:255" json:"assigned_by,omitempty"`
+}
+
+// TableName specifies the table name for VoucherAssignment entity
+func (VoucherAssignment) TableName() string {
+	return "voucher_assignments"
+}
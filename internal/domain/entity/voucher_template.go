@@ -0,0 +1,23 @@
+package entity
+
+import "time"
+
+// VoucherTemplate is a named preset of voucher attributes that creates can
+// start from. Fields left empty are taken from the create request instead.
+type VoucherTemplate struct {
+	ID              uint     `gorm:"primaryKey" json:"id"`
+	Name            string   `gorm:"uniqueIndex;size:100;not null" json:"name"`
+	DiscountPercent *float64 `gorm:"type:decimal(5,2);check:discount_percent IS NULL OR (discount_percent >= 1 AND discount_percent <= 100)" json:"discount_percent,omitempty"`
+	// ExpiryOffset is how long after creation vouchers expire, e.g. "+30d", "+2w" or "+3m"
+	ExpiryOffset string    `gorm:"size:10" json:"expiry_offset,omitempty"`
+	CodePrefix   string    `gorm:"size:20" json:"code_prefix,omitempty"`
+	Channels     []string  `gorm:"type:text;serializer:json" json:"channels"`
+	CreatedBy    string    `gorm:"size:255" json:"created_by,omitempty"`
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+// TableName specifies the table name for VoucherTemplate entity
+func (VoucherTemplate) TableName() string {
+	return "voucher_templates"
+}
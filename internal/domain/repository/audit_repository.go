@@ -0,0 +1,13 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/shoelfikar/voucher-management-system/internal/domain/entity"
+)
+
+// AuditRepository defines the interface for audit trail data operations
+type AuditRepository interface {
+	// Create records an audit entry
+	Create(ctx context.Context, entry *entity.AuditEntry) error
+}
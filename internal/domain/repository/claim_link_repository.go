@@ -0,0 +1,24 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/shoelfikar/voucher-management-system/internal/domain/entity"
+)
+
+// ClaimLinkRepository defines the interface for voucher claim link data operations
+type ClaimLinkRepository interface {
+	// CreateBatch stores several claim links in one insert
+	CreateBatch(ctx context.Context, links []*entity.ClaimLink) error
+
+	// FindByTokenID retrieves the claim link a token was issued for; when there is none it returns nil, nil
+	FindByTokenID(ctx context.Context, tokenID string) (*entity.ClaimLink, error)
+
+	// MarkClaimed records that customerID claimed the link and returns the number of rows affected.
+	// A link that was already claimed is left alone, so only one concurrent claim succeeds.
+	MarkClaimed(ctx context.Context, id, customerID uint, claimedAt time.Time) (int64, error)
+
+	// Release makes a claimed link claimable again, after the claim could not be completed
+	Release(ctx context.Context, id uint) error
+}
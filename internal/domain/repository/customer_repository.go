@@ -0,0 +1,61 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/shoelfikar/voucher-management-system/internal/domain/entity"
+	"github.com/shoelfikar/voucher-management-system/pkg/utils"
+)
+
+// CustomerErasure counts what erasing a customer anonymized
+type CustomerErasure struct {
+	// Customers is the erased customer plus the duplicates merged into it
+	Customers      int64
+	Redemptions    int64
+	SMSDeliveries  int64
+	SegmentMembers int64
+}
+
+// CustomerRepository defines the interface for customer data operations
+type CustomerRepository interface {
+	// FindAll retrieves a page of customers that were not merged away, whose
+	// external ID, email or phone contains search, with the total number of matches
+	FindAll(ctx context.Context, page, limit int, search string, sort []utils.SortField) ([]*entity.Customer, int64, error)
+
+	// FindByID retrieves a customer by ID; a missing customer returns gorm.ErrRecordNotFound
+	FindByID(ctx context.Context, id uint) (*entity.Customer, error)
+
+	// FindByExternalID retrieves a customer by external ID; when none matches it returns nil, nil
+	FindByExternalID(ctx context.Context, externalID string) (*entity.Customer, error)
+
+	// FindOrCreate retrieves the customer with the external ID, creating it
+	// when there is none. Concurrent calls for a new ID create it once.
+	FindOrCreate(ctx context.Context, externalID string) (*entity.Customer, error)
+
+	// Create stores a customer; an external ID that is taken returns gorm.ErrDuplicatedKey
+	Create(ctx context.Context, customer *entity.Customer) error
+
+	// Update saves a customer's external ID and contact details and returns the number of rows affected
+	Update(ctx context.Context, customer *entity.Customer) (int64, error)
+
+	// Delete removes a customer and returns the number of rows affected. A
+	// customer with assignments, redemptions or claims returns gorm.ErrForeignKeyViolated.
+	Delete(ctx context.Context, id uint) (int64, error)
+
+	// Merge moves the assignments, redemptions and claims of duplicateID to
+	// survivor, saves survivor's contact details and marks the duplicate as
+	// merged into it, all in one transaction. Where both had the same voucher
+	// assigned, survivor keeps its own assignment.
+	Merge(ctx context.Context, survivor *entity.Customer, duplicateID uint) error
+
+	// Erase anonymizes a customer and the duplicates merged into it in one
+	// transaction: their external IDs are replaced and contact details
+	// cleared, order IDs are cleared from their redemptions, phone numbers,
+	// IDs and bodies from their SMS deliveries (unsent ones are discarded),
+	// and they are removed from static segments. Their rows and those of
+	// their assignments, redemptions and claims are kept. audit is called with
+	// the counts and the entry it returns is recorded in the same transaction.
+	// A missing customer returns gorm.ErrRecordNotFound.
+	Erase(ctx context.Context, id uint, erasedAt time.Time, audit func(erasure *CustomerErasure) (*entity.AuditEntry, error)) (*CustomerErasure, error)
+}
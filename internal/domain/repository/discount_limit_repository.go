@@ -0,0 +1,22 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/shoelfikar/voucher-management-system/internal/domain/entity"
+)
+
+// DiscountLimitRepository defines the interface for per-role discount limit data operations
+type DiscountLimitRepository interface {
+	// FindAll retrieves every configured limit, ordered by role
+	FindAll(ctx context.Context) ([]*entity.DiscountLimit, error)
+
+	// FindByRole retrieves the limit of a role; when it has none it returns nil, nil
+	FindByRole(ctx context.Context, role string) (*entity.DiscountLimit, error)
+
+	// Save creates or replaces the limit of a role
+	Save(ctx context.Context, limit *entity.DiscountLimit) error
+
+	// Delete removes the limit of a role and returns the number of rows affected
+	Delete(ctx context.Context, role string) (int64, error)
+}
@@ -0,0 +1,19 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/shoelfikar/voucher-management-system/internal/domain/entity"
+)
+
+// EmailTemplateRepository defines the interface for email template data operations
+type EmailTemplateRepository interface {
+	// FindLatest retrieves the highest version of a key; when it has none it returns nil, nil
+	FindLatest(ctx context.Context, key string) (*entity.EmailTemplate, error)
+
+	// FindVersions retrieves every version of a key, newest first
+	FindVersions(ctx context.Context, key string) ([]*entity.EmailTemplate, error)
+
+	// Create stores a template as the next version of its key and sets its Version
+	Create(ctx context.Context, template *entity.EmailTemplate) error
+}
@@ -0,0 +1,28 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/shoelfikar/voucher-management-system/internal/domain/entity"
+	"github.com/shoelfikar/voucher-management-system/pkg/utils"
+)
+
+// ImportRuleRepository defines the interface for import rule data operations
+type ImportRuleRepository interface {
+	// FindAll retrieves a page of rules whose name contains search, with the total number of matches
+	FindAll(ctx context.Context, page, limit int, search string, sort []utils.SortField) ([]*entity.ImportRule, int64, error)
+
+	// FindEnabled retrieves the rules the import pipeline evaluates, ordered by ID
+	FindEnabled(ctx context.Context) ([]*entity.ImportRule, error)
+
+	// FindByID retrieves a rule by ID; a missing rule returns gorm.ErrRecordNotFound
+	FindByID(ctx context.Context, id uint) (*entity.ImportRule, error)
+
+	Create(ctx context.Context, rule *entity.ImportRule) error
+
+	// Update saves a rule and returns the number of rows affected
+	Update(ctx context.Context, rule *entity.ImportRule) (int64, error)
+
+	// Delete removes a rule and returns the number of rows affected
+	Delete(ctx context.Context, id uint) (int64, error)
+}
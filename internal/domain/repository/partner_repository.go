@@ -0,0 +1,39 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/shoelfikar/voucher-management-system/internal/domain/entity"
+	"github.com/shoelfikar/voucher-management-system/pkg/utils"
+)
+
+// PartnerRepository defines the interface for partner data operations
+type PartnerRepository interface {
+	// FindAll retrieves a page of partners whose name contains search, with the total number of matches
+	FindAll(ctx context.Context, page, limit int, search string, sort []utils.SortField) ([]*entity.Partner, int64, error)
+
+	// FindByID retrieves a partner by ID; a missing partner returns gorm.ErrRecordNotFound
+	FindByID(ctx context.Context, id uint) (*entity.Partner, error)
+
+	// FindByName retrieves a partner by name; when no partner matches it returns nil, nil
+	FindByName(ctx context.Context, name string) (*entity.Partner, error)
+
+	// FindByAPIKeyHash retrieves the partner owning an API key hash; when no partner matches it returns nil, nil
+	FindByAPIKeyHash(ctx context.Context, hash string) (*entity.Partner, error)
+
+	// Create creates a new partner
+	Create(ctx context.Context, partner *entity.Partner) error
+
+	// Update saves the name, quota and active flag of a partner and returns the number of rows affected
+	Update(ctx context.Context, partner *entity.Partner) (int64, error)
+
+	// UpdateAPIKey replaces the API key hash and prefix of a partner and returns the number of rows affected
+	UpdateAPIKey(ctx context.Context, id uint, hash, prefix string) (int64, error)
+
+	// ReserveQuota adds n to the partner's issued count if that keeps it within the quota,
+	// and reports whether it did
+	ReserveQuota(ctx context.Context, id uint, n int64) (bool, error)
+
+	// ReleaseQuota gives back n previously reserved vouchers
+	ReleaseQuota(ctx context.Context, id uint, n int64) error
+}
@@ -0,0 +1,17 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/shoelfikar/voucher-management-system/internal/domain/entity"
+)
+
+// RedemptionRepository defines the interface for voucher redemption data operations
+type RedemptionRepository interface {
+	// Create stores a redemption and increments the voucher's redemption count in one transaction.
+	// The voucher row is locked first, then check is called with its limits and count and the
+	// number of times the customer already redeemed it; an error from check aborts the redemption
+	// and is returned as is. Concurrent redemptions of the same voucher are serialized, so the
+	// counts check sees are current. A voucher that does not exist returns gorm.ErrRecordNotFound.
+	Create(ctx context.Context, redemption *entity.Redemption, check func(voucher *entity.Voucher, customerRedemptions int64) error) error
+}
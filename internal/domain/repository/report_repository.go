@@ -0,0 +1,47 @@
+package repository
+
+import (
+	"context"
+	"time"
+)
+
+// RedemptionTotals sums up the redemptions made in a period
+type RedemptionTotals struct {
+	Redemptions int64
+	// Customers counts the distinct customers who redeemed
+	Customers int64
+}
+
+// VoucherRedemptions is how often one voucher was redeemed in a period
+type VoucherRedemptions struct {
+	VoucherID   uint
+	VoucherCode string
+	DisplayName string
+	Redemptions int64
+}
+
+// DailyRedemptions is how many redemptions were made on one day
+type DailyRedemptions struct {
+	Day         time.Time
+	Redemptions int64
+}
+
+// ReportRepository aggregates vouchers and redemptions for reports. Periods
+// include from and exclude to.
+type ReportRepository interface {
+	// CountVouchersCreated counts the vouchers created in the period, deleted ones included
+	CountVouchersCreated(ctx context.Context, from, to time.Time) (int64, error)
+
+	// CountActiveVouchers counts the active vouchers that have not expired at at
+	CountActiveVouchers(ctx context.Context, at time.Time) (int64, error)
+
+	// SumRedemptions counts the redemptions made in the period and the customers who made them
+	SumRedemptions(ctx context.Context, from, to time.Time) (RedemptionTotals, error)
+
+	// TopRedeemedVouchers returns up to limit vouchers with the most redemptions in the period, most first
+	TopRedeemedVouchers(ctx context.Context, from, to time.Time, limit int) ([]VoucherRedemptions, error)
+
+	// CountRedemptionsByDay returns the redemptions per day in the period, oldest
+	// first; days without redemptions are left out
+	CountRedemptionsByDay(ctx context.Context, from, to time.Time) ([]DailyRedemptions, error)
+}
@@ -0,0 +1,16 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/shoelfikar/voucher-management-system/internal/domain/entity"
+)
+
+// RetentionPolicyRepository stores the single expired-voucher retention policy
+type RetentionPolicyRepository interface {
+	// Get retrieves the policy; before one is saved it returns gorm.ErrRecordNotFound
+	Get(ctx context.Context) (*entity.RetentionPolicy, error)
+
+	// Save creates or replaces the policy
+	Save(ctx context.Context, policy *entity.RetentionPolicy) error
+}
@@ -0,0 +1,38 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/shoelfikar/voucher-management-system/internal/domain/entity"
+	"github.com/shoelfikar/voucher-management-system/pkg/utils"
+)
+
+// SegmentRepository defines the interface for customer segment data operations
+type SegmentRepository interface {
+	// FindAll retrieves a page of segments whose name contains search, with the total number of matches
+	FindAll(ctx context.Context, page, limit int, search string, sort []utils.SortField) ([]*entity.Segment, int64, error)
+
+	// FindByID retrieves a segment by ID; a missing segment returns gorm.ErrRecordNotFound
+	FindByID(ctx context.Context, id uint) (*entity.Segment, error)
+
+	// FindByName retrieves a segment by name; when no segment matches it returns nil, nil
+	FindByName(ctx context.Context, name string) (*entity.Segment, error)
+
+	// Create creates a segment together with its members
+	Create(ctx context.Context, segment *entity.Segment, customerIDs []string) error
+
+	// Update saves a segment, replaces its members, and returns the number of segments affected
+	Update(ctx context.Context, segment *entity.Segment, customerIDs []string) (int64, error)
+
+	// Delete removes a segment and its members and returns the number of segments affected
+	Delete(ctx context.Context, id uint) (int64, error)
+
+	// IsMember reports whether customerID is listed in the segment
+	IsMember(ctx context.Context, segmentID uint, customerID string) (bool, error)
+
+	// CountMembers counts the customers listed in the segment
+	CountMembers(ctx context.Context, segmentID uint) (int64, error)
+
+	// CountVouchers counts the vouchers targeting the segment, archived ones included
+	CountVouchers(ctx context.Context, segmentID uint) (int64, error)
+}
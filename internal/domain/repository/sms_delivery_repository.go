@@ -0,0 +1,37 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/shoelfikar/voucher-management-system/internal/domain/entity"
+)
+
+// SMSDeliveryFilter narrows the SMS deliveries returned by FindAll; zero fields match everything
+type SMSDeliveryFilter struct {
+	VoucherID uint
+	Status    string
+}
+
+// SMSDeliveryRepository defines the interface for SMS delivery data operations
+type SMSDeliveryRepository interface {
+	// CreateBatch stores several deliveries in one insert
+	CreateBatch(ctx context.Context, deliveries []*entity.SMSDelivery) error
+
+	// FindDue retrieves up to limit pending deliveries whose next attempt is due at now, oldest first
+	FindDue(ctx context.Context, now time.Time, limit int) ([]*entity.SMSDelivery, error)
+
+	// Update saves the outcome of a delivery attempt
+	Update(ctx context.Context, delivery *entity.SMSDelivery) error
+
+	// FindAll retrieves deliveries matching filter with pagination, newest first, and the total count
+	FindAll(ctx context.Context, filter SMSDeliveryFilter, page, limit int) ([]*entity.SMSDelivery, int64, error)
+
+	// Requeue makes a failed delivery pending again with fresh attempts, due at
+	// now, and returns the rows affected; deliveries in other states are left alone
+	Requeue(ctx context.Context, id uint, now time.Time) (int64, error)
+
+	// Discard marks a failed delivery discarded and returns the rows affected;
+	// deliveries in other states are left alone
+	Discard(ctx context.Context, id uint) (int64, error)
+}
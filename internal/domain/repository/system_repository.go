@@ -0,0 +1,16 @@
+package repository
+
+import "context"
+
+// SystemRepository reports on the health and schema of the database
+type SystemRepository interface {
+	// Ping checks the database answers
+	Ping(ctx context.Context) error
+
+	// MigrationVersion returns the version recorded by the SQL migrations and
+	// whether the last one failed halfway; ok is false when none were applied
+	MigrationVersion(ctx context.Context) (version uint, dirty bool, ok bool, err error)
+
+	// PendingSchemaChanges lists the tables and columns of the models missing from the database
+	PendingSchemaChanges(ctx context.Context) ([]string, error)
+}
@@ -1,9 +1,24 @@
 package repository
 
-import "github.com/shoelfikar/voucher-management-system/internal/domain/entity"
+import (
+	"context"
+	"time"
+
+	"github.com/shoelfikar/voucher-management-system/internal/domain/entity"
+)
 
 // UserRepository defines the interface for user data operations
 type UserRepository interface {
-	FindByEmail(email string) (*entity.User, error)
-	Create(user *entity.User) error
+	// FindByEmail retrieves a user by email; a missing user returns gorm.ErrRecordNotFound
+	FindByEmail(ctx context.Context, email string) (*entity.User, error)
+	Create(ctx context.Context, user *entity.User) error
+	// CreateFirst creates user only if no accounts exist yet, checked atomically
+	// with the insert, and reports whether it did
+	CreateFirst(ctx context.Context, user *entity.User) (bool, error)
+	// Count returns how many accounts exist
+	Count(ctx context.Context) (int64, error)
+	// MarkEmailVerified records when the user's email address was verified and returns the rows affected
+	MarkEmailVerified(ctx context.Context, email string, verifiedAt time.Time) (int64, error)
+	// UpdatePassword replaces the stored password hash of the user
+	UpdatePassword(ctx context.Context, email, hashedPassword string) error
 }
@@ -0,0 +1,23 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/shoelfikar/voucher-management-system/internal/domain/entity"
+)
+
+// VoucherAssignmentRepository defines the interface for voucher assignment data operations
+type VoucherAssignmentRepository interface {
+	// Create stores an assignment; assigning a voucher to the same customer twice returns gorm.ErrDuplicatedKey
+	Create(ctx context.Context, assignment *entity.VoucherAssignment) error
+
+	// FindByVoucherAndCustomer retrieves a customer's assignment of a voucher; when there is none it returns nil, nil
+	FindByVoucherAndCustomer(ctx context.Context, voucherID, customerID uint) (*entity.VoucherAssignment, error)
+
+	// FindByVoucher retrieves a page of a voucher's assignments with their customers, newest first, with the total count
+	FindByVoucher(ctx context.Context, voucherID uint, page, limit int) ([]*entity.VoucherAssignment, int64, error)
+
+	// DeleteExpiredBefore deletes up to limit assignments that expired before cutoff and returns how many it deleted
+	DeleteExpiredBefore(ctx context.Context, cutoff time.Time, limit int) (int64, error)
+}
@@ -1,30 +1,114 @@
 package repository
 
-import "github.com/shoelfikar/voucher-management-system/internal/domain/entity"
+import (
+	"context"
+	"time"
 
-// VoucherRepository defines the interface for voucher data operations
+	"github.com/shoelfikar/voucher-management-system/internal/domain/entity"
+	"github.com/shoelfikar/voucher-management-system/pkg/utils"
+)
+
+// VoucherFilter selects vouchers for bulk operations; every set field must match
+type VoucherFilter struct {
+	IDs        []uint
+	Codes      []string
+	CodePrefix string
+	Status     string
+	// ExcludeStatus leaves out vouchers with this status
+	ExcludeStatus string
+	SegmentID     *uint
+	PartnerID     *uint
+	DiscountType  string
+}
+
+// VoucherRepository defines the interface for voucher data operations. Every
+// method runs its queries under ctx, so a cancelled request stops them.
 type VoucherRepository interface {
 	// FindAll retrieves all vouchers with pagination, search, and sorting
-	FindAll(page, limit int, search, sortBy, sortOrder string) ([]*entity.Voucher, int64, error)
+	FindAll(ctx context.Context, page, limit int, search string, sort []utils.SortField) ([]*entity.Voucher, int64, error)
 
-	// FindByID retrieves a voucher by ID
-	FindByID(id uint) (*entity.Voucher, error)
+	// FindAllByPartner retrieves the vouchers issued by a partner with pagination, search, and sorting
+	FindAllByPartner(ctx context.Context, partnerID uint, page, limit int, search string, sort []utils.SortField) ([]*entity.Voucher, int64, error)
+
+	// FindByID retrieves a voucher by ID; a missing voucher returns gorm.ErrRecordNotFound
+	FindByID(ctx context.Context, id uint) (*entity.Voucher, error)
 
 	// Create creates a new voucher
-	Create(voucher *entity.Voucher) error
+	Create(ctx context.Context, voucher *entity.Voucher) error
+
+	// Update updates an existing voucher and returns the number of rows affected
+	Update(ctx context.Context, voucher *entity.Voucher) (int64, error)
+
+	// Delete soft deletes a voucher by ID and returns the number of rows affected
+	Delete(ctx context.Context, id uint) (int64, error)
 
-	// Update updates an existing voucher
-	Update(voucher *entity.Voucher) error
+	// Approve activates a voucher that is pending approval and returns the number of rows affected
+	Approve(ctx context.Context, id uint, approvedBy string, approvedAt time.Time) (int64, error)
 
-	// Delete soft deletes a voucher by ID
-	Delete(id uint) error
+	// FindByIDs retrieves the vouchers with the given IDs in one query, ordered by ID;
+	// IDs without a voucher are left out
+	FindByIDs(ctx context.Context, ids []uint) ([]*entity.Voucher, error)
 
-	// FindByVoucherCode retrieves a voucher by voucher code
-	FindByVoucherCode(code string) (*entity.Voucher, error)
+	// FindByVoucherCodes retrieves the vouchers with the given codes in one query, ignoring case,
+	// ordered by ID; codes without a voucher are left out
+	FindByVoucherCodes(ctx context.Context, codes []string) ([]*entity.Voucher, error)
+
+	// FindByVoucherCode retrieves a voucher by voucher code, ignoring case;
+	// when no voucher matches it returns nil, nil
+	FindByVoucherCode(ctx context.Context, code string) (*entity.Voucher, error)
+
+	// SuggestCodes returns up to limit voucher codes starting with prefix, ignoring case
+	SuggestCodes(ctx context.Context, prefix string, limit int) ([]string, error)
+
+	// FindByExternalID retrieves a voucher by its client-provided external ID;
+	// when no voucher matches it returns nil, nil
+	FindByExternalID(ctx context.Context, externalID string) (*entity.Voucher, error)
+
+	// CheckDuplicateExternalIDs checks which external IDs already exist
+	CheckDuplicateExternalIDs(ctx context.Context, externalIDs []string) ([]string, error)
 
 	// BulkCreate creates multiple vouchers at once
-	BulkCreate(vouchers []*entity.Voucher) error
+	BulkCreate(ctx context.Context, vouchers []*entity.Voucher) error
+
+	// BulkCreateSkipConflicts creates vouchers one by one, skipping any that conflict
+	// with an existing row, and returns the codes that were skipped
+	BulkCreateSkipConflicts(ctx context.Context, vouchers []*entity.Voucher) ([]string, error)
+
+	// CheckDuplicateCodes checks which voucher codes already exist, ignoring case
+	CheckDuplicateCodes(ctx context.Context, codes []string) ([]string, error)
+
+	// DeactivateByCodes marks the vouchers with the given codes inactive and returns the rows affected
+	DeactivateByCodes(ctx context.Context, codes []string) (int64, error)
+
+	// DeactivateByPrefix marks up to limit vouchers whose code starts with prefix inactive
+	// and returns the rows affected; call it repeatedly until it returns 0
+	DeactivateByPrefix(ctx context.Context, prefix string, limit int) (int64, error)
+
+	// FindIDsByFilter returns up to limit IDs of vouchers matching filter with an ID above afterID, ordered by ID
+	FindIDsByFilter(ctx context.Context, filter VoucherFilter, afterID uint, limit int) ([]uint, error)
+
+	// UpdateFields sets the given columns on the vouchers with the given IDs and returns the rows affected
+	UpdateFields(ctx context.Context, ids []uint, fields map[string]interface{}) (int64, error)
+
+	// UpdateFieldsForApproval sets the given columns like UpdateFields and, in
+	// the same transaction, sends the active vouchers among them back for
+	// approval; other statuses are kept. It returns the rows affected and the
+	// IDs of the vouchers now pending approval.
+	UpdateFieldsForApproval(ctx context.Context, ids []uint, fields map[string]interface{}) (int64, []uint, error)
+
+	// CountExpiredBefore counts vouchers that expired before cutoff; includeArchived also counts soft-deleted ones
+	CountExpiredBefore(ctx context.Context, cutoff time.Time, includeArchived bool) (int64, error)
+
+	// FindExpiredCodesBefore returns up to limit codes of vouchers that expired before cutoff, oldest first
+	FindExpiredCodesBefore(ctx context.Context, cutoff time.Time, includeArchived bool, limit int) ([]string, error)
+
+	// ArchiveExpiredBefore soft deletes up to limit vouchers that expired before cutoff
+	ArchiveExpiredBefore(ctx context.Context, cutoff time.Time, limit int) (int64, error)
+
+	// FindChangedSince returns up to limit vouchers with an ID above afterID that were created,
+	// updated or soft deleted after since, soft-deleted ones included, ordered by ID
+	FindChangedSince(ctx context.Context, since time.Time, afterID uint, limit int) ([]*entity.Voucher, error)
 
-	// CheckDuplicateCodes checks which voucher codes already exist
-	CheckDuplicateCodes(codes []string) ([]string, error)
+	// PurgeExpiredBefore permanently deletes up to limit vouchers that expired before cutoff, archived or not
+	PurgeExpiredBefore(ctx context.Context, cutoff time.Time, limit int) (int64, error)
 }
@@ -0,0 +1,28 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/shoelfikar/voucher-management-system/internal/domain/entity"
+	"github.com/shoelfikar/voucher-management-system/pkg/utils"
+)
+
+// VoucherTemplateRepository defines the interface for voucher template data operations
+type VoucherTemplateRepository interface {
+	// FindAll retrieves a page of templates whose name contains search, with the total number of matches
+	FindAll(ctx context.Context, page, limit int, search string, sort []utils.SortField) ([]*entity.VoucherTemplate, int64, error)
+
+	// FindByID retrieves a template by ID; a missing template returns gorm.ErrRecordNotFound
+	FindByID(ctx context.Context, id uint) (*entity.VoucherTemplate, error)
+
+	// FindByName retrieves a template by name; when no template matches it returns nil, nil
+	FindByName(ctx context.Context, name string) (*entity.VoucherTemplate, error)
+
+	Create(ctx context.Context, template *entity.VoucherTemplate) error
+
+	// Update saves a template and returns the number of rows affected
+	Update(ctx context.Context, template *entity.VoucherTemplate) (int64, error)
+
+	// Delete removes a template and returns the number of rows affected
+	Delete(ctx context.Context, id uint) (int64, error)
+}
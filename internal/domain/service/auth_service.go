@@ -1,12 +1,36 @@
 package service
 
-import "github.com/shoelfikar/voucher-management-system/internal/domain/entity"
+import (
+	"context"
+	"errors"
+
+	"github.com/shoelfikar/voucher-management-system/internal/domain/entity"
+)
+
+// ErrInvalidCredentials is returned when no account has the email, or the password does not match
+var ErrInvalidCredentials = errors.New("invalid credentials")
+
+// ErrAccountLocked is returned when the password matches but the account has been locked
+var ErrAccountLocked = errors.New("account is locked")
+
+// ErrEmailNotVerified is returned when verification is required and the account's email is not verified
+var ErrEmailNotVerified = errors.New("email address has not been verified")
+
+// ErrEmailAlreadyRegistered is returned when registering an email that already has an account
+var ErrEmailAlreadyRegistered = errors.New("email is already registered")
+
+// ErrEmailRequired is returned when registering without an email address
+var ErrEmailRequired = errors.New("email is required")
+
+// ErrRegistrationClosed is returned when self-registration is disabled and an account already exists
+var ErrRegistrationClosed = errors.New("registration is closed")
 
 // AuthService defines the interface for authentication operations
 type AuthService interface {
 	// Login authenticates a user and returns a token
-	Login(email, password string) (string, *entity.User, error)
+	Login(ctx context.Context, email, password string) (string, *entity.User, error)
 
-	// Register new user
-	Register(email, password string) (string, error)
+	// Register creates an account and returns it with a token. The token is
+	// empty when the email address must be verified before logging in.
+	Register(ctx context.Context, email, password string) (string, *entity.User, error)
 }
@@ -0,0 +1,44 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/shoelfikar/voucher-management-system/internal/domain/entity"
+)
+
+// MaxClaimLinks is the most claim links generated by one request
+const MaxClaimLinks = 1000
+
+// ErrInvalidClaimLink is returned when a claim token is forged, expired or unknown
+var ErrInvalidClaimLink = errors.New("invalid or expired claim link")
+
+// ErrClaimLinkUsed is returned when a claim link was already used by another customer
+var ErrClaimLinkUsed = errors.New("claim link has already been used")
+
+// CreateClaimLinksCommand represents the data required to generate claim links for a voucher
+type CreateClaimLinksCommand struct {
+	VoucherID uint
+	Count     int
+	CreatedBy string
+}
+
+// ClaimLinkBatch holds freshly generated claim links. The URLs carry the
+// signed tokens and cannot be retrieved again later.
+type ClaimLinkBatch struct {
+	VoucherID uint      `json:"voucher_id"`
+	ExpiresAt time.Time `json:"expires_at"`
+	URLs      []string  `json:"urls"`
+}
+
+// ClaimService hands vouchers out through one-time claim links, so they can be
+// distributed by email or SMS without exposing their codes
+type ClaimService interface {
+	// CreateLinks generates one-time claim links for an active, unexpired voucher
+	CreateLinks(ctx context.Context, cmd *CreateClaimLinksCommand) (*ClaimLinkBatch, error)
+
+	// Claim assigns the voucher a claim link was generated for to the customer,
+	// using the link up
+	Claim(ctx context.Context, token, customerID string) (*entity.VoucherAssignment, error)
+}
@@ -0,0 +1,98 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"io"
+	"time"
+
+	"github.com/shoelfikar/voucher-management-system/internal/domain/entity"
+	"github.com/shoelfikar/voucher-management-system/pkg/utils"
+)
+
+// ErrCustomerNotFound is returned when a customer does not exist
+var ErrCustomerNotFound = errors.New("customer not found")
+
+// ErrDuplicateCustomer is returned when an external ID already belongs to another customer
+var ErrDuplicateCustomer = errors.New("customer external id already exists")
+
+// ErrCustomerInUse is returned when deleting a customer that has assignments, redemptions or claims
+var ErrCustomerInUse = errors.New("customer has vouchers assigned, redeemed or claimed; merge it instead")
+
+// ErrCustomerMerged is returned when changing a customer that was merged into another one
+var ErrCustomerMerged = errors.New("customer was merged into another customer")
+
+// ErrCustomerErased is returned when changing or using a customer whose data was erased
+var ErrCustomerErased = errors.New("customer data was erased")
+
+// CustomerErasure reports what erasing a customer anonymized. The customer's
+// assignments, redemptions and claims are kept, so counts stay intact.
+type CustomerErasure struct {
+	CustomerID uint      `json:"customer_id"`
+	ErasedAt   time.Time `json:"erased_at"`
+	// MergedCustomers counts the duplicates merged into the customer, which were erased with it
+	MergedCustomers    int64 `json:"merged_customers"`
+	Redemptions        int64 `json:"redemptions"`
+	SMSDeliveries      int64 `json:"sms_deliveries"`
+	SegmentMemberships int64 `json:"segment_memberships"`
+}
+
+// CustomerCommand represents the data required to create or update a customer
+type CustomerCommand struct {
+	ExternalID string
+	Email      string
+	Phone      string
+}
+
+// CustomerImportResult represents the result of a customer CSV import. Rows
+// for an external ID that already exists update that customer.
+type CustomerImportResult struct {
+	TotalRows int           `json:"total_rows"`
+	Created   int           `json:"created"`
+	Updated   int           `json:"updated"`
+	Failed    int           `json:"failed"`
+	Errors    []ImportError `json:"errors,omitempty"`
+}
+
+// CustomerService manages the customers vouchers are assigned to, claimed by
+// and redeemed by
+type CustomerService interface {
+	// GetAll retrieves a page of customers, leaving out merged duplicates, with the total number of matches
+	GetAll(ctx context.Context, page, limit int, search string, sort []utils.SortField) ([]*entity.Customer, int64, error)
+
+	// GetByID retrieves a customer by ID, merged duplicates included
+	GetByID(ctx context.Context, id uint) (*entity.Customer, error)
+
+	// Create validates and stores a new customer
+	Create(ctx context.Context, cmd *CustomerCommand) (*entity.Customer, error)
+
+	// Update validates and saves a customer's external ID and contact details
+	Update(ctx context.Context, id uint, cmd *CustomerCommand) (*entity.Customer, error)
+
+	// Delete removes a customer nothing was recorded against
+	Delete(ctx context.Context, id uint) error
+
+	// Import creates or updates customers from CSV rows with an external_id
+	// column and optional email and phone columns, in any order
+	Import(ctx context.Context, file io.Reader) (*CustomerImportResult, error)
+
+	// Merge folds a duplicate into the surviving customer: its assignments,
+	// redemptions and claims move over, contact details the survivor lacks
+	// are copied, and its external ID resolves to the survivor from then on
+	Merge(ctx context.Context, survivorID, duplicateID uint) (*entity.Customer, error)
+
+	// Erase anonymizes a customer for a data deletion request on behalf of
+	// actor: its identifiers and contact details, and those of the duplicates
+	// merged into it, are removed from everything recorded against it, and
+	// the erasure is audited
+	Erase(ctx context.Context, id uint, actor string) (*CustomerErasure, error)
+
+	// Resolve returns the customer with the external ID, creating it on first
+	// use; a merged duplicate resolves to the customer it was merged into.
+	// An erased customer returns ErrCustomerErased.
+	Resolve(ctx context.Context, externalID string) (*entity.Customer, error)
+
+	// Find returns the customer with the external ID like Resolve, but returns
+	// nil instead of creating one
+	Find(ctx context.Context, externalID string) (*entity.Customer, error)
+}
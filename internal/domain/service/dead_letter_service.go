@@ -0,0 +1,45 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// Kinds of background work that can end up as dead letters
+const (
+	DeadLetterKindSMS = "sms"
+)
+
+// DeadLetterKinds lists every kind of dead letter
+var DeadLetterKinds = []string{DeadLetterKindSMS}
+
+// ErrDeadLetterNotFound is returned when no failed job has the dead letter's ID,
+// including when it was already retried or discarded
+var ErrDeadLetterNotFound = errors.New("dead letter not found")
+
+// DeadLetter is a background job that failed for good and waits for an admin
+// to retry or discard it. ID combines the kind with the job's own ID, e.g.
+// "sms-42". Preview shows the job's payload with secrets such as claim link
+// tokens and voucher codes redacted.
+type DeadLetter struct {
+	ID        string                 `json:"id"`
+	Kind      string                 `json:"kind"`
+	Attempts  int                    `json:"attempts"`
+	LastError string                 `json:"last_error"`
+	FailedAt  time.Time              `json:"failed_at"`
+	CreatedBy string                 `json:"created_by,omitempty"`
+	Preview   map[string]interface{} `json:"preview"`
+}
+
+// DeadLetterService lets admins recover background jobs that ran out of attempts
+type DeadLetterService interface {
+	// GetAll retrieves a page of dead letters, optionally of one kind, newest first
+	GetAll(ctx context.Context, kind string, page, limit int) ([]*DeadLetter, int64, error)
+
+	// Retry queues the job again with a fresh set of attempts
+	Retry(ctx context.Context, id, actor string) error
+
+	// Discard gives up on the job; it stays recorded but leaves the dead letters
+	Discard(ctx context.Context, id, actor string) error
+}
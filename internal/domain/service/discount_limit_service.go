@@ -0,0 +1,33 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/shoelfikar/voucher-management-system/internal/domain/entity"
+)
+
+// ErrDiscountLimitNotFound is returned when a role has no discount limit
+var ErrDiscountLimitNotFound = errors.New("discount limit not found")
+
+// SetDiscountLimitCommand represents the data required to set a role's discount limit
+type SetDiscountLimitCommand struct {
+	Role               string
+	MaxDiscountPercent float64
+	// MaxDiscountAmounts caps fixed amounts per currency, in the major unit
+	MaxDiscountAmounts map[string]float64
+	UpdatedBy          string
+}
+
+// DiscountLimitService manages the per-role caps on voucher discounts, which
+// keep mistyped promotions from going live. The voucher service enforces them.
+type DiscountLimitService interface {
+	// GetAll returns every configured limit
+	GetAll(ctx context.Context) ([]*entity.DiscountLimit, error)
+
+	// Set validates and creates or replaces a role's limit; admins cannot be limited
+	Set(ctx context.Context, cmd *SetDiscountLimitCommand) (*entity.DiscountLimit, error)
+
+	// Delete lifts a role's limit
+	Delete(ctx context.Context, role string) error
+}
@@ -0,0 +1,75 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/shoelfikar/voucher-management-system/internal/domain/entity"
+)
+
+// ErrEmailTemplateNotFound is returned for a key that is not one of entity.EmailTemplateKeys
+var ErrEmailTemplateNotFound = errors.New("email template not found")
+
+// ErrInvalidEmailTemplate is returned when a subject or body does not parse,
+// or uses a variable its email does not provide
+var ErrInvalidEmailTemplate = errors.New("invalid email template")
+
+// EmailTemplateSummary describes the wording an email is currently sent with
+type EmailTemplateSummary struct {
+	Key string `json:"key"`
+	// Version is the saved version in use, or 0 while the built-in default is sent
+	Version   int        `json:"version"`
+	Subject   string     `json:"subject"`
+	Body      string     `json:"body"`
+	Variables []string   `json:"variables"`
+	UpdatedBy string     `json:"updated_by,omitempty"`
+	UpdatedAt *time.Time `json:"updated_at,omitempty"`
+}
+
+// RenderedEmail is an email with its variables filled in, ready to send
+type RenderedEmail struct {
+	Subject string `json:"subject"`
+	Body    string `json:"body"`
+}
+
+// SaveEmailTemplateCommand represents the data required to save a new version of an email
+type SaveEmailTemplateCommand struct {
+	Key       string
+	Subject   string
+	Body      string
+	CreatedBy string
+}
+
+// PreviewEmailTemplateCommand selects what to render: a draft subject and
+// body, or the current wording when they are empty. Variables override the
+// sample values the email is otherwise rendered with.
+type PreviewEmailTemplateCommand struct {
+	Key       string
+	Subject   string
+	Body      string
+	Variables map[string]string
+}
+
+// EmailTemplateService manages the editable wording of the emails the system
+// sends. Each save adds a version; keys never saved use built-in defaults.
+type EmailTemplateService interface {
+	// GetAll describes every editable email and the variables it may use
+	GetAll(ctx context.Context) ([]*EmailTemplateSummary, error)
+
+	// GetVersions returns the saved versions of an email, newest first
+	GetVersions(ctx context.Context, key string) ([]*entity.EmailTemplate, error)
+
+	// Save validates a subject and body and stores them as the email's next version
+	Save(ctx context.Context, cmd *SaveEmailTemplateCommand) (*entity.EmailTemplate, error)
+
+	// Preview renders an email without sending it
+	Preview(ctx context.Context, cmd *PreviewEmailTemplateCommand) (*RenderedEmail, error)
+
+	// SendTest renders an email as Preview does and sends it to the given address
+	SendTest(ctx context.Context, cmd *PreviewEmailTemplateCommand, to string) (*RenderedEmail, error)
+
+	// Render fills in an email's current wording. When the saved version
+	// cannot be loaded or rendered the built-in default is used instead.
+	Render(ctx context.Context, key string, vars map[string]string) (*RenderedEmail, error)
+}
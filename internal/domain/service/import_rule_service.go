@@ -0,0 +1,40 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/shoelfikar/voucher-management-system/internal/domain/entity"
+	"github.com/shoelfikar/voucher-management-system/pkg/utils"
+)
+
+// ErrImportRuleNotFound is returned when an import rule does not exist
+var ErrImportRuleNotFound = errors.New("import rule not found")
+
+// ImportRuleCommand represents the data required to create or replace an import rule
+type ImportRuleCommand struct {
+	Name      string
+	Type      string
+	Value     string
+	Message   string
+	Enabled   bool
+	CreatedBy string
+}
+
+// ImportRuleService manages the validation rules applied to every voucher import
+type ImportRuleService interface {
+	// GetAll retrieves a page of import rules, enabled or not, with the total number of matches
+	GetAll(ctx context.Context, page, limit int, search string, sort []utils.SortField) ([]*entity.ImportRule, int64, error)
+
+	// GetByID retrieves an import rule by ID
+	GetByID(ctx context.Context, id uint) (*entity.ImportRule, error)
+
+	// Create validates and stores a new import rule
+	Create(ctx context.Context, cmd *ImportRuleCommand) (*entity.ImportRule, error)
+
+	// Update validates and replaces an existing import rule
+	Update(ctx context.Context, id uint, cmd *ImportRuleCommand) (*entity.ImportRule, error)
+
+	// Delete removes an import rule
+	Delete(ctx context.Context, id uint) error
+}
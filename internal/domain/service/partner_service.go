@@ -0,0 +1,67 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/shoelfikar/voucher-management-system/internal/domain/entity"
+	"github.com/shoelfikar/voucher-management-system/pkg/utils"
+)
+
+// ErrPartnerNotFound is returned when a partner does not exist
+var ErrPartnerNotFound = errors.New("partner not found")
+
+// ErrDuplicatePartnerName is returned when a partner name is already taken
+var ErrDuplicatePartnerName = errors.New("partner name already exists")
+
+// ErrInvalidAPIKey is returned when an API key does not belong to any partner
+var ErrInvalidAPIKey = errors.New("invalid api key")
+
+// ErrPartnerInactive is returned when a deactivated partner uses its API key
+var ErrPartnerInactive = errors.New("partner is inactive")
+
+// ErrPartnerQuotaExceeded is returned when a partner has issued its whole quota
+var ErrPartnerQuotaExceeded = errors.New("partner voucher quota exceeded")
+
+// PartnerCommand represents the data required to create or update a partner
+type PartnerCommand struct {
+	Name      string
+	Quota     int64
+	RateLimit int
+	Active    bool
+	CreatedBy string
+}
+
+// PartnerCredentials is a partner together with its plaintext API key. The key
+// is only available when it is generated; afterwards just its hash is stored.
+type PartnerCredentials struct {
+	*entity.Partner
+	APIKey string `json:"api_key"`
+}
+
+// PartnerService manages resellers and the vouchers they issue with their API keys
+type PartnerService interface {
+	// GetAll retrieves a page of partners with the total number of matches
+	GetAll(ctx context.Context, page, limit int, search string, sort []utils.SortField) ([]*entity.Partner, int64, error)
+
+	// GetByID retrieves a partner by ID
+	GetByID(ctx context.Context, id uint) (*entity.Partner, error)
+
+	// Create stores a new partner and generates its API key
+	Create(ctx context.Context, cmd *PartnerCommand) (*PartnerCredentials, error)
+
+	// Update changes the name, quota, rate limit and active flag of a partner
+	Update(ctx context.Context, id uint, cmd *PartnerCommand) (*entity.Partner, error)
+
+	// RotateKey replaces a partner's API key; the old key stops working at once
+	RotateKey(ctx context.Context, id uint) (*PartnerCredentials, error)
+
+	// Authenticate returns the active partner owning apiKey
+	Authenticate(ctx context.Context, apiKey string) (*entity.Partner, error)
+
+	// IssueVoucher creates a voucher owned by the partner, counting it against its quota
+	IssueVoucher(ctx context.Context, partnerID uint, cmd *CreateVoucherCommand) (*entity.Voucher, error)
+
+	// GetVouchers retrieves a page of the vouchers the partner issued
+	GetVouchers(ctx context.Context, partnerID uint, page, limit int, search string, sort []utils.SortField) ([]*entity.Voucher, int64, error)
+}
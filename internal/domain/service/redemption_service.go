@@ -0,0 +1,35 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/shoelfikar/voucher-management-system/internal/domain/entity"
+	"github.com/shoelfikar/voucher-management-system/pkg/rules"
+)
+
+// ErrVoucherAlreadyRedeemed is returned when a customer has redeemed a voucher as often as its per-customer limit allows
+var ErrVoucherAlreadyRedeemed = errors.New("voucher has already been redeemed by this customer")
+
+// ErrVoucherFullyRedeemed is returned when a voucher has been redeemed as often as its total limit allows
+var ErrVoucherFullyRedeemed = errors.New("voucher has been fully redeemed")
+
+// ErrVoucherNotRedeemable is returned when a voucher does not apply to the purchase it is redeemed on
+var ErrVoucherNotRedeemable = errors.New("voucher cannot be redeemed")
+
+// RedeemVoucherCommand represents the data required to redeem a voucher on a purchase
+type RedeemVoucherCommand struct {
+	VoucherCode string
+	// OrderID is the caller's reference for the purchase, kept for reconciliation
+	OrderID    string
+	Context    rules.Context
+	RedeemedBy string
+}
+
+// RedemptionService records vouchers being used on purchases
+type RedemptionService interface {
+	// Redeem validates a voucher against the purchase like VoucherService.Validate and records
+	// its use by the customer, returning the redemption with the discount to apply. The voucher's
+	// MaxRedemptions and MaxRedemptionsPerUser are enforced atomically with the record.
+	Redeem(ctx context.Context, cmd *RedeemVoucherCommand) (*entity.Redemption, error)
+}
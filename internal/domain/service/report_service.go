@@ -0,0 +1,57 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// MaxReportPeriod is the longest period a report covers
+const MaxReportPeriod = 366 * 24 * time.Hour
+
+// Buckets of a report's redemption trend
+const (
+	ReportBucketDay  = "day"
+	ReportBucketWeek = "week"
+)
+
+// ErrInvalidReportPeriod is returned when a report period is empty, reversed or too long
+var ErrInvalidReportPeriod = errors.New("report period must end after it starts and cover at most 366 days")
+
+// ReportVoucher is one of the most redeemed vouchers of a report period
+type ReportVoucher struct {
+	VoucherID uint `json:"voucher_id"`
+	// Name is the voucher's display name, or its masked code when it has none
+	Name        string `json:"name"`
+	Redemptions int64  `json:"redemptions"`
+}
+
+// ReportTrendPoint is the redemptions of one bucket of a report's trend, a
+// day or a week starting at Start
+type ReportTrendPoint struct {
+	Start       time.Time `json:"start"`
+	Redemptions int64     `json:"redemptions"`
+}
+
+// ReportSummary sums up voucher activity in a period that includes From and excludes To
+type ReportSummary struct {
+	From               time.Time          `json:"from"`
+	To                 time.Time          `json:"to"`
+	VouchersCreated    int64              `json:"vouchers_created"`
+	ActiveVouchers     int64              `json:"active_vouchers"`
+	Redemptions        int64              `json:"redemptions"`
+	RedeemingCustomers int64              `json:"redeeming_customers"`
+	TopVouchers        []ReportVoucher    `json:"top_vouchers"`
+	TrendBucket        string             `json:"trend_bucket"`
+	RedemptionTrend    []ReportTrendPoint `json:"redemption_trend"`
+	GeneratedAt        time.Time          `json:"generated_at"`
+}
+
+// ReportService summarises voucher activity for stakeholders
+type ReportService interface {
+	// Summary sums up the period from to to
+	Summary(ctx context.Context, from, to time.Time) (*ReportSummary, error)
+
+	// RenderSummaryPDF renders the summary of the period as a one-page PDF
+	RenderSummaryPDF(ctx context.Context, from, to time.Time) ([]byte, error)
+}
@@ -0,0 +1,55 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/shoelfikar/voucher-management-system/internal/domain/entity"
+)
+
+// MaxCleanupPreviewCodes is the number of sample codes listed in a cleanup preview
+const MaxCleanupPreviewCodes = 20
+
+// EventCleanupCompleted is published after each scheduled cleanup run
+const EventCleanupCompleted = "cleanup.completed"
+
+// RetentionPolicyCommand represents the data required to replace the retention policy
+type RetentionPolicyCommand struct {
+	KeepDays  int
+	Action    string
+	Enabled   bool
+	UpdatedBy string
+}
+
+// CleanupPreview describes what the next cleanup run would do under the current policy
+type CleanupPreview struct {
+	Enabled     bool      `json:"enabled"`
+	Action      string    `json:"action"`
+	Cutoff      time.Time `json:"cutoff"`
+	Affected    int64     `json:"affected"`
+	SampleCodes []string  `json:"sample_codes"`
+}
+
+// CleanupResult reports the outcome of a cleanup run
+type CleanupResult struct {
+	Action              string    `json:"action"`
+	Cutoff              time.Time `json:"cutoff"`
+	Affected            int64     `json:"affected"`
+	AssignmentsAffected int64     `json:"assignments_affected"`
+}
+
+// RetentionService manages the expired voucher retention policy and applies it
+type RetentionService interface {
+	// GetPolicy returns the current policy, or the disabled default when none was saved
+	GetPolicy(ctx context.Context) (*entity.RetentionPolicy, error)
+
+	// UpdatePolicy validates and replaces the policy
+	UpdatePolicy(ctx context.Context, cmd *RetentionPolicyCommand) (*entity.RetentionPolicy, error)
+
+	// Preview reports which vouchers the next cleanup run would archive or purge
+	Preview(ctx context.Context) (*CleanupPreview, error)
+
+	// RunCleanup applies the policy, also deleting voucher assignments that
+	// expired before the cutoff; it does nothing while the policy is disabled
+	RunCleanup(ctx context.Context) (*CleanupResult, error)
+}
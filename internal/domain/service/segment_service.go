@@ -0,0 +1,59 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/shoelfikar/voucher-management-system/internal/domain/entity"
+	"github.com/shoelfikar/voucher-management-system/pkg/utils"
+)
+
+// ErrSegmentNotFound is returned when a segment does not exist
+var ErrSegmentNotFound = errors.New("segment not found")
+
+// ErrDuplicateSegmentName is returned when a segment name is already taken
+var ErrDuplicateSegmentName = errors.New("segment name already exists")
+
+// ErrSegmentInUse is returned when deleting a segment vouchers still target
+var ErrSegmentInUse = errors.New("segment is targeted by vouchers")
+
+// ErrSegmentProviderUnavailable is returned when an external segment is used but no provider is configured
+var ErrSegmentProviderUnavailable = errors.New("external segments are not configured")
+
+// SegmentCommand represents the data required to create or replace a segment.
+// CustomerIDs lists the members of a static segment; ExternalRef names an external one.
+type SegmentCommand struct {
+	Name        string
+	Type        string
+	ExternalRef string
+	CustomerIDs []string
+	CreatedBy   string
+}
+
+// SegmentDetail is a segment with the number of customers it lists
+type SegmentDetail struct {
+	*entity.Segment
+	MemberCount int64 `json:"member_count"`
+}
+
+// SegmentService manages the customer segments vouchers can be restricted to
+type SegmentService interface {
+	// GetAll retrieves a page of segments with the total number of matches
+	GetAll(ctx context.Context, page, limit int, search string, sort []utils.SortField) ([]*entity.Segment, int64, error)
+
+	// GetByID retrieves a segment by ID with its member count
+	GetByID(ctx context.Context, id uint) (*SegmentDetail, error)
+
+	// Create validates and stores a new segment
+	Create(ctx context.Context, cmd *SegmentCommand) (*SegmentDetail, error)
+
+	// Update validates and replaces an existing segment, members included
+	Update(ctx context.Context, id uint, cmd *SegmentCommand) (*SegmentDetail, error)
+
+	// Delete removes a segment no voucher targets
+	Delete(ctx context.Context, id uint) error
+
+	// IsMember reports whether the customer belongs to the segment, asking the
+	// external segment provider for external segments
+	IsMember(ctx context.Context, segmentID uint, customerID string) (bool, error)
+}
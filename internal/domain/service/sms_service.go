@@ -0,0 +1,50 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/shoelfikar/voucher-management-system/internal/domain/entity"
+)
+
+// MaxSMSRecipients is the most recipients queued by one send request
+const MaxSMSRecipients = 1000
+
+// ErrInvalidPhoneNumber is returned when a recipient's phone number is not in E.164 format
+var ErrInvalidPhoneNumber = errors.New("phone number must be in E.164 format, e.g. +14155550123")
+
+// SMSRecipient is a customer to text a voucher to
+type SMSRecipient struct {
+	PhoneNumber string
+	CustomerID  string
+}
+
+// SendSMSCommand represents the data required to text a voucher to customers.
+// Content is entity.SMSContentCode to send the voucher code itself, or
+// entity.SMSContentClaimLink to send each recipient their own claim link.
+type SendSMSCommand struct {
+	VoucherID  uint
+	Recipients []SMSRecipient
+	Content    string
+	CreatedBy  string
+}
+
+// SMSDispatchResult reports the outcome of a dispatch run
+type SMSDispatchResult struct {
+	Sent     int `json:"sent"`
+	Retrying int `json:"retrying"`
+	Failed   int `json:"failed"`
+}
+
+// SMSService texts voucher codes and claim links to customers. Messages are
+// queued and sent by Dispatch, which retries failed attempts with backoff.
+type SMSService interface {
+	// Send queues one message per recipient for an active, unexpired voucher
+	Send(ctx context.Context, cmd *SendSMSCommand) ([]*entity.SMSDelivery, error)
+
+	// Dispatch sends the queued messages that are due
+	Dispatch(ctx context.Context) (*SMSDispatchResult, error)
+
+	// GetDeliveries retrieves deliveries with pagination, optionally for one voucher or status
+	GetDeliveries(ctx context.Context, voucherID uint, status string, page, limit int) ([]*entity.SMSDelivery, int64, error)
+}
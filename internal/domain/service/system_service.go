@@ -0,0 +1,93 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/shoelfikar/voucher-management-system/pkg/buildinfo"
+)
+
+// Dependency health statuses
+const (
+	DependencyStatusUp       = "up"
+	DependencyStatusDown     = "down"
+	DependencyStatusDisabled = "disabled"
+)
+
+// Public status values, for the service as a whole and for each component
+const (
+	StatusOperational = "operational"
+	StatusDegraded    = "degraded"
+	StatusOutage      = "outage"
+)
+
+// StatusCacheTTL is how long a public status summary is served before the
+// checks behind it run again, so the unauthenticated endpoint cannot be used
+// to hammer dependencies
+const StatusCacheTTL = 30 * time.Second
+
+// ErrConfigReloadDisabled is returned when the running instance cannot reload its configuration
+var ErrConfigReloadDisabled = errors.New("configuration reload is not enabled")
+
+// ConfigReload reports the settings a configuration reload changed, by environment variable name
+type ConfigReload struct {
+	Changed    []string  `json:"changed"`
+	ReloadedAt time.Time `json:"reloaded_at"`
+}
+
+// MigrationStatus describes the database schema version
+type MigrationStatus struct {
+	// Version is the last SQL migration applied, or nil when none were recorded
+	Version              *uint    `json:"version"`
+	Dirty                bool     `json:"dirty"`
+	PendingSchemaChanges []string `json:"pending_schema_changes"`
+	Error                string   `json:"error,omitempty"`
+}
+
+// DependencyHealth is the outcome of checking one dependency of the service
+type DependencyHealth struct {
+	Name      string `json:"name"`
+	Status    string `json:"status"`
+	LatencyMs int64  `json:"latency_ms"`
+	Error     string `json:"error,omitempty"`
+}
+
+// SystemInfo is an operational snapshot of a running instance
+type SystemInfo struct {
+	Build         buildinfo.Info         `json:"build"`
+	StartedAt     time.Time              `json:"started_at"`
+	Uptime        string                 `json:"uptime"`
+	UptimeSeconds int64                  `json:"uptime_seconds"`
+	Config        map[string]interface{} `json:"config"`
+	Migration     MigrationStatus        `json:"migration"`
+	Dependencies  []DependencyHealth     `json:"dependencies"`
+}
+
+// ComponentStatus is how one part of the service is doing, under a name fit for customers
+type ComponentStatus struct {
+	Name   string `json:"name"`
+	Status string `json:"status"`
+}
+
+// PublicStatus summarizes the service's health for a public status page. It
+// names components generically and leaves out error details and internals.
+type PublicStatus struct {
+	Status     string            `json:"status"`
+	Version    string            `json:"version"`
+	UpdatedAt  time.Time         `json:"updated_at"`
+	Components []ComponentStatus `json:"components"`
+}
+
+// SystemService reports on the running instance for support triage
+type SystemService interface {
+	// Info returns the build, uptime, redacted configuration, schema version and dependency health
+	Info(ctx context.Context) *SystemInfo
+
+	// Status returns the public health summary, refreshed at most every StatusCacheTTL
+	Status(ctx context.Context) *PublicStatus
+
+	// ReloadConfig re-reads the settings that can change without a restart on behalf of actor
+	// and reports which of them changed; the previous settings stay when reading fails
+	ReloadConfig(ctx context.Context, actor string) (*ConfigReload, error)
+}
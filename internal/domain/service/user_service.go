@@ -0,0 +1,22 @@
+package service
+
+import (
+	"context"
+
+	"github.com/shoelfikar/voucher-management-system/internal/domain/entity"
+)
+
+// UserService defines the interface for user account operations
+type UserService interface {
+	// Invite emails a signed invitation link for a new account with the given role
+	Invite(ctx context.Context, email, role, invitedBy string) error
+
+	// AcceptInvite creates the invited account with the chosen password and returns an access token
+	AcceptInvite(ctx context.Context, token, password string) (string, *entity.User, error)
+
+	// SendVerificationEmail emails a signed link that confirms the user owns the address
+	SendVerificationEmail(ctx context.Context, email string) error
+
+	// VerifyEmail marks the account in a verification token as verified
+	VerifyEmail(ctx context.Context, token string) error
+}
@@ -1,18 +1,152 @@
 package service
 
 import (
+	"context"
+	"errors"
 	"mime/multipart"
+	"time"
 
-	"github.com/shoelfikar/voucher-management-system/internal/delivery/http/request"
 	"github.com/shoelfikar/voucher-management-system/internal/domain/entity"
+	"github.com/shoelfikar/voucher-management-system/pkg/rules"
+	"github.com/shoelfikar/voucher-management-system/pkg/utils"
 )
 
+// ErrVoucherNotFound is returned when a voucher does not exist
+var ErrVoucherNotFound = errors.New("voucher not found")
+
+// Events published by the voucher service
+const (
+	EventImportCompleted      = "import.completed"
+	EventBatchImportCompleted = "batch_import.completed"
+	// EventApprovalRequested carries an ApprovalRequest so approvers learn
+	// about vouchers waiting for them
+	EventApprovalRequested = "voucher.approval_requested"
+	// EventVoucherApproved carries the approved voucher's VoucherApproval
+	EventVoucherApproved = "voucher.approved"
+)
+
+// MaxSuggestions is the number of codes returned by code autocomplete
+const MaxSuggestions = 10
+
+// MaxBatchLookup is the number of IDs or codes a single batch lookup may ask for
+const MaxBatchLookup = 100
+
+// ErrDuplicateVoucherCode is returned when a voucher code, compared case-insensitively, is already taken
+var ErrDuplicateVoucherCode = errors.New("voucher code already exists")
+
+// ErrVoucherNotPendingApproval is returned when approving a voucher that is not waiting for approval
+var ErrVoucherNotPendingApproval = errors.New("voucher is not pending approval")
+
+// ErrSelfApproval is returned when the approver is unknown, or is who created,
+// imported or last edited the voucher
+var ErrSelfApproval = errors.New("voucher must be approved by a different admin than the one who created or last changed it")
+
+// ErrDuplicateExternalID is returned when an external ID is already used by another voucher
+var ErrDuplicateExternalID = errors.New("external id already exists")
+
+// ErrVoucherAlreadyAssigned is returned when a voucher is assigned to a customer who already has it
+var ErrVoucherAlreadyAssigned = errors.New("voucher is already assigned to this customer")
+
+// ErrVoucherNotAssignable is returned when a voucher that is not active, or has expired, is assigned
+var ErrVoucherNotAssignable = errors.New("voucher cannot be assigned")
+
+// ErrDiscountLimitExceeded is returned when a user gives a voucher a larger discount than their role allows
+var ErrDiscountLimitExceeded = errors.New("discount exceeds the limit for your role")
+
+// Schema versions of voucher CSV files. Version 1 files have the voucher_code,
+// discount_percent and expiry_date columns; version 2 adds external_id,
+// display_name, description and terms_url; version 3 adds discount_type,
+// discount_amount and currency. Exports use the current version.
+const (
+	CSVSchemaV1             = 1
+	CSVSchemaV2             = 2
+	CSVSchemaV3             = 3
+	CurrentCSVSchemaVersion = CSVSchemaV3
+)
+
+// ErrUnsupportedCSVSchema is returned when a CSV file declares a schema version that does not exist
+var ErrUnsupportedCSVSchema = errors.New("unsupported CSV schema version")
+
+// ErrVoucherNotPrintable is returned when a voucher that is not active, or has expired, is printed
+var ErrVoucherNotPrintable = errors.New("voucher cannot be printed")
+
+// CreateVoucherCommand represents the data required to create a voucher
+type CreateVoucherCommand struct {
+	VoucherCode string
+	ExternalID  string
+	// DiscountType is entity.DiscountTypePercent, the default, or
+	// entity.DiscountTypeFixedAmount, which takes DiscountAmount off in Currency.
+	// DiscountAmount is in the major unit, e.g. 10.5; it is stored in minor units.
+	DiscountType    string
+	DiscountPercent float64
+	DiscountAmount  float64
+	Currency        string
+	ExpiryDate      string
+	ValidityDays    *int
+	// MaxRedemptions caps redemptions in total, nil for no cap; MaxRedemptionsPerUser
+	// caps them per customer, 0 for entity.DefaultMaxRedemptionsPerUser
+	MaxRedemptions        *int
+	MaxRedemptionsPerUser int
+	Rules                 string
+	DisplayName           string
+	Description           string
+	TermsURL              string
+	Metadata              map[string]string
+	SegmentID             *uint
+	PartnerID             *uint
+	// TemplateID names a voucher template whose code prefix, discount, expiry
+	// offset and channels fill in or constrain the fields above
+	TemplateID *uint
+	CreatedBy  string
+	// Role of the creator, checked against the discount limits
+	Role string
+}
+
+// UpdateVoucherCommand represents the data required to update a voucher
+type UpdateVoucherCommand struct {
+	VoucherCode           string
+	DiscountType          string
+	DiscountPercent       float64
+	DiscountAmount        float64
+	Currency              string
+	ExpiryDate            string
+	ValidityDays          *int
+	MaxRedemptions        *int
+	MaxRedemptionsPerUser int
+	Rules                 string
+	DisplayName           string
+	Description           string
+	TermsURL              string
+	Metadata              map[string]string
+	SegmentID             *uint
+	UpdatedBy             string
+	// Role of the editor, checked against the discount limits
+	Role string
+}
+
+// ApprovalRequest lists vouchers that entered pending_approval together
+type ApprovalRequest struct {
+	VoucherIDs  []uint `json:"voucher_ids"`
+	RequestedBy string `json:"requested_by"`
+}
+
+// VoucherApproval records who activated a voucher pending approval
+type VoucherApproval struct {
+	VoucherID  uint      `json:"voucher_id"`
+	ApprovedBy string    `json:"approved_by"`
+	ApprovedAt time.Time `json:"approved_at"`
+}
+
 // ImportResult represents the result of CSV import
 type ImportResult struct {
-	TotalRows int           `json:"total_rows"`
-	Success   int           `json:"success"`
-	Failed    int           `json:"failed"`
-	Errors    []ImportError `json:"errors,omitempty"`
+	// SchemaVersion is the CSV schema the rows were read with
+	SchemaVersion int           `json:"schema_version"`
+	TotalRows     int           `json:"total_rows"`
+	Success       int           `json:"success"`
+	Failed        int           `json:"failed"`
+	Errors        []ImportError `json:"errors,omitempty"`
+	// Warnings flag suspicious rows that were still imported
+	Warnings []ImportWarning `json:"warnings,omitempty"`
 }
 
 // ImportError represents an error during CSV import
@@ -21,6 +155,13 @@ type ImportError struct {
 	Error string `json:"error"`
 }
 
+// ImportWarning represents suspicious data in an imported row, such as a 100%
+// discount, that did not stop the row from being imported
+type ImportWarning struct {
+	Row     int    `json:"row"`
+	Warning string `json:"warning"`
+}
+
 // BatchImportResult represents the result of batch import
 type BatchImportResult struct {
 	TotalReceived  int      `json:"total_received"`
@@ -30,29 +171,150 @@ type BatchImportResult struct {
 	Errors         []string `json:"errors"`
 }
 
+// BulkDeactivateResult represents the outcome of a bulk deactivation
+type BulkDeactivateResult struct {
+	Matched      int      `json:"matched"`
+	Deactivated  int64    `json:"deactivated"`
+	MissingCodes []string `json:"missing_codes"`
+}
+
+// BulkEditFilter selects the vouchers a bulk edit applies to; every set field must match
+type BulkEditFilter struct {
+	IDs        []uint   `json:"ids,omitempty"`
+	Codes      []string `json:"codes,omitempty"`
+	CodePrefix string   `json:"prefix,omitempty"`
+	Status     string   `json:"status,omitempty"`
+	SegmentID  *uint    `json:"segment_id,omitempty"`
+	PartnerID  *uint    `json:"partner_id,omitempty"`
+}
+
+// BulkEditCommand represents a change applied to every voucher matching a filter.
+// Patch is a JSON merge patch object (RFC 7396) or a JSON Patch array (RFC 6902)
+// of top-level voucher fields.
+type BulkEditCommand struct {
+	Filter   BulkEditFilter
+	Patch    []byte
+	EditedBy string
+	// Role of the editor, checked against the discount limits
+	Role string
+}
+
+// BulkEditResult represents the outcome of a bulk edit
+type BulkEditResult struct {
+	Fields  []string `json:"fields"`
+	Updated int64    `json:"updated"`
+	Chunks  int      `json:"chunks"`
+}
+
+// ValidationResult represents whether a voucher can be applied to a purchase
+type ValidationResult struct {
+	VoucherID       uint     `json:"voucher_id"`
+	VoucherCode     string   `json:"voucher_code"`
+	Valid           bool     `json:"valid"`
+	DiscountType    string   `json:"discount_type"`
+	DiscountPercent float64  `json:"discount_percent"`
+	DiscountAmount  float64  `json:"discount_amount,omitempty"`
+	Currency        string   `json:"currency,omitempty"`
+	Reasons         []string `json:"reasons,omitempty"`
+}
+
 // VoucherService defines the interface for voucher business logic
 type VoucherService interface {
 	// GetAll retrieves all vouchers with pagination and filters
-	GetAll(page, limit int, search, sortBy, sortOrder string) ([]*entity.Voucher, int64, error)
+	GetAll(ctx context.Context, page, limit int, search string, sort []utils.SortField) ([]*entity.Voucher, int64, error)
 
 	// GetByID retrieves a voucher by ID
-	GetByID(id uint) (*entity.Voucher, error)
+	GetByID(ctx context.Context, id uint) (*entity.Voucher, error)
+
+	// GetByIDs retrieves up to MaxBatchLookup vouchers by ID in one query; missing IDs are left out
+	GetByIDs(ctx context.Context, ids []uint) ([]*entity.Voucher, error)
+
+	// GetByCodes retrieves up to MaxBatchLookup vouchers by code, ignoring case, in one query;
+	// missing codes are left out
+	GetByCodes(ctx context.Context, codes []string) ([]*entity.Voucher, error)
+
+	// Suggest returns up to MaxSuggestions voucher codes starting with query, ignoring case
+	Suggest(ctx context.Context, query string) ([]string, error)
+
+	// CodeAvailable reports whether no voucher uses code yet, ignoring case as Create does
+	CodeAvailable(ctx context.Context, code string) (bool, error)
+
+	// GetByExternalID retrieves a voucher by its client-provided external ID
+	GetByExternalID(ctx context.Context, externalID string) (*entity.Voucher, error)
 
 	// Create creates a new voucher with validation
-	Create(req *request.CreateVoucherRequest) (*entity.Voucher, error)
+	Create(ctx context.Context, cmd *CreateVoucherCommand) (*entity.Voucher, error)
 
 	// Update updates an existing voucher with validation
-	Update(id uint, req *request.UpdateVoucherRequest) (*entity.Voucher, error)
+	Update(ctx context.Context, id uint, cmd *UpdateVoucherCommand) (*entity.Voucher, error)
 
 	// Delete deletes a voucher by ID
-	Delete(id uint) error
+	Delete(ctx context.Context, id uint) error
+
+	// BulkDeactivate immediately deactivates vouchers by code prefix or by an explicit code list
+	BulkDeactivate(ctx context.Context, prefix string, codes []string) (*BulkDeactivateResult, error)
 
-	// ImportVouchers imports vouchers from CSV file
-	ImportVouchers(file multipart.File) (*ImportResult, error)
+	// BulkEdit applies a patch to every voucher matching a filter, in chunks,
+	// recording an audit entry per chunk
+	BulkEdit(ctx context.Context, cmd *BulkEditCommand) (*BulkEditResult, error)
 
-	// ImportBatch imports a batch of vouchers with duplicate checking
-	ImportBatch(vouchers []request.CreateVoucherRequest) (*BatchImportResult, error)
+	// Validate checks whether a voucher can be applied to purchase.
+	// A voucher with validity days is only valid for customers it was assigned
+	// to, until their assignment expires.
+	Validate(ctx context.Context, code string, purchase rules.Context) (*ValidationResult, error)
+
+	// Approve activates a voucher pending approval on behalf of the approver,
+	// who must differ from whoever created, imported or last edited it
+	Approve(ctx context.Context, id uint, approvedBy string) (*entity.Voucher, error)
+
+	// ImportVouchers imports vouchers from a CSV file on behalf of importedBy
+	// in the given schema version, or the version its header row implies when
+	// schemaVersion is 0. Rows above the discount limit of role are rejected.
+	ImportVouchers(ctx context.Context, file multipart.File, schemaVersion int, importedBy, role string) (*ImportResult, error)
+
+	// ImportRecords imports vouchers on behalf of importedBy from tabular rows
+	// whose first row is a header, reading them in the schema version the
+	// header implies. Rows above the discount limit of role are rejected.
+	ImportRecords(ctx context.Context, records [][]string, importedBy, role string) (*ImportResult, error)
+
+	// ImportBatch imports a batch of vouchers with duplicate checking;
+	// vouchers above the discount limit of their Role are rejected
+	ImportBatch(ctx context.Context, vouchers []CreateVoucherCommand) (*BatchImportResult, error)
+
+	// ImportTemplate returns the header row of the current CSV schema version
+	// followed by an example row that would import as-is
+	ImportTemplate() [][]string
+
+	// ExportVouchers exports all vouchers to CSV format in the given schema version
+	ExportVouchers(ctx context.Context, schemaVersion int) ([]byte, error)
+
+	// ExportVoucherParts exports all vouchers as CSV files of at most rowsPerFile rows
+	// in the given schema version, calling emit with each file in order
+	ExportVoucherParts(ctx context.Context, rowsPerFile, schemaVersion int, emit func(data []byte) error) error
+
+	// ExportVoucherChanges exports vouchers created, updated or deleted after since to CSV format,
+	// with changed_at and deleted columns; deleted vouchers are listed as tombstones
+	ExportVoucherChanges(ctx context.Context, since time.Time) ([]byte, error)
+
+	// Assign gives an active, unexpired voucher to a customer, fixing the
+	// assignment's expiry from the voucher's validity days
+	Assign(ctx context.Context, id uint, customerID, assignedBy string) (*entity.VoucherAssignment, error)
+
+	// GetAssignments retrieves a page of a voucher's assignments, newest first
+	GetAssignments(ctx context.Context, id uint, page, limit int) ([]*entity.VoucherAssignment, int64, error)
+
+	// RenderPDF renders up to MaxBatchLookup active, unexpired vouchers as a printable PDF,
+	// one page per voucher in the order requested
+	RenderPDF(ctx context.Context, ids []uint) ([]byte, error)
+
+	// RenderPreviewImage renders a voucher's discount and expiry as a PNG
+	// for social sharing previews
+	RenderPreviewImage(ctx context.Context, id uint, options PreviewImageOptions) ([]byte, error)
+}
 
-	// ExportVouchers exports all vouchers to CSV format
-	ExportVouchers() ([]byte, error)
+// PreviewImageOptions controls whether a voucher preview image shows the code
+type PreviewImageOptions struct {
+	ShowCode bool
+	// MaskCode partially redacts a shown code
+	MaskCode bool
 }
@@ -0,0 +1,43 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/shoelfikar/voucher-management-system/internal/domain/entity"
+	"github.com/shoelfikar/voucher-management-system/pkg/utils"
+)
+
+// ErrVoucherTemplateNotFound is returned when a voucher template does not exist
+var ErrVoucherTemplateNotFound = errors.New("voucher template not found")
+
+// ErrDuplicateVoucherTemplateName is returned when a template name is already taken
+var ErrDuplicateVoucherTemplateName = errors.New("voucher template name already exists")
+
+// VoucherTemplateCommand represents the data required to create or replace a voucher template
+type VoucherTemplateCommand struct {
+	Name            string
+	DiscountPercent *float64
+	ExpiryOffset    string
+	CodePrefix      string
+	Channels        []string
+	CreatedBy       string
+}
+
+// VoucherTemplateService manages the presets voucher creates can start from
+type VoucherTemplateService interface {
+	// GetAll retrieves a page of templates with the total number of matches
+	GetAll(ctx context.Context, page, limit int, search string, sort []utils.SortField) ([]*entity.VoucherTemplate, int64, error)
+
+	// GetByID retrieves a template by ID
+	GetByID(ctx context.Context, id uint) (*entity.VoucherTemplate, error)
+
+	// Create validates and stores a new template
+	Create(ctx context.Context, cmd *VoucherTemplateCommand) (*entity.VoucherTemplate, error)
+
+	// Update validates and replaces an existing template; vouchers created from it are unchanged
+	Update(ctx context.Context, id uint, cmd *VoucherTemplateCommand) (*entity.VoucherTemplate, error)
+
+	// Delete removes a template; vouchers created from it are unchanged
+	Delete(ctx context.Context, id uint) error
+}
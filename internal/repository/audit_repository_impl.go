@@ -0,0 +1,24 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/shoelfikar/voucher-management-system/internal/domain/entity"
+	"github.com/shoelfikar/voucher-management-system/internal/domain/repository"
+	"gorm.io/gorm"
+)
+
+// auditRepositoryImpl implements domain repository.AuditRepository
+type auditRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewAuditRepository creates a new audit repository instance
+func NewAuditRepository(db *gorm.DB) repository.AuditRepository {
+	return &auditRepositoryImpl{db: db}
+}
+
+// Create records an audit entry
+func (r *auditRepositoryImpl) Create(ctx context.Context, entry *entity.AuditEntry) error {
+	return r.db.WithContext(ctx).Create(entry).Error
+}
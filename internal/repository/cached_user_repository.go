@@ -0,0 +1,82 @@
+package repository
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/shoelfikar/voucher-management-system/internal/domain/entity"
+	"github.com/shoelfikar/voucher-management-system/internal/domain/repository"
+)
+
+// cachedUserRepository remembers users found by email for a short time, so
+// per-request principal lookups do not each query the database. Missing
+// users are not cached. Changes made elsewhere show up once the TTL elapses.
+type cachedUserRepository struct {
+	repository.UserRepository
+
+	ttl   time.Duration
+	mu    sync.Mutex
+	users map[string]cachedUser
+}
+
+type cachedUser struct {
+	user      entity.User
+	expiresAt time.Time
+}
+
+// NewCachedUserRepository wraps a user repository with a by-email cache
+func NewCachedUserRepository(inner repository.UserRepository, ttl time.Duration) repository.UserRepository {
+	return &cachedUserRepository{
+		UserRepository: inner,
+		ttl:            ttl,
+		users:          make(map[string]cachedUser),
+	}
+}
+
+// FindByEmail answers from the cache while the entry is fresh; callers get their own copy
+func (r *cachedUserRepository) FindByEmail(ctx context.Context, email string) (*entity.User, error) {
+	now := time.Now()
+
+	r.mu.Lock()
+	cached, ok := r.users[email]
+	r.mu.Unlock()
+	if ok && now.Before(cached.expiresAt) {
+		user := cached.user
+		return &user, nil
+	}
+
+	user, err := r.UserRepository.FindByEmail(ctx, email)
+	if err != nil {
+		return nil, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.users[email] = cachedUser{user: *user, expiresAt: now.Add(r.ttl)}
+	// Expired entries are swept on insert so the map does not keep departed users
+	for key, entry := range r.users {
+		if !now.Before(entry.expiresAt) {
+			delete(r.users, key)
+		}
+	}
+	return user, nil
+}
+
+// MarkEmailVerified marks the user verified and drops the stale cache entry
+func (r *cachedUserRepository) MarkEmailVerified(ctx context.Context, email string, verifiedAt time.Time) (int64, error) {
+	r.mu.Lock()
+	delete(r.users, email)
+	r.mu.Unlock()
+
+	return r.UserRepository.MarkEmailVerified(ctx, email, verifiedAt)
+}
+
+// UpdatePassword replaces the password hash and drops the stale cache entry
+func (r *cachedUserRepository) UpdatePassword(ctx context.Context, email, hashedPassword string) error {
+	r.mu.Lock()
+	delete(r.users, email)
+	r.mu.Unlock()
+
+	return r.UserRepository.UpdatePassword(ctx, email, hashedPassword)
+}
@@ -0,0 +1,85 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/shoelfikar/voucher-management-system/internal/domain/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCachedUserRepository_FindByEmail_ServedFromCache(t *testing.T) {
+	// Arrange
+	db := setupTestDB(t)
+	repo := NewCachedUserRepository(NewUserRepository(db), time.Minute)
+	assert.NoError(t, db.Create(&entity.User{Email: "admin@example.com", Password: "hash", Role: entity.UserRoleAdmin}).Error)
+
+	_, err := repo.FindByEmail(context.Background(), "admin@example.com")
+	assert.NoError(t, err)
+
+	// Change the row behind the cache's back
+	db.Model(&entity.User{}).Where("email = ?", "admin@example.com").Update("role", entity.UserRoleViewer)
+
+	// Act
+	user, err := repo.FindByEmail(context.Background(), "admin@example.com")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, entity.UserRoleAdmin, user.Role)
+}
+
+func TestCachedUserRepository_FindByEmail_MissingIsNotCached(t *testing.T) {
+	// Arrange
+	db := setupTestDB(t)
+	repo := NewCachedUserRepository(NewUserRepository(db), time.Minute)
+
+	_, err := repo.FindByEmail(context.Background(), "new@example.com")
+	assert.Error(t, err)
+	assert.NoError(t, db.Create(&entity.User{Email: "new@example.com", Password: "hash"}).Error)
+
+	// Act
+	user, err := repo.FindByEmail(context.Background(), "new@example.com")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "new@example.com", user.Email)
+}
+
+func TestCachedUserRepository_MarkEmailVerified_Invalidates(t *testing.T) {
+	// Arrange
+	db := setupTestDB(t)
+	repo := NewCachedUserRepository(NewUserRepository(db), time.Minute)
+	assert.NoError(t, db.Create(&entity.User{Email: "admin@example.com", Password: "hash"}).Error)
+
+	cached, err := repo.FindByEmail(context.Background(), "admin@example.com")
+	assert.NoError(t, err)
+	assert.Nil(t, cached.EmailVerifiedAt)
+
+	// Act
+	_, err = repo.MarkEmailVerified(context.Background(), "admin@example.com", time.Now())
+	assert.NoError(t, err)
+	user, err := repo.FindByEmail(context.Background(), "admin@example.com")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.NotNil(t, user.EmailVerifiedAt)
+}
+
+func TestCachedUserRepository_UpdatePassword_Invalidates(t *testing.T) {
+	// Arrange
+	db := setupTestDB(t)
+	repo := NewCachedUserRepository(NewUserRepository(db), time.Minute)
+	assert.NoError(t, db.Create(&entity.User{Email: "admin@example.com", Password: "old_hash"}).Error)
+
+	_, err := repo.FindByEmail(context.Background(), "admin@example.com")
+	assert.NoError(t, err)
+
+	// Act
+	assert.NoError(t, repo.UpdatePassword(context.Background(), "admin@example.com", "new_hash"))
+	user, err := repo.FindByEmail(context.Background(), "admin@example.com")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "new_hash", user.Password)
+}
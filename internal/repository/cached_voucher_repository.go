@@ -0,0 +1,160 @@
+package repository
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/shoelfikar/voucher-management-system/internal/domain/entity"
+	"github.com/shoelfikar/voucher-management-system/internal/domain/repository"
+)
+
+// cachedVoucherRepository remembers voucher codes known to exist for a short
+// time, so overlapping duplicate checks during sustained imports skip the
+// SQL IN query for codes that were already seen.
+type cachedVoucherRepository struct {
+	repository.VoucherRepository
+
+	ttl        time.Duration
+	mu         sync.Mutex
+	knownCodes map[string]time.Time
+	lastSweep  time.Time
+}
+
+// NewCachedVoucherRepository wraps a voucher repository with a known-code cache
+func NewCachedVoucherRepository(inner repository.VoucherRepository, ttl time.Duration) repository.VoucherRepository {
+	return &cachedVoucherRepository{
+		VoucherRepository: inner,
+		ttl:               ttl,
+		knownCodes:        make(map[string]time.Time),
+		lastSweep:         time.Now(),
+	}
+}
+
+// Create creates a voucher and remembers its code
+func (r *cachedVoucherRepository) Create(ctx context.Context, voucher *entity.Voucher) error {
+	if err := r.VoucherRepository.Create(ctx, voucher); err != nil {
+		return err
+	}
+	r.remember([]string{voucher.VoucherCode})
+	return nil
+}
+
+// Update updates a voucher; the old code may have been freed, so the cache is reset
+func (r *cachedVoucherRepository) Update(ctx context.Context, voucher *entity.Voucher) (int64, error) {
+	r.reset()
+	return r.VoucherRepository.Update(ctx, voucher)
+}
+
+// Delete deletes a voucher; its code is freed, so the cache is reset
+func (r *cachedVoucherRepository) Delete(ctx context.Context, id uint) (int64, error) {
+	r.reset()
+	return r.VoucherRepository.Delete(ctx, id)
+}
+
+// ArchiveExpiredBefore archives expired vouchers; they drop out of lookups, so the cache is reset
+func (r *cachedVoucherRepository) ArchiveExpiredBefore(ctx context.Context, cutoff time.Time, limit int) (int64, error) {
+	r.reset()
+	return r.VoucherRepository.ArchiveExpiredBefore(ctx, cutoff, limit)
+}
+
+// PurgeExpiredBefore purges expired vouchers; their codes are freed, so the cache is reset
+func (r *cachedVoucherRepository) PurgeExpiredBefore(ctx context.Context, cutoff time.Time, limit int) (int64, error) {
+	r.reset()
+	return r.VoucherRepository.PurgeExpiredBefore(ctx, cutoff, limit)
+}
+
+// BulkCreate creates vouchers and remembers their codes
+func (r *cachedVoucherRepository) BulkCreate(ctx context.Context, vouchers []*entity.Voucher) error {
+	if err := r.VoucherRepository.BulkCreate(ctx, vouchers); err != nil {
+		return err
+	}
+
+	codes := make([]string, len(vouchers))
+	for i, voucher := range vouchers {
+		codes[i] = voucher.VoucherCode
+	}
+	r.remember(codes)
+	return nil
+}
+
+// BulkCreateSkipConflicts creates vouchers and remembers the codes that were inserted
+func (r *cachedVoucherRepository) BulkCreateSkipConflicts(ctx context.Context, vouchers []*entity.Voucher) ([]string, error) {
+	skippedCodes, err := r.VoucherRepository.BulkCreateSkipConflicts(ctx, vouchers)
+	if err != nil {
+		return nil, err
+	}
+
+	// A row may have been skipped over its external ID, so only inserted codes are known to exist
+	var codes []string
+	for _, voucher := range vouchers {
+		if voucher.ID != 0 {
+			codes = append(codes, voucher.VoucherCode)
+		}
+	}
+	r.remember(codes)
+	return skippedCodes, nil
+}
+
+// CheckDuplicateCodes answers from the cache where possible and queries the rest
+func (r *cachedVoucherRepository) CheckDuplicateCodes(ctx context.Context, codes []string) ([]string, error) {
+	existingCodes, unknownCodes := r.lookup(codes)
+	if len(unknownCodes) == 0 {
+		return existingCodes, nil
+	}
+
+	found, err := r.VoucherRepository.CheckDuplicateCodes(ctx, unknownCodes)
+	if err != nil {
+		return nil, err
+	}
+	r.remember(found)
+
+	return append(existingCodes, found...), nil
+}
+
+// lookup splits codes into those cached as existing and those not cached
+func (r *cachedVoucherRepository) lookup(codes []string) ([]string, []string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now()
+	var existingCodes, unknownCodes []string
+	for _, code := range codes {
+		if expiresAt, ok := r.knownCodes[code]; ok && now.Before(expiresAt) {
+			existingCodes = append(existingCodes, code)
+		} else {
+			unknownCodes = append(unknownCodes, code)
+		}
+	}
+	return existingCodes, unknownCodes
+}
+
+// remember caches codes as existing until the TTL elapses
+func (r *cachedVoucherRepository) remember(codes []string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now()
+	expiresAt := now.Add(r.ttl)
+	for _, code := range codes {
+		r.knownCodes[code] = expiresAt
+	}
+
+	// Drop expired entries at most once per TTL to keep memory bounded
+	if now.Sub(r.lastSweep) >= r.ttl {
+		for code, codeExpiresAt := range r.knownCodes {
+			if !now.Before(codeExpiresAt) {
+				delete(r.knownCodes, code)
+			}
+		}
+		r.lastSweep = now
+	}
+}
+
+// reset forgets every cached code
+func (r *cachedVoucherRepository) reset() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.knownCodes = make(map[string]time.Time)
+}
@@ -0,0 +1,83 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/shoelfikar/voucher-management-system/internal/domain/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCachedVoucherRepository_CheckDuplicateCodes_ServedFromCache(t *testing.T) {
+	// Arrange
+	db := setupVoucherTestDB(t)
+	repo := NewCachedVoucherRepository(NewVoucherRepository(db), time.Minute)
+
+	err := repo.BulkCreate(context.Background(), []*entity.Voucher{createTestVoucher("CACHED1", 10.0)})
+	assert.NoError(t, err)
+
+	// Remove the row behind the cache's back
+	db.Unscoped().Where("voucher_code = ?", "CACHED1").Delete(&entity.Voucher{})
+
+	// Act
+	duplicates, err := repo.CheckDuplicateCodes(context.Background(), []string{"CACHED1", "NEW1"})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"CACHED1"}, duplicates)
+}
+
+func TestCachedVoucherRepository_CheckDuplicateCodes_QueriesUnknownCodes(t *testing.T) {
+	// Arrange
+	db := setupVoucherTestDB(t)
+	inner := NewVoucherRepository(db)
+	repo := NewCachedVoucherRepository(inner, time.Minute)
+
+	err := inner.Create(context.Background(), createTestVoucher("EXISTING1", 10.0))
+	assert.NoError(t, err)
+
+	// Act
+	duplicates, err := repo.CheckDuplicateCodes(context.Background(), []string{"EXISTING1", "NEW1"})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"EXISTING1"}, duplicates)
+}
+
+func TestCachedVoucherRepository_Delete_ResetsCache(t *testing.T) {
+	// Arrange
+	db := setupVoucherTestDB(t)
+	repo := NewCachedVoucherRepository(NewVoucherRepository(db), time.Minute)
+
+	voucher := createTestVoucher("FREED1", 10.0)
+	err := repo.Create(context.Background(), voucher)
+	assert.NoError(t, err)
+
+	// Act
+	_, err = repo.Delete(context.Background(), voucher.ID)
+	assert.NoError(t, err)
+	duplicates, err := repo.CheckDuplicateCodes(context.Background(), []string{"FREED1"})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Empty(t, duplicates)
+}
+
+func TestCachedVoucherRepository_CheckDuplicateCodes_ExpiredEntry(t *testing.T) {
+	// Arrange
+	db := setupVoucherTestDB(t)
+	repo := NewCachedVoucherRepository(NewVoucherRepository(db), time.Millisecond)
+
+	err := repo.Create(context.Background(), createTestVoucher("SHORT1", 10.0))
+	assert.NoError(t, err)
+	db.Unscoped().Where("voucher_code = ?", "SHORT1").Delete(&entity.Voucher{})
+	time.Sleep(5 * time.Millisecond)
+
+	// Act
+	duplicates, err := repo.CheckDuplicateCodes(context.Background(), []string{"SHORT1"})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Empty(t, duplicates)
+}
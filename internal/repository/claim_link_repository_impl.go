@@ -0,0 +1,60 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/shoelfikar/voucher-management-system/internal/domain/entity"
+	"github.com/shoelfikar/voucher-management-system/internal/domain/repository"
+	"gorm.io/gorm"
+)
+
+// claimLinkRepositoryImpl implements domain repository.ClaimLinkRepository
+type claimLinkRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewClaimLinkRepository creates a new claim link repository instance
+func NewClaimLinkRepository(db *gorm.DB) repository.ClaimLinkRepository {
+	return &claimLinkRepositoryImpl{db: db}
+}
+
+// CreateBatch stores several claim links in one insert
+func (r *claimLinkRepositoryImpl) CreateBatch(ctx context.Context, links []*entity.ClaimLink) error {
+	return r.db.WithContext(ctx).Create(&links).Error
+}
+
+// FindByTokenID retrieves the claim link a token was issued for
+func (r *claimLinkRepositoryImpl) FindByTokenID(ctx context.Context, tokenID string) (*entity.ClaimLink, error) {
+	var link entity.ClaimLink
+	err := r.db.WithContext(ctx).Where("token_id = ?", tokenID).First(&link).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &link, nil
+}
+
+// MarkClaimed records the claim unless the link was already claimed
+func (r *claimLinkRepositoryImpl) MarkClaimed(ctx context.Context, id, customerID uint, claimedAt time.Time) (int64, error) {
+	result := r.db.WithContext(ctx).Model(&entity.ClaimLink{}).
+		Where("id = ? AND claimed_at IS NULL", id).
+		Updates(map[string]interface{}{
+			"claimed_at":  claimedAt,
+			"customer_id": customerID,
+		})
+	return result.RowsAffected, result.Error
+}
+
+// Release makes a claimed link claimable again
+func (r *claimLinkRepositoryImpl) Release(ctx context.Context, id uint) error {
+	return r.db.WithContext(ctx).Model(&entity.ClaimLink{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"claimed_at":  nil,
+			"customer_id": nil,
+		}).Error
+}
@@ -0,0 +1,69 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/shoelfikar/voucher-management-system/internal/domain/entity"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func setupClaimLinkTestDB(t *testing.T) *gorm.DB {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{TranslateError: true})
+	if err != nil {
+		t.Fatalf("Failed to connect to test database: %v", err)
+	}
+	if err := db.AutoMigrate(&entity.ClaimLink{}); err != nil {
+		t.Fatalf("Failed to migrate test database: %v", err)
+	}
+	return db
+}
+
+func TestClaimLinkRepository_CreateBatchAndFind(t *testing.T) {
+	// Arrange
+	db := setupClaimLinkTestDB(t)
+	repo := NewClaimLinkRepository(db)
+	expiresAt := time.Now().Add(time.Hour)
+	links := []*entity.ClaimLink{
+		{VoucherID: 1, TokenID: "token-1", ExpiresAt: expiresAt},
+		{VoucherID: 1, TokenID: "token-2", ExpiresAt: expiresAt},
+	}
+
+	// Act
+	err := repo.CreateBatch(context.Background(), links)
+	found, findErr := repo.FindByTokenID(context.Background(), "token-2")
+	missing, missingErr := repo.FindByTokenID(context.Background(), "token-3")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.NoError(t, findErr)
+	assert.Equal(t, links[1].ID, found.ID)
+	assert.NoError(t, missingErr)
+	assert.Nil(t, missing)
+}
+
+func TestClaimLinkRepository_MarkClaimedOnce(t *testing.T) {
+	// Arrange
+	db := setupClaimLinkTestDB(t)
+	repo := NewClaimLinkRepository(db)
+	link := &entity.ClaimLink{VoucherID: 1, TokenID: "token-1", ExpiresAt: time.Now().Add(time.Hour)}
+	assert.NoError(t, repo.CreateBatch(context.Background(), []*entity.ClaimLink{link}))
+
+	// Act
+	first, err := repo.MarkClaimed(context.Background(), link.ID, 1, time.Now())
+	second, _ := repo.MarkClaimed(context.Background(), link.ID, 2, time.Now())
+	releaseErr := repo.Release(context.Background(), link.ID)
+	third, _ := repo.MarkClaimed(context.Background(), link.ID, 2, time.Now())
+
+	// Assert
+	assert.NoError(t, err)
+	assert.NoError(t, releaseErr)
+	assert.Equal(t, []int64{1, 0, 1}, []int64{first, second, third})
+	claimed, _ := repo.FindByTokenID(context.Background(), "token-1")
+	if assert.NotNil(t, claimed.CustomerID) {
+		assert.Equal(t, uint(2), *claimed.CustomerID)
+	}
+}
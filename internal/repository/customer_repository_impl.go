@@ -0,0 +1,216 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/shoelfikar/voucher-management-system/internal/domain/entity"
+	"github.com/shoelfikar/voucher-management-system/internal/domain/repository"
+	"github.com/shoelfikar/voucher-management-system/pkg/utils"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// customerRepositoryImpl implements domain repository.CustomerRepository
+type customerRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewCustomerRepository creates a new customer repository instance
+func NewCustomerRepository(db *gorm.DB) repository.CustomerRepository {
+	return &customerRepositoryImpl{db: db}
+}
+
+// FindAll retrieves customers with pagination, search, and sorting
+func (r *customerRepositoryImpl) FindAll(ctx context.Context, page, limit int, search string, sort []utils.SortField) ([]*entity.Customer, int64, error) {
+	var customers []*entity.Customer
+	var total int64
+
+	query := r.db.WithContext(ctx).Model(&entity.Customer{}).Where("merged_into_id IS NULL")
+	if search != "" {
+		pattern := "%" + search + "%"
+		query = query.Where("LOWER(external_id) LIKE LOWER(?) OR LOWER(email) LIKE LOWER(?) OR phone LIKE ?", pattern, pattern, pattern)
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	if len(sort) > 0 {
+		query = query.Order(utils.OrderClause(sort))
+	} else {
+		query = query.Order("id")
+	}
+
+	err := query.Offset((page - 1) * limit).Limit(limit).Find(&customers).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return customers, total, nil
+}
+
+// FindByID retrieves a customer by ID
+func (r *customerRepositoryImpl) FindByID(ctx context.Context, id uint) (*entity.Customer, error) {
+	var customer entity.Customer
+	if err := r.db.WithContext(ctx).First(&customer, id).Error; err != nil {
+		return nil, err
+	}
+	return &customer, nil
+}
+
+// FindByExternalID retrieves a customer by external ID
+func (r *customerRepositoryImpl) FindByExternalID(ctx context.Context, externalID string) (*entity.Customer, error) {
+	var customer entity.Customer
+	err := r.db.WithContext(ctx).Where("external_id = ?", externalID).First(&customer).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &customer, nil
+}
+
+// FindOrCreate inserts the customer unless the external ID exists, then reads
+// it back, so a concurrent insert of the same ID is not an error
+func (r *customerRepositoryImpl) FindOrCreate(ctx context.Context, externalID string) (*entity.Customer, error) {
+	err := r.db.WithContext(ctx).Clauses(clause.OnConflict{Columns: []clause.Column{{Name: "external_id"}}, DoNothing: true}).
+		Create(&entity.Customer{ExternalID: externalID}).Error
+	if err != nil {
+		return nil, err
+	}
+
+	var customer entity.Customer
+	if err := r.db.WithContext(ctx).Where("external_id = ?", externalID).First(&customer).Error; err != nil {
+		return nil, err
+	}
+	return &customer, nil
+}
+
+// Create creates a new customer
+func (r *customerRepositoryImpl) Create(ctx context.Context, customer *entity.Customer) error {
+	return r.db.WithContext(ctx).Create(customer).Error
+}
+
+// Update saves the editable fields of a customer and returns the number of rows affected
+func (r *customerRepositoryImpl) Update(ctx context.Context, customer *entity.Customer) (int64, error) {
+	result := r.db.WithContext(ctx).Model(customer).
+		Where("id = ?", customer.ID).
+		Select("external_id", "email", "phone").
+		Updates(customer)
+	return result.RowsAffected, result.Error
+}
+
+// Delete removes a customer and returns the number of rows affected
+func (r *customerRepositoryImpl) Delete(ctx context.Context, id uint) (int64, error) {
+	result := r.db.WithContext(ctx).Delete(&entity.Customer{}, id)
+	return result.RowsAffected, result.Error
+}
+
+// Merge moves everything recorded against the duplicate to the survivor.
+// Customers merged into the duplicate earlier are pointed at the survivor
+// too, so a lookup never has to follow more than one merge.
+func (r *customerRepositoryImpl) Merge(ctx context.Context, survivor *entity.Customer, duplicateID uint) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		survivorVouchers := tx.Model(&entity.VoucherAssignment{}).Select("voucher_id").Where("customer_id = ?", survivor.ID)
+		if err := tx.Where("customer_id = ? AND voucher_id IN (?)", duplicateID, survivorVouchers).
+			Delete(&entity.VoucherAssignment{}).Error; err != nil {
+			return err
+		}
+
+		for _, model := range []interface{}{&entity.VoucherAssignment{}, &entity.Redemption{}, &entity.ClaimLink{}} {
+			if err := tx.Model(model).Where("customer_id = ?", duplicateID).
+				Update("customer_id", survivor.ID).Error; err != nil {
+				return err
+			}
+		}
+
+		if err := tx.Model(&entity.Customer{}).
+			Where("id = ? OR merged_into_id = ?", duplicateID, duplicateID).
+			Update("merged_into_id", survivor.ID).Error; err != nil {
+			return err
+		}
+
+		return tx.Model(survivor).Select("email", "phone").Updates(survivor).Error
+	})
+}
+
+// Erase anonymizes the customer and its merged duplicates. SMS deliveries
+// name the customer by external ID, or only by phone number when sent
+// without one, so both are matched before they are cleared.
+func (r *customerRepositoryImpl) Erase(ctx context.Context, id uint, erasedAt time.Time, audit func(erasure *repository.CustomerErasure) (*entity.AuditEntry, error)) (*repository.CustomerErasure, error) {
+	erasure := &repository.CustomerErasure{}
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var customers []*entity.Customer
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("id = ? OR merged_into_id = ?", id, id).
+			Order("id").
+			Find(&customers).Error; err != nil {
+			return err
+		}
+		if len(customers) == 0 || customers[0].ID != id {
+			return gorm.ErrRecordNotFound
+		}
+
+		externalIDs := make([]string, 0, len(customers))
+		phones := []string{}
+		for _, customer := range customers {
+			externalIDs = append(externalIDs, customer.ExternalID)
+			if customer.Phone != "" {
+				phones = append(phones, customer.Phone)
+			}
+			if err := tx.Model(customer).Updates(map[string]interface{}{
+				"external_id": entity.ErasedExternalID(customer.ID),
+				"email":       "",
+				"phone":       "",
+				"erased_at":   erasedAt,
+			}).Error; err != nil {
+				return err
+			}
+		}
+		erasure.Customers = int64(len(customers))
+
+		// Duplicates' redemptions moved to the survivor when they were merged
+		result := tx.Model(&entity.Redemption{}).
+			Where("customer_id = ? AND order_id <> ''", id).
+			Update("order_id", "")
+		if result.Error != nil {
+			return result.Error
+		}
+		erasure.Redemptions = result.RowsAffected
+
+		deliveries := tx.Model(&entity.SMSDelivery{}).Where("customer_id IN ?", externalIDs)
+		if len(phones) > 0 {
+			deliveries = deliveries.Or("customer_id = '' AND phone_number IN ?", phones)
+		}
+		result = deliveries.Updates(map[string]interface{}{
+			"phone_number": "",
+			"customer_id":  "",
+			"body":         "",
+			"status": gorm.Expr("CASE WHEN status IN ? THEN ? ELSE status END",
+				[]string{entity.SMSDeliveryStatusPending, entity.SMSDeliveryStatusFailed}, entity.SMSDeliveryStatusDiscarded),
+		})
+		if result.Error != nil {
+			return result.Error
+		}
+		erasure.SMSDeliveries = result.RowsAffected
+
+		result = tx.Where("customer_id IN ?", externalIDs).Delete(&entity.SegmentMember{})
+		if result.Error != nil {
+			return result.Error
+		}
+		erasure.SegmentMembers = result.RowsAffected
+
+		entry, err := audit(erasure)
+		if err != nil {
+			return err
+		}
+		return tx.Create(entry).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return erasure, nil
+}
@@ -0,0 +1,191 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/shoelfikar/voucher-management-system/internal/domain/entity"
+	"github.com/shoelfikar/voucher-management-system/internal/domain/repository"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func setupCustomerTestDB(t *testing.T) *gorm.DB {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{TranslateError: true})
+	if err != nil {
+		t.Fatalf("Failed to connect to test database: %v", err)
+	}
+	if err := db.AutoMigrate(&entity.Customer{}, &entity.VoucherAssignment{}, &entity.Redemption{}, &entity.ClaimLink{}); err != nil {
+		t.Fatalf("Failed to migrate test database: %v", err)
+	}
+	return db
+}
+
+func TestCustomerRepository_FindOrCreate(t *testing.T) {
+	// Arrange
+	db := setupCustomerTestDB(t)
+	repo := NewCustomerRepository(db)
+
+	// Act
+	first, err := repo.FindOrCreate(context.Background(), "crm-1")
+	again, againErr := repo.FindOrCreate(context.Background(), "crm-1")
+	missing, missingErr := repo.FindByExternalID(context.Background(), "crm-2")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.NoError(t, againErr)
+	assert.NotZero(t, first.ID)
+	assert.Equal(t, first.ID, again.ID)
+	assert.NoError(t, missingErr)
+	assert.Nil(t, missing)
+}
+
+func TestCustomerRepository_FindAll_SkipsMerged(t *testing.T) {
+	// Arrange
+	db := setupCustomerTestDB(t)
+	repo := NewCustomerRepository(db)
+	survivor := &entity.Customer{ExternalID: "crm-1", Email: "ana@example.com"}
+	assert.NoError(t, repo.Create(context.Background(), survivor))
+	assert.NoError(t, repo.Create(context.Background(), &entity.Customer{ExternalID: "legacy-1", Email: "ana@old.example.com", MergedIntoID: &survivor.ID}))
+	assert.NoError(t, repo.Create(context.Background(), &entity.Customer{ExternalID: "crm-2", Email: "bo@example.com"}))
+
+	// Act
+	customers, total, err := repo.FindAll(context.Background(), 1, 10, "ANA", nil)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), total)
+	if assert.Len(t, customers, 1) {
+		assert.Equal(t, "crm-1", customers[0].ExternalID)
+	}
+}
+
+func TestCustomerRepository_Merge(t *testing.T) {
+	// Arrange
+	db := setupCustomerTestDB(t)
+	repo := NewCustomerRepository(db)
+	survivor := &entity.Customer{ExternalID: "crm-1"}
+	duplicate := &entity.Customer{ExternalID: "legacy-1", Phone: "+14155550123"}
+	earlier := &entity.Customer{ExternalID: "legacy-0"}
+	for _, customer := range []*entity.Customer{survivor, duplicate, earlier} {
+		assert.NoError(t, repo.Create(context.Background(), customer))
+	}
+	assert.NoError(t, db.Model(earlier).Update("merged_into_id", duplicate.ID).Error)
+
+	now := time.Now()
+	assignmentRepo := NewVoucherAssignmentRepository(db)
+	assert.NoError(t, assignmentRepo.Create(context.Background(), &entity.VoucherAssignment{VoucherID: 1, CustomerID: survivor.ID, AssignedAt: now, ExpiresAt: now}))
+	assert.NoError(t, assignmentRepo.Create(context.Background(), &entity.VoucherAssignment{VoucherID: 1, CustomerID: duplicate.ID, AssignedAt: now, ExpiresAt: now}))
+	assert.NoError(t, assignmentRepo.Create(context.Background(), &entity.VoucherAssignment{VoucherID: 2, CustomerID: duplicate.ID, AssignedAt: now, ExpiresAt: now}))
+	assert.NoError(t, db.Create(&entity.Redemption{VoucherID: 1, CustomerID: duplicate.ID, RedeemedAt: now}).Error)
+	link := &entity.ClaimLink{VoucherID: 2, TokenID: "token-1", ExpiresAt: now, CustomerID: &duplicate.ID}
+	assert.NoError(t, db.Create(link).Error)
+
+	// Act
+	survivor.Phone = duplicate.Phone
+	err := repo.Merge(context.Background(), survivor, duplicate.ID)
+
+	// Assert
+	assert.NoError(t, err)
+	var assignments []entity.VoucherAssignment
+	db.Order("voucher_id").Find(&assignments)
+	if assert.Len(t, assignments, 2) {
+		assert.Equal(t, []uint{survivor.ID, survivor.ID}, []uint{assignments[0].CustomerID, assignments[1].CustomerID})
+	}
+	var redemption entity.Redemption
+	db.First(&redemption)
+	assert.Equal(t, survivor.ID, redemption.CustomerID)
+	var claimed entity.ClaimLink
+	db.First(&claimed, link.ID)
+	assert.Equal(t, survivor.ID, *claimed.CustomerID)
+
+	merged, _ := repo.FindByExternalID(context.Background(), "legacy-1")
+	mergedEarlier, _ := repo.FindByExternalID(context.Background(), "legacy-0")
+	saved, _ := repo.FindByID(context.Background(), survivor.ID)
+	assert.Equal(t, survivor.ID, *merged.MergedIntoID)
+	assert.Equal(t, survivor.ID, *mergedEarlier.MergedIntoID)
+	assert.Equal(t, "+14155550123", saved.Phone)
+}
+
+func TestCustomerRepository_Erase(t *testing.T) {
+	// Arrange
+	db := setupCustomerTestDB(t)
+	assert.NoError(t, db.AutoMigrate(&entity.SMSDelivery{}, &entity.SegmentMember{}, &entity.AuditEntry{}))
+	repo := NewCustomerRepository(db)
+	now := time.Now()
+
+	customer := &entity.Customer{ExternalID: "crm-1", Email: "ana@example.com", Phone: "+14155550123"}
+	other := &entity.Customer{ExternalID: "crm-2", Email: "bo@example.com"}
+	assert.NoError(t, db.Create(customer).Error)
+	assert.NoError(t, db.Create(other).Error)
+	duplicate := &entity.Customer{ExternalID: "legacy-1", Phone: "+14155550199", MergedIntoID: &customer.ID}
+	assert.NoError(t, db.Create(duplicate).Error)
+
+	assert.NoError(t, db.Create(&[]entity.Redemption{
+		{VoucherID: 1, CustomerID: customer.ID, OrderID: "ORD-1", DiscountPercent: 10, RedeemedAt: now},
+		{VoucherID: 2, CustomerID: customer.ID, DiscountPercent: 10, RedeemedAt: now},
+		{VoucherID: 1, CustomerID: other.ID, OrderID: "ORD-2", DiscountPercent: 10, RedeemedAt: now},
+	}).Error)
+	assert.NoError(t, db.Create(&entity.VoucherAssignment{VoucherID: 1, CustomerID: customer.ID, AssignedAt: now, ExpiresAt: now}).Error)
+	assert.NoError(t, db.Create(&[]entity.SMSDelivery{
+		{VoucherID: 1, PhoneNumber: "+14155550123", CustomerID: "crm-1", Content: entity.SMSContentCode, Body: "Your code", Status: entity.SMSDeliveryStatusSent, NextAttemptAt: now},
+		{VoucherID: 1, PhoneNumber: "+14155550199", Content: entity.SMSContentCode, Body: "Your code", Status: entity.SMSDeliveryStatusPending, NextAttemptAt: now},
+		{VoucherID: 1, PhoneNumber: "+14155550100", CustomerID: "crm-2", Content: entity.SMSContentCode, Body: "Your code", Status: entity.SMSDeliveryStatusPending, NextAttemptAt: now},
+	}).Error)
+	assert.NoError(t, db.Create(&[]entity.SegmentMember{{SegmentID: 1, CustomerID: "crm-1"}, {SegmentID: 1, CustomerID: "legacy-1"}, {SegmentID: 1, CustomerID: "crm-2"}}).Error)
+
+	var audited *repository.CustomerErasure
+	audit := func(erasure *repository.CustomerErasure) (*entity.AuditEntry, error) {
+		audited = erasure
+		return &entity.AuditEntry{Action: entity.AuditActionCustomerErase, Actor: "admin@example.com", Details: "{}"}, nil
+	}
+
+	// Act
+	erasure, err := repo.Erase(context.Background(), customer.ID, now, audit)
+	_, missingErr := repo.Erase(context.Background(), duplicate.ID+1, now, audit)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, &repository.CustomerErasure{Customers: 2, Redemptions: 1, SMSDeliveries: 2, SegmentMembers: 2}, erasure)
+	assert.Equal(t, erasure, audited)
+	assert.ErrorIs(t, missingErr, gorm.ErrRecordNotFound)
+
+	var erased, erasedDuplicate, untouched entity.Customer
+	db.First(&erased, customer.ID)
+	db.First(&erasedDuplicate, duplicate.ID)
+	db.First(&untouched, other.ID)
+	assert.Equal(t, entity.ErasedExternalID(customer.ID), erased.ExternalID)
+	assert.Empty(t, erased.Email)
+	assert.Empty(t, erased.Phone)
+	assert.NotNil(t, erased.ErasedAt)
+	assert.Equal(t, entity.ErasedExternalID(duplicate.ID), erasedDuplicate.ExternalID)
+	assert.Empty(t, erasedDuplicate.Phone)
+	assert.Equal(t, "crm-2", untouched.ExternalID)
+	assert.Nil(t, untouched.ErasedAt)
+
+	var redemptions, assignments, members, entries int64
+	db.Model(&entity.Redemption{}).Where("customer_id = ?", customer.ID).Count(&redemptions)
+	db.Model(&entity.VoucherAssignment{}).Count(&assignments)
+	db.Model(&entity.SegmentMember{}).Count(&members)
+	db.Model(&entity.AuditEntry{}).Where("action = ?", entity.AuditActionCustomerErase).Count(&entries)
+	assert.Equal(t, int64(2), redemptions)
+	assert.Equal(t, int64(1), assignments)
+	assert.Equal(t, int64(1), members)
+	assert.Equal(t, int64(1), entries)
+
+	var orderIDs []string
+	db.Model(&entity.Redemption{}).Order("id").Pluck("order_id", &orderIDs)
+	assert.Equal(t, []string{"", "", "ORD-2"}, orderIDs)
+
+	var deliveries []entity.SMSDelivery
+	db.Order("id").Find(&deliveries)
+	assert.Equal(t, "", deliveries[0].PhoneNumber)
+	assert.Equal(t, "", deliveries[0].Body)
+	assert.Equal(t, entity.SMSDeliveryStatusSent, deliveries[0].Status)
+	assert.Equal(t, "", deliveries[1].PhoneNumber)
+	assert.Equal(t, entity.SMSDeliveryStatusDiscarded, deliveries[1].Status)
+	assert.Equal(t, "+14155550100", deliveries[2].PhoneNumber)
+	assert.Equal(t, entity.SMSDeliveryStatusPending, deliveries[2].Status)
+}
@@ -0,0 +1,51 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/shoelfikar/voucher-management-system/internal/domain/entity"
+	"github.com/shoelfikar/voucher-management-system/internal/domain/repository"
+	"gorm.io/gorm"
+)
+
+// discountLimitRepositoryImpl implements domain repository.DiscountLimitRepository
+type discountLimitRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewDiscountLimitRepository creates a new discount limit repository instance
+func NewDiscountLimitRepository(db *gorm.DB) repository.DiscountLimitRepository {
+	return &discountLimitRepositoryImpl{db: db}
+}
+
+// FindAll retrieves every configured limit
+func (r *discountLimitRepositoryImpl) FindAll(ctx context.Context) ([]*entity.DiscountLimit, error) {
+	var limits []*entity.DiscountLimit
+	err := r.db.WithContext(ctx).Order("role ASC").Find(&limits).Error
+	return limits, err
+}
+
+// FindByRole retrieves the limit of a role
+func (r *discountLimitRepositoryImpl) FindByRole(ctx context.Context, role string) (*entity.DiscountLimit, error) {
+	var limit entity.DiscountLimit
+	err := r.db.WithContext(ctx).Where("role = ?", role).First(&limit).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &limit, nil
+}
+
+// Save creates or replaces the limit of a role
+func (r *discountLimitRepositoryImpl) Save(ctx context.Context, limit *entity.DiscountLimit) error {
+	return r.db.WithContext(ctx).Save(limit).Error
+}
+
+// Delete removes the limit of a role
+func (r *discountLimitRepositoryImpl) Delete(ctx context.Context, role string) (int64, error) {
+	result := r.db.WithContext(ctx).Where("role = ?", role).Delete(&entity.DiscountLimit{})
+	return result.RowsAffected, result.Error
+}
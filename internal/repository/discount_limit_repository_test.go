@@ -0,0 +1,59 @@
+package repository
+
+import (
+	"context"
+	"testing"
+
+	"github.com/shoelfikar/voucher-management-system/internal/domain/entity"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func setupDiscountLimitTestDB(t *testing.T) *gorm.DB {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{TranslateError: true})
+	if err != nil {
+		t.Fatalf("Failed to connect to test database: %v", err)
+	}
+	if err := db.AutoMigrate(&entity.DiscountLimit{}); err != nil {
+		t.Fatalf("Failed to migrate test database: %v", err)
+	}
+	return db
+}
+
+func TestDiscountLimitRepository_SaveReplaces(t *testing.T) {
+	// Arrange
+	db := setupDiscountLimitTestDB(t)
+	repo := NewDiscountLimitRepository(db)
+
+	// Act
+	err := repo.Save(context.Background(), &entity.DiscountLimit{Role: entity.UserRoleMarketing, MaxDiscountPercent: 30})
+	replaceErr := repo.Save(context.Background(), &entity.DiscountLimit{Role: entity.UserRoleMarketing, MaxDiscountPercent: 25})
+	found, findErr := repo.FindByRole(context.Background(), entity.UserRoleMarketing)
+	missing, missingErr := repo.FindByRole(context.Background(), entity.UserRoleViewer)
+	all, _ := repo.FindAll(context.Background())
+
+	// Assert
+	assert.NoError(t, err)
+	assert.NoError(t, replaceErr)
+	assert.NoError(t, findErr)
+	assert.Equal(t, 25.0, found.MaxDiscountPercent)
+	assert.NoError(t, missingErr)
+	assert.Nil(t, missing)
+	assert.Len(t, all, 1)
+}
+
+func TestDiscountLimitRepository_Delete(t *testing.T) {
+	// Arrange
+	db := setupDiscountLimitTestDB(t)
+	repo := NewDiscountLimitRepository(db)
+	assert.NoError(t, repo.Save(context.Background(), &entity.DiscountLimit{Role: entity.UserRoleMarketing, MaxDiscountPercent: 30}))
+
+	// Act
+	deleted, err := repo.Delete(context.Background(), entity.UserRoleMarketing)
+	again, _ := repo.Delete(context.Background(), entity.UserRoleMarketing)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, []int64{1, 0}, []int64{deleted, again})
+}
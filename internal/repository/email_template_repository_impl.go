@@ -0,0 +1,60 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/shoelfikar/voucher-management-system/internal/domain/entity"
+	"github.com/shoelfikar/voucher-management-system/internal/domain/repository"
+	"gorm.io/gorm"
+)
+
+// emailTemplateRepositoryImpl implements domain repository.EmailTemplateRepository
+type emailTemplateRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewEmailTemplateRepository creates a new email template repository instance
+func NewEmailTemplateRepository(db *gorm.DB) repository.EmailTemplateRepository {
+	return &emailTemplateRepositoryImpl{db: db}
+}
+
+// FindLatest retrieves the highest version of a key
+func (r *emailTemplateRepositoryImpl) FindLatest(ctx context.Context, key string) (*entity.EmailTemplate, error) {
+	var template entity.EmailTemplate
+	err := r.db.WithContext(ctx).Where("key = ?", key).Order("version DESC").First(&template).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &template, nil
+}
+
+// FindVersions retrieves every version of a key, newest first
+func (r *emailTemplateRepositoryImpl) FindVersions(ctx context.Context, key string) ([]*entity.EmailTemplate, error) {
+	var templates []*entity.EmailTemplate
+	if err := r.db.WithContext(ctx).Where("key = ?", key).Order("version DESC").Find(&templates).Error; err != nil {
+		return nil, err
+	}
+	return templates, nil
+}
+
+// Create stores a template as the next version of its key. Two admins saving
+// at once both read the same latest version; the unique index on key and
+// version then rejects the second insert instead of letting it overwrite.
+func (r *emailTemplateRepositoryImpl) Create(ctx context.Context, template *entity.EmailTemplate) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var latest int
+		err := tx.Model(&entity.EmailTemplate{}).
+			Where("key = ?", template.Key).
+			Select("COALESCE(MAX(version), 0)").
+			Scan(&latest).Error
+		if err != nil {
+			return err
+		}
+		template.Version = latest + 1
+		return tx.Create(template).Error
+	})
+}
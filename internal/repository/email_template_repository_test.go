@@ -0,0 +1,49 @@
+package repository
+
+import (
+	"context"
+	"testing"
+
+	"github.com/shoelfikar/voucher-management-system/internal/domain/entity"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func setupEmailTemplateTestDB(t *testing.T) *gorm.DB {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{TranslateError: true})
+	if err != nil {
+		t.Fatalf("Failed to connect to test database: %v", err)
+	}
+	if err := db.AutoMigrate(&entity.EmailTemplate{}); err != nil {
+		t.Fatalf("Failed to migrate test database: %v", err)
+	}
+	return db
+}
+
+func TestEmailTemplateRepository_CreateAddsVersions(t *testing.T) {
+	// Arrange
+	db := setupEmailTemplateTestDB(t)
+	repo := NewEmailTemplateRepository(db)
+	first := &entity.EmailTemplate{Key: entity.EmailTemplateInvite, Subject: "Welcome", Body: "v1"}
+	second := &entity.EmailTemplate{Key: entity.EmailTemplateInvite, Subject: "Welcome", Body: "v2"}
+	other := &entity.EmailTemplate{Key: entity.EmailTemplateVerifyEmail, Subject: "Verify", Body: "v1"}
+
+	// Act
+	assert.NoError(t, repo.Create(context.Background(), first))
+	assert.NoError(t, repo.Create(context.Background(), second))
+	assert.NoError(t, repo.Create(context.Background(), other))
+	latest, latestErr := repo.FindLatest(context.Background(), entity.EmailTemplateInvite)
+	versions, versionsErr := repo.FindVersions(context.Background(), entity.EmailTemplateInvite)
+	missing, missingErr := repo.FindLatest(context.Background(), entity.EmailTemplateExpiryDigest)
+
+	// Assert
+	assert.Equal(t, []int{1, 2, 1}, []int{first.Version, second.Version, other.Version})
+	assert.NoError(t, latestErr)
+	assert.Equal(t, "v2", latest.Body)
+	assert.NoError(t, versionsErr)
+	assert.Len(t, versions, 2)
+	assert.Equal(t, 2, versions[0].Version)
+	assert.NoError(t, missingErr)
+	assert.Nil(t, missing)
+}
@@ -0,0 +1,235 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/shoelfikar/voucher-management-system/internal/domain/entity"
+	"github.com/shoelfikar/voucher-management-system/internal/domain/repository"
+	"github.com/shoelfikar/voucher-management-system/pkg/fieldcrypt"
+	"github.com/shoelfikar/voucher-management-system/pkg/utils"
+)
+
+// encryptedVoucherRepository encrypts the values of sensitive metadata keys
+// before they reach the database and decrypts them on the way out, so the
+// service and API layers only ever see plaintext. Other metadata keys and
+// voucher columns are stored as they are.
+type encryptedVoucherRepository struct {
+	repository.VoucherRepository
+
+	cipher        *fieldcrypt.Cipher
+	sensitiveKeys map[string]bool
+}
+
+// NewEncryptedVoucherRepository wraps a voucher repository with metadata field encryption
+func NewEncryptedVoucherRepository(inner repository.VoucherRepository, cipher *fieldcrypt.Cipher, sensitiveKeys []string) repository.VoucherRepository {
+	keys := make(map[string]bool, len(sensitiveKeys))
+	for _, key := range sensitiveKeys {
+		keys[key] = true
+	}
+
+	return &encryptedVoucherRepository{
+		VoucherRepository: inner,
+		cipher:            cipher,
+		sensitiveKeys:     keys,
+	}
+}
+
+// FindAll retrieves vouchers and decrypts their metadata
+func (r *encryptedVoucherRepository) FindAll(ctx context.Context, page, limit int, search string, sort []utils.SortField) ([]*entity.Voucher, int64, error) {
+	vouchers, total, err := r.VoucherRepository.FindAll(ctx, page, limit, search, sort)
+	if err != nil {
+		return nil, 0, err
+	}
+	if err := r.decryptAll(vouchers); err != nil {
+		return nil, 0, err
+	}
+	return vouchers, total, nil
+}
+
+// FindAllByPartner retrieves a partner's vouchers and decrypts their metadata
+func (r *encryptedVoucherRepository) FindAllByPartner(ctx context.Context, partnerID uint, page, limit int, search string, sort []utils.SortField) ([]*entity.Voucher, int64, error) {
+	vouchers, total, err := r.VoucherRepository.FindAllByPartner(ctx, partnerID, page, limit, search, sort)
+	if err != nil {
+		return nil, 0, err
+	}
+	if err := r.decryptAll(vouchers); err != nil {
+		return nil, 0, err
+	}
+	return vouchers, total, nil
+}
+
+// FindChangedSince retrieves changed vouchers and decrypts their metadata
+func (r *encryptedVoucherRepository) FindChangedSince(ctx context.Context, since time.Time, afterID uint, limit int) ([]*entity.Voucher, error) {
+	vouchers, err := r.VoucherRepository.FindChangedSince(ctx, since, afterID, limit)
+	if err != nil {
+		return nil, err
+	}
+	if err := r.decryptAll(vouchers); err != nil {
+		return nil, err
+	}
+	return vouchers, nil
+}
+
+// FindByID retrieves a voucher and decrypts its metadata
+func (r *encryptedVoucherRepository) FindByID(ctx context.Context, id uint) (*entity.Voucher, error) {
+	return r.decryptOne(r.VoucherRepository.FindByID(ctx, id))
+}
+
+// FindByIDs retrieves vouchers by ID and decrypts their metadata
+func (r *encryptedVoucherRepository) FindByIDs(ctx context.Context, ids []uint) ([]*entity.Voucher, error) {
+	vouchers, err := r.VoucherRepository.FindByIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	if err := r.decryptAll(vouchers); err != nil {
+		return nil, err
+	}
+	return vouchers, nil
+}
+
+// FindByVoucherCodes retrieves vouchers by code and decrypts their metadata
+func (r *encryptedVoucherRepository) FindByVoucherCodes(ctx context.Context, codes []string) ([]*entity.Voucher, error) {
+	vouchers, err := r.VoucherRepository.FindByVoucherCodes(ctx, codes)
+	if err != nil {
+		return nil, err
+	}
+	if err := r.decryptAll(vouchers); err != nil {
+		return nil, err
+	}
+	return vouchers, nil
+}
+
+// FindByVoucherCode retrieves a voucher and decrypts its metadata
+func (r *encryptedVoucherRepository) FindByVoucherCode(ctx context.Context, code string) (*entity.Voucher, error) {
+	return r.decryptOne(r.VoucherRepository.FindByVoucherCode(ctx, code))
+}
+
+// FindByExternalID retrieves a voucher and decrypts its metadata
+func (r *encryptedVoucherRepository) FindByExternalID(ctx context.Context, externalID string) (*entity.Voucher, error) {
+	return r.decryptOne(r.VoucherRepository.FindByExternalID(ctx, externalID))
+}
+
+// Create encrypts sensitive metadata and creates the voucher
+func (r *encryptedVoucherRepository) Create(ctx context.Context, voucher *entity.Voucher) error {
+	restore, err := r.encrypt([]*entity.Voucher{voucher})
+	if err != nil {
+		return err
+	}
+	defer restore()
+
+	return r.VoucherRepository.Create(ctx, voucher)
+}
+
+// Update encrypts sensitive metadata and updates the voucher
+func (r *encryptedVoucherRepository) Update(ctx context.Context, voucher *entity.Voucher) (int64, error) {
+	restore, err := r.encrypt([]*entity.Voucher{voucher})
+	if err != nil {
+		return 0, err
+	}
+	defer restore()
+
+	return r.VoucherRepository.Update(ctx, voucher)
+}
+
+// BulkCreate encrypts sensitive metadata and creates the vouchers
+func (r *encryptedVoucherRepository) BulkCreate(ctx context.Context, vouchers []*entity.Voucher) error {
+	restore, err := r.encrypt(vouchers)
+	if err != nil {
+		return err
+	}
+	defer restore()
+
+	return r.VoucherRepository.BulkCreate(ctx, vouchers)
+}
+
+// BulkCreateSkipConflicts encrypts sensitive metadata and creates the vouchers that do not conflict
+func (r *encryptedVoucherRepository) BulkCreateSkipConflicts(ctx context.Context, vouchers []*entity.Voucher) ([]string, error) {
+	restore, err := r.encrypt(vouchers)
+	if err != nil {
+		return nil, err
+	}
+	defer restore()
+
+	return r.VoucherRepository.BulkCreateSkipConflicts(ctx, vouchers)
+}
+
+// encrypt replaces the metadata of each voucher with its encrypted form and
+// returns a function that puts the plaintext back once the write is done,
+// since callers keep using the entity after it is saved
+func (r *encryptedVoucherRepository) encrypt(vouchers []*entity.Voucher) (func(), error) {
+	plaintexts := make([]string, len(vouchers))
+	restore := func() {
+		for i, voucher := range vouchers {
+			voucher.Metadata = plaintexts[i]
+		}
+	}
+
+	for i, voucher := range vouchers {
+		plaintexts[i] = voucher.Metadata
+		encrypted, err := r.transform(voucher.Metadata, r.cipher.Encrypt)
+		if err != nil {
+			restore()
+			return nil, fmt.Errorf("failed to encrypt metadata of voucher %s: %w", voucher.VoucherCode, err)
+		}
+		voucher.Metadata = encrypted
+	}
+	return restore, nil
+}
+
+func (r *encryptedVoucherRepository) decryptOne(voucher *entity.Voucher, err error) (*entity.Voucher, error) {
+	if err != nil || voucher == nil {
+		return voucher, err
+	}
+	if err := r.decryptAll([]*entity.Voucher{voucher}); err != nil {
+		return nil, err
+	}
+	return voucher, nil
+}
+
+func (r *encryptedVoucherRepository) decryptAll(vouchers []*entity.Voucher) error {
+	for _, voucher := range vouchers {
+		decrypted, err := r.transform(voucher.Metadata, r.cipher.Decrypt)
+		if err != nil {
+			return fmt.Errorf("failed to decrypt metadata of voucher %s: %w", voucher.VoucherCode, err)
+		}
+		voucher.Metadata = decrypted
+	}
+	return nil
+}
+
+// transform applies fn to the values of sensitive keys in serialized metadata
+func (r *encryptedVoucherRepository) transform(metadata string, fn func(string) (string, error)) (string, error) {
+	if metadata == "" || len(r.sensitiveKeys) == 0 {
+		return metadata, nil
+	}
+
+	var values map[string]string
+	if err := json.Unmarshal([]byte(metadata), &values); err != nil {
+		return "", err
+	}
+
+	changed := false
+	for key, value := range values {
+		if !r.sensitiveKeys[key] {
+			continue
+		}
+		transformed, err := fn(value)
+		if err != nil {
+			return "", err
+		}
+		values[key] = transformed
+		changed = true
+	}
+	if !changed {
+		return metadata, nil
+	}
+
+	data, err := json.Marshal(values)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
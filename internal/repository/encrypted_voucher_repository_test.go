@@ -0,0 +1,103 @@
+package repository
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/shoelfikar/voucher-management-system/internal/domain/entity"
+	"github.com/shoelfikar/voucher-management-system/pkg/fieldcrypt"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestCipher(t *testing.T) *fieldcrypt.Cipher {
+	cipher, err := fieldcrypt.NewCipher(bytes.Repeat([]byte{7}, fieldcrypt.KeySize))
+	if err != nil {
+		t.Fatalf("Failed to create cipher: %v", err)
+	}
+	return cipher
+}
+
+func TestEncryptedVoucherRepository_Create_StoresSensitiveKeysEncrypted(t *testing.T) {
+	// Arrange
+	db := setupVoucherTestDB(t)
+	repo := NewEncryptedVoucherRepository(NewVoucherRepository(db), newTestCipher(t), []string{"partner_account"})
+
+	voucher := createTestVoucher("SECRET1", 10.0)
+	voucher.Metadata = `{"channel":"email","partner_account":"ACC-123"}`
+
+	// Act
+	err := repo.Create(context.Background(), voucher)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, `{"channel":"email","partner_account":"ACC-123"}`, voucher.Metadata)
+
+	var stored entity.Voucher
+	db.First(&stored, voucher.ID)
+	var raw map[string]string
+	assert.NoError(t, json.Unmarshal([]byte(stored.Metadata), &raw))
+	assert.Equal(t, "email", raw["channel"])
+	assert.True(t, fieldcrypt.IsEncrypted(raw["partner_account"]))
+	assert.NotContains(t, stored.Metadata, "ACC-123")
+}
+
+func TestEncryptedVoucherRepository_FindByID_DecryptsMetadata(t *testing.T) {
+	// Arrange
+	db := setupVoucherTestDB(t)
+	repo := NewEncryptedVoucherRepository(NewVoucherRepository(db), newTestCipher(t), []string{"partner_account"})
+
+	voucher := createTestVoucher("SECRET2", 10.0)
+	voucher.Metadata = `{"partner_account":"ACC-456"}`
+	assert.NoError(t, repo.Create(context.Background(), voucher))
+
+	// Act
+	found, err := repo.FindByID(context.Background(), voucher.ID)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"partner_account":"ACC-456"}`, found.Metadata)
+}
+
+func TestEncryptedVoucherRepository_Update_KeepsPlaintextOnEntity(t *testing.T) {
+	// Arrange
+	db := setupVoucherTestDB(t)
+	repo := NewEncryptedVoucherRepository(NewVoucherRepository(db), newTestCipher(t), []string{"partner_account"})
+
+	voucher := createTestVoucher("SECRET3", 10.0)
+	assert.NoError(t, repo.Create(context.Background(), voucher))
+	voucher.Metadata = `{"partner_account":"ACC-789"}`
+
+	// Act
+	rows, err := repo.Update(context.Background(), voucher)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), rows)
+	assert.Equal(t, `{"partner_account":"ACC-789"}`, voucher.Metadata)
+
+	found, err := repo.FindByVoucherCode(context.Background(), "SECRET3")
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"partner_account":"ACC-789"}`, found.Metadata)
+}
+
+func TestEncryptedVoucherRepository_FindAll_ReadsPlaintextRows(t *testing.T) {
+	// Arrange
+	db := setupVoucherTestDB(t)
+	inner := NewVoucherRepository(db)
+	repo := NewEncryptedVoucherRepository(inner, newTestCipher(t), []string{"partner_account"})
+
+	// Rows written before encryption was enabled
+	voucher := createTestVoucher("LEGACY1", 10.0)
+	voucher.Metadata = `{"partner_account":"ACC-000"}`
+	assert.NoError(t, inner.Create(context.Background(), voucher))
+
+	// Act
+	vouchers, total, err := repo.FindAll(context.Background(), 1, 10, "", nil)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), total)
+	assert.JSONEq(t, `{"partner_account":"ACC-000"}`, vouchers[0].Metadata)
+}
@@ -0,0 +1,84 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/shoelfikar/voucher-management-system/internal/domain/entity"
+	"github.com/shoelfikar/voucher-management-system/internal/domain/repository"
+	"github.com/shoelfikar/voucher-management-system/pkg/utils"
+	"gorm.io/gorm"
+)
+
+// importRuleRepositoryImpl implements domain repository.ImportRuleRepository
+type importRuleRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewImportRuleRepository creates a new import rule repository instance
+func NewImportRuleRepository(db *gorm.DB) repository.ImportRuleRepository {
+	return &importRuleRepositoryImpl{db: db}
+}
+
+// FindAll retrieves rules with pagination, search by name, and sorting
+func (r *importRuleRepositoryImpl) FindAll(ctx context.Context, page, limit int, search string, sort []utils.SortField) ([]*entity.ImportRule, int64, error) {
+	var rules []*entity.ImportRule
+	var total int64
+
+	query := r.db.WithContext(ctx).Model(&entity.ImportRule{})
+	if search != "" {
+		query = query.Where("LOWER(name) LIKE LOWER(?)", "%"+search+"%")
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	if len(sort) > 0 {
+		query = query.Order(utils.OrderClause(sort))
+	} else {
+		query = query.Order("id")
+	}
+
+	err := query.Offset((page - 1) * limit).Limit(limit).Find(&rules).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return rules, total, nil
+}
+
+// FindEnabled retrieves the enabled rules ordered by ID
+func (r *importRuleRepositoryImpl) FindEnabled(ctx context.Context) ([]*entity.ImportRule, error) {
+	var rules []*entity.ImportRule
+	err := r.db.WithContext(ctx).Where("enabled = ?", true).Order("id").Find(&rules).Error
+	return rules, err
+}
+
+// FindByID retrieves a rule by ID
+func (r *importRuleRepositoryImpl) FindByID(ctx context.Context, id uint) (*entity.ImportRule, error) {
+	var rule entity.ImportRule
+	if err := r.db.WithContext(ctx).First(&rule, id).Error; err != nil {
+		return nil, err
+	}
+	return &rule, nil
+}
+
+// Create creates a new rule
+func (r *importRuleRepositoryImpl) Create(ctx context.Context, rule *entity.ImportRule) error {
+	return r.db.WithContext(ctx).Create(rule).Error
+}
+
+// Update saves the editable fields of a rule and returns the number of rows affected
+func (r *importRuleRepositoryImpl) Update(ctx context.Context, rule *entity.ImportRule) (int64, error) {
+	result := r.db.WithContext(ctx).Model(rule).
+		Where("id = ?", rule.ID).
+		Select("name", "type", "value", "message", "enabled").
+		Updates(rule)
+	return result.RowsAffected, result.Error
+}
+
+// Delete removes a rule and returns the number of rows affected
+func (r *importRuleRepositoryImpl) Delete(ctx context.Context, id uint) (int64, error) {
+	result := r.db.WithContext(ctx).Delete(&entity.ImportRule{}, id)
+	return result.RowsAffected, result.Error
+}
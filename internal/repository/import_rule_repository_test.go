@@ -0,0 +1,49 @@
+package repository
+
+import (
+	"context"
+	"testing"
+
+	"github.com/shoelfikar/voucher-management-system/internal/domain/entity"
+	"github.com/shoelfikar/voucher-management-system/pkg/utils"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func setupImportRuleTestDB(t *testing.T) *gorm.DB {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("Failed to connect to test database: %v", err)
+	}
+	if err := db.AutoMigrate(&entity.ImportRule{}); err != nil {
+		t.Fatalf("Failed to migrate test database: %v", err)
+	}
+	return db
+}
+
+func TestImportRuleRepository_FindAll_PaginatesAndSearches(t *testing.T) {
+	// Arrange
+	db := setupImportRuleTestDB(t)
+	repo := NewImportRuleRepository(db)
+	for _, name := range []string{"Summer prefix", "Discount cap", "Summer cap"} {
+		assert.NoError(t, repo.Create(context.Background(), &entity.ImportRule{Name: name, Type: entity.ImportRuleMaxDiscount, Value: "50", Enabled: true}))
+	}
+
+	// Act
+	firstPage, total, err := repo.FindAll(context.Background(), 1, 2, "", nil)
+	searched, searchedTotal, searchErr := repo.FindAll(context.Background(), 1, 10, "SUMMER", []utils.SortField{{Field: "name", Desc: true}})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), total)
+	if assert.Len(t, firstPage, 2) {
+		assert.Equal(t, "Summer prefix", firstPage[0].Name)
+	}
+	assert.NoError(t, searchErr)
+	assert.Equal(t, int64(2), searchedTotal)
+	if assert.Len(t, searched, 2) {
+		assert.Equal(t, "Summer prefix", searched[0].Name)
+		assert.Equal(t, "Summer cap", searched[1].Name)
+	}
+}
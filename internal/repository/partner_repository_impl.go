@@ -0,0 +1,120 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/shoelfikar/voucher-management-system/internal/domain/entity"
+	"github.com/shoelfikar/voucher-management-system/internal/domain/repository"
+	"github.com/shoelfikar/voucher-management-system/pkg/utils"
+	"gorm.io/gorm"
+)
+
+// partnerRepositoryImpl implements domain repository.PartnerRepository
+type partnerRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewPartnerRepository creates a new partner repository instance
+func NewPartnerRepository(db *gorm.DB) repository.PartnerRepository {
+	return &partnerRepositoryImpl{db: db}
+}
+
+// FindAll retrieves partners with pagination, search by name, and sorting
+func (r *partnerRepositoryImpl) FindAll(ctx context.Context, page, limit int, search string, sort []utils.SortField) ([]*entity.Partner, int64, error) {
+	var partners []*entity.Partner
+	var total int64
+
+	query := r.db.WithContext(ctx).Model(&entity.Partner{})
+	if search != "" {
+		query = query.Where("LOWER(name) LIKE LOWER(?)", "%"+search+"%")
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	if len(sort) > 0 {
+		query = query.Order(utils.OrderClause(sort))
+	} else {
+		query = query.Order("id")
+	}
+
+	err := query.Offset((page - 1) * limit).Limit(limit).Find(&partners).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return partners, total, nil
+}
+
+// FindByID retrieves a partner by ID
+func (r *partnerRepositoryImpl) FindByID(ctx context.Context, id uint) (*entity.Partner, error) {
+	var partner entity.Partner
+	if err := r.db.WithContext(ctx).First(&partner, id).Error; err != nil {
+		return nil, err
+	}
+	return &partner, nil
+}
+
+// FindByName retrieves a partner by name
+func (r *partnerRepositoryImpl) FindByName(ctx context.Context, name string) (*entity.Partner, error) {
+	return r.findOne(ctx, "name = ?", name)
+}
+
+// FindByAPIKeyHash retrieves the partner owning an API key hash
+func (r *partnerRepositoryImpl) FindByAPIKeyHash(ctx context.Context, hash string) (*entity.Partner, error) {
+	return r.findOne(ctx, "api_key_hash = ?", hash)
+}
+
+// findOne returns the first partner matching the condition, or nil, nil when none does
+func (r *partnerRepositoryImpl) findOne(ctx context.Context, condition string, value string) (*entity.Partner, error) {
+	var partner entity.Partner
+	err := r.db.WithContext(ctx).Where(condition, value).First(&partner).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &partner, nil
+}
+
+// Create creates a new partner
+func (r *partnerRepositoryImpl) Create(ctx context.Context, partner *entity.Partner) error {
+	return r.db.WithContext(ctx).Create(partner).Error
+}
+
+// Update saves the editable fields of a partner; the issued count and API key are left alone
+func (r *partnerRepositoryImpl) Update(ctx context.Context, partner *entity.Partner) (int64, error) {
+	result := r.db.WithContext(ctx).Model(partner).
+		Where("id = ?", partner.ID).
+		Select("name", "quota", "rate_limit", "active").
+		Updates(partner)
+	return result.RowsAffected, result.Error
+}
+
+// UpdateAPIKey replaces the API key hash and prefix of a partner
+func (r *partnerRepositoryImpl) UpdateAPIKey(ctx context.Context, id uint, hash, prefix string) (int64, error) {
+	result := r.db.WithContext(ctx).Model(&entity.Partner{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{"api_key_hash": hash, "key_prefix": prefix})
+	return result.RowsAffected, result.Error
+}
+
+// ReserveQuota raises the issued count in a single conditional UPDATE, so
+// concurrent requests cannot together issue more than the quota
+func (r *partnerRepositoryImpl) ReserveQuota(ctx context.Context, id uint, n int64) (bool, error) {
+	result := r.db.WithContext(ctx).Model(&entity.Partner{}).
+		Where("id = ? AND issued_count + ? <= quota", id, n).
+		UpdateColumn("issued_count", gorm.Expr("issued_count + ?", n))
+	return result.RowsAffected > 0, result.Error
+}
+
+// ReleaseQuota lowers the issued count, never below zero
+func (r *partnerRepositoryImpl) ReleaseQuota(ctx context.Context, id uint, n int64) error {
+	return r.db.WithContext(ctx).Model(&entity.Partner{}).
+		Where("id = ?", id).
+		UpdateColumn("issued_count", gorm.Expr("CASE WHEN issued_count > ? THEN issued_count - ? ELSE 0 END", n, n)).
+		Error
+}
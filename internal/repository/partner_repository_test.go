@@ -0,0 +1,161 @@
+package repository
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/shoelfikar/voucher-management-system/internal/domain/entity"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func setupPartnerTestDB(t *testing.T) *gorm.DB {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("Failed to connect to test database: %v", err)
+	}
+	// Each connection to :memory: is a separate database, so the concurrent
+	// quota test must share one
+	sqlDB, _ := db.DB()
+	sqlDB.SetMaxOpenConns(1)
+	if err := db.AutoMigrate(&entity.Partner{}, &entity.Voucher{}); err != nil {
+		t.Fatalf("Failed to migrate test database: %v", err)
+	}
+	return db
+}
+
+func TestPartnerRepository_FindByAPIKeyHash(t *testing.T) {
+	// Arrange
+	db := setupPartnerTestDB(t)
+	repo := NewPartnerRepository(db)
+	partner := &entity.Partner{Name: "Acme", APIKeyHash: "hash-1", KeyPrefix: "vpk_12345678", Quota: 10, Active: true}
+	assert.NoError(t, repo.Create(context.Background(), partner))
+
+	// Act
+	found, err := repo.FindByAPIKeyHash(context.Background(), "hash-1")
+	missing, missingErr := repo.FindByAPIKeyHash(context.Background(), "hash-2")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, partner.ID, found.ID)
+	assert.NoError(t, missingErr)
+	assert.Nil(t, missing)
+}
+
+func TestPartnerRepository_UpdateAPIKey(t *testing.T) {
+	// Arrange
+	db := setupPartnerTestDB(t)
+	repo := NewPartnerRepository(db)
+	partner := &entity.Partner{Name: "Acme", APIKeyHash: "hash-1", KeyPrefix: "vpk_11111111", Quota: 10, Active: true}
+	assert.NoError(t, repo.Create(context.Background(), partner))
+
+	// Act
+	rows, err := repo.UpdateAPIKey(context.Background(), partner.ID, "hash-2", "vpk_22222222")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), rows)
+	old, _ := repo.FindByAPIKeyHash(context.Background(), "hash-1")
+	assert.Nil(t, old)
+	stored, _ := repo.FindByID(context.Background(), partner.ID)
+	assert.Equal(t, "hash-2", stored.APIKeyHash)
+	assert.Equal(t, "vpk_22222222", stored.KeyPrefix)
+}
+
+func TestPartnerRepository_ReserveQuota_StopsAtQuota(t *testing.T) {
+	// Arrange
+	db := setupPartnerTestDB(t)
+	repo := NewPartnerRepository(db)
+	partner := &entity.Partner{Name: "Acme", APIKeyHash: "hash-1", KeyPrefix: "vpk_12345678", Quota: 5, Active: true}
+	assert.NoError(t, repo.Create(context.Background(), partner))
+
+	// Act
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	reserved := 0
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			ok, err := repo.ReserveQuota(context.Background(), partner.ID, 1)
+			assert.NoError(t, err)
+			if ok {
+				mu.Lock()
+				reserved++
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	// Assert
+	assert.Equal(t, 5, reserved)
+	stored, _ := repo.FindByID(context.Background(), partner.ID)
+	assert.Equal(t, int64(5), stored.IssuedCount)
+}
+
+func TestPartnerRepository_ReleaseQuota(t *testing.T) {
+	// Arrange
+	db := setupPartnerTestDB(t)
+	repo := NewPartnerRepository(db)
+	partner := &entity.Partner{Name: "Acme", APIKeyHash: "hash-1", KeyPrefix: "vpk_12345678", Quota: 1, Active: true}
+	assert.NoError(t, repo.Create(context.Background(), partner))
+	ok, _ := repo.ReserveQuota(context.Background(), partner.ID, 1)
+	assert.True(t, ok)
+
+	// Act
+	err := repo.ReleaseQuota(context.Background(), partner.ID, 1)
+	again, againErr := repo.ReserveQuota(context.Background(), partner.ID, 1)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.NoError(t, againErr)
+	assert.True(t, again)
+}
+
+func TestPartnerRepository_Update_KeepsIssuedCountAndKey(t *testing.T) {
+	// Arrange
+	db := setupPartnerTestDB(t)
+	repo := NewPartnerRepository(db)
+	partner := &entity.Partner{Name: "Acme", APIKeyHash: "hash-1", KeyPrefix: "vpk_12345678", Quota: 5, Active: true}
+	assert.NoError(t, repo.Create(context.Background(), partner))
+	_, _ = repo.ReserveQuota(context.Background(), partner.ID, 2)
+
+	// Act
+	rows, err := repo.Update(context.Background(), &entity.Partner{ID: partner.ID, Name: "Acme Resellers", Quota: 1, RateLimit: 30, Active: false})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), rows)
+	stored, _ := repo.FindByID(context.Background(), partner.ID)
+	assert.Equal(t, "Acme Resellers", stored.Name)
+	assert.Equal(t, int64(1), stored.Quota)
+	assert.Equal(t, 30, stored.RateLimit)
+	assert.False(t, stored.Active)
+	assert.Equal(t, int64(2), stored.IssuedCount)
+	assert.Equal(t, "hash-1", stored.APIKeyHash)
+}
+
+func TestVoucherRepository_FindAllByPartner(t *testing.T) {
+	// Arrange
+	db := setupPartnerTestDB(t)
+	repo := NewVoucherRepository(db)
+	partnerID := uint(1)
+	otherID := uint(2)
+	expiry := time.Now().AddDate(0, 1, 0)
+	assert.NoError(t, repo.Create(context.Background(), &entity.Voucher{VoucherCode: "ACME1", DiscountPercent: 10, ExpiryDate: expiry, Status: entity.VoucherStatusActive, PartnerID: &partnerID}))
+	assert.NoError(t, repo.Create(context.Background(), &entity.Voucher{VoucherCode: "OTHER1", DiscountPercent: 10, ExpiryDate: expiry, Status: entity.VoucherStatusActive, PartnerID: &otherID}))
+	assert.NoError(t, repo.Create(context.Background(), &entity.Voucher{VoucherCode: "ADMIN1", DiscountPercent: 10, ExpiryDate: expiry, Status: entity.VoucherStatusActive}))
+
+	// Act
+	vouchers, total, err := repo.FindAllByPartner(context.Background(), partnerID, 1, 10, "", nil)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), total)
+	assert.Len(t, vouchers, 1)
+	assert.Equal(t, "ACME1", vouchers[0].VoucherCode)
+}
@@ -0,0 +1,52 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/shoelfikar/voucher-management-system/internal/domain/entity"
+	"github.com/shoelfikar/voucher-management-system/internal/domain/repository"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// redemptionRepositoryImpl implements domain repository.RedemptionRepository
+type redemptionRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewRedemptionRepository creates a new redemption repository instance
+func NewRedemptionRepository(db *gorm.DB) repository.RedemptionRepository {
+	return &redemptionRepositoryImpl{db: db}
+}
+
+// Create locks the voucher with SELECT ... FOR UPDATE, lets check inspect
+// its status, expiry, limits and the customer's earlier redemptions, then
+// stores the new one and bumps the count in the same transaction. SQLite has
+// no row locks, but only runs one write transaction at a time anyway.
+func (r *redemptionRepositoryImpl) Create(ctx context.Context, redemption *entity.Redemption, check func(voucher *entity.Voucher, customerRedemptions int64) error) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var voucher entity.Voucher
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Select("id", "status", "expiry_date", "max_redemptions", "max_redemptions_per_user", "redemption_count").
+			First(&voucher, redemption.VoucherID).Error; err != nil {
+			return err
+		}
+
+		var customerRedemptions int64
+		if err := tx.Model(&entity.Redemption{}).
+			Where("voucher_id = ? AND customer_id = ?", redemption.VoucherID, redemption.CustomerID).
+			Count(&customerRedemptions).Error; err != nil {
+			return err
+		}
+		if err := check(&voucher, customerRedemptions); err != nil {
+			return err
+		}
+
+		if err := tx.Create(redemption).Error; err != nil {
+			return err
+		}
+		return tx.Model(&entity.Voucher{}).
+			Where("id = ?", redemption.VoucherID).
+			UpdateColumn("redemption_count", gorm.Expr("redemption_count + 1")).Error
+	})
+}
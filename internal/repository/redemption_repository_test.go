@@ -0,0 +1,68 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/shoelfikar/voucher-management-system/internal/domain/entity"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func setupRedemptionTestDB(t *testing.T) *gorm.DB {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{TranslateError: true})
+	if err != nil {
+		t.Fatalf("Failed to connect to test database: %v", err)
+	}
+	if err := db.AutoMigrate(&entity.Voucher{}, &entity.Redemption{}); err != nil {
+		t.Fatalf("Failed to migrate test database: %v", err)
+	}
+	return db
+}
+
+func TestRedemptionRepository_Create(t *testing.T) {
+	// Arrange
+	db := setupRedemptionTestDB(t)
+	repo := NewRedemptionRepository(db)
+	maxRedemptions := 5
+	voucher := &entity.Voucher{VoucherCode: "SUMMER24", DiscountPercent: 10, ExpiryDate: time.Now().AddDate(0, 1, 0), Status: entity.VoucherStatusActive, MaxRedemptions: &maxRedemptions}
+	assert.NoError(t, db.Create(voucher).Error)
+	now := time.Now()
+
+	var seen []int64
+	var seenVoucher *entity.Voucher
+	record := func(v *entity.Voucher, customerRedemptions int64) error {
+		seenVoucher = v
+		seen = append(seen, customerRedemptions)
+		return nil
+	}
+	errLimit := errors.New("limit reached")
+
+	// Act
+	err := repo.Create(context.Background(), &entity.Redemption{VoucherID: voucher.ID, CustomerID: 1, DiscountPercent: 10, RedeemedAt: now}, record)
+	againErr := repo.Create(context.Background(), &entity.Redemption{VoucherID: voucher.ID, CustomerID: 1, DiscountPercent: 10, RedeemedAt: now}, record)
+	rejectedErr := repo.Create(context.Background(), &entity.Redemption{VoucherID: voucher.ID, CustomerID: 2, DiscountPercent: 10, RedeemedAt: now}, func(*entity.Voucher, int64) error { return errLimit })
+	missingErr := repo.Create(context.Background(), &entity.Redemption{VoucherID: voucher.ID + 1, CustomerID: 1, DiscountPercent: 10, RedeemedAt: now}, record)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.NoError(t, againErr)
+	assert.Equal(t, []int64{0, 1}, seen)
+	assert.Equal(t, 1, seenVoucher.RedemptionCount)
+	assert.Equal(t, 5, *seenVoucher.MaxRedemptions)
+	assert.Equal(t, 1, seenVoucher.MaxRedemptionsPerUser)
+	assert.Equal(t, entity.VoucherStatusActive, seenVoucher.Status)
+	assert.Equal(t, voucher.ExpiryDate.Format("2006-01-02"), seenVoucher.ExpiryDate.Format("2006-01-02"))
+	assert.ErrorIs(t, rejectedErr, errLimit)
+	assert.ErrorIs(t, missingErr, gorm.ErrRecordNotFound)
+
+	var count int64
+	db.Model(&entity.Redemption{}).Count(&count)
+	assert.Equal(t, int64(2), count)
+	var stored entity.Voucher
+	assert.NoError(t, db.First(&stored, voucher.ID).Error)
+	assert.Equal(t, 2, stored.RedemptionCount)
+}
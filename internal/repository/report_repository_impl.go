@@ -0,0 +1,92 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/shoelfikar/voucher-management-system/internal/domain/entity"
+	"github.com/shoelfikar/voucher-management-system/internal/domain/repository"
+	"gorm.io/gorm"
+)
+
+// reportRepositoryImpl implements domain repository.ReportRepository
+type reportRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewReportRepository creates a new report repository instance
+func NewReportRepository(db *gorm.DB) repository.ReportRepository {
+	return &reportRepositoryImpl{db: db}
+}
+
+// CountVouchersCreated counts the vouchers created in the period, deleted ones included
+func (r *reportRepositoryImpl) CountVouchersCreated(ctx context.Context, from, to time.Time) (int64, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Unscoped().Model(&entity.Voucher{}).
+		Where("created_at >= ? AND created_at < ?", from, to).
+		Count(&count).Error
+	return count, err
+}
+
+// CountActiveVouchers counts the active vouchers that have not expired at at
+func (r *reportRepositoryImpl) CountActiveVouchers(ctx context.Context, at time.Time) (int64, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Model(&entity.Voucher{}).
+		Where("status = ? AND expiry_date >= ?", entity.VoucherStatusActive, at).
+		Count(&count).Error
+	return count, err
+}
+
+// SumRedemptions counts the redemptions made in the period and the customers who made them
+func (r *reportRepositoryImpl) SumRedemptions(ctx context.Context, from, to time.Time) (repository.RedemptionTotals, error) {
+	var totals repository.RedemptionTotals
+	err := r.db.WithContext(ctx).Model(&entity.Redemption{}).
+		Select("COUNT(*) AS redemptions, COUNT(DISTINCT customer_id) AS customers").
+		Where("redeemed_at >= ? AND redeemed_at < ?", from, to).
+		Scan(&totals).Error
+	return totals, err
+}
+
+// TopRedeemedVouchers returns the most redeemed vouchers of the period; ties
+// go to the older voucher so the ranking is stable
+func (r *reportRepositoryImpl) TopRedeemedVouchers(ctx context.Context, from, to time.Time, limit int) ([]repository.VoucherRedemptions, error) {
+	top := []repository.VoucherRedemptions{}
+	err := r.db.WithContext(ctx).Table("redemptions").
+		Select("vouchers.id AS voucher_id, vouchers.voucher_code, vouchers.display_name, COUNT(*) AS redemptions").
+		Joins("JOIN vouchers ON vouchers.id = redemptions.voucher_id").
+		Where("redemptions.redeemed_at >= ? AND redemptions.redeemed_at < ?", from, to).
+		Group("vouchers.id, vouchers.voucher_code, vouchers.display_name").
+		Order("redemptions DESC, vouchers.id").
+		Limit(limit).
+		Scan(&top).Error
+	return top, err
+}
+
+// CountRedemptionsByDay returns the redemptions per UTC day in the period.
+// DATE() exists in both PostgreSQL and SQLite; the day is read back as text
+// because the drivers return it as a date and a string respectively.
+func (r *reportRepositoryImpl) CountRedemptionsByDay(ctx context.Context, from, to time.Time) ([]repository.DailyRedemptions, error) {
+	var rows []struct {
+		Day         string
+		Redemptions int64
+	}
+	err := r.db.WithContext(ctx).Model(&entity.Redemption{}).
+		Select("CAST(DATE(redeemed_at) AS TEXT) AS day, COUNT(*) AS redemptions").
+		Where("redeemed_at >= ? AND redeemed_at < ?", from, to).
+		Group("DATE(redeemed_at)").
+		Order("DATE(redeemed_at)").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	days := make([]repository.DailyRedemptions, 0, len(rows))
+	for _, row := range rows {
+		day, err := time.Parse("2006-01-02", row.Day)
+		if err != nil {
+			return nil, err
+		}
+		days = append(days, repository.DailyRedemptions{Day: day, Redemptions: row.Redemptions})
+	}
+	return days, nil
+}
@@ -0,0 +1,65 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/shoelfikar/voucher-management-system/internal/domain/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReportRepository_Aggregates(t *testing.T) {
+	// Arrange
+	db := setupRedemptionTestDB(t)
+	repo := NewReportRepository(db)
+	ctx := context.Background()
+	from := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 0, 7)
+
+	summer := &entity.Voucher{VoucherCode: "SUMMER24", DisplayName: "Summer sale", DiscountPercent: 10, ExpiryDate: to.AddDate(0, 1, 0), Status: entity.VoucherStatusActive, CreatedAt: from.Add(time.Hour)}
+	winter := &entity.Voucher{VoucherCode: "WINTER24", DiscountPercent: 10, ExpiryDate: to.AddDate(0, 1, 0), Status: entity.VoucherStatusInactive, CreatedAt: from.AddDate(0, 0, 2)}
+	old := &entity.Voucher{VoucherCode: "OLD2025", DiscountPercent: 10, ExpiryDate: from.AddDate(0, 0, -1), Status: entity.VoucherStatusActive, CreatedAt: from.AddDate(0, -1, 0)}
+	for _, v := range []*entity.Voucher{summer, winter, old} {
+		assert.NoError(t, db.Create(v).Error)
+	}
+	redemptions := []entity.Redemption{
+		{VoucherID: summer.ID, CustomerID: 1, DiscountPercent: 10, RedeemedAt: from.Add(2 * time.Hour)},
+		{VoucherID: summer.ID, CustomerID: 2, DiscountPercent: 10, RedeemedAt: from.Add(3 * time.Hour)},
+		{VoucherID: winter.ID, CustomerID: 1, DiscountPercent: 10, RedeemedAt: from.AddDate(0, 0, 3)},
+		{VoucherID: old.ID, CustomerID: 3, DiscountPercent: 10, RedeemedAt: from.Add(-time.Hour)},
+		{VoucherID: summer.ID, CustomerID: 3, DiscountPercent: 10, RedeemedAt: to},
+	}
+	assert.NoError(t, db.Create(&redemptions).Error)
+
+	// Act
+	created, createdErr := repo.CountVouchersCreated(ctx, from, to)
+	active, activeErr := repo.CountActiveVouchers(ctx, from)
+	totals, totalsErr := repo.SumRedemptions(ctx, from, to)
+	top, topErr := repo.TopRedeemedVouchers(ctx, from, to, 5)
+	days, daysErr := repo.CountRedemptionsByDay(ctx, from, to)
+
+	// Assert
+	assert.NoError(t, createdErr)
+	assert.Equal(t, int64(2), created)
+	assert.NoError(t, activeErr)
+	assert.Equal(t, int64(1), active)
+	assert.NoError(t, totalsErr)
+	assert.Equal(t, int64(3), totals.Redemptions)
+	assert.Equal(t, int64(2), totals.Customers)
+	assert.NoError(t, topErr)
+	if assert.Len(t, top, 2) {
+		assert.Equal(t, summer.ID, top[0].VoucherID)
+		assert.Equal(t, "SUMMER24", top[0].VoucherCode)
+		assert.Equal(t, "Summer sale", top[0].DisplayName)
+		assert.Equal(t, int64(2), top[0].Redemptions)
+		assert.Equal(t, winter.ID, top[1].VoucherID)
+	}
+	assert.NoError(t, daysErr)
+	if assert.Len(t, days, 2) {
+		assert.True(t, days[0].Day.Equal(from))
+		assert.Equal(t, int64(2), days[0].Redemptions)
+		assert.True(t, days[1].Day.Equal(from.AddDate(0, 0, 3)))
+		assert.Equal(t, int64(1), days[1].Redemptions)
+	}
+}
@@ -0,0 +1,37 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/shoelfikar/voucher-management-system/internal/domain/entity"
+	"github.com/shoelfikar/voucher-management-system/internal/domain/repository"
+	"gorm.io/gorm"
+)
+
+// retentionPolicyID is the primary key of the single policy row
+const retentionPolicyID = 1
+
+// retentionPolicyRepositoryImpl implements domain repository.RetentionPolicyRepository
+type retentionPolicyRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewRetentionPolicyRepository creates a new retention policy repository instance
+func NewRetentionPolicyRepository(db *gorm.DB) repository.RetentionPolicyRepository {
+	return &retentionPolicyRepositoryImpl{db: db}
+}
+
+// Get retrieves the policy
+func (r *retentionPolicyRepositoryImpl) Get(ctx context.Context) (*entity.RetentionPolicy, error) {
+	var policy entity.RetentionPolicy
+	if err := r.db.WithContext(ctx).First(&policy, retentionPolicyID).Error; err != nil {
+		return nil, err
+	}
+	return &policy, nil
+}
+
+// Save creates or replaces the policy row
+func (r *retentionPolicyRepositoryImpl) Save(ctx context.Context, policy *entity.RetentionPolicy) error {
+	policy.ID = retentionPolicyID
+	return r.db.WithContext(ctx).Save(policy).Error
+}
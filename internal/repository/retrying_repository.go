@@ -0,0 +1,99 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/shoelfikar/voucher-management-system/internal/domain/entity"
+	"github.com/shoelfikar/voucher-management-system/internal/domain/repository"
+	"github.com/shoelfikar/voucher-management-system/pkg/database"
+)
+
+// retryingVoucherRepository runs the inserts of the import and issuing paths
+// again after transient database errors, so a brief failover does not fail a
+// whole import. Other operations pass through unchanged.
+type retryingVoucherRepository struct {
+	repository.VoucherRepository
+
+	policy database.RetryPolicy
+}
+
+// NewRetryingVoucherRepository wraps a voucher repository with retries on its write paths
+func NewRetryingVoucherRepository(inner repository.VoucherRepository, policy database.RetryPolicy) repository.VoucherRepository {
+	return &retryingVoucherRepository{VoucherRepository: inner, policy: policy}
+}
+
+// Create creates a voucher, retrying transient errors
+func (r *retryingVoucherRepository) Create(ctx context.Context, voucher *entity.Voucher) error {
+	return r.policy.Do(ctx, func() error {
+		return r.VoucherRepository.Create(ctx, voucher)
+	})
+}
+
+// BulkCreate creates vouchers in one statement, retrying transient errors;
+// the statement either inserts every row or none, so it is safe to repeat
+func (r *retryingVoucherRepository) BulkCreate(ctx context.Context, vouchers []*entity.Voucher) error {
+	return r.policy.Do(ctx, func() error {
+		return r.VoucherRepository.BulkCreate(ctx, vouchers)
+	})
+}
+
+// BulkCreateSkipConflicts retries each voucher on its own. Repeating the
+// whole call after a partial failure would report the vouchers inserted by
+// the first attempt as conflicts.
+func (r *retryingVoucherRepository) BulkCreateSkipConflicts(ctx context.Context, vouchers []*entity.Voucher) ([]string, error) {
+	skippedCodes := []string{}
+	for _, voucher := range vouchers {
+		var skipped []string
+		err := r.policy.Do(ctx, func() error {
+			var err error
+			skipped, err = r.VoucherRepository.BulkCreateSkipConflicts(ctx, []*entity.Voucher{voucher})
+			return err
+		})
+		if err != nil {
+			return nil, err
+		}
+		skippedCodes = append(skippedCodes, skipped...)
+	}
+	return skippedCodes, nil
+}
+
+// retryingVoucherAssignmentRepository retries storing assignments, the write
+// made when a voucher is handed to a customer
+type retryingVoucherAssignmentRepository struct {
+	repository.VoucherAssignmentRepository
+
+	policy database.RetryPolicy
+}
+
+// NewRetryingVoucherAssignmentRepository wraps an assignment repository with retries on Create
+func NewRetryingVoucherAssignmentRepository(inner repository.VoucherAssignmentRepository, policy database.RetryPolicy) repository.VoucherAssignmentRepository {
+	return &retryingVoucherAssignmentRepository{VoucherAssignmentRepository: inner, policy: policy}
+}
+
+// Create stores an assignment, retrying transient errors
+func (r *retryingVoucherAssignmentRepository) Create(ctx context.Context, assignment *entity.VoucherAssignment) error {
+	return r.policy.Do(ctx, func() error {
+		return r.VoucherAssignmentRepository.Create(ctx, assignment)
+	})
+}
+
+// retryingRedemptionRepository retries recording redemptions. The voucher
+// lock, limit check and insert run in one transaction, which a transient
+// error rolls back, so running it again cannot redeem twice.
+type retryingRedemptionRepository struct {
+	repository.RedemptionRepository
+
+	policy database.RetryPolicy
+}
+
+// NewRetryingRedemptionRepository wraps a redemption repository with retries on Create
+func NewRetryingRedemptionRepository(inner repository.RedemptionRepository, policy database.RetryPolicy) repository.RedemptionRepository {
+	return &retryingRedemptionRepository{RedemptionRepository: inner, policy: policy}
+}
+
+// Create records a redemption, retrying transient errors; check runs again on each attempt
+func (r *retryingRedemptionRepository) Create(ctx context.Context, redemption *entity.Redemption, check func(voucher *entity.Voucher, customerRedemptions int64) error) error {
+	return r.policy.Do(ctx, func() error {
+		return r.RedemptionRepository.Create(ctx, redemption, check)
+	})
+}
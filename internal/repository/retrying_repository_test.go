@@ -0,0 +1,183 @@
+package repository
+
+import (
+	"context"
+	"database/sql/driver"
+	"testing"
+	"time"
+
+	"github.com/shoelfikar/voucher-management-system/internal/domain/entity"
+	"github.com/shoelfikar/voucher-management-system/internal/domain/repository"
+	"github.com/shoelfikar/voucher-management-system/pkg/database"
+	"github.com/stretchr/testify/assert"
+)
+
+// retryTestPolicy retries without waiting
+var retryTestPolicy = database.RetryPolicy{MaxAttempts: 3}
+
+// flakyVoucherRepository fails the given calls with a dropped connection
+// before passing them on, as during a database failover
+type flakyVoucherRepository struct {
+	repository.VoucherRepository
+
+	calls   int
+	failing map[int]bool
+}
+
+func (r *flakyVoucherRepository) fail() bool {
+	r.calls++
+	return r.failing[r.calls]
+}
+
+func (r *flakyVoucherRepository) Create(ctx context.Context, voucher *entity.Voucher) error {
+	if r.fail() {
+		return driver.ErrBadConn
+	}
+	return r.VoucherRepository.Create(ctx, voucher)
+}
+
+func (r *flakyVoucherRepository) BulkCreateSkipConflicts(ctx context.Context, vouchers []*entity.Voucher) ([]string, error) {
+	if r.fail() {
+		return nil, driver.ErrBadConn
+	}
+	return r.VoucherRepository.BulkCreateSkipConflicts(ctx, vouchers)
+}
+
+type flakyVoucherAssignmentRepository struct {
+	repository.VoucherAssignmentRepository
+
+	failures int
+}
+
+func (r *flakyVoucherAssignmentRepository) Create(ctx context.Context, assignment *entity.VoucherAssignment) error {
+	if r.failures > 0 {
+		r.failures--
+		return driver.ErrBadConn
+	}
+	return r.VoucherAssignmentRepository.Create(ctx, assignment)
+}
+
+type flakyRedemptionRepository struct {
+	repository.RedemptionRepository
+
+	failures int
+}
+
+func (r *flakyRedemptionRepository) Create(ctx context.Context, redemption *entity.Redemption, check func(voucher *entity.Voucher, customerRedemptions int64) error) error {
+	if r.failures > 0 {
+		r.failures--
+		return driver.ErrBadConn
+	}
+	return r.RedemptionRepository.Create(ctx, redemption, check)
+}
+
+func TestRetryingVoucherRepository_Create_RetriesTransientErrors(t *testing.T) {
+	// Arrange
+	db := setupVoucherTestDB(t)
+	flaky := &flakyVoucherRepository{VoucherRepository: NewVoucherRepository(db), failing: map[int]bool{1: true, 2: true}}
+	repo := NewRetryingVoucherRepository(flaky, retryTestPolicy)
+
+	// Act
+	err := repo.Create(context.Background(), createTestVoucher("RETRY1", 10.0))
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, 3, flaky.calls)
+	found, err := repo.FindByVoucherCode(context.Background(), "RETRY1")
+	assert.NoError(t, err)
+	assert.NotNil(t, found)
+}
+
+func TestRetryingVoucherRepository_Create_DoesNotRetryConflicts(t *testing.T) {
+	// Arrange
+	db := setupVoucherTestDB(t)
+	flaky := &flakyVoucherRepository{VoucherRepository: NewVoucherRepository(db)}
+	repo := NewRetryingVoucherRepository(flaky, retryTestPolicy)
+	assert.NoError(t, repo.Create(context.Background(), createTestVoucher("TAKEN1", 10.0)))
+
+	// Act
+	err := repo.Create(context.Background(), createTestVoucher("TAKEN1", 20.0))
+
+	// Assert
+	assert.Error(t, err)
+	assert.Equal(t, 2, flaky.calls)
+}
+
+func TestRetryingVoucherRepository_BulkCreateSkipConflicts_RetriesEachVoucher(t *testing.T) {
+	// Arrange
+	db := setupVoucherTestDB(t)
+	inner := NewVoucherRepository(db)
+	assert.NoError(t, inner.Create(context.Background(), createTestVoucher("SKIP2", 10.0)))
+	// The first voucher is inserted before the connection drops on the second
+	flaky := &flakyVoucherRepository{VoucherRepository: inner, failing: map[int]bool{2: true}}
+	repo := NewRetryingVoucherRepository(flaky, retryTestPolicy)
+
+	// Act
+	skipped, err := repo.BulkCreateSkipConflicts(context.Background(), []*entity.Voucher{
+		createTestVoucher("SKIP1", 10.0),
+		createTestVoucher("SKIP2", 10.0),
+		createTestVoucher("SKIP3", 10.0),
+	})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"SKIP2"}, skipped)
+	duplicates, err := inner.CheckDuplicateCodes(context.Background(), []string{"SKIP1", "SKIP3"})
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []string{"SKIP1", "SKIP3"}, duplicates)
+}
+
+func TestRetryingVoucherRepository_GivesUpAfterMaxAttempts(t *testing.T) {
+	// Arrange
+	db := setupVoucherTestDB(t)
+	flaky := &flakyVoucherRepository{VoucherRepository: NewVoucherRepository(db), failing: map[int]bool{1: true, 2: true, 3: true}}
+	repo := NewRetryingVoucherRepository(flaky, retryTestPolicy)
+
+	// Act
+	err := repo.Create(context.Background(), createTestVoucher("DOWN1", 10.0))
+
+	// Assert
+	assert.ErrorIs(t, err, driver.ErrBadConn)
+	assert.Equal(t, 3, flaky.calls)
+}
+
+func TestRetryingVoucherAssignmentRepository_Create_RetriesTransientErrors(t *testing.T) {
+	// Arrange
+	db := setupVoucherAssignmentTestDB(t)
+	flaky := &flakyVoucherAssignmentRepository{VoucherAssignmentRepository: NewVoucherAssignmentRepository(db), failures: 1}
+	repo := NewRetryingVoucherAssignmentRepository(flaky, retryTestPolicy)
+	now := time.Now()
+
+	// Act
+	err := repo.Create(context.Background(), &entity.VoucherAssignment{VoucherID: 1, CustomerID: 1, AssignedAt: now, ExpiresAt: now.AddDate(0, 0, 30)})
+
+	// Assert
+	assert.NoError(t, err)
+	found, err := repo.FindByVoucherAndCustomer(context.Background(), 1, 1)
+	assert.NoError(t, err)
+	assert.NotNil(t, found)
+}
+
+func TestRetryingRedemptionRepository_Create_RetriesTransientErrors(t *testing.T) {
+	// Arrange
+	db := setupRedemptionTestDB(t)
+	voucher := &entity.Voucher{VoucherCode: "RETRY24", DiscountPercent: 10, ExpiryDate: time.Now().AddDate(0, 1, 0), Status: entity.VoucherStatusActive}
+	assert.NoError(t, db.Create(voucher).Error)
+	flaky := &flakyRedemptionRepository{RedemptionRepository: NewRedemptionRepository(db), failures: 1}
+	repo := NewRetryingRedemptionRepository(flaky, retryTestPolicy)
+	checks := 0
+
+	// Act
+	err := repo.Create(context.Background(), &entity.Redemption{VoucherID: voucher.ID, CustomerID: 1, DiscountPercent: 10, RedeemedAt: time.Now()},
+		func(*entity.Voucher, int64) error {
+			checks++
+			return nil
+		})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, 1, checks)
+	var stored entity.Voucher
+	assert.NoError(t, db.First(&stored, voucher.ID).Error)
+	assert.Equal(t, 1, stored.RedemptionCount)
+}
@@ -0,0 +1,158 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/shoelfikar/voucher-management-system/internal/domain/entity"
+	"github.com/shoelfikar/voucher-management-system/internal/domain/repository"
+	"github.com/shoelfikar/voucher-management-system/pkg/utils"
+	"gorm.io/gorm"
+)
+
+// segmentMemberBatchSize bounds the rows inserted per statement when storing members
+const segmentMemberBatchSize = 500
+
+// segmentRepositoryImpl implements domain repository.SegmentRepository
+type segmentRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewSegmentRepository creates a new segment repository instance
+func NewSegmentRepository(db *gorm.DB) repository.SegmentRepository {
+	return &segmentRepositoryImpl{db: db}
+}
+
+// FindAll retrieves segments with pagination, search by name, and sorting
+func (r *segmentRepositoryImpl) FindAll(ctx context.Context, page, limit int, search string, sort []utils.SortField) ([]*entity.Segment, int64, error) {
+	var segments []*entity.Segment
+	var total int64
+
+	query := r.db.WithContext(ctx).Model(&entity.Segment{})
+	if search != "" {
+		query = query.Where("LOWER(name) LIKE LOWER(?)", "%"+search+"%")
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	if len(sort) > 0 {
+		query = query.Order(utils.OrderClause(sort))
+	} else {
+		query = query.Order("id")
+	}
+
+	err := query.Offset((page - 1) * limit).Limit(limit).Find(&segments).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return segments, total, nil
+}
+
+// FindByID retrieves a segment by ID
+func (r *segmentRepositoryImpl) FindByID(ctx context.Context, id uint) (*entity.Segment, error) {
+	var segment entity.Segment
+	if err := r.db.WithContext(ctx).First(&segment, id).Error; err != nil {
+		return nil, err
+	}
+	return &segment, nil
+}
+
+// FindByName retrieves a segment by name
+func (r *segmentRepositoryImpl) FindByName(ctx context.Context, name string) (*entity.Segment, error) {
+	var segment entity.Segment
+	err := r.db.WithContext(ctx).Where("name = ?", name).First(&segment).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &segment, nil
+}
+
+// Create creates a segment and its members in one transaction
+func (r *segmentRepositoryImpl) Create(ctx context.Context, segment *entity.Segment, customerIDs []string) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(segment).Error; err != nil {
+			return err
+		}
+		return createSegmentMembers(tx, segment.ID, customerIDs)
+	})
+}
+
+// Update saves the editable fields of a segment and replaces its members in one transaction
+func (r *segmentRepositoryImpl) Update(ctx context.Context, segment *entity.Segment, customerIDs []string) (int64, error) {
+	var rowsAffected int64
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(segment).
+			Where("id = ?", segment.ID).
+			Select("name", "type", "external_ref").
+			Updates(segment)
+		if result.Error != nil || result.RowsAffected == 0 {
+			return result.Error
+		}
+		rowsAffected = result.RowsAffected
+
+		if err := tx.Where("segment_id = ?", segment.ID).Delete(&entity.SegmentMember{}).Error; err != nil {
+			return err
+		}
+		return createSegmentMembers(tx, segment.ID, customerIDs)
+	})
+
+	return rowsAffected, err
+}
+
+// Delete removes a segment and its members and returns the number of segments affected
+func (r *segmentRepositoryImpl) Delete(ctx context.Context, id uint) (int64, error) {
+	var rowsAffected int64
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("segment_id = ?", id).Delete(&entity.SegmentMember{}).Error; err != nil {
+			return err
+		}
+		result := tx.Delete(&entity.Segment{}, id)
+		rowsAffected = result.RowsAffected
+		return result.Error
+	})
+
+	return rowsAffected, err
+}
+
+// IsMember reports whether customerID is listed in the segment
+func (r *segmentRepositoryImpl) IsMember(ctx context.Context, segmentID uint, customerID string) (bool, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Model(&entity.SegmentMember{}).
+		Where("segment_id = ? AND customer_id = ?", segmentID, customerID).
+		Count(&count).Error
+	return count > 0, err
+}
+
+// CountMembers counts the customers listed in the segment
+func (r *segmentRepositoryImpl) CountMembers(ctx context.Context, segmentID uint) (int64, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Model(&entity.SegmentMember{}).Where("segment_id = ?", segmentID).Count(&count).Error
+	return count, err
+}
+
+// CountVouchers counts the vouchers targeting the segment, archived ones included
+func (r *segmentRepositoryImpl) CountVouchers(ctx context.Context, segmentID uint) (int64, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Unscoped().Model(&entity.Voucher{}).Where("segment_id = ?", segmentID).Count(&count).Error
+	return count, err
+}
+
+func createSegmentMembers(tx *gorm.DB, segmentID uint, customerIDs []string) error {
+	if len(customerIDs) == 0 {
+		return nil
+	}
+
+	members := make([]entity.SegmentMember, len(customerIDs))
+	for i, customerID := range customerIDs {
+		members[i] = entity.SegmentMember{SegmentID: segmentID, CustomerID: customerID}
+	}
+	return tx.CreateInBatches(members, segmentMemberBatchSize).Error
+}
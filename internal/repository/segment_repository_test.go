@@ -0,0 +1,125 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/shoelfikar/voucher-management-system/internal/domain/entity"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func setupSegmentTestDB(t *testing.T) *gorm.DB {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("Failed to connect to test database: %v", err)
+	}
+	if err := db.AutoMigrate(&entity.Segment{}, &entity.SegmentMember{}, &entity.Voucher{}); err != nil {
+		t.Fatalf("Failed to migrate test database: %v", err)
+	}
+	return db
+}
+
+func TestSegmentRepository_Create_StoresMembers(t *testing.T) {
+	// Arrange
+	db := setupSegmentTestDB(t)
+	repo := NewSegmentRepository(db)
+	segment := &entity.Segment{Name: "VIP", Type: entity.SegmentTypeStatic}
+
+	// Act
+	err := repo.Create(context.Background(), segment, []string{"cust-1", "cust-2"})
+
+	// Assert
+	assert.NoError(t, err)
+	member, memberErr := repo.IsMember(context.Background(), segment.ID, "cust-2")
+	assert.NoError(t, memberErr)
+	assert.True(t, member)
+	outsider, outsiderErr := repo.IsMember(context.Background(), segment.ID, "cust-3")
+	assert.NoError(t, outsiderErr)
+	assert.False(t, outsider)
+	count, _ := repo.CountMembers(context.Background(), segment.ID)
+	assert.Equal(t, int64(2), count)
+}
+
+func TestSegmentRepository_Update_ReplacesMembers(t *testing.T) {
+	// Arrange
+	db := setupSegmentTestDB(t)
+	repo := NewSegmentRepository(db)
+	segment := &entity.Segment{Name: "VIP", Type: entity.SegmentTypeStatic}
+	assert.NoError(t, repo.Create(context.Background(), segment, []string{"cust-1", "cust-2"}))
+
+	// Act
+	rows, err := repo.Update(context.Background(), &entity.Segment{ID: segment.ID, Name: "Gold", Type: entity.SegmentTypeStatic}, []string{"cust-3"})
+	missingRows, missingErr := repo.Update(context.Background(), &entity.Segment{ID: 9999, Name: "Other", Type: entity.SegmentTypeStatic}, []string{"cust-4"})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), rows)
+	stored, _ := repo.FindByID(context.Background(), segment.ID)
+	assert.Equal(t, "Gold", stored.Name)
+	oldMember, _ := repo.IsMember(context.Background(), segment.ID, "cust-1")
+	newMember, _ := repo.IsMember(context.Background(), segment.ID, "cust-3")
+	assert.False(t, oldMember)
+	assert.True(t, newMember)
+	assert.NoError(t, missingErr)
+	assert.Equal(t, int64(0), missingRows)
+	orphan, _ := repo.IsMember(context.Background(), 9999, "cust-4")
+	assert.False(t, orphan)
+}
+
+func TestSegmentRepository_Delete_RemovesMembers(t *testing.T) {
+	// Arrange
+	db := setupSegmentTestDB(t)
+	repo := NewSegmentRepository(db)
+	segment := &entity.Segment{Name: "VIP", Type: entity.SegmentTypeStatic}
+	assert.NoError(t, repo.Create(context.Background(), segment, []string{"cust-1"}))
+
+	// Act
+	rows, err := repo.Delete(context.Background(), segment.ID)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), rows)
+	count, _ := repo.CountMembers(context.Background(), segment.ID)
+	assert.Equal(t, int64(0), count)
+}
+
+func TestSegmentRepository_CountVouchers_IncludesArchived(t *testing.T) {
+	// Arrange
+	db := setupSegmentTestDB(t)
+	repo := NewSegmentRepository(db)
+	voucherRepo := NewVoucherRepository(db)
+	segment := &entity.Segment{Name: "VIP", Type: entity.SegmentTypeStatic}
+	assert.NoError(t, repo.Create(context.Background(), segment, nil))
+
+	archived := &entity.Voucher{VoucherCode: "VIP10", DiscountPercent: 10, ExpiryDate: time.Now(), SegmentID: &segment.ID}
+	assert.NoError(t, voucherRepo.Create(context.Background(), archived))
+	_, err := voucherRepo.Delete(context.Background(), archived.ID)
+	assert.NoError(t, err)
+
+	// Act
+	count, err := repo.CountVouchers(context.Background(), segment.ID)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), count)
+}
+
+func TestSegmentRepository_FindByName(t *testing.T) {
+	// Arrange
+	db := setupSegmentTestDB(t)
+	repo := NewSegmentRepository(db)
+	assert.NoError(t, repo.Create(context.Background(), &entity.Segment{Name: "VIP", Type: entity.SegmentTypeExternal, ExternalRef: "crm-vip"}, nil))
+
+	// Act
+	found, err := repo.FindByName(context.Background(), "VIP")
+	missing, missingErr := repo.FindByName(context.Background(), "Other")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "crm-vip", found.ExternalRef)
+	assert.NoError(t, missingErr)
+	assert.Nil(t, missing)
+}
@@ -0,0 +1,88 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/shoelfikar/voucher-management-system/internal/domain/entity"
+	"github.com/shoelfikar/voucher-management-system/internal/domain/repository"
+	"gorm.io/gorm"
+)
+
+// smsDeliveryRepositoryImpl implements domain repository.SMSDeliveryRepository
+type smsDeliveryRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewSMSDeliveryRepository creates a new SMS delivery repository instance
+func NewSMSDeliveryRepository(db *gorm.DB) repository.SMSDeliveryRepository {
+	return &smsDeliveryRepositoryImpl{db: db}
+}
+
+// CreateBatch stores several deliveries in one insert
+func (r *smsDeliveryRepositoryImpl) CreateBatch(ctx context.Context, deliveries []*entity.SMSDelivery) error {
+	return r.db.WithContext(ctx).Create(&deliveries).Error
+}
+
+// FindDue retrieves pending deliveries whose next attempt is due
+func (r *smsDeliveryRepositoryImpl) FindDue(ctx context.Context, now time.Time, limit int) ([]*entity.SMSDelivery, error) {
+	var deliveries []*entity.SMSDelivery
+	err := r.db.WithContext(ctx).
+		Where("status = ? AND next_attempt_at <= ?", entity.SMSDeliveryStatusPending, now).
+		Order("next_attempt_at ASC, id ASC").
+		Limit(limit).
+		Find(&deliveries).Error
+	return deliveries, err
+}
+
+// Update saves the outcome of a delivery attempt
+func (r *smsDeliveryRepositoryImpl) Update(ctx context.Context, delivery *entity.SMSDelivery) error {
+	return r.db.WithContext(ctx).Model(delivery).
+		Select("status", "attempts", "last_error", "provider_message_id", "next_attempt_at", "sent_at").
+		Updates(delivery).Error
+}
+
+// FindAll retrieves deliveries matching filter with pagination
+func (r *smsDeliveryRepositoryImpl) FindAll(ctx context.Context, filter repository.SMSDeliveryFilter, page, limit int) ([]*entity.SMSDelivery, int64, error) {
+	var deliveries []*entity.SMSDelivery
+	var total int64
+
+	query := r.db.WithContext(ctx).Model(&entity.SMSDelivery{})
+	if filter.VoucherID != 0 {
+		query = query.Where("voucher_id = ?", filter.VoucherID)
+	}
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	offset := (page - 1) * limit
+	if err := query.Order("created_at DESC, id DESC").Offset(offset).Limit(limit).Find(&deliveries).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return deliveries, total, nil
+}
+
+// Requeue resets a failed delivery so the dispatch job sends it again
+func (r *smsDeliveryRepositoryImpl) Requeue(ctx context.Context, id uint, now time.Time) (int64, error) {
+	result := r.db.WithContext(ctx).Model(&entity.SMSDelivery{}).
+		Where("id = ? AND status = ?", id, entity.SMSDeliveryStatusFailed).
+		Updates(map[string]interface{}{
+			"status":          entity.SMSDeliveryStatusPending,
+			"attempts":        0,
+			"next_attempt_at": now,
+		})
+	return result.RowsAffected, result.Error
+}
+
+// Discard marks a failed delivery discarded
+func (r *smsDeliveryRepositoryImpl) Discard(ctx context.Context, id uint) (int64, error) {
+	result := r.db.WithContext(ctx).Model(&entity.SMSDelivery{}).
+		Where("id = ? AND status = ?", id, entity.SMSDeliveryStatusFailed).
+		Update("status", entity.SMSDeliveryStatusDiscarded)
+	return result.RowsAffected, result.Error
+}
@@ -0,0 +1,111 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/shoelfikar/voucher-management-system/internal/domain/entity"
+	"github.com/shoelfikar/voucher-management-system/internal/domain/repository"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func setupSMSDeliveryTestDB(t *testing.T) *gorm.DB {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{TranslateError: true})
+	if err != nil {
+		t.Fatalf("Failed to connect to test database: %v", err)
+	}
+	if err := db.AutoMigrate(&entity.SMSDelivery{}); err != nil {
+		t.Fatalf("Failed to migrate test database: %v", err)
+	}
+	return db
+}
+
+func TestSMSDeliveryRepository_FindDue(t *testing.T) {
+	// Arrange
+	db := setupSMSDeliveryTestDB(t)
+	repo := NewSMSDeliveryRepository(db)
+	now := time.Now()
+	deliveries := []*entity.SMSDelivery{
+		{VoucherID: 1, PhoneNumber: "+15550000001", Content: entity.SMSContentCode, Body: "a", Status: entity.SMSDeliveryStatusPending, NextAttemptAt: now.Add(-time.Minute)},
+		{VoucherID: 1, PhoneNumber: "+15550000002", Content: entity.SMSContentCode, Body: "b", Status: entity.SMSDeliveryStatusPending, NextAttemptAt: now.Add(time.Minute)},
+		{VoucherID: 1, PhoneNumber: "+15550000003", Content: entity.SMSContentCode, Body: "c", Status: entity.SMSDeliveryStatusSent, NextAttemptAt: now.Add(-time.Minute)},
+	}
+	assert.NoError(t, repo.CreateBatch(context.Background(), deliveries))
+
+	// Act
+	due, err := repo.FindDue(context.Background(), now, 10)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Len(t, due, 1)
+	assert.Equal(t, deliveries[0].ID, due[0].ID)
+}
+
+func TestSMSDeliveryRepository_UpdateAndFindAll(t *testing.T) {
+	// Arrange
+	db := setupSMSDeliveryTestDB(t)
+	repo := NewSMSDeliveryRepository(db)
+	now := time.Now()
+	deliveries := []*entity.SMSDelivery{
+		{VoucherID: 1, PhoneNumber: "+15550000001", Content: entity.SMSContentCode, Body: "a", Status: entity.SMSDeliveryStatusPending, NextAttemptAt: now},
+		{VoucherID: 2, PhoneNumber: "+15550000002", Content: entity.SMSContentCode, Body: "b", Status: entity.SMSDeliveryStatusPending, NextAttemptAt: now},
+	}
+	assert.NoError(t, repo.CreateBatch(context.Background(), deliveries))
+
+	deliveries[0].Status = entity.SMSDeliveryStatusSent
+	deliveries[0].Attempts = 1
+	deliveries[0].ProviderMessageID = "SM1"
+	deliveries[0].SentAt = &now
+
+	// Act
+	err := repo.Update(context.Background(), deliveries[0])
+	sent, sentTotal, findErr := repo.FindAll(context.Background(), repository.SMSDeliveryFilter{Status: entity.SMSDeliveryStatusSent}, 1, 10)
+	forVoucher, voucherTotal, _ := repo.FindAll(context.Background(), repository.SMSDeliveryFilter{VoucherID: 2}, 1, 10)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.NoError(t, findErr)
+	assert.Equal(t, int64(1), sentTotal)
+	assert.Equal(t, "SM1", sent[0].ProviderMessageID)
+	assert.Equal(t, 1, sent[0].Attempts)
+	assert.Equal(t, int64(1), voucherTotal)
+	assert.Equal(t, deliveries[1].ID, forVoucher[0].ID)
+}
+
+func TestSMSDeliveryRepository_RequeueAndDiscard(t *testing.T) {
+	// Arrange
+	db := setupSMSDeliveryTestDB(t)
+	repo := NewSMSDeliveryRepository(db)
+	now := time.Now()
+	deliveries := []*entity.SMSDelivery{
+		{VoucherID: 1, PhoneNumber: "+15550000001", Content: entity.SMSContentCode, Body: "a", Status: entity.SMSDeliveryStatusFailed, Attempts: 5, NextAttemptAt: now.Add(-time.Hour)},
+		{VoucherID: 1, PhoneNumber: "+15550000002", Content: entity.SMSContentCode, Body: "b", Status: entity.SMSDeliveryStatusFailed, Attempts: 5, NextAttemptAt: now.Add(-time.Hour)},
+		{VoucherID: 1, PhoneNumber: "+15550000003", Content: entity.SMSContentCode, Body: "c", Status: entity.SMSDeliveryStatusSent, NextAttemptAt: now},
+	}
+	assert.NoError(t, repo.CreateBatch(context.Background(), deliveries))
+
+	// Act
+	requeued, requeueErr := repo.Requeue(context.Background(), deliveries[0].ID, now)
+	discarded, discardErr := repo.Discard(context.Background(), deliveries[1].ID)
+	sentRequeued, _ := repo.Requeue(context.Background(), deliveries[2].ID, now)
+	discardedAgain, _ := repo.Discard(context.Background(), deliveries[1].ID)
+
+	// Assert
+	assert.NoError(t, requeueErr)
+	assert.NoError(t, discardErr)
+	assert.Equal(t, int64(1), requeued)
+	assert.Equal(t, int64(1), discarded)
+	assert.Equal(t, int64(0), sentRequeued)
+	assert.Equal(t, int64(0), discardedAgain)
+
+	due, _ := repo.FindDue(context.Background(), now, 10)
+	assert.Len(t, due, 1)
+	assert.Equal(t, deliveries[0].ID, due[0].ID)
+	assert.Equal(t, 0, due[0].Attempts)
+	gone, total, _ := repo.FindAll(context.Background(), repository.SMSDeliveryFilter{Status: entity.SMSDeliveryStatusDiscarded}, 1, 10)
+	assert.Equal(t, int64(1), total)
+	assert.Equal(t, deliveries[1].ID, gone[0].ID)
+}
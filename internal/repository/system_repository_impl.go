@@ -0,0 +1,58 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/shoelfikar/voucher-management-system/internal/domain/repository"
+	"github.com/shoelfikar/voucher-management-system/pkg/database"
+	"gorm.io/gorm"
+)
+
+// migrationsTable is where the SQL migrations under migrations/ record their version
+const migrationsTable = "schema_migrations"
+
+// systemRepositoryImpl implements domain repository.SystemRepository
+type systemRepositoryImpl struct {
+	db     *gorm.DB
+	models []interface{}
+}
+
+// NewSystemRepository creates a new system repository instance; models are
+// the entities whose tables the service expects to exist
+func NewSystemRepository(db *gorm.DB, models ...interface{}) repository.SystemRepository {
+	return &systemRepositoryImpl{db: db, models: models}
+}
+
+// Ping checks the database answers
+func (r *systemRepositoryImpl) Ping(ctx context.Context) error {
+	sqlDB, err := r.db.WithContext(ctx).DB()
+	if err != nil {
+		return err
+	}
+	return sqlDB.PingContext(ctx)
+}
+
+// MigrationVersion reads the version row kept by the SQL migrations
+func (r *systemRepositoryImpl) MigrationVersion(ctx context.Context) (uint, bool, bool, error) {
+	if !r.db.WithContext(ctx).Migrator().HasTable(migrationsTable) {
+		return 0, false, false, nil
+	}
+
+	var row struct {
+		Version uint
+		Dirty   bool
+	}
+	result := r.db.WithContext(ctx).Table(migrationsTable).Select("version, dirty").Limit(1).Scan(&row)
+	if result.Error != nil {
+		return 0, false, false, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return 0, false, false, nil
+	}
+	return row.Version, row.Dirty, true, nil
+}
+
+// PendingSchemaChanges lists the tables and columns of the models missing from the database
+func (r *systemRepositoryImpl) PendingSchemaChanges(ctx context.Context) ([]string, error) {
+	return database.PendingSchemaChanges(r.db, r.models...)
+}
@@ -0,0 +1,64 @@
+package repository
+
+import (
+	"context"
+	"testing"
+
+	"github.com/shoelfikar/voucher-management-system/internal/domain/entity"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func setupSystemTestDB(t *testing.T) *gorm.DB {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("Failed to connect to test database: %v", err)
+	}
+	return db
+}
+
+func TestSystemRepository_MigrationVersion_WithoutMigrationsTable(t *testing.T) {
+	// Arrange
+	repo := NewSystemRepository(setupSystemTestDB(t))
+
+	// Act
+	_, _, ok, err := repo.MigrationVersion(context.Background())
+
+	// Assert
+	assert.NoError(t, err)
+	assert.False(t, ok)
+}
+
+func TestSystemRepository_MigrationVersion(t *testing.T) {
+	// Arrange
+	db := setupSystemTestDB(t)
+	assert.NoError(t, db.Exec("CREATE TABLE schema_migrations (version BIGINT NOT NULL PRIMARY KEY, dirty BOOLEAN NOT NULL)").Error)
+	assert.NoError(t, db.Exec("INSERT INTO schema_migrations (version, dirty) VALUES (17, false)").Error)
+	repo := NewSystemRepository(db)
+
+	// Act
+	version, dirty, ok, err := repo.MigrationVersion(context.Background())
+
+	// Assert
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, uint(17), version)
+	assert.False(t, dirty)
+}
+
+func TestSystemRepository_PingAndPendingSchemaChanges(t *testing.T) {
+	// Arrange
+	db := setupSystemTestDB(t)
+	assert.NoError(t, db.AutoMigrate(&entity.User{}))
+	repo := NewSystemRepository(db, &entity.User{}, &entity.Partner{})
+
+	// Act
+	pingErr := repo.Ping(context.Background())
+	pending, err := repo.PendingSchemaChanges(context.Background())
+
+	// Assert
+	assert.NoError(t, pingErr)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"table partners"}, pending)
+}
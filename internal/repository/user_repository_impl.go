@@ -1,6 +1,9 @@
 package repository
 
 import (
+	"context"
+	"time"
+
 	"github.com/shoelfikar/voucher-management-system/internal/domain/entity"
 	"github.com/shoelfikar/voucher-management-system/internal/domain/repository"
 	"gorm.io/gorm"
@@ -17,9 +20,9 @@ func NewUserRepository(db *gorm.DB) repository.UserRepository {
 }
 
 // FindByEmail finds a user by email
-func (r *userRepositoryImpl) FindByEmail(email string) (*entity.User, error) {
+func (r *userRepositoryImpl) FindByEmail(ctx context.Context, email string) (*entity.User, error) {
 	var user entity.User
-	err := r.db.Where("email = ?", email).First(&user).Error
+	err := r.db.WithContext(ctx).Where("email = ?", email).First(&user).Error
 	if err != nil {
 		return nil, err
 	}
@@ -27,6 +30,60 @@ func (r *userRepositoryImpl) FindByEmail(email string) (*entity.User, error) {
 }
 
 // Create creates a new user
-func (r *userRepositoryImpl) Create(user *entity.User) error {
-	return r.db.Create(user).Error
+func (r *userRepositoryImpl) Create(ctx context.Context, user *entity.User) error {
+	return r.db.WithContext(ctx).Create(user).Error
+}
+
+// firstUserLockKey is the transaction-level advisory lock that serializes
+// creating the first account on PostgreSQL
+const firstUserLockKey = 0x7573657273 // "users"
+
+// CreateFirst creates the user only if the table is empty. On PostgreSQL
+// concurrent callers wait on an advisory lock, so they cannot all see an
+// empty table and insert; SQLite allows a single writing transaction anyway.
+func (r *userRepositoryImpl) CreateFirst(ctx context.Context, user *entity.User) (bool, error) {
+	created := false
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if tx.Dialector.Name() == "postgres" {
+			if err := tx.Exec("SELECT pg_advisory_xact_lock(?)", firstUserLockKey).Error; err != nil {
+				return err
+			}
+		}
+
+		var count int64
+		if err := tx.Model(&entity.User{}).Count(&count).Error; err != nil {
+			return err
+		}
+		if count > 0 {
+			return nil
+		}
+		if err := tx.Create(user).Error; err != nil {
+			return err
+		}
+		created = true
+		return nil
+	})
+	return created, err
+}
+
+// Count returns how many accounts exist
+func (r *userRepositoryImpl) Count(ctx context.Context) (int64, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Model(&entity.User{}).Count(&count).Error
+	return count, err
+}
+
+// MarkEmailVerified records when the user's email address was verified
+func (r *userRepositoryImpl) MarkEmailVerified(ctx context.Context, email string, verifiedAt time.Time) (int64, error) {
+	result := r.db.WithContext(ctx).Model(&entity.User{}).
+		Where("email = ? AND email_verified_at IS NULL", email).
+		Update("email_verified_at", verifiedAt)
+	return result.RowsAffected, result.Error
+}
+
+// UpdatePassword replaces the stored password hash
+func (r *userRepositoryImpl) UpdatePassword(ctx context.Context, email, hashedPassword string) error {
+	return r.db.WithContext(ctx).Model(&entity.User{}).
+		Where("email = ?", email).
+		Update("password", hashedPassword).Error
 }
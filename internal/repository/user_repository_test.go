@@ -1,7 +1,9 @@
 package repository
 
 import (
+	"context"
 	"testing"
+	"time"
 
 	"github.com/shoelfikar/voucher-management-system/internal/domain/entity"
 	"github.com/stretchr/testify/assert"
@@ -36,13 +38,35 @@ func TestUserRepository_Create_Success(t *testing.T) {
 	}
 
 	// Act
-	err := repo.Create(user)
+	err := repo.Create(context.Background(), user)
 
 	// Assert
 	assert.NoError(t, err)
 	assert.NotZero(t, user.ID)
 }
 
+func TestUserRepository_CreateFirst(t *testing.T) {
+	// Arrange
+	db := setupTestDB(t)
+	repo := NewUserRepository(db)
+
+	first := &entity.User{Email: "owner@example.com", Password: "hashed_password", Role: entity.UserRoleAdmin}
+	second := &entity.User{Email: "late@example.com", Password: "hashed_password", Role: entity.UserRoleAdmin}
+
+	// Act
+	firstCreated, firstErr := repo.CreateFirst(context.Background(), first)
+	secondCreated, secondErr := repo.CreateFirst(context.Background(), second)
+
+	// Assert
+	assert.NoError(t, firstErr)
+	assert.True(t, firstCreated)
+	assert.NotZero(t, first.ID)
+	assert.NoError(t, secondErr)
+	assert.False(t, secondCreated)
+	count, _ := repo.Count(context.Background())
+	assert.Equal(t, int64(1), count)
+}
+
 func TestUserRepository_Create_DuplicateEmail(t *testing.T) {
 	// Arrange
 	db := setupTestDB(t)
@@ -59,8 +83,8 @@ func TestUserRepository_Create_DuplicateEmail(t *testing.T) {
 	}
 
 	// Act
-	err1 := repo.Create(user1)
-	err2 := repo.Create(user2)
+	err1 := repo.Create(context.Background(), user1)
+	err2 := repo.Create(context.Background(), user2)
 
 	// Assert
 	assert.NoError(t, err1)
@@ -77,11 +101,11 @@ func TestUserRepository_FindByEmail_Success(t *testing.T) {
 		Password: "hashed_password",
 	}
 
-	err := repo.Create(user)
+	err := repo.Create(context.Background(), user)
 	assert.NoError(t, err)
 
 	// Act
-	foundUser, err := repo.FindByEmail("test@example.com")
+	foundUser, err := repo.FindByEmail(context.Background(), "test@example.com")
 
 	// Assert
 	assert.NoError(t, err)
@@ -96,7 +120,7 @@ func TestUserRepository_FindByEmail_NotFound(t *testing.T) {
 	repo := NewUserRepository(db)
 
 	// Act
-	foundUser, err := repo.FindByEmail("nonexistent@example.com")
+	foundUser, err := repo.FindByEmail(context.Background(), "nonexistent@example.com")
 
 	// Assert
 	assert.Error(t, err)
@@ -110,7 +134,7 @@ func TestUserRepository_FindByEmail_EmptyEmail(t *testing.T) {
 	repo := NewUserRepository(db)
 
 	// Act
-	foundUser, err := repo.FindByEmail("")
+	foundUser, err := repo.FindByEmail(context.Background(), "")
 
 	// Assert
 	assert.Error(t, err)
@@ -131,15 +155,73 @@ func TestUserRepository_Multiple_Users(t *testing.T) {
 
 	// Act
 	for _, user := range users {
-		err := repo.Create(user)
+		err := repo.Create(context.Background(), user)
 		assert.NoError(t, err)
 	}
 
 	// Assert - Find each user
 	for _, user := range users {
-		foundUser, err := repo.FindByEmail(user.Email)
+		foundUser, err := repo.FindByEmail(context.Background(), user.Email)
 		assert.NoError(t, err)
 		assert.NotNil(t, foundUser)
 		assert.Equal(t, user.Email, foundUser.Email)
 	}
 }
+
+func TestUserRepository_MarkEmailVerified(t *testing.T) {
+	// Arrange
+	db := setupTestDB(t)
+	repo := NewUserRepository(db)
+
+	user := &entity.User{Email: "verify@example.com", Password: "hashed_password"}
+	err := repo.Create(context.Background(), user)
+	assert.NoError(t, err)
+
+	// Act
+	firstRows, err := repo.MarkEmailVerified(context.Background(), "verify@example.com", time.Now())
+	assert.NoError(t, err)
+	secondRows, err := repo.MarkEmailVerified(context.Background(), "verify@example.com", time.Now())
+	assert.NoError(t, err)
+
+	// Assert
+	assert.Equal(t, int64(1), firstRows)
+	assert.Equal(t, int64(0), secondRows)
+
+	found, err := repo.FindByEmail(context.Background(), "verify@example.com")
+	assert.NoError(t, err)
+	assert.NotNil(t, found.EmailVerifiedAt)
+}
+
+func TestUserRepository_UpdatePassword(t *testing.T) {
+	// Arrange
+	db := setupTestDB(t)
+	repo := NewUserRepository(db)
+	assert.NoError(t, repo.Create(context.Background(), &entity.User{Email: "rehash@example.com", Password: "old_hash"}))
+
+	// Act
+	err := repo.UpdatePassword(context.Background(), "rehash@example.com", "new_hash")
+
+	// Assert
+	assert.NoError(t, err)
+	found, err := repo.FindByEmail(context.Background(), "rehash@example.com")
+	assert.NoError(t, err)
+	assert.Equal(t, "new_hash", found.Password)
+}
+
+func TestUserRepository_Count(t *testing.T) {
+	// Arrange
+	db := setupTestDB(t)
+	repo := NewUserRepository(db)
+	empty, err := repo.Count(context.Background())
+	assert.NoError(t, err)
+
+	// Act
+	assert.NoError(t, repo.Create(context.Background(), &entity.User{Email: "first@example.com", Password: "hash"}))
+	assert.NoError(t, repo.Create(context.Background(), &entity.User{Email: "second@example.com", Password: "hash"}))
+	count, err := repo.Count(context.Background())
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), empty)
+	assert.Equal(t, int64(2), count)
+}
@@ -0,0 +1,67 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/shoelfikar/voucher-management-system/internal/domain/entity"
+	"github.com/shoelfikar/voucher-management-system/internal/domain/repository"
+	"gorm.io/gorm"
+)
+
+// voucherAssignmentRepositoryImpl implements domain repository.VoucherAssignmentRepository
+type voucherAssignmentRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewVoucherAssignmentRepository creates a new voucher assignment repository instance
+func NewVoucherAssignmentRepository(db *gorm.DB) repository.VoucherAssignmentRepository {
+	return &voucherAssignmentRepositoryImpl{db: db}
+}
+
+// Create stores an assignment
+func (r *voucherAssignmentRepositoryImpl) Create(ctx context.Context, assignment *entity.VoucherAssignment) error {
+	return r.db.WithContext(ctx).Create(assignment).Error
+}
+
+// FindByVoucherAndCustomer retrieves a customer's assignment of a voucher
+func (r *voucherAssignmentRepositoryImpl) FindByVoucherAndCustomer(ctx context.Context, voucherID, customerID uint) (*entity.VoucherAssignment, error) {
+	var assignment entity.VoucherAssignment
+	err := r.db.WithContext(ctx).Where("voucher_id = ? AND customer_id = ?", voucherID, customerID).First(&assignment).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &assignment, nil
+}
+
+// FindByVoucher retrieves a page of a voucher's assignments with their customers, newest first
+func (r *voucherAssignmentRepositoryImpl) FindByVoucher(ctx context.Context, voucherID uint, page, limit int) ([]*entity.VoucherAssignment, int64, error) {
+	var assignments []*entity.VoucherAssignment
+	var total int64
+
+	query := r.db.WithContext(ctx).Model(&entity.VoucherAssignment{}).Where("voucher_id = ?", voucherID)
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	err := query.Preload("Customer").Order("assigned_at DESC, id DESC").Offset((page - 1) * limit).Limit(limit).Find(&assignments).Error
+	if err != nil {
+		return nil, 0, err
+	}
+	return assignments, total, nil
+}
+
+// DeleteExpiredBefore deletes up to limit assignments that expired before cutoff
+func (r *voucherAssignmentRepositoryImpl) DeleteExpiredBefore(ctx context.Context, cutoff time.Time, limit int) (int64, error) {
+	chunk := r.db.WithContext(ctx).Model(&entity.VoucherAssignment{}).
+		Select("id").
+		Where("expires_at < ?", cutoff).
+		Limit(limit)
+
+	result := r.db.WithContext(ctx).Where("id IN (?)", chunk).Delete(&entity.VoucherAssignment{})
+	return result.RowsAffected, result.Error
+}
@@ -0,0 +1,88 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/shoelfikar/voucher-management-system/internal/domain/entity"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func setupVoucherAssignmentTestDB(t *testing.T) *gorm.DB {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{TranslateError: true})
+	if err != nil {
+		t.Fatalf("Failed to connect to test database: %v", err)
+	}
+	if err := db.AutoMigrate(&entity.VoucherAssignment{}); err != nil {
+		t.Fatalf("Failed to migrate test database: %v", err)
+	}
+	return db
+}
+
+func TestVoucherAssignmentRepository_CreateAndFind(t *testing.T) {
+	// Arrange
+	db := setupVoucherAssignmentTestDB(t)
+	repo := NewVoucherAssignmentRepository(db)
+	now := time.Now()
+	assignment := &entity.VoucherAssignment{VoucherID: 1, CustomerID: 1, AssignedAt: now, ExpiresAt: now.AddDate(0, 0, 30)}
+
+	// Act
+	err := repo.Create(context.Background(), assignment)
+	duplicateErr := repo.Create(context.Background(), &entity.VoucherAssignment{VoucherID: 1, CustomerID: 1, AssignedAt: now, ExpiresAt: now})
+	found, findErr := repo.FindByVoucherAndCustomer(context.Background(), 1, 1)
+	missing, missingErr := repo.FindByVoucherAndCustomer(context.Background(), 1, 2)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.ErrorIs(t, duplicateErr, gorm.ErrDuplicatedKey)
+	assert.NoError(t, findErr)
+	assert.Equal(t, assignment.ID, found.ID)
+	assert.NoError(t, missingErr)
+	assert.Nil(t, missing)
+}
+
+func TestVoucherAssignmentRepository_FindByVoucher(t *testing.T) {
+	// Arrange
+	db := setupVoucherAssignmentTestDB(t)
+	repo := NewVoucherAssignmentRepository(db)
+	now := time.Now()
+	for i, customerID := range []uint{1, 2, 3} {
+		assert.NoError(t, repo.Create(context.Background(), &entity.VoucherAssignment{VoucherID: 1, CustomerID: customerID, AssignedAt: now.Add(time.Duration(i) * time.Minute), ExpiresAt: now}))
+	}
+	assert.NoError(t, repo.Create(context.Background(), &entity.VoucherAssignment{VoucherID: 2, CustomerID: 1, AssignedAt: now, ExpiresAt: now}))
+
+	// Act
+	page, total, err := repo.FindByVoucher(context.Background(), 1, 1, 2)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), total)
+	if assert.Len(t, page, 2) {
+		assert.Equal(t, uint(3), page[0].CustomerID)
+		assert.Equal(t, uint(2), page[1].CustomerID)
+	}
+}
+
+func TestVoucherAssignmentRepository_DeleteExpiredBefore(t *testing.T) {
+	// Arrange
+	db := setupVoucherAssignmentTestDB(t)
+	repo := NewVoucherAssignmentRepository(db)
+	today := time.Now().Truncate(24 * time.Hour)
+	assert.NoError(t, repo.Create(context.Background(), &entity.VoucherAssignment{VoucherID: 1, CustomerID: 1, AssignedAt: today, ExpiresAt: today.AddDate(0, 0, -3)}))
+	assert.NoError(t, repo.Create(context.Background(), &entity.VoucherAssignment{VoucherID: 1, CustomerID: 2, AssignedAt: today, ExpiresAt: today.AddDate(0, 0, -2)}))
+	assert.NoError(t, repo.Create(context.Background(), &entity.VoucherAssignment{VoucherID: 1, CustomerID: 3, AssignedAt: today, ExpiresAt: today}))
+
+	// Act
+	first, err := repo.DeleteExpiredBefore(context.Background(), today, 1)
+	second, _ := repo.DeleteExpiredBefore(context.Background(), today, 1)
+	third, _ := repo.DeleteExpiredBefore(context.Background(), today, 1)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, []int64{1, 1, 0}, []int64{first, second, third})
+	live, _ := repo.FindByVoucherAndCustomer(context.Background(), 1, 3)
+	assert.NotNil(t, live)
+}
@@ -0,0 +1,21 @@
+package repository
+
+import (
+	"github.com/shoelfikar/voucher-management-system/internal/domain/entity"
+	"github.com/shoelfikar/voucher-management-system/pkg/database"
+)
+
+// VoucherConstraints are the CHECK constraints the vouchers table is expected
+// to enforce, mirroring the validation done in the service layer
+var VoucherConstraints = []database.CheckConstraint{
+	{
+		Table:  entity.Voucher{}.TableName(),
+		Column: "discount_percent",
+		Bounds: []float64{entity.MinDiscountPercent, entity.MaxDiscountPercent},
+	},
+	{
+		Table:  entity.Voucher{}.TableName(),
+		Column: "status",
+		Values: entity.VoucherStatuses,
+	},
+}
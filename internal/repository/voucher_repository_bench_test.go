@@ -0,0 +1,97 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/shoelfikar/voucher-management-system/internal/config"
+	"github.com/shoelfikar/voucher-management-system/internal/domain/entity"
+	"github.com/shoelfikar/voucher-management-system/pkg/database"
+	"gorm.io/driver/postgres"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+// benchmarkVoucherCount is how many vouchers the benchmark tables are seeded with
+const benchmarkVoucherCount = 10000
+
+// benchmarkDB is a database the benchmarks run against
+type benchmarkDB struct {
+	name string
+	db   *gorm.DB
+}
+
+// benchmarkDatabases opens SQLite and, when BENCH_POSTGRES_DSN is set,
+// PostgreSQL with the production statement settings. The PostgreSQL database
+// must be a scratch one: its benchmark tables are dropped and recreated.
+func benchmarkDatabases(b *testing.B, models ...interface{}) []benchmarkDB {
+	cfg := &config.DatabaseConfig{PrepareStmt: true, StatementCacheSize: 500, StatementCacheTTL: time.Hour}
+
+	sqliteDB, err := gorm.Open(sqlite.Open(":memory:"), database.NewGormConfig(cfg))
+	if err != nil {
+		b.Fatalf("Failed to connect to SQLite: %v", err)
+	}
+	// Every connection to :memory: is a separate database
+	sqlDB, err := sqliteDB.DB()
+	if err != nil {
+		b.Fatalf("Failed to get SQLite instance: %v", err)
+	}
+	sqlDB.SetMaxOpenConns(1)
+	dbs := []benchmarkDB{{"sqlite", sqliteDB}}
+
+	if dsn := os.Getenv("BENCH_POSTGRES_DSN"); dsn != "" {
+		postgresDB, err := gorm.Open(postgres.Open(dsn), database.NewGormConfig(cfg))
+		if err != nil {
+			b.Fatalf("Failed to connect to PostgreSQL: %v", err)
+		}
+		if err := postgresDB.Migrator().DropTable(models...); err != nil {
+			b.Fatalf("Failed to reset PostgreSQL: %v", err)
+		}
+		dbs = append(dbs, benchmarkDB{"postgres", postgresDB})
+	}
+
+	for _, target := range dbs {
+		if err := target.db.AutoMigrate(models...); err != nil {
+			b.Fatalf("Failed to migrate %s: %v", target.name, err)
+		}
+	}
+	return dbs
+}
+
+func seedBenchmarkVouchers(b *testing.B, db *gorm.DB) {
+	vouchers := make([]*entity.Voucher, benchmarkVoucherCount)
+	for i := range vouchers {
+		vouchers[i] = &entity.Voucher{
+			VoucherCode:     fmt.Sprintf("BENCH%05d", i),
+			DiscountPercent: 10,
+			ExpiryDate:      time.Now().AddDate(1, 0, 0),
+			Status:          entity.VoucherStatusActive,
+		}
+	}
+	if err := db.CreateInBatches(vouchers, 500).Error; err != nil {
+		b.Fatalf("Failed to seed vouchers: %v", err)
+	}
+}
+
+func BenchmarkVoucherRepository_FindByVoucherCode(b *testing.B) {
+	for _, target := range benchmarkDatabases(b, &entity.Voucher{}) {
+		seedBenchmarkVouchers(b, target.db)
+		repo := NewVoucherRepository(target.db)
+
+		b.Run(target.name, func(b *testing.B) {
+			b.ReportAllocs()
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				// Lower case exercises the case-insensitive match used at checkout
+				voucher, err := repo.FindByVoucherCode(context.Background(), fmt.Sprintf("bench%05d", i%benchmarkVoucherCount))
+				if err != nil || voucher == nil {
+					b.Fatalf("Failed to find voucher: %v", err)
+				}
+			}
+		})
+	}
+}
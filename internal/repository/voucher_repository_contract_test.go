@@ -0,0 +1,144 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/shoelfikar/voucher-management-system/internal/domain/repository"
+	"github.com/shoelfikar/voucher-management-system/pkg/fieldcrypt"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+// The contract tests pin down the semantics documented on the repository
+// interfaces, such as FindByVoucherCode returning nil, nil when nothing
+// matches while FindByID returns gorm.ErrRecordNotFound. Service tests mock
+// these interfaces, so every implementation must behave the way the mocks
+// are set up to behave; new implementations should be added to the lists below.
+
+var voucherRepositoryImplementations = map[string]func(db *gorm.DB) repository.VoucherRepository{
+	"gorm": NewVoucherRepository,
+	"cached": func(db *gorm.DB) repository.VoucherRepository {
+		return NewCachedVoucherRepository(NewVoucherRepository(db), time.Minute)
+	},
+	"encrypted": func(db *gorm.DB) repository.VoucherRepository {
+		cipher, _ := fieldcrypt.NewCipher(make([]byte, fieldcrypt.KeySize))
+		return NewEncryptedVoucherRepository(NewVoucherRepository(db), cipher, []string{"secret"})
+	},
+}
+
+func TestVoucherRepositoryContract(t *testing.T) {
+	for name, newRepo := range voucherRepositoryImplementations {
+		t.Run(name, func(t *testing.T) {
+			runVoucherRepositoryContract(t, func() repository.VoucherRepository {
+				return newRepo(setupVoucherTestDB(t))
+			})
+		})
+	}
+}
+
+func runVoucherRepositoryContract(t *testing.T, newRepo func() repository.VoucherRepository) {
+	t.Run("FindByID missing returns ErrRecordNotFound", func(t *testing.T) {
+		voucher, err := newRepo().FindByID(context.Background(), 999)
+
+		assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+		assert.Nil(t, voucher)
+	})
+
+	t.Run("FindByVoucherCode missing returns nil, nil", func(t *testing.T) {
+		voucher, err := newRepo().FindByVoucherCode(context.Background(), "MISSING")
+
+		assert.NoError(t, err)
+		assert.Nil(t, voucher)
+	})
+
+	t.Run("FindByExternalID missing returns nil, nil", func(t *testing.T) {
+		voucher, err := newRepo().FindByExternalID(context.Background(), "missing")
+
+		assert.NoError(t, err)
+		assert.Nil(t, voucher)
+	})
+
+	t.Run("Update missing affects no rows", func(t *testing.T) {
+		voucher := createTestVoucher("MISSING", 10.0)
+		voucher.ID = 999
+
+		rows, err := newRepo().Update(context.Background(), voucher)
+
+		assert.NoError(t, err)
+		assert.Zero(t, rows)
+	})
+
+	t.Run("Delete missing affects no rows", func(t *testing.T) {
+		rows, err := newRepo().Delete(context.Background(), 999)
+
+		assert.NoError(t, err)
+		assert.Zero(t, rows)
+	})
+
+	t.Run("Approve of an active voucher affects no rows", func(t *testing.T) {
+		repo := newRepo()
+		voucher := createTestVoucher("ACTIVE1", 10.0)
+		assert.NoError(t, repo.Create(context.Background(), voucher))
+
+		rows, err := repo.Approve(context.Background(), voucher.ID, "approver@example.com", time.Now())
+
+		assert.NoError(t, err)
+		assert.Zero(t, rows)
+	})
+
+	t.Run("CheckDuplicateCodes returns only existing codes as given", func(t *testing.T) {
+		repo := newRepo()
+		assert.NoError(t, repo.Create(context.Background(), createTestVoucher("EXISTING1", 10.0)))
+
+		none, noneErr := repo.CheckDuplicateCodes(context.Background(), []string{"NEW1"})
+		some, someErr := repo.CheckDuplicateCodes(context.Background(), []string{"existing1", "NEW1"})
+
+		assert.NoError(t, noneErr)
+		assert.Empty(t, none)
+		assert.NoError(t, someErr)
+		assert.Equal(t, []string{"existing1"}, some)
+	})
+
+	t.Run("DeactivateByCodes missing affects no rows", func(t *testing.T) {
+		rows, err := newRepo().DeactivateByCodes(context.Background(), []string{"MISSING"})
+
+		assert.NoError(t, err)
+		assert.Zero(t, rows)
+	})
+
+	t.Run("SuggestCodes without matches returns an empty list", func(t *testing.T) {
+		codes, err := newRepo().SuggestCodes(context.Background(), "missing", 10)
+
+		assert.NoError(t, err)
+		assert.Empty(t, codes)
+	})
+}
+
+var userRepositoryImplementations = map[string]func(db *gorm.DB) repository.UserRepository{
+	"gorm": NewUserRepository,
+	"cached": func(db *gorm.DB) repository.UserRepository {
+		return NewCachedUserRepository(NewUserRepository(db), time.Minute)
+	},
+}
+
+func TestUserRepositoryContract(t *testing.T) {
+	for name, newRepo := range userRepositoryImplementations {
+		t.Run(name, func(t *testing.T) {
+			t.Run("FindByEmail missing returns ErrRecordNotFound", func(t *testing.T) {
+				user, err := newRepo(setupTestDB(t)).FindByEmail(context.Background(), "missing@example.com")
+
+				assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+				assert.Nil(t, user)
+			})
+
+			t.Run("MarkEmailVerified missing affects no rows", func(t *testing.T) {
+				rows, err := newRepo(setupTestDB(t)).MarkEmailVerified(context.Background(), "missing@example.com", time.Now())
+
+				assert.NoError(t, err)
+				assert.Zero(t, rows)
+			})
+		})
+	}
+}
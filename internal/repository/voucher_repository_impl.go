@@ -1,9 +1,15 @@
 package repository
 
 import (
+	"context"
+	"strings"
+	"time"
+
 	"github.com/shoelfikar/voucher-management-system/internal/domain/entity"
 	"github.com/shoelfikar/voucher-management-system/internal/domain/repository"
+	"github.com/shoelfikar/voucher-management-system/pkg/utils"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 // voucherRepositoryImpl implements repository.VoucherRepository
@@ -17,14 +23,22 @@ func NewVoucherRepository(db *gorm.DB) repository.VoucherRepository {
 }
 
 // FindAll retrieves all vouchers with pagination, search, and sorting
-func (r *voucherRepositoryImpl) FindAll(page, limit int, search, sortBy, sortOrder string) ([]*entity.Voucher, int64, error) {
+func (r *voucherRepositoryImpl) FindAll(ctx context.Context, page, limit int, search string, sort []utils.SortField) ([]*entity.Voucher, int64, error) {
+	return r.findPage(r.db.WithContext(ctx).Model(&entity.Voucher{}), page, limit, search, sort)
+}
+
+// FindAllByPartner retrieves the vouchers issued by a partner with pagination, search, and sorting
+func (r *voucherRepositoryImpl) FindAllByPartner(ctx context.Context, partnerID uint, page, limit int, search string, sort []utils.SortField) ([]*entity.Voucher, int64, error) {
+	return r.findPage(r.db.WithContext(ctx).Model(&entity.Voucher{}).Where("partner_id = ?", partnerID), page, limit, search, sort)
+}
+
+// findPage applies search, sorting and pagination to query and returns the page with the total number of matches
+func (r *voucherRepositoryImpl) findPage(query *gorm.DB, page, limit int, search string, sort []utils.SortField) ([]*entity.Voucher, int64, error) {
 	var vouchers []*entity.Voucher
 	var total int64
 
 	offset := (page - 1) * limit
 
-	query := r.db.Model(&entity.Voucher{})
-
 	if search != "" {
 		query = query.Where("LOWER(voucher_code) LIKE LOWER(?)", "%"+search+"%")
 	}
@@ -33,9 +47,8 @@ func (r *voucherRepositoryImpl) FindAll(page, limit int, search, sortBy, sortOrd
 		return nil, 0, err
 	}
 
-	if sortBy != "" {
-		order := sortBy + " " + sortOrder
-		query = query.Order(order)
+	if len(sort) > 0 {
+		query = query.Order(utils.OrderClause(sort))
 	} else {
 		query = query.Order("created_at desc")
 	}
@@ -50,9 +63,9 @@ func (r *voucherRepositoryImpl) FindAll(page, limit int, search, sortBy, sortOrd
 }
 
 // FindByID retrieves a voucher by ID
-func (r *voucherRepositoryImpl) FindByID(id uint) (*entity.Voucher, error) {
+func (r *voucherRepositoryImpl) FindByID(ctx context.Context, id uint) (*entity.Voucher, error) {
 	var voucher entity.Voucher
-	err := r.db.First(&voucher, id).Error
+	err := r.db.WithContext(ctx).First(&voucher, id).Error
 	if err != nil {
 		return nil, err
 	}
@@ -60,24 +73,134 @@ func (r *voucherRepositoryImpl) FindByID(id uint) (*entity.Voucher, error) {
 }
 
 // Create creates a new voucher
-func (r *voucherRepositoryImpl) Create(voucher *entity.Voucher) error {
-	return r.db.Create(voucher).Error
+func (r *voucherRepositoryImpl) Create(ctx context.Context, voucher *entity.Voucher) error {
+	return r.db.WithContext(ctx).Create(voucher).Error
+}
+
+// Update updates an existing voucher and returns the number of rows affected.
+// Only live rows matching the voucher ID are touched, so a missing or soft
+// deleted voucher affects no rows instead of being recreated. A zero
+// MaxRedemptionsPerUser leaves the stored per-customer cap alone, and an
+// empty DiscountType the stored discount type. The stored status is kept,
+// except that a Status of pending_approval sends an active voucher back for
+// approval; inactive vouchers stay inactive so approving cannot reactivate
+// them. The voucher is refreshed with the stored row, status included.
+func (r *voucherRepositoryImpl) Update(ctx context.Context, voucher *entity.Voucher) (int64, error) {
+	columns := []string{"voucher_code", "discount_percent", "discount_amount", "currency", "expiry_date", "rules", "display_name", "description", "terms_url", "metadata", "segment_id", "validity_days", "max_redemptions", "updated_by"}
+	if voucher.DiscountType != "" {
+		columns = append(columns, "discount_type")
+	}
+	if voucher.MaxRedemptionsPerUser > 0 {
+		columns = append(columns, "max_redemptions_per_user")
+	}
+	sendForApproval := voucher.Status == entity.VoucherStatusPendingApproval
+
+	var rowsAffected int64
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(voucher).
+			Clauses(clause.Returning{}).
+			Where("id = ?", voucher.ID).
+			Select(columns).
+			Updates(voucher)
+		rowsAffected = result.RowsAffected
+		if result.Error != nil || rowsAffected == 0 || !sendForApproval {
+			return result.Error
+		}
+
+		pending := tx.Model(&entity.Voucher{}).
+			Where("id = ? AND status = ?", voucher.ID, entity.VoucherStatusActive).
+			Updates(map[string]interface{}{
+				"status":      entity.VoucherStatusPendingApproval,
+				"approved_by": "",
+				"approved_at": nil,
+			})
+		if pending.Error != nil {
+			return pending.Error
+		}
+		if pending.RowsAffected > 0 {
+			voucher.Status = entity.VoucherStatusPendingApproval
+			voucher.ApprovedBy = ""
+			voucher.ApprovedAt = nil
+		}
+		return nil
+	})
+	return rowsAffected, err
+}
+
+// Delete soft deletes a voucher by ID and returns the number of rows affected
+func (r *voucherRepositoryImpl) Delete(ctx context.Context, id uint) (int64, error) {
+	result := r.db.WithContext(ctx).Delete(&entity.Voucher{}, id)
+	return result.RowsAffected, result.Error
+}
+
+// Approve activates a voucher that is pending approval and returns the number of rows affected.
+// The status condition makes concurrent approvals of the same voucher succeed only once.
+func (r *voucherRepositoryImpl) Approve(ctx context.Context, id uint, approvedBy string, approvedAt time.Time) (int64, error) {
+	result := r.db.WithContext(ctx).Model(&entity.Voucher{}).
+		Where("id = ? AND status = ?", id, entity.VoucherStatusPendingApproval).
+		Updates(map[string]interface{}{
+			"status":      entity.VoucherStatusActive,
+			"approved_by": approvedBy,
+			"approved_at": approvedAt,
+		})
+	return result.RowsAffected, result.Error
+}
+
+// FindByVoucherCode retrieves a voucher by voucher code, ignoring case
+func (r *voucherRepositoryImpl) FindByVoucherCode(ctx context.Context, code string) (*entity.Voucher, error) {
+	var voucher entity.Voucher
+	err := r.db.WithContext(ctx).Where("LOWER(voucher_code) = LOWER(?)", code).First(&voucher).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &voucher, nil
 }
 
-// Update updates an existing voucher
-func (r *voucherRepositoryImpl) Update(voucher *entity.Voucher) error {
-	return r.db.Save(voucher).Error
+// FindByIDs retrieves the vouchers with the given IDs, ordered by ID
+func (r *voucherRepositoryImpl) FindByIDs(ctx context.Context, ids []uint) ([]*entity.Voucher, error) {
+	vouchers := []*entity.Voucher{}
+	err := r.db.WithContext(ctx).Where("id IN ?", ids).Order("id").Find(&vouchers).Error
+	return vouchers, err
 }
 
-// Delete soft deletes a voucher by ID
-func (r *voucherRepositoryImpl) Delete(id uint) error {
-	return r.db.Delete(&entity.Voucher{}, id).Error
+// FindByVoucherCodes retrieves the vouchers with the given codes, ignoring case, ordered by ID
+func (r *voucherRepositoryImpl) FindByVoucherCodes(ctx context.Context, codes []string) ([]*entity.Voucher, error) {
+	lowered := make([]string, len(codes))
+	for i, code := range codes {
+		lowered[i] = strings.ToLower(code)
+	}
+
+	vouchers := []*entity.Voucher{}
+	err := r.db.WithContext(ctx).Where("LOWER(voucher_code) IN ?", lowered).Order("id").Find(&vouchers).Error
+	return vouchers, err
+}
+
+// SuggestCodes returns up to limit voucher codes starting with prefix, ignoring case.
+// The LOWER(voucher_code) prefix index keeps this cheap for autocomplete.
+func (r *voucherRepositoryImpl) SuggestCodes(ctx context.Context, prefix string, limit int) ([]string, error) {
+	codes := []string{}
+
+	err := r.db.WithContext(ctx).Model(&entity.Voucher{}).
+		Where(`LOWER(voucher_code) LIKE ? ESCAPE '\'`, likePrefixPattern(strings.ToLower(prefix))).
+		Order("voucher_code").
+		Limit(limit).
+		Pluck("voucher_code", &codes).
+		Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	return codes, nil
 }
 
-// FindByVoucherCode retrieves a voucher by voucher code
-func (r *voucherRepositoryImpl) FindByVoucherCode(code string) (*entity.Voucher, error) {
+// FindByExternalID retrieves a voucher by its client-provided external ID
+func (r *voucherRepositoryImpl) FindByExternalID(ctx context.Context, externalID string) (*entity.Voucher, error) {
 	var voucher entity.Voucher
-	err := r.db.Where("voucher_code = ?", code).First(&voucher).Error
+	err := r.db.WithContext(ctx).Where("external_id = ?", externalID).First(&voucher).Error
 	if err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return nil, nil
@@ -87,23 +210,236 @@ func (r *voucherRepositoryImpl) FindByVoucherCode(code string) (*entity.Voucher,
 	return &voucher, nil
 }
 
+// CheckDuplicateExternalIDs checks which external IDs already exist.
+// Soft-deleted vouchers are included since they still hold the unique index entry.
+func (r *voucherRepositoryImpl) CheckDuplicateExternalIDs(ctx context.Context, externalIDs []string) ([]string, error) {
+	var existingIDs []string
+
+	err := r.db.WithContext(ctx).Unscoped().Model(&entity.Voucher{}).
+		Where("external_id IN ?", externalIDs).
+		Pluck("external_id", &existingIDs).
+		Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	return existingIDs, nil
+}
+
 // BulkCreate creates multiple vouchers at once
-func (r *voucherRepositoryImpl) BulkCreate(vouchers []*entity.Voucher) error {
-	return r.db.Create(&vouchers).Error
+func (r *voucherRepositoryImpl) BulkCreate(ctx context.Context, vouchers []*entity.Voucher) error {
+	return r.db.WithContext(ctx).Create(&vouchers).Error
+}
+
+// BulkCreateSkipConflicts creates vouchers one by one with ON CONFLICT DO NOTHING.
+// Rows are inserted individually so skipped codes are known exactly; it is the
+// fallback for when a BulkCreate races with another import.
+func (r *voucherRepositoryImpl) BulkCreateSkipConflicts(ctx context.Context, vouchers []*entity.Voucher) ([]string, error) {
+	skippedCodes := []string{}
+	for _, voucher := range vouchers {
+		result := r.db.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(voucher)
+		if result.Error != nil {
+			return nil, result.Error
+		}
+		if result.RowsAffected == 0 {
+			voucher.ID = 0
+			skippedCodes = append(skippedCodes, voucher.VoucherCode)
+		}
+	}
+	return skippedCodes, nil
 }
 
-// CheckDuplicateCodes checks which voucher codes already exist
-func (r *voucherRepositoryImpl) CheckDuplicateCodes(codes []string) ([]string, error) {
-	var existingCodes []string
+// CheckDuplicateCodes checks which of the given voucher codes already exist,
+// ignoring case. The codes are returned as given, not as stored.
+func (r *voucherRepositoryImpl) CheckDuplicateCodes(ctx context.Context, codes []string) ([]string, error) {
+	lowered := make([]string, len(codes))
+	for i, code := range codes {
+		lowered[i] = strings.ToLower(code)
+	}
+
+	var storedCodes []string
 
-	err := r.db.Model(&entity.Voucher{}).
-		Where("voucher_code IN ?", codes).
-		Pluck("voucher_code", &existingCodes).
+	err := r.db.WithContext(ctx).Model(&entity.Voucher{}).
+		Where("LOWER(voucher_code) IN ?", lowered).
+		Pluck("LOWER(voucher_code)", &storedCodes).
 		Error
 
 	if err != nil {
 		return nil, err
 	}
 
+	stored := make(map[string]bool, len(storedCodes))
+	for _, code := range storedCodes {
+		stored[code] = true
+	}
+
+	existingCodes := []string{}
+	for i, code := range codes {
+		if stored[lowered[i]] {
+			existingCodes = append(existingCodes, code)
+		}
+	}
+
 	return existingCodes, nil
 }
+
+// DeactivateByCodes marks the vouchers with the given codes inactive, ignoring case
+func (r *voucherRepositoryImpl) DeactivateByCodes(ctx context.Context, codes []string) (int64, error) {
+	lowered := make([]string, len(codes))
+	for i, code := range codes {
+		lowered[i] = strings.ToLower(code)
+	}
+
+	result := r.db.WithContext(ctx).Model(&entity.Voucher{}).
+		Where("LOWER(voucher_code) IN ? AND status <> ?", lowered, entity.VoucherStatusInactive).
+		Update("status", entity.VoucherStatusInactive)
+	return result.RowsAffected, result.Error
+}
+
+// DeactivateByPrefix marks up to limit vouchers whose code starts with prefix inactive
+func (r *voucherRepositoryImpl) DeactivateByPrefix(ctx context.Context, prefix string, limit int) (int64, error) {
+	chunk := r.db.WithContext(ctx).Model(&entity.Voucher{}).
+		Select("id").
+		Where(`voucher_code LIKE ? ESCAPE '\' AND status <> ?`, likePrefixPattern(prefix), entity.VoucherStatusInactive).
+		Limit(limit)
+
+	result := r.db.WithContext(ctx).Model(&entity.Voucher{}).
+		Where("id IN (?)", chunk).
+		Update("status", entity.VoucherStatusInactive)
+	return result.RowsAffected, result.Error
+}
+
+// FindIDsByFilter returns up to limit IDs of vouchers matching filter, after afterID
+func (r *voucherRepositoryImpl) FindIDsByFilter(ctx context.Context, filter repository.VoucherFilter, afterID uint, limit int) ([]uint, error) {
+	query := r.db.WithContext(ctx).Model(&entity.Voucher{}).Where("id > ?", afterID)
+	if len(filter.IDs) > 0 {
+		query = query.Where("id IN ?", filter.IDs)
+	}
+	if len(filter.Codes) > 0 {
+		lowered := make([]string, len(filter.Codes))
+		for i, code := range filter.Codes {
+			lowered[i] = strings.ToLower(code)
+		}
+		query = query.Where("LOWER(voucher_code) IN ?", lowered)
+	}
+	if filter.CodePrefix != "" {
+		query = query.Where(`LOWER(voucher_code) LIKE ? ESCAPE '\'`, likePrefixPattern(strings.ToLower(filter.CodePrefix)))
+	}
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+	if filter.ExcludeStatus != "" {
+		query = query.Where("status <> ?", filter.ExcludeStatus)
+	}
+	if filter.SegmentID != nil {
+		query = query.Where("segment_id = ?", *filter.SegmentID)
+	}
+	if filter.PartnerID != nil {
+		query = query.Where("partner_id = ?", *filter.PartnerID)
+	}
+	if filter.DiscountType != "" {
+		query = query.Where("discount_type = ?", filter.DiscountType)
+	}
+
+	var ids []uint
+	err := query.Order("id ASC").Limit(limit).Pluck("id", &ids).Error
+	return ids, err
+}
+
+// UpdateFields sets the given columns on the vouchers with the given IDs
+func (r *voucherRepositoryImpl) UpdateFields(ctx context.Context, ids []uint, fields map[string]interface{}) (int64, error) {
+	result := r.db.WithContext(ctx).Model(&entity.Voucher{}).Where("id IN ?", ids).Updates(fields)
+	return result.RowsAffected, result.Error
+}
+
+// UpdateFieldsForApproval sets the given columns on the vouchers with the
+// given IDs and sends the active ones among them back for approval
+func (r *voucherRepositoryImpl) UpdateFieldsForApproval(ctx context.Context, ids []uint, fields map[string]interface{}) (int64, []uint, error) {
+	var rowsAffected int64
+	var pending []entity.Voucher
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(&pending).
+			Clauses(clause.Returning{Columns: []clause.Column{{Name: "id"}}}).
+			Where("id IN ? AND status = ?", ids, entity.VoucherStatusActive).
+			Updates(map[string]interface{}{
+				"status":      entity.VoucherStatusPendingApproval,
+				"approved_by": "",
+				"approved_at": nil,
+			}).Error
+		if err != nil {
+			return err
+		}
+
+		result := tx.Model(&entity.Voucher{}).Where("id IN ?", ids).Updates(fields)
+		rowsAffected = result.RowsAffected
+		return result.Error
+	})
+	if err != nil {
+		return 0, nil, err
+	}
+
+	pendingIDs := make([]uint, len(pending))
+	for i, voucher := range pending {
+		pendingIDs[i] = voucher.ID
+	}
+	return rowsAffected, pendingIDs, nil
+}
+
+// CountExpiredBefore counts vouchers that expired before cutoff
+func (r *voucherRepositoryImpl) CountExpiredBefore(ctx context.Context, cutoff time.Time, includeArchived bool) (int64, error) {
+	var count int64
+	err := r.expiredBefore(ctx, cutoff, includeArchived).Count(&count).Error
+	return count, err
+}
+
+// FindExpiredCodesBefore returns up to limit codes of vouchers that expired before cutoff, oldest first
+func (r *voucherRepositoryImpl) FindExpiredCodesBefore(ctx context.Context, cutoff time.Time, includeArchived bool, limit int) ([]string, error) {
+	var codes []string
+	err := r.expiredBefore(ctx, cutoff, includeArchived).
+		Order("expiry_date, id").
+		Limit(limit).
+		Pluck("voucher_code", &codes).Error
+	return codes, err
+}
+
+// ArchiveExpiredBefore soft deletes up to limit vouchers that expired before cutoff
+func (r *voucherRepositoryImpl) ArchiveExpiredBefore(ctx context.Context, cutoff time.Time, limit int) (int64, error) {
+	chunk := r.expiredBefore(ctx, cutoff, false).Select("id").Limit(limit)
+
+	result := r.db.WithContext(ctx).Where("id IN (?)", chunk).Delete(&entity.Voucher{})
+	return result.RowsAffected, result.Error
+}
+
+// PurgeExpiredBefore permanently deletes up to limit vouchers that expired before cutoff
+func (r *voucherRepositoryImpl) PurgeExpiredBefore(ctx context.Context, cutoff time.Time, limit int) (int64, error) {
+	chunk := r.expiredBefore(ctx, cutoff, true).Select("id").Limit(limit)
+
+	result := r.db.WithContext(ctx).Unscoped().Where("id IN (?)", chunk).Delete(&entity.Voucher{})
+	return result.RowsAffected, result.Error
+}
+
+// FindChangedSince returns vouchers changed after since, including soft-deleted ones
+func (r *voucherRepositoryImpl) FindChangedSince(ctx context.Context, since time.Time, afterID uint, limit int) ([]*entity.Voucher, error) {
+	var vouchers []*entity.Voucher
+	// Soft deletes only set deleted_at, so it is checked alongside updated_at
+	err := r.db.WithContext(ctx).Unscoped().
+		Where("(updated_at > ? OR deleted_at > ?) AND id > ?", since, since, afterID).
+		Order("id").
+		Limit(limit).
+		Find(&vouchers).Error
+	return vouchers, err
+}
+
+func (r *voucherRepositoryImpl) expiredBefore(ctx context.Context, cutoff time.Time, includeArchived bool) *gorm.DB {
+	query := r.db.WithContext(ctx).Model(&entity.Voucher{})
+	if includeArchived {
+		query = query.Unscoped()
+	}
+	return query.Where("expiry_date < ?", cutoff)
+}
+
+// likePrefixPattern escapes LIKE wildcards so prefix is matched literally
+func likePrefixPattern(prefix string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(prefix) + "%"
+}
@@ -1,10 +1,13 @@
 package repository
 
 import (
+	"context"
 	"testing"
 	"time"
 
 	"github.com/shoelfikar/voucher-management-system/internal/domain/entity"
+	"github.com/shoelfikar/voucher-management-system/internal/domain/repository"
+	"github.com/shoelfikar/voucher-management-system/pkg/utils"
 	"github.com/stretchr/testify/assert"
 	"gorm.io/driver/sqlite"
 	"gorm.io/gorm"
@@ -43,7 +46,7 @@ func TestVoucherRepository_Create_Success(t *testing.T) {
 	voucher := createTestVoucher("TEST123", 10.0)
 
 	// Act
-	err := repo.Create(voucher)
+	err := repo.Create(context.Background(), voucher)
 
 	// Assert
 	assert.NoError(t, err)
@@ -52,6 +55,31 @@ func TestVoucherRepository_Create_Success(t *testing.T) {
 	assert.NotZero(t, voucher.UpdatedAt)
 }
 
+func TestVoucherRepository_Create_FixedAmount(t *testing.T) {
+	// Arrange
+	db := setupVoucherTestDB(t)
+	repo := NewVoucherRepository(db)
+
+	fixed := &entity.Voucher{VoucherCode: "RP50K", DiscountType: entity.DiscountTypeFixedAmount, DiscountAmount: 5000000, Currency: "IDR", ExpiryDate: time.Now().Add(24 * time.Hour)}
+	noCurrency := &entity.Voucher{VoucherCode: "NOCUR", DiscountType: entity.DiscountTypeFixedAmount, DiscountAmount: 50000, ExpiryDate: time.Now().Add(24 * time.Hour)}
+	percent := createTestVoucher("PCT10", 10.0)
+
+	// Act
+	err := repo.Create(context.Background(), fixed)
+	noCurrencyErr := repo.Create(context.Background(), noCurrency)
+	repo.Create(context.Background(), percent)
+	found, _ := repo.FindByID(context.Background(), fixed.ID)
+	percentIDs, _ := repo.FindIDsByFilter(context.Background(), repository.VoucherFilter{DiscountType: entity.DiscountTypePercent}, 0, 10)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Error(t, noCurrencyErr)
+	assert.Equal(t, int64(5000000), found.DiscountAmount)
+	assert.Equal(t, "IDR", found.Currency)
+	assert.Zero(t, found.DiscountPercent)
+	assert.Equal(t, []uint{percent.ID}, percentIDs)
+}
+
 func TestVoucherRepository_Create_DuplicateCode(t *testing.T) {
 	// Arrange
 	db := setupVoucherTestDB(t)
@@ -61,8 +89,8 @@ func TestVoucherRepository_Create_DuplicateCode(t *testing.T) {
 	voucher2 := createTestVoucher("TEST123", 20.0)
 
 	// Act
-	err1 := repo.Create(voucher1)
-	err2 := repo.Create(voucher2)
+	err1 := repo.Create(context.Background(), voucher1)
+	err2 := repo.Create(context.Background(), voucher2)
 
 	// Assert
 	assert.NoError(t, err1)
@@ -76,11 +104,11 @@ func TestVoucherRepository_FindByID_Success(t *testing.T) {
 	repo := NewVoucherRepository(db)
 
 	voucher := createTestVoucher("TEST123", 10.0)
-	err := repo.Create(voucher)
+	err := repo.Create(context.Background(), voucher)
 	assert.NoError(t, err)
 
 	// Act
-	foundVoucher, err := repo.FindByID(voucher.ID)
+	foundVoucher, err := repo.FindByID(context.Background(), voucher.ID)
 
 	// Assert
 	assert.NoError(t, err)
@@ -96,7 +124,7 @@ func TestVoucherRepository_FindByID_NotFound(t *testing.T) {
 	repo := NewVoucherRepository(db)
 
 	// Act
-	foundVoucher, err := repo.FindByID(999)
+	foundVoucher, err := repo.FindByID(context.Background(), 999)
 
 	// Assert
 	assert.Error(t, err)
@@ -104,6 +132,23 @@ func TestVoucherRepository_FindByID_NotFound(t *testing.T) {
 	assert.Equal(t, gorm.ErrRecordNotFound, err)
 }
 
+func TestVoucherRepository_FindByID_CancelledContext(t *testing.T) {
+	// Arrange
+	db := setupVoucherTestDB(t)
+	repo := NewVoucherRepository(db)
+	voucher := createTestVoucher("TEST123", 10.0)
+	assert.NoError(t, repo.Create(context.Background(), voucher))
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	// Act
+	foundVoucher, err := repo.FindByID(ctx, voucher.ID)
+
+	// Assert
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Nil(t, foundVoucher)
+}
+
 // Test FindByVoucherCode
 func TestVoucherRepository_FindByVoucherCode_Success(t *testing.T) {
 	// Arrange
@@ -111,11 +156,11 @@ func TestVoucherRepository_FindByVoucherCode_Success(t *testing.T) {
 	repo := NewVoucherRepository(db)
 
 	voucher := createTestVoucher("TEST123", 10.0)
-	err := repo.Create(voucher)
+	err := repo.Create(context.Background(), voucher)
 	assert.NoError(t, err)
 
 	// Act
-	foundVoucher, err := repo.FindByVoucherCode("TEST123")
+	foundVoucher, err := repo.FindByVoucherCode(context.Background(), "TEST123")
 
 	// Assert
 	assert.NoError(t, err)
@@ -129,13 +174,57 @@ func TestVoucherRepository_FindByVoucherCode_NotFound(t *testing.T) {
 	repo := NewVoucherRepository(db)
 
 	// Act
-	foundVoucher, err := repo.FindByVoucherCode("NONEXISTENT")
+	foundVoucher, err := repo.FindByVoucherCode(context.Background(), "NONEXISTENT")
 
 	// Assert
 	assert.NoError(t, err)
 	assert.Nil(t, foundVoucher)
 }
 
+func TestVoucherRepository_FindByIDs(t *testing.T) {
+	// Arrange
+	db := setupVoucherTestDB(t)
+	repo := NewVoucherRepository(db)
+
+	first := createTestVoucher("FIRST", 10.0)
+	second := createTestVoucher("SECOND", 10.0)
+	deleted := createTestVoucher("DELETED", 10.0)
+	assert.NoError(t, repo.Create(context.Background(), first))
+	assert.NoError(t, repo.Create(context.Background(), second))
+	assert.NoError(t, repo.Create(context.Background(), deleted))
+	_, err := repo.Delete(context.Background(), deleted.ID)
+	assert.NoError(t, err)
+
+	// Act
+	vouchers, err := repo.FindByIDs(context.Background(), []uint{second.ID, deleted.ID, first.ID, 9999})
+
+	// Assert
+	assert.NoError(t, err)
+	if assert.Len(t, vouchers, 2) {
+		assert.Equal(t, "FIRST", vouchers[0].VoucherCode)
+		assert.Equal(t, "SECOND", vouchers[1].VoucherCode)
+	}
+}
+
+func TestVoucherRepository_FindByVoucherCodes_IgnoresCase(t *testing.T) {
+	// Arrange
+	db := setupVoucherTestDB(t)
+	repo := NewVoucherRepository(db)
+
+	assert.NoError(t, repo.Create(context.Background(), createTestVoucher("Summer10", 10.0)))
+	assert.NoError(t, repo.Create(context.Background(), createTestVoucher("WINTER20", 20.0)))
+
+	// Act
+	vouchers, err := repo.FindByVoucherCodes(context.Background(), []string{"winter20", "SUMMER10", "MISSING"})
+
+	// Assert
+	assert.NoError(t, err)
+	if assert.Len(t, vouchers, 2) {
+		assert.Equal(t, "Summer10", vouchers[0].VoucherCode)
+		assert.Equal(t, "WINTER20", vouchers[1].VoucherCode)
+	}
+}
+
 // Test Update
 func TestVoucherRepository_Update_Success(t *testing.T) {
 	// Arrange
@@ -143,24 +232,94 @@ func TestVoucherRepository_Update_Success(t *testing.T) {
 	repo := NewVoucherRepository(db)
 
 	voucher := createTestVoucher("TEST123", 10.0)
-	err := repo.Create(voucher)
+	err := repo.Create(context.Background(), voucher)
 	assert.NoError(t, err)
 
 	// Act
 	voucher.VoucherCode = "UPDATED123"
 	voucher.DiscountPercent = 20.0
-	err = repo.Update(voucher)
+	rowsAffected, err := repo.Update(context.Background(), voucher)
 
 	// Assert
 	assert.NoError(t, err)
+	assert.Equal(t, int64(1), rowsAffected)
 
 	// Verify update
-	foundVoucher, err := repo.FindByID(voucher.ID)
+	foundVoucher, err := repo.FindByID(context.Background(), voucher.ID)
 	assert.NoError(t, err)
 	assert.Equal(t, "UPDATED123", foundVoucher.VoucherCode)
 	assert.Equal(t, 20.0, foundVoucher.DiscountPercent)
 }
 
+func TestVoucherRepository_Update_ReturnsStoredFields(t *testing.T) {
+	// Arrange
+	db := setupVoucherTestDB(t)
+	repo := NewVoucherRepository(db)
+
+	voucher := createTestVoucher("TEST123", 10.0)
+	err := repo.Create(context.Background(), voucher)
+	assert.NoError(t, err)
+
+	// Act
+	changes := &entity.Voucher{
+		ID:              voucher.ID,
+		VoucherCode:     "UPDATED123",
+		DiscountPercent: 20.0,
+		ExpiryDate:      voucher.ExpiryDate,
+	}
+	rowsAffected, err := repo.Update(context.Background(), changes)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), rowsAffected)
+	assert.False(t, changes.CreatedAt.IsZero())
+}
+
+func TestVoucherRepository_Update_SendsOnlyActiveVouchersForApproval(t *testing.T) {
+	tests := []struct {
+		name       string
+		status     string
+		wantStatus string
+	}{
+		{name: "active", status: entity.VoucherStatusActive, wantStatus: entity.VoucherStatusPendingApproval},
+		{name: "inactive", status: entity.VoucherStatusInactive, wantStatus: entity.VoucherStatusInactive},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			db := setupVoucherTestDB(t)
+			repo := NewVoucherRepository(db)
+
+			voucher := createTestVoucher("RAISE75", 10.0)
+			voucher.Status = tt.status
+			voucher.ApprovedBy = "approver@example.com"
+			assert.NoError(t, repo.Create(context.Background(), voucher))
+
+			// Act
+			changes := &entity.Voucher{
+				ID:              voucher.ID,
+				VoucherCode:     "RAISE75",
+				DiscountPercent: 75.0,
+				ExpiryDate:      voucher.ExpiryDate,
+				Status:          entity.VoucherStatusPendingApproval,
+				UpdatedBy:       "editor@example.com",
+			}
+			rowsAffected, err := repo.Update(context.Background(), changes)
+
+			// Assert
+			assert.NoError(t, err)
+			assert.Equal(t, int64(1), rowsAffected)
+			assert.Equal(t, tt.wantStatus, changes.Status)
+			stored, err := repo.FindByID(context.Background(), voucher.ID)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantStatus, stored.Status)
+			assert.Equal(t, 75.0, stored.DiscountPercent)
+			assert.Equal(t, "editor@example.com", stored.UpdatedBy)
+		})
+	}
+}
+
 func TestVoucherRepository_Update_NotFound(t *testing.T) {
 	// Arrange
 	db := setupVoucherTestDB(t)
@@ -174,20 +333,83 @@ func TestVoucherRepository_Update_NotFound(t *testing.T) {
 	}
 
 	// Act
-	err := repo.Update(voucher)
+	rowsAffected, err := repo.Update(context.Background(), voucher)
 
 	// Assert
-	// GORM's Save (used in Update) performs an upsert operation:
-	// it will create the record if it doesn't exist
-	// This is the expected behavior, not an error
 	assert.NoError(t, err)
+	assert.Equal(t, int64(0), rowsAffected)
 
-	// Verify the record was created by Save
-	foundVoucher, findErr := repo.FindByID(999)
-	assert.NoError(t, findErr)
-	assert.NotNil(t, foundVoucher)
-	assert.Equal(t, uint(999), foundVoucher.ID)
-	assert.Equal(t, "TEST123", foundVoucher.VoucherCode)
+	// Verify no phantom record was created
+	foundVoucher, findErr := repo.FindByID(context.Background(), 999)
+	assert.Error(t, findErr)
+	assert.Nil(t, foundVoucher)
+}
+
+func TestVoucherRepository_Update_SoftDeleted(t *testing.T) {
+	// Arrange
+	db := setupVoucherTestDB(t)
+	repo := NewVoucherRepository(db)
+
+	voucher := createTestVoucher("TEST123", 10.0)
+	err := repo.Create(context.Background(), voucher)
+	assert.NoError(t, err)
+	_, err = repo.Delete(context.Background(), voucher.ID)
+	assert.NoError(t, err)
+
+	// Act
+	voucher.DiscountPercent = 20.0
+	rowsAffected, err := repo.Update(context.Background(), voucher)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), rowsAffected)
+
+	// Verify the voucher stays deleted
+	foundVoucher, findErr := repo.FindByID(context.Background(), voucher.ID)
+	assert.Error(t, findErr)
+	assert.Nil(t, foundVoucher)
+}
+
+// Test Approve
+func TestVoucherRepository_Approve_Success(t *testing.T) {
+	// Arrange
+	db := setupVoucherTestDB(t)
+	repo := NewVoucherRepository(db)
+
+	voucher := createTestVoucher("BIG75", 75.0)
+	voucher.Status = entity.VoucherStatusPendingApproval
+	err := repo.Create(context.Background(), voucher)
+	assert.NoError(t, err)
+
+	// Act
+	rowsAffected, err := repo.Approve(context.Background(), voucher.ID, "approver@example.com", time.Now())
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), rowsAffected)
+
+	foundVoucher, err := repo.FindByID(context.Background(), voucher.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, entity.VoucherStatusActive, foundVoucher.Status)
+	assert.Equal(t, "approver@example.com", foundVoucher.ApprovedBy)
+	assert.NotNil(t, foundVoucher.ApprovedAt)
+}
+
+func TestVoucherRepository_Approve_AlreadyActive(t *testing.T) {
+	// Arrange
+	db := setupVoucherTestDB(t)
+	repo := NewVoucherRepository(db)
+
+	voucher := createTestVoucher("TEST123", 10.0)
+	err := repo.Create(context.Background(), voucher)
+	assert.NoError(t, err)
+
+	// Act
+	rowsAffected, err := repo.Approve(context.Background(), voucher.ID, "approver@example.com", time.Now())
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), rowsAffected)
 }
 
 // Test Delete (Soft Delete)
@@ -197,17 +419,18 @@ func TestVoucherRepository_Delete_Success(t *testing.T) {
 	repo := NewVoucherRepository(db)
 
 	voucher := createTestVoucher("TEST123", 10.0)
-	err := repo.Create(voucher)
+	err := repo.Create(context.Background(), voucher)
 	assert.NoError(t, err)
 
 	// Act
-	err = repo.Delete(voucher.ID)
+	rowsAffected, err := repo.Delete(context.Background(), voucher.ID)
 
 	// Assert
 	assert.NoError(t, err)
+	assert.Equal(t, int64(1), rowsAffected)
 
 	// Verify soft delete - should not find with normal query
-	foundVoucher, err := repo.FindByID(voucher.ID)
+	foundVoucher, err := repo.FindByID(context.Background(), voucher.ID)
 	assert.Error(t, err)
 	assert.Nil(t, foundVoucher)
 
@@ -218,6 +441,19 @@ func TestVoucherRepository_Delete_Success(t *testing.T) {
 	assert.NotZero(t, deletedVoucher.DeletedAt)
 }
 
+func TestVoucherRepository_Delete_NotFound(t *testing.T) {
+	// Arrange
+	db := setupVoucherTestDB(t)
+	repo := NewVoucherRepository(db)
+
+	// Act
+	rowsAffected, err := repo.Delete(context.Background(), 999)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), rowsAffected)
+}
+
 // Test FindAll
 func TestVoucherRepository_FindAll_Success(t *testing.T) {
 	// Arrange
@@ -231,12 +467,12 @@ func TestVoucherRepository_FindAll_Success(t *testing.T) {
 	}
 
 	for _, v := range vouchers {
-		err := repo.Create(v)
+		err := repo.Create(context.Background(), v)
 		assert.NoError(t, err)
 	}
 
 	// Act
-	foundVouchers, total, err := repo.FindAll(1, 10, "", "created_at", "asc")
+	foundVouchers, total, err := repo.FindAll(context.Background(), 1, 10, "", []utils.SortField{{Field: "created_at"}})
 
 	// Assert
 	assert.NoError(t, err)
@@ -252,12 +488,12 @@ func TestVoucherRepository_FindAll_WithPagination(t *testing.T) {
 	// Create 5 vouchers
 	for i := 1; i <= 5; i++ {
 		voucher := createTestVoucher(string(rune(i))+"TEST", float64(i*10))
-		err := repo.Create(voucher)
+		err := repo.Create(context.Background(), voucher)
 		assert.NoError(t, err)
 	}
 
 	// Act - Get page 1 with limit 2
-	page1Vouchers, total, err := repo.FindAll(1, 2, "", "created_at", "asc")
+	page1Vouchers, total, err := repo.FindAll(context.Background(), 1, 2, "", []utils.SortField{{Field: "created_at"}})
 
 	// Assert
 	assert.NoError(t, err)
@@ -265,7 +501,7 @@ func TestVoucherRepository_FindAll_WithPagination(t *testing.T) {
 	assert.Equal(t, int64(5), total)
 
 	// Act - Get page 2 with limit 2
-	page2Vouchers, total, err := repo.FindAll(2, 2, "", "created_at", "asc")
+	page2Vouchers, total, err := repo.FindAll(context.Background(), 2, 2, "", []utils.SortField{{Field: "created_at"}})
 
 	// Assert
 	assert.NoError(t, err)
@@ -273,7 +509,7 @@ func TestVoucherRepository_FindAll_WithPagination(t *testing.T) {
 	assert.Equal(t, int64(5), total)
 
 	// Act - Get page 3 with limit 2
-	page3Vouchers, total, err := repo.FindAll(3, 2, "", "created_at", "asc")
+	page3Vouchers, total, err := repo.FindAll(context.Background(), 3, 2, "", []utils.SortField{{Field: "created_at"}})
 
 	// Assert
 	assert.NoError(t, err)
@@ -293,12 +529,12 @@ func TestVoucherRepository_FindAll_WithSearch(t *testing.T) {
 	}
 
 	for _, v := range vouchers {
-		err := repo.Create(v)
+		err := repo.Create(context.Background(), v)
 		assert.NoError(t, err)
 	}
 
 	// Act
-	foundVouchers, total, err := repo.FindAll(1, 10, "SUMMER", "created_at", "asc")
+	foundVouchers, total, err := repo.FindAll(context.Background(), 1, 10, "SUMMER", []utils.SortField{{Field: "created_at"}})
 
 	// Assert
 	assert.NoError(t, err)
@@ -319,12 +555,12 @@ func TestVoucherRepository_FindAll_WithSorting(t *testing.T) {
 
 	for _, v := range vouchers {
 		time.Sleep(1 * time.Millisecond) // Ensure different timestamps
-		err := repo.Create(v)
+		err := repo.Create(context.Background(), v)
 		assert.NoError(t, err)
 	}
 
 	// Act - Sort by voucher_code ascending
-	foundVouchers, _, err := repo.FindAll(1, 10, "", "voucher_code", "asc")
+	foundVouchers, _, err := repo.FindAll(context.Background(), 1, 10, "", []utils.SortField{{Field: "voucher_code"}})
 
 	// Assert
 	assert.NoError(t, err)
@@ -346,16 +582,16 @@ func TestVoucherRepository_FindAll_ExcludesDeleted(t *testing.T) {
 	}
 
 	for _, v := range vouchers {
-		err := repo.Create(v)
+		err := repo.Create(context.Background(), v)
 		assert.NoError(t, err)
 	}
 
 	// Delete one voucher
-	err := repo.Delete(vouchers[1].ID)
+	_, err := repo.Delete(context.Background(), vouchers[1].ID)
 	assert.NoError(t, err)
 
 	// Act
-	foundVouchers, total, err := repo.FindAll(1, 10, "", "created_at", "asc")
+	foundVouchers, total, err := repo.FindAll(context.Background(), 1, 10, "", []utils.SortField{{Field: "created_at"}})
 
 	// Assert
 	assert.NoError(t, err)
@@ -376,13 +612,13 @@ func TestVoucherRepository_BulkCreate_Success(t *testing.T) {
 	}
 
 	// Act
-	err := repo.BulkCreate(vouchers)
+	err := repo.BulkCreate(context.Background(), vouchers)
 
 	// Assert
 	assert.NoError(t, err)
 
 	// Verify all were created
-	foundVouchers, total, err := repo.FindAll(1, 10, "", "created_at", "asc")
+	foundVouchers, total, err := repo.FindAll(context.Background(), 1, 10, "", []utils.SortField{{Field: "created_at"}})
 	assert.NoError(t, err)
 	assert.Equal(t, 3, len(foundVouchers))
 	assert.Equal(t, int64(3), total)
@@ -401,13 +637,13 @@ func TestVoucherRepository_CheckDuplicateCodes_Success(t *testing.T) {
 	}
 
 	for _, v := range existingVouchers {
-		err := repo.Create(v)
+		err := repo.Create(context.Background(), v)
 		assert.NoError(t, err)
 	}
 
 	// Act - Check for duplicates
 	codes := []string{"EXISTING1", "NEW1", "EXISTING2", "NEW2"}
-	duplicates, err := repo.CheckDuplicateCodes(codes)
+	duplicates, err := repo.CheckDuplicateCodes(context.Background(), codes)
 
 	// Assert
 	assert.NoError(t, err)
@@ -423,9 +659,372 @@ func TestVoucherRepository_CheckDuplicateCodes_NoDuplicates(t *testing.T) {
 
 	// Act - Check for duplicates with no existing vouchers
 	codes := []string{"NEW1", "NEW2", "NEW3"}
-	duplicates, err := repo.CheckDuplicateCodes(codes)
+	duplicates, err := repo.CheckDuplicateCodes(context.Background(), codes)
 
 	// Assert
 	assert.NoError(t, err)
 	assert.Equal(t, 0, len(duplicates))
 }
+
+// Test DeactivateByPrefix
+func TestVoucherRepository_DeactivateByPrefix_Chunked(t *testing.T) {
+	// Arrange
+	db := setupVoucherTestDB(t)
+	repo := NewVoucherRepository(db)
+
+	for _, code := range []string{"LEAK1", "LEAK2", "LEAK3", "KEEP1"} {
+		repo.Create(context.Background(), createTestVoucher(code, 10.0))
+	}
+
+	// Act
+	first, err1 := repo.DeactivateByPrefix(context.Background(), "LEAK", 2)
+	second, err2 := repo.DeactivateByPrefix(context.Background(), "LEAK", 2)
+	third, err3 := repo.DeactivateByPrefix(context.Background(), "LEAK", 2)
+
+	// Assert
+	assert.NoError(t, err1)
+	assert.NoError(t, err2)
+	assert.NoError(t, err3)
+	assert.Equal(t, int64(2), first)
+	assert.Equal(t, int64(1), second)
+	assert.Equal(t, int64(0), third)
+
+	kept, _ := repo.FindByVoucherCode(context.Background(), "KEEP1")
+	assert.Equal(t, entity.VoucherStatusActive, kept.Status)
+}
+
+func TestVoucherRepository_DeactivateByPrefix_EscapesWildcards(t *testing.T) {
+	// Arrange
+	db := setupVoucherTestDB(t)
+	repo := NewVoucherRepository(db)
+
+	repo.Create(context.Background(), createTestVoucher("A_B1", 10.0))
+	repo.Create(context.Background(), createTestVoucher("AXB1", 10.0))
+
+	// Act
+	rows, err := repo.DeactivateByPrefix(context.Background(), "A_B", 10)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), rows)
+
+	untouched, _ := repo.FindByVoucherCode(context.Background(), "AXB1")
+	assert.Equal(t, entity.VoucherStatusActive, untouched.Status)
+}
+
+func TestVoucherRepository_FindIDsByFilter_Paged(t *testing.T) {
+	// Arrange
+	db := setupVoucherTestDB(t)
+	repo := NewVoucherRepository(db)
+
+	for _, code := range []string{"SUMM1", "summ2", "SUMM3", "WINT1"} {
+		repo.Create(context.Background(), createTestVoucher(code, 10.0))
+	}
+	pending := createTestVoucher("SUMM4", 10.0)
+	pending.Status = entity.VoucherStatusPendingApproval
+	repo.Create(context.Background(), pending)
+
+	filter := repository.VoucherFilter{CodePrefix: "Summ", ExcludeStatus: entity.VoucherStatusPendingApproval}
+
+	// Act
+	first, err := repo.FindIDsByFilter(context.Background(), filter, 0, 2)
+	second, _ := repo.FindIDsByFilter(context.Background(), filter, first[len(first)-1], 2)
+	byCode, _ := repo.FindIDsByFilter(context.Background(), repository.VoucherFilter{Codes: []string{"wint1"}}, 0, 10)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Len(t, first, 2)
+	assert.Len(t, second, 1)
+	assert.Len(t, byCode, 1)
+}
+
+func TestVoucherRepository_UpdateFields(t *testing.T) {
+	// Arrange
+	db := setupVoucherTestDB(t)
+	repo := NewVoucherRepository(db)
+
+	first := createTestVoucher("EDIT1", 10.0)
+	second := createTestVoucher("EDIT2", 10.0)
+	repo.Create(context.Background(), first)
+	repo.Create(context.Background(), second)
+
+	// Act
+	rows, err := repo.UpdateFields(context.Background(), []uint{first.ID}, map[string]interface{}{
+		"status":       entity.VoucherStatusInactive,
+		"display_name": "Summer sale",
+	})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), rows)
+	edited, _ := repo.FindByID(context.Background(), first.ID)
+	assert.Equal(t, entity.VoucherStatusInactive, edited.Status)
+	assert.Equal(t, "Summer sale", edited.DisplayName)
+	untouched, _ := repo.FindByID(context.Background(), second.ID)
+	assert.Equal(t, entity.VoucherStatusActive, untouched.Status)
+}
+
+// Test DeactivateByCodes
+func TestVoucherRepository_DeactivateByCodes_Success(t *testing.T) {
+	// Arrange
+	db := setupVoucherTestDB(t)
+	repo := NewVoucherRepository(db)
+
+	repo.Create(context.Background(), createTestVoucher("CODE1", 10.0))
+	repo.Create(context.Background(), createTestVoucher("CODE2", 10.0))
+
+	// Act
+	rows, err := repo.DeactivateByCodes(context.Background(), []string{"CODE1", "MISSING"})
+	again, _ := repo.DeactivateByCodes(context.Background(), []string{"CODE1"})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), rows)
+	assert.Equal(t, int64(0), again)
+
+	deactivated, _ := repo.FindByVoucherCode(context.Background(), "CODE1")
+	assert.Equal(t, entity.VoucherStatusInactive, deactivated.Status)
+}
+
+// Test FindByExternalID
+func TestVoucherRepository_FindByExternalID_Success(t *testing.T) {
+	// Arrange
+	db := setupVoucherTestDB(t)
+	repo := NewVoucherRepository(db)
+
+	externalID := "crm-42"
+	voucher := createTestVoucher("EXT1", 10.0)
+	voucher.ExternalID = &externalID
+	repo.Create(context.Background(), voucher)
+	repo.Create(context.Background(), createTestVoucher("NOEXT1", 10.0))
+	repo.Create(context.Background(), createTestVoucher("NOEXT2", 10.0))
+
+	// Act
+	found, err := repo.FindByExternalID(context.Background(), "crm-42")
+	missing, missingErr := repo.FindByExternalID(context.Background(), "crm-404")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "EXT1", found.VoucherCode)
+	assert.NoError(t, missingErr)
+	assert.Nil(t, missing)
+}
+
+// Test CheckDuplicateExternalIDs
+func TestVoucherRepository_CheckDuplicateExternalIDs_IncludesDeleted(t *testing.T) {
+	// Arrange
+	db := setupVoucherTestDB(t)
+	repo := NewVoucherRepository(db)
+
+	externalID := "crm-42"
+	voucher := createTestVoucher("EXT1", 10.0)
+	voucher.ExternalID = &externalID
+	repo.Create(context.Background(), voucher)
+	repo.Delete(context.Background(), voucher.ID)
+
+	// Act
+	existing, err := repo.CheckDuplicateExternalIDs(context.Background(), []string{"crm-42", "crm-43"})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"crm-42"}, existing)
+}
+
+// Test case-insensitive voucher codes
+func TestVoucherRepository_Create_CaseVariantDuplicate(t *testing.T) {
+	// Arrange
+	db := setupVoucherTestDB(t)
+	repo := NewVoucherRepository(db)
+
+	repo.Create(context.Background(), createTestVoucher("SUMMER24", 10.0))
+
+	// Act
+	err := repo.Create(context.Background(), createTestVoucher("summer24", 10.0))
+
+	// Assert
+	assert.Error(t, err)
+}
+
+func TestVoucherRepository_FindByVoucherCode_IgnoresCase(t *testing.T) {
+	// Arrange
+	db := setupVoucherTestDB(t)
+	repo := NewVoucherRepository(db)
+
+	repo.Create(context.Background(), createTestVoucher("SUMMER24", 10.0))
+
+	// Act
+	found, err := repo.FindByVoucherCode(context.Background(), "Summer24")
+
+	// Assert
+	assert.NoError(t, err)
+	if assert.NotNil(t, found) {
+		assert.Equal(t, "SUMMER24", found.VoucherCode)
+	}
+}
+
+func TestVoucherRepository_CheckDuplicateCodes_IgnoresCase(t *testing.T) {
+	// Arrange
+	db := setupVoucherTestDB(t)
+	repo := NewVoucherRepository(db)
+
+	repo.Create(context.Background(), createTestVoucher("SUMMER24", 10.0))
+
+	// Act
+	existing, err := repo.CheckDuplicateCodes(context.Background(), []string{"summer24", "WINTER24"})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"summer24"}, existing)
+}
+
+// Test BulkCreateSkipConflicts
+func TestVoucherRepository_BulkCreateSkipConflicts_ReportsSkipped(t *testing.T) {
+	// Arrange
+	db := setupVoucherTestDB(t)
+	repo := NewVoucherRepository(db)
+
+	repo.Create(context.Background(), createTestVoucher("RACE2", 10.0))
+
+	vouchers := []*entity.Voucher{
+		createTestVoucher("RACE1", 10.0),
+		createTestVoucher("race2", 10.0),
+		createTestVoucher("RACE3", 10.0),
+	}
+
+	// Act
+	skipped, err := repo.BulkCreateSkipConflicts(context.Background(), vouchers)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"race2"}, skipped)
+	assert.NotZero(t, vouchers[0].ID)
+	assert.Zero(t, vouchers[1].ID)
+	assert.NotZero(t, vouchers[2].ID)
+
+	var count int64
+	db.Model(&entity.Voucher{}).Count(&count)
+	assert.Equal(t, int64(3), count)
+}
+
+// Test SuggestCodes
+func TestVoucherRepository_SuggestCodes_PrefixIgnoringCase(t *testing.T) {
+	// Arrange
+	db := setupVoucherTestDB(t)
+	repo := NewVoucherRepository(db)
+
+	for _, code := range []string{"SUMMER24", "summit10", "SUM_X", "WINTER24", "ASUMMER"} {
+		repo.Create(context.Background(), createTestVoucher(code, 10.0))
+	}
+
+	// Act
+	codes, err := repo.SuggestCodes(context.Background(), "sum", 10)
+	limited, _ := repo.SuggestCodes(context.Background(), "SUM", 1)
+	literal, _ := repo.SuggestCodes(context.Background(), "sum_", 10)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []string{"SUMMER24", "summit10", "SUM_X"}, codes)
+	assert.Len(t, limited, 1)
+	assert.Equal(t, []string{"SUM_X"}, literal)
+}
+
+// Test expired voucher cleanup
+func TestVoucherRepository_ArchiveAndPurgeExpiredBefore(t *testing.T) {
+	// Arrange
+	db := setupVoucherTestDB(t)
+	repo := NewVoucherRepository(db)
+	cutoff := time.Now().AddDate(0, 0, -30)
+
+	for i, code := range []string{"OLD1", "OLD2", "RECENT1"} {
+		voucher := createTestVoucher(code, 10.0)
+		voucher.ExpiryDate = time.Now().AddDate(0, 0, -60+i*25)
+		assert.NoError(t, repo.Create(context.Background(), voucher))
+	}
+
+	// Act
+	count, countErr := repo.CountExpiredBefore(context.Background(), cutoff, false)
+	codes, codesErr := repo.FindExpiredCodesBefore(context.Background(), cutoff, false, 10)
+	archived, archiveErr := repo.ArchiveExpiredBefore(context.Background(), cutoff, 1)
+	remaining, _ := repo.CountExpiredBefore(context.Background(), cutoff, false)
+	withArchived, _ := repo.CountExpiredBefore(context.Background(), cutoff, true)
+	purged, purgeErr := repo.PurgeExpiredBefore(context.Background(), cutoff, 10)
+
+	// Assert
+	assert.NoError(t, countErr)
+	assert.Equal(t, int64(2), count)
+	assert.NoError(t, codesErr)
+	assert.Equal(t, []string{"OLD1", "OLD2"}, codes)
+	assert.NoError(t, archiveErr)
+	assert.Equal(t, int64(1), archived)
+	assert.Equal(t, int64(1), remaining)
+	assert.Equal(t, int64(2), withArchived)
+	assert.NoError(t, purgeErr)
+	assert.Equal(t, int64(2), purged)
+
+	var total int64
+	db.Unscoped().Model(&entity.Voucher{}).Count(&total)
+	assert.Equal(t, int64(1), total)
+}
+
+func TestVoucherRepository_FindChangedSince_IncludesDeleted(t *testing.T) {
+	// Arrange
+	db := setupVoucherTestDB(t)
+	repo := NewVoucherRepository(db)
+
+	unchanged := createTestVoucher("UNCHANGED", 10.0)
+	deleted := createTestVoucher("DELETED", 10.0)
+	assert.NoError(t, repo.Create(context.Background(), unchanged))
+	assert.NoError(t, repo.Create(context.Background(), deleted))
+	since := time.Now()
+	db.Model(&entity.Voucher{}).Where("id IN ?", []uint{unchanged.ID, deleted.ID}).UpdateColumn("updated_at", since.Add(-time.Hour))
+
+	created := createTestVoucher("CREATED", 10.0)
+	assert.NoError(t, repo.Create(context.Background(), created))
+	_, err := repo.Delete(context.Background(), deleted.ID)
+	assert.NoError(t, err)
+
+	// Act
+	vouchers, err := repo.FindChangedSince(context.Background(), since, 0, 10)
+	afterFirst, afterErr := repo.FindChangedSince(context.Background(), since, deleted.ID, 10)
+
+	// Assert
+	assert.NoError(t, err)
+	if assert.Len(t, vouchers, 2) {
+		assert.Equal(t, "DELETED", vouchers[0].VoucherCode)
+		assert.True(t, vouchers[0].DeletedAt.Valid)
+		assert.Equal(t, "CREATED", vouchers[1].VoucherCode)
+	}
+	assert.NoError(t, afterErr)
+	if assert.Len(t, afterFirst, 1) {
+		assert.Equal(t, "CREATED", afterFirst[0].VoucherCode)
+	}
+}
+
+func TestVoucherRepository_UpdateFieldsForApproval(t *testing.T) {
+	// Arrange
+	db := setupVoucherTestDB(t)
+	repo := NewVoucherRepository(db)
+
+	active := createTestVoucher("RAISE1", 10.0)
+	inactive := createTestVoucher("RAISE2", 10.0)
+	inactive.Status = entity.VoucherStatusInactive
+	repo.Create(context.Background(), active)
+	repo.Create(context.Background(), inactive)
+
+	// Act
+	rows, pendingIDs, err := repo.UpdateFieldsForApproval(context.Background(), []uint{active.ID, inactive.ID}, map[string]interface{}{
+		"discount_percent": 80.0,
+		"updated_by":       "editor@example.com",
+	})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), rows)
+	assert.Equal(t, []uint{active.ID}, pendingIDs)
+	sent, _ := repo.FindByID(context.Background(), active.ID)
+	assert.Equal(t, entity.VoucherStatusPendingApproval, sent.Status)
+	assert.Equal(t, 80.0, sent.DiscountPercent)
+	kept, _ := repo.FindByID(context.Background(), inactive.ID)
+	assert.Equal(t, entity.VoucherStatusInactive, kept.Status)
+	assert.Equal(t, "editor@example.com", kept.UpdatedBy)
+}
@@ -0,0 +1,91 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/shoelfikar/voucher-management-system/internal/domain/entity"
+	"github.com/shoelfikar/voucher-management-system/internal/domain/repository"
+	"github.com/shoelfikar/voucher-management-system/pkg/utils"
+	"gorm.io/gorm"
+)
+
+// voucherTemplateRepositoryImpl implements domain repository.VoucherTemplateRepository
+type voucherTemplateRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewVoucherTemplateRepository creates a new voucher template repository instance
+func NewVoucherTemplateRepository(db *gorm.DB) repository.VoucherTemplateRepository {
+	return &voucherTemplateRepositoryImpl{db: db}
+}
+
+// FindAll retrieves templates with pagination, search by name, and sorting
+func (r *voucherTemplateRepositoryImpl) FindAll(ctx context.Context, page, limit int, search string, sort []utils.SortField) ([]*entity.VoucherTemplate, int64, error) {
+	var templates []*entity.VoucherTemplate
+	var total int64
+
+	query := r.db.WithContext(ctx).Model(&entity.VoucherTemplate{})
+	if search != "" {
+		query = query.Where("LOWER(name) LIKE LOWER(?)", "%"+search+"%")
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	if len(sort) > 0 {
+		query = query.Order(utils.OrderClause(sort))
+	} else {
+		query = query.Order("id")
+	}
+
+	err := query.Offset((page - 1) * limit).Limit(limit).Find(&templates).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return templates, total, nil
+}
+
+// FindByID retrieves a template by ID
+func (r *voucherTemplateRepositoryImpl) FindByID(ctx context.Context, id uint) (*entity.VoucherTemplate, error) {
+	var template entity.VoucherTemplate
+	if err := r.db.WithContext(ctx).First(&template, id).Error; err != nil {
+		return nil, err
+	}
+	return &template, nil
+}
+
+// FindByName retrieves a template by name
+func (r *voucherTemplateRepositoryImpl) FindByName(ctx context.Context, name string) (*entity.VoucherTemplate, error) {
+	var template entity.VoucherTemplate
+	err := r.db.WithContext(ctx).Where("name = ?", name).First(&template).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &template, nil
+}
+
+// Create creates a new template
+func (r *voucherTemplateRepositoryImpl) Create(ctx context.Context, template *entity.VoucherTemplate) error {
+	return r.db.WithContext(ctx).Create(template).Error
+}
+
+// Update saves the editable fields of a template and returns the number of rows affected
+func (r *voucherTemplateRepositoryImpl) Update(ctx context.Context, template *entity.VoucherTemplate) (int64, error) {
+	result := r.db.WithContext(ctx).Model(template).
+		Where("id = ?", template.ID).
+		Select("name", "discount_percent", "expiry_offset", "code_prefix", "channels").
+		Updates(template)
+	return result.RowsAffected, result.Error
+}
+
+// Delete removes a template and returns the number of rows affected
+func (r *voucherTemplateRepositoryImpl) Delete(ctx context.Context, id uint) (int64, error) {
+	result := r.db.WithContext(ctx).Delete(&entity.VoucherTemplate{}, id)
+	return result.RowsAffected, result.Error
+}
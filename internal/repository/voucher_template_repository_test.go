@@ -0,0 +1,90 @@
+package repository
+
+import (
+	"context"
+	"testing"
+
+	"github.com/shoelfikar/voucher-management-system/internal/domain/entity"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func setupVoucherTemplateTestDB(t *testing.T) *gorm.DB {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("Failed to connect to test database: %v", err)
+	}
+	if err := db.AutoMigrate(&entity.VoucherTemplate{}); err != nil {
+		t.Fatalf("Failed to migrate test database: %v", err)
+	}
+	return db
+}
+
+func TestVoucherTemplateRepository_CreateAndFind(t *testing.T) {
+	// Arrange
+	db := setupVoucherTemplateTestDB(t)
+	repo := NewVoucherTemplateRepository(db)
+	discount := 15.0
+	template := &entity.VoucherTemplate{Name: "Store promo", DiscountPercent: &discount, ExpiryOffset: "+30d", CodePrefix: "STORE-", Channels: []string{"pos", "web"}}
+
+	// Act
+	err := repo.Create(context.Background(), template)
+	byID, byIDErr := repo.FindByID(context.Background(), template.ID)
+	byName, byNameErr := repo.FindByName(context.Background(), "Store promo")
+	missing, missingErr := repo.FindByName(context.Background(), "Other")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.NoError(t, byIDErr)
+	assert.Equal(t, []string{"pos", "web"}, byID.Channels)
+	if assert.NotNil(t, byID.DiscountPercent) {
+		assert.Equal(t, 15.0, *byID.DiscountPercent)
+	}
+	assert.NoError(t, byNameErr)
+	assert.Equal(t, template.ID, byName.ID)
+	assert.NoError(t, missingErr)
+	assert.Nil(t, missing)
+}
+
+func TestVoucherTemplateRepository_UpdateClearsFields(t *testing.T) {
+	// Arrange
+	db := setupVoucherTemplateTestDB(t)
+	repo := NewVoucherTemplateRepository(db)
+	discount := 15.0
+	template := &entity.VoucherTemplate{Name: "Store promo", DiscountPercent: &discount, CodePrefix: "STORE-", Channels: []string{"pos"}}
+	assert.NoError(t, repo.Create(context.Background(), template))
+
+	// Act
+	rowsAffected, err := repo.Update(context.Background(), &entity.VoucherTemplate{ID: template.ID, Name: "Web promo", ExpiryOffset: "+2w"})
+	updated, _ := repo.FindByID(context.Background(), template.ID)
+	missingRows, missingErr := repo.Update(context.Background(), &entity.VoucherTemplate{ID: template.ID + 1, Name: "Nope"})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), rowsAffected)
+	assert.Equal(t, "Web promo", updated.Name)
+	assert.Nil(t, updated.DiscountPercent)
+	assert.Empty(t, updated.CodePrefix)
+	assert.Empty(t, updated.Channels)
+	assert.Equal(t, "+2w", updated.ExpiryOffset)
+	assert.NoError(t, missingErr)
+	assert.Equal(t, int64(0), missingRows)
+}
+
+func TestVoucherTemplateRepository_Delete(t *testing.T) {
+	// Arrange
+	db := setupVoucherTemplateTestDB(t)
+	repo := NewVoucherTemplateRepository(db)
+	template := &entity.VoucherTemplate{Name: "Store promo"}
+	assert.NoError(t, repo.Create(context.Background(), template))
+
+	// Act
+	rowsAffected, err := repo.Delete(context.Background(), template.ID)
+	_, findErr := repo.FindByID(context.Background(), template.ID)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), rowsAffected)
+	assert.ErrorIs(t, findErr, gorm.ErrRecordNotFound)
+}
@@ -1,50 +1,218 @@
 package service
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
 	"github.com/shoelfikar/voucher-management-system/internal/domain/entity"
 	"github.com/shoelfikar/voucher-management-system/internal/domain/repository"
 	domainService "github.com/shoelfikar/voucher-management-system/internal/domain/service"
 	"github.com/shoelfikar/voucher-management-system/pkg/jwt"
+	"github.com/shoelfikar/voucher-management-system/pkg/logger"
+	"github.com/shoelfikar/voucher-management-system/pkg/password"
+	"gorm.io/gorm"
 )
 
+// RegistrationPolicy controls self-registration. The first account can
+// always register and becomes an admin, so a fresh installation can be set
+// up; later accounts can only register while Open reports true, and get Role.
+// Open is asked on every registration so reloading the configuration can open
+// or close registration; nil keeps it closed.
+type RegistrationPolicy struct {
+	Open func() bool
+	Role string
+}
+
+// VerificationSender emails the link that verifies an account's address
+type VerificationSender interface {
+	SendVerificationEmail(ctx context.Context, email string) error
+}
+
 // authServiceImpl implements domain service.AuthService
 type authServiceImpl struct {
-	userRepo   repository.UserRepository
-	jwtService jwt.JWTService
+	userRepo             repository.UserRepository
+	jwtService           jwt.JWTService
+	hasher               password.Hasher
+	requireVerifiedEmail bool
+	registration         RegistrationPolicy
+	verification         VerificationSender
+
+	dummyHashOnce  sync.Once
+	dummyHashValue string
 }
 
-// NewAuthService creates a new auth service instance
-func NewAuthService(userRepo repository.UserRepository, jwtService jwt.JWTService) domainService.AuthService {
+// NewAuthService creates a new auth service instance. When requireVerifiedEmail
+// is set, only accounts with a verified email address may log in, and
+// registered accounts are sent a verification email through verification.
+func NewAuthService(
+	userRepo repository.UserRepository,
+	jwtService jwt.JWTService,
+	hasher password.Hasher,
+	requireVerifiedEmail bool,
+	registration RegistrationPolicy,
+	verification VerificationSender,
+) domainService.AuthService {
 	return &authServiceImpl{
-		userRepo:   userRepo,
-		jwtService: jwtService,
+		userRepo:             userRepo,
+		jwtService:           jwtService,
+		hasher:               hasher,
+		requireVerifiedEmail: requireVerifiedEmail,
+		registration:         registration,
+		verification:         verification,
 	}
 }
 
-// Login authenticates a user with dummy validation and returns a JWT token
-func (s *authServiceImpl) Login(email, password string) (string, *entity.User, error) {
-	// Dummy validation - accept any email/password combination
-	// In production, you should:
-	// 1. Find user by email from database
-	// _, err := s.userRepo.FindByEmail(email)
-	// if err != nil {
-	// 	return "", nil, err
-	// }
-	// 2. Compare hashed password with bcrypt
-	// 3. Return error if credentials are invalid
+// Login authenticates a user by email and password and returns a JWT token.
+// Unknown emails and wrong passwords both fail with ErrInvalidCredentials, and
+// a locked account is only reported once its password has been verified, so
+// login responses do not reveal which accounts exist. Stored password hashes
+// are rehashed when the hashing configuration has changed.
+func (s *authServiceImpl) Login(ctx context.Context, email, plainPassword string) (string, *entity.User, error) {
+	account, err := s.userRepo.FindByEmail(ctx, email)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		// Spend the time of a real verification so unknown emails are not faster to reject
+		_, _ = s.hasher.Verify(plainPassword, s.dummyHash())
+		return "", nil, domainService.ErrInvalidCredentials
+	}
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to find user: %w", err)
+	}
 
-	user := &entity.User{
-		Email: email,
+	ok, err := s.hasher.Verify(plainPassword, account.Password)
+	if err != nil || !ok {
+		return "", nil, domainService.ErrInvalidCredentials
 	}
+	if account.LockedAt != nil {
+		return "", nil, domainService.ErrAccountLocked
+	}
+	if s.requireVerifiedEmail && account.EmailVerifiedAt == nil {
+		return "", nil, domainService.ErrEmailNotVerified
+	}
+	s.rehashIfNeeded(ctx, account, plainPassword)
 
-	token, err := s.jwtService.GenerateToken(email)
+	token, err := s.jwtService.GenerateToken(account.Email, account.Role)
 	if err != nil {
 		return "", nil, err
 	}
 
-	return token, user, nil
+	return token, account, nil
 }
 
-func (s *authServiceImpl) Register(email, password string) (string, error) {
-	return "", nil
+// dummyHash returns a hash made with the current settings that logins for
+// unknown emails are verified against
+func (s *authServiceImpl) dummyHash() string {
+	s.dummyHashOnce.Do(func() {
+		s.dummyHashValue, _ = s.hasher.Hash("not-a-real-password")
+	})
+	return s.dummyHashValue
+}
+
+// rehashIfNeeded upgrades a verified password hash to the current algorithm
+// and parameters. Failures only delay the upgrade to a later login.
+func (s *authServiceImpl) rehashIfNeeded(ctx context.Context, account *entity.User, plainPassword string) {
+	if !s.hasher.NeedsRehash(account.Password) {
+		return
+	}
+
+	hashed, err := s.hasher.Hash(plainPassword)
+	if err != nil {
+		logger.FromContext(ctx).Error("failed to rehash password", "email", account.Email, "error", err)
+		return
+	}
+	if err := s.userRepo.UpdatePassword(ctx, account.Email, hashed); err != nil {
+		logger.FromContext(ctx).Error("failed to store rehashed password", "email", account.Email, "error", err)
+		return
+	}
+	account.Password = hashed
+}
+
+// registrationOpen reports whether the policy lets anyone register
+func (s *authServiceImpl) registrationOpen() bool {
+	return s.registration.Open != nil && s.registration.Open()
+}
+
+// createAccount saves a new account. While no accounts exist the first one
+// becomes an admin; the repository checks that atomically with the insert, so
+// of concurrent first registrations only one is made admin and the others are
+// registered like any later account, if registration is open.
+func (s *authServiceImpl) createAccount(ctx context.Context, account *entity.User, first bool) error {
+	if first {
+		admin := *account
+		admin.Role = entity.UserRoleAdmin
+		created, err := s.userRepo.CreateFirst(ctx, &admin)
+		if err != nil {
+			return err
+		}
+		if created {
+			*account = admin
+			return nil
+		}
+		if !s.registrationOpen() {
+			return domainService.ErrRegistrationClosed
+		}
+	}
+	return s.userRepo.Create(ctx, account)
+}
+
+// Register creates an account with a hashed password and returns a JWT token
+// for it. The first account becomes an admin; later ones need the policy to
+// be open. When email verification is required no token is returned and a
+// verification email is sent instead.
+func (s *authServiceImpl) Register(ctx context.Context, email, plainPassword string) (string, *entity.User, error) {
+	email = strings.TrimSpace(email)
+	if email == "" {
+		return "", nil, domainService.ErrEmailRequired
+	}
+
+	count, err := s.userRepo.Count(ctx)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to count users: %w", err)
+	}
+	if count > 0 && !s.registrationOpen() {
+		return "", nil, domainService.ErrRegistrationClosed
+	}
+
+	_, err = s.userRepo.FindByEmail(ctx, email)
+	if err == nil {
+		return "", nil, domainService.ErrEmailAlreadyRegistered
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return "", nil, fmt.Errorf("failed to find user: %w", err)
+	}
+
+	hashedPassword, err := s.hasher.Hash(plainPassword)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to hash password: %w", err)
+	}
+
+	account := &entity.User{Email: email, Password: hashedPassword, Role: s.registration.Role}
+	if err := s.createAccount(ctx, account, count == 0); err != nil {
+		// Another request registered the same email since the check above
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return "", nil, domainService.ErrEmailAlreadyRegistered
+		}
+		if errors.Is(err, domainService.ErrRegistrationClosed) {
+			return "", nil, err
+		}
+		return "", nil, fmt.Errorf("failed to create user: %w", err)
+	}
+	logger.FromContext(ctx).Info("user registered", "email", account.Email, "role", account.Role)
+
+	if s.requireVerifiedEmail {
+		if err := s.verification.SendVerificationEmail(ctx, account.Email); err != nil {
+			// The account exists either way; failing here would only make a retry report the email as taken
+			logger.FromContext(ctx).Error("failed to send verification email", "email", account.Email, "error", err)
+		}
+		return "", account, nil
+	}
+
+	token, err := s.jwtService.GenerateToken(account.Email, account.Role)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return token, account, nil
 }
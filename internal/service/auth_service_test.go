@@ -1,21 +1,27 @@
 package service
 
 import (
+	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/shoelfikar/voucher-management-system/internal/domain/entity"
+	domainService "github.com/shoelfikar/voucher-management-system/internal/domain/service"
 	jwtPkg "github.com/shoelfikar/voucher-management-system/pkg/jwt"
+	"github.com/shoelfikar/voucher-management-system/pkg/password"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
 )
 
-// MockUserRepository is a mock implementation of UserRepository
+// MockUserRepository is a mock implementation of UserRepository that ignores the context argument
 type MockUserRepository struct {
 	mock.Mock
 }
 
-func (m *MockUserRepository) FindByEmail(email string) (*entity.User, error) {
+func (m *MockUserRepository) FindByEmail(ctx context.Context, email string) (*entity.User, error) {
 	args := m.Called(email)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
@@ -23,18 +29,38 @@ func (m *MockUserRepository) FindByEmail(email string) (*entity.User, error) {
 	return args.Get(0).(*entity.User), args.Error(1)
 }
 
-func (m *MockUserRepository) Create(user *entity.User) error {
+func (m *MockUserRepository) Create(ctx context.Context, user *entity.User) error {
 	args := m.Called(user)
 	return args.Error(0)
 }
 
+func (m *MockUserRepository) CreateFirst(ctx context.Context, user *entity.User) (bool, error) {
+	args := m.Called(user)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockUserRepository) Count(ctx context.Context) (int64, error) {
+	args := m.Called()
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockUserRepository) MarkEmailVerified(ctx context.Context, email string, verifiedAt time.Time) (int64, error) {
+	args := m.Called(email, verifiedAt)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockUserRepository) UpdatePassword(ctx context.Context, email, hashedPassword string) error {
+	args := m.Called(email, hashedPassword)
+	return args.Error(0)
+}
+
 // MockJWTService is a mock implementation of JWTService
 type MockJWTService struct {
 	mock.Mock
 }
 
-func (m *MockJWTService) GenerateToken(email string) (string, error) {
-	args := m.Called(email)
+func (m *MockJWTService) GenerateToken(email, role string) (string, error) {
+	args := m.Called(email, role)
 	return args.String(0), args.Error(1)
 }
 
@@ -46,26 +72,54 @@ func (m *MockJWTService) ValidateToken(token string) (*jwtPkg.Claims, error) {
 	return args.Get(0).(*jwtPkg.Claims), args.Error(1)
 }
 
+func (m *MockJWTService) GenerateActionToken(purpose, email, role string, ttl time.Duration) (string, error) {
+	args := m.Called(purpose, email, role, ttl)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockJWTService) GenerateOneTimeToken(purpose, id string, ttl time.Duration) (string, error) {
+	args := m.Called(purpose, id, ttl)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockJWTService) ValidateActionToken(token, purpose string) (*jwtPkg.Claims, error) {
+	args := m.Called(token, purpose)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*jwtPkg.Claims), args.Error(1)
+}
+
+// testHasher uses the cheapest bcrypt cost to keep tests fast
+var testHasher, _ = password.NewHasher(password.Config{Algorithm: password.AlgorithmBcrypt, BcryptCost: bcrypt.MinCost})
+
+// testAccount returns a user whose stored password hash matches plainPassword
+func testAccount(email, plainPassword string) *entity.User {
+	hashed, _ := testHasher.Hash(plainPassword)
+	return &entity.User{ID: 1, Email: email, Password: hashed, Role: entity.UserRoleAdmin}
+}
+
 func TestAuthService_Login_Success(t *testing.T) {
 	// Arrange
 	mockUserRepo := new(MockUserRepository)
 	mockJWTService := new(MockJWTService)
 
-	authService := NewAuthService(mockUserRepo, mockJWTService)
+	authService := NewAuthService(mockUserRepo, mockJWTService, testHasher, false, RegistrationPolicy{}, nil)
 
 	email := "test@example.com"
 	password := "password123"
 	expectedToken := "mock.jwt.token"
+	account := testAccount(email, password)
 
-	mockJWTService.On("GenerateToken", email).Return(expectedToken, nil)
+	mockUserRepo.On("FindByEmail", email).Return(account, nil)
+	mockJWTService.On("GenerateToken", email, entity.UserRoleAdmin).Return(expectedToken, nil)
 
 	// Act
-	token, user, err := authService.Login(email, password)
+	token, user, err := authService.Login(context.Background(), email, password)
 
 	// Assert
 	assert.NoError(t, err)
-	assert.NotNil(t, user)
-	assert.Equal(t, email, user.Email)
+	assert.Equal(t, account, user)
 	assert.Equal(t, expectedToken, token)
 	mockJWTService.AssertExpectations(t)
 }
@@ -75,16 +129,17 @@ func TestAuthService_Login_JWT_GenerateToken_Error(t *testing.T) {
 	mockUserRepo := new(MockUserRepository)
 	mockJWTService := new(MockJWTService)
 
-	authService := NewAuthService(mockUserRepo, mockJWTService)
+	authService := NewAuthService(mockUserRepo, mockJWTService, testHasher, false, RegistrationPolicy{}, nil)
 
 	email := "test@example.com"
 	password := "password123"
 	expectedError := errors.New("failed to generate token")
 
-	mockJWTService.On("GenerateToken", email).Return("", expectedError)
+	mockUserRepo.On("FindByEmail", email).Return(testAccount(email, password), nil)
+	mockJWTService.On("GenerateToken", email, entity.UserRoleAdmin).Return("", expectedError)
 
 	// Act
-	token, user, err := authService.Login(email, password)
+	token, user, err := authService.Login(context.Background(), email, password)
 
 	// Assert
 	assert.Error(t, err)
@@ -94,28 +149,42 @@ func TestAuthService_Login_JWT_GenerateToken_Error(t *testing.T) {
 	mockJWTService.AssertExpectations(t)
 }
 
-func TestAuthService_Login_EmptyEmail(t *testing.T) {
+func TestAuthService_Login_MissingUser(t *testing.T) {
 	// Arrange
 	mockUserRepo := new(MockUserRepository)
 	mockJWTService := new(MockJWTService)
 
-	authService := NewAuthService(mockUserRepo, mockJWTService)
+	authService := NewAuthService(mockUserRepo, mockJWTService, testHasher, false, RegistrationPolicy{}, nil)
 
-	email := ""
-	password := "password123"
-	expectedToken := "mock.jwt.token"
+	mockUserRepo.On("FindByEmail", "nobody@example.com").Return(nil, gorm.ErrRecordNotFound)
 
-	mockJWTService.On("GenerateToken", email).Return(expectedToken, nil)
+	// Act
+	token, user, err := authService.Login(context.Background(), "nobody@example.com", "password123")
+
+	// Assert
+	assert.ErrorIs(t, err, domainService.ErrInvalidCredentials)
+	assert.Empty(t, token)
+	assert.Nil(t, user)
+	mockJWTService.AssertNotCalled(t, "GenerateToken", mock.Anything, mock.Anything)
+}
+
+func TestAuthService_Login_EmptyEmail(t *testing.T) {
+	// Arrange
+	mockUserRepo := new(MockUserRepository)
+	mockJWTService := new(MockJWTService)
+
+	authService := NewAuthService(mockUserRepo, mockJWTService, testHasher, false, RegistrationPolicy{}, nil)
+
+	mockUserRepo.On("FindByEmail", "").Return(nil, gorm.ErrRecordNotFound)
 
 	// Act
-	token, user, err := authService.Login(email, password)
+	token, user, err := authService.Login(context.Background(), "", "password123")
 
 	// Assert
-	assert.NoError(t, err)
-	assert.NotNil(t, user)
-	assert.Equal(t, email, user.Email)
-	assert.Equal(t, expectedToken, token)
-	mockJWTService.AssertExpectations(t)
+	assert.ErrorIs(t, err, domainService.ErrInvalidCredentials)
+	assert.Empty(t, token)
+	assert.Nil(t, user)
+	mockJWTService.AssertNotCalled(t, "GenerateToken", mock.Anything, mock.Anything)
 }
 
 func TestAuthService_Login_EmptyPassword(t *testing.T) {
@@ -123,21 +192,391 @@ func TestAuthService_Login_EmptyPassword(t *testing.T) {
 	mockUserRepo := new(MockUserRepository)
 	mockJWTService := new(MockJWTService)
 
-	authService := NewAuthService(mockUserRepo, mockJWTService)
+	authService := NewAuthService(mockUserRepo, mockJWTService, testHasher, false, RegistrationPolicy{}, nil)
 
 	email := "test@example.com"
-	password := ""
-	expectedToken := "mock.jwt.token"
+	mockUserRepo.On("FindByEmail", email).Return(testAccount(email, "password123"), nil)
+
+	// Act
+	token, user, err := authService.Login(context.Background(), email, "")
+
+	// Assert
+	assert.ErrorIs(t, err, domainService.ErrInvalidCredentials)
+	assert.Empty(t, token)
+	assert.Nil(t, user)
+	mockJWTService.AssertNotCalled(t, "GenerateToken", mock.Anything, mock.Anything)
+}
 
-	mockJWTService.On("GenerateToken", email).Return(expectedToken, nil)
+func TestAuthService_Login_RepositoryError(t *testing.T) {
+	// Arrange
+	mockUserRepo := new(MockUserRepository)
+	mockJWTService := new(MockJWTService)
+
+	authService := NewAuthService(mockUserRepo, mockJWTService, testHasher, false, RegistrationPolicy{}, nil)
+
+	mockUserRepo.On("FindByEmail", "test@example.com").Return(nil, errors.New("connection refused"))
 
 	// Act
-	token, user, err := authService.Login(email, password)
+	_, _, err := authService.Login(context.Background(), "test@example.com", "password123")
+
+	// Assert
+	assert.Error(t, err)
+	assert.NotErrorIs(t, err, domainService.ErrInvalidCredentials)
+	mockJWTService.AssertNotCalled(t, "GenerateToken", mock.Anything, mock.Anything)
+}
+
+func TestAuthService_Login_LockedAccount(t *testing.T) {
+	// Arrange
+	mockUserRepo := new(MockUserRepository)
+	mockJWTService := new(MockJWTService)
+
+	authService := NewAuthService(mockUserRepo, mockJWTService, testHasher, false, RegistrationPolicy{}, nil)
+
+	account := testAccount("locked@example.com", "password123")
+	lockedAt := time.Now()
+	account.LockedAt = &lockedAt
+	mockUserRepo.On("FindByEmail", "locked@example.com").Return(account, nil)
+
+	// Act
+	token, user, err := authService.Login(context.Background(), "locked@example.com", "password123")
+	_, _, wrongPasswordErr := authService.Login(context.Background(), "locked@example.com", "wrong")
+
+	// Assert
+	assert.ErrorIs(t, err, domainService.ErrAccountLocked)
+	assert.Empty(t, token)
+	assert.Nil(t, user)
+	// A wrong password does not reveal that the account is locked
+	assert.ErrorIs(t, wrongPasswordErr, domainService.ErrInvalidCredentials)
+	mockJWTService.AssertNotCalled(t, "GenerateToken", mock.Anything, mock.Anything)
+}
+
+func TestAuthService_Login_UnverifiedEmailBlocked(t *testing.T) {
+	// Arrange
+	mockUserRepo := new(MockUserRepository)
+	mockJWTService := new(MockJWTService)
+
+	authService := NewAuthService(mockUserRepo, mockJWTService, testHasher, true, RegistrationPolicy{}, nil)
+
+	mockUserRepo.On("FindByEmail", "new@example.com").Return(testAccount("new@example.com", "password123"), nil)
+
+	// Act
+	token, user, err := authService.Login(context.Background(), "new@example.com", "password123")
+
+	// Assert
+	assert.ErrorIs(t, err, domainService.ErrEmailNotVerified)
+	assert.Empty(t, token)
+	assert.Nil(t, user)
+	mockJWTService.AssertNotCalled(t, "GenerateToken", mock.Anything, mock.Anything)
+}
+
+func TestAuthService_Login_VerifiedEmailAllowed(t *testing.T) {
+	// Arrange
+	mockUserRepo := new(MockUserRepository)
+	mockJWTService := new(MockJWTService)
+
+	authService := NewAuthService(mockUserRepo, mockJWTService, testHasher, true, RegistrationPolicy{}, nil)
+
+	verifiedAt := time.Now()
+	account := testAccount("verified@example.com", "password123")
+	account.EmailVerifiedAt = &verifiedAt
+	mockUserRepo.On("FindByEmail", "verified@example.com").Return(account, nil)
+	mockJWTService.On("GenerateToken", "verified@example.com", entity.UserRoleAdmin).Return("mock.jwt.token", nil)
+
+	// Act
+	token, user, err := authService.Login(context.Background(), "verified@example.com", "password123")
 
 	// Assert
 	assert.NoError(t, err)
-	assert.NotNil(t, user)
-	assert.Equal(t, email, user.Email)
-	assert.Equal(t, expectedToken, token)
-	mockJWTService.AssertExpectations(t)
+	assert.Equal(t, "mock.jwt.token", token)
+	assert.Equal(t, account, user)
+}
+
+func TestAuthService_Login_WrongPassword(t *testing.T) {
+	// Arrange
+	mockUserRepo := new(MockUserRepository)
+	mockJWTService := new(MockJWTService)
+
+	authService := NewAuthService(mockUserRepo, mockJWTService, testHasher, false, RegistrationPolicy{}, nil)
+
+	hashed, _ := testHasher.Hash("password123")
+	mockUserRepo.On("FindByEmail", "user@example.com").Return(&entity.User{Email: "user@example.com", Password: hashed}, nil)
+
+	// Act
+	token, user, err := authService.Login(context.Background(), "user@example.com", "wrong")
+
+	// Assert
+	assert.ErrorIs(t, err, domainService.ErrInvalidCredentials)
+	assert.Empty(t, token)
+	assert.Nil(t, user)
+	mockJWTService.AssertNotCalled(t, "GenerateToken", mock.Anything, mock.Anything)
+}
+
+func TestAuthService_Login_CurrentHashNotRehashed(t *testing.T) {
+	// Arrange
+	mockUserRepo := new(MockUserRepository)
+	mockJWTService := new(MockJWTService)
+
+	authService := NewAuthService(mockUserRepo, mockJWTService, testHasher, false, RegistrationPolicy{}, nil)
+
+	hashed, _ := testHasher.Hash("password123")
+	mockUserRepo.On("FindByEmail", "user@example.com").Return(&entity.User{Email: "user@example.com", Password: hashed}, nil)
+	mockJWTService.On("GenerateToken", "user@example.com", "").Return("mock.jwt.token", nil)
+
+	// Act
+	token, _, err := authService.Login(context.Background(), "user@example.com", "password123")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "mock.jwt.token", token)
+	mockUserRepo.AssertNotCalled(t, "UpdatePassword", mock.Anything, mock.Anything)
+}
+
+func TestAuthService_Login_RehashesOutdatedHash(t *testing.T) {
+	// Arrange
+	mockUserRepo := new(MockUserRepository)
+	mockJWTService := new(MockJWTService)
+
+	argon2Hasher, err := password.NewHasher(password.Config{
+		Algorithm:         password.AlgorithmArgon2id,
+		Argon2Memory:      64,
+		Argon2Iterations:  1,
+		Argon2Parallelism: 1,
+	})
+	assert.NoError(t, err)
+	authService := NewAuthService(mockUserRepo, mockJWTService, argon2Hasher, false, RegistrationPolicy{}, nil)
+
+	legacy, _ := testHasher.Hash("password123")
+	mockUserRepo.On("FindByEmail", "user@example.com").Return(&entity.User{Email: "user@example.com", Password: legacy}, nil)
+	mockUserRepo.On("UpdatePassword", "user@example.com", mock.MatchedBy(func(hashed string) bool {
+		ok, _ := argon2Hasher.Verify("password123", hashed)
+		return ok && !argon2Hasher.NeedsRehash(hashed)
+	})).Return(nil)
+	mockJWTService.On("GenerateToken", "user@example.com", "").Return("mock.jwt.token", nil)
+
+	// Act
+	_, user, err := authService.Login(context.Background(), "user@example.com", "password123")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Contains(t, user.Password, "$argon2id$")
+	mockUserRepo.AssertExpectations(t)
+}
+
+func TestAuthService_Login_RehashFailureDoesNotBlockLogin(t *testing.T) {
+	// Arrange
+	mockUserRepo := new(MockUserRepository)
+	mockJWTService := new(MockJWTService)
+
+	newHasher, _ := password.NewHasher(password.Config{Algorithm: password.AlgorithmBcrypt, BcryptCost: bcrypt.MinCost + 1})
+	authService := NewAuthService(mockUserRepo, mockJWTService, newHasher, false, RegistrationPolicy{}, nil)
+
+	legacy, _ := testHasher.Hash("password123")
+	mockUserRepo.On("FindByEmail", "user@example.com").Return(&entity.User{Email: "user@example.com", Password: legacy}, nil)
+	mockUserRepo.On("UpdatePassword", "user@example.com", mock.Anything).Return(errors.New("database is read-only"))
+	mockJWTService.On("GenerateToken", "user@example.com", "").Return("mock.jwt.token", nil)
+
+	// Act
+	token, user, err := authService.Login(context.Background(), "user@example.com", "password123")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "mock.jwt.token", token)
+	assert.Equal(t, legacy, user.Password)
+}
+
+// MockVerificationSender is a mock implementation of VerificationSender
+type MockVerificationSender struct {
+	mock.Mock
+}
+
+func (m *MockVerificationSender) SendVerificationEmail(ctx context.Context, email string) error {
+	args := m.Called(email)
+	return args.Error(0)
+}
+
+func TestAuthService_Register_FirstAccountBecomesAdmin(t *testing.T) {
+	// Arrange
+	mockUserRepo := new(MockUserRepository)
+	mockJWTService := new(MockJWTService)
+
+	authService := NewAuthService(mockUserRepo, mockJWTService, testHasher, false, RegistrationPolicy{Role: entity.UserRoleViewer}, nil)
+
+	mockUserRepo.On("Count").Return(int64(0), nil)
+	mockUserRepo.On("FindByEmail", "owner@example.com").Return(nil, gorm.ErrRecordNotFound)
+	mockUserRepo.On("CreateFirst", mock.MatchedBy(func(user *entity.User) bool {
+		return user.Role == entity.UserRoleAdmin
+	})).Return(true, nil)
+	mockJWTService.On("GenerateToken", "owner@example.com", entity.UserRoleAdmin).Return("mock.jwt.token", nil)
+
+	// Act
+	token, user, err := authService.Register(context.Background(), " owner@example.com ", "password123")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "mock.jwt.token", token)
+	assert.Equal(t, "owner@example.com", user.Email)
+	assert.Equal(t, entity.UserRoleAdmin, user.Role)
+	ok, _ := testHasher.Verify("password123", user.Password)
+	assert.True(t, ok)
+}
+
+func TestAuthService_Register_LosesFirstAccountRace(t *testing.T) {
+	testCases := []struct {
+		name         string
+		open         func() bool
+		expectedErr  error
+		expectCreate bool
+	}{
+		{"registration closed", nil, domainService.ErrRegistrationClosed, false},
+		{"registration open", func() bool { return true }, nil, true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			mockUserRepo := new(MockUserRepository)
+			mockJWTService := new(MockJWTService)
+
+			authService := NewAuthService(mockUserRepo, mockJWTService, testHasher, false, RegistrationPolicy{Open: tc.open, Role: entity.UserRoleViewer}, nil)
+
+			// Another registration created the first account after the count
+			mockUserRepo.On("Count").Return(int64(0), nil)
+			mockUserRepo.On("FindByEmail", "second@example.com").Return(nil, gorm.ErrRecordNotFound)
+			mockUserRepo.On("CreateFirst", mock.AnythingOfType("*entity.User")).Return(false, nil)
+			mockUserRepo.On("Create", mock.MatchedBy(func(user *entity.User) bool {
+				return user.Role == entity.UserRoleViewer
+			})).Return(nil)
+			mockJWTService.On("GenerateToken", "second@example.com", entity.UserRoleViewer).Return("mock.jwt.token", nil)
+
+			// Act
+			_, user, err := authService.Register(context.Background(), "second@example.com", "password123")
+
+			// Assert
+			if tc.expectedErr != nil {
+				assert.ErrorIs(t, err, tc.expectedErr)
+				assert.Nil(t, user)
+				mockUserRepo.AssertNotCalled(t, "Create", mock.Anything)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, entity.UserRoleViewer, user.Role)
+		})
+	}
+}
+
+func TestAuthService_Register_OpenPolicyAssignsRole(t *testing.T) {
+	// Arrange
+	mockUserRepo := new(MockUserRepository)
+	mockJWTService := new(MockJWTService)
+
+	authService := NewAuthService(mockUserRepo, mockJWTService, testHasher, false, RegistrationPolicy{Open: func() bool { return true }, Role: entity.UserRoleViewer}, nil)
+
+	mockUserRepo.On("Count").Return(int64(3), nil)
+	mockUserRepo.On("FindByEmail", "new@example.com").Return(nil, gorm.ErrRecordNotFound)
+	mockUserRepo.On("Create", mock.AnythingOfType("*entity.User")).Return(nil)
+	mockJWTService.On("GenerateToken", "new@example.com", entity.UserRoleViewer).Return("mock.jwt.token", nil)
+
+	// Act
+	_, user, err := authService.Register(context.Background(), "new@example.com", "password123")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, entity.UserRoleViewer, user.Role)
+}
+
+func TestAuthService_Register_ClosedAfterFirstAccount(t *testing.T) {
+	// Arrange
+	mockUserRepo := new(MockUserRepository)
+	mockJWTService := new(MockJWTService)
+
+	authService := NewAuthService(mockUserRepo, mockJWTService, testHasher, false, RegistrationPolicy{Role: entity.UserRoleViewer}, nil)
+
+	mockUserRepo.On("Count").Return(int64(1), nil)
+
+	// Act
+	token, user, err := authService.Register(context.Background(), "new@example.com", "password123")
+
+	// Assert
+	assert.ErrorIs(t, err, domainService.ErrRegistrationClosed)
+	assert.Empty(t, token)
+	assert.Nil(t, user)
+	mockUserRepo.AssertNotCalled(t, "Create", mock.Anything)
+}
+
+func TestAuthService_Register_BlankEmail(t *testing.T) {
+	// Arrange
+	mockUserRepo := new(MockUserRepository)
+	mockJWTService := new(MockJWTService)
+
+	authService := NewAuthService(mockUserRepo, mockJWTService, testHasher, false, RegistrationPolicy{Role: entity.UserRoleViewer}, nil)
+
+	// Act
+	token, user, err := authService.Register(context.Background(), "  ", "password123")
+
+	// Assert
+	assert.ErrorIs(t, err, domainService.ErrEmailRequired)
+	assert.Empty(t, token)
+	assert.Nil(t, user)
+	mockUserRepo.AssertNotCalled(t, "Count")
+}
+
+func TestAuthService_Register_DuplicateEmail(t *testing.T) {
+	testCases := []struct {
+		name      string
+		existing  *entity.User
+		createErr error
+	}{
+		{"found by lookup", testAccount("taken@example.com", "password123"), nil},
+		{"created concurrently", nil, gorm.ErrDuplicatedKey},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			mockUserRepo := new(MockUserRepository)
+			mockJWTService := new(MockJWTService)
+
+			authService := NewAuthService(mockUserRepo, mockJWTService, testHasher, false, RegistrationPolicy{Open: func() bool { return true }, Role: entity.UserRoleViewer}, nil)
+
+			mockUserRepo.On("Count").Return(int64(1), nil)
+			if tc.existing != nil {
+				mockUserRepo.On("FindByEmail", "taken@example.com").Return(tc.existing, nil)
+			} else {
+				mockUserRepo.On("FindByEmail", "taken@example.com").Return(nil, gorm.ErrRecordNotFound)
+				mockUserRepo.On("Create", mock.AnythingOfType("*entity.User")).Return(tc.createErr)
+			}
+
+			// Act
+			_, user, err := authService.Register(context.Background(), "taken@example.com", "password123")
+
+			// Assert
+			assert.ErrorIs(t, err, domainService.ErrEmailAlreadyRegistered)
+			assert.Nil(t, user)
+			mockJWTService.AssertNotCalled(t, "GenerateToken", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestAuthService_Register_VerificationRequired(t *testing.T) {
+	// Arrange
+	mockUserRepo := new(MockUserRepository)
+	mockJWTService := new(MockJWTService)
+	mockVerification := new(MockVerificationSender)
+
+	authService := NewAuthService(mockUserRepo, mockJWTService, testHasher, true, RegistrationPolicy{Open: func() bool { return true }, Role: entity.UserRoleViewer}, mockVerification)
+
+	mockUserRepo.On("Count").Return(int64(1), nil)
+	mockUserRepo.On("FindByEmail", "new@example.com").Return(nil, gorm.ErrRecordNotFound)
+	mockUserRepo.On("Create", mock.AnythingOfType("*entity.User")).Return(nil)
+	mockVerification.On("SendVerificationEmail", "new@example.com").Return(nil)
+
+	// Act
+	token, user, err := authService.Register(context.Background(), "new@example.com", "password123")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Empty(t, token)
+	assert.Nil(t, user.EmailVerifiedAt)
+	mockVerification.AssertExpectations(t)
+	mockJWTService.AssertNotCalled(t, "GenerateToken", mock.Anything, mock.Anything)
 }
@@ -0,0 +1,179 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/shoelfikar/voucher-management-system/internal/domain/entity"
+	"github.com/shoelfikar/voucher-management-system/internal/domain/repository"
+	domainService "github.com/shoelfikar/voucher-management-system/internal/domain/service"
+	"github.com/shoelfikar/voucher-management-system/pkg/jwt"
+	"github.com/shoelfikar/voucher-management-system/pkg/logger"
+)
+
+const (
+	// claimVoucherPurpose marks the signed tokens in claim links
+	claimVoucherPurpose = "claim_voucher"
+	// claimTokenIDBytes is the number of random bytes in a claim token's jti
+	claimTokenIDBytes = 16
+)
+
+// ClaimLinks configures the claim links handed to customers. Each link gets
+// the signed token appended as the "token" query parameter.
+type ClaimLinks struct {
+	URL        string
+	Expiration time.Duration
+}
+
+// claimServiceImpl implements domain service.ClaimService
+type claimServiceImpl struct {
+	linkRepo       repository.ClaimLinkRepository
+	voucherService domainService.VoucherService
+	customers      domainService.CustomerService
+	jwtService     jwt.JWTService
+	links          ClaimLinks
+}
+
+// NewClaimService creates a new claim service instance. Claims are assigned
+// through voucherService, which must have assignments enabled.
+func NewClaimService(
+	linkRepo repository.ClaimLinkRepository,
+	voucherService domainService.VoucherService,
+	customerService domainService.CustomerService,
+	jwtService jwt.JWTService,
+	links ClaimLinks,
+) domainService.ClaimService {
+	return &claimServiceImpl{
+		linkRepo:       linkRepo,
+		voucherService: voucherService,
+		customers:      customerService,
+		jwtService:     jwtService,
+		links:          links,
+	}
+}
+
+// CreateLinks generates one-time claim links for a voucher in one insert
+func (s *claimServiceImpl) CreateLinks(ctx context.Context, cmd *domainService.CreateClaimLinksCommand) (*domainService.ClaimLinkBatch, error) {
+	if cmd.Count < 1 || cmd.Count > domainService.MaxClaimLinks {
+		return nil, fmt.Errorf("count must be between 1 and %d", domainService.MaxClaimLinks)
+	}
+
+	voucher, err := s.voucherService.GetByID(ctx, cmd.VoucherID)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	if voucher.Status != entity.VoucherStatusActive {
+		return nil, fmt.Errorf("%w: %s is %s", domainService.ErrVoucherNotAssignable, voucher.VoucherCode, voucher.Status)
+	}
+	if isExpired(voucher, now) {
+		return nil, fmt.Errorf("%w: %s has expired", domainService.ErrVoucherNotAssignable, voucher.VoucherCode)
+	}
+
+	base, err := url.Parse(s.links.URL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid claim link URL: %w", err)
+	}
+
+	batch := &domainService.ClaimLinkBatch{
+		VoucherID: voucher.ID,
+		ExpiresAt: now.Add(s.links.Expiration),
+		URLs:      make([]string, 0, cmd.Count),
+	}
+	links := make([]*entity.ClaimLink, 0, cmd.Count)
+	for i := 0; i < cmd.Count; i++ {
+		tokenID, err := generateClaimTokenID()
+		if err != nil {
+			return nil, err
+		}
+		token, err := s.jwtService.GenerateOneTimeToken(claimVoucherPurpose, tokenID, s.links.Expiration)
+		if err != nil {
+			return nil, err
+		}
+
+		link := *base
+		query := link.Query()
+		query.Set("token", token)
+		link.RawQuery = query.Encode()
+		batch.URLs = append(batch.URLs, link.String())
+
+		links = append(links, &entity.ClaimLink{
+			VoucherID: voucher.ID,
+			TokenID:   tokenID,
+			ExpiresAt: batch.ExpiresAt,
+			CreatedBy: cmd.CreatedBy,
+		})
+	}
+
+	if err := s.linkRepo.CreateBatch(ctx, links); err != nil {
+		return nil, fmt.Errorf("failed to store claim links: %w", err)
+	}
+
+	logger.FromContext(ctx).Info("claim links created", "voucher_id", voucher.ID, "count", cmd.Count, "created_by", cmd.CreatedBy)
+	return batch, nil
+}
+
+// Claim reserves the link before assigning the voucher, so two customers
+// presenting the same link at once cannot both get it. When the assignment
+// fails the link is released for another try.
+func (s *claimServiceImpl) Claim(ctx context.Context, token, customerID string) (*entity.VoucherAssignment, error) {
+	customerID = strings.TrimSpace(customerID)
+	if customerID == "" {
+		return nil, errors.New("customer id is required")
+	}
+
+	claims, err := s.jwtService.ValidateActionToken(token, claimVoucherPurpose)
+	if err != nil || claims.ID == "" {
+		return nil, domainService.ErrInvalidClaimLink
+	}
+
+	link, err := s.linkRepo.FindByTokenID(ctx, claims.ID)
+	if err != nil {
+		return nil, err
+	}
+	if link == nil {
+		return nil, domainService.ErrInvalidClaimLink
+	}
+	if link.ClaimedAt != nil {
+		return nil, domainService.ErrClaimLinkUsed
+	}
+
+	customer, err := s.customers.Resolve(ctx, customerID)
+	if err != nil {
+		return nil, err
+	}
+
+	rowsAffected, err := s.linkRepo.MarkClaimed(ctx, link.ID, customer.ID, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	if rowsAffected == 0 {
+		// Another customer got there first
+		return nil, domainService.ErrClaimLinkUsed
+	}
+
+	assignment, err := s.voucherService.Assign(ctx, link.VoucherID, customer.ExternalID, fmt.Sprintf("claim link %d", link.ID))
+	if err != nil {
+		if releaseErr := s.linkRepo.Release(ctx, link.ID); releaseErr != nil {
+			logger.FromContext(ctx).Error("failed to release claim link", "claim_link_id", link.ID, "error", releaseErr)
+		}
+		return nil, err
+	}
+
+	return assignment, nil
+}
+
+// generateClaimTokenID returns a random jti for a claim token
+func generateClaimTokenID() (string, error) {
+	buf := make([]byte, claimTokenIDBytes)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("failed to generate claim token: %w", err)
+	}
+	return hex.EncodeToString(buf), nil
+}
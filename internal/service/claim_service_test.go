@@ -0,0 +1,159 @@
+package service
+
+import (
+	"context"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/shoelfikar/voucher-management-system/internal/domain/entity"
+	domainService "github.com/shoelfikar/voucher-management-system/internal/domain/service"
+	jwtPkg "github.com/shoelfikar/voucher-management-system/pkg/jwt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+// MockClaimLinkRepository is a mock implementation of ClaimLinkRepository
+type MockClaimLinkRepository struct {
+	mock.Mock
+}
+
+func (m *MockClaimLinkRepository) CreateBatch(ctx context.Context, links []*entity.ClaimLink) error {
+	args := m.Called(links)
+	return args.Error(0)
+}
+
+func (m *MockClaimLinkRepository) FindByTokenID(ctx context.Context, tokenID string) (*entity.ClaimLink, error) {
+	args := m.Called(tokenID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.ClaimLink), args.Error(1)
+}
+
+func (m *MockClaimLinkRepository) MarkClaimed(ctx context.Context, id, customerID uint, claimedAt time.Time) (int64, error) {
+	args := m.Called(id, customerID, claimedAt)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockClaimLinkRepository) Release(ctx context.Context, id uint) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+var testClaimLinks = ClaimLinks{URL: "https://shop.example.com/claim", Expiration: 24 * time.Hour}
+
+func TestClaimService_CreateLinks(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockVoucherRepository)
+	mockLinkRepo := new(MockClaimLinkRepository)
+	jwtService := jwtPkg.NewJWTService("test-secret", time.Hour)
+	claimService := NewClaimService(mockLinkRepo, NewVoucherService(mockRepo, 0), NewCustomerService(new(MockCustomerRepository)), jwtService, testClaimLinks)
+
+	mockRepo.On("FindByID", uint(1)).Return(&entity.Voucher{ID: 1, VoucherCode: "WELCOME", ExpiryDate: time.Now().AddDate(0, 1, 0), Status: entity.VoucherStatusActive}, nil)
+	mockLinkRepo.On("CreateBatch", mock.MatchedBy(func(links []*entity.ClaimLink) bool { return len(links) == 3 })).Return(nil)
+
+	// Act
+	batch, err := claimService.CreateLinks(context.Background(), &domainService.CreateClaimLinksCommand{VoucherID: 1, Count: 3, CreatedBy: "admin@example.com"})
+
+	// Assert
+	assert.NoError(t, err)
+	if assert.Len(t, batch.URLs, 3) {
+		link, _ := url.Parse(batch.URLs[0])
+		assert.Equal(t, "shop.example.com", link.Host)
+		claims, err := jwtService.ValidateActionToken(link.Query().Get("token"), claimVoucherPurpose)
+		assert.NoError(t, err)
+		assert.Len(t, claims.ID, 2*claimTokenIDBytes)
+		assert.NotEqual(t, batch.URLs[0], batch.URLs[1])
+	}
+	mockLinkRepo.AssertExpectations(t)
+}
+
+func TestClaimService_CreateLinks_Refused(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockVoucherRepository)
+	mockLinkRepo := new(MockClaimLinkRepository)
+	claimService := NewClaimService(mockLinkRepo, NewVoucherService(mockRepo, 0), NewCustomerService(new(MockCustomerRepository)), jwtPkg.NewJWTService("test-secret", time.Hour), testClaimLinks)
+
+	mockRepo.On("FindByID", uint(1)).Return(&entity.Voucher{ID: 1, VoucherCode: "OLD", ExpiryDate: time.Now().AddDate(0, 1, 0), Status: entity.VoucherStatusInactive}, nil)
+
+	// Act
+	_, inactiveErr := claimService.CreateLinks(context.Background(), &domainService.CreateClaimLinksCommand{VoucherID: 1, Count: 1})
+	_, countErr := claimService.CreateLinks(context.Background(), &domainService.CreateClaimLinksCommand{VoucherID: 1, Count: domainService.MaxClaimLinks + 1})
+
+	// Assert
+	assert.ErrorIs(t, inactiveErr, domainService.ErrVoucherNotAssignable)
+	assert.Error(t, countErr)
+	mockLinkRepo.AssertNotCalled(t, "CreateBatch", mock.Anything)
+}
+
+func TestClaimService_Claim(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockVoucherRepository)
+	mockAssignmentRepo := new(MockVoucherAssignmentRepository)
+	mockLinkRepo := new(MockClaimLinkRepository)
+	jwtService := jwtPkg.NewJWTService("test-secret", time.Hour)
+	mockCustomerRepo := new(MockCustomerRepository)
+	customerService := NewCustomerService(mockCustomerRepo)
+	voucherService := NewVoucherService(mockRepo, 0, WithAssignments(mockAssignmentRepo, customerService))
+	claimService := NewClaimService(mockLinkRepo, voucherService, customerService, jwtService, testClaimLinks)
+
+	token, _ := jwtService.GenerateOneTimeToken(claimVoucherPurpose, "token-1", time.Hour)
+	mockLinkRepo.On("FindByTokenID", "token-1").Return(&entity.ClaimLink{ID: 5, VoucherID: 1, TokenID: "token-1"}, nil)
+	mockCustomerRepo.On("FindOrCreate", "cust-1").Return(&entity.Customer{ID: 8, ExternalID: "cust-1"}, nil)
+	mockLinkRepo.On("MarkClaimed", uint(5), uint(8), mock.AnythingOfType("time.Time")).Return(int64(1), nil)
+	mockRepo.On("FindByID", uint(1)).Return(&entity.Voucher{ID: 1, VoucherCode: "WELCOME", ExpiryDate: time.Now().AddDate(0, 1, 0), Status: entity.VoucherStatusActive}, nil)
+	mockAssignmentRepo.On("Create", mock.AnythingOfType("*entity.VoucherAssignment")).Return(nil)
+
+	// Act
+	assignment, err := claimService.Claim(context.Background(), token, "cust-1")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, uint(8), assignment.CustomerID)
+	assert.Equal(t, "claim link 5", assignment.AssignedBy)
+	mockLinkRepo.AssertNotCalled(t, "Release", mock.Anything)
+}
+
+func TestClaimService_Claim_Refused(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockVoucherRepository)
+	mockAssignmentRepo := new(MockVoucherAssignmentRepository)
+	mockLinkRepo := new(MockClaimLinkRepository)
+	jwtService := jwtPkg.NewJWTService("test-secret", time.Hour)
+	mockCustomerRepo := new(MockCustomerRepository)
+	customerService := NewCustomerService(mockCustomerRepo)
+	voucherService := NewVoucherService(mockRepo, 0, WithAssignments(mockAssignmentRepo, customerService))
+	claimService := NewClaimService(mockLinkRepo, voucherService, customerService, jwtService, testClaimLinks)
+
+	usedToken, _ := jwtService.GenerateOneTimeToken(claimVoucherPurpose, "used", time.Hour)
+	racedToken, _ := jwtService.GenerateOneTimeToken(claimVoucherPurpose, "raced", time.Hour)
+	twiceToken, _ := jwtService.GenerateOneTimeToken(claimVoucherPurpose, "twice", time.Hour)
+	inviteToken, _ := jwtService.GenerateActionToken("invite", "new@example.com", "viewer", time.Hour)
+	claimedAt := time.Now()
+	mockLinkRepo.On("FindByTokenID", "used").Return(&entity.ClaimLink{ID: 1, VoucherID: 1, ClaimedAt: &claimedAt}, nil)
+	mockCustomerRepo.On("FindOrCreate", "cust-1").Return(&entity.Customer{ID: 8, ExternalID: "cust-1"}, nil)
+	mockLinkRepo.On("FindByTokenID", "raced").Return(&entity.ClaimLink{ID: 2, VoucherID: 1}, nil)
+	mockLinkRepo.On("MarkClaimed", uint(2), uint(8), mock.AnythingOfType("time.Time")).Return(int64(0), nil)
+	mockLinkRepo.On("FindByTokenID", "twice").Return(&entity.ClaimLink{ID: 3, VoucherID: 1}, nil)
+	mockLinkRepo.On("MarkClaimed", uint(3), uint(8), mock.AnythingOfType("time.Time")).Return(int64(1), nil)
+	mockLinkRepo.On("Release", uint(3)).Return(nil)
+	mockRepo.On("FindByID", uint(1)).Return(&entity.Voucher{ID: 1, VoucherCode: "WELCOME", ExpiryDate: time.Now().AddDate(0, 1, 0), Status: entity.VoucherStatusActive}, nil)
+	mockAssignmentRepo.On("Create", mock.AnythingOfType("*entity.VoucherAssignment")).Return(gorm.ErrDuplicatedKey)
+
+	// Act
+	_, forgedErr := claimService.Claim(context.Background(), "not-a-token", "cust-1")
+	_, inviteErr := claimService.Claim(context.Background(), inviteToken, "cust-1")
+	_, usedErr := claimService.Claim(context.Background(), usedToken, "cust-1")
+	_, racedErr := claimService.Claim(context.Background(), racedToken, "cust-1")
+	_, twiceErr := claimService.Claim(context.Background(), twiceToken, "cust-1")
+
+	// Assert
+	assert.ErrorIs(t, forgedErr, domainService.ErrInvalidClaimLink)
+	assert.ErrorIs(t, inviteErr, domainService.ErrInvalidClaimLink)
+	assert.ErrorIs(t, usedErr, domainService.ErrClaimLinkUsed)
+	assert.ErrorIs(t, racedErr, domainService.ErrClaimLinkUsed)
+	assert.ErrorIs(t, twiceErr, domainService.ErrVoucherAlreadyAssigned)
+	mockLinkRepo.AssertCalled(t, "Release", uint(3))
+}
@@ -0,0 +1,362 @@
+package service
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/mail"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/shoelfikar/voucher-management-system/internal/domain/entity"
+	"github.com/shoelfikar/voucher-management-system/internal/domain/repository"
+	domainService "github.com/shoelfikar/voucher-management-system/internal/domain/service"
+	"github.com/shoelfikar/voucher-management-system/pkg/logger"
+	"github.com/shoelfikar/voucher-management-system/pkg/utils"
+	"gorm.io/gorm"
+)
+
+// maxCustomerImportRows bounds the data rows of one customer import
+const maxCustomerImportRows = 50000
+
+// customerServiceImpl implements domain service.CustomerService
+type customerServiceImpl struct {
+	customerRepo repository.CustomerRepository
+	now          func() time.Time
+}
+
+// NewCustomerService creates a new customer service instance
+func NewCustomerService(customerRepo repository.CustomerRepository) domainService.CustomerService {
+	return &customerServiceImpl{customerRepo: customerRepo, now: time.Now}
+}
+
+// GetAll retrieves customers with pagination, search, and sorting
+func (s *customerServiceImpl) GetAll(ctx context.Context, page, limit int, search string, sort []utils.SortField) ([]*entity.Customer, int64, error) {
+	return s.customerRepo.FindAll(ctx, page, limit, search, sort)
+}
+
+// GetByID retrieves a customer by ID
+func (s *customerServiceImpl) GetByID(ctx context.Context, id uint) (*entity.Customer, error) {
+	customer, err := s.customerRepo.FindByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domainService.ErrCustomerNotFound
+		}
+		return nil, err
+	}
+	return customer, nil
+}
+
+// Create validates and stores a new customer
+func (s *customerServiceImpl) Create(ctx context.Context, cmd *domainService.CustomerCommand) (*entity.Customer, error) {
+	customer, err := validateCustomer(cmd)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.customerRepo.Create(ctx, customer); err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return nil, domainService.ErrDuplicateCustomer
+		}
+		return nil, fmt.Errorf("failed to create customer: %w", err)
+	}
+	return customer, nil
+}
+
+// Update validates and saves a customer that was not merged away
+func (s *customerServiceImpl) Update(ctx context.Context, id uint, cmd *domainService.CustomerCommand) (*entity.Customer, error) {
+	existing, err := s.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if existing.MergedIntoID != nil {
+		return nil, domainService.ErrCustomerMerged
+	}
+	if existing.ErasedAt != nil {
+		return nil, domainService.ErrCustomerErased
+	}
+
+	customer, err := validateCustomer(cmd)
+	if err != nil {
+		return nil, err
+	}
+	customer.ID = id
+	customer.CreatedAt = existing.CreatedAt
+
+	if _, err := s.customerRepo.Update(ctx, customer); err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return nil, domainService.ErrDuplicateCustomer
+		}
+		return nil, fmt.Errorf("failed to update customer: %w", err)
+	}
+	return customer, nil
+}
+
+// Delete removes a customer; the database refuses while anything references it
+func (s *customerServiceImpl) Delete(ctx context.Context, id uint) error {
+	rowsAffected, err := s.customerRepo.Delete(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrForeignKeyViolated) {
+			return domainService.ErrCustomerInUse
+		}
+		return err
+	}
+	if rowsAffected == 0 {
+		return domainService.ErrCustomerNotFound
+	}
+	return nil
+}
+
+// Import creates or updates customers row by row; a bad row is reported and skipped
+func (s *customerServiceImpl) Import(ctx context.Context, file io.Reader) (*domainService.CustomerImportResult, error) {
+	records, err := csv.NewReader(file).ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CSV file: %w", err)
+	}
+	if len(records) < 2 {
+		return nil, errors.New("CSV file is empty or has no data rows")
+	}
+	if len(records)-1 > maxCustomerImportRows {
+		return nil, fmt.Errorf("CSV file may have at most %d data rows", maxCustomerImportRows)
+	}
+
+	header := make([]string, len(records[0]))
+	for i, name := range records[0] {
+		header[i] = strings.ToLower(strings.TrimSpace(name))
+	}
+	externalIDCol := slices.Index(header, "external_id")
+	if externalIDCol < 0 {
+		return nil, errors.New("CSV header must have an external_id column")
+	}
+	emailCol := slices.Index(header, "email")
+	phoneCol := slices.Index(header, "phone")
+
+	result := &domainService.CustomerImportResult{
+		TotalRows: len(records) - 1,
+		Errors:    []domainService.ImportError{},
+	}
+	for i, record := range records[1:] {
+		cmd := &domainService.CustomerCommand{
+			ExternalID: csvField(record, externalIDCol),
+			Email:      csvField(record, emailCol),
+			Phone:      csvField(record, phoneCol),
+		}
+		created, err := s.importCustomer(ctx, cmd)
+		if err != nil {
+			result.Failed++
+			result.Errors = append(result.Errors, domainService.ImportError{Row: i + 2, Error: err.Error()})
+			continue
+		}
+		if created {
+			result.Created++
+		} else {
+			result.Updated++
+		}
+	}
+
+	logger.FromContext(ctx).Info("customers imported", "created", result.Created, "updated", result.Updated, "failed", result.Failed)
+	return result, nil
+}
+
+// importCustomer creates the customer in one import row, or fills in the
+// contact details given for an existing one, and reports whether it was new
+func (s *customerServiceImpl) importCustomer(ctx context.Context, cmd *domainService.CustomerCommand) (bool, error) {
+	customer, err := validateCustomer(cmd)
+	if err != nil {
+		return false, err
+	}
+
+	existing, err := s.Find(ctx, customer.ExternalID)
+	if err != nil {
+		return false, err
+	}
+	if existing == nil {
+		if err := s.customerRepo.Create(ctx, customer); err != nil {
+			return false, fmt.Errorf("failed to create customer: %w", err)
+		}
+		return true, nil
+	}
+
+	// Blank cells leave the stored details alone
+	if customer.Email != "" {
+		existing.Email = customer.Email
+	}
+	if customer.Phone != "" {
+		existing.Phone = customer.Phone
+	}
+	if _, err := s.customerRepo.Update(ctx, existing); err != nil {
+		return false, fmt.Errorf("failed to update customer: %w", err)
+	}
+	return false, nil
+}
+
+// Merge folds a duplicate into the survivor. Neither may have been merged
+// away already, so merges never form chains or cycles.
+func (s *customerServiceImpl) Merge(ctx context.Context, survivorID, duplicateID uint) (*entity.Customer, error) {
+	if survivorID == duplicateID {
+		return nil, errors.New("a customer cannot be merged into itself")
+	}
+
+	survivor, err := s.GetByID(ctx, survivorID)
+	if err != nil {
+		return nil, err
+	}
+	duplicate, err := s.GetByID(ctx, duplicateID)
+	if err != nil {
+		return nil, err
+	}
+	if survivor.MergedIntoID != nil || duplicate.MergedIntoID != nil {
+		return nil, domainService.ErrCustomerMerged
+	}
+	if survivor.ErasedAt != nil || duplicate.ErasedAt != nil {
+		return nil, domainService.ErrCustomerErased
+	}
+
+	if survivor.Email == "" {
+		survivor.Email = duplicate.Email
+	}
+	if survivor.Phone == "" {
+		survivor.Phone = duplicate.Phone
+	}
+	if err := s.customerRepo.Merge(ctx, survivor, duplicate.ID); err != nil {
+		return nil, fmt.Errorf("failed to merge customers: %w", err)
+	}
+
+	logger.FromContext(ctx).Info("customer merged", "duplicate_id", duplicate.ID, "survivor_id", survivor.ID)
+	return survivor, nil
+}
+
+// customerErasureAudit is the audit entry details of an erasure; it names
+// customers by ID only, so the trail holds nothing that was erased
+type customerErasureAudit struct {
+	CustomerID         uint  `json:"customer_id"`
+	MergedCustomers    int64 `json:"merged_customers"`
+	Redemptions        int64 `json:"redemptions"`
+	SMSDeliveries      int64 `json:"sms_deliveries"`
+	SegmentMemberships int64 `json:"segment_memberships"`
+}
+
+// Erase anonymizes a customer that was not merged away. A duplicate is
+// erased through the customer it was merged into, which also holds its records.
+func (s *customerServiceImpl) Erase(ctx context.Context, id uint, actor string) (*domainService.CustomerErasure, error) {
+	customer, err := s.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if customer.MergedIntoID != nil {
+		return nil, domainService.ErrCustomerMerged
+	}
+	if customer.ErasedAt != nil {
+		return nil, domainService.ErrCustomerErased
+	}
+
+	erasedAt := s.now()
+	erasure, err := s.customerRepo.Erase(ctx, id, erasedAt, func(erasure *repository.CustomerErasure) (*entity.AuditEntry, error) {
+		details, err := json.Marshal(customerErasureAudit{
+			CustomerID:         id,
+			MergedCustomers:    erasure.Customers - 1,
+			Redemptions:        erasure.Redemptions,
+			SMSDeliveries:      erasure.SMSDeliveries,
+			SegmentMemberships: erasure.SegmentMembers,
+		})
+		if err != nil {
+			return nil, err
+		}
+		return &entity.AuditEntry{Action: entity.AuditActionCustomerErase, Actor: actor, Details: string(details)}, nil
+	})
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domainService.ErrCustomerNotFound
+		}
+		return nil, fmt.Errorf("failed to erase customer: %w", err)
+	}
+
+	logger.FromContext(ctx).Info("customer erased", "customer_id", id, "erased_by", actor)
+	return &domainService.CustomerErasure{
+		CustomerID:         id,
+		ErasedAt:           erasedAt,
+		MergedCustomers:    erasure.Customers - 1,
+		Redemptions:        erasure.Redemptions,
+		SMSDeliveries:      erasure.SMSDeliveries,
+		SegmentMemberships: erasure.SegmentMembers,
+	}, nil
+}
+
+// Resolve returns the customer with the external ID, creating it on first use
+func (s *customerServiceImpl) Resolve(ctx context.Context, externalID string) (*entity.Customer, error) {
+	externalID = strings.TrimSpace(externalID)
+	if externalID == "" {
+		return nil, errors.New("customer id is required")
+	}
+
+	customer, err := s.customerRepo.FindOrCreate(ctx, externalID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve customer: %w", err)
+	}
+	return s.survivor(ctx, customer)
+}
+
+// Find returns the customer with the external ID, or nil when there is none
+func (s *customerServiceImpl) Find(ctx context.Context, externalID string) (*entity.Customer, error) {
+	customer, err := s.customerRepo.FindByExternalID(ctx, strings.TrimSpace(externalID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to look up customer: %w", err)
+	}
+	if customer == nil {
+		return nil, nil
+	}
+	return s.survivor(ctx, customer)
+}
+
+// survivor returns the customer a merged duplicate was merged into, or the
+// customer itself; nothing more may be recorded against an erased customer
+func (s *customerServiceImpl) survivor(ctx context.Context, customer *entity.Customer) (*entity.Customer, error) {
+	if customer.MergedIntoID != nil {
+		var err error
+		customer, err = s.customerRepo.FindByID(ctx, *customer.MergedIntoID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to look up merged customer: %w", err)
+		}
+	}
+	if customer.ErasedAt != nil {
+		return nil, domainService.ErrCustomerErased
+	}
+	return customer, nil
+}
+
+// validateCustomer trims a customer command and checks its contact details
+func validateCustomer(cmd *domainService.CustomerCommand) (*entity.Customer, error) {
+	customer := &entity.Customer{
+		ExternalID: strings.TrimSpace(cmd.ExternalID),
+		Email:      strings.TrimSpace(cmd.Email),
+		Phone:      strings.TrimSpace(cmd.Phone),
+	}
+	if customer.ExternalID == "" {
+		return nil, errors.New("customer external id is required")
+	}
+	if len(customer.ExternalID) > 255 {
+		return nil, errors.New("customer external id must be at most 255 characters")
+	}
+	if customer.Email != "" {
+		address, err := mail.ParseAddress(customer.Email)
+		if err != nil || address.Address != customer.Email {
+			return nil, fmt.Errorf("invalid email '%s'", customer.Email)
+		}
+	}
+	if customer.Phone != "" && !e164Pattern.MatchString(customer.Phone) {
+		return nil, domainService.ErrInvalidPhoneNumber
+	}
+	return customer, nil
+}
+
+// csvField returns the trimmed cell at col, or "" when the column is absent or the row short
+func csvField(record []string, col int) string {
+	if col < 0 || col >= len(record) {
+		return ""
+	}
+	return strings.TrimSpace(record[col])
+}
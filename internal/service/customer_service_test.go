@@ -0,0 +1,319 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/shoelfikar/voucher-management-system/internal/domain/entity"
+	"github.com/shoelfikar/voucher-management-system/internal/domain/repository"
+	domainService "github.com/shoelfikar/voucher-management-system/internal/domain/service"
+	"github.com/shoelfikar/voucher-management-system/pkg/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+// MockCustomerRepository is a mock implementation of CustomerRepository
+type MockCustomerRepository struct {
+	mock.Mock
+
+	// AuditEntry is the entry the audit callback of the last Erase returned
+	AuditEntry *entity.AuditEntry
+}
+
+func (m *MockCustomerRepository) FindAll(ctx context.Context, page, limit int, search string, sort []utils.SortField) ([]*entity.Customer, int64, error) {
+	args := m.Called(page, limit, search, sort)
+	return args.Get(0).([]*entity.Customer), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockCustomerRepository) FindByID(ctx context.Context, id uint) (*entity.Customer, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Customer), args.Error(1)
+}
+
+func (m *MockCustomerRepository) FindByExternalID(ctx context.Context, externalID string) (*entity.Customer, error) {
+	args := m.Called(externalID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Customer), args.Error(1)
+}
+
+func (m *MockCustomerRepository) FindOrCreate(ctx context.Context, externalID string) (*entity.Customer, error) {
+	args := m.Called(externalID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Customer), args.Error(1)
+}
+
+func (m *MockCustomerRepository) Create(ctx context.Context, customer *entity.Customer) error {
+	args := m.Called(customer)
+	return args.Error(0)
+}
+
+func (m *MockCustomerRepository) Update(ctx context.Context, customer *entity.Customer) (int64, error) {
+	args := m.Called(customer)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockCustomerRepository) Delete(ctx context.Context, id uint) (int64, error) {
+	args := m.Called(id)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockCustomerRepository) Merge(ctx context.Context, survivor *entity.Customer, duplicateID uint) error {
+	args := m.Called(survivor, duplicateID)
+	return args.Error(0)
+}
+
+func (m *MockCustomerRepository) Erase(ctx context.Context, id uint, erasedAt time.Time, audit func(erasure *repository.CustomerErasure) (*entity.AuditEntry, error)) (*repository.CustomerErasure, error) {
+	args := m.Called(id, erasedAt)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	erasure := args.Get(0).(*repository.CustomerErasure)
+	entry, err := audit(erasure)
+	if err != nil {
+		return nil, err
+	}
+	m.AuditEntry = entry
+	return erasure, args.Error(1)
+}
+
+func TestCustomerService_Resolve_FollowsMerge(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockCustomerRepository)
+	customerService := NewCustomerService(mockRepo)
+	survivorID := uint(1)
+	survivor := &entity.Customer{ID: 1, ExternalID: "crm-1"}
+	mockRepo.On("FindOrCreate", "crm-1").Return(survivor, nil)
+	mockRepo.On("FindOrCreate", "legacy-1").Return(&entity.Customer{ID: 2, ExternalID: "legacy-1", MergedIntoID: &survivorID}, nil)
+	mockRepo.On("FindByID", uint(1)).Return(survivor, nil)
+
+	// Act
+	direct, directErr := customerService.Resolve(context.Background(), " crm-1 ")
+	merged, mergedErr := customerService.Resolve(context.Background(), "legacy-1")
+	_, blankErr := customerService.Resolve(context.Background(), " ")
+
+	// Assert
+	assert.NoError(t, directErr)
+	assert.Equal(t, survivor, direct)
+	assert.NoError(t, mergedErr)
+	assert.Equal(t, survivor, merged)
+	assert.Error(t, blankErr)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestCustomerService_Create(t *testing.T) {
+	tests := []struct {
+		name    string
+		cmd     *domainService.CustomerCommand
+		repoErr error
+		wantErr error
+	}{
+		{name: "created", cmd: &domainService.CustomerCommand{ExternalID: " crm-1 ", Email: "ana@example.com", Phone: "+14155550123"}},
+		{name: "taken external id", cmd: &domainService.CustomerCommand{ExternalID: "crm-1"}, repoErr: gorm.ErrDuplicatedKey, wantErr: domainService.ErrDuplicateCustomer},
+		{name: "invalid phone", cmd: &domainService.CustomerCommand{ExternalID: "crm-1", Phone: "0415"}, wantErr: domainService.ErrInvalidPhoneNumber},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			mockRepo := new(MockCustomerRepository)
+			customerService := NewCustomerService(mockRepo)
+			mockRepo.On("Create", mock.AnythingOfType("*entity.Customer")).Return(tt.repoErr)
+
+			// Act
+			customer, err := customerService.Create(context.Background(), tt.cmd)
+
+			// Assert
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, "crm-1", customer.ExternalID)
+		})
+	}
+}
+
+func TestCustomerService_Delete(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockCustomerRepository)
+	customerService := NewCustomerService(mockRepo)
+	mockRepo.On("Delete", uint(1)).Return(int64(1), nil)
+	mockRepo.On("Delete", uint(2)).Return(int64(0), gorm.ErrForeignKeyViolated)
+	mockRepo.On("Delete", uint(3)).Return(int64(0), nil)
+
+	// Act
+	deletedErr := customerService.Delete(context.Background(), 1)
+	inUseErr := customerService.Delete(context.Background(), 2)
+	missingErr := customerService.Delete(context.Background(), 3)
+
+	// Assert
+	assert.NoError(t, deletedErr)
+	assert.ErrorIs(t, inUseErr, domainService.ErrCustomerInUse)
+	assert.ErrorIs(t, missingErr, domainService.ErrCustomerNotFound)
+}
+
+func TestCustomerService_Import(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockCustomerRepository)
+	customerService := NewCustomerService(mockRepo)
+	existing := &entity.Customer{ID: 4, ExternalID: "crm-2", Email: "old@example.com", Phone: "+14155550100"}
+	mockRepo.On("FindByExternalID", "crm-1").Return(nil, nil)
+	mockRepo.On("FindByExternalID", "crm-2").Return(existing, nil)
+	mockRepo.On("Create", &entity.Customer{ExternalID: "crm-1", Email: "ana@example.com"}).Return(nil)
+	mockRepo.On("Update", &entity.Customer{ID: 4, ExternalID: "crm-2", Email: "new@example.com", Phone: "+14155550100"}).Return(int64(1), nil)
+	csvData := "phone,External_ID,email\n" +
+		",crm-1,ana@example.com\n" +
+		",crm-2,new@example.com\n" +
+		",,nobody@example.com\n" +
+		",crm-3,not-an-email\n"
+
+	// Act
+	result, err := customerService.Import(context.Background(), strings.NewReader(csvData))
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, 4, result.TotalRows)
+	assert.Equal(t, 1, result.Created)
+	assert.Equal(t, 1, result.Updated)
+	assert.Equal(t, 2, result.Failed)
+	if assert.Len(t, result.Errors, 2) {
+		assert.Equal(t, 4, result.Errors[0].Row)
+		assert.Equal(t, 5, result.Errors[1].Row)
+	}
+	mockRepo.AssertExpectations(t)
+}
+
+func TestCustomerService_Import_MissingColumn(t *testing.T) {
+	// Arrange
+	customerService := NewCustomerService(new(MockCustomerRepository))
+
+	// Act
+	_, err := customerService.Import(context.Background(), strings.NewReader("email\nana@example.com\n"))
+
+	// Assert
+	assert.EqualError(t, err, "CSV header must have an external_id column")
+}
+
+func TestCustomerService_Merge(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockCustomerRepository)
+	customerService := NewCustomerService(mockRepo)
+	mockRepo.On("FindByID", uint(1)).Return(&entity.Customer{ID: 1, ExternalID: "crm-1", Email: "ana@example.com"}, nil)
+	mockRepo.On("FindByID", uint(2)).Return(&entity.Customer{ID: 2, ExternalID: "legacy-1", Email: "ana@old.example.com", Phone: "+14155550123"}, nil)
+	mockRepo.On("Merge", &entity.Customer{ID: 1, ExternalID: "crm-1", Email: "ana@example.com", Phone: "+14155550123"}, uint(2)).Return(nil)
+
+	// Act
+	survivor, err := customerService.Merge(context.Background(), 1, 2)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "ana@example.com", survivor.Email)
+	assert.Equal(t, "+14155550123", survivor.Phone)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestCustomerService_Merge_Rejected(t *testing.T) {
+	mergedInto := uint(9)
+	tests := []struct {
+		name      string
+		duplicate *entity.Customer
+		findErr   error
+		wantErr   error
+	}{
+		{name: "already merged", duplicate: &entity.Customer{ID: 2, ExternalID: "legacy-1", MergedIntoID: &mergedInto}, wantErr: domainService.ErrCustomerMerged},
+		{name: "missing duplicate", findErr: gorm.ErrRecordNotFound, wantErr: domainService.ErrCustomerNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			mockRepo := new(MockCustomerRepository)
+			customerService := NewCustomerService(mockRepo)
+			mockRepo.On("FindByID", uint(1)).Return(&entity.Customer{ID: 1, ExternalID: "crm-1"}, nil)
+			if tt.duplicate != nil {
+				mockRepo.On("FindByID", uint(2)).Return(tt.duplicate, nil)
+			} else {
+				mockRepo.On("FindByID", uint(2)).Return(nil, tt.findErr)
+			}
+
+			// Act
+			_, err := customerService.Merge(context.Background(), 1, 2)
+
+			// Assert
+			assert.ErrorIs(t, err, tt.wantErr)
+			mockRepo.AssertNotCalled(t, "Merge", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestCustomerService_Merge_Self(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockCustomerRepository)
+	customerService := NewCustomerService(mockRepo)
+
+	// Act
+	_, err := customerService.Merge(context.Background(), 1, 1)
+
+	// Assert
+	assert.Error(t, err)
+	assert.False(t, errors.Is(err, domainService.ErrCustomerMerged))
+	mockRepo.AssertNotCalled(t, "FindByID", mock.Anything)
+}
+
+func TestCustomerService_Erase(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockCustomerRepository)
+	customerService := NewCustomerService(mockRepo).(*customerServiceImpl)
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	customerService.now = func() time.Time { return now }
+	survivorID := uint(1)
+	mockRepo.On("FindByID", uint(1)).Return(&entity.Customer{ID: 1, ExternalID: "crm-1", Email: "ana@example.com"}, nil)
+	mockRepo.On("FindByID", uint(2)).Return(&entity.Customer{ID: 2, ExternalID: "legacy-1", MergedIntoID: &survivorID}, nil)
+	mockRepo.On("FindByID", uint(3)).Return(&entity.Customer{ID: 3, ExternalID: entity.ErasedExternalID(3), ErasedAt: &now}, nil)
+	mockRepo.On("FindByID", uint(4)).Return(nil, gorm.ErrRecordNotFound)
+	mockRepo.On("Erase", uint(1), now).Return(&repository.CustomerErasure{Customers: 2, Redemptions: 3, SMSDeliveries: 1, SegmentMembers: 2}, nil)
+
+	// Act
+	erasure, err := customerService.Erase(context.Background(), 1, "admin@example.com")
+	_, mergedErr := customerService.Erase(context.Background(), 2, "admin@example.com")
+	_, erasedErr := customerService.Erase(context.Background(), 3, "admin@example.com")
+	_, missingErr := customerService.Erase(context.Background(), 4, "admin@example.com")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, &domainService.CustomerErasure{CustomerID: 1, ErasedAt: now, MergedCustomers: 1, Redemptions: 3, SMSDeliveries: 1, SegmentMemberships: 2}, erasure)
+	if assert.NotNil(t, mockRepo.AuditEntry) {
+		assert.Equal(t, entity.AuditActionCustomerErase, mockRepo.AuditEntry.Action)
+		assert.Equal(t, "admin@example.com", mockRepo.AuditEntry.Actor)
+		assert.JSONEq(t, `{"customer_id":1,"merged_customers":1,"redemptions":3,"sms_deliveries":1,"segment_memberships":2}`, mockRepo.AuditEntry.Details)
+	}
+	assert.ErrorIs(t, mergedErr, domainService.ErrCustomerMerged)
+	assert.ErrorIs(t, erasedErr, domainService.ErrCustomerErased)
+	assert.ErrorIs(t, missingErr, domainService.ErrCustomerNotFound)
+	mockRepo.AssertNumberOfCalls(t, "Erase", 1)
+}
+
+func TestCustomerService_Resolve_ErasedCustomer(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockCustomerRepository)
+	customerService := NewCustomerService(mockRepo)
+	erasedAt := time.Now()
+	mockRepo.On("FindOrCreate", "erased:7").Return(&entity.Customer{ID: 7, ExternalID: "erased:7", ErasedAt: &erasedAt}, nil)
+
+	// Act
+	_, err := customerService.Resolve(context.Background(), "erased:7")
+
+	// Assert
+	assert.ErrorIs(t, err, domainService.ErrCustomerErased)
+}
@@ -0,0 +1,148 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/shoelfikar/voucher-management-system/internal/domain/entity"
+	"github.com/shoelfikar/voucher-management-system/internal/domain/repository"
+	domainService "github.com/shoelfikar/voucher-management-system/internal/domain/service"
+	"github.com/shoelfikar/voucher-management-system/pkg/logger"
+	"github.com/shoelfikar/voucher-management-system/pkg/utils"
+	"gorm.io/gorm"
+)
+
+// claimTokenPattern matches the signed token of a claim link in a message
+var claimTokenPattern = regexp.MustCompile(`([?&]token=)[^&\s]+`)
+
+// deadLetterServiceImpl implements domain service.DeadLetterService. Failed
+// SMS deliveries are the only jobs that run out of attempts today; other
+// kinds of background work plug in as further kinds.
+type deadLetterServiceImpl struct {
+	deliveryRepo repository.SMSDeliveryRepository
+	voucherRepo  repository.VoucherRepository
+	now          func() time.Time
+}
+
+// NewDeadLetterService creates a new dead-letter service instance
+func NewDeadLetterService(deliveryRepo repository.SMSDeliveryRepository, voucherRepo repository.VoucherRepository) domainService.DeadLetterService {
+	return &deadLetterServiceImpl{
+		deliveryRepo: deliveryRepo,
+		voucherRepo:  voucherRepo,
+		now:          time.Now,
+	}
+}
+
+// GetAll lists failed jobs with a redacted preview of their payload
+func (s *deadLetterServiceImpl) GetAll(ctx context.Context, kind string, page, limit int) ([]*domainService.DeadLetter, int64, error) {
+	if kind != "" && !slices.Contains(domainService.DeadLetterKinds, kind) {
+		return nil, 0, fmt.Errorf("kind must be one of %s", strings.Join(domainService.DeadLetterKinds, ", "))
+	}
+
+	deliveries, total, err := s.deliveryRepo.FindAll(ctx, repository.SMSDeliveryFilter{Status: entity.SMSDeliveryStatusFailed}, page, limit)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to fetch failed SMS deliveries: %w", err)
+	}
+
+	vouchers := map[uint]*entity.Voucher{}
+	letters := make([]*domainService.DeadLetter, 0, len(deliveries))
+	for _, delivery := range deliveries {
+		letters = append(letters, &domainService.DeadLetter{
+			ID:        domainService.DeadLetterKindSMS + "-" + strconv.FormatUint(uint64(delivery.ID), 10),
+			Kind:      domainService.DeadLetterKindSMS,
+			Attempts:  delivery.Attempts,
+			LastError: delivery.LastError,
+			FailedAt:  delivery.UpdatedAt,
+			CreatedBy: delivery.CreatedBy,
+			Preview: map[string]interface{}{
+				"voucher_id":   delivery.VoucherID,
+				"phone_number": delivery.PhoneNumber,
+				"customer_id":  delivery.CustomerID,
+				"content":      delivery.Content,
+				"message":      s.redactSMSBody(ctx, delivery, vouchers),
+			},
+		})
+	}
+	return letters, total, nil
+}
+
+// Retry makes a failed job pending again, due at once
+func (s *deadLetterServiceImpl) Retry(ctx context.Context, id, actor string) error {
+	deliveryID, err := parseDeadLetterID(id)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := s.deliveryRepo.Requeue(ctx, deliveryID, s.now())
+	if err != nil {
+		return fmt.Errorf("failed to requeue SMS delivery: %w", err)
+	}
+	if rowsAffected == 0 {
+		return domainService.ErrDeadLetterNotFound
+	}
+
+	logger.FromContext(ctx).Info("dead letter requeued", "dead_letter_id", id, "requeued_by", actor)
+	return nil
+}
+
+// Discard gives up on a failed job
+func (s *deadLetterServiceImpl) Discard(ctx context.Context, id, actor string) error {
+	deliveryID, err := parseDeadLetterID(id)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := s.deliveryRepo.Discard(ctx, deliveryID)
+	if err != nil {
+		return fmt.Errorf("failed to discard SMS delivery: %w", err)
+	}
+	if rowsAffected == 0 {
+		return domainService.ErrDeadLetterNotFound
+	}
+
+	logger.FromContext(ctx).Info("dead letter discarded", "dead_letter_id", id, "discarded_by", actor)
+	return nil
+}
+
+// redactSMSBody hides the claim link token and voucher code in a message.
+// Vouchers are looked up once per listing through the vouchers map.
+func (s *deadLetterServiceImpl) redactSMSBody(ctx context.Context, delivery *entity.SMSDelivery, vouchers map[uint]*entity.Voucher) string {
+	body := claimTokenPattern.ReplaceAllString(delivery.Body, "${1}[REDACTED]")
+	if delivery.Content != entity.SMSContentCode {
+		return body
+	}
+
+	voucher, ok := vouchers[delivery.VoucherID]
+	if !ok {
+		found, err := s.voucherRepo.FindByID(ctx, delivery.VoucherID)
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			logger.FromContext(ctx).Warn("failed to fetch voucher for a dead letter preview", "voucher_id", delivery.VoucherID, "error", err)
+		}
+		voucher = found
+		vouchers[delivery.VoucherID] = voucher
+	}
+	if voucher == nil || voucher.VoucherCode == "" {
+		// Without the code it cannot be picked out, so none of the message is shown
+		return "[REDACTED]"
+	}
+	return strings.ReplaceAll(body, voucher.VoucherCode, utils.MaskCode(voucher.VoucherCode))
+}
+
+// parseDeadLetterID returns the job ID of a dead letter ID such as "sms-42"
+func parseDeadLetterID(id string) (uint, error) {
+	kind, rawID, ok := strings.Cut(id, "-")
+	if !ok || kind != domainService.DeadLetterKindSMS {
+		return 0, domainService.ErrDeadLetterNotFound
+	}
+	jobID, err := strconv.ParseUint(rawID, 10, 32)
+	if err != nil || jobID == 0 {
+		return 0, domainService.ErrDeadLetterNotFound
+	}
+	return uint(jobID), nil
+}
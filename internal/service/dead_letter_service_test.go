@@ -0,0 +1,118 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/shoelfikar/voucher-management-system/internal/domain/entity"
+	"github.com/shoelfikar/voucher-management-system/internal/domain/repository"
+	domainService "github.com/shoelfikar/voucher-management-system/internal/domain/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestDeadLetterService_GetAll_RedactsPayload(t *testing.T) {
+	// Arrange
+	mockDeliveryRepo := new(MockSMSDeliveryRepository)
+	mockVoucherRepo := new(MockVoucherRepository)
+	deadLetterService := NewDeadLetterService(mockDeliveryRepo, mockVoucherRepo)
+
+	failedAt := time.Now()
+	deliveries := []*entity.SMSDelivery{
+		{ID: 7, VoucherID: 1, PhoneNumber: "+15550000001", Content: entity.SMSContentCode, Body: "Your voucher code SUMMER2024 gives you 10% off.", Status: entity.SMSDeliveryStatusFailed, Attempts: 5, LastError: "carrier rejected", UpdatedAt: failedAt},
+		{ID: 8, VoucherID: 1, PhoneNumber: "+15550000002", Content: entity.SMSContentCode, Body: "Your voucher code SUMMER2024 gives you 10% off.", Status: entity.SMSDeliveryStatusFailed, Attempts: 5},
+		{ID: 9, VoucherID: 1, PhoneNumber: "+15550000003", Content: entity.SMSContentClaimLink, Body: "Claim it here: https://example.com/claim?token=secret.jwt.value", Status: entity.SMSDeliveryStatusFailed, Attempts: 5},
+	}
+	mockDeliveryRepo.On("FindAll", repository.SMSDeliveryFilter{Status: entity.SMSDeliveryStatusFailed}, 1, 10).Return(deliveries, int64(3), nil)
+	mockVoucherRepo.On("FindByID", uint(1)).Return(&entity.Voucher{ID: 1, VoucherCode: "SUMMER2024"}, nil).Once()
+
+	// Act
+	letters, total, err := deadLetterService.GetAll(context.Background(), "", 1, 10)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), total)
+	assert.Equal(t, "sms-7", letters[0].ID)
+	assert.Equal(t, domainService.DeadLetterKindSMS, letters[0].Kind)
+	assert.Equal(t, "carrier rejected", letters[0].LastError)
+	assert.Equal(t, failedAt, letters[0].FailedAt)
+	assert.Equal(t, "Your voucher code SUMM****24 gives you 10% off.", letters[0].Preview["message"])
+	assert.Equal(t, "Claim it here: https://example.com/claim?token=[REDACTED]", letters[2].Preview["message"])
+	// The voucher is looked up once for the whole page
+	mockVoucherRepo.AssertNumberOfCalls(t, "FindByID", 1)
+}
+
+func TestDeadLetterService_GetAll_UnknownKind(t *testing.T) {
+	// Arrange
+	deadLetterService := NewDeadLetterService(new(MockSMSDeliveryRepository), new(MockVoucherRepository))
+
+	// Act
+	letters, _, err := deadLetterService.GetAll(context.Background(), "webhook", 1, 10)
+
+	// Assert
+	assert.Error(t, err)
+	assert.Nil(t, letters)
+}
+
+func TestDeadLetterService_Retry(t *testing.T) {
+	// Arrange
+	mockDeliveryRepo := new(MockSMSDeliveryRepository)
+	deadLetterService := NewDeadLetterService(mockDeliveryRepo, new(MockVoucherRepository))
+
+	mockDeliveryRepo.On("Requeue", uint(7), mock.AnythingOfType("time.Time")).Return(int64(1), nil)
+	mockDeliveryRepo.On("Requeue", uint(8), mock.AnythingOfType("time.Time")).Return(int64(0), nil)
+
+	// Act
+	err := deadLetterService.Retry(context.Background(), "sms-7", "admin@example.com")
+	alreadyRetriedErr := deadLetterService.Retry(context.Background(), "sms-8", "admin@example.com")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.ErrorIs(t, alreadyRetriedErr, domainService.ErrDeadLetterNotFound)
+}
+
+func TestDeadLetterService_Discard(t *testing.T) {
+	// Arrange
+	mockDeliveryRepo := new(MockSMSDeliveryRepository)
+	deadLetterService := NewDeadLetterService(mockDeliveryRepo, new(MockVoucherRepository))
+
+	mockDeliveryRepo.On("Discard", uint(7)).Return(int64(1), nil)
+	mockDeliveryRepo.On("Discard", uint(9)).Return(int64(0), errors.New("connection refused"))
+
+	// Act
+	err := deadLetterService.Discard(context.Background(), "sms-7", "admin@example.com")
+	repoErr := deadLetterService.Discard(context.Background(), "sms-9", "admin@example.com")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Error(t, repoErr)
+	assert.NotErrorIs(t, repoErr, domainService.ErrDeadLetterNotFound)
+}
+
+func TestParseDeadLetterID(t *testing.T) {
+	testCases := []struct {
+		id       string
+		expected uint
+		valid    bool
+	}{
+		{"sms-42", 42, true},
+		{"sms-0", 0, false},
+		{"sms-abc", 0, false},
+		{"webhook-42", 0, false},
+		{"42", 0, false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.id, func(t *testing.T) {
+			id, err := parseDeadLetterID(tc.id)
+			if tc.valid {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.expected, id)
+			} else {
+				assert.ErrorIs(t, err, domainService.ErrDeadLetterNotFound)
+			}
+		})
+	}
+}
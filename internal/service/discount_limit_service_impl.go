@@ -0,0 +1,99 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/shoelfikar/voucher-management-system/internal/domain/entity"
+	"github.com/shoelfikar/voucher-management-system/internal/domain/repository"
+	domainService "github.com/shoelfikar/voucher-management-system/internal/domain/service"
+	"github.com/shoelfikar/voucher-management-system/pkg/logger"
+)
+
+// discountLimitServiceImpl implements domain service.DiscountLimitService
+type discountLimitServiceImpl struct {
+	limitRepo repository.DiscountLimitRepository
+}
+
+// NewDiscountLimitService creates a new discount limit service instance
+func NewDiscountLimitService(limitRepo repository.DiscountLimitRepository) domainService.DiscountLimitService {
+	return &discountLimitServiceImpl{limitRepo: limitRepo}
+}
+
+// GetAll returns every configured limit
+func (s *discountLimitServiceImpl) GetAll(ctx context.Context) ([]*entity.DiscountLimit, error) {
+	return s.limitRepo.FindAll(ctx)
+}
+
+// Set validates and creates or replaces a role's limit
+func (s *discountLimitServiceImpl) Set(ctx context.Context, cmd *domainService.SetDiscountLimitCommand) (*entity.DiscountLimit, error) {
+	if err := validateLimitedRole(cmd.Role); err != nil {
+		return nil, err
+	}
+	if err := validateDiscountPercent(cmd.MaxDiscountPercent); err != nil {
+		return nil, err
+	}
+	maxAmounts, err := validateMaxDiscountAmounts(cmd.MaxDiscountAmounts)
+	if err != nil {
+		return nil, err
+	}
+
+	limit := &entity.DiscountLimit{
+		Role:               cmd.Role,
+		MaxDiscountPercent: cmd.MaxDiscountPercent,
+		MaxDiscountAmounts: maxAmounts,
+		UpdatedBy:          cmd.UpdatedBy,
+	}
+	if err := s.limitRepo.Save(ctx, limit); err != nil {
+		return nil, fmt.Errorf("failed to save discount limit: %w", err)
+	}
+
+	logger.FromContext(ctx).Info("discount limit set", "role", limit.Role, "max_discount_percent", limit.MaxDiscountPercent, "max_discount_amounts", limit.MaxDiscountAmounts, "updated_by", limit.UpdatedBy)
+	return limit, nil
+}
+
+// Delete lifts a role's limit
+func (s *discountLimitServiceImpl) Delete(ctx context.Context, role string) error {
+	rowsAffected, err := s.limitRepo.Delete(ctx, role)
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return domainService.ErrDiscountLimitNotFound
+	}
+	return nil
+}
+
+// validateLimitedRole checks a discount limit targets a role other than admin
+func validateLimitedRole(role string) error {
+	if role == entity.UserRoleAdmin {
+		return fmt.Errorf("%s users cannot be limited", entity.UserRoleAdmin)
+	}
+	if !slices.Contains(entity.UserRoles, role) {
+		return fmt.Errorf("invalid role '%s'", role)
+	}
+	return nil
+}
+
+// validateMaxDiscountAmounts checks the per-currency caps on fixed amounts
+// and returns them keyed by upper-case currency code, in minor units
+func validateMaxDiscountAmounts(amounts map[string]float64) (map[string]int64, error) {
+	maxAmounts := make(map[string]int64, len(amounts))
+	for currency, amount := range amounts {
+		currency = strings.ToUpper(strings.TrimSpace(currency))
+		if !isCurrencyCode(currency) {
+			return nil, fmt.Errorf("invalid currency '%s': must be a 3-letter ISO 4217 code such as IDR", currency)
+		}
+		if _, ok := maxAmounts[currency]; ok {
+			return nil, fmt.Errorf("currency %s is repeated", currency)
+		}
+		minor, err := validateDiscountAmount(amount, currency)
+		if err != nil {
+			return nil, err
+		}
+		maxAmounts[currency] = minor
+	}
+	return maxAmounts, nil
+}
@@ -0,0 +1,95 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/shoelfikar/voucher-management-system/internal/domain/entity"
+	domainService "github.com/shoelfikar/voucher-management-system/internal/domain/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockDiscountLimitRepository is a mock implementation of DiscountLimitRepository
+type MockDiscountLimitRepository struct {
+	mock.Mock
+}
+
+func (m *MockDiscountLimitRepository) FindAll(ctx context.Context) ([]*entity.DiscountLimit, error) {
+	args := m.Called()
+	return args.Get(0).([]*entity.DiscountLimit), args.Error(1)
+}
+
+func (m *MockDiscountLimitRepository) FindByRole(ctx context.Context, role string) (*entity.DiscountLimit, error) {
+	args := m.Called(role)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.DiscountLimit), args.Error(1)
+}
+
+func (m *MockDiscountLimitRepository) Save(ctx context.Context, limit *entity.DiscountLimit) error {
+	args := m.Called(limit)
+	return args.Error(0)
+}
+
+func (m *MockDiscountLimitRepository) Delete(ctx context.Context, role string) (int64, error) {
+	args := m.Called(role)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func TestDiscountLimitService_Set(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockDiscountLimitRepository)
+	limitService := NewDiscountLimitService(mockRepo)
+	mockRepo.On("Save", mock.AnythingOfType("*entity.DiscountLimit")).Return(nil)
+
+	// Act
+	limit, err := limitService.Set(context.Background(), &domainService.SetDiscountLimitCommand{
+		Role:               entity.UserRoleMarketing,
+		MaxDiscountPercent: 30,
+		MaxDiscountAmounts: map[string]float64{" idr ": 50000, "KWD": 1.125},
+		UpdatedBy:          "owner@example.com",
+	})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, entity.UserRoleMarketing, limit.Role)
+	assert.Equal(t, 30.0, limit.MaxDiscountPercent)
+	assert.Equal(t, map[string]int64{"IDR": 5000000, "KWD": 1125}, limit.MaxDiscountAmounts)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestDiscountLimitService_Set_Invalid(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockDiscountLimitRepository)
+	limitService := NewDiscountLimitService(mockRepo)
+
+	// Act
+	_, adminErr := limitService.Set(context.Background(), &domainService.SetDiscountLimitCommand{Role: entity.UserRoleAdmin, MaxDiscountPercent: 30})
+	_, roleErr := limitService.Set(context.Background(), &domainService.SetDiscountLimitCommand{Role: "intern", MaxDiscountPercent: 30})
+	_, percentErr := limitService.Set(context.Background(), &domainService.SetDiscountLimitCommand{Role: entity.UserRoleMarketing, MaxDiscountPercent: 120})
+	_, currencyErr := limitService.Set(context.Background(), &domainService.SetDiscountLimitCommand{Role: entity.UserRoleMarketing, MaxDiscountPercent: 30, MaxDiscountAmounts: map[string]float64{"RUPIAH": 50000}})
+	_, amountErr := limitService.Set(context.Background(), &domainService.SetDiscountLimitCommand{Role: entity.UserRoleMarketing, MaxDiscountPercent: 30, MaxDiscountAmounts: map[string]float64{"JPY": 0.5}})
+
+	// Assert
+	assert.Error(t, adminErr)
+	assert.Error(t, roleErr)
+	assert.Error(t, percentErr)
+	assert.Error(t, currencyErr)
+	assert.Error(t, amountErr)
+	mockRepo.AssertNotCalled(t, "Save", mock.Anything)
+}
+
+func TestDiscountLimitService_Delete_NotFound(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockDiscountLimitRepository)
+	limitService := NewDiscountLimitService(mockRepo)
+	mockRepo.On("Delete", entity.UserRoleViewer).Return(int64(0), nil)
+
+	// Act
+	err := limitService.Delete(context.Background(), entity.UserRoleViewer)
+
+	// Assert
+	assert.ErrorIs(t, err, domainService.ErrDiscountLimitNotFound)
+}
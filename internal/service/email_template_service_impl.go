@@ -0,0 +1,269 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"maps"
+	"slices"
+	"strings"
+	"text/template"
+
+	"github.com/shoelfikar/voucher-management-system/internal/domain/entity"
+	"github.com/shoelfikar/voucher-management-system/internal/domain/repository"
+	domainService "github.com/shoelfikar/voucher-management-system/internal/domain/service"
+	"github.com/shoelfikar/voucher-management-system/pkg/logger"
+	"github.com/shoelfikar/voucher-management-system/pkg/mailer"
+)
+
+// emailDefault is the wording an email is sent with until an admin saves one,
+// with the sample values previews fill its variables with
+type emailDefault struct {
+	subject string
+	body    string
+	sample  map[string]string
+}
+
+// emailDefaults holds the built-in wording of every editable email. The
+// variables an email may use are the keys of its sample.
+var emailDefaults = map[string]emailDefault{
+	entity.EmailTemplateInvite: {
+		subject: "You have been invited to the Voucher Management System",
+		body: "{{.invited_by}} has invited you to the Voucher Management System as {{.role}}.\n\n" +
+			"Set your password to activate the account:\n{{.link}}\n\nThis link expires in {{.expires_in}}.",
+		sample: map[string]string{
+			"invited_by": "admin@example.com",
+			"role":       entity.UserRoleMarketing,
+			"link":       "https://vouchers.example.com/accept-invite?token=sample",
+			"expires_in": "72h0m0s",
+		},
+	},
+	entity.EmailTemplateVerifyEmail: {
+		subject: "Verify your email address",
+		body:    "Confirm your email address for the Voucher Management System:\n{{.link}}\n\nThis link expires in {{.expires_in}}.",
+		sample: map[string]string{
+			"link":       "https://vouchers.example.com/api/v1/auth/verify-email?token=sample",
+			"expires_in": "24h0m0s",
+		},
+	},
+	entity.EmailTemplatePasswordReset: {
+		subject: "Reset your Voucher Management System password",
+		body: "A password reset was requested for {{.email}}.\n\n" +
+			"Choose a new password:\n{{.link}}\n\nThis link expires in {{.expires_in}}. " +
+			"If you did not ask for a reset, ignore this email and your password stays the same.",
+		sample: map[string]string{
+			"email":      "user@example.com",
+			"link":       "https://vouchers.example.com/reset-password?token=sample",
+			"expires_in": "1h0m0s",
+		},
+	},
+	entity.EmailTemplateImportReport: {
+		subject: "Voucher import from {{.source}}: {{.success}} of {{.total_rows}} rows imported",
+		body: "The voucher import from {{.source}} has finished.\n\n" +
+			"Rows: {{.total_rows}}\nImported: {{.success}}\nFailed: {{.failed}}",
+		sample: map[string]string{
+			"source":     "vouchers.csv",
+			"total_rows": "120",
+			"success":    "118",
+			"failed":     "2",
+		},
+	},
+	entity.EmailTemplateExpiryDigest: {
+		subject: "{{.count}} vouchers expire in the next {{.days}} days",
+		body: "These vouchers expire in the next {{.days}} days:\n\n{{.vouchers}}\n\n" +
+			"Extend or replace them before then if they are still in use.",
+		sample: map[string]string{
+			"count":    "2",
+			"days":     "7",
+			"vouchers": "SUMMER25 (expires 2026-07-01)\nWELCOME10 (expires 2026-07-03)",
+		},
+	},
+}
+
+// emailTemplateServiceImpl implements domain service.EmailTemplateService
+type emailTemplateServiceImpl struct {
+	templateRepo repository.EmailTemplateRepository
+	mailer       mailer.Mailer
+}
+
+// NewEmailTemplateService creates a new email template service instance
+func NewEmailTemplateService(templateRepo repository.EmailTemplateRepository, mailer mailer.Mailer) domainService.EmailTemplateService {
+	return &emailTemplateServiceImpl{templateRepo: templateRepo, mailer: mailer}
+}
+
+// GetAll describes every editable email, in the order of entity.EmailTemplateKeys
+func (s *emailTemplateServiceImpl) GetAll(ctx context.Context) ([]*domainService.EmailTemplateSummary, error) {
+	summaries := make([]*domainService.EmailTemplateSummary, 0, len(entity.EmailTemplateKeys))
+	for _, key := range entity.EmailTemplateKeys {
+		def := emailDefaults[key]
+		summary := &domainService.EmailTemplateSummary{
+			Key:       key,
+			Subject:   def.subject,
+			Body:      def.body,
+			Variables: slices.Sorted(maps.Keys(def.sample)),
+		}
+
+		saved, err := s.templateRepo.FindLatest(ctx, key)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch email template: %w", err)
+		}
+		if saved != nil {
+			summary.Version = saved.Version
+			summary.Subject = saved.Subject
+			summary.Body = saved.Body
+			summary.UpdatedBy = saved.CreatedBy
+			summary.UpdatedAt = &saved.CreatedAt
+		}
+		summaries = append(summaries, summary)
+	}
+	return summaries, nil
+}
+
+// GetVersions returns the saved versions of an email, newest first
+func (s *emailTemplateServiceImpl) GetVersions(ctx context.Context, key string) ([]*entity.EmailTemplate, error) {
+	if _, ok := emailDefaults[key]; !ok {
+		return nil, domainService.ErrEmailTemplateNotFound
+	}
+	return s.templateRepo.FindVersions(ctx, key)
+}
+
+// Save checks that the subject and body render with the email's variables
+// before storing them, so a typo cannot break the email once it is sent
+func (s *emailTemplateServiceImpl) Save(ctx context.Context, cmd *domainService.SaveEmailTemplateCommand) (*entity.EmailTemplate, error) {
+	def, ok := emailDefaults[cmd.Key]
+	if !ok {
+		return nil, domainService.ErrEmailTemplateNotFound
+	}
+	if _, err := renderEmail(cmd.Key, cmd.Subject, cmd.Body, def.sample); err != nil {
+		return nil, err
+	}
+
+	emailTemplate := &entity.EmailTemplate{
+		Key:       cmd.Key,
+		Subject:   cmd.Subject,
+		Body:      cmd.Body,
+		CreatedBy: cmd.CreatedBy,
+	}
+	if err := s.templateRepo.Create(ctx, emailTemplate); err != nil {
+		return nil, fmt.Errorf("failed to save email template: %w", err)
+	}
+
+	logger.FromContext(ctx).Info("email template saved", "key", emailTemplate.Key, "version", emailTemplate.Version, "created_by", emailTemplate.CreatedBy)
+	return emailTemplate, nil
+}
+
+// Preview renders a draft, or the current wording, with sample values
+func (s *emailTemplateServiceImpl) Preview(ctx context.Context, cmd *domainService.PreviewEmailTemplateCommand) (*domainService.RenderedEmail, error) {
+	def, ok := emailDefaults[cmd.Key]
+	if !ok {
+		return nil, domainService.ErrEmailTemplateNotFound
+	}
+
+	subject, body := cmd.Subject, cmd.Body
+	if subject == "" || body == "" {
+		current, err := s.current(ctx, cmd.Key)
+		if err != nil {
+			return nil, err
+		}
+		if subject == "" {
+			subject = current.Subject
+		}
+		if body == "" {
+			body = current.Body
+		}
+	}
+
+	vars := maps.Clone(def.sample)
+	maps.Copy(vars, cmd.Variables)
+	return renderEmail(cmd.Key, subject, body, vars)
+}
+
+// SendTest renders an email as Preview does and sends it, marked as a test
+func (s *emailTemplateServiceImpl) SendTest(ctx context.Context, cmd *domainService.PreviewEmailTemplateCommand, to string) (*domainService.RenderedEmail, error) {
+	email, err := s.Preview(ctx, cmd)
+	if err != nil {
+		return nil, err
+	}
+
+	email.Subject = "[Test] " + email.Subject
+	if err := s.mailer.Send(to, email.Subject, email.Body); err != nil {
+		return nil, fmt.Errorf("failed to send test email: %w", err)
+	}
+
+	logger.FromContext(ctx).Info("test email sent", "key", cmd.Key, "to", to)
+	return email, nil
+}
+
+// Render fills in an email's current wording, falling back to the built-in
+// default when the saved version cannot be loaded or rendered
+func (s *emailTemplateServiceImpl) Render(ctx context.Context, key string, vars map[string]string) (*domainService.RenderedEmail, error) {
+	if _, ok := emailDefaults[key]; !ok {
+		return nil, domainService.ErrEmailTemplateNotFound
+	}
+
+	saved, err := s.templateRepo.FindLatest(ctx, key)
+	if err != nil {
+		slog.Warn("failed to fetch email template, sending the default", "key", key, "error", err)
+	} else if saved != nil {
+		email, err := renderEmail(key, saved.Subject, saved.Body, vars)
+		if err == nil {
+			return email, nil
+		}
+		slog.Warn("failed to render email template, sending the default", "key", key, "version", saved.Version, "error", err)
+	}
+
+	return renderDefaultEmail(key, vars)
+}
+
+// current returns the saved wording of an email, or its default when none is saved
+func (s *emailTemplateServiceImpl) current(ctx context.Context, key string) (*domainService.RenderedEmail, error) {
+	saved, err := s.templateRepo.FindLatest(ctx, key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch email template: %w", err)
+	}
+	if saved != nil {
+		return &domainService.RenderedEmail{Subject: saved.Subject, Body: saved.Body}, nil
+	}
+	def := emailDefaults[key]
+	return &domainService.RenderedEmail{Subject: def.subject, Body: def.body}, nil
+}
+
+// renderDefaultEmail fills in the built-in wording of an email
+func renderDefaultEmail(key string, vars map[string]string) (*domainService.RenderedEmail, error) {
+	def, ok := emailDefaults[key]
+	if !ok {
+		return nil, domainService.ErrEmailTemplateNotFound
+	}
+	return renderEmail(key, def.subject, def.body, vars)
+}
+
+// renderEmail executes a subject and body as text templates over vars. A
+// variable missing from vars is an error rather than "<no value>" in the email.
+func renderEmail(key, subject, body string, vars map[string]string) (*domainService.RenderedEmail, error) {
+	renderedSubject, err := executeEmailTemplate(key+" subject", subject, vars)
+	if err != nil {
+		return nil, err
+	}
+	// A line break in the subject would end the header and start another
+	if strings.ContainsAny(renderedSubject, "\r\n") {
+		return nil, fmt.Errorf("%w: subject must be a single line", domainService.ErrInvalidEmailTemplate)
+	}
+	renderedBody, err := executeEmailTemplate(key+" body", body, vars)
+	if err != nil {
+		return nil, err
+	}
+	return &domainService.RenderedEmail{Subject: renderedSubject, Body: renderedBody}, nil
+}
+
+// executeEmailTemplate parses and executes one text template
+func executeEmailTemplate(name, source string, vars map[string]string) (string, error) {
+	tmpl, err := template.New(name).Option("missingkey=error").Parse(source)
+	if err != nil {
+		return "", fmt.Errorf("%w: %v", domainService.ErrInvalidEmailTemplate, err)
+	}
+	var out strings.Builder
+	if err := tmpl.Execute(&out, vars); err != nil {
+		return "", fmt.Errorf("%w: %v", domainService.ErrInvalidEmailTemplate, err)
+	}
+	return out.String(), nil
+}
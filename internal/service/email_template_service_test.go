@@ -0,0 +1,159 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/shoelfikar/voucher-management-system/internal/domain/entity"
+	domainService "github.com/shoelfikar/voucher-management-system/internal/domain/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockEmailTemplateRepository is a mock implementation of EmailTemplateRepository
+type MockEmailTemplateRepository struct {
+	mock.Mock
+}
+
+func (m *MockEmailTemplateRepository) FindLatest(ctx context.Context, key string) (*entity.EmailTemplate, error) {
+	args := m.Called(key)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.EmailTemplate), args.Error(1)
+}
+
+func (m *MockEmailTemplateRepository) FindVersions(ctx context.Context, key string) ([]*entity.EmailTemplate, error) {
+	args := m.Called(key)
+	return args.Get(0).([]*entity.EmailTemplate), args.Error(1)
+}
+
+func (m *MockEmailTemplateRepository) Create(ctx context.Context, template *entity.EmailTemplate) error {
+	args := m.Called(template)
+	return args.Error(0)
+}
+
+func TestEmailDefaults_RenderWithSamples(t *testing.T) {
+	for _, key := range entity.EmailTemplateKeys {
+		def, ok := emailDefaults[key]
+		assert.True(t, ok, key)
+
+		_, err := renderEmail(key, def.subject, def.body, def.sample)
+		assert.NoError(t, err, key)
+	}
+}
+
+func TestEmailTemplateService_Save(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockEmailTemplateRepository)
+	templateService := NewEmailTemplateService(mockRepo, new(MockMailer))
+	mockRepo.On("Create", mock.AnythingOfType("*entity.EmailTemplate")).Return(nil)
+
+	// Act
+	saved, err := templateService.Save(context.Background(), &domainService.SaveEmailTemplateCommand{
+		Key:       entity.EmailTemplateVerifyEmail,
+		Subject:   "Please confirm your address",
+		Body:      "Click {{.link}} within {{.expires_in}}.",
+		CreatedBy: "admin@example.com",
+	})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, entity.EmailTemplateVerifyEmail, saved.Key)
+	assert.Equal(t, "admin@example.com", saved.CreatedBy)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestEmailTemplateService_Save_Invalid(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockEmailTemplateRepository)
+	templateService := NewEmailTemplateService(mockRepo, new(MockMailer))
+
+	// Act
+	_, keyErr := templateService.Save(context.Background(), &domainService.SaveEmailTemplateCommand{Key: "newsletter", Subject: "Hi", Body: "Hi"})
+	_, syntaxErr := templateService.Save(context.Background(), &domainService.SaveEmailTemplateCommand{Key: entity.EmailTemplateInvite, Subject: "Hi", Body: "{{.link"})
+	_, variableErr := templateService.Save(context.Background(), &domainService.SaveEmailTemplateCommand{Key: entity.EmailTemplateInvite, Subject: "Hi", Body: "{{.voucher_code}}"})
+	_, subjectErr := templateService.Save(context.Background(), &domainService.SaveEmailTemplateCommand{Key: entity.EmailTemplateInvite, Subject: "Hi\nBcc: x@example.com", Body: "{{.link}}"})
+
+	// Assert
+	assert.ErrorIs(t, keyErr, domainService.ErrEmailTemplateNotFound)
+	assert.ErrorIs(t, syntaxErr, domainService.ErrInvalidEmailTemplate)
+	assert.ErrorIs(t, variableErr, domainService.ErrInvalidEmailTemplate)
+	assert.ErrorIs(t, subjectErr, domainService.ErrInvalidEmailTemplate)
+	mockRepo.AssertNotCalled(t, "Create", mock.Anything)
+}
+
+func TestEmailTemplateService_Preview(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockEmailTemplateRepository)
+	templateService := NewEmailTemplateService(mockRepo, new(MockMailer))
+	mockRepo.On("FindLatest", entity.EmailTemplateImportReport).
+		Return(&entity.EmailTemplate{Key: entity.EmailTemplateImportReport, Version: 3, Subject: "Import done", Body: "Saved {{.success}}"}, nil)
+
+	// Act
+	current, currentErr := templateService.Preview(context.Background(), &domainService.PreviewEmailTemplateCommand{Key: entity.EmailTemplateImportReport})
+	draft, draftErr := templateService.Preview(context.Background(), &domainService.PreviewEmailTemplateCommand{
+		Key:       entity.EmailTemplateImportReport,
+		Body:      "{{.failed}} of {{.total_rows}} failed",
+		Variables: map[string]string{"failed": "5"},
+	})
+
+	// Assert
+	assert.NoError(t, currentErr)
+	assert.Equal(t, &domainService.RenderedEmail{Subject: "Import done", Body: "Saved 118"}, current)
+	assert.NoError(t, draftErr)
+	assert.Equal(t, &domainService.RenderedEmail{Subject: "Import done", Body: "5 of 120 failed"}, draft)
+}
+
+func TestEmailTemplateService_SendTest(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockEmailTemplateRepository)
+	mockMailer := new(MockMailer)
+	templateService := NewEmailTemplateService(mockRepo, mockMailer)
+	mockRepo.On("FindLatest", entity.EmailTemplateExpiryDigest).Return(nil, nil)
+	mockMailer.On("Send", "admin@example.com", "[Test] 2 vouchers expire in the next 7 days", mock.Anything).Return(nil)
+
+	// Act
+	email, err := templateService.SendTest(context.Background(), &domainService.PreviewEmailTemplateCommand{Key: entity.EmailTemplateExpiryDigest}, "admin@example.com")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Contains(t, email.Body, "SUMMER25")
+	mockMailer.AssertExpectations(t)
+}
+
+func TestEmailTemplateService_Render_FallsBackToDefault(t *testing.T) {
+	vars := map[string]string{"link": "https://example.com/verify?token=t", "expires_in": "1h0m0s"}
+	tests := []struct {
+		name  string
+		saved *entity.EmailTemplate
+		err   error
+		want  string
+	}{
+		{name: "saved version", saved: &entity.EmailTemplate{Version: 2, Subject: "Verify", Body: "Open {{.link}}"}, want: "Open https://example.com/verify?token=t"},
+		{name: "nothing saved", want: "Confirm your email address for the Voucher Management System:\nhttps://example.com/verify?token=t\n\nThis link expires in 1h0m0s."},
+		{name: "broken saved version", saved: &entity.EmailTemplate{Version: 2, Subject: "Verify", Body: "Open {{.reset_link}}"}, want: "Confirm your email address for the Voucher Management System:\nhttps://example.com/verify?token=t\n\nThis link expires in 1h0m0s."},
+		{name: "database down", err: errors.New("connection refused"), want: "Confirm your email address for the Voucher Management System:\nhttps://example.com/verify?token=t\n\nThis link expires in 1h0m0s."},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			mockRepo := new(MockEmailTemplateRepository)
+			templateService := NewEmailTemplateService(mockRepo, new(MockMailer))
+			if tt.saved != nil {
+				mockRepo.On("FindLatest", entity.EmailTemplateVerifyEmail).Return(tt.saved, nil)
+			} else {
+				mockRepo.On("FindLatest", entity.EmailTemplateVerifyEmail).Return(nil, tt.err)
+			}
+
+			// Act
+			email, err := templateService.Render(context.Background(), entity.EmailTemplateVerifyEmail, vars)
+
+			// Assert
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, email.Body)
+		})
+	}
+}
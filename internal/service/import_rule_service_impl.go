@@ -0,0 +1,180 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/shoelfikar/voucher-management-system/internal/domain/entity"
+	"github.com/shoelfikar/voucher-management-system/internal/domain/repository"
+	domainService "github.com/shoelfikar/voucher-management-system/internal/domain/service"
+	"github.com/shoelfikar/voucher-management-system/pkg/utils"
+	"gorm.io/gorm"
+)
+
+// importRuleServiceImpl implements domain service.ImportRuleService
+type importRuleServiceImpl struct {
+	ruleRepo repository.ImportRuleRepository
+}
+
+// NewImportRuleService creates a new import rule service instance
+func NewImportRuleService(ruleRepo repository.ImportRuleRepository) domainService.ImportRuleService {
+	return &importRuleServiceImpl{ruleRepo: ruleRepo}
+}
+
+// GetAll retrieves import rules with pagination, search, and sorting
+func (s *importRuleServiceImpl) GetAll(ctx context.Context, page, limit int, search string, sort []utils.SortField) ([]*entity.ImportRule, int64, error) {
+	return s.ruleRepo.FindAll(ctx, page, limit, search, sort)
+}
+
+// GetByID retrieves an import rule by ID
+func (s *importRuleServiceImpl) GetByID(ctx context.Context, id uint) (*entity.ImportRule, error) {
+	rule, err := s.ruleRepo.FindByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domainService.ErrImportRuleNotFound
+		}
+		return nil, err
+	}
+	return rule, nil
+}
+
+// Create validates and stores a new import rule
+func (s *importRuleServiceImpl) Create(ctx context.Context, cmd *domainService.ImportRuleCommand) (*entity.ImportRule, error) {
+	rule := &entity.ImportRule{
+		Name:      strings.TrimSpace(cmd.Name),
+		Type:      cmd.Type,
+		Value:     strings.TrimSpace(cmd.Value),
+		Message:   strings.TrimSpace(cmd.Message),
+		Enabled:   cmd.Enabled,
+		CreatedBy: cmd.CreatedBy,
+	}
+	if err := validateImportRule(rule); err != nil {
+		return nil, err
+	}
+
+	if err := s.ruleRepo.Create(ctx, rule); err != nil {
+		return nil, fmt.Errorf("failed to create import rule: %w", err)
+	}
+	return rule, nil
+}
+
+// Update validates and replaces an existing import rule
+func (s *importRuleServiceImpl) Update(ctx context.Context, id uint, cmd *domainService.ImportRuleCommand) (*entity.ImportRule, error) {
+	rule := &entity.ImportRule{
+		ID:      id,
+		Name:    strings.TrimSpace(cmd.Name),
+		Type:    cmd.Type,
+		Value:   strings.TrimSpace(cmd.Value),
+		Message: strings.TrimSpace(cmd.Message),
+		Enabled: cmd.Enabled,
+	}
+	if err := validateImportRule(rule); err != nil {
+		return nil, err
+	}
+
+	rowsAffected, err := s.ruleRepo.Update(ctx, rule)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update import rule: %w", err)
+	}
+	if rowsAffected == 0 {
+		return nil, domainService.ErrImportRuleNotFound
+	}
+
+	return s.GetByID(ctx, id)
+}
+
+// Delete removes an import rule
+func (s *importRuleServiceImpl) Delete(ctx context.Context, id uint) error {
+	rowsAffected, err := s.ruleRepo.Delete(ctx, id)
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return domainService.ErrImportRuleNotFound
+	}
+	return nil
+}
+
+// validateImportRule checks that a rule is complete and its value fits its type
+func validateImportRule(rule *entity.ImportRule) error {
+	if rule.Name == "" {
+		return errors.New("rule name is required")
+	}
+	if rule.Value == "" {
+		return errors.New("rule value is required")
+	}
+
+	switch rule.Type {
+	case entity.ImportRuleCodePrefix:
+		return nil
+	case entity.ImportRuleCodePattern:
+		if _, err := regexp.Compile(rule.Value); err != nil {
+			return fmt.Errorf("invalid code pattern: %w", err)
+		}
+	case entity.ImportRuleMinDiscount, entity.ImportRuleMaxDiscount:
+		percent, err := strconv.ParseFloat(rule.Value, 64)
+		if err != nil || percent < entity.MinDiscountPercent || percent > entity.MaxDiscountPercent {
+			return fmt.Errorf("rule value '%s' must be a discount percent between 1 and 100", rule.Value)
+		}
+		if !entity.HasDiscountPercentScale(percent) {
+			return fmt.Errorf("rule value '%s' has more than %d decimal places", rule.Value, entity.DiscountPercentScale)
+		}
+	case entity.ImportRuleMaxValidityDays:
+		days, err := strconv.Atoi(rule.Value)
+		if err != nil || days < 1 {
+			return fmt.Errorf("rule value '%s' must be a positive number of days", rule.Value)
+		}
+	default:
+		return fmt.Errorf("unknown rule type '%s'", rule.Type)
+	}
+	return nil
+}
+
+// checkImportRules returns an error naming the first rule the voucher violates.
+// Rules are validated when saved, so values that no longer parse are skipped.
+// Discount rules are percents, so fixed-amount vouchers are not checked against them.
+func checkImportRules(rules []*entity.ImportRule, voucher *entity.Voucher, now time.Time) error {
+	for _, rule := range rules {
+		violation := ""
+
+		switch rule.Type {
+		case entity.ImportRuleCodePrefix:
+			if !strings.HasPrefix(strings.ToUpper(voucher.VoucherCode), strings.ToUpper(rule.Value)) {
+				violation = fmt.Sprintf("voucher code must start with '%s'", rule.Value)
+			}
+		case entity.ImportRuleCodePattern:
+			if pattern, err := regexp.Compile(rule.Value); err == nil && !pattern.MatchString(voucher.VoucherCode) {
+				violation = fmt.Sprintf("voucher code must match '%s'", rule.Value)
+			}
+		case entity.ImportRuleMinDiscount:
+			if percent, err := strconv.ParseFloat(rule.Value, 64); err == nil && !voucher.IsFixedAmount() && entity.DiscountHundredths(voucher.DiscountPercent) < entity.DiscountHundredths(percent) {
+				violation = fmt.Sprintf("discount percent must be at least %s", rule.Value)
+			}
+		case entity.ImportRuleMaxDiscount:
+			if percent, err := strconv.ParseFloat(rule.Value, 64); err == nil && !voucher.IsFixedAmount() && entity.DiscountHundredths(voucher.DiscountPercent) > entity.DiscountHundredths(percent) {
+				violation = fmt.Sprintf("discount percent must be at most %s", rule.Value)
+			}
+		case entity.ImportRuleMaxValidityDays:
+			if days, err := strconv.Atoi(rule.Value); err == nil {
+				latest := now.Truncate(24*time.Hour).AddDate(0, 0, days)
+				if voucher.ExpiryDate.After(latest) {
+					violation = fmt.Sprintf("expiry date must be within %d days", days)
+				}
+			}
+		}
+
+		if violation == "" {
+			continue
+		}
+		if rule.Message != "" {
+			violation = rule.Message
+		}
+		return fmt.Errorf("violates import rule '%s': %s", rule.Name, violation)
+	}
+	return nil
+}
@@ -0,0 +1,225 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/shoelfikar/voucher-management-system/internal/domain/entity"
+	domainService "github.com/shoelfikar/voucher-management-system/internal/domain/service"
+	"github.com/shoelfikar/voucher-management-system/pkg/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+// MockImportRuleRepository is a mock implementation of ImportRuleRepository
+type MockImportRuleRepository struct {
+	mock.Mock
+}
+
+func (m *MockImportRuleRepository) FindAll(ctx context.Context, page, limit int, search string, sort []utils.SortField) ([]*entity.ImportRule, int64, error) {
+	args := m.Called(page, limit, search, sort)
+	return args.Get(0).([]*entity.ImportRule), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockImportRuleRepository) FindEnabled(ctx context.Context) ([]*entity.ImportRule, error) {
+	args := m.Called()
+	return args.Get(0).([]*entity.ImportRule), args.Error(1)
+}
+
+func (m *MockImportRuleRepository) FindByID(ctx context.Context, id uint) (*entity.ImportRule, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.ImportRule), args.Error(1)
+}
+
+func (m *MockImportRuleRepository) Create(ctx context.Context, rule *entity.ImportRule) error {
+	args := m.Called(rule)
+	return args.Error(0)
+}
+
+func (m *MockImportRuleRepository) Update(ctx context.Context, rule *entity.ImportRule) (int64, error) {
+	args := m.Called(rule)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockImportRuleRepository) Delete(ctx context.Context, id uint) (int64, error) {
+	args := m.Called(id)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func TestImportRuleService_Create_Success(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockImportRuleRepository)
+	ruleService := NewImportRuleService(mockRepo)
+
+	mockRepo.On("Create", mock.AnythingOfType("*entity.ImportRule")).Return(nil)
+
+	// Act
+	rule, err := ruleService.Create(context.Background(), &domainService.ImportRuleCommand{
+		Name: "ACME prefix", Type: entity.ImportRuleCodePrefix, Value: "ACME-", Enabled: true,
+	})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "ACME-", rule.Value)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestImportRuleService_Create_InvalidValue(t *testing.T) {
+	testCases := []struct {
+		name string
+		cmd  domainService.ImportRuleCommand
+	}{
+		{"bad pattern", domainService.ImportRuleCommand{Name: "Pattern", Type: entity.ImportRuleCodePattern, Value: "[A-Z"}},
+		{"discount with too many decimals", domainService.ImportRuleCommand{Name: "Floor", Type: entity.ImportRuleMinDiscount, Value: "10.005"}},
+		{"discount out of range", domainService.ImportRuleCommand{Name: "Cap", Type: entity.ImportRuleMaxDiscount, Value: "140"}},
+		{"non-numeric days", domainService.ImportRuleCommand{Name: "Window", Type: entity.ImportRuleMaxValidityDays, Value: "soon"}},
+		{"unknown type", domainService.ImportRuleCommand{Name: "Other", Type: "code_suffix", Value: "-X"}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			mockRepo := new(MockImportRuleRepository)
+			ruleService := NewImportRuleService(mockRepo)
+
+			// Act
+			rule, err := ruleService.Create(context.Background(), &tc.cmd)
+
+			// Assert
+			assert.Error(t, err)
+			assert.Nil(t, rule)
+			mockRepo.AssertNotCalled(t, "Create", mock.Anything)
+		})
+	}
+}
+
+func TestImportRuleService_Update_NotFound(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockImportRuleRepository)
+	ruleService := NewImportRuleService(mockRepo)
+
+	mockRepo.On("Update", mock.AnythingOfType("*entity.ImportRule")).Return(int64(0), nil)
+
+	// Act
+	rule, err := ruleService.Update(context.Background(), 99, &domainService.ImportRuleCommand{
+		Name: "Cap", Type: entity.ImportRuleMaxDiscount, Value: "40",
+	})
+
+	// Assert
+	assert.ErrorIs(t, err, domainService.ErrImportRuleNotFound)
+	assert.Nil(t, rule)
+}
+
+func TestImportRuleService_GetByID_NotFound(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockImportRuleRepository)
+	ruleService := NewImportRuleService(mockRepo)
+
+	mockRepo.On("FindByID", uint(99)).Return(nil, gorm.ErrRecordNotFound)
+
+	// Act
+	rule, err := ruleService.GetByID(context.Background(), 99)
+
+	// Assert
+	assert.ErrorIs(t, err, domainService.ErrImportRuleNotFound)
+	assert.Nil(t, rule)
+}
+
+func TestCheckImportRules(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	rules := []*entity.ImportRule{
+		{Name: "ACME prefix", Type: entity.ImportRuleCodePrefix, Value: "ACME-"},
+		{Name: "Discount cap", Type: entity.ImportRuleMaxDiscount, Value: "40", Message: "partners may not exceed 40% off"},
+		{Name: "Short campaigns", Type: entity.ImportRuleMaxValidityDays, Value: "30"},
+	}
+
+	testCases := []struct {
+		name    string
+		voucher *entity.Voucher
+		wantErr string
+	}{
+		{"passes", &entity.Voucher{VoucherCode: "acme-1", DiscountPercent: 40, ExpiryDate: now.AddDate(0, 0, 10)}, ""},
+		{"wrong prefix", &entity.Voucher{VoucherCode: "OTHER-1", DiscountPercent: 10, ExpiryDate: now}, "violates import rule 'ACME prefix': voucher code must start with 'ACME-'"},
+		{"custom message", &entity.Voucher{VoucherCode: "ACME-2", DiscountPercent: 50, ExpiryDate: now}, "violates import rule 'Discount cap': partners may not exceed 40% off"},
+		{"too long", &entity.Voucher{VoucherCode: "ACME-3", DiscountPercent: 10, ExpiryDate: now.AddDate(0, 0, 60)}, "violates import rule 'Short campaigns': expiry date must be within 30 days"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Act
+			err := checkImportRules(rules, tc.voucher, now)
+
+			// Assert
+			if tc.wantErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestVoucherService_ImportRecords_ReportsRuleViolations(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockVoucherRepository)
+	mockRuleRepo := new(MockImportRuleRepository)
+	voucherService := NewVoucherService(mockRepo, 0, WithImportRules(mockRuleRepo))
+
+	tomorrow := time.Now().Add(24 * time.Hour).Format("2006-01-02")
+	records := [][]string{
+		{"voucher_code", "discount_percent", "expiry_date"},
+		{"ACME-1", "10", tomorrow},
+		{"OTHER-1", "10", tomorrow},
+	}
+
+	mockRuleRepo.On("FindEnabled").Return([]*entity.ImportRule{
+		{Name: "ACME prefix", Type: entity.ImportRuleCodePrefix, Value: "ACME-"},
+	}, nil)
+	mockRepo.On("CheckDuplicateCodes", []string{"ACME-1", "OTHER-1"}).Return([]string{}, nil)
+	mockRepo.On("BulkCreate", mock.MatchedBy(func(vouchers []*entity.Voucher) bool {
+		return len(vouchers) == 1 && vouchers[0].VoucherCode == "ACME-1"
+	})).Return(nil)
+
+	// Act
+	result, err := voucherService.ImportRecords(context.Background(), records, "importer@example.com", entity.UserRoleAdmin)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, 1, result.Success)
+	assert.Equal(t, 1, result.Failed)
+	assert.Equal(t, 3, result.Errors[0].Row)
+	assert.Contains(t, result.Errors[0].Error, "ACME prefix")
+	mockRepo.AssertExpectations(t)
+}
+
+func TestVoucherService_ImportBatch_ReportsRuleViolations(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockVoucherRepository)
+	mockRuleRepo := new(MockImportRuleRepository)
+	voucherService := NewVoucherService(mockRepo, 0, WithImportRules(mockRuleRepo))
+
+	tomorrow := time.Now().Add(24 * time.Hour).Format("2006-01-02")
+	commands := []domainService.CreateVoucherCommand{
+		{VoucherCode: "BATCH1", DiscountPercent: 30, ExpiryDate: tomorrow},
+		{VoucherCode: "BATCH2", DiscountPercent: 60, ExpiryDate: tomorrow},
+	}
+
+	mockRuleRepo.On("FindEnabled").Return([]*entity.ImportRule{
+		{Name: "Discount cap", Type: entity.ImportRuleMaxDiscount, Value: "40"},
+	}, nil)
+	mockRepo.On("CheckDuplicateCodes", []string{"BATCH1", "BATCH2"}).Return([]string{}, nil)
+	mockRepo.On("BulkCreate", mock.AnythingOfType("[]*entity.Voucher")).Return(nil)
+
+	// Act
+	result, err := voucherService.ImportBatch(context.Background(), commands)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, 1, result.Inserted)
+	assert.Equal(t, []string{"Code BATCH2: violates import rule 'Discount cap': discount percent must be at most 40"}, result.Errors)
+}
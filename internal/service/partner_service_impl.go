@@ -0,0 +1,214 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/shoelfikar/voucher-management-system/internal/domain/entity"
+	"github.com/shoelfikar/voucher-management-system/internal/domain/repository"
+	domainService "github.com/shoelfikar/voucher-management-system/internal/domain/service"
+	"github.com/shoelfikar/voucher-management-system/pkg/utils"
+	"gorm.io/gorm"
+)
+
+// Partner API keys are apiKeyPrefix followed by apiKeyBytes random bytes in hex;
+// the first keyPrefixLength characters are kept in clear to tell keys apart
+const (
+	apiKeyPrefix    = "vpk_"
+	apiKeyBytes     = 32
+	keyPrefixLength = 12
+)
+
+// partnerServiceImpl implements domain service.PartnerService
+type partnerServiceImpl struct {
+	partnerRepo    repository.PartnerRepository
+	voucherRepo    repository.VoucherRepository
+	voucherService domainService.VoucherService
+}
+
+// NewPartnerService creates a new partner service instance. Partner vouchers
+// are created through voucherService so they pass the same checks as any other.
+func NewPartnerService(partnerRepo repository.PartnerRepository, voucherRepo repository.VoucherRepository, voucherService domainService.VoucherService) domainService.PartnerService {
+	return &partnerServiceImpl{
+		partnerRepo:    partnerRepo,
+		voucherRepo:    voucherRepo,
+		voucherService: voucherService,
+	}
+}
+
+// GetAll retrieves partners with pagination, search, and sorting
+func (s *partnerServiceImpl) GetAll(ctx context.Context, page, limit int, search string, sort []utils.SortField) ([]*entity.Partner, int64, error) {
+	return s.partnerRepo.FindAll(ctx, page, limit, search, sort)
+}
+
+// GetByID retrieves a partner by ID
+func (s *partnerServiceImpl) GetByID(ctx context.Context, id uint) (*entity.Partner, error) {
+	partner, err := s.partnerRepo.FindByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domainService.ErrPartnerNotFound
+		}
+		return nil, err
+	}
+	return partner, nil
+}
+
+// Create stores a new partner with a freshly generated API key
+func (s *partnerServiceImpl) Create(ctx context.Context, cmd *domainService.PartnerCommand) (*domainService.PartnerCredentials, error) {
+	partner, err := s.validatePartner(ctx, 0, cmd)
+	if err != nil {
+		return nil, err
+	}
+
+	apiKey, err := generateAPIKey()
+	if err != nil {
+		return nil, err
+	}
+	partner.APIKeyHash = hashAPIKey(apiKey)
+	partner.KeyPrefix = apiKey[:keyPrefixLength]
+	partner.CreatedBy = cmd.CreatedBy
+
+	if err := s.partnerRepo.Create(ctx, partner); err != nil {
+		return nil, fmt.Errorf("failed to create partner: %w", err)
+	}
+	return &domainService.PartnerCredentials{Partner: partner, APIKey: apiKey}, nil
+}
+
+// Update changes the name, quota and active flag of a partner. Lowering the
+// quota below the issued count keeps existing vouchers but blocks new ones.
+func (s *partnerServiceImpl) Update(ctx context.Context, id uint, cmd *domainService.PartnerCommand) (*entity.Partner, error) {
+	partner, err := s.validatePartner(ctx, id, cmd)
+	if err != nil {
+		return nil, err
+	}
+	partner.ID = id
+
+	rowsAffected, err := s.partnerRepo.Update(ctx, partner)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update partner: %w", err)
+	}
+	if rowsAffected == 0 {
+		return nil, domainService.ErrPartnerNotFound
+	}
+
+	return s.GetByID(ctx, id)
+}
+
+// RotateKey replaces a partner's API key
+func (s *partnerServiceImpl) RotateKey(ctx context.Context, id uint) (*domainService.PartnerCredentials, error) {
+	apiKey, err := generateAPIKey()
+	if err != nil {
+		return nil, err
+	}
+
+	rowsAffected, err := s.partnerRepo.UpdateAPIKey(ctx, id, hashAPIKey(apiKey), apiKey[:keyPrefixLength])
+	if err != nil {
+		return nil, fmt.Errorf("failed to rotate partner api key: %w", err)
+	}
+	if rowsAffected == 0 {
+		return nil, domainService.ErrPartnerNotFound
+	}
+
+	partner, err := s.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return &domainService.PartnerCredentials{Partner: partner, APIKey: apiKey}, nil
+}
+
+// Authenticate looks a partner up by the hash of its API key
+func (s *partnerServiceImpl) Authenticate(ctx context.Context, apiKey string) (*entity.Partner, error) {
+	if !strings.HasPrefix(apiKey, apiKeyPrefix) {
+		return nil, domainService.ErrInvalidAPIKey
+	}
+
+	partner, err := s.partnerRepo.FindByAPIKeyHash(ctx, hashAPIKey(apiKey))
+	if err != nil {
+		return nil, err
+	}
+	if partner == nil {
+		return nil, domainService.ErrInvalidAPIKey
+	}
+	if !partner.Active {
+		return nil, domainService.ErrPartnerInactive
+	}
+	return partner, nil
+}
+
+// IssueVoucher reserves one voucher of the partner's quota before creating it
+// and gives the reservation back when the voucher is rejected
+func (s *partnerServiceImpl) IssueVoucher(ctx context.Context, partnerID uint, cmd *domainService.CreateVoucherCommand) (*entity.Voucher, error) {
+	reserved, err := s.partnerRepo.ReserveQuota(ctx, partnerID, 1)
+	if err != nil {
+		return nil, err
+	}
+	if !reserved {
+		return nil, domainService.ErrPartnerQuotaExceeded
+	}
+
+	cmd.PartnerID = &partnerID
+	voucher, err := s.voucherService.Create(ctx, cmd)
+	if err != nil {
+		if releaseErr := s.partnerRepo.ReleaseQuota(ctx, partnerID, 1); releaseErr != nil {
+			return nil, fmt.Errorf("%w (releasing quota failed: %v)", err, releaseErr)
+		}
+		return nil, err
+	}
+	return voucher, nil
+}
+
+// GetVouchers retrieves the vouchers the partner issued
+func (s *partnerServiceImpl) GetVouchers(ctx context.Context, partnerID uint, page, limit int, search string, sort []utils.SortField) ([]*entity.Voucher, int64, error) {
+	return s.voucherRepo.FindAllByPartner(ctx, partnerID, page, limit, search, sort)
+}
+
+// validatePartner checks a partner command and returns the partner it describes.
+// id is the partner being updated, or 0.
+func (s *partnerServiceImpl) validatePartner(ctx context.Context, id uint, cmd *domainService.PartnerCommand) (*entity.Partner, error) {
+	partner := &entity.Partner{
+		Name:      strings.TrimSpace(cmd.Name),
+		Quota:     cmd.Quota,
+		RateLimit: cmd.RateLimit,
+		Active:    cmd.Active,
+	}
+	if partner.Name == "" {
+		return nil, errors.New("partner name is required")
+	}
+	if partner.Quota < 0 {
+		return nil, errors.New("partner quota cannot be negative")
+	}
+	if partner.RateLimit < 0 {
+		return nil, errors.New("partner rate limit cannot be negative")
+	}
+
+	existing, err := s.partnerRepo.FindByName(ctx, partner.Name)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil && existing.ID != id {
+		return nil, domainService.ErrDuplicatePartnerName
+	}
+
+	return partner, nil
+}
+
+// generateAPIKey returns a new random partner API key
+func generateAPIKey() (string, error) {
+	buf := make([]byte, apiKeyBytes)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("failed to generate api key: %w", err)
+	}
+	return apiKeyPrefix + hex.EncodeToString(buf), nil
+}
+
+// hashAPIKey returns the hex SHA-256 of an API key. Keys are long and random,
+// so a fast unsalted hash is enough and allows looking them up by hash.
+func hashAPIKey(apiKey string) string {
+	sum := sha256.Sum256([]byte(apiKey))
+	return hex.EncodeToString(sum[:])
+}
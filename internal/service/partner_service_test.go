@@ -0,0 +1,330 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/shoelfikar/voucher-management-system/internal/domain/entity"
+	domainService "github.com/shoelfikar/voucher-management-system/internal/domain/service"
+	"github.com/shoelfikar/voucher-management-system/pkg/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+// MockPartnerRepository is a mock implementation of PartnerRepository
+type MockPartnerRepository struct {
+	mock.Mock
+}
+
+func (m *MockPartnerRepository) FindAll(ctx context.Context, page, limit int, search string, sort []utils.SortField) ([]*entity.Partner, int64, error) {
+	args := m.Called(page, limit, search, sort)
+	return args.Get(0).([]*entity.Partner), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockPartnerRepository) FindByID(ctx context.Context, id uint) (*entity.Partner, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Partner), args.Error(1)
+}
+
+func (m *MockPartnerRepository) FindByName(ctx context.Context, name string) (*entity.Partner, error) {
+	args := m.Called(name)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Partner), args.Error(1)
+}
+
+func (m *MockPartnerRepository) FindByAPIKeyHash(ctx context.Context, hash string) (*entity.Partner, error) {
+	args := m.Called(hash)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Partner), args.Error(1)
+}
+
+func (m *MockPartnerRepository) Create(ctx context.Context, partner *entity.Partner) error {
+	args := m.Called(partner)
+	return args.Error(0)
+}
+
+func (m *MockPartnerRepository) Update(ctx context.Context, partner *entity.Partner) (int64, error) {
+	args := m.Called(partner)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockPartnerRepository) UpdateAPIKey(ctx context.Context, id uint, hash, prefix string) (int64, error) {
+	args := m.Called(id, hash, prefix)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockPartnerRepository) ReserveQuota(ctx context.Context, id uint, n int64) (bool, error) {
+	args := m.Called(id, n)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockPartnerRepository) ReleaseQuota(ctx context.Context, id uint, n int64) error {
+	args := m.Called(id, n)
+	return args.Error(0)
+}
+
+func TestPartnerService_Create_GeneratesHashedKey(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockPartnerRepository)
+	partnerService := NewPartnerService(mockRepo, nil, nil)
+
+	mockRepo.On("FindByName", "Acme").Return(nil, nil)
+	mockRepo.On("Create", mock.AnythingOfType("*entity.Partner")).Return(nil)
+
+	// Act
+	credentials, err := partnerService.Create(context.Background(), &domainService.PartnerCommand{Name: " Acme ", Quota: 100, Active: true, CreatedBy: "admin@example.com"})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(credentials.APIKey, apiKeyPrefix))
+	assert.Equal(t, "Acme", credentials.Name)
+	assert.Equal(t, hashAPIKey(credentials.APIKey), credentials.APIKeyHash)
+	assert.NotEqual(t, credentials.APIKey, credentials.APIKeyHash)
+	assert.Equal(t, credentials.APIKey[:keyPrefixLength], credentials.KeyPrefix)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestPartnerService_Create_Invalid(t *testing.T) {
+	testCases := []struct {
+		name string
+		cmd  domainService.PartnerCommand
+	}{
+		{"missing name", domainService.PartnerCommand{Quota: 10}},
+		{"negative quota", domainService.PartnerCommand{Name: "Acme", Quota: -1}},
+		{"negative rate limit", domainService.PartnerCommand{Name: "Acme", Quota: 10, RateLimit: -1}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			mockRepo := new(MockPartnerRepository)
+			partnerService := NewPartnerService(mockRepo, nil, nil)
+
+			// Act
+			credentials, err := partnerService.Create(context.Background(), &tc.cmd)
+
+			// Assert
+			assert.Error(t, err)
+			assert.Nil(t, credentials)
+			mockRepo.AssertNotCalled(t, "Create", mock.Anything)
+		})
+	}
+}
+
+func TestPartnerService_Create_DuplicateName(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockPartnerRepository)
+	partnerService := NewPartnerService(mockRepo, nil, nil)
+
+	mockRepo.On("FindByName", "Acme").Return(&entity.Partner{ID: 1, Name: "Acme"}, nil)
+
+	// Act
+	_, err := partnerService.Create(context.Background(), &domainService.PartnerCommand{Name: "Acme", Quota: 10})
+
+	// Assert
+	assert.ErrorIs(t, err, domainService.ErrDuplicatePartnerName)
+}
+
+func TestPartnerService_Update_NotFound(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockPartnerRepository)
+	partnerService := NewPartnerService(mockRepo, nil, nil)
+
+	mockRepo.On("FindByName", "Acme").Return(nil, nil)
+	mockRepo.On("Update", mock.AnythingOfType("*entity.Partner")).Return(int64(0), nil)
+
+	// Act
+	_, err := partnerService.Update(context.Background(), 9, &domainService.PartnerCommand{Name: "Acme", Quota: 10})
+
+	// Assert
+	assert.ErrorIs(t, err, domainService.ErrPartnerNotFound)
+}
+
+func TestPartnerService_RotateKey(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockPartnerRepository)
+	partnerService := NewPartnerService(mockRepo, nil, nil)
+
+	var storedHash string
+	mockRepo.On("UpdateAPIKey", uint(1), mock.AnythingOfType("string"), mock.AnythingOfType("string")).
+		Run(func(args mock.Arguments) { storedHash = args.String(1) }).
+		Return(int64(1), nil)
+	mockRepo.On("FindByID", uint(1)).Return(&entity.Partner{ID: 1, Name: "Acme"}, nil)
+
+	// Act
+	credentials, err := partnerService.RotateKey(context.Background(), 1)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, hashAPIKey(credentials.APIKey), storedHash)
+}
+
+func TestPartnerService_RotateKey_NotFound(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockPartnerRepository)
+	partnerService := NewPartnerService(mockRepo, nil, nil)
+
+	mockRepo.On("UpdateAPIKey", uint(9), mock.Anything, mock.Anything).Return(int64(0), nil)
+
+	// Act
+	_, err := partnerService.RotateKey(context.Background(), 9)
+
+	// Assert
+	assert.ErrorIs(t, err, domainService.ErrPartnerNotFound)
+}
+
+func TestPartnerService_Authenticate(t *testing.T) {
+	active := &entity.Partner{ID: 1, Name: "Acme", Active: true}
+	inactive := &entity.Partner{ID: 2, Name: "Dormant", Active: false}
+
+	testCases := []struct {
+		name    string
+		apiKey  string
+		partner *entity.Partner
+		wantErr error
+	}{
+		{"active partner", "vpk_active", active, nil},
+		{"inactive partner", "vpk_inactive", inactive, domainService.ErrPartnerInactive},
+		{"unknown key", "vpk_unknown", nil, domainService.ErrInvalidAPIKey},
+		{"malformed key", "not-a-key", nil, domainService.ErrInvalidAPIKey},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			mockRepo := new(MockPartnerRepository)
+			partnerService := NewPartnerService(mockRepo, nil, nil)
+			if tc.partner != nil {
+				mockRepo.On("FindByAPIKeyHash", hashAPIKey(tc.apiKey)).Return(tc.partner, nil)
+			} else {
+				mockRepo.On("FindByAPIKeyHash", mock.Anything).Return(nil, nil)
+			}
+
+			// Act
+			partner, err := partnerService.Authenticate(context.Background(), tc.apiKey)
+
+			// Assert
+			if tc.wantErr != nil {
+				assert.ErrorIs(t, err, tc.wantErr)
+				assert.Nil(t, partner)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.partner, partner)
+		})
+	}
+}
+
+func TestPartnerService_IssueVoucher_SetsPartner(t *testing.T) {
+	// Arrange
+	mockPartnerRepo := new(MockPartnerRepository)
+	mockVoucherRepo := new(MockVoucherRepository)
+	partnerService := NewPartnerService(mockPartnerRepo, mockVoucherRepo, NewVoucherService(mockVoucherRepo, 0))
+
+	tomorrow := time.Now().Add(24 * time.Hour).Format("2006-01-02")
+	mockPartnerRepo.On("ReserveQuota", uint(7), int64(1)).Return(true, nil)
+	mockVoucherRepo.On("FindByVoucherCode", "ACME10").Return((*entity.Voucher)(nil), nil)
+	mockVoucherRepo.On("Create", mock.AnythingOfType("*entity.Voucher")).Return(nil)
+
+	// Act
+	voucher, err := partnerService.IssueVoucher(context.Background(), 7, &domainService.CreateVoucherCommand{
+		VoucherCode: "ACME10", DiscountPercent: 10, ExpiryDate: tomorrow,
+	})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, uint(7), *voucher.PartnerID)
+	mockPartnerRepo.AssertNotCalled(t, "ReleaseQuota", mock.Anything, mock.Anything)
+}
+
+func TestPartnerService_IssueVoucher_QuotaExceeded(t *testing.T) {
+	// Arrange
+	mockPartnerRepo := new(MockPartnerRepository)
+	mockVoucherRepo := new(MockVoucherRepository)
+	partnerService := NewPartnerService(mockPartnerRepo, mockVoucherRepo, NewVoucherService(mockVoucherRepo, 0))
+
+	mockPartnerRepo.On("ReserveQuota", uint(7), int64(1)).Return(false, nil)
+
+	// Act
+	voucher, err := partnerService.IssueVoucher(context.Background(), 7, &domainService.CreateVoucherCommand{VoucherCode: "ACME10"})
+
+	// Assert
+	assert.ErrorIs(t, err, domainService.ErrPartnerQuotaExceeded)
+	assert.Nil(t, voucher)
+	mockVoucherRepo.AssertNotCalled(t, "Create", mock.Anything)
+}
+
+func TestPartnerService_IssueVoucher_ReleasesQuotaOnRejection(t *testing.T) {
+	// Arrange
+	mockPartnerRepo := new(MockPartnerRepository)
+	mockVoucherRepo := new(MockVoucherRepository)
+	partnerService := NewPartnerService(mockPartnerRepo, mockVoucherRepo, NewVoucherService(mockVoucherRepo, 0))
+
+	mockPartnerRepo.On("ReserveQuota", uint(7), int64(1)).Return(true, nil)
+	mockPartnerRepo.On("ReleaseQuota", uint(7), int64(1)).Return(nil)
+	mockVoucherRepo.On("FindByVoucherCode", "ACME10").Return(&entity.Voucher{ID: 1, VoucherCode: "ACME10"}, nil)
+
+	// Act
+	_, err := partnerService.IssueVoucher(context.Background(), 7, &domainService.CreateVoucherCommand{VoucherCode: "ACME10"})
+
+	// Assert
+	assert.ErrorIs(t, err, domainService.ErrDuplicateVoucherCode)
+	mockPartnerRepo.AssertExpectations(t)
+}
+
+func TestPartnerService_GetByID_NotFound(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockPartnerRepository)
+	partnerService := NewPartnerService(mockRepo, nil, nil)
+
+	mockRepo.On("FindByID", uint(9)).Return(nil, gorm.ErrRecordNotFound)
+
+	// Act
+	_, err := partnerService.GetByID(context.Background(), 9)
+
+	// Assert
+	assert.ErrorIs(t, err, domainService.ErrPartnerNotFound)
+}
+
+func TestPartnerService_GetVouchers_ScopedToPartner(t *testing.T) {
+	// Arrange
+	mockVoucherRepo := new(MockVoucherRepository)
+	partnerService := NewPartnerService(new(MockPartnerRepository), mockVoucherRepo, nil)
+
+	vouchers := []*entity.Voucher{{ID: 1, VoucherCode: "ACME10"}}
+	mockVoucherRepo.On("FindAllByPartner", uint(7), 1, 10, "", []utils.SortField(nil)).Return(vouchers, int64(1), nil)
+
+	// Act
+	result, total, err := partnerService.GetVouchers(context.Background(), 7, 1, 10, "", nil)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), total)
+	assert.Equal(t, vouchers, result)
+	mockVoucherRepo.AssertNotCalled(t, "FindAll", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestPartnerService_GetAll_PropagatesError(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockPartnerRepository)
+	partnerService := NewPartnerService(mockRepo, nil, nil)
+
+	mockRepo.On("FindAll", 1, 10, "", []utils.SortField(nil)).Return([]*entity.Partner(nil), int64(0), errors.New("db down"))
+
+	// Act
+	_, _, err := partnerService.GetAll(context.Background(), 1, 10, "", nil)
+
+	// Assert
+	assert.EqualError(t, err, "db down")
+}
@@ -0,0 +1,203 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/shoelfikar/voucher-management-system/internal/domain/entity"
+	domainService "github.com/shoelfikar/voucher-management-system/internal/domain/service"
+	"github.com/shoelfikar/voucher-management-system/internal/repository"
+	"github.com/shoelfikar/voucher-management-system/pkg/rules"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+// redemptionRace wires the redemption service to real repositories on one
+// SQLite database, so the concurrency tests below exercise the same locking
+// transaction as production. Run them with `make test-race`.
+type redemptionRace struct {
+	db          *gorm.DB
+	vouchers    domainService.VoucherService
+	redemptions domainService.RedemptionService
+}
+
+func setupRedemptionRace(t *testing.T, voucher *entity.Voucher) *redemptionRace {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{TranslateError: true})
+	if err != nil {
+		t.Fatalf("Failed to connect to test database: %v", err)
+	}
+	// Every connection to :memory: is a separate database
+	sqlDB, err := db.DB()
+	if err != nil {
+		t.Fatalf("Failed to get SQLite instance: %v", err)
+	}
+	sqlDB.SetMaxOpenConns(1)
+	if err := db.AutoMigrate(&entity.Voucher{}, &entity.Redemption{}, &entity.Customer{}); err != nil {
+		t.Fatalf("Failed to migrate test database: %v", err)
+	}
+	if err := db.Create(voucher).Error; err != nil {
+		t.Fatalf("Failed to create voucher: %v", err)
+	}
+
+	vouchers := NewVoucherService(repository.NewVoucherRepository(db), 0)
+	return &redemptionRace{
+		db:          db,
+		vouchers:    vouchers,
+		redemptions: NewRedemptionService(repository.NewRedemptionRepository(db), vouchers, NewCustomerService(repository.NewCustomerRepository(db))),
+	}
+}
+
+// redeem redeems code for the n-th customer
+func (r *redemptionRace) redeem(code string, n int) error {
+	_, err := r.redemptions.Redeem(context.Background(), &domainService.RedeemVoucherCommand{
+		VoucherCode: code,
+		Context:     rules.Context{CustomerID: fmt.Sprintf("c-%d", n)},
+	})
+	return err
+}
+
+// stored returns the voucher's redemption count and its number of redemptions
+func (r *redemptionRace) stored(t *testing.T, voucherID uint) (int, int64) {
+	var voucher entity.Voucher
+	assert.NoError(t, r.db.First(&voucher, voucherID).Error)
+	var rows int64
+	assert.NoError(t, r.db.Model(&entity.Redemption{}).Where("voucher_id = ?", voucherID).Count(&rows).Error)
+	return voucher.RedemptionCount, rows
+}
+
+// isRejection reports whether err is one of the errors Redeem returns for a
+// voucher that may not be redeemed (any more)
+func isRejection(err error) bool {
+	return errors.Is(err, domainService.ErrVoucherFullyRedeemed) || errors.Is(err, domainService.ErrVoucherNotRedeemable)
+}
+
+func TestRedemptionService_Redeem_ConcurrentMaxRedemptions(t *testing.T) {
+	// Arrange
+	maxRedemptions := 5
+	voucher := &entity.Voucher{VoucherCode: "RUSH", DiscountPercent: 10, ExpiryDate: time.Now().AddDate(0, 1, 0), Status: entity.VoucherStatusActive, MaxRedemptions: &maxRedemptions, MaxRedemptionsPerUser: 1}
+	race := setupRedemptionRace(t, voucher)
+
+	// Act
+	var wg sync.WaitGroup
+	var redeemed, rejected atomic.Int64
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			err := race.redeem("RUSH", i)
+			switch {
+			case err == nil:
+				redeemed.Add(1)
+			case isRejection(err):
+				rejected.Add(1)
+			default:
+				t.Errorf("unexpected error: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	// Assert
+	assert.Equal(t, int64(5), redeemed.Load())
+	assert.Equal(t, int64(15), rejected.Load())
+	count, rows := race.stored(t, voucher.ID)
+	assert.Equal(t, 5, count)
+	assert.Equal(t, int64(5), rows)
+}
+
+func TestRedemptionService_Redeem_ConcurrentPerCustomerLimit(t *testing.T) {
+	// Arrange
+	voucher := &entity.Voucher{VoucherCode: "ONCE", DiscountPercent: 10, ExpiryDate: time.Now().AddDate(0, 1, 0), Status: entity.VoucherStatusActive, MaxRedemptionsPerUser: 2}
+	race := setupRedemptionRace(t, voucher)
+
+	// Act
+	var wg sync.WaitGroup
+	var redeemed atomic.Int64
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			err := race.redeem("ONCE", 1)
+			switch {
+			case err == nil:
+				redeemed.Add(1)
+			case errors.Is(err, domainService.ErrVoucherAlreadyRedeemed), isRejection(err):
+			default:
+				t.Errorf("unexpected error: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	// Assert
+	assert.Equal(t, int64(2), redeemed.Load())
+	count, rows := race.stored(t, voucher.ID)
+	assert.Equal(t, 2, count)
+	assert.Equal(t, int64(2), rows)
+}
+
+// raceRedemptions keeps redeeming code from several goroutines while stop
+// runs, then checks that no redemption was stored once stop had returned,
+// even by requests that validated the voucher before, and that the stored
+// count matches the redemptions made
+func raceRedemptions(t *testing.T, race *redemptionRace, voucher *entity.Voucher, stop func() error) {
+	var wg sync.WaitGroup
+	var redeemed atomic.Int64
+	for worker := 0; worker < 4; worker++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 25; i++ {
+				err := race.redeem(voucher.VoucherCode, worker*100+i)
+				switch {
+				case err == nil:
+					redeemed.Add(1)
+				case isRejection(err):
+				default:
+					t.Errorf("unexpected error: %v", err)
+				}
+			}
+		}()
+	}
+
+	time.Sleep(5 * time.Millisecond)
+	assert.NoError(t, stop())
+	_, rowsAtStop := race.stored(t, voucher.ID)
+	wg.Wait()
+
+	count, rows := race.stored(t, voucher.ID)
+	assert.Equal(t, rowsAtStop, rows, "redemptions were stored after the voucher was stopped")
+	assert.Equal(t, redeemed.Load(), rows)
+	assert.Equal(t, int(rows), count)
+	assert.True(t, isRejection(race.redeem(voucher.VoucherCode, 999)))
+}
+
+func TestRedemptionService_Redeem_RacesDeactivate(t *testing.T) {
+	// Arrange
+	voucher := &entity.Voucher{VoucherCode: "FLASH", DiscountPercent: 10, ExpiryDate: time.Now().AddDate(0, 1, 0), Status: entity.VoucherStatusActive, MaxRedemptionsPerUser: 1}
+	race := setupRedemptionRace(t, voucher)
+
+	// Act & Assert
+	raceRedemptions(t, race, voucher, func() error {
+		_, err := race.vouchers.BulkDeactivate(context.Background(), "", []string{"FLASH"})
+		return err
+	})
+}
+
+func TestRedemptionService_Redeem_RacesExpiry(t *testing.T) {
+	// Arrange
+	voucher := &entity.Voucher{VoucherCode: "LASTDAY", DiscountPercent: 10, ExpiryDate: time.Now().AddDate(0, 1, 0), Status: entity.VoucherStatusActive, MaxRedemptionsPerUser: 1}
+	race := setupRedemptionRace(t, voucher)
+
+	// Act & Assert
+	raceRedemptions(t, race, voucher, func() error {
+		return race.db.Model(&entity.Voucher{}).Where("id = ?", voucher.ID).
+			Update("expiry_date", time.Now().AddDate(0, 0, -1)).Error
+	})
+}
@@ -0,0 +1,110 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/shoelfikar/voucher-management-system/internal/domain/entity"
+	"github.com/shoelfikar/voucher-management-system/internal/domain/repository"
+	domainService "github.com/shoelfikar/voucher-management-system/internal/domain/service"
+	"github.com/shoelfikar/voucher-management-system/pkg/logger"
+	"gorm.io/gorm"
+)
+
+// redemptionServiceImpl implements domain service.RedemptionService
+type redemptionServiceImpl struct {
+	redemptionRepo repository.RedemptionRepository
+	voucherService domainService.VoucherService
+	customers      domainService.CustomerService
+}
+
+// NewRedemptionService creates a new redemption service instance. Vouchers are
+// checked against the purchase by voucherService before they are redeemed, and
+// redemptions are recorded against the customer customerService resolves.
+func NewRedemptionService(redemptionRepo repository.RedemptionRepository, voucherService domainService.VoucherService, customerService domainService.CustomerService) domainService.RedemptionService {
+	return &redemptionServiceImpl{
+		redemptionRepo: redemptionRepo,
+		voucherService: voucherService,
+		customers:      customerService,
+	}
+}
+
+// Redeem validates the voucher, then leaves it to the repository to record
+// the redemption atomically, so concurrent requests cannot overshoot the
+// voucher's limits
+func (s *redemptionServiceImpl) Redeem(ctx context.Context, cmd *domainService.RedeemVoucherCommand) (*entity.Redemption, error) {
+	customerID := strings.TrimSpace(cmd.Context.CustomerID)
+	if customerID == "" {
+		return nil, errors.New("customer id is required")
+	}
+	purchase := cmd.Context
+	purchase.CustomerID = customerID
+
+	result, err := s.voucherService.Validate(ctx, cmd.VoucherCode, purchase)
+	if err != nil {
+		return nil, err
+	}
+	if !result.Valid {
+		return nil, fmt.Errorf("%w: %s", domainService.ErrVoucherNotRedeemable, strings.Join(result.Reasons, "; "))
+	}
+
+	customer, err := s.customers.Resolve(ctx, customerID)
+	if err != nil {
+		return nil, err
+	}
+	discountAmount, err := entity.ToMinorUnits(result.DiscountAmount, result.Currency)
+	if err != nil {
+		return nil, err
+	}
+
+	redemption := &entity.Redemption{
+		VoucherID:       result.VoucherID,
+		CustomerID:      customer.ID,
+		Customer:        customer,
+		OrderID:         strings.TrimSpace(cmd.OrderID),
+		DiscountType:    result.DiscountType,
+		DiscountPercent: result.DiscountPercent,
+		DiscountAmount:  discountAmount,
+		Currency:        result.Currency,
+		RedeemedBy:      cmd.RedeemedBy,
+		RedeemedAt:      time.Now(),
+	}
+	err = s.redemptionRepo.Create(ctx, redemption, checkRedemptionLimits)
+	if err != nil {
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			// Deleted since it was validated
+			return nil, domainService.ErrVoucherNotFound
+		case errors.Is(err, domainService.ErrVoucherFullyRedeemed), errors.Is(err, domainService.ErrVoucherAlreadyRedeemed),
+			errors.Is(err, domainService.ErrVoucherNotRedeemable):
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to record redemption: %w", err)
+	}
+
+	logger.FromContext(ctx).Info("voucher redeemed", "voucher_id", redemption.VoucherID, "customer_id", customer.ID, "redeemed_by", cmd.RedeemedBy)
+	return redemption, nil
+}
+
+// checkRedemptionLimits runs with the voucher locked and reports whether one
+// more redemption, by a customer who redeemed it customerRedemptions times,
+// stays within the voucher's limits. Status and expiry are checked again as
+// the voucher may have been deactivated or expired since it was validated.
+func checkRedemptionLimits(voucher *entity.Voucher, customerRedemptions int64) error {
+	if voucher.Status != entity.VoucherStatusActive {
+		return fmt.Errorf("%w: voucher is not active", domainService.ErrVoucherNotRedeemable)
+	}
+	if isExpired(voucher, time.Now()) {
+		return fmt.Errorf("%w: voucher has expired", domainService.ErrVoucherNotRedeemable)
+	}
+	if remaining := voucher.RemainingRedemptions(); remaining != nil && *remaining == 0 {
+		return domainService.ErrVoucherFullyRedeemed
+	}
+	if customerRedemptions >= int64(voucher.MaxRedemptionsPerUser) {
+		return domainService.ErrVoucherAlreadyRedeemed
+	}
+	return nil
+}
@@ -0,0 +1,155 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/shoelfikar/voucher-management-system/internal/domain/entity"
+	domainService "github.com/shoelfikar/voucher-management-system/internal/domain/service"
+	"github.com/shoelfikar/voucher-management-system/pkg/rules"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+// MockRedemptionRepository is a mock implementation of RedemptionRepository.
+// The locked voucher and the customer's earlier redemptions handed to check
+// are taken from the expectation's return values.
+type MockRedemptionRepository struct {
+	mock.Mock
+}
+
+func (m *MockRedemptionRepository) Create(ctx context.Context, redemption *entity.Redemption, check func(voucher *entity.Voucher, customerRedemptions int64) error) error {
+	args := m.Called(redemption)
+	if err := args.Error(2); err != nil {
+		return err
+	}
+	return check(args.Get(0).(*entity.Voucher), args.Get(1).(int64))
+}
+
+func TestRedemptionService_Redeem(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockVoucherRepository)
+	mockRedemptionRepo := new(MockRedemptionRepository)
+	mockCustomerRepo := new(MockCustomerRepository)
+	redemptionService := NewRedemptionService(mockRedemptionRepo, NewVoucherService(mockRepo, 0), NewCustomerService(mockCustomerRepo))
+
+	mockCustomerRepo.On("FindOrCreate", "c-1").Return(&entity.Customer{ID: 7, ExternalID: "c-1"}, nil)
+	voucher := &entity.Voucher{ID: 3, VoucherCode: "SUMMER24", DiscountPercent: 15, ExpiryDate: time.Now().AddDate(0, 1, 0), Status: entity.VoucherStatusActive, MaxRedemptionsPerUser: 2}
+	mockRepo.On("FindByVoucherCode", "SUMMER24").Return(voucher, nil)
+	mockRedemptionRepo.On("Create", mock.MatchedBy(func(r *entity.Redemption) bool {
+		return r.VoucherID == 3 && r.CustomerID == 7 && r.OrderID == "order-9" && r.DiscountPercent == 15 && r.RedeemedBy == "cashier@example.com"
+	})).Return(voucher, int64(1), nil)
+
+	// Act
+	redemption, err := redemptionService.Redeem(context.Background(), &domainService.RedeemVoucherCommand{
+		VoucherCode: "SUMMER24",
+		OrderID:     " order-9 ",
+		Context:     rules.Context{CustomerID: " c-1 "},
+		RedeemedBy:  "cashier@example.com",
+	})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, 15.0, redemption.DiscountPercent)
+	assert.False(t, redemption.RedeemedAt.IsZero())
+	mockRedemptionRepo.AssertExpectations(t)
+}
+
+func TestRedemptionService_Redeem_FixedAmount(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockVoucherRepository)
+	mockRedemptionRepo := new(MockRedemptionRepository)
+	mockCustomerRepo := new(MockCustomerRepository)
+	redemptionService := NewRedemptionService(mockRedemptionRepo, NewVoucherService(mockRepo, 0), NewCustomerService(mockCustomerRepo))
+
+	mockCustomerRepo.On("FindOrCreate", "c-1").Return(&entity.Customer{ID: 7, ExternalID: "c-1"}, nil)
+	voucher := &entity.Voucher{ID: 3, VoucherCode: "KWD1", DiscountType: entity.DiscountTypeFixedAmount, DiscountAmount: 1125, Currency: "KWD", ExpiryDate: time.Now().AddDate(0, 1, 0), Status: entity.VoucherStatusActive, MaxRedemptionsPerUser: 1}
+	mockRepo.On("FindByVoucherCode", "KWD1").Return(voucher, nil)
+	mockRedemptionRepo.On("Create", mock.MatchedBy(func(r *entity.Redemption) bool {
+		return r.DiscountType == entity.DiscountTypeFixedAmount && r.DiscountAmount == 1125 && r.Currency == "KWD"
+	})).Return(voucher, int64(0), nil)
+
+	// Act
+	_, err := redemptionService.Redeem(context.Background(), &domainService.RedeemVoucherCommand{
+		VoucherCode: "KWD1",
+		Context:     rules.Context{CustomerID: "c-1"},
+	})
+
+	// Assert
+	assert.NoError(t, err)
+	mockRedemptionRepo.AssertExpectations(t)
+}
+
+func TestRedemptionService_Redeem_Errors(t *testing.T) {
+	five, two := 5, 2
+	expiry := time.Now().AddDate(0, 1, 0)
+	active := &entity.Voucher{ID: 3, VoucherCode: "SUMMER24", DiscountPercent: 15, ExpiryDate: expiry, Status: entity.VoucherStatusActive, MaxRedemptionsPerUser: 1}
+	expired := &entity.Voucher{ID: 4, VoucherCode: "OLD", DiscountPercent: 15, ExpiryDate: time.Now().AddDate(0, 0, -1), Status: entity.VoucherStatusActive, MaxRedemptionsPerUser: 1}
+	// Validated with one use left, used up by a concurrent redemption before the lock was taken
+	lastUse := &entity.Voucher{ID: 5, VoucherCode: "LAST", DiscountPercent: 15, ExpiryDate: expiry, Status: entity.VoucherStatusActive, MaxRedemptions: &five, MaxRedemptionsPerUser: 1, RedemptionCount: 4}
+	usedUp := &entity.Voucher{ID: 5, ExpiryDate: expiry, Status: entity.VoucherStatusActive, MaxRedemptions: &five, MaxRedemptionsPerUser: 1, RedemptionCount: 5}
+	// Validated while active, deactivated or expired before the lock was taken
+	deactivated := &entity.Voucher{ID: 3, ExpiryDate: expiry, Status: entity.VoucherStatusInactive, MaxRedemptionsPerUser: 1}
+	expiredMeanwhile := &entity.Voucher{ID: 3, ExpiryDate: time.Now().AddDate(0, 0, -1), Status: entity.VoucherStatusActive, MaxRedemptionsPerUser: 1}
+	soldOut := &entity.Voucher{ID: 6, VoucherCode: "SOLDOUT", DiscountPercent: 15, ExpiryDate: expiry, Status: entity.VoucherStatusActive, MaxRedemptions: &two, MaxRedemptionsPerUser: 1, RedemptionCount: 2}
+
+	tests := []struct {
+		name                string
+		code                string
+		customerID          string
+		locked              *entity.Voucher
+		customerRedemptions int64
+		createErr           error
+		expectedErr         error
+		expectedReason      string
+	}{
+		{name: "per customer limit", code: "SUMMER24", customerID: "c-1", locked: active, customerRedemptions: 1, expectedErr: domainService.ErrVoucherAlreadyRedeemed},
+		{name: "total limit reached meanwhile", code: "LAST", customerID: "c-1", locked: usedUp, expectedErr: domainService.ErrVoucherFullyRedeemed},
+		{name: "deactivated meanwhile", code: "SUMMER24", customerID: "c-1", locked: deactivated, expectedErr: domainService.ErrVoucherNotRedeemable},
+		{name: "expired meanwhile", code: "SUMMER24", customerID: "c-1", locked: expiredMeanwhile, expectedErr: domainService.ErrVoucherNotRedeemable},
+		{name: "total limit reached", code: "SOLDOUT", customerID: "c-1", expectedErr: domainService.ErrVoucherNotRedeemable, expectedReason: "voucher has been fully redeemed"},
+		{name: "expired", code: "OLD", customerID: "c-1", expectedErr: domainService.ErrVoucherNotRedeemable, expectedReason: "voucher has expired"},
+		{name: "unknown code", code: "NOPE", customerID: "c-1", expectedErr: domainService.ErrVoucherNotFound},
+		{name: "deleted meanwhile", code: "SUMMER24", customerID: "c-1", createErr: gorm.ErrRecordNotFound, expectedErr: domainService.ErrVoucherNotFound},
+		{name: "database down", code: "SUMMER24", customerID: "c-1", createErr: errors.New("connection refused")},
+		{name: "missing customer", code: "SUMMER24"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			mockRepo := new(MockVoucherRepository)
+			mockRedemptionRepo := new(MockRedemptionRepository)
+			mockCustomerRepo := new(MockCustomerRepository)
+			redemptionService := NewRedemptionService(mockRedemptionRepo, NewVoucherService(mockRepo, 0), NewCustomerService(mockCustomerRepo))
+
+			mockRepo.On("FindByVoucherCode", "SUMMER24").Return(active, nil)
+			mockRepo.On("FindByVoucherCode", "OLD").Return(expired, nil)
+			mockRepo.On("FindByVoucherCode", "LAST").Return(lastUse, nil)
+			mockRepo.On("FindByVoucherCode", "SOLDOUT").Return(soldOut, nil)
+			mockRepo.On("FindByVoucherCode", "NOPE").Return(nil, nil)
+			mockCustomerRepo.On("FindOrCreate", "c-1").Return(&entity.Customer{ID: 7, ExternalID: "c-1"}, nil)
+			mockRedemptionRepo.On("Create", mock.Anything).Return(tt.locked, tt.customerRedemptions, tt.createErr)
+
+			// Act
+			redemption, err := redemptionService.Redeem(context.Background(), &domainService.RedeemVoucherCommand{
+				VoucherCode: tt.code,
+				Context:     rules.Context{CustomerID: tt.customerID},
+			})
+
+			// Assert
+			assert.Error(t, err)
+			assert.Nil(t, redemption)
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+			}
+			if tt.expectedReason != "" {
+				assert.Contains(t, err.Error(), tt.expectedReason)
+				mockRedemptionRepo.AssertNotCalled(t, "Create", mock.Anything)
+			}
+		})
+	}
+}
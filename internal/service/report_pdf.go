@@ -0,0 +1,127 @@
+package service
+
+import (
+	"strconv"
+
+	domainService "github.com/shoelfikar/voucher-management-system/internal/domain/service"
+	"github.com/shoelfikar/voucher-management-system/pkg/pdf"
+)
+
+// Layout of a summary report on an A4 page, in points
+const (
+	reportMargin       = 48
+	reportTotalHeight  = 64
+	reportTotalGap     = 12
+	reportRowHeight    = 20
+	reportChartHeight  = 220
+	reportChartBarGap  = 0.2
+	reportLabelSize    = 9
+	reportHeadingSize  = 13
+	reportTotalFigSize = 22
+)
+
+// renderReportPDF lays out the summary on one A4 page: the period, a row of
+// totals, the top vouchers and a bar chart of the redemption trend
+func renderReportPDF(summary *domainService.ReportSummary) []byte {
+	doc := pdf.New(pdf.A4Width, pdf.A4Height)
+	page := doc.AddPage()
+	width := float64(pdf.A4Width - 2*reportMargin)
+
+	// The period excludes To, so the last day shown is the one before it
+	page.Text(reportMargin, 780, pdf.Bold, 22, "Voucher summary")
+	page.Text(reportMargin, 760, pdf.Regular, 11,
+		summary.From.Format("2 January 2006")+" to "+summary.To.AddDate(0, 0, -1).Format("2 January 2006"))
+
+	totals := []struct {
+		label string
+		value int64
+	}{
+		{"Vouchers created", summary.VouchersCreated},
+		{"Active vouchers", summary.ActiveVouchers},
+		{"Redemptions", summary.Redemptions},
+		{"Redeeming customers", summary.RedeemingCustomers},
+	}
+	boxWidth := (width - reportTotalGap*float64(len(totals)-1)) / float64(len(totals))
+	top := 730.0
+	for i, total := range totals {
+		x := reportMargin + float64(i)*(boxWidth+reportTotalGap)
+		page.StrokeRect(x, top-reportTotalHeight, boxWidth, reportTotalHeight, 1)
+		page.Text(x+10, top-20, pdf.Regular, reportLabelSize, total.label)
+		page.Text(x+10, top-50, pdf.Bold, reportTotalFigSize, formatCount(total.value))
+	}
+
+	y := top - reportTotalHeight - 40
+	page.Text(reportMargin, y, pdf.Bold, reportHeadingSize, "Top vouchers")
+	y -= reportRowHeight + 4
+	if len(summary.TopVouchers) == 0 {
+		page.Text(reportMargin, y, pdf.Regular, 10, "No vouchers were redeemed in this period.")
+		y -= reportRowHeight
+	}
+	for i, voucher := range summary.TopVouchers {
+		count := formatCount(voucher.Redemptions)
+		name := wrapText(voucher.Name, width-120, 10, 1)
+		page.Text(reportMargin, y, pdf.Regular, 10, strconv.Itoa(i+1)+".")
+		if len(name) > 0 {
+			page.Text(reportMargin+20, y, pdf.Regular, 10, name[0])
+		}
+		page.Text(reportMargin+width-pdf.TextWidth(count, 10), y, pdf.Bold, 10, count)
+		y -= reportRowHeight
+	}
+
+	y -= 20
+	heading := "Redemptions per day"
+	if summary.TrendBucket == domainService.ReportBucketWeek {
+		heading = "Redemptions per week"
+	}
+	page.Text(reportMargin, y, pdf.Bold, reportHeadingSize, heading)
+	renderTrendChart(page, reportMargin, y-16-reportChartHeight, width, reportChartHeight, summary.RedemptionTrend)
+
+	page.Text(reportMargin, 36, pdf.Regular, 8, "Generated "+summary.GeneratedAt.UTC().Format("2 January 2006 15:04 MST"))
+	return doc.Bytes()
+}
+
+// renderTrendChart draws the trend as bars in the box whose bottom left
+// corner is x, y, scaled so the busiest bucket fills the box, with the first
+// and last bucket labelled below it
+func renderTrendChart(page *pdf.Page, x, y, width, height float64, trend []domainService.ReportTrendPoint) {
+	page.StrokeRect(x, y, width, height, 0.5)
+	if len(trend) == 0 {
+		return
+	}
+
+	var peak int64
+	for _, point := range trend {
+		if point.Redemptions > peak {
+			peak = point.Redemptions
+		}
+	}
+	peakLabel := formatCount(peak)
+	page.Text(x+4, y+height-12, pdf.Regular, reportLabelSize-1, "Peak "+peakLabel)
+
+	slot := width / float64(len(trend))
+	if peak > 0 {
+		// Headroom above the tallest bar keeps it clear of the peak label
+		scale := (height - 20) / float64(peak)
+		for i, point := range trend {
+			if point.Redemptions > 0 {
+				page.Rect(x+float64(i)*slot+slot*reportChartBarGap/2, y, slot*(1-reportChartBarGap), float64(point.Redemptions)*scale)
+			}
+		}
+	}
+
+	first := trend[0].Start.Format("2 Jan")
+	last := trend[len(trend)-1].Start.Format("2 Jan")
+	page.Text(x, y-12, pdf.Regular, reportLabelSize-1, first)
+	if len(trend) > 1 {
+		page.Text(x+width-pdf.TextWidth(last, reportLabelSize-1), y-12, pdf.Regular, reportLabelSize-1, last)
+	}
+}
+
+// formatCount writes a count with thousands separators, e.g. 12,345
+func formatCount(value int64) string {
+	digits := strconv.FormatInt(value, 10)
+	for i := len(digits) - 3; i > 0; i -= 3 {
+		digits = digits[:i] + "," + digits[i:]
+	}
+	return digits
+}
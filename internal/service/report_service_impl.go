@@ -0,0 +1,120 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/shoelfikar/voucher-management-system/internal/domain/repository"
+	domainService "github.com/shoelfikar/voucher-management-system/internal/domain/service"
+	"github.com/shoelfikar/voucher-management-system/pkg/utils"
+)
+
+// Shape of a report
+const (
+	reportTopVouchers = 5
+	// reportMaxDailyBuckets is the longest period whose trend is shown per
+	// day; longer ones are shown per week so the bars stay readable
+	reportMaxDailyBuckets = 62
+)
+
+// reportServiceImpl implements domain service.ReportService
+type reportServiceImpl struct {
+	reportRepo repository.ReportRepository
+	now        func() time.Time
+}
+
+// NewReportService creates a new report service instance
+func NewReportService(reportRepo repository.ReportRepository) domainService.ReportService {
+	return &reportServiceImpl{
+		reportRepo: reportRepo,
+		now:        time.Now,
+	}
+}
+
+// Summary gathers the totals, top vouchers and redemption trend of the period
+func (s *reportServiceImpl) Summary(ctx context.Context, from, to time.Time) (*domainService.ReportSummary, error) {
+	if !to.After(from) || to.Sub(from) > domainService.MaxReportPeriod {
+		return nil, domainService.ErrInvalidReportPeriod
+	}
+
+	now := s.now()
+	summary := &domainService.ReportSummary{From: from, To: to, GeneratedAt: now}
+
+	var err error
+	if summary.VouchersCreated, err = s.reportRepo.CountVouchersCreated(ctx, from, to); err != nil {
+		return nil, fmt.Errorf("failed to count created vouchers: %w", err)
+	}
+
+	// Vouchers are counted as active at the end of the period, or now for a period that has not ended
+	activeAt := to
+	if now.Before(activeAt) {
+		activeAt = now
+	}
+	if summary.ActiveVouchers, err = s.reportRepo.CountActiveVouchers(ctx, activeAt); err != nil {
+		return nil, fmt.Errorf("failed to count active vouchers: %w", err)
+	}
+
+	totals, err := s.reportRepo.SumRedemptions(ctx, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sum redemptions: %w", err)
+	}
+	summary.Redemptions = totals.Redemptions
+	summary.RedeemingCustomers = totals.Customers
+
+	top, err := s.reportRepo.TopRedeemedVouchers(ctx, from, to, reportTopVouchers)
+	if err != nil {
+		return nil, fmt.Errorf("failed to rank vouchers: %w", err)
+	}
+	summary.TopVouchers = make([]domainService.ReportVoucher, len(top))
+	for i, voucher := range top {
+		// Reports are shared beyond the people allowed to see voucher codes
+		name := voucher.DisplayName
+		if name == "" {
+			name = utils.MaskCode(voucher.VoucherCode)
+		}
+		summary.TopVouchers[i] = domainService.ReportVoucher{VoucherID: voucher.VoucherID, Name: name, Redemptions: voucher.Redemptions}
+	}
+
+	days, err := s.reportRepo.CountRedemptionsByDay(ctx, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count redemptions by day: %w", err)
+	}
+	summary.TrendBucket, summary.RedemptionTrend = redemptionTrend(from, to, days)
+
+	return summary, nil
+}
+
+// RenderSummaryPDF renders the summary of the period as a one-page PDF
+func (s *reportServiceImpl) RenderSummaryPDF(ctx context.Context, from, to time.Time) ([]byte, error) {
+	summary, err := s.Summary(ctx, from, to)
+	if err != nil {
+		return nil, err
+	}
+	return renderReportPDF(summary), nil
+}
+
+// redemptionTrend spreads the daily counts over buckets covering the whole
+// period, so days without redemptions show up as gaps in the chart. Buckets
+// start at from, a day or a week apart.
+func redemptionTrend(from, to time.Time, days []repository.DailyRedemptions) (string, []domainService.ReportTrendPoint) {
+	bucket, step := domainService.ReportBucketDay, 1
+	if to.Sub(from) > reportMaxDailyBuckets*24*time.Hour {
+		bucket, step = domainService.ReportBucketWeek, 7
+	}
+
+	var trend []domainService.ReportTrendPoint
+	for start := from; start.Before(to); start = start.AddDate(0, 0, step) {
+		trend = append(trend, domainService.ReportTrendPoint{Start: start})
+	}
+
+	// Days are counted from the UTC midnight at or before from
+	origin := time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, time.UTC)
+	for _, day := range days {
+		index := int(day.Day.Sub(origin).Hours()/24) / step
+		if index >= 0 && index < len(trend) {
+			trend[index].Redemptions += day.Redemptions
+		}
+	}
+	return bucket, trend
+}
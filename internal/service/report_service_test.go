@@ -0,0 +1,159 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/shoelfikar/voucher-management-system/internal/domain/repository"
+	domainService "github.com/shoelfikar/voucher-management-system/internal/domain/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockReportRepository is a mock implementation of ReportRepository; the
+// context is not matched on
+type MockReportRepository struct {
+	mock.Mock
+}
+
+func (m *MockReportRepository) CountVouchersCreated(ctx context.Context, from, to time.Time) (int64, error) {
+	args := m.Called(from, to)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockReportRepository) CountActiveVouchers(ctx context.Context, at time.Time) (int64, error) {
+	args := m.Called(at)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockReportRepository) SumRedemptions(ctx context.Context, from, to time.Time) (repository.RedemptionTotals, error) {
+	args := m.Called(from, to)
+	return args.Get(0).(repository.RedemptionTotals), args.Error(1)
+}
+
+func (m *MockReportRepository) TopRedeemedVouchers(ctx context.Context, from, to time.Time, limit int) ([]repository.VoucherRedemptions, error) {
+	args := m.Called(from, to, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]repository.VoucherRedemptions), args.Error(1)
+}
+
+func (m *MockReportRepository) CountRedemptionsByDay(ctx context.Context, from, to time.Time) ([]repository.DailyRedemptions, error) {
+	args := m.Called(from, to)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]repository.DailyRedemptions), args.Error(1)
+}
+
+func TestReportService_Summary(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockReportRepository)
+	reportService := NewReportService(mockRepo).(*reportServiceImpl)
+	from := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 0, 7)
+	now := to.AddDate(0, 0, 2)
+	reportService.now = func() time.Time { return now }
+
+	mockRepo.On("CountVouchersCreated", from, to).Return(int64(4), nil)
+	mockRepo.On("CountActiveVouchers", to).Return(int64(12), nil)
+	mockRepo.On("SumRedemptions", from, to).Return(repository.RedemptionTotals{Redemptions: 9, Customers: 6}, nil)
+	mockRepo.On("TopRedeemedVouchers", from, to, 5).Return([]repository.VoucherRedemptions{
+		{VoucherID: 1, VoucherCode: "SUMMER2024", DisplayName: "Summer sale", Redemptions: 6},
+		{VoucherID: 2, VoucherCode: "WINTER2024", Redemptions: 3},
+	}, nil)
+	mockRepo.On("CountRedemptionsByDay", from, to).Return([]repository.DailyRedemptions{
+		{Day: from, Redemptions: 5},
+		{Day: from.AddDate(0, 0, 3), Redemptions: 4},
+	}, nil)
+
+	// Act
+	summary, err := reportService.Summary(context.Background(), from, to)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, int64(4), summary.VouchersCreated)
+	assert.Equal(t, int64(12), summary.ActiveVouchers)
+	assert.Equal(t, int64(9), summary.Redemptions)
+	assert.Equal(t, int64(6), summary.RedeemingCustomers)
+	assert.Equal(t, []domainService.ReportVoucher{
+		{VoucherID: 1, Name: "Summer sale", Redemptions: 6},
+		{VoucherID: 2, Name: "WINT****24", Redemptions: 3},
+	}, summary.TopVouchers)
+	assert.Equal(t, domainService.ReportBucketDay, summary.TrendBucket)
+	if assert.Len(t, summary.RedemptionTrend, 7) {
+		assert.Equal(t, int64(5), summary.RedemptionTrend[0].Redemptions)
+		assert.Equal(t, int64(0), summary.RedemptionTrend[1].Redemptions)
+		assert.Equal(t, int64(4), summary.RedemptionTrend[3].Redemptions)
+		assert.True(t, summary.RedemptionTrend[6].Start.Equal(from.AddDate(0, 0, 6)))
+	}
+	assert.Equal(t, now, summary.GeneratedAt)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestReportService_Summary_InvalidPeriod(t *testing.T) {
+	// Arrange
+	reportService := NewReportService(new(MockReportRepository))
+	from := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	// Act
+	_, reversedErr := reportService.Summary(context.Background(), from, from.AddDate(0, 0, -1))
+	_, emptyErr := reportService.Summary(context.Background(), from, from)
+	_, longErr := reportService.Summary(context.Background(), from, from.AddDate(0, 0, 367))
+
+	// Assert
+	assert.ErrorIs(t, reversedErr, domainService.ErrInvalidReportPeriod)
+	assert.ErrorIs(t, emptyErr, domainService.ErrInvalidReportPeriod)
+	assert.ErrorIs(t, longErr, domainService.ErrInvalidReportPeriod)
+}
+
+func TestReportService_RenderSummaryPDF(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockReportRepository)
+	reportService := NewReportService(mockRepo)
+	from := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 3, 0)
+
+	mockRepo.On("CountVouchersCreated", from, to).Return(int64(1250), nil)
+	mockRepo.On("CountActiveVouchers", mock.Anything).Return(int64(300), nil)
+	mockRepo.On("SumRedemptions", from, to).Return(repository.RedemptionTotals{Redemptions: 40, Customers: 31}, nil)
+	mockRepo.On("TopRedeemedVouchers", from, to, 5).Return([]repository.VoucherRedemptions{
+		{VoucherID: 1, VoucherCode: "SUMMER2024", Redemptions: 40},
+	}, nil)
+	mockRepo.On("CountRedemptionsByDay", from, to).Return([]repository.DailyRedemptions{
+		{Day: from.AddDate(0, 0, 8), Redemptions: 40},
+	}, nil)
+
+	// Act
+	document, err := reportService.RenderSummaryPDF(context.Background(), from, to)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.True(t, bytes.HasPrefix(document, []byte("%PDF-")))
+	assert.Equal(t, 1, bytes.Count(document, []byte("/Type /Page ")))
+	assert.Contains(t, string(document), "(Redemptions per week)")
+	assert.Contains(t, string(document), "(1,250)")
+	assert.Contains(t, string(document), "(SUMM****24)")
+	assert.NotContains(t, string(document), "SUMMER2024")
+}
+
+func TestReportService_RenderSummaryPDF_RepositoryError(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockReportRepository)
+	reportService := NewReportService(mockRepo)
+	from := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 0, 7)
+
+	mockRepo.On("CountVouchersCreated", from, to).Return(int64(0), errors.New("connection refused"))
+
+	// Act
+	document, err := reportService.RenderSummaryPDF(context.Background(), from, to)
+
+	// Assert
+	assert.Error(t, err)
+	assert.Nil(t, document)
+}
@@ -0,0 +1,171 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/shoelfikar/voucher-management-system/internal/domain/entity"
+	"github.com/shoelfikar/voucher-management-system/internal/domain/repository"
+	domainService "github.com/shoelfikar/voucher-management-system/internal/domain/service"
+	"gorm.io/gorm"
+)
+
+// cleanupChunkSize caps the rows archived or purged per statement
+const cleanupChunkSize = 500
+
+// retentionServiceImpl implements domain service.RetentionService
+type retentionServiceImpl struct {
+	policyRepo     repository.RetentionPolicyRepository
+	voucherRepo    repository.VoucherRepository
+	assignmentRepo repository.VoucherAssignmentRepository
+	now            func() time.Time
+}
+
+// RetentionServiceOption configures optional retention service dependencies
+type RetentionServiceOption func(*retentionServiceImpl)
+
+// WithAssignmentCleanup makes cleanup runs also delete voucher assignments
+// that expired before the cutoff
+func WithAssignmentCleanup(assignmentRepo repository.VoucherAssignmentRepository) RetentionServiceOption {
+	return func(s *retentionServiceImpl) {
+		s.assignmentRepo = assignmentRepo
+	}
+}
+
+// NewRetentionService creates a new retention service instance
+func NewRetentionService(policyRepo repository.RetentionPolicyRepository, voucherRepo repository.VoucherRepository, opts ...RetentionServiceOption) domainService.RetentionService {
+	s := &retentionServiceImpl{
+		policyRepo:  policyRepo,
+		voucherRepo: voucherRepo,
+		now:         time.Now,
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// GetPolicy returns the current policy, or the disabled default when none was saved
+func (s *retentionServiceImpl) GetPolicy(ctx context.Context) (*entity.RetentionPolicy, error) {
+	policy, err := s.policyRepo.Get(ctx)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return &entity.RetentionPolicy{
+			KeepDays: entity.DefaultRetentionKeepDays,
+			Action:   entity.RetentionActionArchive,
+		}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load retention policy: %w", err)
+	}
+	return policy, nil
+}
+
+// UpdatePolicy validates and replaces the policy
+func (s *retentionServiceImpl) UpdatePolicy(ctx context.Context, cmd *domainService.RetentionPolicyCommand) (*entity.RetentionPolicy, error) {
+	if cmd.KeepDays < 0 || cmd.KeepDays > entity.MaxRetentionKeepDays {
+		return nil, fmt.Errorf("keep days must be between 0 and %d", entity.MaxRetentionKeepDays)
+	}
+	if cmd.Action != entity.RetentionActionArchive && cmd.Action != entity.RetentionActionPurge {
+		return nil, fmt.Errorf("action must be %s or %s", entity.RetentionActionArchive, entity.RetentionActionPurge)
+	}
+
+	policy := &entity.RetentionPolicy{
+		KeepDays:  cmd.KeepDays,
+		Action:    cmd.Action,
+		Enabled:   cmd.Enabled,
+		UpdatedBy: cmd.UpdatedBy,
+	}
+	if err := s.policyRepo.Save(ctx, policy); err != nil {
+		return nil, fmt.Errorf("failed to save retention policy: %w", err)
+	}
+	return policy, nil
+}
+
+// Preview reports which vouchers the next cleanup run would archive or purge
+func (s *retentionServiceImpl) Preview(ctx context.Context) (*domainService.CleanupPreview, error) {
+	policy, err := s.GetPolicy(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	cutoff := s.cutoff(policy)
+	includeArchived := policy.Action == entity.RetentionActionPurge
+
+	affected, err := s.voucherRepo.CountExpiredBefore(ctx, cutoff, includeArchived)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count expired vouchers: %w", err)
+	}
+	codes, err := s.voucherRepo.FindExpiredCodesBefore(ctx, cutoff, includeArchived, domainService.MaxCleanupPreviewCodes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list expired vouchers: %w", err)
+	}
+	if codes == nil {
+		codes = []string{}
+	}
+
+	return &domainService.CleanupPreview{
+		Enabled:     policy.Enabled,
+		Action:      policy.Action,
+		Cutoff:      cutoff,
+		Affected:    affected,
+		SampleCodes: codes,
+	}, nil
+}
+
+// RunCleanup archives or purges vouchers past the retention period in chunks,
+// so a large backlog does not hold one long-running DELETE
+func (s *retentionServiceImpl) RunCleanup(ctx context.Context) (*domainService.CleanupResult, error) {
+	policy, err := s.GetPolicy(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &domainService.CleanupResult{
+		Action: policy.Action,
+		Cutoff: s.cutoff(policy),
+	}
+	if !policy.Enabled {
+		return result, nil
+	}
+
+	cleanup := s.voucherRepo.ArchiveExpiredBefore
+	if policy.Action == entity.RetentionActionPurge {
+		cleanup = s.voucherRepo.PurgeExpiredBefore
+	}
+
+	for {
+		rows, err := cleanup(ctx, result.Cutoff, cleanupChunkSize)
+		if err != nil {
+			return nil, fmt.Errorf("failed to %s expired vouchers: %w", policy.Action, err)
+		}
+		if rows == 0 {
+			break
+		}
+		result.Affected += rows
+	}
+
+	// Assignments are deleted whatever the action; they carry nothing worth archiving
+	if s.assignmentRepo != nil {
+		for {
+			rows, err := s.assignmentRepo.DeleteExpiredBefore(ctx, result.Cutoff, cleanupChunkSize)
+			if err != nil {
+				return nil, fmt.Errorf("failed to delete expired voucher assignments: %w", err)
+			}
+			if rows == 0 {
+				break
+			}
+			result.AssignmentsAffected += rows
+		}
+	}
+
+	slog.Info("retention cleanup finished", "action", policy.Action, "vouchers", result.Affected, "assignments", result.AssignmentsAffected, "cutoff", result.Cutoff.Format("2006-01-02"))
+	return result, nil
+}
+
+// cutoff returns the date before which expired vouchers are past retention
+func (s *retentionServiceImpl) cutoff(policy *entity.RetentionPolicy) time.Time {
+	return s.now().Truncate(24*time.Hour).AddDate(0, 0, -policy.KeepDays)
+}
@@ -0,0 +1,176 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/shoelfikar/voucher-management-system/internal/domain/entity"
+	domainService "github.com/shoelfikar/voucher-management-system/internal/domain/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+// MockRetentionPolicyRepository is a mock implementation of RetentionPolicyRepository
+type MockRetentionPolicyRepository struct {
+	mock.Mock
+}
+
+func (m *MockRetentionPolicyRepository) Get(ctx context.Context) (*entity.RetentionPolicy, error) {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.RetentionPolicy), args.Error(1)
+}
+
+func (m *MockRetentionPolicyRepository) Save(ctx context.Context, policy *entity.RetentionPolicy) error {
+	args := m.Called(policy)
+	return args.Error(0)
+}
+
+func newTestRetentionService(policyRepo *MockRetentionPolicyRepository, voucherRepo *MockVoucherRepository, now time.Time) *retentionServiceImpl {
+	return &retentionServiceImpl{
+		policyRepo:  policyRepo,
+		voucherRepo: voucherRepo,
+		now:         func() time.Time { return now },
+	}
+}
+
+func TestRetentionService_GetPolicy_DefaultsWhenUnset(t *testing.T) {
+	// Arrange
+	mockPolicyRepo := new(MockRetentionPolicyRepository)
+	retentionService := NewRetentionService(mockPolicyRepo, new(MockVoucherRepository))
+
+	mockPolicyRepo.On("Get").Return(nil, gorm.ErrRecordNotFound)
+
+	// Act
+	policy, err := retentionService.GetPolicy(context.Background())
+
+	// Assert
+	assert.NoError(t, err)
+	assert.False(t, policy.Enabled)
+	assert.Equal(t, entity.DefaultRetentionKeepDays, policy.KeepDays)
+	assert.Equal(t, entity.RetentionActionArchive, policy.Action)
+}
+
+func TestRetentionService_UpdatePolicy_InvalidAction(t *testing.T) {
+	// Arrange
+	mockPolicyRepo := new(MockRetentionPolicyRepository)
+	retentionService := NewRetentionService(mockPolicyRepo, new(MockVoucherRepository))
+
+	// Act
+	policy, err := retentionService.UpdatePolicy(context.Background(), &domainService.RetentionPolicyCommand{KeepDays: 30, Action: "shred"})
+
+	// Assert
+	assert.Error(t, err)
+	assert.Nil(t, policy)
+	mockPolicyRepo.AssertNotCalled(t, "Save", mock.Anything)
+}
+
+func TestRetentionService_Preview_PurgeIncludesArchived(t *testing.T) {
+	// Arrange
+	mockPolicyRepo := new(MockRetentionPolicyRepository)
+	mockVoucherRepo := new(MockVoucherRepository)
+	now := time.Date(2026, 3, 31, 15, 0, 0, 0, time.UTC)
+	retentionService := newTestRetentionService(mockPolicyRepo, mockVoucherRepo, now)
+	cutoff := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	mockPolicyRepo.On("Get").Return(&entity.RetentionPolicy{KeepDays: 30, Action: entity.RetentionActionPurge, Enabled: true}, nil)
+	mockVoucherRepo.On("CountExpiredBefore", cutoff, true).Return(int64(42), nil)
+	mockVoucherRepo.On("FindExpiredCodesBefore", cutoff, true, domainService.MaxCleanupPreviewCodes).Return([]string{"OLD1", "OLD2"}, nil)
+
+	// Act
+	preview, err := retentionService.Preview(context.Background())
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, cutoff, preview.Cutoff)
+	assert.Equal(t, int64(42), preview.Affected)
+	assert.Equal(t, []string{"OLD1", "OLD2"}, preview.SampleCodes)
+	mockVoucherRepo.AssertExpectations(t)
+}
+
+func TestRetentionService_RunCleanup_ArchivesInChunks(t *testing.T) {
+	// Arrange
+	mockPolicyRepo := new(MockRetentionPolicyRepository)
+	mockVoucherRepo := new(MockVoucherRepository)
+	now := time.Date(2026, 3, 31, 15, 0, 0, 0, time.UTC)
+	retentionService := newTestRetentionService(mockPolicyRepo, mockVoucherRepo, now)
+	cutoff := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	mockPolicyRepo.On("Get").Return(&entity.RetentionPolicy{KeepDays: 30, Action: entity.RetentionActionArchive, Enabled: true}, nil)
+	mockVoucherRepo.On("ArchiveExpiredBefore", cutoff, cleanupChunkSize).Return(int64(cleanupChunkSize), nil).Once()
+	mockVoucherRepo.On("ArchiveExpiredBefore", cutoff, cleanupChunkSize).Return(int64(20), nil).Once()
+	mockVoucherRepo.On("ArchiveExpiredBefore", cutoff, cleanupChunkSize).Return(int64(0), nil).Once()
+
+	// Act
+	result, err := retentionService.RunCleanup(context.Background())
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, int64(cleanupChunkSize+20), result.Affected)
+	mockVoucherRepo.AssertExpectations(t)
+	mockVoucherRepo.AssertNotCalled(t, "PurgeExpiredBefore", mock.Anything, mock.Anything)
+}
+
+func TestRetentionService_RunCleanup_DeletesExpiredAssignments(t *testing.T) {
+	// Arrange
+	mockPolicyRepo := new(MockRetentionPolicyRepository)
+	mockVoucherRepo := new(MockVoucherRepository)
+	mockAssignmentRepo := new(MockVoucherAssignmentRepository)
+	now := time.Date(2026, 3, 31, 15, 0, 0, 0, time.UTC)
+	retentionService := newTestRetentionService(mockPolicyRepo, mockVoucherRepo, now)
+	retentionService.assignmentRepo = mockAssignmentRepo
+	cutoff := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	mockPolicyRepo.On("Get").Return(&entity.RetentionPolicy{KeepDays: 30, Action: entity.RetentionActionPurge, Enabled: true}, nil)
+	mockVoucherRepo.On("PurgeExpiredBefore", cutoff, cleanupChunkSize).Return(int64(0), nil).Once()
+	mockAssignmentRepo.On("DeleteExpiredBefore", cutoff, cleanupChunkSize).Return(int64(cleanupChunkSize), nil).Once()
+	mockAssignmentRepo.On("DeleteExpiredBefore", cutoff, cleanupChunkSize).Return(int64(0), nil).Once()
+
+	// Act
+	result, err := retentionService.RunCleanup(context.Background())
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Zero(t, result.Affected)
+	assert.Equal(t, int64(cleanupChunkSize), result.AssignmentsAffected)
+	mockAssignmentRepo.AssertExpectations(t)
+}
+
+func TestRetentionService_RunCleanup_DisabledDoesNothing(t *testing.T) {
+	// Arrange
+	mockPolicyRepo := new(MockRetentionPolicyRepository)
+	mockVoucherRepo := new(MockVoucherRepository)
+	retentionService := NewRetentionService(mockPolicyRepo, mockVoucherRepo)
+
+	mockPolicyRepo.On("Get").Return(&entity.RetentionPolicy{KeepDays: 30, Action: entity.RetentionActionPurge}, nil)
+
+	// Act
+	result, err := retentionService.RunCleanup(context.Background())
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Zero(t, result.Affected)
+	mockVoucherRepo.AssertNotCalled(t, "PurgeExpiredBefore", mock.Anything, mock.Anything)
+}
+
+func TestRetentionService_RunCleanup_RepositoryError(t *testing.T) {
+	// Arrange
+	mockPolicyRepo := new(MockRetentionPolicyRepository)
+	mockVoucherRepo := new(MockVoucherRepository)
+	retentionService := NewRetentionService(mockPolicyRepo, mockVoucherRepo)
+
+	mockPolicyRepo.On("Get").Return(&entity.RetentionPolicy{KeepDays: 30, Action: entity.RetentionActionPurge, Enabled: true}, nil)
+	mockVoucherRepo.On("PurgeExpiredBefore", mock.Anything, cleanupChunkSize).Return(int64(0), errors.New("connection reset"))
+
+	// Act
+	result, err := retentionService.RunCleanup(context.Background())
+
+	// Assert
+	assert.Error(t, err)
+	assert.Nil(t, result)
+}
@@ -0,0 +1,183 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/shoelfikar/voucher-management-system/internal/domain/entity"
+	"github.com/shoelfikar/voucher-management-system/internal/domain/repository"
+	domainService "github.com/shoelfikar/voucher-management-system/internal/domain/service"
+	"github.com/shoelfikar/voucher-management-system/pkg/segments"
+	"github.com/shoelfikar/voucher-management-system/pkg/utils"
+	"gorm.io/gorm"
+)
+
+// maxSegmentMembers bounds the customer IDs a static segment may list
+const maxSegmentMembers = 100000
+
+// segmentServiceImpl implements domain service.SegmentService
+type segmentServiceImpl struct {
+	segmentRepo repository.SegmentRepository
+	provider    segments.Provider
+}
+
+// NewSegmentService creates a new segment service instance. provider answers
+// membership of external segments; pass nil to allow static segments only.
+func NewSegmentService(segmentRepo repository.SegmentRepository, provider segments.Provider) domainService.SegmentService {
+	return &segmentServiceImpl{segmentRepo: segmentRepo, provider: provider}
+}
+
+// GetAll retrieves segments with pagination, search, and sorting
+func (s *segmentServiceImpl) GetAll(ctx context.Context, page, limit int, search string, sort []utils.SortField) ([]*entity.Segment, int64, error) {
+	return s.segmentRepo.FindAll(ctx, page, limit, search, sort)
+}
+
+// GetByID retrieves a segment by ID with its member count
+func (s *segmentServiceImpl) GetByID(ctx context.Context, id uint) (*domainService.SegmentDetail, error) {
+	segment, err := s.segmentRepo.FindByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domainService.ErrSegmentNotFound
+		}
+		return nil, err
+	}
+
+	members, err := s.segmentRepo.CountMembers(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return &domainService.SegmentDetail{Segment: segment, MemberCount: members}, nil
+}
+
+// Create validates and stores a new segment
+func (s *segmentServiceImpl) Create(ctx context.Context, cmd *domainService.SegmentCommand) (*domainService.SegmentDetail, error) {
+	segment, customerIDs, err := s.validateSegment(ctx, 0, cmd)
+	if err != nil {
+		return nil, err
+	}
+	segment.CreatedBy = cmd.CreatedBy
+
+	if err := s.segmentRepo.Create(ctx, segment, customerIDs); err != nil {
+		return nil, fmt.Errorf("failed to create segment: %w", err)
+	}
+	return &domainService.SegmentDetail{Segment: segment, MemberCount: int64(len(customerIDs))}, nil
+}
+
+// Update validates and replaces an existing segment, members included
+func (s *segmentServiceImpl) Update(ctx context.Context, id uint, cmd *domainService.SegmentCommand) (*domainService.SegmentDetail, error) {
+	segment, customerIDs, err := s.validateSegment(ctx, id, cmd)
+	if err != nil {
+		return nil, err
+	}
+	segment.ID = id
+
+	rowsAffected, err := s.segmentRepo.Update(ctx, segment, customerIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update segment: %w", err)
+	}
+	if rowsAffected == 0 {
+		return nil, domainService.ErrSegmentNotFound
+	}
+
+	return s.GetByID(ctx, id)
+}
+
+// Delete removes a segment no voucher targets
+func (s *segmentServiceImpl) Delete(ctx context.Context, id uint) error {
+	vouchers, err := s.segmentRepo.CountVouchers(ctx, id)
+	if err != nil {
+		return err
+	}
+	if vouchers > 0 {
+		return domainService.ErrSegmentInUse
+	}
+
+	rowsAffected, err := s.segmentRepo.Delete(ctx, id)
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return domainService.ErrSegmentNotFound
+	}
+	return nil
+}
+
+// IsMember checks static segments against their member list and asks the provider about external ones
+func (s *segmentServiceImpl) IsMember(ctx context.Context, segmentID uint, customerID string) (bool, error) {
+	segment, err := s.segmentRepo.FindByID(ctx, segmentID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return false, domainService.ErrSegmentNotFound
+		}
+		return false, err
+	}
+
+	if segment.Type == entity.SegmentTypeExternal {
+		if s.provider == nil {
+			return false, domainService.ErrSegmentProviderUnavailable
+		}
+		return s.provider.IsMember(ctx, segment.ExternalRef, customerID)
+	}
+	return s.segmentRepo.IsMember(ctx, segmentID, customerID)
+}
+
+// validateSegment checks a segment command and returns the segment with its
+// trimmed, de-duplicated customer IDs. id is the segment being replaced, or 0.
+func (s *segmentServiceImpl) validateSegment(ctx context.Context, id uint, cmd *domainService.SegmentCommand) (*entity.Segment, []string, error) {
+	segment := &entity.Segment{
+		Name:        strings.TrimSpace(cmd.Name),
+		Type:        cmd.Type,
+		ExternalRef: strings.TrimSpace(cmd.ExternalRef),
+	}
+	if segment.Name == "" {
+		return nil, nil, errors.New("segment name is required")
+	}
+
+	var customerIDs []string
+	switch segment.Type {
+	case entity.SegmentTypeStatic:
+		if segment.ExternalRef != "" {
+			return nil, nil, errors.New("static segments cannot have an external reference")
+		}
+		customerIDs = uniqueCustomerIDs(cmd.CustomerIDs)
+		if len(customerIDs) > maxSegmentMembers {
+			return nil, nil, fmt.Errorf("static segments may list at most %d customers", maxSegmentMembers)
+		}
+	case entity.SegmentTypeExternal:
+		if segment.ExternalRef == "" {
+			return nil, nil, errors.New("external segments require an external reference")
+		}
+		if len(cmd.CustomerIDs) > 0 {
+			return nil, nil, errors.New("external segments cannot list customers")
+		}
+	default:
+		return nil, nil, fmt.Errorf("unknown segment type '%s'", segment.Type)
+	}
+
+	existing, err := s.segmentRepo.FindByName(ctx, segment.Name)
+	if err != nil {
+		return nil, nil, err
+	}
+	if existing != nil && existing.ID != id {
+		return nil, nil, domainService.ErrDuplicateSegmentName
+	}
+
+	return segment, customerIDs, nil
+}
+
+// uniqueCustomerIDs trims customer IDs and drops blanks and repeats, keeping the first occurrence
+func uniqueCustomerIDs(customerIDs []string) []string {
+	seen := make(map[string]bool, len(customerIDs))
+	unique := make([]string, 0, len(customerIDs))
+	for _, customerID := range customerIDs {
+		customerID = strings.TrimSpace(customerID)
+		if customerID == "" || seen[customerID] {
+			continue
+		}
+		seen[customerID] = true
+		unique = append(unique, customerID)
+	}
+	return unique
+}
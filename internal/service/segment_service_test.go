@@ -0,0 +1,217 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/shoelfikar/voucher-management-system/internal/domain/entity"
+	domainService "github.com/shoelfikar/voucher-management-system/internal/domain/service"
+	"github.com/shoelfikar/voucher-management-system/pkg/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+// MockSegmentRepository is a mock implementation of SegmentRepository
+type MockSegmentRepository struct {
+	mock.Mock
+}
+
+func (m *MockSegmentRepository) FindAll(ctx context.Context, page, limit int, search string, sort []utils.SortField) ([]*entity.Segment, int64, error) {
+	args := m.Called(page, limit, search, sort)
+	return args.Get(0).([]*entity.Segment), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockSegmentRepository) FindByID(ctx context.Context, id uint) (*entity.Segment, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Segment), args.Error(1)
+}
+
+func (m *MockSegmentRepository) FindByName(ctx context.Context, name string) (*entity.Segment, error) {
+	args := m.Called(name)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Segment), args.Error(1)
+}
+
+func (m *MockSegmentRepository) Create(ctx context.Context, segment *entity.Segment, customerIDs []string) error {
+	args := m.Called(segment, customerIDs)
+	return args.Error(0)
+}
+
+func (m *MockSegmentRepository) Update(ctx context.Context, segment *entity.Segment, customerIDs []string) (int64, error) {
+	args := m.Called(segment, customerIDs)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockSegmentRepository) Delete(ctx context.Context, id uint) (int64, error) {
+	args := m.Called(id)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockSegmentRepository) IsMember(ctx context.Context, segmentID uint, customerID string) (bool, error) {
+	args := m.Called(segmentID, customerID)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockSegmentRepository) CountMembers(ctx context.Context, segmentID uint) (int64, error) {
+	args := m.Called(segmentID)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockSegmentRepository) CountVouchers(ctx context.Context, segmentID uint) (int64, error) {
+	args := m.Called(segmentID)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+// MockSegmentProvider is a mock implementation of segments.Provider
+type MockSegmentProvider struct {
+	mock.Mock
+}
+
+func (m *MockSegmentProvider) IsMember(ctx context.Context, ref, customerID string) (bool, error) {
+	args := m.Called(ref, customerID)
+	return args.Bool(0), args.Error(1)
+}
+
+func TestSegmentService_Create_StaticDeduplicatesCustomers(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockSegmentRepository)
+	segmentService := NewSegmentService(mockRepo, nil)
+
+	mockRepo.On("FindByName", "VIP").Return(nil, nil)
+	mockRepo.On("Create", mock.AnythingOfType("*entity.Segment"), []string{"cust-1", "cust-2"}).Return(nil)
+
+	// Act
+	segment, err := segmentService.Create(context.Background(), &domainService.SegmentCommand{
+		Name: " VIP ", Type: entity.SegmentTypeStatic, CustomerIDs: []string{"cust-1", " cust-2", "", "cust-1"}, CreatedBy: "admin@example.com",
+	})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "VIP", segment.Name)
+	assert.Equal(t, "admin@example.com", segment.CreatedBy)
+	assert.Equal(t, int64(2), segment.MemberCount)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestSegmentService_Create_Invalid(t *testing.T) {
+	testCases := []struct {
+		name string
+		cmd  domainService.SegmentCommand
+	}{
+		{"missing name", domainService.SegmentCommand{Type: entity.SegmentTypeStatic}},
+		{"unknown type", domainService.SegmentCommand{Name: "VIP", Type: "dynamic"}},
+		{"external without reference", domainService.SegmentCommand{Name: "VIP", Type: entity.SegmentTypeExternal}},
+		{"external with customers", domainService.SegmentCommand{Name: "VIP", Type: entity.SegmentTypeExternal, ExternalRef: "crm-vip", CustomerIDs: []string{"cust-1"}}},
+		{"static with reference", domainService.SegmentCommand{Name: "VIP", Type: entity.SegmentTypeStatic, ExternalRef: "crm-vip"}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			mockRepo := new(MockSegmentRepository)
+			segmentService := NewSegmentService(mockRepo, nil)
+
+			// Act
+			segment, err := segmentService.Create(context.Background(), &tc.cmd)
+
+			// Assert
+			assert.Error(t, err)
+			assert.Nil(t, segment)
+			mockRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestSegmentService_Update_DuplicateName(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockSegmentRepository)
+	segmentService := NewSegmentService(mockRepo, nil)
+
+	mockRepo.On("FindByName", "Gold").Return(&entity.Segment{ID: 2, Name: "Gold"}, nil)
+
+	// Act
+	segment, err := segmentService.Update(context.Background(), 1, &domainService.SegmentCommand{Name: "Gold", Type: entity.SegmentTypeStatic})
+
+	// Assert
+	assert.ErrorIs(t, err, domainService.ErrDuplicateSegmentName)
+	assert.Nil(t, segment)
+	mockRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+}
+
+func TestSegmentService_Delete_InUse(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockSegmentRepository)
+	segmentService := NewSegmentService(mockRepo, nil)
+
+	mockRepo.On("CountVouchers", uint(1)).Return(int64(3), nil)
+
+	// Act
+	err := segmentService.Delete(context.Background(), 1)
+
+	// Assert
+	assert.ErrorIs(t, err, domainService.ErrSegmentInUse)
+	mockRepo.AssertNotCalled(t, "Delete", mock.Anything)
+}
+
+func TestSegmentService_IsMember(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockSegmentRepository)
+	mockProvider := new(MockSegmentProvider)
+	segmentService := NewSegmentService(mockRepo, mockProvider)
+
+	mockRepo.On("FindByID", uint(1)).Return(&entity.Segment{ID: 1, Type: entity.SegmentTypeStatic}, nil)
+	mockRepo.On("FindByID", uint(2)).Return(&entity.Segment{ID: 2, Type: entity.SegmentTypeExternal, ExternalRef: "crm-vip"}, nil)
+	mockRepo.On("FindByID", uint(3)).Return(nil, gorm.ErrRecordNotFound)
+	mockRepo.On("IsMember", uint(1), "cust-1").Return(true, nil)
+	mockProvider.On("IsMember", "crm-vip", "cust-1").Return(false, nil)
+
+	// Act
+	static, staticErr := segmentService.IsMember(context.Background(), 1, "cust-1")
+	external, externalErr := segmentService.IsMember(context.Background(), 2, "cust-1")
+	_, missingErr := segmentService.IsMember(context.Background(), 3, "cust-1")
+
+	// Assert
+	assert.NoError(t, staticErr)
+	assert.True(t, static)
+	assert.NoError(t, externalErr)
+	assert.False(t, external)
+	assert.ErrorIs(t, missingErr, domainService.ErrSegmentNotFound)
+	mockProvider.AssertExpectations(t)
+}
+
+func TestSegmentService_IsMember_ExternalWithoutProvider(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockSegmentRepository)
+	segmentService := NewSegmentService(mockRepo, nil)
+
+	mockRepo.On("FindByID", uint(2)).Return(&entity.Segment{ID: 2, Type: entity.SegmentTypeExternal, ExternalRef: "crm-vip"}, nil)
+
+	// Act
+	_, err := segmentService.IsMember(context.Background(), 2, "cust-1")
+
+	// Assert
+	assert.ErrorIs(t, err, domainService.ErrSegmentProviderUnavailable)
+}
+
+func TestSegmentService_IsMember_ProviderError(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockSegmentRepository)
+	mockProvider := new(MockSegmentProvider)
+	segmentService := NewSegmentService(mockRepo, mockProvider)
+
+	mockRepo.On("FindByID", uint(2)).Return(&entity.Segment{ID: 2, Type: entity.SegmentTypeExternal, ExternalRef: "crm-vip"}, nil)
+	mockProvider.On("IsMember", "crm-vip", "cust-1").Return(false, errors.New("timeout"))
+
+	// Act
+	_, err := segmentService.IsMember(context.Background(), 2, "cust-1")
+
+	// Assert
+	assert.Error(t, err)
+}
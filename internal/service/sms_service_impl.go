@@ -0,0 +1,236 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/shoelfikar/voucher-management-system/internal/domain/entity"
+	"github.com/shoelfikar/voucher-management-system/internal/domain/repository"
+	domainService "github.com/shoelfikar/voucher-management-system/internal/domain/service"
+	"github.com/shoelfikar/voucher-management-system/pkg/logger"
+	"github.com/shoelfikar/voucher-management-system/pkg/sms"
+)
+
+const (
+	// smsDispatchBatchSize is the number of due deliveries loaded at a time by Dispatch
+	smsDispatchBatchSize = 100
+	// smsRetryBackoff is the wait before the first retry; it doubles with every failed attempt
+	smsRetryBackoff = time.Minute
+	// smsMaxRetryBackoff caps the wait between retries
+	smsMaxRetryBackoff = time.Hour
+)
+
+// e164Pattern matches phone numbers in E.164 format
+var e164Pattern = regexp.MustCompile(`^\+[1-9]\d{7,14}$`)
+
+// smsServiceImpl implements domain service.SMSService
+type smsServiceImpl struct {
+	deliveryRepo   repository.SMSDeliveryRepository
+	voucherService domainService.VoucherService
+	claimService   domainService.ClaimService
+	sender         sms.Sender
+	maxAttempts    int
+}
+
+// NewSMSService creates a new SMS service instance. A delivery is marked
+// failed after maxAttempts unsuccessful attempts.
+func NewSMSService(
+	deliveryRepo repository.SMSDeliveryRepository,
+	voucherService domainService.VoucherService,
+	claimService domainService.ClaimService,
+	sender sms.Sender,
+	maxAttempts int,
+) domainService.SMSService {
+	return &smsServiceImpl{
+		deliveryRepo:   deliveryRepo,
+		voucherService: voucherService,
+		claimService:   claimService,
+		sender:         sender,
+		maxAttempts:    maxAttempts,
+	}
+}
+
+// Send validates the recipients and queues their messages in one insert.
+// Claim link messages each get a link of their own.
+func (s *smsServiceImpl) Send(ctx context.Context, cmd *domainService.SendSMSCommand) ([]*entity.SMSDelivery, error) {
+	if cmd.Content != entity.SMSContentCode && cmd.Content != entity.SMSContentClaimLink {
+		return nil, fmt.Errorf("content must be %s or %s", entity.SMSContentCode, entity.SMSContentClaimLink)
+	}
+
+	recipients, err := normalizeSMSRecipients(cmd.Recipients)
+	if err != nil {
+		return nil, err
+	}
+
+	voucher, err := s.voucherService.GetByID(ctx, cmd.VoucherID)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	if voucher.Status != entity.VoucherStatusActive {
+		return nil, fmt.Errorf("%w: %s is %s", domainService.ErrVoucherNotAssignable, voucher.VoucherCode, voucher.Status)
+	}
+	if isExpired(voucher, now) {
+		return nil, fmt.Errorf("%w: %s has expired", domainService.ErrVoucherNotAssignable, voucher.VoucherCode)
+	}
+
+	bodies := make([]string, len(recipients))
+	if cmd.Content == entity.SMSContentClaimLink {
+		batch, err := s.claimService.CreateLinks(ctx, &domainService.CreateClaimLinksCommand{
+			VoucherID: voucher.ID,
+			Count:     len(recipients),
+			CreatedBy: cmd.CreatedBy,
+		})
+		if err != nil {
+			return nil, err
+		}
+		for i, link := range batch.URLs {
+			bodies[i] = claimLinkSMSBody(voucher, link)
+		}
+	} else {
+		for i := range bodies {
+			bodies[i] = voucherCodeSMSBody(voucher)
+		}
+	}
+
+	deliveries := make([]*entity.SMSDelivery, 0, len(recipients))
+	for i, recipient := range recipients {
+		deliveries = append(deliveries, &entity.SMSDelivery{
+			VoucherID:     voucher.ID,
+			PhoneNumber:   recipient.PhoneNumber,
+			CustomerID:    recipient.CustomerID,
+			Content:       cmd.Content,
+			Body:          bodies[i],
+			Status:        entity.SMSDeliveryStatusPending,
+			NextAttemptAt: now,
+			CreatedBy:     cmd.CreatedBy,
+		})
+	}
+	if err := s.deliveryRepo.CreateBatch(ctx, deliveries); err != nil {
+		return nil, fmt.Errorf("failed to queue SMS deliveries: %w", err)
+	}
+
+	logger.FromContext(ctx).Info("SMS deliveries queued", "voucher_id", voucher.ID, "deliveries", len(deliveries), "created_by", cmd.CreatedBy)
+	return deliveries, nil
+}
+
+// Dispatch sends due deliveries a batch at a time. A failed attempt is
+// retried with exponential backoff until the delivery runs out of attempts.
+func (s *smsServiceImpl) Dispatch(ctx context.Context) (*domainService.SMSDispatchResult, error) {
+	result := &domainService.SMSDispatchResult{}
+	now := time.Now()
+
+	for {
+		// A shutdown stops the run between batches; the rest stay due for the next one
+		if err := ctx.Err(); err != nil {
+			return result, err
+		}
+
+		deliveries, err := s.deliveryRepo.FindDue(ctx, now, smsDispatchBatchSize)
+		if err != nil {
+			return result, err
+		}
+
+		for _, delivery := range deliveries {
+			s.attempt(ctx, delivery, result)
+			if err := s.deliveryRepo.Update(ctx, delivery); err != nil {
+				return result, fmt.Errorf("failed to record SMS delivery %d: %w", delivery.ID, err)
+			}
+		}
+
+		if len(deliveries) < smsDispatchBatchSize {
+			break
+		}
+	}
+
+	if result.Sent > 0 || result.Retrying > 0 || result.Failed > 0 {
+		logger.FromContext(ctx).Info("SMS dispatch finished", "sent", result.Sent, "retrying", result.Retrying, "failed", result.Failed)
+	}
+	return result, nil
+}
+
+// attempt sends one delivery and records the outcome on it
+func (s *smsServiceImpl) attempt(ctx context.Context, delivery *entity.SMSDelivery, result *domainService.SMSDispatchResult) {
+	delivery.Attempts++
+	messageID, err := s.sender.Send(delivery.PhoneNumber, delivery.Body)
+	now := time.Now()
+
+	if err == nil {
+		delivery.Status = entity.SMSDeliveryStatusSent
+		delivery.ProviderMessageID = messageID
+		delivery.LastError = ""
+		delivery.SentAt = &now
+		result.Sent++
+		return
+	}
+
+	delivery.LastError = err.Error()
+	if delivery.Attempts >= s.maxAttempts {
+		delivery.Status = entity.SMSDeliveryStatusFailed
+		result.Failed++
+		logger.FromContext(ctx).Warn("SMS delivery failed", "sms_delivery_id", delivery.ID, "attempts", delivery.Attempts, "error", err)
+		return
+	}
+	delivery.NextAttemptAt = now.Add(smsRetryDelay(delivery.Attempts))
+	result.Retrying++
+}
+
+// GetDeliveries retrieves deliveries with pagination
+func (s *smsServiceImpl) GetDeliveries(ctx context.Context, voucherID uint, status string, page, limit int) ([]*entity.SMSDelivery, int64, error) {
+	if status != "" && !slices.Contains(entity.SMSDeliveryStatuses, status) {
+		return nil, 0, fmt.Errorf("status must be one of %s", strings.Join(entity.SMSDeliveryStatuses, ", "))
+	}
+	return s.deliveryRepo.FindAll(ctx, repository.SMSDeliveryFilter{VoucherID: voucherID, Status: status}, page, limit)
+}
+
+// normalizeSMSRecipients trims and validates the recipients, dropping repeated phone numbers
+func normalizeSMSRecipients(recipients []domainService.SMSRecipient) ([]domainService.SMSRecipient, error) {
+	if len(recipients) == 0 {
+		return nil, fmt.Errorf("at least one recipient is required")
+	}
+	if len(recipients) > domainService.MaxSMSRecipients {
+		return nil, fmt.Errorf("at most %d recipients can be sent to at once", domainService.MaxSMSRecipients)
+	}
+
+	seen := make(map[string]bool, len(recipients))
+	normalized := make([]domainService.SMSRecipient, 0, len(recipients))
+	for _, recipient := range recipients {
+		phone := strings.TrimSpace(recipient.PhoneNumber)
+		if !e164Pattern.MatchString(phone) {
+			return nil, fmt.Errorf("%w: %q", domainService.ErrInvalidPhoneNumber, phone)
+		}
+		if seen[phone] {
+			continue
+		}
+		seen[phone] = true
+		normalized = append(normalized, domainService.SMSRecipient{
+			PhoneNumber: phone,
+			CustomerID:  strings.TrimSpace(recipient.CustomerID),
+		})
+	}
+	return normalized, nil
+}
+
+// smsRetryDelay returns the wait after the given number of failed attempts
+func smsRetryDelay(attempts int) time.Duration {
+	delay := smsRetryBackoff
+	for i := 1; i < attempts && delay < smsMaxRetryBackoff; i++ {
+		delay *= 2
+	}
+	return min(delay, smsMaxRetryBackoff)
+}
+
+// voucherCodeSMSBody is the message text carrying a voucher's code
+func voucherCodeSMSBody(voucher *entity.Voucher) string {
+	return fmt.Sprintf("Your voucher code %s gives you %s off. Valid until %s.",
+		voucher.VoucherCode, formatDiscount(voucher), voucher.ExpiryDate.Format("2 Jan 2006"))
+}
+
+// claimLinkSMSBody is the message text carrying a claim link
+func claimLinkSMSBody(voucher *entity.Voucher, link string) string {
+	return fmt.Sprintf("You've got a %s off voucher! Claim it here: %s", formatDiscount(voucher), link)
+}
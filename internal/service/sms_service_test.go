@@ -0,0 +1,200 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/shoelfikar/voucher-management-system/internal/domain/entity"
+	"github.com/shoelfikar/voucher-management-system/internal/domain/repository"
+	domainService "github.com/shoelfikar/voucher-management-system/internal/domain/service"
+	jwtPkg "github.com/shoelfikar/voucher-management-system/pkg/jwt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockSMSDeliveryRepository is a mock implementation of SMSDeliveryRepository
+type MockSMSDeliveryRepository struct {
+	mock.Mock
+}
+
+func (m *MockSMSDeliveryRepository) CreateBatch(ctx context.Context, deliveries []*entity.SMSDelivery) error {
+	args := m.Called(deliveries)
+	return args.Error(0)
+}
+
+func (m *MockSMSDeliveryRepository) FindDue(ctx context.Context, now time.Time, limit int) ([]*entity.SMSDelivery, error) {
+	args := m.Called(now, limit)
+	return args.Get(0).([]*entity.SMSDelivery), args.Error(1)
+}
+
+func (m *MockSMSDeliveryRepository) Update(ctx context.Context, delivery *entity.SMSDelivery) error {
+	args := m.Called(delivery)
+	return args.Error(0)
+}
+
+func (m *MockSMSDeliveryRepository) FindAll(ctx context.Context, filter repository.SMSDeliveryFilter, page, limit int) ([]*entity.SMSDelivery, int64, error) {
+	args := m.Called(filter, page, limit)
+	return args.Get(0).([]*entity.SMSDelivery), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockSMSDeliveryRepository) Requeue(ctx context.Context, id uint, now time.Time) (int64, error) {
+	args := m.Called(id, now)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockSMSDeliveryRepository) Discard(ctx context.Context, id uint) (int64, error) {
+	args := m.Called(id)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+// MockSMSSender is a mock implementation of sms.Sender
+type MockSMSSender struct {
+	mock.Mock
+}
+
+func (m *MockSMSSender) Send(to, body string) (string, error) {
+	args := m.Called(to, body)
+	return args.String(0), args.Error(1)
+}
+
+func TestSMSService_Send_Code(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockVoucherRepository)
+	mockDeliveryRepo := new(MockSMSDeliveryRepository)
+	smsService := NewSMSService(mockDeliveryRepo, NewVoucherService(mockRepo, 0), nil, new(MockSMSSender), 3)
+
+	mockRepo.On("FindByID", uint(1)).Return(&entity.Voucher{ID: 1, VoucherCode: "WELCOME", DiscountPercent: 12.5, ExpiryDate: time.Now().AddDate(0, 1, 0), Status: entity.VoucherStatusActive}, nil)
+	mockDeliveryRepo.On("CreateBatch", mock.AnythingOfType("[]*entity.SMSDelivery")).Return(nil)
+
+	// Act
+	deliveries, err := smsService.Send(context.Background(), &domainService.SendSMSCommand{
+		VoucherID: 1,
+		Recipients: []domainService.SMSRecipient{
+			{PhoneNumber: " +14155550123 ", CustomerID: "cust-1"},
+			{PhoneNumber: "+14155550123", CustomerID: "cust-1"},
+			{PhoneNumber: "+447700900123"},
+		},
+		Content:   entity.SMSContentCode,
+		CreatedBy: "admin@example.com",
+	})
+
+	// Assert
+	assert.NoError(t, err)
+	if assert.Len(t, deliveries, 2) {
+		assert.Equal(t, "+14155550123", deliveries[0].PhoneNumber)
+		assert.Equal(t, entity.SMSDeliveryStatusPending, deliveries[0].Status)
+		assert.Contains(t, deliveries[0].Body, "WELCOME")
+		assert.Contains(t, deliveries[0].Body, "12.5% off")
+	}
+}
+
+func TestSMSService_Send_ClaimLinks(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockVoucherRepository)
+	mockLinkRepo := new(MockClaimLinkRepository)
+	mockDeliveryRepo := new(MockSMSDeliveryRepository)
+	voucherService := NewVoucherService(mockRepo, 0)
+	claimService := NewClaimService(mockLinkRepo, voucherService, NewCustomerService(new(MockCustomerRepository)), jwtPkg.NewJWTService("test-secret", time.Hour), testClaimLinks)
+	smsService := NewSMSService(mockDeliveryRepo, voucherService, claimService, new(MockSMSSender), 3)
+
+	mockRepo.On("FindByID", uint(1)).Return(&entity.Voucher{ID: 1, VoucherCode: "WELCOME", DiscountPercent: 10, ExpiryDate: time.Now().AddDate(0, 1, 0), Status: entity.VoucherStatusActive}, nil)
+	mockLinkRepo.On("CreateBatch", mock.MatchedBy(func(links []*entity.ClaimLink) bool { return len(links) == 2 })).Return(nil)
+	mockDeliveryRepo.On("CreateBatch", mock.AnythingOfType("[]*entity.SMSDelivery")).Return(nil)
+
+	// Act
+	deliveries, err := smsService.Send(context.Background(), &domainService.SendSMSCommand{
+		VoucherID:  1,
+		Recipients: []domainService.SMSRecipient{{PhoneNumber: "+14155550123"}, {PhoneNumber: "+14155550124"}},
+		Content:    entity.SMSContentClaimLink,
+	})
+
+	// Assert
+	assert.NoError(t, err)
+	if assert.Len(t, deliveries, 2) {
+		assert.Contains(t, deliveries[0].Body, testClaimLinks.URL+"?token=")
+		assert.NotContains(t, deliveries[0].Body, "WELCOME")
+		assert.NotEqual(t, deliveries[0].Body, deliveries[1].Body)
+	}
+	mockLinkRepo.AssertExpectations(t)
+}
+
+func TestSMSService_Send_Refused(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockVoucherRepository)
+	mockDeliveryRepo := new(MockSMSDeliveryRepository)
+	smsService := NewSMSService(mockDeliveryRepo, NewVoucherService(mockRepo, 0), nil, new(MockSMSSender), 3)
+
+	mockRepo.On("FindByID", uint(1)).Return(&entity.Voucher{ID: 1, VoucherCode: "OLD", ExpiryDate: time.Now().AddDate(0, 0, -1), Status: entity.VoucherStatusActive}, nil)
+	valid := []domainService.SMSRecipient{{PhoneNumber: "+14155550123"}}
+
+	// Act
+	_, phoneErr := smsService.Send(context.Background(), &domainService.SendSMSCommand{VoucherID: 1, Recipients: []domainService.SMSRecipient{{PhoneNumber: "0812345678"}}, Content: entity.SMSContentCode})
+	_, emptyErr := smsService.Send(context.Background(), &domainService.SendSMSCommand{VoucherID: 1, Content: entity.SMSContentCode})
+	_, contentErr := smsService.Send(context.Background(), &domainService.SendSMSCommand{VoucherID: 1, Recipients: valid, Content: "pdf"})
+	_, expiredErr := smsService.Send(context.Background(), &domainService.SendSMSCommand{VoucherID: 1, Recipients: valid, Content: entity.SMSContentCode})
+
+	// Assert
+	assert.ErrorIs(t, phoneErr, domainService.ErrInvalidPhoneNumber)
+	assert.Error(t, emptyErr)
+	assert.Error(t, contentErr)
+	assert.ErrorIs(t, expiredErr, domainService.ErrVoucherNotAssignable)
+	mockDeliveryRepo.AssertNotCalled(t, "CreateBatch", mock.Anything)
+}
+
+func TestSMSService_Dispatch(t *testing.T) {
+	// Arrange
+	mockDeliveryRepo := new(MockSMSDeliveryRepository)
+	mockSender := new(MockSMSSender)
+	smsService := NewSMSService(mockDeliveryRepo, nil, nil, mockSender, 3)
+
+	sent := &entity.SMSDelivery{ID: 1, PhoneNumber: "+14155550001", Body: "a", Status: entity.SMSDeliveryStatusPending}
+	retrying := &entity.SMSDelivery{ID: 2, PhoneNumber: "+14155550002", Body: "b", Status: entity.SMSDeliveryStatusPending, Attempts: 1}
+	exhausted := &entity.SMSDelivery{ID: 3, PhoneNumber: "+14155550003", Body: "c", Status: entity.SMSDeliveryStatusPending, Attempts: 2}
+	mockDeliveryRepo.On("FindDue", mock.AnythingOfType("time.Time"), smsDispatchBatchSize).Return([]*entity.SMSDelivery{sent, retrying, exhausted}, nil)
+	mockDeliveryRepo.On("Update", mock.AnythingOfType("*entity.SMSDelivery")).Return(nil)
+	mockSender.On("Send", "+14155550001", "a").Return("SM1", nil)
+	mockSender.On("Send", "+14155550002", "b").Return("", errors.New("carrier unavailable"))
+	mockSender.On("Send", "+14155550003", "c").Return("", errors.New("carrier unavailable"))
+
+	// Act
+	before := time.Now()
+	result, err := smsService.Dispatch(context.Background())
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, domainService.SMSDispatchResult{Sent: 1, Retrying: 1, Failed: 1}, *result)
+	assert.Equal(t, entity.SMSDeliveryStatusSent, sent.Status)
+	assert.Equal(t, "SM1", sent.ProviderMessageID)
+	assert.NotNil(t, sent.SentAt)
+	assert.Equal(t, entity.SMSDeliveryStatusPending, retrying.Status)
+	assert.Equal(t, 2, retrying.Attempts)
+	assert.True(t, retrying.NextAttemptAt.After(before.Add(smsRetryBackoff)))
+	assert.Equal(t, entity.SMSDeliveryStatusFailed, exhausted.Status)
+	assert.Contains(t, exhausted.LastError, "carrier unavailable")
+	mockDeliveryRepo.AssertNumberOfCalls(t, "Update", 3)
+}
+
+func TestSMSService_Dispatch_StopsWhenCanceled(t *testing.T) {
+	// Arrange
+	mockDeliveryRepo := new(MockSMSDeliveryRepository)
+	mockSender := new(MockSMSSender)
+	smsService := NewSMSService(mockDeliveryRepo, nil, nil, mockSender, 3)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	// Act
+	result, err := smsService.Dispatch(ctx)
+
+	// Assert
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, domainService.SMSDispatchResult{}, *result)
+	mockDeliveryRepo.AssertNotCalled(t, "FindDue", mock.Anything, mock.Anything)
+}
+
+func TestSMSRetryDelay(t *testing.T) {
+	assert.Equal(t, time.Minute, smsRetryDelay(1))
+	assert.Equal(t, 4*time.Minute, smsRetryDelay(3))
+	assert.Equal(t, smsMaxRetryBackoff, smsRetryDelay(20))
+}
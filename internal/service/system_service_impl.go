@@ -0,0 +1,190 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/shoelfikar/voucher-management-system/internal/domain/repository"
+	domainService "github.com/shoelfikar/voucher-management-system/internal/domain/service"
+	"github.com/shoelfikar/voucher-management-system/pkg/buildinfo"
+	"github.com/shoelfikar/voucher-management-system/pkg/logger"
+)
+
+// dependencyCheckTimeout bounds each dependency check so a hung dependency cannot stall the snapshot
+const dependencyCheckTimeout = 3 * time.Second
+
+// DependencyCheck checks one dependency of the service. A nil Run reports it as disabled.
+// Component is the name the public status shows it under; checks without one are left off it.
+type DependencyCheck struct {
+	Name      string
+	Component string
+	Run       func(ctx context.Context) error
+}
+
+// apiComponent is the public name of the API itself, which is down when its database is
+const apiComponent = "Voucher API"
+
+// ConfigReloader re-reads the settings that can change without a restart
+// and returns the names of those that changed
+type ConfigReloader interface {
+	Reload() ([]string, error)
+}
+
+// systemServiceImpl implements domain service.SystemService
+type systemServiceImpl struct {
+	systemRepo    repository.SystemRepository
+	configSummary func() map[string]interface{}
+	reloader      ConfigReloader
+	checks        []DependencyCheck
+	startedAt     time.Time
+	now           func() time.Time
+
+	statusMu sync.Mutex
+	status   *domainService.PublicStatus
+}
+
+// NewSystemService creates a new system service instance. configSummary is
+// called for every snapshot, so reloaded settings show up, and must already
+// have its secrets redacted. A nil reloader disables configuration reloads.
+// The database is always checked, before checks.
+func NewSystemService(systemRepo repository.SystemRepository, configSummary func() map[string]interface{}, reloader ConfigReloader, startedAt time.Time, checks ...DependencyCheck) domainService.SystemService {
+	return &systemServiceImpl{
+		systemRepo:    systemRepo,
+		configSummary: configSummary,
+		reloader:      reloader,
+		checks:        checks,
+		startedAt:     startedAt,
+		now:           time.Now,
+	}
+}
+
+// Info gathers the snapshot; failing parts are reported in it rather than returned as errors
+func (s *systemServiceImpl) Info(ctx context.Context) *domainService.SystemInfo {
+	uptime := s.now().Sub(s.startedAt).Truncate(time.Second)
+
+	info := &domainService.SystemInfo{
+		Build:         buildinfo.Get(),
+		StartedAt:     s.startedAt,
+		Uptime:        uptime.String(),
+		UptimeSeconds: int64(uptime.Seconds()),
+		Config:        s.configSummary(),
+		Migration:     s.migrationStatus(ctx),
+	}
+
+	for _, check := range s.allChecks() {
+		info.Dependencies = append(info.Dependencies, s.runCheck(ctx, check))
+	}
+	return info
+}
+
+// Status runs the checks that have a public component name, reusing the last
+// summary while it is fresh. The service is in an outage when the API's
+// database is down and degraded when any other component is.
+func (s *systemServiceImpl) Status(ctx context.Context) *domainService.PublicStatus {
+	s.statusMu.Lock()
+	defer s.statusMu.Unlock()
+
+	now := s.now()
+	if s.status != nil && now.Sub(s.status.UpdatedAt) < domainService.StatusCacheTTL {
+		return s.status
+	}
+
+	status := &domainService.PublicStatus{
+		Status:     domainService.StatusOperational,
+		Version:    buildinfo.Get().Version,
+		UpdatedAt:  now.UTC(),
+		Components: []domainService.ComponentStatus{},
+	}
+	for _, check := range s.allChecks() {
+		if check.Component == "" || check.Run == nil {
+			continue
+		}
+		component := domainService.ComponentStatus{Name: check.Component, Status: domainService.StatusOperational}
+		if s.runCheck(ctx, check).Status == domainService.DependencyStatusDown {
+			component.Status = domainService.StatusDegraded
+			switch {
+			case check.Component == apiComponent:
+				component.Status = domainService.StatusOutage
+				status.Status = domainService.StatusOutage
+			case status.Status == domainService.StatusOperational:
+				status.Status = domainService.StatusDegraded
+			}
+		}
+		status.Components = append(status.Components, component)
+	}
+
+	s.status = status
+	return status
+}
+
+// ReloadConfig reloads the configuration and logs who changed what
+func (s *systemServiceImpl) ReloadConfig(ctx context.Context, actor string) (*domainService.ConfigReload, error) {
+	if s.reloader == nil {
+		return nil, domainService.ErrConfigReloadDisabled
+	}
+
+	changed, err := s.reloader.Reload()
+	if err != nil {
+		return nil, fmt.Errorf("failed to reload configuration: %w", err)
+	}
+	if changed == nil {
+		changed = []string{}
+	}
+
+	logger.FromContext(ctx).Info("configuration reloaded", "reloaded_by", actor, "changed", changed)
+	return &domainService.ConfigReload{Changed: changed, ReloadedAt: s.now()}, nil
+}
+
+// migrationStatus reads the SQL migration version and the schema changes AutoMigrate has not applied
+func (s *systemServiceImpl) migrationStatus(ctx context.Context) domainService.MigrationStatus {
+	status := domainService.MigrationStatus{PendingSchemaChanges: []string{}}
+
+	version, dirty, ok, err := s.systemRepo.MigrationVersion(ctx)
+	if err != nil {
+		status.Error = err.Error()
+		return status
+	}
+	if ok {
+		status.Version = &version
+		status.Dirty = dirty
+	}
+
+	pending, err := s.systemRepo.PendingSchemaChanges(ctx)
+	if err != nil {
+		status.Error = err.Error()
+		return status
+	}
+	if pending != nil {
+		status.PendingSchemaChanges = pending
+	}
+	return status
+}
+
+// allChecks returns the database check followed by the configured ones
+func (s *systemServiceImpl) allChecks() []DependencyCheck {
+	return append([]DependencyCheck{{Name: "database", Component: apiComponent, Run: s.systemRepo.Ping}}, s.checks...)
+}
+
+// runCheck runs one dependency check with a timeout and times it
+func (s *systemServiceImpl) runCheck(ctx context.Context, check DependencyCheck) domainService.DependencyHealth {
+	health := domainService.DependencyHealth{Name: check.Name, Status: domainService.DependencyStatusDisabled}
+	if check.Run == nil {
+		return health
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, dependencyCheckTimeout)
+	defer cancel()
+
+	start := time.Now()
+	err := check.Run(ctx)
+	health.LatencyMs = time.Since(start).Milliseconds()
+	if err != nil {
+		health.Status = domainService.DependencyStatusDown
+		health.Error = err.Error()
+		return health
+	}
+	health.Status = domainService.DependencyStatusUp
+	return health
+}
@@ -0,0 +1,210 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	domainService "github.com/shoelfikar/voucher-management-system/internal/domain/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockSystemRepository is a mock implementation of SystemRepository
+type MockSystemRepository struct {
+	mock.Mock
+}
+
+func (m *MockSystemRepository) Ping(ctx context.Context) error {
+	args := m.Called(ctx)
+	return args.Error(0)
+}
+
+func (m *MockSystemRepository) MigrationVersion(ctx context.Context) (uint, bool, bool, error) {
+	args := m.Called()
+	return args.Get(0).(uint), args.Bool(1), args.Bool(2), args.Error(3)
+}
+
+func (m *MockSystemRepository) PendingSchemaChanges(ctx context.Context) ([]string, error) {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]string), args.Error(1)
+}
+
+// MockConfigReloader is a mock implementation of ConfigReloader
+type MockConfigReloader struct {
+	mock.Mock
+}
+
+func (m *MockConfigReloader) Reload() ([]string, error) {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func TestSystemService_Info(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockSystemRepository)
+	summary := map[string]interface{}{"server": map[string]interface{}{"port": "8080"}}
+	startedAt := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	systemService := NewSystemService(mockRepo, func() map[string]interface{} { return summary }, nil, startedAt,
+		DependencyCheck{Name: "smtp"},
+		DependencyCheck{Name: "segment provider", Run: func(ctx context.Context) error { return errors.New("connection refused") }},
+	).(*systemServiceImpl)
+	systemService.now = func() time.Time { return startedAt.Add(90*time.Minute + 500*time.Millisecond) }
+
+	mockRepo.On("Ping", mock.Anything).Return(nil)
+	mockRepo.On("MigrationVersion").Return(uint(17), false, true, nil)
+	mockRepo.On("PendingSchemaChanges").Return(nil, nil)
+
+	// Act
+	info := systemService.Info(context.Background())
+
+	// Assert
+	assert.Equal(t, "1h30m0s", info.Uptime)
+	assert.Equal(t, int64(5400), info.UptimeSeconds)
+	assert.Equal(t, summary, info.Config)
+	assert.NotEmpty(t, info.Build.GoVersion)
+	assert.Equal(t, uint(17), *info.Migration.Version)
+	assert.Equal(t, []string{}, info.Migration.PendingSchemaChanges)
+	assert.Len(t, info.Dependencies, 3)
+	assert.Equal(t, "database", info.Dependencies[0].Name)
+	assert.Equal(t, domainService.DependencyStatusUp, info.Dependencies[0].Status)
+	assert.Equal(t, domainService.DependencyStatusDisabled, info.Dependencies[1].Status)
+	assert.Equal(t, domainService.DependencyStatusDown, info.Dependencies[2].Status)
+	assert.Equal(t, "connection refused", info.Dependencies[2].Error)
+}
+
+func TestSystemService_Info_ReportsDatabaseFailures(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockSystemRepository)
+	systemService := NewSystemService(mockRepo, func() map[string]interface{} { return nil }, nil, time.Now())
+
+	mockRepo.On("Ping", mock.Anything).Return(errors.New("database is down"))
+	mockRepo.On("MigrationVersion").Return(uint(0), false, false, errors.New("database is down"))
+
+	// Act
+	info := systemService.Info(context.Background())
+
+	// Assert
+	assert.Nil(t, info.Migration.Version)
+	assert.Equal(t, "database is down", info.Migration.Error)
+	assert.Equal(t, domainService.DependencyStatusDown, info.Dependencies[0].Status)
+	mockRepo.AssertNotCalled(t, "PendingSchemaChanges")
+}
+
+func TestSystemService_Status(t *testing.T) {
+	smsDown := DependencyCheck{Name: "sms-dispatch job", Component: "SMS delivery", Run: func(ctx context.Context) error { return errors.New("gateway timeout") }}
+	tests := []struct {
+		name           string
+		pingErr        error
+		checks         []DependencyCheck
+		wantStatus     string
+		wantComponents []domainService.ComponentStatus
+	}{
+		{
+			name: "operational",
+			checks: []DependencyCheck{
+				{Name: "smtp", Component: "Email delivery", Run: func(ctx context.Context) error { return nil }},
+				{Name: "segment provider", Component: "Customer segments"},
+				{Name: "internal only", Run: func(ctx context.Context) error { return errors.New("down") }},
+			},
+			wantStatus: domainService.StatusOperational,
+			wantComponents: []domainService.ComponentStatus{
+				{Name: "Voucher API", Status: domainService.StatusOperational},
+				{Name: "Email delivery", Status: domainService.StatusOperational},
+			},
+		},
+		{
+			name:       "degraded component",
+			checks:     []DependencyCheck{smsDown},
+			wantStatus: domainService.StatusDegraded,
+			wantComponents: []domainService.ComponentStatus{
+				{Name: "Voucher API", Status: domainService.StatusOperational},
+				{Name: "SMS delivery", Status: domainService.StatusDegraded},
+			},
+		},
+		{
+			name:       "database down",
+			pingErr:    errors.New("connection refused"),
+			checks:     []DependencyCheck{smsDown},
+			wantStatus: domainService.StatusOutage,
+			wantComponents: []domainService.ComponentStatus{
+				{Name: "Voucher API", Status: domainService.StatusOutage},
+				{Name: "SMS delivery", Status: domainService.StatusDegraded},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			mockRepo := new(MockSystemRepository)
+			systemService := NewSystemService(mockRepo, func() map[string]interface{} { return nil }, nil, time.Now(), tt.checks...)
+			mockRepo.On("Ping", mock.Anything).Return(tt.pingErr)
+
+			// Act
+			status := systemService.Status(context.Background())
+
+			// Assert
+			assert.Equal(t, tt.wantStatus, status.Status)
+			assert.NotEmpty(t, status.Version)
+			assert.Equal(t, tt.wantComponents, status.Components)
+		})
+	}
+}
+
+func TestSystemService_Status_IsCached(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockSystemRepository)
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	systemService := NewSystemService(mockRepo, func() map[string]interface{} { return nil }, nil, now).(*systemServiceImpl)
+	systemService.now = func() time.Time { return now }
+	mockRepo.On("Ping", mock.Anything).Return(nil).Once()
+	mockRepo.On("Ping", mock.Anything).Return(errors.New("connection refused")).Once()
+
+	// Act
+	first := systemService.Status(context.Background())
+	now = now.Add(domainService.StatusCacheTTL - time.Second)
+	cached := systemService.Status(context.Background())
+	now = now.Add(time.Second)
+	refreshed := systemService.Status(context.Background())
+
+	// Assert
+	assert.Same(t, first, cached)
+	assert.Equal(t, domainService.StatusOperational, cached.Status)
+	assert.Equal(t, domainService.StatusOutage, refreshed.Status)
+	mockRepo.AssertNumberOfCalls(t, "Ping", 2)
+}
+
+func TestSystemService_ReloadConfig(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockSystemRepository)
+	mockReloader := new(MockConfigReloader)
+	systemService := NewSystemService(mockRepo, func() map[string]interface{} { return nil }, mockReloader, time.Now())
+	disabled := NewSystemService(mockRepo, func() map[string]interface{} { return nil }, nil, time.Now())
+
+	mockReloader.On("Reload").Return([]string{"THROTTLE_CLAIM_LIMIT"}, nil).Once()
+	mockReloader.On("Reload").Return(nil, nil).Once()
+	mockReloader.On("Reload").Return(nil, errors.New("invalid duration")).Once()
+
+	// Act
+	changed, err := systemService.ReloadConfig(context.Background(), "admin@example.com")
+	unchanged, unchangedErr := systemService.ReloadConfig(context.Background(), "SIGHUP")
+	_, failedErr := systemService.ReloadConfig(context.Background(), "SIGHUP")
+	_, disabledErr := disabled.ReloadConfig(context.Background(), "admin@example.com")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"THROTTLE_CLAIM_LIMIT"}, changed.Changed)
+	assert.False(t, changed.ReloadedAt.IsZero())
+	assert.NoError(t, unchangedErr)
+	assert.Equal(t, []string{}, unchanged.Changed)
+	assert.ErrorContains(t, failedErr, "invalid duration")
+	assert.ErrorIs(t, disabledErr, domainService.ErrConfigReloadDisabled)
+}
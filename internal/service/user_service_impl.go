@@ -0,0 +1,224 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"slices"
+	"time"
+
+	"github.com/shoelfikar/voucher-management-system/internal/domain/entity"
+	"github.com/shoelfikar/voucher-management-system/internal/domain/repository"
+	domainService "github.com/shoelfikar/voucher-management-system/internal/domain/service"
+	"github.com/shoelfikar/voucher-management-system/pkg/jwt"
+	"github.com/shoelfikar/voucher-management-system/pkg/logger"
+	"github.com/shoelfikar/voucher-management-system/pkg/mailer"
+	"github.com/shoelfikar/voucher-management-system/pkg/password"
+	"gorm.io/gorm"
+)
+
+// Purposes of the signed action tokens sent by email
+const (
+	invitePurpose      = "invite"
+	verifyEmailPurpose = "verify_email"
+)
+
+// EmailLinks configures the links sent by email. Each link gets the signed
+// token appended as the "token" query parameter.
+type EmailLinks struct {
+	InviteURL              string
+	InviteExpiration       time.Duration
+	VerificationURL        string
+	VerificationExpiration time.Duration
+}
+
+// EmailRenderer fills in the editable wording of an email
+type EmailRenderer interface {
+	Render(ctx context.Context, key string, vars map[string]string) (*domainService.RenderedEmail, error)
+}
+
+// userServiceImpl implements domain service.UserService
+type userServiceImpl struct {
+	userRepo   repository.UserRepository
+	jwtService jwt.JWTService
+	hasher     password.Hasher
+	mailer     mailer.Mailer
+	emails     EmailRenderer
+	links      EmailLinks
+}
+
+// NewUserService creates a new user service instance; with a nil emails the
+// built-in wording is always sent
+func NewUserService(
+	userRepo repository.UserRepository,
+	jwtService jwt.JWTService,
+	hasher password.Hasher,
+	mailer mailer.Mailer,
+	emails EmailRenderer,
+	links EmailLinks,
+) domainService.UserService {
+	return &userServiceImpl{
+		userRepo:   userRepo,
+		jwtService: jwtService,
+		hasher:     hasher,
+		mailer:     mailer,
+		emails:     emails,
+		links:      links,
+	}
+}
+
+// Invite emails a signed invitation link for a new account
+func (s *userServiceImpl) Invite(ctx context.Context, email, role, invitedBy string) error {
+	if !slices.Contains(entity.UserRoles, role) {
+		return fmt.Errorf("invalid role '%s'", role)
+	}
+
+	existing, err := s.findUser(ctx, email)
+	if err != nil {
+		return err
+	}
+	if existing != nil {
+		return fmt.Errorf("user '%s' already exists", email)
+	}
+
+	link, err := s.tokenLink(s.links.InviteURL, invitePurpose, email, role, s.links.InviteExpiration)
+	if err != nil {
+		return err
+	}
+
+	if err := s.sendEmail(ctx, email, entity.EmailTemplateInvite, map[string]string{
+		"invited_by": invitedBy,
+		"role":       role,
+		"link":       link,
+		"expires_in": s.links.InviteExpiration.String(),
+	}); err != nil {
+		return err
+	}
+
+	logger.FromContext(ctx).Info("user invited", "email", email, "role", role, "invited_by", invitedBy)
+
+	return nil
+}
+
+// AcceptInvite creates the invited account and returns an access token
+func (s *userServiceImpl) AcceptInvite(ctx context.Context, token, password string) (string, *entity.User, error) {
+	claims, err := s.jwtService.ValidateActionToken(token, invitePurpose)
+	if err != nil {
+		return "", nil, errors.New("invalid or expired invitation")
+	}
+
+	// The account is created once; a second use of the same link is rejected
+	existing, err := s.findUser(ctx, claims.Email)
+	if err != nil {
+		return "", nil, err
+	}
+	if existing != nil {
+		return "", nil, errors.New("invitation has already been accepted")
+	}
+
+	hashedPassword, err := s.hasher.Hash(password)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to hash password: %w", err)
+	}
+
+	// Following the emailed link proves ownership of the address
+	verifiedAt := time.Now()
+	user := &entity.User{
+		Email:           claims.Email,
+		Password:        hashedPassword,
+		Role:            claims.Role,
+		EmailVerifiedAt: &verifiedAt,
+	}
+	if err := s.userRepo.Create(ctx, user); err != nil {
+		return "", nil, fmt.Errorf("failed to create user: %w", err)
+	}
+
+	accessToken, err := s.jwtService.GenerateToken(user.Email, user.Role)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return accessToken, user, nil
+}
+
+// SendVerificationEmail emails a signed link that confirms the user owns the address
+func (s *userServiceImpl) SendVerificationEmail(ctx context.Context, email string) error {
+	link, err := s.tokenLink(s.links.VerificationURL, verifyEmailPurpose, email, "", s.links.VerificationExpiration)
+	if err != nil {
+		return err
+	}
+
+	return s.sendEmail(ctx, email, entity.EmailTemplateVerifyEmail, map[string]string{
+		"link":       link,
+		"expires_in": s.links.VerificationExpiration.String(),
+	})
+}
+
+// VerifyEmail marks the account in a verification token as verified
+func (s *userServiceImpl) VerifyEmail(ctx context.Context, token string) error {
+	claims, err := s.jwtService.ValidateActionToken(token, verifyEmailPurpose)
+	if err != nil {
+		return errors.New("invalid or expired verification link")
+	}
+
+	user, err := s.findUser(ctx, claims.Email)
+	if err != nil {
+		return err
+	}
+	if user == nil {
+		return errors.New("invalid or expired verification link")
+	}
+
+	// Verifying twice is harmless; only the first click records a timestamp
+	if _, err := s.userRepo.MarkEmailVerified(ctx, claims.Email, time.Now()); err != nil {
+		return fmt.Errorf("failed to verify email: %w", err)
+	}
+
+	return nil
+}
+
+// tokenLink builds baseURL with a signed action token appended as ?token=
+func (s *userServiceImpl) tokenLink(baseURL, purpose, email, role string, ttl time.Duration) (string, error) {
+	token, err := s.jwtService.GenerateActionToken(purpose, email, role, ttl)
+	if err != nil {
+		return "", err
+	}
+
+	link, err := url.Parse(baseURL)
+	if err != nil {
+		return "", fmt.Errorf("invalid link URL: %w", err)
+	}
+	query := link.Query()
+	query.Set("token", token)
+	link.RawQuery = query.Encode()
+
+	return link.String(), nil
+}
+
+// sendEmail renders an editable email and sends it to the given address
+func (s *userServiceImpl) sendEmail(ctx context.Context, to, key string, vars map[string]string) error {
+	var email *domainService.RenderedEmail
+	var err error
+	if s.emails != nil {
+		email, err = s.emails.Render(ctx, key, vars)
+	} else {
+		email, err = renderDefaultEmail(key, vars)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to render %s email: %w", key, err)
+	}
+	return s.mailer.Send(to, email.Subject, email.Body)
+}
+
+// findUser returns the user with the given email, or nil if there is none
+func (s *userServiceImpl) findUser(ctx context.Context, email string) (*entity.User, error) {
+	user, err := s.userRepo.FindByEmail(ctx, email)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return user, nil
+}
@@ -0,0 +1,210 @@
+package service
+
+import (
+	"context"
+	"net/url"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/shoelfikar/voucher-management-system/internal/domain/entity"
+	jwtPkg "github.com/shoelfikar/voucher-management-system/pkg/jwt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
+)
+
+// MockMailer is a mock implementation of mailer.Mailer
+type MockMailer struct {
+	mock.Mock
+}
+
+func (m *MockMailer) Send(to, subject, body string) error {
+	args := m.Called(to, subject, body)
+	return args.Error(0)
+}
+
+var testEmailLinks = EmailLinks{
+	InviteURL:              "http://localhost:5173/accept-invite",
+	InviteExpiration:       time.Hour,
+	VerificationURL:        "http://localhost:8080/api/v1/auth/verify-email",
+	VerificationExpiration: time.Hour,
+}
+
+var linkPattern = regexp.MustCompile(`https?://\S+`)
+
+// tokenFromBody extracts the token from the link in an email body
+func tokenFromBody(t *testing.T, body string) string {
+	link, err := url.Parse(linkPattern.FindString(body))
+	if err != nil {
+		t.Fatalf("Email has no valid link: %v", err)
+	}
+	return link.Query().Get("token")
+}
+
+func TestUserService_Invite_Success(t *testing.T) {
+	// Arrange
+	mockUserRepo := new(MockUserRepository)
+	mockMailer := new(MockMailer)
+	jwtService := jwtPkg.NewJWTService("test-secret", time.Hour)
+	userService := NewUserService(mockUserRepo, jwtService, testHasher, mockMailer, nil, testEmailLinks)
+
+	mockUserRepo.On("FindByEmail", "new@example.com").Return(nil, gorm.ErrRecordNotFound)
+	mockMailer.On("Send", "new@example.com", mock.Anything, mock.Anything).Return(nil)
+
+	// Act
+	err := userService.Invite(context.Background(), "new@example.com", entity.UserRoleViewer, "admin@example.com")
+
+	// Assert
+	assert.NoError(t, err)
+	body := mockMailer.Calls[0].Arguments.String(2)
+	claims, err := jwtService.ValidateActionToken(tokenFromBody(t, body), invitePurpose)
+	assert.NoError(t, err)
+	assert.Equal(t, "new@example.com", claims.Email)
+	assert.Equal(t, entity.UserRoleViewer, claims.Role)
+	mockUserRepo.AssertExpectations(t)
+	mockMailer.AssertExpectations(t)
+}
+
+func TestUserService_Invite_UsesSavedTemplate(t *testing.T) {
+	// Arrange
+	mockUserRepo := new(MockUserRepository)
+	mockMailer := new(MockMailer)
+	mockTemplateRepo := new(MockEmailTemplateRepository)
+	jwtService := jwtPkg.NewJWTService("test-secret", time.Hour)
+	emails := NewEmailTemplateService(mockTemplateRepo, mockMailer)
+	userService := NewUserService(mockUserRepo, jwtService, testHasher, mockMailer, emails, testEmailLinks)
+
+	mockUserRepo.On("FindByEmail", "new@example.com").Return(nil, gorm.ErrRecordNotFound)
+	mockTemplateRepo.On("FindLatest", entity.EmailTemplateInvite).
+		Return(&entity.EmailTemplate{Version: 1, Subject: "Join us as {{.role}}", Body: "Welcome! {{.link}}"}, nil)
+	mockMailer.On("Send", "new@example.com", "Join us as viewer", mock.MatchedBy(func(body string) bool {
+		return strings.HasPrefix(body, "Welcome! http://localhost:5173/accept-invite?token=")
+	})).Return(nil)
+
+	// Act
+	err := userService.Invite(context.Background(), "new@example.com", entity.UserRoleViewer, "admin@example.com")
+
+	// Assert
+	assert.NoError(t, err)
+	mockMailer.AssertExpectations(t)
+}
+
+func TestUserService_Invite_ExistingUser(t *testing.T) {
+	// Arrange
+	mockUserRepo := new(MockUserRepository)
+	mockMailer := new(MockMailer)
+	jwtService := jwtPkg.NewJWTService("test-secret", time.Hour)
+	userService := NewUserService(mockUserRepo, jwtService, testHasher, mockMailer, nil, testEmailLinks)
+
+	mockUserRepo.On("FindByEmail", "existing@example.com").Return(&entity.User{Email: "existing@example.com"}, nil)
+
+	// Act
+	err := userService.Invite(context.Background(), "existing@example.com", entity.UserRoleAdmin, "admin@example.com")
+
+	// Assert
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "already exists")
+	mockMailer.AssertNotCalled(t, "Send", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestUserService_AcceptInvite_Success(t *testing.T) {
+	// Arrange
+	mockUserRepo := new(MockUserRepository)
+	jwtService := jwtPkg.NewJWTService("test-secret", time.Hour)
+	userService := NewUserService(mockUserRepo, jwtService, testHasher, new(MockMailer), nil, testEmailLinks)
+
+	inviteToken, _ := jwtService.GenerateActionToken(invitePurpose, "new@example.com", entity.UserRoleViewer, time.Hour)
+
+	mockUserRepo.On("FindByEmail", "new@example.com").Return(nil, gorm.ErrRecordNotFound)
+	mockUserRepo.On("Create", mock.AnythingOfType("*entity.User")).Return(nil)
+
+	// Act
+	accessToken, user, err := userService.AcceptInvite(context.Background(), inviteToken, "secret123")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.NotEmpty(t, accessToken)
+	assert.Equal(t, "new@example.com", user.Email)
+	assert.Equal(t, entity.UserRoleViewer, user.Role)
+	assert.NoError(t, bcrypt.CompareHashAndPassword([]byte(user.Password), []byte("secret123")))
+	mockUserRepo.AssertExpectations(t)
+}
+
+func TestUserService_AcceptInvite_AccessTokenRejected(t *testing.T) {
+	// Arrange
+	mockUserRepo := new(MockUserRepository)
+	jwtService := jwtPkg.NewJWTService("test-secret", time.Hour)
+	userService := NewUserService(mockUserRepo, jwtService, testHasher, new(MockMailer), nil, testEmailLinks)
+
+	accessToken, _ := jwtService.GenerateToken("someone@example.com", entity.UserRoleViewer)
+
+	// Act
+	_, user, err := userService.AcceptInvite(context.Background(), accessToken, "secret123")
+
+	// Assert
+	assert.Error(t, err)
+	assert.Nil(t, user)
+	mockUserRepo.AssertNotCalled(t, "Create", mock.Anything)
+}
+
+func TestUserService_AcceptInvite_AlreadyAccepted(t *testing.T) {
+	// Arrange
+	mockUserRepo := new(MockUserRepository)
+	jwtService := jwtPkg.NewJWTService("test-secret", time.Hour)
+	userService := NewUserService(mockUserRepo, jwtService, testHasher, new(MockMailer), nil, testEmailLinks)
+
+	inviteToken, _ := jwtService.GenerateActionToken(invitePurpose, "new@example.com", entity.UserRoleAdmin, time.Hour)
+
+	mockUserRepo.On("FindByEmail", "new@example.com").Return(&entity.User{Email: "new@example.com"}, nil)
+
+	// Act
+	_, user, err := userService.AcceptInvite(context.Background(), inviteToken, "secret123")
+
+	// Assert
+	assert.Error(t, err)
+	assert.Nil(t, user)
+	assert.Contains(t, err.Error(), "already been accepted")
+	mockUserRepo.AssertNotCalled(t, "Create", mock.Anything)
+}
+
+func TestUserService_VerifyEmail_Success(t *testing.T) {
+	// Arrange
+	mockUserRepo := new(MockUserRepository)
+	mockMailer := new(MockMailer)
+	jwtService := jwtPkg.NewJWTService("test-secret", time.Hour)
+	userService := NewUserService(mockUserRepo, jwtService, testHasher, mockMailer, nil, testEmailLinks)
+
+	mockMailer.On("Send", "new@example.com", mock.Anything, mock.Anything).Return(nil)
+	mockUserRepo.On("FindByEmail", "new@example.com").Return(&entity.User{Email: "new@example.com"}, nil)
+	mockUserRepo.On("MarkEmailVerified", "new@example.com", mock.AnythingOfType("time.Time")).Return(int64(1), nil)
+
+	err := userService.SendVerificationEmail(context.Background(), "new@example.com")
+	assert.NoError(t, err)
+	token := tokenFromBody(t, mockMailer.Calls[0].Arguments.String(2))
+
+	// Act
+	err = userService.VerifyEmail(context.Background(), token)
+
+	// Assert
+	assert.NoError(t, err)
+	mockUserRepo.AssertExpectations(t)
+}
+
+func TestUserService_VerifyEmail_InviteTokenRejected(t *testing.T) {
+	// Arrange
+	mockUserRepo := new(MockUserRepository)
+	jwtService := jwtPkg.NewJWTService("test-secret", time.Hour)
+	userService := NewUserService(mockUserRepo, jwtService, testHasher, new(MockMailer), nil, testEmailLinks)
+
+	inviteToken, _ := jwtService.GenerateActionToken(invitePurpose, "new@example.com", entity.UserRoleAdmin, time.Hour)
+
+	// Act
+	err := userService.VerifyEmail(context.Background(), inviteToken)
+
+	// Assert
+	assert.Error(t, err)
+	mockUserRepo.AssertNotCalled(t, "MarkEmailVerified", mock.Anything, mock.Anything)
+}
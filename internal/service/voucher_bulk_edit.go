@@ -0,0 +1,301 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/shoelfikar/voucher-management-system/internal/domain/entity"
+	"github.com/shoelfikar/voucher-management-system/internal/domain/repository"
+	domainService "github.com/shoelfikar/voucher-management-system/internal/domain/service"
+	"github.com/shoelfikar/voucher-management-system/pkg/logger"
+)
+
+// bulkEditChunkSize bounds the vouchers updated per statement by a bulk edit
+const bulkEditChunkSize = 500
+
+// jsonPatchOperation is one operation of a JSON Patch document
+type jsonPatchOperation struct {
+	Op    string          `json:"op"`
+	Path  string          `json:"path"`
+	Value json.RawMessage `json:"value"`
+}
+
+// bulkEditAudit is the audit entry detail recorded for each chunk of a bulk edit
+type bulkEditAudit struct {
+	Filter     domainService.BulkEditFilter `json:"filter"`
+	Changes    map[string]interface{}       `json:"changes"`
+	VoucherIDs []uint                       `json:"voucher_ids"`
+	Updated    int64                        `json:"updated"`
+}
+
+// BulkEdit applies a patch to every voucher matching the filter. Vouchers are
+// updated a chunk at a time so a large edit does not hold one long-running
+// UPDATE; each chunk gets its own audit entry.
+func (s *voucherServiceImpl) BulkEdit(ctx context.Context, cmd *domainService.BulkEditCommand) (*domainService.BulkEditResult, error) {
+	filter, err := bulkEditRepositoryFilter(cmd.Filter)
+	if err != nil {
+		return nil, err
+	}
+
+	fields, err := parseVoucherPatch(cmd.Patch)
+	if err != nil {
+		return nil, err
+	}
+	columns, sendForApproval, err := s.bulkEditColumns(ctx, fields, cmd.Role)
+	if err != nil {
+		return nil, err
+	}
+
+	// Vouchers awaiting approval can only be activated by approving them
+	if columns["status"] == entity.VoucherStatusActive {
+		filter.ExcludeStatus = entity.VoucherStatusPendingApproval
+	}
+	// A new percent only applies to vouchers that take a percentage off
+	if _, ok := columns["discount_percent"]; ok {
+		filter.DiscountType = entity.DiscountTypePercent
+	}
+
+	result := &domainService.BulkEditResult{Fields: make([]string, 0, len(columns))}
+	for field := range columns {
+		result.Fields = append(result.Fields, field)
+	}
+	sort.Strings(result.Fields)
+
+	// The editor becomes the last to change the vouchers, and so may not approve them
+	updates := maps.Clone(columns)
+	updates["updated_by"] = cmd.EditedBy
+
+	var afterID uint
+	for {
+		ids, err := s.voucherRepo.FindIDsByFilter(ctx, filter, afterID, bulkEditChunkSize)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find vouchers: %w", err)
+		}
+		if len(ids) == 0 {
+			break
+		}
+
+		var rows int64
+		var pendingIDs []uint
+		if sendForApproval {
+			rows, pendingIDs, err = s.voucherRepo.UpdateFieldsForApproval(ctx, ids, updates)
+		} else {
+			rows, err = s.voucherRepo.UpdateFields(ctx, ids, updates)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to update vouchers: %w", err)
+		}
+		result.Updated += rows
+		result.Chunks++
+
+		if err := s.auditBulkEdit(ctx, cmd, columns, ids, rows); err != nil {
+			return nil, err
+		}
+		if columns["status"] == entity.VoucherStatusPendingApproval {
+			pendingIDs = ids
+		}
+		s.requestApproval(ctx, pendingIDs, cmd.EditedBy)
+
+		if len(ids) < bulkEditChunkSize {
+			break
+		}
+		afterID = ids[len(ids)-1]
+	}
+
+	logger.FromContext(ctx).Info("vouchers bulk edited", "fields", result.Fields, "edited_by", cmd.EditedBy, "updated", result.Updated)
+	return result, nil
+}
+
+// auditBulkEdit records one chunk of a bulk edit, when auditing is enabled
+func (s *voucherServiceImpl) auditBulkEdit(ctx context.Context, cmd *domainService.BulkEditCommand, columns map[string]interface{}, ids []uint, rows int64) error {
+	if s.auditRepo == nil {
+		return nil
+	}
+	details, err := json.Marshal(bulkEditAudit{Filter: cmd.Filter, Changes: columns, VoucherIDs: ids, Updated: rows})
+	if err != nil {
+		return err
+	}
+	entry := &entity.AuditEntry{
+		Action:  entity.AuditActionVoucherBulkEdit,
+		Actor:   cmd.EditedBy,
+		Details: string(details),
+	}
+	if err := s.auditRepo.Create(ctx, entry); err != nil {
+		return fmt.Errorf("failed to record audit entry: %w", err)
+	}
+	return nil
+}
+
+// bulkEditColumns validates the patched fields and converts them to the
+// voucher columns to set. Raising the discount above the approval threshold
+// sends the vouchers back for approval, as a single update does: the ones
+// the edit activates always, and otherwise only those that are active, which
+// sendForApproval reports. Deactivated vouchers stay inactive, so approving
+// cannot reactivate them.
+func (s *voucherServiceImpl) bulkEditColumns(ctx context.Context, fields map[string]json.RawMessage, role string) (map[string]interface{}, bool, error) {
+	names := make([]string, 0, len(fields))
+	for name := range fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	columns := make(map[string]interface{}, len(fields))
+	for _, name := range names {
+		raw := fields[name]
+		switch name {
+		case "status":
+			var status string
+			if err := decodePatchValue(name, raw, &status); err != nil {
+				return nil, false, err
+			}
+			if status != entity.VoucherStatusActive && status != entity.VoucherStatusInactive {
+				return nil, false, fmt.Errorf("status must be %s or %s", entity.VoucherStatusActive, entity.VoucherStatusInactive)
+			}
+			columns[name] = status
+		case "discount_percent":
+			var percent float64
+			if err := decodePatchValue(name, raw, &percent); err != nil {
+				return nil, false, err
+			}
+			if err := validateDiscountPercent(percent); err != nil {
+				return nil, false, err
+			}
+			if err := s.checkDiscountLimit(ctx, role, voucherDiscount{discountType: entity.DiscountTypePercent, percent: percent}); err != nil {
+				return nil, false, err
+			}
+			columns[name] = percent
+		case "expiry_date":
+			var date string
+			if err := decodePatchValue(name, raw, &date); err != nil {
+				return nil, false, err
+			}
+			expiryDate, err := time.Parse("2006-01-02", date)
+			if err != nil {
+				return nil, false, errors.New("invalid date format, expected YYYY-MM-DD")
+			}
+			if isPastDate(expiryDate, time.Now()) {
+				return nil, false, errors.New("expiry date must be today or in the future")
+			}
+			columns[name] = expiryDate
+		case "validity_days":
+			var days *int
+			if err := decodePatchValue(name, raw, &days); err != nil {
+				return nil, false, err
+			}
+			if err := validateValidityDays(days); err != nil {
+				return nil, false, err
+			}
+			columns[name] = days
+		case "display_name", "description", "terms_url":
+			var text string
+			if err := decodePatchValue(name, raw, &text); err != nil {
+				return nil, false, err
+			}
+			display := map[string]string{name: text}
+			if err := validateDisplayMetadata(display["display_name"], display["description"], display["terms_url"]); err != nil {
+				return nil, false, err
+			}
+			columns[name] = text
+		case "segment_id":
+			var segmentID *uint
+			if err := decodePatchValue(name, raw, &segmentID); err != nil {
+				return nil, false, err
+			}
+			if err := s.checkSegment(ctx, segmentID); err != nil {
+				return nil, false, err
+			}
+			columns[name] = segmentID
+		default:
+			return nil, false, fmt.Errorf("field '%s' cannot be bulk edited", name)
+		}
+	}
+
+	if percent, ok := columns["discount_percent"].(float64); ok && s.initialStatus(voucherDiscount{discountType: entity.DiscountTypePercent, percent: percent}) == entity.VoucherStatusPendingApproval {
+		switch columns["status"] {
+		case entity.VoucherStatusActive:
+			columns["status"] = entity.VoucherStatusPendingApproval
+		case nil:
+			return columns, true, nil
+		}
+	}
+	return columns, false, nil
+}
+
+// bulkEditRepositoryFilter checks a bulk edit selects vouchers by at least one
+// criterion and maps it to a repository filter
+func bulkEditRepositoryFilter(filter domainService.BulkEditFilter) (repository.VoucherFilter, error) {
+	if len(filter.IDs) == 0 && len(filter.Codes) == 0 && filter.CodePrefix == "" &&
+		filter.Status == "" && filter.SegmentID == nil && filter.PartnerID == nil {
+		return repository.VoucherFilter{}, errors.New("filter must select vouchers by ids, codes, prefix, status, segment or partner")
+	}
+	if filter.CodePrefix != "" && len(filter.CodePrefix) < minDeactivatePrefixLength {
+		return repository.VoucherFilter{}, fmt.Errorf("prefix must be at least %d characters", minDeactivatePrefixLength)
+	}
+	return repository.VoucherFilter{
+		IDs:        filter.IDs,
+		Codes:      filter.Codes,
+		CodePrefix: filter.CodePrefix,
+		Status:     filter.Status,
+		SegmentID:  filter.SegmentID,
+		PartnerID:  filter.PartnerID,
+	}, nil
+}
+
+// parseVoucherPatch reads a JSON merge patch object or JSON Patch array of
+// top-level voucher fields into the new value of each field. Removing a field,
+// or setting it to null, clears it.
+func parseVoucherPatch(doc []byte) (map[string]json.RawMessage, error) {
+	doc = bytes.TrimSpace(doc)
+	fields := map[string]json.RawMessage{}
+
+	switch {
+	case len(doc) > 0 && doc[0] == '{':
+		if err := json.Unmarshal(doc, &fields); err != nil {
+			return nil, fmt.Errorf("invalid merge patch: %w", err)
+		}
+	case len(doc) > 0 && doc[0] == '[':
+		var operations []jsonPatchOperation
+		if err := json.Unmarshal(doc, &operations); err != nil {
+			return nil, fmt.Errorf("invalid JSON Patch: %w", err)
+		}
+		for _, operation := range operations {
+			field := strings.TrimPrefix(operation.Path, "/")
+			if !strings.HasPrefix(operation.Path, "/") || field == "" || strings.Contains(field, "/") {
+				return nil, fmt.Errorf("invalid JSON Patch path '%s': only top-level voucher fields can be patched", operation.Path)
+			}
+			switch operation.Op {
+			case "add", "replace":
+				if len(operation.Value) == 0 {
+					return nil, fmt.Errorf("JSON Patch %s of %s needs a value", operation.Op, operation.Path)
+				}
+				fields[field] = operation.Value
+			case "remove":
+				fields[field] = json.RawMessage("null")
+			default:
+				return nil, fmt.Errorf("unsupported JSON Patch operation '%s'", operation.Op)
+			}
+		}
+	default:
+		return nil, errors.New("patch must be a JSON merge patch object or a JSON Patch array")
+	}
+
+	if len(fields) == 0 {
+		return nil, errors.New("patch changes no fields")
+	}
+	return fields, nil
+}
+
+// decodePatchValue decodes the new value of a patched field
+func decodePatchValue(field string, raw json.RawMessage, value interface{}) error {
+	if err := json.Unmarshal(raw, value); err != nil {
+		return fmt.Errorf("invalid value for %s", field)
+	}
+	return nil
+}
@@ -0,0 +1,159 @@
+package service
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/shoelfikar/voucher-management-system/internal/domain/entity"
+	domainService "github.com/shoelfikar/voucher-management-system/internal/domain/service"
+)
+
+// csvSchema is the column layout of one voucher CSV schema version
+type csvSchema struct {
+	version int
+	columns []string
+}
+
+// csvSchemas lists every schema version that can be imported or exported.
+// A new version only appends columns, so readers of an older version can
+// ignore the ones they do not know.
+var csvSchemas = map[int]csvSchema{
+	domainService.CSVSchemaV1: {
+		version: domainService.CSVSchemaV1,
+		columns: []string{"voucher_code", "discount_percent", "expiry_date"},
+	},
+	domainService.CSVSchemaV2: {
+		version: domainService.CSVSchemaV2,
+		columns: []string{"voucher_code", "discount_percent", "expiry_date", "external_id", "display_name", "description", "terms_url"},
+	},
+	domainService.CSVSchemaV3: {
+		version: domainService.CSVSchemaV3,
+		columns: []string{"voucher_code", "discount_percent", "expiry_date", "external_id", "display_name", "description", "terms_url", "discount_type", "discount_amount", "currency"},
+	},
+}
+
+// csvColumnExamples are the values of each column in the import template's
+// example row; every column of every schema version must have one
+var csvColumnExamples = map[string]func(now time.Time) string{
+	"voucher_code":     func(time.Time) string { return "SUMMER25" },
+	"discount_percent": func(time.Time) string { return "10.00" },
+	"expiry_date":      func(now time.Time) string { return now.AddDate(0, 3, 0).Format("2006-01-02") },
+	"external_id":      func(time.Time) string { return "crm-1001" },
+	"display_name":     func(time.Time) string { return "Summer sale" },
+	"description":      func(time.Time) string { return "10% off your next order" },
+	"terms_url":        func(time.Time) string { return "https://example.com/terms" },
+	"discount_type":    func(time.Time) string { return entity.DiscountTypePercent },
+	"discount_amount":  func(time.Time) string { return "" },
+	"currency":         func(time.Time) string { return "" },
+}
+
+// lookupCSVSchema returns the schema of a declared version
+func lookupCSVSchema(version int) (csvSchema, error) {
+	schema, ok := csvSchemas[version]
+	if !ok {
+		return csvSchema{}, fmt.Errorf("%w: %d (supported: 1, 2, 3)", domainService.ErrUnsupportedCSVSchema, version)
+	}
+	return schema, nil
+}
+
+// resolveCSVSchema picks the schema to read a file with. A declared version
+// wins, but its header row may not name columns the version lacks. Files that
+// declare nothing are version 1 unless the header has more than three columns
+// or names one added later, in which case they are read with the latest.
+func resolveCSVSchema(version int, header []string) (csvSchema, error) {
+	latest := csvSchemas[domainService.CurrentCSVSchemaVersion]
+
+	if version == 0 {
+		v1 := csvSchemas[domainService.CSVSchemaV1]
+		if len(header) > len(v1.columns) {
+			return latest, nil
+		}
+		for _, column := range header {
+			name := normalizeCSVColumn(column)
+			if slices.Contains(latest.columns, name) && !slices.Contains(v1.columns, name) {
+				return latest, nil
+			}
+		}
+		return v1, nil
+	}
+
+	schema, err := lookupCSVSchema(version)
+	if err != nil {
+		return csvSchema{}, err
+	}
+	for _, column := range header {
+		name := normalizeCSVColumn(column)
+		if slices.Contains(latest.columns, name) && !slices.Contains(schema.columns, name) {
+			return csvSchema{}, fmt.Errorf("header has column '%s', which CSV schema version %d does not have", name, schema.version)
+		}
+	}
+	return schema, nil
+}
+
+// normalizeCSVColumn makes header names comparable, ignoring case, spaces and a UTF-8 BOM
+func normalizeCSVColumn(column string) string {
+	return strings.ToLower(strings.TrimSpace(strings.TrimPrefix(column, "\ufeff")))
+}
+
+// fields returns the values of a row this schema reads; columns past the
+// schema's are dropped
+func (schema csvSchema) fields(record []string) []string {
+	if len(record) > len(schema.columns) {
+		return record[:len(schema.columns)]
+	}
+	return record
+}
+
+// header returns the header row of an export in this schema
+func (schema csvSchema) header() []string {
+	return slices.Clone(schema.columns)
+}
+
+// example returns a row of sample values that imports in this schema
+func (schema csvSchema) example(now time.Time) []string {
+	row := make([]string, len(schema.columns))
+	for i, column := range schema.columns {
+		row[i] = csvColumnExamples[column](now)
+	}
+	return row
+}
+
+// record renders a voucher as an export row in this schema. Fixed-amount
+// vouchers leave discount_percent empty, so in version 1 and 2 rows they
+// cannot be imported again.
+func (schema csvSchema) record(voucher *entity.Voucher) []string {
+	externalID := ""
+	if voucher.ExternalID != nil {
+		externalID = *voucher.ExternalID
+	}
+	discountPercent, discountAmount := fmt.Sprintf("%.2f", voucher.DiscountPercent), ""
+	if voucher.IsFixedAmount() {
+		discountPercent, discountAmount = "", entity.FormatMinorUnits(voucher.DiscountAmount, voucher.Currency)
+	}
+	discountType := voucher.DiscountType
+	if discountType == "" {
+		discountType = entity.DiscountTypePercent
+	}
+	record := []string{
+		voucher.VoucherCode,
+		discountPercent,
+		voucher.ExpiryDate.Format("2006-01-02"),
+		externalID,
+		voucher.DisplayName,
+		voucher.Description,
+		voucher.TermsURL,
+		discountType,
+		discountAmount,
+		voucher.Currency,
+	}
+	return record[:len(schema.columns):len(schema.columns)]
+}
+
+// ImportTemplate returns the header row of the current CSV schema version
+// followed by an example row that would import as-is
+func (s *voucherServiceImpl) ImportTemplate() [][]string {
+	schema := csvSchemas[domainService.CurrentCSVSchemaVersion]
+	return [][]string{schema.header(), schema.example(time.Now())}
+}
@@ -0,0 +1,127 @@
+package service
+
+import (
+	"strings"
+
+	"github.com/shoelfikar/voucher-management-system/internal/domain/entity"
+	"github.com/shoelfikar/voucher-management-system/pkg/pdf"
+	"github.com/shoelfikar/voucher-management-system/pkg/qrcode"
+)
+
+// Layout of a printed voucher on an A6 page, in points
+const (
+	printMargin       = 28
+	printQRSize       = 150
+	printQRQuietZone  = 4
+	printDescFontSize = 9
+	printDescLines    = 4
+	printTermsSize    = 7
+)
+
+// renderVoucherPDF lays out each voucher on its own A6 page: title, discount,
+// QR code of the voucher code, the code itself, expiry, description and terms
+func renderVoucherPDF(vouchers []*entity.Voucher) ([]byte, error) {
+	doc := pdf.New(pdf.A6Width, pdf.A6Height)
+	for _, voucher := range vouchers {
+		if err := renderVoucherPage(doc.AddPage(), voucher); err != nil {
+			return nil, err
+		}
+	}
+	return doc.Bytes(), nil
+}
+
+func renderVoucherPage(page *pdf.Page, voucher *entity.Voucher) error {
+	code, err := qrcode.Encode(voucher.VoucherCode)
+	if err != nil {
+		return err
+	}
+
+	width := float64(pdf.A6Width)
+	page.StrokeRect(printMargin/2, printMargin/2, width-printMargin, pdf.A6Height-printMargin, 1)
+
+	title := voucher.DisplayName
+	if title == "" {
+		title = "Voucher"
+	}
+	page.Text(printMargin, 380, pdf.Bold, 14, title)
+	page.Text(printMargin, 346, pdf.Bold, 26, formatDiscount(voucher)+" OFF")
+
+	// The quiet zone around the symbol is part of its printed size
+	modules := code.Size + 2*printQRQuietZone
+	scale := float64(printQRSize) / float64(modules)
+	left := (width - printQRSize) / 2
+	bottom := 176.0
+	for row := 0; row < code.Size; row++ {
+		for col := 0; col < code.Size; col++ {
+			if code.Dark(row, col) {
+				x := left + float64(col+printQRQuietZone)*scale
+				y := bottom + float64(code.Size-row-1+printQRQuietZone)*scale
+				page.Rect(x, y, scale, scale)
+			}
+		}
+	}
+
+	centered(page, 152, pdf.Bold, 18, voucher.VoucherCode)
+	centered(page, 134, pdf.Regular, 10, "Valid until "+voucher.ExpiryDate.Format("2 January 2006"))
+
+	y := 110.0
+	for _, line := range wrapText(voucher.Description, width-2*printMargin, printDescFontSize, printDescLines) {
+		page.Text(printMargin, y, pdf.Regular, printDescFontSize, line)
+		y -= printDescFontSize + 3
+	}
+
+	if voucher.TermsURL != "" {
+		y = 36.0
+		for _, line := range wrapText("Terms: "+voucher.TermsURL, width-2*printMargin, printTermsSize, 2) {
+			page.Text(printMargin, y, pdf.Regular, printTermsSize, line)
+			y -= printTermsSize + 2
+		}
+	}
+	return nil
+}
+
+// centered draws text centered horizontally on the page
+func centered(page *pdf.Page, y float64, font string, size float64, text string) {
+	page.Text((pdf.A6Width-pdf.TextWidth(text, size))/2, y, font, size, text)
+}
+
+// wrapText breaks text into at most maxLines lines that fit width, breaking
+// between words where it can; text that does not fit ends with "..."
+func wrapText(text string, width, size float64, maxLines int) []string {
+	var lines []string
+	var line string
+	for _, word := range strings.Fields(text) {
+		// Words too long for a line, such as URLs, are split anywhere
+		for pdf.TextWidth(word, size) > width {
+			cut := int(width / pdf.TextWidth("x", size))
+			if line != "" {
+				lines = append(lines, line)
+				line = ""
+			}
+			runes := []rune(word)
+			lines = append(lines, string(runes[:cut]))
+			word = string(runes[cut:])
+		}
+		switch {
+		case line == "":
+			line = word
+		case pdf.TextWidth(line+" "+word, size) <= width:
+			line += " " + word
+		default:
+			lines = append(lines, line)
+			line = word
+		}
+	}
+	if line != "" {
+		lines = append(lines, line)
+	}
+
+	if len(lines) > maxLines {
+		last := []rune(lines[maxLines-1])
+		if len(last) > 3 {
+			last = last[:len(last)-3]
+		}
+		lines = append(lines[:maxLines-1], string(last)+"...")
+	}
+	return lines
+}
@@ -0,0 +1,105 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"time"
+
+	"github.com/shoelfikar/voucher-management-system/internal/domain/entity"
+	domainService "github.com/shoelfikar/voucher-management-system/internal/domain/service"
+	"github.com/shoelfikar/voucher-management-system/pkg/pixelfont"
+	"github.com/shoelfikar/voucher-management-system/pkg/utils"
+	"gorm.io/gorm"
+)
+
+// Layout of a preview image, in pixels; 1200x630 is the size Open Graph
+// consumers crop least
+const (
+	previewWidth         = 1200
+	previewHeight        = 630
+	previewMargin        = 80
+	previewStripeWidth   = 24
+	previewTitleScale    = 6
+	previewDiscountScale = 18
+	previewCodeScale     = 8
+	previewCodePadding   = 24
+	previewExpiryScale   = 5
+)
+
+// Preview image colours
+var (
+	previewBackground = color.RGBA{R: 30, G: 58, B: 138, A: 255}
+	previewAccent     = color.RGBA{R: 245, G: 158, B: 11, A: 255}
+	previewText       = color.RGBA{R: 255, G: 255, B: 255, A: 255}
+	previewCodeText   = color.RGBA{R: 17, G: 24, B: 39, A: 255}
+)
+
+// RenderPreviewImage renders a voucher as a PNG for social sharing previews
+func (s *voucherServiceImpl) RenderPreviewImage(ctx context.Context, id uint, options domainService.PreviewImageOptions) ([]byte, error) {
+	voucher, err := s.voucherRepo.FindByID(ctx, id)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, domainService.ErrVoucherNotFound
+		}
+		return nil, fmt.Errorf("failed to fetch voucher: %w", err)
+	}
+
+	code := ""
+	if options.ShowCode {
+		code = voucher.VoucherCode
+		if options.MaskCode {
+			code = utils.MaskCode(code)
+		}
+	}
+	return renderVoucherPreview(voucher, code, time.Now())
+}
+
+// renderVoucherPreview draws the title, discount, code when given and expiry
+// on the brand background, with an accent stripe along the left edge
+func renderVoucherPreview(voucher *entity.Voucher, code string, now time.Time) ([]byte, error) {
+	img := image.NewRGBA(image.Rect(0, 0, previewWidth, previewHeight))
+	draw.Draw(img, img.Bounds(), image.NewUniform(previewBackground), image.Point{}, draw.Src)
+	draw.Draw(img, image.Rect(0, 0, previewStripeWidth, previewHeight), image.NewUniform(previewAccent), image.Point{}, draw.Src)
+
+	textWidth := previewWidth - 2*previewMargin
+
+	title := voucher.DisplayName
+	if title == "" {
+		title = "Voucher"
+	}
+	pixelfont.Draw(img, previewMargin, 70, previewTitleScale, previewText, pixelfont.Fit(title, previewTitleScale, textWidth))
+
+	// Long discounts such as 12.75% or IDR 1,500,000 step down in size rather than being cut
+	discount := formatDiscount(voucher) + " OFF"
+	scale := previewDiscountScale
+	for scale > 1 && pixelfont.Width(discount, scale) > textWidth {
+		scale--
+	}
+	pixelfont.Draw(img, previewMargin, 160, scale, previewAccent, discount)
+
+	if code != "" {
+		code = pixelfont.Fit(code, previewCodeScale, textWidth-2*previewCodePadding)
+		box := image.Rect(previewMargin, 340,
+			previewMargin+pixelfont.Width(code, previewCodeScale)+2*previewCodePadding,
+			340+pixelfont.GlyphHeight*previewCodeScale+2*previewCodePadding)
+		draw.Draw(img, box, image.NewUniform(previewText), image.Point{}, draw.Src)
+		pixelfont.Draw(img, box.Min.X+previewCodePadding, box.Min.Y+previewCodePadding, previewCodeScale, previewCodeText, code)
+	}
+
+	expiry := "Valid until " + voucher.ExpiryDate.Format("2 Jan 2006")
+	if isExpired(voucher, now) {
+		expiry = "Expired " + voucher.ExpiryDate.Format("2 Jan 2006")
+	}
+	pixelfont.Draw(img, previewMargin, 520, previewExpiryScale, previewText, expiry)
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,137 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/shoelfikar/voucher-management-system/internal/config"
+	"github.com/shoelfikar/voucher-management-system/internal/domain/entity"
+	domainService "github.com/shoelfikar/voucher-management-system/internal/domain/service"
+	"github.com/shoelfikar/voucher-management-system/internal/repository"
+	"github.com/shoelfikar/voucher-management-system/pkg/database"
+	"github.com/shoelfikar/voucher-management-system/pkg/rules"
+	"gorm.io/driver/postgres"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+// benchmarkDB is a database the benchmarks run against
+type benchmarkDB struct {
+	name string
+	db   *gorm.DB
+}
+
+// benchmarkDatabases opens SQLite and, when BENCH_POSTGRES_DSN is set, a
+// scratch PostgreSQL database whose voucher tables are dropped and recreated
+func benchmarkDatabases(b *testing.B) []benchmarkDB {
+	cfg := &config.DatabaseConfig{PrepareStmt: true, StatementCacheSize: 500, StatementCacheTTL: time.Hour}
+	models := []interface{}{&entity.Voucher{}, &entity.VoucherAssignment{}, &entity.Customer{}}
+
+	sqliteDB, err := gorm.Open(sqlite.Open(":memory:"), database.NewGormConfig(cfg))
+	if err != nil {
+		b.Fatalf("Failed to connect to SQLite: %v", err)
+	}
+	// Every connection to :memory: is a separate database
+	sqlDB, err := sqliteDB.DB()
+	if err != nil {
+		b.Fatalf("Failed to get SQLite instance: %v", err)
+	}
+	sqlDB.SetMaxOpenConns(1)
+	dbs := []benchmarkDB{{"sqlite", sqliteDB}}
+
+	if dsn := os.Getenv("BENCH_POSTGRES_DSN"); dsn != "" {
+		postgresDB, err := gorm.Open(postgres.Open(dsn), database.NewGormConfig(cfg))
+		if err != nil {
+			b.Fatalf("Failed to connect to PostgreSQL: %v", err)
+		}
+		if err := postgresDB.Migrator().DropTable(models...); err != nil {
+			b.Fatalf("Failed to reset PostgreSQL: %v", err)
+		}
+		dbs = append(dbs, benchmarkDB{"postgres", postgresDB})
+	}
+
+	for _, target := range dbs {
+		if err := target.db.AutoMigrate(models...); err != nil {
+			b.Fatalf("Failed to migrate %s: %v", target.name, err)
+		}
+	}
+	return dbs
+}
+
+// silenceLogs keeps per-call log lines out of benchmark output
+func silenceLogs(b *testing.B) {
+	log.SetOutput(io.Discard)
+	b.Cleanup(func() { log.SetOutput(os.Stderr) })
+}
+
+// BenchmarkVoucherService_Redeem measures handing a voucher with relative
+// expiry and eligibility rules to a customer and validating it at checkout
+func BenchmarkVoucherService_Redeem(b *testing.B) {
+	silenceLogs(b)
+	validityDays := 30
+
+	for _, target := range benchmarkDatabases(b) {
+		voucherRepo := repository.NewVoucherRepository(target.db)
+		voucherService := NewVoucherService(voucherRepo, 0,
+			WithAssignments(repository.NewVoucherAssignmentRepository(target.db), NewCustomerService(repository.NewCustomerRepository(target.db))))
+
+		voucher := &entity.Voucher{
+			VoucherCode:     "REDEEM",
+			DiscountPercent: 15,
+			ExpiryDate:      time.Now().AddDate(1, 0, 0),
+			Status:          entity.VoucherStatusActive,
+			ValidityDays:    &validityDays,
+			Rules:           `[{"type":"min_items","value":2},{"type":"channel","values":["pos","web"]}]`,
+		}
+		if err := voucherRepo.Create(context.Background(), voucher); err != nil {
+			b.Fatalf("Failed to seed voucher: %v", err)
+		}
+
+		b.Run(target.name, func(b *testing.B) {
+			b.ReportAllocs()
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				// Customers must be new on every run, including the calibration runs
+				customerID := fmt.Sprintf("cust-%d-%d", b.N, i)
+				if _, err := voucherService.Assign(context.Background(), voucher.ID, customerID, "bench"); err != nil {
+					b.Fatalf("Failed to assign voucher: %v", err)
+				}
+				result, err := voucherService.Validate(context.Background(), "redeem", rules.Context{CustomerID: customerID, ItemCount: 3, Channel: "web"})
+				if err != nil || !result.Valid {
+					b.Fatalf("Voucher not redeemable: %v %v", err, result)
+				}
+			}
+		})
+	}
+}
+
+// BenchmarkVoucherService_ParseCSVRow measures validating one import row,
+// including its duplicate code lookup
+func BenchmarkVoucherService_ParseCSVRow(b *testing.B) {
+	for _, target := range benchmarkDatabases(b) {
+		voucherService := NewVoucherService(repository.NewVoucherRepository(target.db), 0).(*voucherServiceImpl)
+		expiryDate := time.Now().AddDate(1, 0, 0).Format("2006-01-02")
+
+		b.Run(target.name, func(b *testing.B) {
+			b.ReportAllocs()
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				record := []string{fmt.Sprintf("CSV%07d", i), "12.50", expiryDate, "", "Summer sale", "15% off everything", "https://example.com/terms"}
+				existing, err := voucherService.findExistingImportKeys(context.Background(), csvSchemas[domainService.CSVSchemaV2], [][]string{record})
+				if err != nil {
+					b.Fatalf("Failed to look up existing keys: %v", err)
+				}
+				if _, err := voucherService.parseCSVRow(record, i+2, existing); err != nil {
+					b.Fatalf("Failed to parse row: %v", err)
+				}
+			}
+		})
+	}
+}
@@ -2,63 +2,421 @@ package service
 
 import (
 	"bytes"
+	"context"
 	"encoding/csv"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"mime/multipart"
+	"net/url"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
 
-	"github.com/shoelfikar/voucher-management-system/internal/delivery/http/request"
 	"github.com/shoelfikar/voucher-management-system/internal/domain/entity"
 	"github.com/shoelfikar/voucher-management-system/internal/domain/repository"
 	domainService "github.com/shoelfikar/voucher-management-system/internal/domain/service"
+	"github.com/shoelfikar/voucher-management-system/pkg/events"
+	"github.com/shoelfikar/voucher-management-system/pkg/logger"
+	"github.com/shoelfikar/voucher-management-system/pkg/rules"
+	"github.com/shoelfikar/voucher-management-system/pkg/utils"
 	"gorm.io/gorm"
 )
 
+const (
+	// deactivateChunkSize bounds the rows touched by a single deactivation UPDATE
+	deactivateChunkSize = 500
+	// minDeactivatePrefixLength guards against deactivating most vouchers by accident
+	minDeactivatePrefixLength = 3
+	// changeExportChunkSize bounds the rows read per query by a delta export
+	changeExportChunkSize = 1000
+	// importWarningExpiryYears is how far away an imported expiry date may be before it is flagged
+	importWarningExpiryYears = 5
+)
+
 // voucherServiceImpl implements domain service.VoucherService
 type voucherServiceImpl struct {
-	voucherRepo repository.VoucherRepository
+	voucherRepo       repository.VoucherRepository
+	importRuleRepo    repository.ImportRuleRepository
+	templateRepo      repository.VoucherTemplateRepository
+	assignmentRepo    repository.VoucherAssignmentRepository
+	customers         domainService.CustomerService
+	discountLimitRepo repository.DiscountLimitRepository
+	auditRepo         repository.AuditRepository
+	events            events.Publisher
+	segments          domainService.SegmentService
+	approvalThreshold float64
+	approvalAmounts   map[string]int64
+	logCode           func(code string) string
+}
+
+// VoucherServiceOption configures optional voucher service dependencies
+type VoucherServiceOption func(*voucherServiceImpl)
+
+// WithImportRules makes imports check every voucher against the enabled import rules
+func WithImportRules(ruleRepo repository.ImportRuleRepository) VoucherServiceOption {
+	return func(s *voucherServiceImpl) {
+		s.importRuleRepo = ruleRepo
+	}
+}
+
+// WithTemplates lets creates start from a voucher template
+func WithTemplates(templateRepo repository.VoucherTemplateRepository) VoucherServiceOption {
+	return func(s *voucherServiceImpl) {
+		s.templateRepo = templateRepo
+	}
+}
+
+// WithAssignments lets vouchers be assigned to customers, which vouchers with
+// relative expiry need before they validate. customerService matches the
+// external customer IDs in requests to customers.
+func WithAssignments(assignmentRepo repository.VoucherAssignmentRepository, customerService domainService.CustomerService) VoucherServiceOption {
+	return func(s *voucherServiceImpl) {
+		s.assignmentRepo = assignmentRepo
+		s.customers = customerService
+	}
+}
+
+// WithDiscountLimits caps the discount non-admin users can give the vouchers
+// they create, edit or import, per their role
+func WithDiscountLimits(discountLimitRepo repository.DiscountLimitRepository) VoucherServiceOption {
+	return func(s *voucherServiceImpl) {
+		s.discountLimitRepo = discountLimitRepo
+	}
+}
+
+// WithAudit records an audit entry for every chunk of a bulk edit
+func WithAudit(auditRepo repository.AuditRepository) VoucherServiceOption {
+	return func(s *voucherServiceImpl) {
+		s.auditRepo = auditRepo
+	}
+}
+
+// WithEvents publishes an event whenever an import finishes
+func WithEvents(publisher events.Publisher) VoucherServiceOption {
+	return func(s *voucherServiceImpl) {
+		s.events = publisher
+	}
+}
+
+// WithSegments lets vouchers target customer segments, checked when a voucher is validated
+func WithSegments(segmentService domainService.SegmentService) VoucherServiceOption {
+	return func(s *voucherServiceImpl) {
+		s.segments = segmentService
+	}
+}
+
+// WithMaskedLogCodes partially redacts voucher codes written to the log
+func WithMaskedLogCodes() VoucherServiceOption {
+	return func(s *voucherServiceImpl) {
+		s.logCode = utils.MaskCode
+	}
+}
+
+// WithApprovalAmountThresholds sets, per currency in minor units, the fixed
+// discount amount above which vouchers require approval. While approval is
+// enabled, fixed amounts in a currency without a threshold always require it.
+func WithApprovalAmountThresholds(thresholds map[string]int64) VoucherServiceOption {
+	return func(s *voucherServiceImpl) {
+		s.approvalAmounts = thresholds
+	}
+}
+
+// NewVoucherService creates a new voucher service instance.
+// Vouchers with a discount above approvalThreshold require approval before
+// they become active; a threshold of 0 disables the approval workflow.
+func NewVoucherService(voucherRepo repository.VoucherRepository, approvalThreshold float64, opts ...VoucherServiceOption) domainService.VoucherService {
+	s := &voucherServiceImpl{
+		voucherRepo:       voucherRepo,
+		events:            events.Discard,
+		approvalThreshold: approvalThreshold,
+		logCode:           func(code string) string { return code },
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// checkSegment verifies the segment a voucher targets exists; nil targets everyone
+func (s *voucherServiceImpl) checkSegment(ctx context.Context, segmentID *uint) error {
+	if segmentID == nil {
+		return nil
+	}
+	if s.segments == nil {
+		return errors.New("customer segments are not enabled")
+	}
+	_, err := s.segments.GetByID(ctx, *segmentID)
+	return err
+}
+
+// loadImportRules returns the enabled import rules, or none when rules are not configured
+func (s *voucherServiceImpl) loadImportRules(ctx context.Context) ([]*entity.ImportRule, error) {
+	if s.importRuleRepo == nil {
+		return nil, nil
+	}
+	rules, err := s.importRuleRepo.FindEnabled(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load import rules: %w", err)
+	}
+	return rules, nil
+}
+
+// applyTemplate returns a copy of cmd completed from its template: the
+// template's discount and expiry offset fill in a missing discount and expiry
+// date, a missing code is generated from the code prefix, a given code must
+// start with it, and the template's channels are added to the rules
+func (s *voucherServiceImpl) applyTemplate(ctx context.Context, cmd *domainService.CreateVoucherCommand) (*domainService.CreateVoucherCommand, error) {
+	if s.templateRepo == nil {
+		return nil, errors.New("voucher templates are not enabled")
+	}
+	template, err := s.templateRepo.FindByID(ctx, *cmd.TemplateID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domainService.ErrVoucherTemplateNotFound
+		}
+		return nil, err
+	}
+
+	applied := *cmd
+	if applied.DiscountPercent == 0 && template.DiscountPercent != nil && applied.DiscountType != entity.DiscountTypeFixedAmount {
+		applied.DiscountPercent = *template.DiscountPercent
+	}
+	if applied.ExpiryDate == "" && template.ExpiryOffset != "" {
+		expiryDate, err := expiryFromOffset(template.ExpiryOffset, time.Now())
+		if err != nil {
+			return nil, err
+		}
+		applied.ExpiryDate = expiryDate.Format("2006-01-02")
+	}
+
+	switch {
+	case applied.VoucherCode == "" && template.CodePrefix == "":
+		return nil, fmt.Errorf("voucher code is required; template '%s' has no code prefix to generate one from", template.Name)
+	case applied.VoucherCode == "":
+		if applied.VoucherCode, err = generateVoucherCode(template.CodePrefix); err != nil {
+			return nil, err
+		}
+	case !strings.HasPrefix(strings.ToUpper(applied.VoucherCode), strings.ToUpper(template.CodePrefix)):
+		return nil, fmt.Errorf("voucher code must start with '%s' to use template '%s'", template.CodePrefix, template.Name)
+	}
+
+	if len(template.Channels) > 0 {
+		if applied.Rules, err = withChannelCondition(applied.Rules, template.Channels); err != nil {
+			return nil, err
+		}
+	}
+
+	return &applied, nil
+}
+
+// checkDiscountLimit verifies a discount is within the limit of role
+func (s *voucherServiceImpl) checkDiscountLimit(ctx context.Context, role string, discount voucherDiscount) error {
+	limit, err := s.discountLimit(ctx, role)
+	if err != nil {
+		return err
+	}
+	return checkWithinLimit(limit, discount)
+}
+
+// discountLimit loads the limit of role. Admins, unknown roles and roles
+// without a limit are not limited and get nil.
+func (s *voucherServiceImpl) discountLimit(ctx context.Context, role string) (*entity.DiscountLimit, error) {
+	if s.discountLimitRepo == nil || role == "" || role == entity.UserRoleAdmin {
+		return nil, nil
+	}
+	limit, err := s.discountLimitRepo.FindByRole(ctx, role)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load discount limit: %w", err)
+	}
+	return limit, nil
+}
+
+// checkWithinLimit verifies a discount is within limit: its percent, or for
+// fixed amounts the limit's cap in the currency. A nil limit allows anything.
+func checkWithinLimit(limit *entity.DiscountLimit, discount voucherDiscount) error {
+	if limit == nil {
+		return nil
+	}
+
+	if discount.discountType == entity.DiscountTypeFixedAmount {
+		maxAmount, ok := limit.MaxDiscountAmounts[discount.currency]
+		if !ok {
+			return fmt.Errorf("%w: %s users cannot give fixed discounts in %s; ask an admin to make this change",
+				domainService.ErrDiscountLimitExceeded, limit.Role, discount.currency)
+		}
+		if discount.amount <= maxAmount {
+			return nil
+		}
+		return fmt.Errorf("%w: %s users can give up to %s %s off, not %s %s; ask an admin to make this change",
+			domainService.ErrDiscountLimitExceeded, limit.Role,
+			discount.currency, entity.FormatMinorUnits(maxAmount, discount.currency),
+			discount.currency, entity.FormatMinorUnits(discount.amount, discount.currency))
+	}
+
+	if entity.DiscountHundredths(discount.percent) <= entity.DiscountHundredths(limit.MaxDiscountPercent) {
+		return nil
+	}
+	return fmt.Errorf("%w: %s users can give up to %g%% off, not %g%%; ask an admin to make this change",
+		domainService.ErrDiscountLimitExceeded, limit.Role, limit.MaxDiscountPercent, discount.percent)
+}
+
+// discountOf returns the discount of a voucher
+func discountOf(voucher *entity.Voucher) voucherDiscount {
+	return voucherDiscount{
+		discountType: voucher.DiscountType,
+		percent:      voucher.DiscountPercent,
+		amount:       voucher.DiscountAmount,
+		currency:     voucher.Currency,
+	}
 }
 
-// NewVoucherService creates a new voucher service instance
-func NewVoucherService(voucherRepo repository.VoucherRepository) domainService.VoucherService {
-	return &voucherServiceImpl{
-		voucherRepo: voucherRepo,
+// initialStatus returns the status a voucher with the given discount starts
+// in. Approval is enabled by a percent threshold or any amount threshold;
+// fixed amounts are then held for approval above their currency's threshold,
+// or always when their currency has none.
+func (s *voucherServiceImpl) initialStatus(discount voucherDiscount) string {
+	if discount.discountType == entity.DiscountTypeFixedAmount {
+		if s.approvalThreshold <= 0 && len(s.approvalAmounts) == 0 {
+			return entity.VoucherStatusActive
+		}
+		if threshold, ok := s.approvalAmounts[discount.currency]; ok && discount.amount <= threshold {
+			return entity.VoucherStatusActive
+		}
+		return entity.VoucherStatusPendingApproval
 	}
+	if s.approvalThreshold > 0 && entity.DiscountHundredths(discount.percent) > entity.DiscountHundredths(s.approvalThreshold) {
+		return entity.VoucherStatusPendingApproval
+	}
+	return entity.VoucherStatusActive
 }
 
 // GetAll retrieves all vouchers with pagination and filters
-func (s *voucherServiceImpl) GetAll(page, limit int, search, sortBy, sortOrder string) ([]*entity.Voucher, int64, error) {
-	return s.voucherRepo.FindAll(page, limit, search, sortBy, sortOrder)
+func (s *voucherServiceImpl) GetAll(ctx context.Context, page, limit int, search string, sort []utils.SortField) ([]*entity.Voucher, int64, error) {
+	return s.voucherRepo.FindAll(ctx, page, limit, search, sort)
 }
 
 // GetByID retrieves a voucher by ID
-func (s *voucherServiceImpl) GetByID(id uint) (*entity.Voucher, error) {
-	voucher, err := s.voucherRepo.FindByID(id)
+func (s *voucherServiceImpl) GetByID(ctx context.Context, id uint) (*entity.Voucher, error) {
+	voucher, err := s.voucherRepo.FindByID(ctx, id)
 	if err != nil {
 		if err == gorm.ErrRecordNotFound {
-			return nil, errors.New("voucher not found")
+			return nil, domainService.ErrVoucherNotFound
 		}
 		return nil, err
 	}
 	return voucher, nil
 }
 
+// GetByIDs retrieves the vouchers with the given IDs in one query
+func (s *voucherServiceImpl) GetByIDs(ctx context.Context, ids []uint) ([]*entity.Voucher, error) {
+	if err := checkBatchLookupSize(len(ids)); err != nil {
+		return nil, err
+	}
+	return s.voucherRepo.FindByIDs(ctx, ids)
+}
+
+// GetByCodes retrieves the vouchers with the given codes in one query
+func (s *voucherServiceImpl) GetByCodes(ctx context.Context, codes []string) ([]*entity.Voucher, error) {
+	trimmed := make([]string, 0, len(codes))
+	for _, code := range codes {
+		if code = strings.TrimSpace(code); code != "" {
+			trimmed = append(trimmed, code)
+		}
+	}
+	if err := checkBatchLookupSize(len(trimmed)); err != nil {
+		return nil, err
+	}
+	return s.voucherRepo.FindByVoucherCodes(ctx, trimmed)
+}
+
+// checkBatchLookupSize rejects empty batch lookups and those over MaxBatchLookup
+func checkBatchLookupSize(n int) error {
+	if n == 0 {
+		return errors.New("at least one voucher must be requested")
+	}
+	if n > domainService.MaxBatchLookup {
+		return fmt.Errorf("at most %d vouchers can be requested at once", domainService.MaxBatchLookup)
+	}
+	return nil
+}
+
+// Suggest returns voucher codes starting with query for autocomplete
+func (s *voucherServiceImpl) Suggest(ctx context.Context, query string) ([]string, error) {
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return []string{}, nil
+	}
+	return s.voucherRepo.SuggestCodes(ctx, query, domainService.MaxSuggestions)
+}
+
+// CodeAvailable looks code up the way Create checks for duplicates
+func (s *voucherServiceImpl) CodeAvailable(ctx context.Context, code string) (bool, error) {
+	existing, err := s.voucherRepo.FindByVoucherCode(ctx, code)
+	if err != nil && err != gorm.ErrRecordNotFound {
+		return false, err
+	}
+	return existing == nil, nil
+}
+
+// GetByExternalID retrieves a voucher by its client-provided external ID
+func (s *voucherServiceImpl) GetByExternalID(ctx context.Context, externalID string) (*entity.Voucher, error) {
+	voucher, err := s.voucherRepo.FindByExternalID(ctx, externalID)
+	if err != nil {
+		return nil, err
+	}
+	if voucher == nil {
+		return nil, domainService.ErrVoucherNotFound
+	}
+	return voucher, nil
+}
+
 // Create creates a new voucher with validation
-func (s *voucherServiceImpl) Create(req *request.CreateVoucherRequest) (*entity.Voucher, error) {
+func (s *voucherServiceImpl) Create(ctx context.Context, cmd *domainService.CreateVoucherCommand) (*entity.Voucher, error) {
+	if cmd.TemplateID != nil {
+		templated, err := s.applyTemplate(ctx, cmd)
+		if err != nil {
+			return nil, err
+		}
+		cmd = templated
+	}
+
 	// Check if voucher code already exists
-	existing, err := s.voucherRepo.FindByVoucherCode(req.VoucherCode)
+	existing, err := s.voucherRepo.FindByVoucherCode(ctx, cmd.VoucherCode)
 	if err != nil && err != gorm.ErrRecordNotFound {
 		return nil, err
 	}
 	if existing != nil {
-		return nil, errors.New("voucher code already exists")
+		return nil, domainService.ErrDuplicateVoucherCode
+	}
+
+	// Check the external ID is not used by another voucher
+	externalID, err := parseExternalID(cmd.ExternalID)
+	if err != nil {
+		return nil, err
+	}
+	if externalID != nil {
+		existingIDs, err := s.voucherRepo.CheckDuplicateExternalIDs(ctx, []string{*externalID})
+		if err != nil {
+			return nil, err
+		}
+		if len(existingIDs) > 0 {
+			return nil, domainService.ErrDuplicateExternalID
+		}
+	}
+
+	// Validate the discount; limits apply to percent discounts only
+	discount, err := validateDiscount(cmd.DiscountType, cmd.DiscountPercent, cmd.DiscountAmount, cmd.Currency)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkDiscountLimit(ctx, cmd.Role, discount); err != nil {
+		return nil, err
 	}
 
 	// Parse expiry date
-	expiryDate, err := time.Parse("2006-01-02", req.ExpiryDate)
+	expiryDate, err := time.Parse("2006-01-02", cmd.ExpiryDate)
 	if err != nil {
 		return nil, errors.New("invalid date format, expected YYYY-MM-DD")
 	}
@@ -73,46 +431,113 @@ func (s *voucherServiceImpl) Create(req *request.CreateVoucherRequest) (*entity.
 		return nil, errors.New("expiry date must be today or in the future")
 	}
 
+	if err := validateValidityDays(cmd.ValidityDays); err != nil {
+		return nil, err
+	}
+
+	maxRedemptionsPerUser, err := validateRedemptionLimits(cmd.MaxRedemptions, cmd.MaxRedemptionsPerUser)
+	if err != nil {
+		return nil, err
+	}
+
+	// Validate eligibility rules
+	if _, err := rules.Parse(cmd.Rules); err != nil {
+		return nil, err
+	}
+
+	// Validate customer-facing display metadata
+	if err := validateDisplayMetadata(cmd.DisplayName, cmd.Description, cmd.TermsURL); err != nil {
+		return nil, err
+	}
+
+	metadata, err := encodeMetadata(cmd.Metadata)
+	if err != nil {
+		return nil, err
+	}
+
+	// Check the targeted segment exists
+	if err := s.checkSegment(ctx, cmd.SegmentID); err != nil {
+		return nil, err
+	}
+
 	// Create voucher entity
 	voucher := &entity.Voucher{
-		VoucherCode:     req.VoucherCode,
-		DiscountPercent: req.DiscountPercent,
-		ExpiryDate:      expiryDate,
+		VoucherCode:           cmd.VoucherCode,
+		ExternalID:            externalID,
+		DiscountType:          discount.discountType,
+		DiscountPercent:       discount.percent,
+		DiscountAmount:        discount.amount,
+		Currency:              discount.currency,
+		ExpiryDate:            expiryDate,
+		ValidityDays:          cmd.ValidityDays,
+		MaxRedemptions:        cmd.MaxRedemptions,
+		MaxRedemptionsPerUser: maxRedemptionsPerUser,
+		Status:                s.initialStatus(discount),
+		Rules:                 cmd.Rules,
+		DisplayName:           cmd.DisplayName,
+		Description:           cmd.Description,
+		TermsURL:              cmd.TermsURL,
+		Metadata:              metadata,
+		SegmentID:             cmd.SegmentID,
+		PartnerID:             cmd.PartnerID,
+		CreatedBy:             cmd.CreatedBy,
+		UpdatedBy:             cmd.CreatedBy,
 	}
 
-	// Save to database
-	err = s.voucherRepo.Create(voucher)
+	// Save to database; a concurrent create can still hit the unique indexes
+	err = s.voucherRepo.Create(ctx, voucher)
 	if err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return nil, s.duplicateKeyError(ctx, externalID)
+		}
 		return nil, err
 	}
 
+	if voucher.Status == entity.VoucherStatusPendingApproval {
+		logger.FromContext(ctx).Info("voucher pending approval", "voucher_code", s.logCode(voucher.VoucherCode), "created_by", voucher.CreatedBy)
+		s.requestApproval(ctx, []uint{voucher.ID}, cmd.CreatedBy)
+	}
+
 	return voucher, nil
 }
 
-// Update updates an existing voucher with validation
-func (s *voucherServiceImpl) Update(id uint, req *request.UpdateVoucherRequest) (*entity.Voucher, error) {
-	// Check if voucher exists
-	voucher, err := s.voucherRepo.FindByID(id)
-	if err != nil {
-		if err == gorm.ErrRecordNotFound {
-			return nil, errors.New("voucher not found")
+// duplicateKeyError tells which unique index a failed insert collided with
+func (s *voucherServiceImpl) duplicateKeyError(ctx context.Context, externalID *string) error {
+	if externalID != nil {
+		existingIDs, err := s.voucherRepo.CheckDuplicateExternalIDs(ctx, []string{*externalID})
+		if err == nil && len(existingIDs) > 0 {
+			return domainService.ErrDuplicateExternalID
 		}
+	}
+	return domainService.ErrDuplicateVoucherCode
+}
+
+// Update updates an existing voucher with validation
+func (s *voucherServiceImpl) Update(ctx context.Context, id uint, cmd *domainService.UpdateVoucherCommand) (*entity.Voucher, error) {
+	// Check if the voucher code already belongs to another voucher
+	existing, err := s.voucherRepo.FindByVoucherCode(ctx, cmd.VoucherCode)
+	if err != nil && err != gorm.ErrRecordNotFound {
 		return nil, err
 	}
+	if existing != nil && existing.ID != id {
+		return nil, domainService.ErrDuplicateVoucherCode
+	}
 
-	// Check if voucher code is being changed and if new code already exists
-	if req.VoucherCode != voucher.VoucherCode {
-		existing, err := s.voucherRepo.FindByVoucherCode(req.VoucherCode)
-		if err != nil && err != gorm.ErrRecordNotFound {
+	// Validate the discount; limits apply to percent discounts only
+	discount, err := validateDiscount(cmd.DiscountType, cmd.DiscountPercent, cmd.DiscountAmount, cmd.Currency)
+	if err != nil {
+		return nil, err
+	}
+	// Editors may keep a discount above their limit that an admin gave, but not raise it
+	if err := s.checkDiscountLimit(ctx, cmd.Role, discount); err != nil {
+		current, findErr := s.voucherRepo.FindByID(ctx, id)
+		if findErr != nil || entity.DiscountHundredths(discount.percent) > entity.DiscountHundredths(current.DiscountPercent) {
 			return nil, err
 		}
-		if existing != nil {
-			return nil, errors.New("voucher code already exists")
-		}
 	}
 
 	// Parse expiry date
-	expiryDate, err := time.Parse("2006-01-02", req.ExpiryDate)
+	expiryDate, err := time.Parse("2006-01-02", cmd.ExpiryDate)
 	if err != nil {
 		return nil, errors.New("invalid date format, expected YYYY-MM-DD")
 	}
@@ -127,37 +552,460 @@ func (s *voucherServiceImpl) Update(id uint, req *request.UpdateVoucherRequest)
 		return nil, errors.New("expiry date must be today or in the future")
 	}
 
-	// Update voucher fields
-	voucher.VoucherCode = req.VoucherCode
-	voucher.DiscountPercent = req.DiscountPercent
-	voucher.ExpiryDate = expiryDate
-
-	// Save to database
-	err = s.voucherRepo.Update(voucher)
+	if err := validateValidityDays(cmd.ValidityDays); err != nil {
+		return nil, err
+	}
+
+	maxRedemptionsPerUser, err := validateRedemptionLimits(cmd.MaxRedemptions, cmd.MaxRedemptionsPerUser)
+	if err != nil {
+		return nil, err
+	}
+
+	// Validate eligibility rules
+	if _, err := rules.Parse(cmd.Rules); err != nil {
+		return nil, err
+	}
+
+	// Validate customer-facing display metadata
+	if err := validateDisplayMetadata(cmd.DisplayName, cmd.Description, cmd.TermsURL); err != nil {
+		return nil, err
+	}
+
+	metadata, err := encodeMetadata(cmd.Metadata)
+	if err != nil {
+		return nil, err
+	}
+
+	// Check the targeted segment exists
+	if err := s.checkSegment(ctx, cmd.SegmentID); err != nil {
+		return nil, err
+	}
+
+	voucher := &entity.Voucher{
+		ID:                    id,
+		VoucherCode:           cmd.VoucherCode,
+		DiscountType:          discount.discountType,
+		DiscountPercent:       discount.percent,
+		DiscountAmount:        discount.amount,
+		Currency:              discount.currency,
+		ExpiryDate:            expiryDate,
+		ValidityDays:          cmd.ValidityDays,
+		MaxRedemptions:        cmd.MaxRedemptions,
+		MaxRedemptionsPerUser: maxRedemptionsPerUser,
+		Rules:                 cmd.Rules,
+		DisplayName:           cmd.DisplayName,
+		Description:           cmd.Description,
+		TermsURL:              cmd.TermsURL,
+		Metadata:              metadata,
+		SegmentID:             cmd.SegmentID,
+		UpdatedBy:             cmd.UpdatedBy,
+	}
+
+	// Raising the discount above the threshold sends an active voucher back
+	// for approval; the repository leaves other statuses alone, so approving
+	// cannot reactivate a deactivated voucher
+	needsApproval := s.initialStatus(discount) == entity.VoucherStatusPendingApproval
+	if needsApproval {
+		voucher.Status = entity.VoucherStatusPendingApproval
+	}
+
+	// Save to database; a missing voucher affects no rows
+	rowsAffected, err := s.voucherRepo.Update(ctx, voucher)
+	if err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return nil, domainService.ErrDuplicateVoucherCode
+		}
+		return nil, err
+	}
+	if rowsAffected == 0 {
+		return nil, domainService.ErrVoucherNotFound
+	}
+
+	// The update returns the stored status, which tells whether the voucher now waits for approval
+	if needsApproval && voucher.Status == entity.VoucherStatusPendingApproval {
+		logger.FromContext(ctx).Info("voucher pending approval", "voucher_code", s.logCode(voucher.VoucherCode), "updated_by", voucher.UpdatedBy)
+		s.requestApproval(ctx, []uint{voucher.ID}, cmd.UpdatedBy)
+	}
+
+	return voucher, nil
+}
+
+// Delete deletes a voucher by ID (soft delete)
+func (s *voucherServiceImpl) Delete(ctx context.Context, id uint) error {
+	// Soft delete; a voucher that does not exist affects no rows
+	rowsAffected, err := s.voucherRepo.Delete(ctx, id)
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return domainService.ErrVoucherNotFound
+	}
+
+	return nil
+}
+
+// BulkDeactivate immediately deactivates vouchers by code prefix or by an explicit code list.
+// Work is done in chunks so a large leak does not hold one long-running UPDATE.
+func (s *voucherServiceImpl) BulkDeactivate(ctx context.Context, prefix string, codes []string) (*domainService.BulkDeactivateResult, error) {
+	if (prefix == "") == (len(codes) == 0) {
+		return nil, errors.New("provide either a code prefix or a list of codes")
+	}
+
+	result := &domainService.BulkDeactivateResult{MissingCodes: []string{}}
+
+	if prefix != "" {
+		if len(prefix) < minDeactivatePrefixLength {
+			return nil, fmt.Errorf("prefix must be at least %d characters", minDeactivatePrefixLength)
+		}
+
+		for {
+			rows, err := s.voucherRepo.DeactivateByPrefix(ctx, prefix, deactivateChunkSize)
+			if err != nil {
+				return nil, fmt.Errorf("failed to deactivate vouchers: %w", err)
+			}
+			if rows == 0 {
+				break
+			}
+			result.Deactivated += rows
+		}
+		result.Matched = int(result.Deactivated)
+
+		logger.FromContext(ctx).Info("vouchers deactivated by prefix", "prefix", prefix, "deactivated", result.Deactivated)
+		return result, nil
+	}
+
+	for start := 0; start < len(codes); start += deactivateChunkSize {
+		end := start + deactivateChunkSize
+		if end > len(codes) {
+			end = len(codes)
+		}
+		chunk := codes[start:end]
+
+		existingCodes, err := s.voucherRepo.CheckDuplicateCodes(ctx, chunk)
+		if err != nil {
+			return nil, fmt.Errorf("failed to look up vouchers: %w", err)
+		}
+		existing := make(map[string]bool, len(existingCodes))
+		for _, code := range existingCodes {
+			existing[code] = true
+		}
+		for _, code := range chunk {
+			if !existing[code] {
+				result.MissingCodes = append(result.MissingCodes, code)
+			}
+		}
+		result.Matched += len(existingCodes)
+
+		if len(existingCodes) == 0 {
+			continue
+		}
+		rows, err := s.voucherRepo.DeactivateByCodes(ctx, existingCodes)
+		if err != nil {
+			return nil, fmt.Errorf("failed to deactivate vouchers: %w", err)
+		}
+		result.Deactivated += rows
+	}
+
+	logger.FromContext(ctx).Info("listed vouchers deactivated", "listed", len(codes), "deactivated", result.Deactivated)
+	return result, nil
+}
+
+// Validate checks whether a voucher can be applied to purchase
+func (s *voucherServiceImpl) Validate(ctx context.Context, code string, purchase rules.Context) (*domainService.ValidationResult, error) {
+	voucher, err := s.voucherRepo.FindByVoucherCode(ctx, code)
+	if err != nil {
+		return nil, err
+	}
+	if voucher == nil {
+		return nil, domainService.ErrVoucherNotFound
+	}
+
+	result := &domainService.ValidationResult{
+		VoucherID:       voucher.ID,
+		VoucherCode:     voucher.VoucherCode,
+		DiscountType:    voucher.DiscountType,
+		DiscountPercent: voucher.DiscountPercent,
+		DiscountAmount:  entity.FromMinorUnits(voucher.DiscountAmount, voucher.Currency),
+		Currency:        voucher.Currency,
+		Reasons:         []string{},
+	}
+
+	if voucher.Status != entity.VoucherStatusActive {
+		result.Reasons = append(result.Reasons, "voucher is not active")
+	}
+
+	now := time.Now()
+	if isExpired(voucher, now) {
+		result.Reasons = append(result.Reasons, "voucher has expired")
+	}
+
+	if remaining := voucher.RemainingRedemptions(); remaining != nil && *remaining == 0 {
+		result.Reasons = append(result.Reasons, "voucher has been fully redeemed")
+	}
+
+	conditions, err := rules.Parse(voucher.Rules)
+	if err != nil {
+		return nil, err
+	}
+	result.Reasons = append(result.Reasons, rules.Evaluate(conditions, purchase)...)
+
+	if voucher.SegmentID != nil {
+		reason, err := s.checkSegmentMembership(ctx, *voucher.SegmentID, purchase.CustomerID)
+		if err != nil {
+			return nil, err
+		}
+		if reason != "" {
+			result.Reasons = append(result.Reasons, reason)
+		}
+	}
+
+	if voucher.ValidityDays != nil {
+		reason, err := s.checkAssignment(ctx, voucher.ID, purchase.CustomerID, now)
+		if err != nil {
+			return nil, err
+		}
+		if reason != "" && !slices.Contains(result.Reasons, reason) {
+			result.Reasons = append(result.Reasons, reason)
+		}
+	}
+
+	result.Valid = len(result.Reasons) == 0
+
+	return result, nil
+}
+
+// isExpired reports whether the voucher's expiry date is before now's local date
+func isExpired(voucher *entity.Voucher, now time.Time) bool {
+	return isPastDate(voucher.ExpiryDate, now)
+}
+
+// isPastDate reports whether date's calendar day is before now's local date
+func isPastDate(date, now time.Time) bool {
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	dateLocal := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, now.Location())
+	return dateLocal.Before(today)
+}
+
+// checkAssignment returns why the customer may not use a voucher with
+// relative expiry, or an empty string when they may. Such a voucher is only
+// valid for customers it was assigned to, until their assignment expires.
+func (s *voucherServiceImpl) checkAssignment(ctx context.Context, voucherID uint, customerID string, now time.Time) (string, error) {
+	if customerID == "" {
+		return "customer id is required for this voucher", nil
+	}
+	if s.assignmentRepo == nil {
+		return "", errors.New("voucher assignments are not enabled")
+	}
+
+	// Validating does not create customers; one never seen has no assignments
+	customer, err := s.customers.Find(ctx, customerID)
+	if err != nil {
+		return "", err
+	}
+	if customer == nil {
+		return "voucher is not assigned to this customer", nil
+	}
+
+	assignment, err := s.assignmentRepo.FindByVoucherAndCustomer(ctx, voucherID, customer.ID)
+	if err != nil {
+		return "", fmt.Errorf("failed to look up voucher assignment: %w", err)
+	}
+	if assignment == nil {
+		return "voucher is not assigned to this customer", nil
+	}
+	if isPastDate(assignment.ExpiresAt, now) {
+		return "voucher has expired for this customer", nil
+	}
+	return "", nil
+}
+
+// Assign gives a voucher to a customer. The assignment expires the voucher's
+// validity days after today, but never after the voucher's expiry date; a
+// voucher without validity days is assigned until its expiry date.
+func (s *voucherServiceImpl) Assign(ctx context.Context, id uint, customerID, assignedBy string) (*entity.VoucherAssignment, error) {
+	if s.assignmentRepo == nil {
+		return nil, errors.New("voucher assignments are not enabled")
+	}
+	customerID = strings.TrimSpace(customerID)
+	if customerID == "" {
+		return nil, errors.New("customer id is required")
+	}
+
+	voucher, err := s.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	if voucher.Status != entity.VoucherStatusActive {
+		return nil, fmt.Errorf("%w: %s is %s", domainService.ErrVoucherNotAssignable, voucher.VoucherCode, voucher.Status)
+	}
+	if isExpired(voucher, now) {
+		return nil, fmt.Errorf("%w: %s has expired", domainService.ErrVoucherNotAssignable, voucher.VoucherCode)
+	}
+
+	customer, err := s.customers.Resolve(ctx, customerID)
+	if err != nil {
+		return nil, err
+	}
+
+	assignment := &entity.VoucherAssignment{
+		VoucherID:  voucher.ID,
+		CustomerID: customer.ID,
+		Customer:   customer,
+		AssignedAt: now,
+		ExpiresAt:  assignmentExpiry(voucher, now),
+		AssignedBy: assignedBy,
+	}
+	if err := s.assignmentRepo.Create(ctx, assignment); err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return nil, domainService.ErrVoucherAlreadyAssigned
+		}
+		return nil, err
+	}
+
+	logger.FromContext(ctx).Info("voucher assigned", "voucher_code", s.logCode(voucher.VoucherCode), "customer_id", customer.ID, "assigned_by", assignedBy, "expires_at", assignment.ExpiresAt.Format("2006-01-02"))
+
+	return assignment, nil
+}
+
+// assignmentExpiry returns the date an assignment made at now expires on
+func assignmentExpiry(voucher *entity.Voucher, now time.Time) time.Time {
+	expiryDate := time.Date(voucher.ExpiryDate.Year(), voucher.ExpiryDate.Month(), voucher.ExpiryDate.Day(), 0, 0, 0, 0, time.UTC)
+	if voucher.ValidityDays == nil {
+		return expiryDate
+	}
+	relative := time.Date(now.Year(), now.Month(), now.Day()+*voucher.ValidityDays, 0, 0, 0, 0, time.UTC)
+	if relative.Before(expiryDate) {
+		return relative
+	}
+	return expiryDate
+}
+
+// GetAssignments retrieves a page of a voucher's assignments, newest first
+func (s *voucherServiceImpl) GetAssignments(ctx context.Context, id uint, page, limit int) ([]*entity.VoucherAssignment, int64, error) {
+	if s.assignmentRepo == nil {
+		return nil, 0, errors.New("voucher assignments are not enabled")
+	}
+	if _, err := s.GetByID(ctx, id); err != nil {
+		return nil, 0, err
+	}
+	return s.assignmentRepo.FindByVoucher(ctx, id, page, limit)
+}
+
+// checkSegmentMembership returns why the customer may not use a voucher
+// targeting the segment, or an empty string when they may
+func (s *voucherServiceImpl) checkSegmentMembership(ctx context.Context, segmentID uint, customerID string) (string, error) {
+	if customerID == "" {
+		return "customer id is required for this voucher", nil
+	}
+	if s.segments == nil {
+		return "", errors.New("customer segments are not enabled")
+	}
+
+	member, err := s.segments.IsMember(ctx, segmentID, customerID)
+	if err != nil {
+		return "", fmt.Errorf("failed to check segment membership: %w", err)
+	}
+	if !member {
+		return "customer is not in the voucher's target segment", nil
+	}
+	return "", nil
+}
+
+// Approve activates a voucher pending approval. The approver must be known
+// and differ from whoever created, imported or last edited the voucher; when
+// neither is recorded nobody can approve it until it is edited.
+func (s *voucherServiceImpl) Approve(ctx context.Context, id uint, approvedBy string) (*entity.Voucher, error) {
+	voucher, err := s.voucherRepo.FindByID(ctx, id)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, domainService.ErrVoucherNotFound
+		}
+		return nil, err
+	}
+
+	if voucher.Status != entity.VoucherStatusPendingApproval {
+		return nil, domainService.ErrVoucherNotPendingApproval
+	}
+	if approvedBy == "" || voucher.UpdatedBy == "" || approvedBy == voucher.UpdatedBy || approvedBy == voucher.CreatedBy {
+		return nil, domainService.ErrSelfApproval
+	}
+
+	approvedAt := time.Now()
+	rowsAffected, err := s.voucherRepo.Approve(ctx, id, approvedBy, approvedAt)
 	if err != nil {
 		return nil, err
 	}
+	if rowsAffected == 0 {
+		// Another approver got there first
+		return nil, domainService.ErrVoucherNotPendingApproval
+	}
+
+	voucher.Status = entity.VoucherStatusActive
+	voucher.ApprovedBy = approvedBy
+	voucher.ApprovedAt = &approvedAt
+
+	logger.FromContext(ctx).Info("voucher approved", "voucher_code", s.logCode(voucher.VoucherCode), "approved_by", approvedBy)
+	approval := domainService.VoucherApproval{VoucherID: voucher.ID, ApprovedBy: approvedBy, ApprovedAt: approvedAt}
+	s.audit(ctx, entity.AuditActionVoucherApprove, approvedBy, approvalAudit{
+		VoucherApproval: approval,
+		VoucherCode:     s.logCode(voucher.VoucherCode),
+		ChangedBy:       voucher.UpdatedBy,
+	})
+	s.events.Publish(domainService.EventVoucherApproved, approval)
 
 	return voucher, nil
 }
 
-// Delete deletes a voucher by ID (soft delete)
-func (s *voucherServiceImpl) Delete(id uint) error {
-	// Check if voucher exists
-	_, err := s.voucherRepo.FindByID(id)
-	if err != nil {
-		if err == gorm.ErrRecordNotFound {
-			return errors.New("voucher not found")
+// approvalAudit is the audit entry detail recorded when a voucher is approved
+type approvalAudit struct {
+	domainService.VoucherApproval
+	VoucherCode string `json:"voucher_code"`
+	// ChangedBy created, imported or last edited the approved voucher
+	ChangedBy string `json:"changed_by"`
+}
+
+// requestApproval records that the vouchers with ids entered pending_approval
+// because of requestedBy, and tells approvers about them
+func (s *voucherServiceImpl) requestApproval(ctx context.Context, ids []uint, requestedBy string) {
+	if len(ids) == 0 {
+		return
+	}
+	request := domainService.ApprovalRequest{VoucherIDs: ids, RequestedBy: requestedBy}
+	s.audit(ctx, entity.AuditActionVoucherApprovalRequest, requestedBy, request)
+	s.events.Publish(domainService.EventApprovalRequested, request)
+}
+
+// pendingApprovalIDs returns the IDs of the saved vouchers waiting for approval
+func pendingApprovalIDs(vouchers []*entity.Voucher) []uint {
+	var ids []uint
+	for _, voucher := range vouchers {
+		if voucher.ID != 0 && voucher.Status == entity.VoucherStatusPendingApproval {
+			ids = append(ids, voucher.ID)
 		}
-		return err
 	}
+	return ids
+}
 
-	// Soft delete
-	return s.voucherRepo.Delete(id)
+// audit records an entry, when auditing is enabled. The change it describes
+// is already saved, so a failure to record it is logged rather than returned.
+func (s *voucherServiceImpl) audit(ctx context.Context, action, actor string, details interface{}) {
+	if s.auditRepo == nil {
+		return
+	}
+	encoded, err := json.Marshal(details)
+	if err == nil {
+		err = s.auditRepo.Create(ctx, &entity.AuditEntry{Action: action, Actor: actor, Details: string(encoded)})
+	}
+	if err != nil {
+		logger.FromContext(ctx).Error("failed to record audit entry", "action", action, "actor", actor, "error", err)
+	}
 }
 
-// ImportVouchers imports vouchers from CSV file
-func (s *voucherServiceImpl) ImportVouchers(file multipart.File) (*domainService.ImportResult, error) {
+// ImportVouchers imports vouchers from a CSV file in the given schema version,
+// or the version its header row implies when schemaVersion is 0
+func (s *voucherServiceImpl) ImportVouchers(ctx context.Context, file multipart.File, schemaVersion int, importedBy, role string) (*domainService.ImportResult, error) {
 	// Read CSV file
 	reader := csv.NewReader(file)
 	records, err := reader.ReadAll()
@@ -169,18 +1017,71 @@ func (s *voucherServiceImpl) ImportVouchers(file multipart.File) (*domainService
 		return nil, errors.New("CSV file is empty or has no data rows")
 	}
 
+	return s.importRecords(ctx, records, schemaVersion, importedBy, role)
+}
+
+// ImportRecords imports vouchers on behalf of importedBy from tabular rows
+// whose first row is a header, reading them in the schema version the header implies
+func (s *voucherServiceImpl) ImportRecords(ctx context.Context, records [][]string, importedBy, role string) (*domainService.ImportResult, error) {
+	return s.importRecords(ctx, records, 0, importedBy, role)
+}
+
+// importRecords imports the rows of records, rejecting those that break an
+// import rule or the discount limit of the importer's role
+func (s *voucherServiceImpl) importRecords(ctx context.Context, records [][]string, schemaVersion int, importedBy, role string) (*domainService.ImportResult, error) {
+	if len(records) < 2 {
+		return nil, errors.New("import data is empty or has no data rows")
+	}
+
+	schema, err := resolveCSVSchema(schemaVersion, records[0])
+	if err != nil {
+		return nil, err
+	}
+
+	importRules, err := s.loadImportRules(ctx)
+	if err != nil {
+		return nil, err
+	}
+	limit, err := s.discountLimit(ctx, role)
+	if err != nil {
+		return nil, err
+	}
+
 	result := &domainService.ImportResult{
-		TotalRows: len(records) - 1,
-		Errors:    []domainService.ImportError{},
+		SchemaVersion: schema.version,
+		TotalRows:     len(records) - 1,
+		Errors:        []domainService.ImportError{},
+	}
+	now := time.Now()
+
+	existing, err := s.findExistingImportKeys(ctx, schema, records[1:])
+	if err != nil {
+		return nil, err
 	}
 
 	var vouchers []*entity.Voucher
+	rowByCode := make(map[string]int)
+	seenExternalIDs := make(map[string]bool)
 
 	// Process each row (skip header)
 	for i, record := range records[1:] {
 		rowNum := i + 2
 
-		voucher, err := s.parseCSVRow(record, rowNum)
+		voucher, err := s.parseCSVRow(schema.fields(record), rowNum, existing)
+		if err == nil {
+			voucher.CreatedBy = importedBy
+			voucher.UpdatedBy = importedBy
+			err = checkImportRules(importRules, voucher, now)
+		}
+		if err == nil {
+			err = checkWithinLimit(limit, discountOf(voucher))
+		}
+		if err == nil && voucher.ExternalID != nil {
+			if seenExternalIDs[*voucher.ExternalID] {
+				err = fmt.Errorf("external id '%s' is repeated in the import", *voucher.ExternalID)
+			}
+			seenExternalIDs[*voucher.ExternalID] = true
+		}
 		if err != nil {
 			result.Errors = append(result.Errors, domainService.ImportError{
 				Row:   rowNum,
@@ -191,23 +1092,116 @@ func (s *voucherServiceImpl) ImportVouchers(file multipart.File) (*domainService
 		}
 
 		vouchers = append(vouchers, voucher)
+		rowByCode[voucher.VoucherCode] = rowNum
+		for _, warning := range importWarnings(voucher, now) {
+			result.Warnings = append(result.Warnings, domainService.ImportWarning{Row: rowNum, Warning: warning})
+		}
 	}
 
 	// Bulk insert valid vouchers
 	if len(vouchers) > 0 {
-		err = s.voucherRepo.BulkCreate(vouchers)
+		skippedCodes, err := s.bulkCreate(ctx, vouchers)
 		if err != nil {
 			return nil, fmt.Errorf("failed to insert vouchers: %w", err)
 		}
-		result.Success = len(vouchers)
+		skippedRows := make(map[int]bool, len(skippedCodes))
+		for _, code := range skippedCodes {
+			skippedRows[rowByCode[code]] = true
+			result.Errors = append(result.Errors, domainService.ImportError{
+				Row:   rowByCode[code],
+				Error: fmt.Sprintf("voucher code '%s' conflicts with a voucher created during the import", code),
+			})
+		}
+		result.Warnings = slices.DeleteFunc(result.Warnings, func(warning domainService.ImportWarning) bool {
+			return skippedRows[warning.Row]
+		})
+		result.Failed += len(skippedCodes)
+		result.Success = len(vouchers) - len(skippedCodes)
+		s.requestApproval(ctx, pendingApprovalIDs(vouchers), importedBy)
 	}
 
+	s.events.Publish(domainService.EventImportCompleted, result)
+
 	return result, nil
 }
 
-// parseCSVRow parses a single CSV row and returns a Voucher entity
-func (s *voucherServiceImpl) parseCSVRow(record []string, rowNum int) (*entity.Voucher, error) {
-	// Validate column count
+// existingImportKeys holds the voucher codes and external IDs of an import
+// that are already taken
+type existingImportKeys struct {
+	codes       map[string]bool
+	externalIDs map[string]bool
+}
+
+// findExistingImportKeys looks up which codes and external IDs of the rows
+// are already taken, with one query each rather than one per row
+func (s *voucherServiceImpl) findExistingImportKeys(ctx context.Context, schema csvSchema, rows [][]string) (*existingImportKeys, error) {
+	var codes, externalIDs []string
+	for _, record := range rows {
+		fields := schema.fields(record)
+		if code := optionalColumn(fields, 0); code != "" {
+			codes = append(codes, code)
+		}
+		if externalID := optionalColumn(fields, 3); externalID != "" {
+			externalIDs = append(externalIDs, externalID)
+		}
+	}
+
+	existing := &existingImportKeys{codes: make(map[string]bool), externalIDs: make(map[string]bool)}
+	if len(codes) > 0 {
+		existingCodes, err := s.voucherRepo.CheckDuplicateCodes(ctx, codes)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check voucher codes: %w", err)
+		}
+		for _, code := range existingCodes {
+			existing.codes[code] = true
+		}
+	}
+	if len(externalIDs) > 0 {
+		existingIDs, err := s.voucherRepo.CheckDuplicateExternalIDs(ctx, externalIDs)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check external ids: %w", err)
+		}
+		for _, id := range existingIDs {
+			existing.externalIDs[id] = true
+		}
+	}
+	return existing, nil
+}
+
+// importWarnings describes what looks suspicious about an otherwise valid
+// imported voucher, so operators can double-check it
+func importWarnings(voucher *entity.Voucher, now time.Time) []string {
+	var warnings []string
+	if entity.DiscountHundredths(voucher.DiscountPercent) == entity.DiscountHundredths(100) {
+		warnings = append(warnings, fmt.Sprintf("voucher %s gives a 100%% discount", voucher.VoucherCode))
+	}
+	if voucher.ExpiryDate.After(now.AddDate(importWarningExpiryYears, 0, 0)) {
+		warnings = append(warnings, fmt.Sprintf("voucher %s expires on %s, more than %d years away",
+			voucher.VoucherCode, voucher.ExpiryDate.Format("2006-01-02"), importWarningExpiryYears))
+	}
+	return warnings
+}
+
+// bulkCreate inserts vouchers in one statement. If that hits a unique
+// violation, e.g. when racing another import, it retries row by row skipping
+// conflicts and returns the codes that were skipped.
+func (s *voucherServiceImpl) bulkCreate(ctx context.Context, vouchers []*entity.Voucher) ([]string, error) {
+	err := s.voucherRepo.BulkCreate(ctx, vouchers)
+	if err == nil {
+		return nil, nil
+	}
+	if !errors.Is(err, gorm.ErrDuplicatedKey) {
+		return nil, err
+	}
+
+	logger.FromContext(ctx).Warn("bulk voucher insert hit a conflict, retrying row by row", "vouchers", len(vouchers))
+	return s.voucherRepo.BulkCreateSkipConflicts(ctx, vouchers)
+}
+
+// parseCSVRow parses a single CSV row, trimmed to the columns of its schema
+// version, and returns a Voucher entity
+func (s *voucherServiceImpl) parseCSVRow(record []string, rowNum int, existing *existingImportKeys) (*entity.Voucher, error) {
+	// Validate column count; the columns added by later schema versions are optional
 	if len(record) < 3 {
 		return nil, fmt.Errorf("insufficient columns (expected 3: voucher_code, discount_percent, expiry_date)")
 	}
@@ -220,23 +1214,31 @@ func (s *voucherServiceImpl) parseCSVRow(record []string, rowNum int) (*entity.V
 		return nil, errors.New("voucher code exceeds 50 characters")
 	}
 
-	// Check if voucher code already exists
-	existing, err := s.voucherRepo.FindByVoucherCode(voucherCode)
-	if err != nil && err != gorm.ErrRecordNotFound {
-		return nil, fmt.Errorf("failed to check voucher code: %w", err)
-	}
-	if existing != nil {
+	if existing.codes[voucherCode] {
 		return nil, fmt.Errorf("voucher code '%s' already exists", voucherCode)
 	}
 
-	// Parse discount percent
+	// Parse the discount; fixed-amount rows may leave discount_percent empty
+	discountType := optionalColumn(record, 7)
 	discountStr := strings.TrimSpace(record[1])
-	discountPercent, err := strconv.ParseFloat(discountStr, 64)
-	if err != nil {
-		return nil, fmt.Errorf("invalid discount percent '%s': must be a number", discountStr)
+	var discountPercent float64
+	var err error
+	if discountStr != "" || discountType != entity.DiscountTypeFixedAmount {
+		discountPercent, err = strconv.ParseFloat(discountStr, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid discount percent '%s': must be a number", discountStr)
+		}
+	}
+	var discountAmount float64
+	if amountStr := optionalColumn(record, 8); amountStr != "" {
+		discountAmount, err = strconv.ParseFloat(amountStr, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid discount amount '%s': must be a number", amountStr)
+		}
 	}
-	if discountPercent < 1 || discountPercent > 100 {
-		return nil, fmt.Errorf("discount percent %.2f out of range (must be 1-100)", discountPercent)
+	discount, err := validateDiscount(discountType, discountPercent, discountAmount, optionalColumn(record, 9))
+	if err != nil {
+		return nil, err
 	}
 
 	// Parse expiry date
@@ -246,43 +1248,137 @@ func (s *voucherServiceImpl) parseCSVRow(record []string, rowNum int) (*entity.V
 		return nil, fmt.Errorf("invalid date format '%s': expected YYYY-MM-DD", expiryDateStr)
 	}
 
-	// Validate expiry date is in the future
-	today := time.Now().Truncate(24 * time.Hour)
-	if expiryDate.Before(today) {
+	// Validate expiry date is today or in the future, in local time like Create
+	if isPastDate(expiryDate, time.Now()) {
 		return nil, fmt.Errorf("expiry date %s must be today or in the future", expiryDateStr)
 	}
 
+	// Parse optional external ID
+	externalID, err := parseExternalID(optionalColumn(record, 3))
+	if err != nil {
+		return nil, err
+	}
+	if externalID != nil && existing.externalIDs[*externalID] {
+		return nil, fmt.Errorf("external id '%s' already exists", *externalID)
+	}
+
+	// Parse optional display metadata
+	displayName := optionalColumn(record, 4)
+	description := optionalColumn(record, 5)
+	termsURL := optionalColumn(record, 6)
+	if err := validateDisplayMetadata(displayName, description, termsURL); err != nil {
+		return nil, err
+	}
+
 	voucher := &entity.Voucher{
 		VoucherCode:     voucherCode,
-		DiscountPercent: discountPercent,
+		ExternalID:      externalID,
+		DiscountType:    discount.discountType,
+		DiscountPercent: discount.percent,
+		DiscountAmount:  discount.amount,
+		Currency:        discount.currency,
 		ExpiryDate:      expiryDate,
+		Status:          s.initialStatus(discount),
+		DisplayName:     displayName,
+		Description:     description,
+		TermsURL:        termsURL,
 	}
 
 	return voucher, nil
 }
 
-// ExportVouchers exports all vouchers to CSV format
-func (s *voucherServiceImpl) ExportVouchers() ([]byte, error) {
-	vouchers, _, err := s.voucherRepo.FindAll(1, 100000, "", "created_at", "asc")
+// ExportVouchers exports all vouchers to CSV format in the given schema version
+func (s *voucherServiceImpl) ExportVouchers(ctx context.Context, schemaVersion int) ([]byte, error) {
+	schema, err := lookupCSVSchema(schemaVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	vouchers, _, err := s.voucherRepo.FindAll(ctx, 1, 100000, "", []utils.SortField{{Field: "created_at"}})
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch vouchers: %w", err)
 	}
 
+	return writeCSV(schema.header(), vouchers, schema.record)
+}
+
+// ExportVoucherParts exports all vouchers as CSV files of at most rowsPerFile
+// rows each, passing every file to emit as soon as it is written
+func (s *voucherServiceImpl) ExportVoucherParts(ctx context.Context, rowsPerFile, schemaVersion int, emit func(data []byte) error) error {
+	if rowsPerFile < 1 {
+		return errors.New("rows per file must be at least 1")
+	}
+	schema, err := lookupCSVSchema(schemaVersion)
+	if err != nil {
+		return err
+	}
+
+	// id breaks created_at ties so pages neither overlap nor skip rows
+	sort := []utils.SortField{{Field: "created_at"}, {Field: "id"}}
+	for page := 1; ; page++ {
+		vouchers, _, err := s.voucherRepo.FindAll(ctx, page, rowsPerFile, "", sort)
+		if err != nil {
+			return fmt.Errorf("failed to fetch vouchers: %w", err)
+		}
+		if len(vouchers) == 0 && page > 1 {
+			return nil
+		}
+
+		data, err := writeCSV(schema.header(), vouchers, schema.record)
+		if err != nil {
+			return err
+		}
+		if err := emit(data); err != nil {
+			return err
+		}
+
+		if len(vouchers) < rowsPerFile {
+			return nil
+		}
+	}
+}
+
+// ExportVoucherChanges exports vouchers created, updated or deleted after since.
+// Deleted vouchers are kept as tombstone rows with deleted set to true.
+func (s *voucherServiceImpl) ExportVoucherChanges(ctx context.Context, since time.Time) ([]byte, error) {
+	var changed []*entity.Voucher
+	var afterID uint
+	for {
+		vouchers, err := s.voucherRepo.FindChangedSince(ctx, since, afterID, changeExportChunkSize)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch changed vouchers: %w", err)
+		}
+		changed = append(changed, vouchers...)
+		if len(vouchers) < changeExportChunkSize {
+			break
+		}
+		afterID = vouchers[len(vouchers)-1].ID
+	}
+
+	// Delta exports always use the current schema, with the change columns after it
+	schema := csvSchemas[domainService.CurrentCSVSchemaVersion]
+	header := append(schema.header(), "changed_at", "deleted")
+	return writeCSV(header, changed, func(voucher *entity.Voucher) []string {
+		changedAt := voucher.UpdatedAt
+		deleted := voucher.DeletedAt.Valid
+		if deleted && voucher.DeletedAt.Time.After(changedAt) {
+			changedAt = voucher.DeletedAt.Time
+		}
+		return append(schema.record(voucher), changedAt.UTC().Format(time.RFC3339Nano), strconv.FormatBool(deleted))
+	})
+}
+
+// writeCSV renders a header and one record per voucher
+func writeCSV(header []string, vouchers []*entity.Voucher, record func(*entity.Voucher) []string) ([]byte, error) {
 	var buf bytes.Buffer
 	writer := csv.NewWriter(&buf)
 
-	header := []string{"voucher_code", "discount_percent", "expiry_date"}
 	if err := writer.Write(header); err != nil {
 		return nil, fmt.Errorf("failed to write CSV header: %w", err)
 	}
 
 	for _, voucher := range vouchers {
-		record := []string{
-			voucher.VoucherCode,
-			fmt.Sprintf("%.2f", voucher.DiscountPercent),
-			voucher.ExpiryDate.Format("2006-01-02"),
-		}
-		if err := writer.Write(record); err != nil {
+		if err := writer.Write(record(voucher)); err != nil {
 			return nil, fmt.Errorf("failed to write CSV row: %w", err)
 		}
 	}
@@ -295,13 +1391,36 @@ func (s *voucherServiceImpl) ExportVouchers() ([]byte, error) {
 	return buf.Bytes(), nil
 }
 
-// ImportBatch imports a batch of vouchers with duplicate checking
-func (s *voucherServiceImpl) ImportBatch(vouchers []request.CreateVoucherRequest) (*domainService.BatchImportResult, error) {
+// ImportBatch imports a batch of vouchers with duplicate checking. Every
+// voucher is checked against the discount limit of its creator's role.
+func (s *voucherServiceImpl) ImportBatch(ctx context.Context, vouchers []domainService.CreateVoucherCommand) (*domainService.BatchImportResult, error) {
+	importRules, err := s.loadImportRules(ctx)
+	if err != nil {
+		return nil, err
+	}
+	limits := make(map[string]*entity.DiscountLimit)
+
 	result := &domainService.BatchImportResult{
 		TotalReceived:  len(vouchers),
 		DuplicateCodes: []string{},
 		Errors:         []string{},
 	}
+	now := time.Now()
+
+	// Step 0: Complete vouchers that use a template, so generated codes are duplicate-checked too
+	completed := make([]domainService.CreateVoucherCommand, 0, len(vouchers))
+	for _, v := range vouchers {
+		if v.TemplateID != nil {
+			applied, err := s.applyTemplate(ctx, &v)
+			if err != nil {
+				result.Errors = append(result.Errors, fmt.Sprintf("Code %s: %s", v.VoucherCode, err.Error()))
+				continue
+			}
+			v = *applied
+		}
+		completed = append(completed, v)
+	}
+	vouchers = completed
 
 	// Step 1: Extract all voucher codes
 	voucherCodes := make([]string, len(vouchers))
@@ -310,7 +1429,7 @@ func (s *voucherServiceImpl) ImportBatch(vouchers []request.CreateVoucherRequest
 	}
 
 	// Step 2: Check duplicates with IN query
-	existingCodes, err := s.voucherRepo.CheckDuplicateCodes(voucherCodes)
+	existingCodes, err := s.voucherRepo.CheckDuplicateCodes(ctx, voucherCodes)
 	if err != nil {
 		return nil, err
 	}
@@ -321,71 +1440,388 @@ func (s *voucherServiceImpl) ImportBatch(vouchers []request.CreateVoucherRequest
 		duplicateMap[code] = true
 	}
 
+	// Step 3b: Check external IDs the same way
+	var externalIDs []string
+	for _, v := range vouchers {
+		if id := strings.TrimSpace(v.ExternalID); id != "" {
+			externalIDs = append(externalIDs, id)
+		}
+	}
+	usedExternalIDs := make(map[string]bool)
+	if len(externalIDs) > 0 {
+		existingIDs, err := s.voucherRepo.CheckDuplicateExternalIDs(ctx, externalIDs)
+		if err != nil {
+			return nil, err
+		}
+		for _, id := range existingIDs {
+			usedExternalIDs[id] = true
+		}
+	}
+
 	// Step 4: Filter valid vouchers
 	validVouchers := []*entity.Voucher{}
-	for _, voucherReq := range vouchers {
+	for _, voucherCmd := range vouchers {
 		// Check if duplicate
-		if duplicateMap[voucherReq.VoucherCode] {
+		if duplicateMap[voucherCmd.VoucherCode] {
 			result.Duplicates++
-			result.DuplicateCodes = append(result.DuplicateCodes, voucherReq.VoucherCode)
+			result.DuplicateCodes = append(result.DuplicateCodes, voucherCmd.VoucherCode)
 			continue
 		}
 
 		// Validate and convert
-		voucher, err := s.validateAndConvert(&voucherReq)
+		voucher, err := s.validateAndConvert(ctx, &voucherCmd)
+		if err == nil {
+			err = checkImportRules(importRules, voucher, now)
+		}
+		if err == nil {
+			limit, ok := limits[voucherCmd.Role]
+			if !ok {
+				if limit, err = s.discountLimit(ctx, voucherCmd.Role); err != nil {
+					return nil, err
+				}
+				limits[voucherCmd.Role] = limit
+			}
+			err = checkWithinLimit(limit, discountOf(voucher))
+		}
+		if err == nil && voucher.ExternalID != nil && usedExternalIDs[*voucher.ExternalID] {
+			err = domainService.ErrDuplicateExternalID
+		}
 		if err != nil {
 			result.Errors = append(result.Errors,
-				fmt.Sprintf("Code %s: %s", voucherReq.VoucherCode, err.Error()))
+				fmt.Sprintf("Code %s: %s", voucherCmd.VoucherCode, err.Error()))
 			continue
 		}
+		if voucher.ExternalID != nil {
+			usedExternalIDs[*voucher.ExternalID] = true
+		}
 
 		validVouchers = append(validVouchers, voucher)
 	}
 
-	// Step 5: Bulk insert valid vouchers
+	// Step 5: Bulk insert valid vouchers; codes created concurrently count as duplicates
 	if len(validVouchers) > 0 {
-		err = s.voucherRepo.BulkCreate(validVouchers)
+		skippedCodes, err := s.bulkCreate(ctx, validVouchers)
 		if err != nil {
 			return nil, err
 		}
-		result.Inserted = len(validVouchers)
+		result.Duplicates += len(skippedCodes)
+		result.DuplicateCodes = append(result.DuplicateCodes, skippedCodes...)
+		result.Inserted = len(validVouchers) - len(skippedCodes)
+		// A batch is imported by one user, who created every voucher in it
+		s.requestApproval(ctx, pendingApprovalIDs(validVouchers), validVouchers[0].CreatedBy)
 	}
 
+	s.events.Publish(domainService.EventBatchImportCompleted, result)
+
 	return result, nil
 }
 
-// validateAndConvert validates a voucher request and converts it to entity
-func (s *voucherServiceImpl) validateAndConvert(req *request.CreateVoucherRequest) (*entity.Voucher, error) {
+// validateAndConvert validates a voucher command and converts it to entity
+func (s *voucherServiceImpl) validateAndConvert(ctx context.Context, cmd *domainService.CreateVoucherCommand) (*entity.Voucher, error) {
 	// Validate voucher code
-	if req.VoucherCode == "" {
+	if cmd.VoucherCode == "" {
 		return nil, errors.New("voucher code is required")
 	}
-	if len(req.VoucherCode) > 50 {
+	if len(cmd.VoucherCode) > 50 {
 		return nil, errors.New("voucher code exceeds 50 characters")
 	}
 
-	// Validate discount percent
-	if req.DiscountPercent < 1 || req.DiscountPercent > 100 {
-		return nil, fmt.Errorf("discount percent %.2f out of range (must be 1-100)", req.DiscountPercent)
+	discount, err := validateDiscount(cmd.DiscountType, cmd.DiscountPercent, cmd.DiscountAmount, cmd.Currency)
+	if err != nil {
+		return nil, err
 	}
 
 	// Parse expiry date
-	expiryDate, err := time.Parse("2006-01-02", req.ExpiryDate)
+	expiryDate, err := time.Parse("2006-01-02", cmd.ExpiryDate)
+	if err != nil {
+		return nil, fmt.Errorf("invalid date format '%s': expected YYYY-MM-DD", cmd.ExpiryDate)
+	}
+
+	// Validate expiry date is today or in the future, in local time like Create
+	if isPastDate(expiryDate, time.Now()) {
+		return nil, fmt.Errorf("expiry date %s must be today or in the future", cmd.ExpiryDate)
+	}
+
+	externalID, err := parseExternalID(cmd.ExternalID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := validateValidityDays(cmd.ValidityDays); err != nil {
+		return nil, err
+	}
+
+	maxRedemptionsPerUser, err := validateRedemptionLimits(cmd.MaxRedemptions, cmd.MaxRedemptionsPerUser)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := validateDisplayMetadata(cmd.DisplayName, cmd.Description, cmd.TermsURL); err != nil {
+		return nil, err
+	}
+
+	metadata, err := encodeMetadata(cmd.Metadata)
 	if err != nil {
-		return nil, fmt.Errorf("invalid date format '%s': expected YYYY-MM-DD", req.ExpiryDate)
+		return nil, err
 	}
 
-	// Validate expiry date is in the future
-	today := time.Now().Truncate(24 * time.Hour)
-	if expiryDate.Before(today) {
-		return nil, fmt.Errorf("expiry date %s must be today or in the future", req.ExpiryDate)
+	// Check the targeted segment exists
+	if err := s.checkSegment(ctx, cmd.SegmentID); err != nil {
+		return nil, err
 	}
 
 	voucher := &entity.Voucher{
-		VoucherCode:     req.VoucherCode,
-		DiscountPercent: req.DiscountPercent,
-		ExpiryDate:      expiryDate,
+		VoucherCode:           cmd.VoucherCode,
+		ExternalID:            externalID,
+		DiscountType:          discount.discountType,
+		DiscountPercent:       discount.percent,
+		DiscountAmount:        discount.amount,
+		Currency:              discount.currency,
+		ExpiryDate:            expiryDate,
+		ValidityDays:          cmd.ValidityDays,
+		MaxRedemptions:        cmd.MaxRedemptions,
+		MaxRedemptionsPerUser: maxRedemptionsPerUser,
+		Status:                s.initialStatus(discount),
+		DisplayName:           cmd.DisplayName,
+		Description:           cmd.Description,
+		TermsURL:              cmd.TermsURL,
+		Metadata:              metadata,
+		SegmentID:             cmd.SegmentID,
+		CreatedBy:             cmd.CreatedBy,
+		UpdatedBy:             cmd.CreatedBy,
 	}
 
 	return voucher, nil
 }
+
+// parseExternalID trims and validates an optional external ID; empty means none
+func parseExternalID(raw string) (*string, error) {
+	externalID := strings.TrimSpace(raw)
+	if externalID == "" {
+		return nil, nil
+	}
+	if len(externalID) > entity.MaxExternalIDLength {
+		return nil, fmt.Errorf("external id exceeds %d characters", entity.MaxExternalIDLength)
+	}
+	return &externalID, nil
+}
+
+// validateRedemptionLimits checks the optional total and per-customer
+// redemption caps and returns the per-customer cap to store, defaulting 0 to
+// entity.DefaultMaxRedemptionsPerUser
+func validateRedemptionLimits(maxRedemptions *int, maxPerUser int) (int, error) {
+	if maxRedemptions != nil && *maxRedemptions < 1 {
+		return 0, errors.New("max redemptions must be at least 1")
+	}
+	if maxPerUser < 0 {
+		return 0, errors.New("max redemptions per user must be at least 1")
+	}
+	if maxPerUser == 0 {
+		maxPerUser = entity.DefaultMaxRedemptionsPerUser
+	}
+	if maxRedemptions != nil && maxPerUser > *maxRedemptions {
+		return 0, errors.New("max redemptions per user cannot exceed max redemptions")
+	}
+	return maxPerUser, nil
+}
+
+// validateDiscountPercent checks a discount percent is in range and fits the
+// two decimal places it is stored with, so it is never silently rounded
+func validateDiscountPercent(percent float64) error {
+	if percent < entity.MinDiscountPercent || percent > entity.MaxDiscountPercent {
+		return fmt.Errorf("discount percent %.2f out of range (must be 1-100)", percent)
+	}
+	if !entity.HasDiscountPercentScale(percent) {
+		return fmt.Errorf("discount percent %v has more than %d decimal places", percent, entity.DiscountPercentScale)
+	}
+	return nil
+}
+
+// validateDiscountAmount checks a fixed discount amount is in range and fits
+// the decimal places of its currency, and returns it in minor units so it is
+// never silently rounded
+func validateDiscountAmount(amount float64, currency string) (int64, error) {
+	if amount <= 0 || amount >= entity.MaxDiscountAmount {
+		return 0, fmt.Errorf("discount amount %v out of range (must be above 0 and below %d)", amount, int64(entity.MaxDiscountAmount))
+	}
+	minor, err := entity.ToMinorUnits(amount, currency)
+	if err != nil {
+		return 0, fmt.Errorf("invalid discount amount: %w", err)
+	}
+	return minor, nil
+}
+
+// voucherDiscount is a validated discount, as stored on a voucher: fixed
+// amounts are in minor units of the currency
+type voucherDiscount struct {
+	discountType string
+	percent      float64
+	amount       int64
+	currency     string
+}
+
+// validateDiscount checks a discount of the given type and returns it
+// normalized: an empty type is a percent discount, the currency code is upper
+// case and the amount is in minor units. Percent discounts have no amount or
+// currency, fixed-amount discounts no percent.
+func validateDiscount(discountType string, percent, amount float64, currency string) (voucherDiscount, error) {
+	currency = strings.ToUpper(strings.TrimSpace(currency))
+
+	switch discountType {
+	case "", entity.DiscountTypePercent:
+		if amount != 0 || currency != "" {
+			return voucherDiscount{}, fmt.Errorf("discount amount and currency only apply to %s discounts", entity.DiscountTypeFixedAmount)
+		}
+		if err := validateDiscountPercent(percent); err != nil {
+			return voucherDiscount{}, err
+		}
+		return voucherDiscount{discountType: entity.DiscountTypePercent, percent: percent}, nil
+	case entity.DiscountTypeFixedAmount:
+		if percent != 0 {
+			return voucherDiscount{}, fmt.Errorf("discount percent does not apply to %s discounts", entity.DiscountTypeFixedAmount)
+		}
+		if !isCurrencyCode(currency) {
+			return voucherDiscount{}, fmt.Errorf("invalid currency '%s': must be a 3-letter ISO 4217 code such as IDR", currency)
+		}
+		minor, err := validateDiscountAmount(amount, currency)
+		if err != nil {
+			return voucherDiscount{}, err
+		}
+		return voucherDiscount{discountType: entity.DiscountTypeFixedAmount, amount: minor, currency: currency}, nil
+	default:
+		return voucherDiscount{}, fmt.Errorf("discount type must be %s or %s", entity.DiscountTypePercent, entity.DiscountTypeFixedAmount)
+	}
+}
+
+// isCurrencyCode reports whether code has the form of an ISO 4217 code: three upper-case letters
+func isCurrencyCode(code string) bool {
+	if len(code) != 3 {
+		return false
+	}
+	for _, r := range code {
+		if r < 'A' || r > 'Z' {
+			return false
+		}
+	}
+	return true
+}
+
+// formatDiscount describes a voucher's discount for customers, e.g. "12.5%" or
+// "IDR 50,000"; the minor unit is only shown when there is any
+func formatDiscount(voucher *entity.Voucher) string {
+	if !voucher.IsFixedAmount() {
+		return strconv.FormatFloat(voucher.DiscountPercent, 'f', -1, 64) + "%"
+	}
+
+	amount := entity.FormatMinorUnits(voucher.DiscountAmount, voucher.Currency)
+	whole, cents, _ := strings.Cut(amount, ".")
+	var grouped strings.Builder
+	for i, digit := range whole {
+		if i > 0 && (len(whole)-i)%3 == 0 {
+			grouped.WriteByte(',')
+		}
+		grouped.WriteRune(digit)
+	}
+	if strings.Trim(cents, "0") != "" {
+		grouped.WriteString("." + cents)
+	}
+	return voucher.Currency + " " + grouped.String()
+}
+
+// validateValidityDays checks an optional relative expiry; nil means the
+// voucher is valid until its expiry date for everyone
+func validateValidityDays(days *int) error {
+	if days == nil {
+		return nil
+	}
+	if *days < 1 || *days > entity.MaxValidityDays {
+		return fmt.Errorf("validity days must be between 1 and %d", entity.MaxValidityDays)
+	}
+	return nil
+}
+
+// validateDisplayMetadata checks the customer-facing name, description and terms link
+func validateDisplayMetadata(displayName, description, termsURL string) error {
+	if len(displayName) > entity.MaxDisplayNameLength {
+		return fmt.Errorf("display name exceeds %d characters", entity.MaxDisplayNameLength)
+	}
+	if len(description) > entity.MaxDescriptionLength {
+		return fmt.Errorf("description exceeds %d characters", entity.MaxDescriptionLength)
+	}
+	if termsURL == "" {
+		return nil
+	}
+	if len(termsURL) > entity.MaxTermsURLLength {
+		return fmt.Errorf("terms url exceeds %d characters", entity.MaxTermsURLLength)
+	}
+	parsed, err := url.Parse(termsURL)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return fmt.Errorf("invalid terms url '%s': must be an absolute http(s) URL", termsURL)
+	}
+	return nil
+}
+
+// optionalColumn returns the trimmed value of an optional column, or "" when the row is shorter
+func optionalColumn(record []string, index int) string {
+	if len(record) <= index {
+		return ""
+	}
+	return strings.TrimSpace(record[index])
+}
+
+// encodeMetadata validates free-form metadata and serializes it for storage; empty means none
+func encodeMetadata(metadata map[string]string) (string, error) {
+	if len(metadata) == 0 {
+		return "", nil
+	}
+	if len(metadata) > entity.MaxMetadataKeys {
+		return "", fmt.Errorf("metadata has more than %d keys", entity.MaxMetadataKeys)
+	}
+	for key := range metadata {
+		if strings.TrimSpace(key) == "" {
+			return "", errors.New("metadata keys must not be empty")
+		}
+	}
+
+	data, err := json.Marshal(metadata)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// RenderPDF renders the requested vouchers as a printable PDF. Vouchers that
+// are not active, or have expired, are refused rather than printed, since
+// printed copies cannot be recalled.
+func (s *voucherServiceImpl) RenderPDF(ctx context.Context, ids []uint) ([]byte, error) {
+	if err := checkBatchLookupSize(len(ids)); err != nil {
+		return nil, err
+	}
+
+	found, err := s.voucherRepo.FindByIDs(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch vouchers: %w", err)
+	}
+	byID := make(map[uint]*entity.Voucher, len(found))
+	for _, voucher := range found {
+		byID[voucher.ID] = voucher
+	}
+
+	now := time.Now()
+	vouchers := make([]*entity.Voucher, 0, len(ids))
+	for _, id := range ids {
+		voucher, ok := byID[id]
+		if !ok {
+			return nil, fmt.Errorf("%w: %d", domainService.ErrVoucherNotFound, id)
+		}
+		if voucher.Status != entity.VoucherStatusActive {
+			return nil, fmt.Errorf("%w: %s is %s", domainService.ErrVoucherNotPrintable, voucher.VoucherCode, voucher.Status)
+		}
+		if isExpired(voucher, now) {
+			return nil, fmt.Errorf("%w: %s has expired", domainService.ErrVoucherNotPrintable, voucher.VoucherCode)
+		}
+		vouchers = append(vouchers, voucher)
+	}
+
+	return renderVoucherPDF(vouchers)
+}
@@ -1,31 +1,52 @@
 package service
 
 import (
+	"bytes"
+	"context"
 	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"mime/multipart"
+	"strings"
 	"testing"
 	"time"
 
-	"github.com/shoelfikar/voucher-management-system/internal/delivery/http/request"
 	"github.com/shoelfikar/voucher-management-system/internal/domain/entity"
+	"github.com/shoelfikar/voucher-management-system/internal/domain/repository"
+	domainService "github.com/shoelfikar/voucher-management-system/internal/domain/service"
+	"github.com/shoelfikar/voucher-management-system/pkg/events"
+	"github.com/shoelfikar/voucher-management-system/pkg/rules"
+	"github.com/shoelfikar/voucher-management-system/pkg/utils"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"gorm.io/gorm"
 )
 
-// MockVoucherRepository is a mock implementation of VoucherRepository
+// MockVoucherRepository is a mock implementation of VoucherRepository.
+// Calls are matched on their arguments after the context.
 type MockVoucherRepository struct {
 	mock.Mock
 }
 
-func (m *MockVoucherRepository) FindAll(page, limit int, search, sortBy, sortOrder string) ([]*entity.Voucher, int64, error) {
-	args := m.Called(page, limit, search, sortBy, sortOrder)
+func (m *MockVoucherRepository) FindAll(ctx context.Context, page, limit int, search string, sort []utils.SortField) ([]*entity.Voucher, int64, error) {
+	args := m.Called(page, limit, search, sort)
 	if args.Get(0) == nil {
 		return nil, args.Get(1).(int64), args.Error(2)
 	}
 	return args.Get(0).([]*entity.Voucher), args.Get(1).(int64), args.Error(2)
 }
 
-func (m *MockVoucherRepository) FindByID(id uint) (*entity.Voucher, error) {
+func (m *MockVoucherRepository) FindAllByPartner(ctx context.Context, partnerID uint, page, limit int, search string, sort []utils.SortField) ([]*entity.Voucher, int64, error) {
+	args := m.Called(partnerID, page, limit, search, sort)
+	if args.Get(0) == nil {
+		return nil, args.Get(1).(int64), args.Error(2)
+	}
+	return args.Get(0).([]*entity.Voucher), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockVoucherRepository) FindByID(ctx context.Context, id uint) (*entity.Voucher, error) {
 	args := m.Called(id)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
@@ -33,22 +54,27 @@ func (m *MockVoucherRepository) FindByID(id uint) (*entity.Voucher, error) {
 	return args.Get(0).(*entity.Voucher), args.Error(1)
 }
 
-func (m *MockVoucherRepository) Create(voucher *entity.Voucher) error {
+func (m *MockVoucherRepository) Create(ctx context.Context, voucher *entity.Voucher) error {
 	args := m.Called(voucher)
 	return args.Error(0)
 }
 
-func (m *MockVoucherRepository) Update(voucher *entity.Voucher) error {
+func (m *MockVoucherRepository) Update(ctx context.Context, voucher *entity.Voucher) (int64, error) {
 	args := m.Called(voucher)
-	return args.Error(0)
+	return args.Get(0).(int64), args.Error(1)
 }
 
-func (m *MockVoucherRepository) Delete(id uint) error {
+func (m *MockVoucherRepository) Delete(ctx context.Context, id uint) (int64, error) {
 	args := m.Called(id)
-	return args.Error(0)
+	return args.Get(0).(int64), args.Error(1)
 }
 
-func (m *MockVoucherRepository) FindByVoucherCode(code string) (*entity.Voucher, error) {
+func (m *MockVoucherRepository) Approve(ctx context.Context, id uint, approvedBy string, approvedAt time.Time) (int64, error) {
+	args := m.Called(id, approvedBy, approvedAt)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockVoucherRepository) FindByVoucherCode(ctx context.Context, code string) (*entity.Voucher, error) {
 	args := m.Called(code)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
@@ -56,12 +82,22 @@ func (m *MockVoucherRepository) FindByVoucherCode(code string) (*entity.Voucher,
 	return args.Get(0).(*entity.Voucher), args.Error(1)
 }
 
-func (m *MockVoucherRepository) BulkCreate(vouchers []*entity.Voucher) error {
+func (m *MockVoucherRepository) FindByIDs(ctx context.Context, ids []uint) ([]*entity.Voucher, error) {
+	args := m.Called(ids)
+	return args.Get(0).([]*entity.Voucher), args.Error(1)
+}
+
+func (m *MockVoucherRepository) FindByVoucherCodes(ctx context.Context, codes []string) ([]*entity.Voucher, error) {
+	args := m.Called(codes)
+	return args.Get(0).([]*entity.Voucher), args.Error(1)
+}
+
+func (m *MockVoucherRepository) BulkCreate(ctx context.Context, vouchers []*entity.Voucher) error {
 	args := m.Called(vouchers)
 	return args.Error(0)
 }
 
-func (m *MockVoucherRepository) CheckDuplicateCodes(codes []string) ([]string, error) {
+func (m *MockVoucherRepository) CheckDuplicateCodes(ctx context.Context, codes []string) ([]string, error) {
 	args := m.Called(codes)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
@@ -69,14 +105,154 @@ func (m *MockVoucherRepository) CheckDuplicateCodes(codes []string) ([]string, e
 	return args.Get(0).([]string), args.Error(1)
 }
 
+func (m *MockVoucherRepository) SuggestCodes(ctx context.Context, prefix string, limit int) ([]string, error) {
+	args := m.Called(prefix, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (m *MockVoucherRepository) FindByExternalID(ctx context.Context, externalID string) (*entity.Voucher, error) {
+	args := m.Called(externalID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Voucher), args.Error(1)
+}
+
+func (m *MockVoucherRepository) CheckDuplicateExternalIDs(ctx context.Context, externalIDs []string) ([]string, error) {
+	args := m.Called(externalIDs)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (m *MockVoucherRepository) BulkCreateSkipConflicts(ctx context.Context, vouchers []*entity.Voucher) ([]string, error) {
+	args := m.Called(vouchers)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (m *MockVoucherRepository) DeactivateByCodes(ctx context.Context, codes []string) (int64, error) {
+	args := m.Called(codes)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockVoucherRepository) DeactivateByPrefix(ctx context.Context, prefix string, limit int) (int64, error) {
+	args := m.Called(prefix, limit)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockVoucherRepository) FindIDsByFilter(ctx context.Context, filter repository.VoucherFilter, afterID uint, limit int) ([]uint, error) {
+	args := m.Called(filter, afterID, limit)
+	return args.Get(0).([]uint), args.Error(1)
+}
+
+func (m *MockVoucherRepository) UpdateFields(ctx context.Context, ids []uint, fields map[string]interface{}) (int64, error) {
+	args := m.Called(ids, fields)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockVoucherRepository) UpdateFieldsForApproval(ctx context.Context, ids []uint, fields map[string]interface{}) (int64, []uint, error) {
+	args := m.Called(ids, fields)
+	if args.Get(1) == nil {
+		return args.Get(0).(int64), nil, args.Error(2)
+	}
+	return args.Get(0).(int64), args.Get(1).([]uint), args.Error(2)
+}
+
+func (m *MockVoucherRepository) CountExpiredBefore(ctx context.Context, cutoff time.Time, includeArchived bool) (int64, error) {
+	args := m.Called(cutoff, includeArchived)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockVoucherRepository) FindExpiredCodesBefore(ctx context.Context, cutoff time.Time, includeArchived bool, limit int) ([]string, error) {
+	args := m.Called(cutoff, includeArchived, limit)
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (m *MockVoucherRepository) ArchiveExpiredBefore(ctx context.Context, cutoff time.Time, limit int) (int64, error) {
+	args := m.Called(cutoff, limit)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockVoucherRepository) FindChangedSince(ctx context.Context, since time.Time, afterID uint, limit int) ([]*entity.Voucher, error) {
+	args := m.Called(since, afterID, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.Voucher), args.Error(1)
+}
+
+func (m *MockVoucherRepository) PurgeExpiredBefore(ctx context.Context, cutoff time.Time, limit int) (int64, error) {
+	args := m.Called(cutoff, limit)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+// csvFile is an uploaded CSV file held in memory
+type csvFile struct {
+	*strings.Reader
+}
+
+func (csvFile) Close() error { return nil }
+
+func newCSVFile(content string) multipart.File {
+	return csvFile{strings.NewReader(content)}
+}
+
+// MockAuditRepository is a mock implementation of AuditRepository
+type MockAuditRepository struct {
+	mock.Mock
+}
+
+func (m *MockAuditRepository) Create(ctx context.Context, entry *entity.AuditEntry) error {
+	args := m.Called(entry)
+	return args.Error(0)
+}
+
+// MockVoucherAssignmentRepository is a mock implementation of VoucherAssignmentRepository
+type MockVoucherAssignmentRepository struct {
+	mock.Mock
+}
+
+func (m *MockVoucherAssignmentRepository) Create(ctx context.Context, assignment *entity.VoucherAssignment) error {
+	args := m.Called(assignment)
+	return args.Error(0)
+}
+
+func (m *MockVoucherAssignmentRepository) FindByVoucherAndCustomer(ctx context.Context, voucherID, customerID uint) (*entity.VoucherAssignment, error) {
+	args := m.Called(voucherID, customerID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.VoucherAssignment), args.Error(1)
+}
+
+func (m *MockVoucherAssignmentRepository) FindByVoucher(ctx context.Context, voucherID uint, page, limit int) ([]*entity.VoucherAssignment, int64, error) {
+	args := m.Called(voucherID, page, limit)
+	if args.Get(0) == nil {
+		return nil, args.Get(1).(int64), args.Error(2)
+	}
+	return args.Get(0).([]*entity.VoucherAssignment), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockVoucherAssignmentRepository) DeleteExpiredBefore(ctx context.Context, cutoff time.Time, limit int) (int64, error) {
+	args := m.Called(cutoff, limit)
+	return args.Get(0).(int64), args.Error(1)
+}
+
 // Test Create Voucher
 func TestVoucherService_Create_Success(t *testing.T) {
 	// Arrange
 	mockRepo := new(MockVoucherRepository)
-	voucherService := NewVoucherService(mockRepo)
+	voucherService := NewVoucherService(mockRepo, 0)
 
 	tomorrow := time.Now().Add(24 * time.Hour).Format("2006-01-02")
-	req := &request.CreateVoucherRequest{
+	req := &domainService.CreateVoucherCommand{
 		VoucherCode:     "TEST123",
 		DiscountPercent: 10.0,
 		ExpiryDate:      tomorrow,
@@ -86,7 +262,7 @@ func TestVoucherService_Create_Success(t *testing.T) {
 	mockRepo.On("Create", mock.AnythingOfType("*entity.Voucher")).Return(nil)
 
 	// Act
-	voucher, err := voucherService.Create(req)
+	voucher, err := voucherService.Create(context.Background(), req)
 
 	// Assert
 	assert.NoError(t, err)
@@ -99,10 +275,10 @@ func TestVoucherService_Create_Success(t *testing.T) {
 func TestVoucherService_Create_DuplicateCode(t *testing.T) {
 	// Arrange
 	mockRepo := new(MockVoucherRepository)
-	voucherService := NewVoucherService(mockRepo)
+	voucherService := NewVoucherService(mockRepo, 0)
 
 	tomorrow := time.Now().Add(24 * time.Hour).Format("2006-01-02")
-	req := &request.CreateVoucherRequest{
+	req := &domainService.CreateVoucherCommand{
 		VoucherCode:     "TEST123",
 		DiscountPercent: 10.0,
 		ExpiryDate:      tomorrow,
@@ -117,7 +293,7 @@ func TestVoucherService_Create_DuplicateCode(t *testing.T) {
 	mockRepo.On("FindByVoucherCode", req.VoucherCode).Return(existingVoucher, nil)
 
 	// Act
-	voucher, err := voucherService.Create(req)
+	voucher, err := voucherService.Create(context.Background(), req)
 
 	// Assert
 	assert.Error(t, err)
@@ -126,12 +302,37 @@ func TestVoucherService_Create_DuplicateCode(t *testing.T) {
 	mockRepo.AssertExpectations(t)
 }
 
+func TestVoucherService_Create_ConcurrentDuplicateCode(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockVoucherRepository)
+	voucherService := NewVoucherService(mockRepo, 0)
+
+	tomorrow := time.Now().Add(24 * time.Hour).Format("2006-01-02")
+	req := &domainService.CreateVoucherCommand{
+		VoucherCode:     "TEST123",
+		DiscountPercent: 10.0,
+		ExpiryDate:      tomorrow,
+	}
+
+	// Another request creates "test123" between the lookup and the insert
+	mockRepo.On("FindByVoucherCode", req.VoucherCode).Return((*entity.Voucher)(nil), nil)
+	mockRepo.On("Create", mock.AnythingOfType("*entity.Voucher")).Return(gorm.ErrDuplicatedKey)
+
+	// Act
+	voucher, err := voucherService.Create(context.Background(), req)
+
+	// Assert
+	assert.ErrorIs(t, err, domainService.ErrDuplicateVoucherCode)
+	assert.Nil(t, voucher)
+	mockRepo.AssertExpectations(t)
+}
+
 func TestVoucherService_Create_InvalidDateFormat(t *testing.T) {
 	// Arrange
 	mockRepo := new(MockVoucherRepository)
-	voucherService := NewVoucherService(mockRepo)
+	voucherService := NewVoucherService(mockRepo, 0)
 
-	req := &request.CreateVoucherRequest{
+	req := &domainService.CreateVoucherCommand{
 		VoucherCode:     "TEST123",
 		DiscountPercent: 10.0,
 		ExpiryDate:      "invalid-date",
@@ -140,7 +341,7 @@ func TestVoucherService_Create_InvalidDateFormat(t *testing.T) {
 	mockRepo.On("FindByVoucherCode", req.VoucherCode).Return((*entity.Voucher)(nil), nil)
 
 	// Act
-	voucher, err := voucherService.Create(req)
+	voucher, err := voucherService.Create(context.Background(), req)
 
 	// Assert
 	assert.Error(t, err)
@@ -152,10 +353,10 @@ func TestVoucherService_Create_InvalidDateFormat(t *testing.T) {
 func TestVoucherService_Create_PastExpiryDate(t *testing.T) {
 	// Arrange
 	mockRepo := new(MockVoucherRepository)
-	voucherService := NewVoucherService(mockRepo)
+	voucherService := NewVoucherService(mockRepo, 0)
 
 	yesterday := time.Now().Add(-24 * time.Hour).Format("2006-01-02")
-	req := &request.CreateVoucherRequest{
+	req := &domainService.CreateVoucherCommand{
 		VoucherCode:     "TEST123",
 		DiscountPercent: 10.0,
 		ExpiryDate:      yesterday,
@@ -164,7 +365,7 @@ func TestVoucherService_Create_PastExpiryDate(t *testing.T) {
 	mockRepo.On("FindByVoucherCode", req.VoucherCode).Return((*entity.Voucher)(nil), nil)
 
 	// Act
-	voucher, err := voucherService.Create(req)
+	voucher, err := voucherService.Create(context.Background(), req)
 
 	// Assert
 	assert.Error(t, err)
@@ -173,147 +374,162 @@ func TestVoucherService_Create_PastExpiryDate(t *testing.T) {
 	mockRepo.AssertExpectations(t)
 }
 
-// Test Update Voucher
-func TestVoucherService_Update_Success(t *testing.T) {
+func TestVoucherService_Create_AboveApprovalThreshold(t *testing.T) {
 	// Arrange
 	mockRepo := new(MockVoucherRepository)
-	voucherService := NewVoucherService(mockRepo)
+	voucherService := NewVoucherService(mockRepo, 50)
 
 	tomorrow := time.Now().Add(24 * time.Hour).Format("2006-01-02")
-	voucherID := uint(1)
-
-	existingVoucher := &entity.Voucher{
-		ID:              voucherID,
-		VoucherCode:     "OLD123",
-		DiscountPercent: 10.0,
-	}
-
-	req := &request.UpdateVoucherRequest{
-		VoucherCode:     "NEW123",
-		DiscountPercent: 15.0,
+	req := &domainService.CreateVoucherCommand{
+		VoucherCode:     "BIG75",
+		DiscountPercent: 75.0,
 		ExpiryDate:      tomorrow,
+		CreatedBy:       "creator@example.com",
 	}
 
-	mockRepo.On("FindByID", voucherID).Return(existingVoucher, nil)
-	mockRepo.On("FindByVoucherCode", req.VoucherCode).Return(nil, gorm.ErrRecordNotFound)
-	mockRepo.On("Update", mock.AnythingOfType("*entity.Voucher")).Return(nil)
+	mockRepo.On("FindByVoucherCode", req.VoucherCode).Return((*entity.Voucher)(nil), nil)
+	mockRepo.On("Create", mock.AnythingOfType("*entity.Voucher")).Return(nil)
 
 	// Act
-	voucher, err := voucherService.Update(voucherID, req)
+	voucher, err := voucherService.Create(context.Background(), req)
 
 	// Assert
 	assert.NoError(t, err)
-	assert.NotNil(t, voucher)
-	assert.Equal(t, req.VoucherCode, voucher.VoucherCode)
-	assert.Equal(t, req.DiscountPercent, voucher.DiscountPercent)
+	assert.Equal(t, entity.VoucherStatusPendingApproval, voucher.Status)
+	assert.Equal(t, "creator@example.com", voucher.CreatedBy)
 	mockRepo.AssertExpectations(t)
 }
 
-func TestVoucherService_Update_NotFound(t *testing.T) {
+func TestVoucherService_Create_TooManyDecimalPlaces(t *testing.T) {
 	// Arrange
 	mockRepo := new(MockVoucherRepository)
-	voucherService := NewVoucherService(mockRepo)
+	voucherService := NewVoucherService(mockRepo, 0)
 
 	tomorrow := time.Now().Add(24 * time.Hour).Format("2006-01-02")
-	voucherID := uint(999)
-
-	req := &request.UpdateVoucherRequest{
-		VoucherCode:     "NEW123",
-		DiscountPercent: 15.0,
+	req := &domainService.CreateVoucherCommand{
+		VoucherCode:     "TEST123",
+		DiscountPercent: 10.125,
 		ExpiryDate:      tomorrow,
 	}
 
-	mockRepo.On("FindByID", voucherID).Return(nil, gorm.ErrRecordNotFound)
+	mockRepo.On("FindByVoucherCode", req.VoucherCode).Return((*entity.Voucher)(nil), nil)
 
 	// Act
-	voucher, err := voucherService.Update(voucherID, req)
+	voucher, err := voucherService.Create(context.Background(), req)
 
 	// Assert
 	assert.Error(t, err)
 	assert.Nil(t, voucher)
-	assert.Contains(t, err.Error(), "not found")
-	mockRepo.AssertExpectations(t)
+	assert.Contains(t, err.Error(), "more than 2 decimal places")
+	mockRepo.AssertNotCalled(t, "Create", mock.Anything)
 }
 
-// Test Delete Voucher
-func TestVoucherService_Delete_Success(t *testing.T) {
+func TestVoucherService_Create_AtApprovalThreshold(t *testing.T) {
 	// Arrange
 	mockRepo := new(MockVoucherRepository)
-	voucherService := NewVoucherService(mockRepo)
+	voucherService := NewVoucherService(mockRepo, 3.3)
 
-	voucherID := uint(1)
-	existingVoucher := &entity.Voucher{
-		ID:              voucherID,
-		VoucherCode:     "TEST123",
-		DiscountPercent: 10.0,
+	// 1.1 + 2.2 is 3.3000000000000003 as a float, but 3.30 once stored
+	base, bonus := 1.1, 2.2
+	tomorrow := time.Now().Add(24 * time.Hour).Format("2006-01-02")
+	req := &domainService.CreateVoucherCommand{
+		VoucherCode:     "EDGE",
+		DiscountPercent: base + bonus,
+		ExpiryDate:      tomorrow,
 	}
 
-	mockRepo.On("FindByID", voucherID).Return(existingVoucher, nil)
-	mockRepo.On("Delete", voucherID).Return(nil)
+	mockRepo.On("FindByVoucherCode", req.VoucherCode).Return((*entity.Voucher)(nil), nil)
+	mockRepo.On("Create", mock.AnythingOfType("*entity.Voucher")).Return(nil)
 
 	// Act
-	err := voucherService.Delete(voucherID)
+	voucher, err := voucherService.Create(context.Background(), req)
 
 	// Assert
 	assert.NoError(t, err)
+	assert.Equal(t, entity.VoucherStatusActive, voucher.Status)
 	mockRepo.AssertExpectations(t)
 }
 
-func TestVoucherService_Delete_NotFound(t *testing.T) {
+func TestVoucherService_GetByCodes_TrimsBlankCodes(t *testing.T) {
 	// Arrange
 	mockRepo := new(MockVoucherRepository)
-	voucherService := NewVoucherService(mockRepo)
-
-	voucherID := uint(999)
+	voucherService := NewVoucherService(mockRepo, 0)
 
-	mockRepo.On("FindByID", voucherID).Return(nil, gorm.ErrRecordNotFound)
+	found := []*entity.Voucher{{ID: 1, VoucherCode: "TEST1"}}
+	mockRepo.On("FindByVoucherCodes", []string{"TEST1", "TEST2"}).Return(found, nil)
 
 	// Act
-	err := voucherService.Delete(voucherID)
+	vouchers, err := voucherService.GetByCodes(context.Background(), []string{" TEST1 ", "", "TEST2"})
 
 	// Assert
-	assert.Error(t, err)
-	assert.Contains(t, err.Error(), "not found")
+	assert.NoError(t, err)
+	assert.Equal(t, found, vouchers)
 	mockRepo.AssertExpectations(t)
 }
 
-// Test GetByID
-func TestVoucherService_GetByID_Success(t *testing.T) {
+func TestVoucherService_GetByIDs_BatchSize(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockVoucherRepository)
+	voucherService := NewVoucherService(mockRepo, 0)
+
+	// Act
+	_, emptyErr := voucherService.GetByIDs(context.Background(), nil)
+	_, tooManyErr := voucherService.GetByIDs(context.Background(), make([]uint, domainService.MaxBatchLookup+1))
+
+	// Assert
+	assert.Error(t, emptyErr)
+	assert.Error(t, tooManyErr)
+	mockRepo.AssertNotCalled(t, "FindByIDs", mock.Anything)
+}
+
+// Test Update Voucher
+func TestVoucherService_Update_Success(t *testing.T) {
 	// Arrange
 	mockRepo := new(MockVoucherRepository)
-	voucherService := NewVoucherService(mockRepo)
+	voucherService := NewVoucherService(mockRepo, 0)
 
+	tomorrow := time.Now().Add(24 * time.Hour).Format("2006-01-02")
 	voucherID := uint(1)
-	expectedVoucher := &entity.Voucher{
-		ID:              voucherID,
-		VoucherCode:     "TEST123",
-		DiscountPercent: 10.0,
+
+	req := &domainService.UpdateVoucherCommand{
+		VoucherCode:     "NEW123",
+		DiscountPercent: 15.0,
+		ExpiryDate:      tomorrow,
 	}
 
-	mockRepo.On("FindByID", voucherID).Return(expectedVoucher, nil)
+	mockRepo.On("FindByVoucherCode", req.VoucherCode).Return(nil, nil)
+	mockRepo.On("Update", mock.AnythingOfType("*entity.Voucher")).Return(int64(1), nil)
 
 	// Act
-	voucher, err := voucherService.GetByID(voucherID)
+	voucher, err := voucherService.Update(context.Background(), voucherID, req)
 
 	// Assert
 	assert.NoError(t, err)
 	assert.NotNil(t, voucher)
-	assert.Equal(t, expectedVoucher.ID, voucher.ID)
-	assert.Equal(t, expectedVoucher.VoucherCode, voucher.VoucherCode)
+	assert.Equal(t, req.VoucherCode, voucher.VoucherCode)
+	assert.Equal(t, req.DiscountPercent, voucher.DiscountPercent)
 	mockRepo.AssertExpectations(t)
 }
 
-func TestVoucherService_GetByID_NotFound(t *testing.T) {
+func TestVoucherService_Update_NotFound(t *testing.T) {
 	// Arrange
 	mockRepo := new(MockVoucherRepository)
-	voucherService := NewVoucherService(mockRepo)
+	voucherService := NewVoucherService(mockRepo, 0)
 
+	tomorrow := time.Now().Add(24 * time.Hour).Format("2006-01-02")
 	voucherID := uint(999)
 
-	mockRepo.On("FindByID", voucherID).Return(nil, gorm.ErrRecordNotFound)
+	req := &domainService.UpdateVoucherCommand{
+		VoucherCode:     "NEW123",
+		DiscountPercent: 15.0,
+		ExpiryDate:      tomorrow,
+	}
+
+	mockRepo.On("FindByVoucherCode", req.VoucherCode).Return(nil, nil)
+	mockRepo.On("Update", mock.AnythingOfType("*entity.Voucher")).Return(int64(0), nil)
 
 	// Act
-	voucher, err := voucherService.GetByID(voucherID)
+	voucher, err := voucherService.Update(context.Background(), voucherID, req)
 
 	// Assert
 	assert.Error(t, err)
@@ -322,69 +538,2076 @@ func TestVoucherService_GetByID_NotFound(t *testing.T) {
 	mockRepo.AssertExpectations(t)
 }
 
-// Test GetAll
-func TestVoucherService_GetAll_Success(t *testing.T) {
+func TestVoucherService_Update_DuplicateCode(t *testing.T) {
 	// Arrange
 	mockRepo := new(MockVoucherRepository)
-	voucherService := NewVoucherService(mockRepo)
+	voucherService := NewVoucherService(mockRepo, 0)
 
-	expectedVouchers := []*entity.Voucher{
-		{ID: 1, VoucherCode: "TEST1", DiscountPercent: 10.0},
-		{ID: 2, VoucherCode: "TEST2", DiscountPercent: 20.0},
+	tomorrow := time.Now().Add(24 * time.Hour).Format("2006-01-02")
+	voucherID := uint(1)
+
+	req := &domainService.UpdateVoucherCommand{
+		VoucherCode:     "TAKEN123",
+		DiscountPercent: 15.0,
+		ExpiryDate:      tomorrow,
+	}
+
+	otherVoucher := &entity.Voucher{
+		ID:          2,
+		VoucherCode: "TAKEN123",
 	}
-	expectedTotal := int64(2)
 
-	mockRepo.On("FindAll", 1, 10, "", "created_at", "desc").Return(expectedVouchers, expectedTotal, nil)
+	mockRepo.On("FindByVoucherCode", req.VoucherCode).Return(otherVoucher, nil)
 
 	// Act
-	vouchers, total, err := voucherService.GetAll(1, 10, "", "created_at", "desc")
+	voucher, err := voucherService.Update(context.Background(), voucherID, req)
 
 	// Assert
-	assert.NoError(t, err)
-	assert.Equal(t, expectedVouchers, vouchers)
-	assert.Equal(t, expectedTotal, total)
+	assert.Error(t, err)
+	assert.Nil(t, voucher)
+	assert.Contains(t, err.Error(), "already exists")
 	mockRepo.AssertExpectations(t)
 }
 
-func TestVoucherService_GetAll_WithSearch(t *testing.T) {
+func TestVoucherService_Create_DiscountLimit(t *testing.T) {
 	// Arrange
 	mockRepo := new(MockVoucherRepository)
-	voucherService := NewVoucherService(mockRepo)
+	mockLimitRepo := new(MockDiscountLimitRepository)
+	voucherService := NewVoucherService(mockRepo, 0, WithDiscountLimits(mockLimitRepo))
 
-	search := "TEST"
-	expectedVouchers := []*entity.Voucher{
-		{ID: 1, VoucherCode: "TEST1", DiscountPercent: 10.0},
+	tomorrow := time.Now().Add(24 * time.Hour).Format("2006-01-02")
+	mockRepo.On("FindByVoucherCode", mock.Anything).Return((*entity.Voucher)(nil), nil)
+	mockRepo.On("Create", mock.AnythingOfType("*entity.Voucher")).Return(nil)
+	mockLimitRepo.On("FindByRole", entity.UserRoleMarketing).Return(&entity.DiscountLimit{Role: entity.UserRoleMarketing, MaxDiscountPercent: 30}, nil)
+	mockLimitRepo.On("FindByRole", entity.UserRoleViewer).Return(nil, nil)
+
+	// Act
+	_, overErr := voucherService.Create(context.Background(), &domainService.CreateVoucherCommand{VoucherCode: "FAT90", DiscountPercent: 90, ExpiryDate: tomorrow, Role: entity.UserRoleMarketing})
+	_, atLimitErr := voucherService.Create(context.Background(), &domainService.CreateVoucherCommand{VoucherCode: "SALE30", DiscountPercent: 30, ExpiryDate: tomorrow, Role: entity.UserRoleMarketing})
+	_, adminErr := voucherService.Create(context.Background(), &domainService.CreateVoucherCommand{VoucherCode: "OWNER90", DiscountPercent: 90, ExpiryDate: tomorrow, Role: entity.UserRoleAdmin})
+	_, unlimitedErr := voucherService.Create(context.Background(), &domainService.CreateVoucherCommand{VoucherCode: "VIEW90", DiscountPercent: 90, ExpiryDate: tomorrow, Role: entity.UserRoleViewer})
+
+	// Assert
+	assert.ErrorIs(t, overErr, domainService.ErrDiscountLimitExceeded)
+	assert.Contains(t, overErr.Error(), "up to 30% off, not 90%")
+	assert.NoError(t, atLimitErr)
+	assert.NoError(t, adminErr)
+	assert.NoError(t, unlimitedErr)
+	mockRepo.AssertNumberOfCalls(t, "Create", 3)
+	mockLimitRepo.AssertNotCalled(t, "FindByRole", entity.UserRoleAdmin)
+}
+
+func TestVoucherService_Update_DiscountLimit(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockVoucherRepository)
+	mockLimitRepo := new(MockDiscountLimitRepository)
+	voucherService := NewVoucherService(mockRepo, 0, WithDiscountLimits(mockLimitRepo))
+
+	tomorrow := time.Now().Add(24 * time.Hour).Format("2006-01-02")
+	mockRepo.On("FindByVoucherCode", mock.Anything).Return(nil, nil)
+	mockRepo.On("FindByID", uint(1)).Return(&entity.Voucher{ID: 1, VoucherCode: "OWNER50", DiscountPercent: 50}, nil)
+	mockRepo.On("Update", mock.AnythingOfType("*entity.Voucher")).Return(int64(1), nil)
+	mockLimitRepo.On("FindByRole", entity.UserRoleMarketing).Return(&entity.DiscountLimit{Role: entity.UserRoleMarketing, MaxDiscountPercent: 30}, nil)
+
+	// Act
+	_, keepErr := voucherService.Update(context.Background(), 1, &domainService.UpdateVoucherCommand{VoucherCode: "OWNER50", DiscountPercent: 50, ExpiryDate: tomorrow, Role: entity.UserRoleMarketing})
+	_, raiseErr := voucherService.Update(context.Background(), 1, &domainService.UpdateVoucherCommand{VoucherCode: "OWNER50", DiscountPercent: 60, ExpiryDate: tomorrow, Role: entity.UserRoleMarketing})
+
+	// Assert
+	assert.NoError(t, keepErr)
+	assert.ErrorIs(t, raiseErr, domainService.ErrDiscountLimitExceeded)
+	mockRepo.AssertNumberOfCalls(t, "Update", 1)
+}
+
+func TestVoucherService_Create_FixedAmountDiscountLimit(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockVoucherRepository)
+	mockLimitRepo := new(MockDiscountLimitRepository)
+	voucherService := NewVoucherService(mockRepo, 0, WithDiscountLimits(mockLimitRepo))
+
+	tomorrow := time.Now().Add(24 * time.Hour).Format("2006-01-02")
+	mockRepo.On("FindByVoucherCode", mock.Anything).Return((*entity.Voucher)(nil), nil)
+	mockRepo.On("Create", mock.AnythingOfType("*entity.Voucher")).Return(nil)
+	mockLimitRepo.On("FindByRole", entity.UserRoleMarketing).Return(&entity.DiscountLimit{
+		Role:               entity.UserRoleMarketing,
+		MaxDiscountPercent: 30,
+		MaxDiscountAmounts: map[string]int64{"IDR": 5000000},
+	}, nil)
+	create := func(code string, amount float64, currency string) error {
+		_, err := voucherService.Create(context.Background(), &domainService.CreateVoucherCommand{
+			VoucherCode: code, DiscountType: entity.DiscountTypeFixedAmount, DiscountAmount: amount, Currency: currency,
+			ExpiryDate: tomorrow, Role: entity.UserRoleMarketing,
+		})
+		return err
+	}
+
+	// Act
+	atLimitErr := create("RP50K", 50000, "IDR")
+	overErr := create("RP1M", 1000000, "IDR")
+	uncappedErr := create("USD10", 10, "USD")
+
+	// Assert
+	assert.NoError(t, atLimitErr)
+	assert.ErrorIs(t, overErr, domainService.ErrDiscountLimitExceeded)
+	assert.Contains(t, overErr.Error(), "up to IDR 50000.00 off, not IDR 1000000.00")
+	assert.ErrorIs(t, uncappedErr, domainService.ErrDiscountLimitExceeded)
+	assert.Contains(t, uncappedErr.Error(), "cannot give fixed discounts in USD")
+	mockRepo.AssertNumberOfCalls(t, "Create", 1)
+}
+
+func TestVoucherService_ImportRecords_DiscountLimit(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockVoucherRepository)
+	mockLimitRepo := new(MockDiscountLimitRepository)
+	voucherService := NewVoucherService(mockRepo, 0, WithDiscountLimits(mockLimitRepo))
+
+	tomorrow := time.Now().Add(24 * time.Hour).Format("2006-01-02")
+	records := [][]string{
+		{"voucher_code", "discount_percent", "expiry_date"},
+		{"SALE30", "30", tomorrow},
+		{"FAT90", "90", tomorrow},
 	}
-	expectedTotal := int64(1)
 
-	mockRepo.On("FindAll", 1, 10, search, "created_at", "desc").Return(expectedVouchers, expectedTotal, nil)
+	mockRepo.On("CheckDuplicateCodes", []string{"SALE30", "FAT90"}).Return([]string{}, nil)
+	mockRepo.On("BulkCreate", mock.MatchedBy(func(vouchers []*entity.Voucher) bool {
+		return len(vouchers) == 1 && vouchers[0].VoucherCode == "SALE30"
+	})).Return(nil)
+	mockLimitRepo.On("FindByRole", entity.UserRoleMarketing).Return(&entity.DiscountLimit{Role: entity.UserRoleMarketing, MaxDiscountPercent: 30}, nil)
 
 	// Act
-	vouchers, total, err := voucherService.GetAll(1, 10, search, "created_at", "desc")
+	result, err := voucherService.ImportRecords(context.Background(), records, "marketer@example.com", entity.UserRoleMarketing)
 
 	// Assert
 	assert.NoError(t, err)
-	assert.Equal(t, expectedVouchers, vouchers)
-	assert.Equal(t, expectedTotal, total)
+	assert.Equal(t, 1, result.Success)
+	assert.Equal(t, 1, result.Failed)
+	assert.Equal(t, 3, result.Errors[0].Row)
+	assert.Contains(t, result.Errors[0].Error, "marketing users can give up to 30% off, not 90%")
 	mockRepo.AssertExpectations(t)
+	mockLimitRepo.AssertNumberOfCalls(t, "FindByRole", 1)
 }
 
-func TestVoucherService_GetAll_Error(t *testing.T) {
+func TestVoucherService_ImportBatch_DiscountLimit(t *testing.T) {
 	// Arrange
 	mockRepo := new(MockVoucherRepository)
-	voucherService := NewVoucherService(mockRepo)
+	mockLimitRepo := new(MockDiscountLimitRepository)
+	voucherService := NewVoucherService(mockRepo, 0, WithDiscountLimits(mockLimitRepo))
 
-	expectedError := errors.New("database error")
+	tomorrow := time.Now().Add(24 * time.Hour).Format("2006-01-02")
+	commands := []domainService.CreateVoucherCommand{
+		{VoucherCode: "SALE30", DiscountPercent: 30, ExpiryDate: tomorrow, Role: entity.UserRoleMarketing},
+		{VoucherCode: "FAT90", DiscountPercent: 90, ExpiryDate: tomorrow, Role: entity.UserRoleMarketing},
+	}
 
-	mockRepo.On("FindAll", 1, 10, "", "created_at", "desc").Return(nil, int64(0), expectedError)
+	mockRepo.On("CheckDuplicateCodes", []string{"SALE30", "FAT90"}).Return([]string{}, nil)
+	mockRepo.On("BulkCreate", mock.MatchedBy(func(vouchers []*entity.Voucher) bool {
+		return len(vouchers) == 1 && vouchers[0].VoucherCode == "SALE30"
+	})).Return(nil)
+	mockLimitRepo.On("FindByRole", entity.UserRoleMarketing).Return(&entity.DiscountLimit{Role: entity.UserRoleMarketing, MaxDiscountPercent: 30}, nil)
 
 	// Act
-	vouchers, total, err := voucherService.GetAll(1, 10, "", "created_at", "desc")
+	result, err := voucherService.ImportBatch(context.Background(), commands)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, 1, result.Inserted)
+	assert.Equal(t, []string{"Code FAT90: discount exceeds the limit for your role: marketing users can give up to 30% off, not 90%; ask an admin to make this change"}, result.Errors)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestVoucherService_Update_RaisedDiscountRequestsApproval(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockVoucherRepository)
+	mockAuditRepo := new(MockAuditRepository)
+	broker := events.NewBroker()
+	received, cancel := broker.Subscribe()
+	defer cancel()
+	voucherService := NewVoucherService(mockRepo, 50, WithAudit(mockAuditRepo), WithEvents(broker))
+
+	tomorrow := time.Now().Add(24 * time.Hour).Format("2006-01-02")
+	mockRepo.On("FindByVoucherCode", "BIG75").Return(nil, nil)
+	mockRepo.On("Update", mock.MatchedBy(func(voucher *entity.Voucher) bool {
+		return voucher.Status == entity.VoucherStatusPendingApproval && voucher.UpdatedBy == "editor@example.com"
+	})).Return(int64(1), nil)
+	mockAuditRepo.On("Create", mock.MatchedBy(func(entry *entity.AuditEntry) bool {
+		return entry.Action == entity.AuditActionVoucherApprovalRequest && entry.Actor == "editor@example.com"
+	})).Return(nil).Once()
+
+	// Act
+	voucher, err := voucherService.Update(context.Background(), 1, &domainService.UpdateVoucherCommand{
+		VoucherCode:     "BIG75",
+		DiscountPercent: 75,
+		ExpiryDate:      tomorrow,
+		UpdatedBy:       "editor@example.com",
+	})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "editor@example.com", voucher.UpdatedBy)
+	event := <-received
+	assert.Equal(t, domainService.EventApprovalRequested, event.Type)
+	assert.Equal(t, domainService.ApprovalRequest{VoucherIDs: []uint{1}, RequestedBy: "editor@example.com"}, event.Data)
+	mockAuditRepo.AssertExpectations(t)
+}
+
+// Test Delete Voucher
+func TestVoucherService_Delete_Success(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockVoucherRepository)
+	voucherService := NewVoucherService(mockRepo, 0)
+
+	voucherID := uint(1)
+
+	mockRepo.On("Delete", voucherID).Return(int64(1), nil)
+
+	// Act
+	err := voucherService.Delete(context.Background(), voucherID)
+
+	// Assert
+	assert.NoError(t, err)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestVoucherService_Delete_NotFound(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockVoucherRepository)
+	voucherService := NewVoucherService(mockRepo, 0)
+
+	voucherID := uint(999)
+
+	mockRepo.On("Delete", voucherID).Return(int64(0), nil)
+
+	// Act
+	err := voucherService.Delete(context.Background(), voucherID)
+
+	// Assert
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "not found")
+	mockRepo.AssertExpectations(t)
+}
+
+// Test Approve Voucher
+func TestVoucherService_Approve_Success(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockVoucherRepository)
+	mockAuditRepo := new(MockAuditRepository)
+	broker := events.NewBroker()
+	received, cancel := broker.Subscribe()
+	defer cancel()
+	voucherService := NewVoucherService(mockRepo, 50, WithAudit(mockAuditRepo), WithEvents(broker))
+
+	pendingVoucher := &entity.Voucher{
+		ID:          1,
+		VoucherCode: "BIG75",
+		Status:      entity.VoucherStatusPendingApproval,
+		CreatedBy:   "creator@example.com",
+		UpdatedBy:   "editor@example.com",
+	}
+
+	mockRepo.On("FindByID", uint(1)).Return(pendingVoucher, nil)
+	mockRepo.On("Approve", uint(1), "approver@example.com", mock.AnythingOfType("time.Time")).Return(int64(1), nil)
+	mockAuditRepo.On("Create", mock.MatchedBy(func(entry *entity.AuditEntry) bool {
+		return entry.Action == entity.AuditActionVoucherApprove && entry.Actor == "approver@example.com" &&
+			strings.Contains(entry.Details, `"changed_by":"editor@example.com"`)
+	})).Return(nil).Once()
+
+	// Act
+	voucher, err := voucherService.Approve(context.Background(), 1, "approver@example.com")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, entity.VoucherStatusActive, voucher.Status)
+	assert.Equal(t, "approver@example.com", voucher.ApprovedBy)
+	assert.NotNil(t, voucher.ApprovedAt)
+	event := <-received
+	assert.Equal(t, domainService.EventVoucherApproved, event.Type)
+	assert.Equal(t, domainService.VoucherApproval{VoucherID: 1, ApprovedBy: "approver@example.com", ApprovedAt: *voucher.ApprovedAt}, event.Data)
+	mockRepo.AssertExpectations(t)
+	mockAuditRepo.AssertExpectations(t)
+}
+
+func TestVoucherService_Approve_SelfApproval(t *testing.T) {
+	tests := []struct {
+		name       string
+		createdBy  string
+		updatedBy  string
+		approvedBy string
+	}{
+		{name: "approver is the creator", createdBy: "creator@example.com", updatedBy: "editor@example.com", approvedBy: "creator@example.com"},
+		{name: "approver made the last change", createdBy: "creator@example.com", updatedBy: "editor@example.com", approvedBy: "editor@example.com"},
+		{name: "approver is unknown", createdBy: "creator@example.com", updatedBy: "creator@example.com", approvedBy: ""},
+		{name: "last change is unrecorded", createdBy: "", updatedBy: "", approvedBy: "approver@example.com"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			mockRepo := new(MockVoucherRepository)
+			voucherService := NewVoucherService(mockRepo, 50)
+
+			mockRepo.On("FindByID", uint(1)).Return(&entity.Voucher{
+				ID:        1,
+				Status:    entity.VoucherStatusPendingApproval,
+				CreatedBy: tt.createdBy,
+				UpdatedBy: tt.updatedBy,
+			}, nil)
+
+			// Act
+			voucher, err := voucherService.Approve(context.Background(), 1, tt.approvedBy)
+
+			// Assert
+			assert.ErrorIs(t, err, domainService.ErrSelfApproval)
+			assert.Nil(t, voucher)
+			mockRepo.AssertNotCalled(t, "Approve", mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestVoucherService_Approve_NotPending(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockVoucherRepository)
+	voucherService := NewVoucherService(mockRepo, 50)
+
+	activeVoucher := &entity.Voucher{
+		ID:     1,
+		Status: entity.VoucherStatusActive,
+	}
+
+	mockRepo.On("FindByID", uint(1)).Return(activeVoucher, nil)
+
+	// Act
+	voucher, err := voucherService.Approve(context.Background(), 1, "approver@example.com")
+
+	// Assert
+	assert.ErrorIs(t, err, domainService.ErrVoucherNotPendingApproval)
+	assert.Nil(t, voucher)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestVoucherService_Create_DuplicateExternalID(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockVoucherRepository)
+	voucherService := NewVoucherService(mockRepo, 0)
+
+	tomorrow := time.Now().Add(24 * time.Hour).Format("2006-01-02")
+	req := &domainService.CreateVoucherCommand{
+		VoucherCode:     "TEST123",
+		ExternalID:      "crm-42",
+		DiscountPercent: 10.0,
+		ExpiryDate:      tomorrow,
+	}
+
+	mockRepo.On("FindByVoucherCode", req.VoucherCode).Return((*entity.Voucher)(nil), nil)
+	mockRepo.On("CheckDuplicateExternalIDs", []string{"crm-42"}).Return([]string{"crm-42"}, nil)
+
+	// Act
+	voucher, err := voucherService.Create(context.Background(), req)
+
+	// Assert
+	assert.ErrorIs(t, err, domainService.ErrDuplicateExternalID)
+	assert.Nil(t, voucher)
+	mockRepo.AssertNotCalled(t, "Create", mock.Anything)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestVoucherService_Create_WithExternalID(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockVoucherRepository)
+	voucherService := NewVoucherService(mockRepo, 0)
+
+	tomorrow := time.Now().Add(24 * time.Hour).Format("2006-01-02")
+	req := &domainService.CreateVoucherCommand{
+		VoucherCode:     "TEST123",
+		ExternalID:      " crm-42 ",
+		DiscountPercent: 10.0,
+		ExpiryDate:      tomorrow,
+	}
+
+	mockRepo.On("FindByVoucherCode", req.VoucherCode).Return((*entity.Voucher)(nil), nil)
+	mockRepo.On("CheckDuplicateExternalIDs", []string{"crm-42"}).Return([]string{}, nil)
+	mockRepo.On("Create", mock.AnythingOfType("*entity.Voucher")).Return(nil)
+
+	// Act
+	voucher, err := voucherService.Create(context.Background(), req)
+
+	// Assert
+	assert.NoError(t, err)
+	if assert.NotNil(t, voucher.ExternalID) {
+		assert.Equal(t, "crm-42", *voucher.ExternalID)
+	}
+	mockRepo.AssertExpectations(t)
+}
+
+func TestVoucherService_Create_InvalidTermsURL(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockVoucherRepository)
+	voucherService := NewVoucherService(mockRepo, 0)
+
+	tomorrow := time.Now().Add(24 * time.Hour).Format("2006-01-02")
+	req := &domainService.CreateVoucherCommand{
+		VoucherCode:     "TEST123",
+		DiscountPercent: 10.0,
+		ExpiryDate:      tomorrow,
+		DisplayName:     "Summer sale",
+		TermsURL:        "javascript:alert(1)",
+	}
+
+	mockRepo.On("FindByVoucherCode", req.VoucherCode).Return((*entity.Voucher)(nil), nil)
+
+	// Act
+	voucher, err := voucherService.Create(context.Background(), req)
+
+	// Assert
+	assert.Error(t, err)
+	assert.Nil(t, voucher)
+	assert.Contains(t, err.Error(), "terms url")
+	mockRepo.AssertNotCalled(t, "Create", mock.Anything)
+}
+
+// Test GetByExternalID
+func TestVoucherService_GetByExternalID_NotFound(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockVoucherRepository)
+	voucherService := NewVoucherService(mockRepo, 0)
+
+	mockRepo.On("FindByExternalID", "crm-404").Return(nil, nil)
+
+	// Act
+	voucher, err := voucherService.GetByExternalID(context.Background(), "crm-404")
+
+	// Assert
+	assert.ErrorIs(t, err, domainService.ErrVoucherNotFound)
+	assert.Nil(t, voucher)
+	mockRepo.AssertExpectations(t)
+}
+
+// Test BulkDeactivate
+func TestVoucherService_BulkDeactivate_ByPrefix(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockVoucherRepository)
+	voucherService := NewVoucherService(mockRepo, 0)
+
+	mockRepo.On("DeactivateByPrefix", "LEAK", deactivateChunkSize).Return(int64(deactivateChunkSize), nil).Once()
+	mockRepo.On("DeactivateByPrefix", "LEAK", deactivateChunkSize).Return(int64(7), nil).Once()
+	mockRepo.On("DeactivateByPrefix", "LEAK", deactivateChunkSize).Return(int64(0), nil).Once()
+
+	// Act
+	result, err := voucherService.BulkDeactivate(context.Background(), "LEAK", nil)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, int64(deactivateChunkSize+7), result.Deactivated)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestVoucherService_BulkDeactivate_PrefixTooShort(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockVoucherRepository)
+	voucherService := NewVoucherService(mockRepo, 0)
+
+	// Act
+	result, err := voucherService.BulkDeactivate(context.Background(), "A", nil)
+
+	// Assert
+	assert.Error(t, err)
+	assert.Nil(t, result)
+	mockRepo.AssertNotCalled(t, "DeactivateByPrefix", mock.Anything, mock.Anything)
+}
+
+func TestVoucherService_BulkDeactivate_ByCodesReportsMissing(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockVoucherRepository)
+	voucherService := NewVoucherService(mockRepo, 0)
+
+	codes := []string{"CODE1", "GONE", "CODE2"}
+	mockRepo.On("CheckDuplicateCodes", codes).Return([]string{"CODE1", "CODE2"}, nil)
+	mockRepo.On("DeactivateByCodes", []string{"CODE1", "CODE2"}).Return(int64(2), nil)
+
+	// Act
+	result, err := voucherService.BulkDeactivate(context.Background(), "", codes)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, 2, result.Matched)
+	assert.Equal(t, int64(2), result.Deactivated)
+	assert.Equal(t, []string{"GONE"}, result.MissingCodes)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestVoucherService_BulkDeactivate_RequiresOneSelector(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockVoucherRepository)
+	voucherService := NewVoucherService(mockRepo, 0)
+
+	// Act
+	_, errNone := voucherService.BulkDeactivate(context.Background(), "", nil)
+	_, errBoth := voucherService.BulkDeactivate(context.Background(), "LEAK", []string{"CODE1"})
+
+	// Assert
+	assert.Error(t, errNone)
+	assert.Error(t, errBoth)
+}
+
+// Test Validate Voucher
+func TestVoucherService_Validate_RulesSatisfied(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockVoucherRepository)
+	voucherService := NewVoucherService(mockRepo, 0)
+
+	existingVoucher := &entity.Voucher{
+		ID:              1,
+		VoucherCode:     "VIP10",
+		DiscountPercent: 10,
+		ExpiryDate:      time.Now().AddDate(0, 1, 0),
+		Status:          entity.VoucherStatusActive,
+		Rules:           `[{"type":"segment","values":["vip"]},{"type":"min_items","value":2}]`,
+	}
+
+	mockRepo.On("FindByVoucherCode", "VIP10").Return(existingVoucher, nil)
+
+	// Act
+	result, err := voucherService.Validate(context.Background(), "VIP10", rules.Context{CustomerSegments: []string{"vip"}, ItemCount: 3})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.True(t, result.Valid)
+	assert.Empty(t, result.Reasons)
+	assert.Equal(t, 10.0, result.DiscountPercent)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestVoucherService_Validate_RulesViolated(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockVoucherRepository)
+	voucherService := NewVoucherService(mockRepo, 0)
+
+	existingVoucher := &entity.Voucher{
+		ID:          1,
+		VoucherCode: "WELCOME",
+		ExpiryDate:  time.Now().AddDate(0, -1, 0),
+		Status:      entity.VoucherStatusActive,
+		Rules:       `[{"type":"first_purchase"}]`,
+	}
+
+	mockRepo.On("FindByVoucherCode", "WELCOME").Return(existingVoucher, nil)
+
+	// Act
+	result, err := voucherService.Validate(context.Background(), "WELCOME", rules.Context{IsFirstPurchase: false})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.False(t, result.Valid)
+	assert.Len(t, result.Reasons, 2)
+	assert.Contains(t, result.Reasons, "voucher has expired")
+	mockRepo.AssertExpectations(t)
+}
+
+func TestVoucherService_Validate_SegmentMembership(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockVoucherRepository)
+	mockSegmentRepo := new(MockSegmentRepository)
+	voucherService := NewVoucherService(mockRepo, 0, WithSegments(NewSegmentService(mockSegmentRepo, nil)))
+
+	segmentID := uint(7)
+	existingVoucher := &entity.Voucher{
+		ID:              1,
+		VoucherCode:     "VIP10",
+		DiscountPercent: 10,
+		ExpiryDate:      time.Now().AddDate(0, 1, 0),
+		Status:          entity.VoucherStatusActive,
+		SegmentID:       &segmentID,
+	}
+
+	mockRepo.On("FindByVoucherCode", "VIP10").Return(existingVoucher, nil)
+	mockSegmentRepo.On("FindByID", segmentID).Return(&entity.Segment{ID: segmentID, Type: entity.SegmentTypeStatic}, nil)
+	mockSegmentRepo.On("IsMember", segmentID, "cust-1").Return(true, nil)
+	mockSegmentRepo.On("IsMember", segmentID, "cust-2").Return(false, nil)
+
+	// Act
+	member, memberErr := voucherService.Validate(context.Background(), "VIP10", rules.Context{CustomerID: "cust-1"})
+	outsider, outsiderErr := voucherService.Validate(context.Background(), "VIP10", rules.Context{CustomerID: "cust-2"})
+	anonymous, anonymousErr := voucherService.Validate(context.Background(), "VIP10", rules.Context{})
+
+	// Assert
+	assert.NoError(t, memberErr)
+	assert.True(t, member.Valid)
+	assert.NoError(t, outsiderErr)
+	assert.False(t, outsider.Valid)
+	assert.Contains(t, outsider.Reasons, "customer is not in the voucher's target segment")
+	assert.NoError(t, anonymousErr)
+	assert.Contains(t, anonymous.Reasons, "customer id is required for this voucher")
+}
+
+func TestVoucherService_Create_UnknownSegment(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockVoucherRepository)
+	mockSegmentRepo := new(MockSegmentRepository)
+	voucherService := NewVoucherService(mockRepo, 0, WithSegments(NewSegmentService(mockSegmentRepo, nil)))
+
+	segmentID := uint(7)
+	req := &domainService.CreateVoucherCommand{
+		VoucherCode:     "VIP10",
+		DiscountPercent: 10,
+		ExpiryDate:      time.Now().Add(24 * time.Hour).Format("2006-01-02"),
+		SegmentID:       &segmentID,
+	}
+
+	mockRepo.On("FindByVoucherCode", req.VoucherCode).Return((*entity.Voucher)(nil), nil)
+	mockSegmentRepo.On("FindByID", segmentID).Return(nil, gorm.ErrRecordNotFound)
+
+	// Act
+	voucher, err := voucherService.Create(context.Background(), req)
+
+	// Assert
+	assert.ErrorIs(t, err, domainService.ErrSegmentNotFound)
+	assert.Nil(t, voucher)
+	mockRepo.AssertNotCalled(t, "Create", mock.Anything)
+}
+
+func TestVoucherService_Validate_NotFound(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockVoucherRepository)
+	voucherService := NewVoucherService(mockRepo, 0)
+
+	mockRepo.On("FindByVoucherCode", "UNKNOWN").Return(nil, nil)
+
+	// Act
+	result, err := voucherService.Validate(context.Background(), "UNKNOWN", rules.Context{})
+
+	// Assert
+	assert.ErrorIs(t, err, domainService.ErrVoucherNotFound)
+	assert.Nil(t, result)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestVoucherService_Create_StoresMetadataAsJSON(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockVoucherRepository)
+	voucherService := NewVoucherService(mockRepo, 0)
+
+	tomorrow := time.Now().Add(24 * time.Hour).Format("2006-01-02")
+	req := &domainService.CreateVoucherCommand{
+		VoucherCode:     "TEST123",
+		DiscountPercent: 10.0,
+		ExpiryDate:      tomorrow,
+		Metadata:        map[string]string{"partner_account": "ACC-123"},
+	}
+
+	mockRepo.On("FindByVoucherCode", req.VoucherCode).Return((*entity.Voucher)(nil), nil)
+	mockRepo.On("Create", mock.AnythingOfType("*entity.Voucher")).Return(nil)
+
+	// Act
+	voucher, err := voucherService.Create(context.Background(), req)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"partner_account":"ACC-123"}`, voucher.Metadata)
+}
+
+func TestVoucherService_Create_TooManyMetadataKeys(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockVoucherRepository)
+	voucherService := NewVoucherService(mockRepo, 0)
+
+	metadata := make(map[string]string)
+	for i := 0; i <= entity.MaxMetadataKeys; i++ {
+		metadata[fmt.Sprintf("key%d", i)] = "value"
+	}
+	tomorrow := time.Now().Add(24 * time.Hour).Format("2006-01-02")
+	req := &domainService.CreateVoucherCommand{
+		VoucherCode:     "TEST123",
+		DiscountPercent: 10.0,
+		ExpiryDate:      tomorrow,
+		Metadata:        metadata,
+	}
+
+	mockRepo.On("FindByVoucherCode", req.VoucherCode).Return((*entity.Voucher)(nil), nil)
+
+	// Act
+	voucher, err := voucherService.Create(context.Background(), req)
+
+	// Assert
+	assert.Error(t, err)
+	assert.Nil(t, voucher)
+	assert.Contains(t, err.Error(), "metadata")
+	mockRepo.AssertNotCalled(t, "Create", mock.Anything)
+}
+
+func TestVoucherService_Create_InvalidRules(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockVoucherRepository)
+	voucherService := NewVoucherService(mockRepo, 0)
+
+	cmd := &domainService.CreateVoucherCommand{
+		VoucherCode:     "BADRULES",
+		DiscountPercent: 10,
+		ExpiryDate:      time.Now().AddDate(0, 1, 0).Format("2006-01-02"),
+		Rules:           `[{"type":"unknown"}]`,
+	}
+
+	mockRepo.On("FindByVoucherCode", "BADRULES").Return(nil, nil)
+
+	// Act
+	voucher, err := voucherService.Create(context.Background(), cmd)
+
+	// Assert
+	assert.Error(t, err)
+	assert.Nil(t, voucher)
+	mockRepo.AssertNotCalled(t, "Create", mock.Anything)
+}
+
+func TestVoucherService_Create_RedemptionLimits(t *testing.T) {
+	ten, zero := 10, 0
+	tests := []struct {
+		name            string
+		maxRedemptions  *int
+		maxPerUser      int
+		expectedPerUser int
+		expectedErr     string
+	}{
+		{name: "defaults to once per customer", expectedPerUser: 1},
+		{name: "capped", maxRedemptions: &ten, maxPerUser: 3, expectedPerUser: 3},
+		{name: "zero total", maxRedemptions: &zero, expectedErr: "max redemptions must be at least 1"},
+		{name: "negative per customer", maxPerUser: -1, expectedErr: "max redemptions per user must be at least 1"},
+		{name: "per customer above total", maxRedemptions: &ten, maxPerUser: 11, expectedErr: "max redemptions per user cannot exceed max redemptions"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			mockRepo := new(MockVoucherRepository)
+			voucherService := NewVoucherService(mockRepo, 0)
+
+			mockRepo.On("FindByVoucherCode", "LIMITED").Return(nil, nil)
+			mockRepo.On("Create", mock.AnythingOfType("*entity.Voucher")).Return(nil)
+
+			// Act
+			voucher, err := voucherService.Create(context.Background(), &domainService.CreateVoucherCommand{
+				VoucherCode:           "LIMITED",
+				DiscountPercent:       10,
+				ExpiryDate:            time.Now().AddDate(0, 1, 0).Format("2006-01-02"),
+				MaxRedemptions:        tt.maxRedemptions,
+				MaxRedemptionsPerUser: tt.maxPerUser,
+			})
+
+			// Assert
+			if tt.expectedErr != "" {
+				assert.EqualError(t, err, tt.expectedErr)
+				mockRepo.AssertNotCalled(t, "Create", mock.Anything)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.maxRedemptions, voucher.MaxRedemptions)
+			assert.Equal(t, tt.expectedPerUser, voucher.MaxRedemptionsPerUser)
+		})
+	}
+}
+
+// Test GetByID
+func TestVoucherService_Create_FixedAmount(t *testing.T) {
+	tests := []struct {
+		name             string
+		cmd              domainService.CreateVoucherCommand
+		expectedAmount   int64
+		expectedCurrency string
+		expectedErr      string
+	}{
+		{name: "fixed amount", cmd: domainService.CreateVoucherCommand{DiscountType: entity.DiscountTypeFixedAmount, DiscountAmount: 50000, Currency: " idr "}, expectedAmount: 5000000, expectedCurrency: "IDR"},
+		{name: "cents", cmd: domainService.CreateVoucherCommand{DiscountType: entity.DiscountTypeFixedAmount, DiscountAmount: 0.1, Currency: "USD"}, expectedAmount: 10, expectedCurrency: "USD"},
+		{name: "currency without minor unit", cmd: domainService.CreateVoucherCommand{DiscountType: entity.DiscountTypeFixedAmount, DiscountAmount: 500, Currency: "JPY"}, expectedAmount: 500, expectedCurrency: "JPY"},
+		{name: "currency with three decimals", cmd: domainService.CreateVoucherCommand{DiscountType: entity.DiscountTypeFixedAmount, DiscountAmount: 1.125, Currency: "KWD"}, expectedAmount: 1125, expectedCurrency: "KWD"},
+		{name: "missing currency", cmd: domainService.CreateVoucherCommand{DiscountType: entity.DiscountTypeFixedAmount, DiscountAmount: 50000}, expectedErr: "invalid currency '': must be a 3-letter ISO 4217 code such as IDR"},
+		{name: "zero amount", cmd: domainService.CreateVoucherCommand{DiscountType: entity.DiscountTypeFixedAmount, Currency: "IDR"}, expectedErr: "discount amount 0 out of range (must be above 0 and below 1000000000000)"},
+		{name: "fractional cents", cmd: domainService.CreateVoucherCommand{DiscountType: entity.DiscountTypeFixedAmount, DiscountAmount: 9.999, Currency: "USD"}, expectedErr: "invalid discount amount: amount 9.999 has more than 2 decimal places for USD"},
+		{name: "fraction of a yen", cmd: domainService.CreateVoucherCommand{DiscountType: entity.DiscountTypeFixedAmount, DiscountAmount: 500.5, Currency: "JPY"}, expectedErr: "invalid discount amount: amount 500.5 has more than 0 decimal places for JPY"},
+		{name: "percent on fixed amount", cmd: domainService.CreateVoucherCommand{DiscountType: entity.DiscountTypeFixedAmount, DiscountPercent: 10, DiscountAmount: 50000, Currency: "IDR"}, expectedErr: "discount percent does not apply to fixed_amount discounts"},
+		{name: "amount on percent", cmd: domainService.CreateVoucherCommand{DiscountPercent: 10, DiscountAmount: 50000}, expectedErr: "discount amount and currency only apply to fixed_amount discounts"},
+		{name: "unknown type", cmd: domainService.CreateVoucherCommand{DiscountType: "bogo", DiscountPercent: 10}, expectedErr: "discount type must be percent or fixed_amount"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			mockRepo := new(MockVoucherRepository)
+			voucherService := NewVoucherService(mockRepo, 0)
+
+			mockRepo.On("FindByVoucherCode", "RP50K").Return(nil, nil)
+			mockRepo.On("Create", mock.AnythingOfType("*entity.Voucher")).Return(nil)
+
+			cmd := tt.cmd
+			cmd.VoucherCode = "RP50K"
+			cmd.ExpiryDate = time.Now().AddDate(0, 1, 0).Format("2006-01-02")
+
+			// Act
+			voucher, err := voucherService.Create(context.Background(), &cmd)
+
+			// Assert
+			if tt.expectedErr != "" {
+				assert.EqualError(t, err, tt.expectedErr)
+				mockRepo.AssertNotCalled(t, "Create", mock.Anything)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, entity.DiscountTypeFixedAmount, voucher.DiscountType)
+			assert.Equal(t, tt.expectedAmount, voucher.DiscountAmount)
+			assert.Equal(t, tt.expectedCurrency, voucher.Currency)
+			assert.Zero(t, voucher.DiscountPercent)
+			assert.Equal(t, entity.VoucherStatusActive, voucher.Status)
+		})
+	}
+}
+
+func TestVoucherService_Create_FixedAmountApproval(t *testing.T) {
+	tests := []struct {
+		name           string
+		thresholds     map[string]int64
+		amount         float64
+		currency       string
+		expectedStatus string
+	}{
+		{name: "at the currency's threshold", thresholds: map[string]int64{"IDR": 10000000}, amount: 100000, currency: "IDR", expectedStatus: entity.VoucherStatusActive},
+		{name: "above the currency's threshold", thresholds: map[string]int64{"IDR": 10000000}, amount: 150000, currency: "IDR", expectedStatus: entity.VoucherStatusPendingApproval},
+		{name: "currency without a threshold", thresholds: map[string]int64{"IDR": 10000000}, amount: 1, currency: "USD", expectedStatus: entity.VoucherStatusPendingApproval},
+		{name: "no amount thresholds", amount: 1, currency: "IDR", expectedStatus: entity.VoucherStatusPendingApproval},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			mockRepo := new(MockVoucherRepository)
+			voucherService := NewVoucherService(mockRepo, 50, WithApprovalAmountThresholds(tt.thresholds))
+
+			mockRepo.On("FindByVoucherCode", "RP50K").Return(nil, nil)
+			mockRepo.On("Create", mock.AnythingOfType("*entity.Voucher")).Return(nil)
+
+			// Act
+			voucher, err := voucherService.Create(context.Background(), &domainService.CreateVoucherCommand{
+				VoucherCode: "RP50K", DiscountType: entity.DiscountTypeFixedAmount, DiscountAmount: tt.amount, Currency: tt.currency,
+				ExpiryDate: time.Now().AddDate(0, 1, 0).Format("2006-01-02"),
+			})
+
+			// Assert
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedStatus, voucher.Status)
+		})
+	}
+}
+
+func TestFormatDiscount(t *testing.T) {
+	tests := []struct {
+		voucher  entity.Voucher
+		expected string
+	}{
+		{entity.Voucher{DiscountPercent: 12.5}, "12.5%"},
+		{entity.Voucher{DiscountType: entity.DiscountTypeFixedAmount, DiscountAmount: 5000000, Currency: "IDR"}, "IDR 50,000"},
+		{entity.Voucher{DiscountType: entity.DiscountTypeFixedAmount, DiscountAmount: 123456750, Currency: "USD"}, "USD 1,234,567.50"},
+		{entity.Voucher{DiscountType: entity.DiscountTypeFixedAmount, DiscountAmount: 99900, Currency: "EUR"}, "EUR 999"},
+		{entity.Voucher{DiscountType: entity.DiscountTypeFixedAmount, DiscountAmount: 5000, Currency: "JPY"}, "JPY 5,000"},
+		{entity.Voucher{DiscountType: entity.DiscountTypeFixedAmount, DiscountAmount: 1250, Currency: "KWD"}, "KWD 1.250"},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.expected, formatDiscount(&tt.voucher))
+	}
+}
+
+func TestVoucherService_GetByID_Success(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockVoucherRepository)
+	voucherService := NewVoucherService(mockRepo, 0)
+
+	voucherID := uint(1)
+	expectedVoucher := &entity.Voucher{
+		ID:              voucherID,
+		VoucherCode:     "TEST123",
+		DiscountPercent: 10.0,
+	}
+
+	mockRepo.On("FindByID", voucherID).Return(expectedVoucher, nil)
+
+	// Act
+	voucher, err := voucherService.GetByID(context.Background(), voucherID)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.NotNil(t, voucher)
+	assert.Equal(t, expectedVoucher.ID, voucher.ID)
+	assert.Equal(t, expectedVoucher.VoucherCode, voucher.VoucherCode)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestVoucherService_GetByID_NotFound(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockVoucherRepository)
+	voucherService := NewVoucherService(mockRepo, 0)
+
+	voucherID := uint(999)
+
+	mockRepo.On("FindByID", voucherID).Return(nil, gorm.ErrRecordNotFound)
+
+	// Act
+	voucher, err := voucherService.GetByID(context.Background(), voucherID)
+
+	// Assert
+	assert.Error(t, err)
+	assert.Nil(t, voucher)
+	assert.Contains(t, err.Error(), "not found")
+	mockRepo.AssertExpectations(t)
+}
+
+// Test GetAll
+func TestVoucherService_GetAll_Success(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockVoucherRepository)
+	voucherService := NewVoucherService(mockRepo, 0)
+
+	expectedVouchers := []*entity.Voucher{
+		{ID: 1, VoucherCode: "TEST1", DiscountPercent: 10.0},
+		{ID: 2, VoucherCode: "TEST2", DiscountPercent: 20.0},
+	}
+	expectedTotal := int64(2)
+
+	mockRepo.On("FindAll", 1, 10, "", []utils.SortField{{Field: "created_at", Desc: true}}).Return(expectedVouchers, expectedTotal, nil)
+
+	// Act
+	vouchers, total, err := voucherService.GetAll(context.Background(), 1, 10, "", []utils.SortField{{Field: "created_at", Desc: true}})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, expectedVouchers, vouchers)
+	assert.Equal(t, expectedTotal, total)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestVoucherService_GetAll_WithSearch(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockVoucherRepository)
+	voucherService := NewVoucherService(mockRepo, 0)
+
+	search := "TEST"
+	expectedVouchers := []*entity.Voucher{
+		{ID: 1, VoucherCode: "TEST1", DiscountPercent: 10.0},
+	}
+	expectedTotal := int64(1)
+
+	mockRepo.On("FindAll", 1, 10, search, []utils.SortField{{Field: "created_at", Desc: true}}).Return(expectedVouchers, expectedTotal, nil)
+
+	// Act
+	vouchers, total, err := voucherService.GetAll(context.Background(), 1, 10, search, []utils.SortField{{Field: "created_at", Desc: true}})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, expectedVouchers, vouchers)
+	assert.Equal(t, expectedTotal, total)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestVoucherService_GetAll_Error(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockVoucherRepository)
+	voucherService := NewVoucherService(mockRepo, 0)
+
+	expectedError := errors.New("database error")
+
+	mockRepo.On("FindAll", 1, 10, "", []utils.SortField{{Field: "created_at", Desc: true}}).Return(nil, int64(0), expectedError)
+
+	// Act
+	vouchers, total, err := voucherService.GetAll(context.Background(), 1, 10, "", []utils.SortField{{Field: "created_at", Desc: true}})
+
+	// Assert
+	assert.Error(t, err)
+	assert.Nil(t, vouchers)
+	assert.Equal(t, int64(0), total)
+	assert.Equal(t, expectedError, err)
+	mockRepo.AssertExpectations(t)
+}
+
+// Test ImportRecords
+func TestVoucherService_ImportRecords_MixedRows(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockVoucherRepository)
+	voucherService := NewVoucherService(mockRepo, 0)
+
+	tomorrow := time.Now().Add(24 * time.Hour).Format("2006-01-02")
+	records := [][]string{
+		{"voucher_code", "discount_percent", "expiry_date"},
+		{"SHEET1", "10", tomorrow},
+		{"SHEET2", "abc", tomorrow},
+	}
+
+	mockRepo.On("CheckDuplicateCodes", []string{"SHEET1", "SHEET2"}).Return([]string{}, nil)
+	mockRepo.On("BulkCreate", mock.AnythingOfType("[]*entity.Voucher")).Return(nil)
+
+	// Act
+	result, err := voucherService.ImportRecords(context.Background(), records, "importer@example.com", entity.UserRoleAdmin)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, 2, result.TotalRows)
+	assert.Equal(t, 1, result.Success)
+	assert.Equal(t, 1, result.Failed)
+	assert.Equal(t, 3, result.Errors[0].Row)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestVoucherService_ImportRecords_NoDataRows(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockVoucherRepository)
+	voucherService := NewVoucherService(mockRepo, 0)
+
+	records := [][]string{
+		{"voucher_code", "discount_percent", "expiry_date"},
+	}
+
+	// Act
+	result, err := voucherService.ImportRecords(context.Background(), records, "importer@example.com", entity.UserRoleAdmin)
+
+	// Assert
+	assert.Error(t, err)
+	assert.Nil(t, result)
+}
+
+func TestVoucherService_ImportRecords_ExistingKeys(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockVoucherRepository)
+	voucherService := NewVoucherService(mockRepo, 0)
+
+	tomorrow := time.Now().Add(24 * time.Hour).Format("2006-01-02")
+	records := [][]string{
+		{"voucher_code", "discount_percent", "expiry_date", "external_id"},
+		{"SHEET1", "10", tomorrow, "crm-1"},
+		{"TAKEN", "10", tomorrow, "crm-2"},
+		{"SHEET3", "10", tomorrow, "crm-3"},
+		{"SHEET4", "10", tomorrow},
+	}
+
+	mockRepo.On("CheckDuplicateCodes", []string{"SHEET1", "TAKEN", "SHEET3", "SHEET4"}).Return([]string{"TAKEN"}, nil).Once()
+	mockRepo.On("CheckDuplicateExternalIDs", []string{"crm-1", "crm-2", "crm-3"}).Return([]string{"crm-3"}, nil).Once()
+	mockRepo.On("BulkCreate", mock.MatchedBy(func(vouchers []*entity.Voucher) bool {
+		return len(vouchers) == 2 && vouchers[0].VoucherCode == "SHEET1" && vouchers[1].VoucherCode == "SHEET4"
+	})).Return(nil)
+
+	// Act
+	result, err := voucherService.ImportRecords(context.Background(), records, "importer@example.com", entity.UserRoleAdmin)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, 2, result.Success)
+	if assert.Len(t, result.Errors, 2) {
+		assert.Equal(t, "voucher code 'TAKEN' already exists", result.Errors[0].Error)
+		assert.Equal(t, "external id 'crm-3' already exists", result.Errors[1].Error)
+	}
+	mockRepo.AssertNotCalled(t, "FindByVoucherCode", mock.Anything)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestVoucherService_ImportRecords_ExpiresToday(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockVoucherRepository)
+	voucherService := NewVoucherService(mockRepo, 0)
+
+	now := time.Now()
+	records := [][]string{
+		{"voucher_code", "discount_percent", "expiry_date"},
+		{"TODAY", "10", now.Format("2006-01-02")},
+		{"YESTERDAY", "10", now.AddDate(0, 0, -1).Format("2006-01-02")},
+	}
+
+	mockRepo.On("CheckDuplicateCodes", []string{"TODAY", "YESTERDAY"}).Return([]string{}, nil)
+	mockRepo.On("BulkCreate", mock.AnythingOfType("[]*entity.Voucher")).Return(nil)
+
+	// Act
+	result, err := voucherService.ImportRecords(context.Background(), records, "importer@example.com", entity.UserRoleAdmin)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, 1, result.Success)
+	if assert.Len(t, result.Errors, 1) {
+		assert.Equal(t, 3, result.Errors[0].Row)
+		assert.Contains(t, result.Errors[0].Error, "must be today or in the future")
+	}
+}
+
+func TestVoucherService_ImportRecords_RepeatedExternalID(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockVoucherRepository)
+	voucherService := NewVoucherService(mockRepo, 0)
+
+	tomorrow := time.Now().Add(24 * time.Hour).Format("2006-01-02")
+	records := [][]string{
+		{"voucher_code", "discount_percent", "expiry_date", "external_id"},
+		{"SHEET1", "10", tomorrow, "crm-1"},
+		{"SHEET2", "10", tomorrow, "crm-1"},
+		{"SHEET3", "10", tomorrow},
+	}
+
+	mockRepo.On("CheckDuplicateCodes", []string{"SHEET1", "SHEET2", "SHEET3"}).Return([]string{}, nil)
+	mockRepo.On("CheckDuplicateExternalIDs", []string{"crm-1", "crm-1"}).Return([]string{}, nil)
+	mockRepo.On("BulkCreate", mock.AnythingOfType("[]*entity.Voucher")).Return(nil)
+
+	// Act
+	result, err := voucherService.ImportRecords(context.Background(), records, "importer@example.com", entity.UserRoleAdmin)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, 2, result.Success)
+	assert.Equal(t, 1, result.Failed)
+	assert.Equal(t, 3, result.Errors[0].Row)
+	assert.Contains(t, result.Errors[0].Error, "repeated")
+	mockRepo.AssertExpectations(t)
+}
+
+func TestVoucherService_ImportRecords_DetectsSchemaVersion(t *testing.T) {
+	tomorrow := time.Now().Add(24 * time.Hour).Format("2006-01-02")
+	tests := []struct {
+		name    string
+		header  []string
+		version int
+	}{
+		{"three columns", []string{"voucher_code", "discount_percent", "expiry_date"}, domainService.CSVSchemaV1},
+		{"legacy names", []string{"code", "discount", "expiry"}, domainService.CSVSchemaV1},
+		{"extended columns", []string{"voucher_code", "discount_percent", "expiry_date", "external_id"}, domainService.CSVSchemaV3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			mockRepo := new(MockVoucherRepository)
+			voucherService := NewVoucherService(mockRepo, 0)
+			mockRepo.On("CheckDuplicateCodes", []string{"SHEET1"}).Return([]string{}, nil)
+			mockRepo.On("BulkCreate", mock.AnythingOfType("[]*entity.Voucher")).Return(nil)
+
+			// Act
+			result, err := voucherService.ImportRecords(context.Background(), [][]string{tt.header, {"SHEET1", "10", tomorrow}}, "importer@example.com", entity.UserRoleAdmin)
+
+			// Assert
+			assert.NoError(t, err)
+			assert.Equal(t, tt.version, result.SchemaVersion)
+			assert.Equal(t, 1, result.Success)
+		})
+	}
+}
+
+func TestVoucherService_ImportVouchers_SchemaV1IgnoresExtraColumns(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockVoucherRepository)
+	voucherService := NewVoucherService(mockRepo, 0)
+
+	tomorrow := time.Now().Add(24 * time.Hour).Format("2006-01-02")
+	file := newCSVFile("code,discount,expiry,notes\nOLD1,10," + tomorrow + ",not an external id\n")
+
+	mockRepo.On("CheckDuplicateCodes", []string{"OLD1"}).Return([]string{}, nil)
+	mockRepo.On("BulkCreate", mock.MatchedBy(func(vouchers []*entity.Voucher) bool {
+		return len(vouchers) == 1 && vouchers[0].ExternalID == nil
+	})).Return(nil)
+
+	// Act
+	result, err := voucherService.ImportVouchers(context.Background(), file, domainService.CSVSchemaV1, "importer@example.com", entity.UserRoleAdmin)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, domainService.CSVSchemaV1, result.SchemaVersion)
+	assert.Equal(t, 1, result.Success)
+	mockRepo.AssertNotCalled(t, "CheckDuplicateExternalIDs", mock.Anything)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestVoucherService_ImportVouchers_HeaderContradictsDeclaredSchema(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockVoucherRepository)
+	voucherService := NewVoucherService(mockRepo, 0)
+
+	file := newCSVFile("voucher_code,discount_percent,expiry_date,external_id\nNEW1,10,2030-01-01,crm-1\n")
+
+	// Act
+	result, err := voucherService.ImportVouchers(context.Background(), file, domainService.CSVSchemaV1, "importer@example.com", entity.UserRoleAdmin)
+
+	// Assert
+	assert.ErrorContains(t, err, "external_id")
+	assert.Nil(t, result)
+	mockRepo.AssertNotCalled(t, "BulkCreate", mock.Anything)
+}
+
+func TestVoucherService_ImportVouchers_UnsupportedSchema(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockVoucherRepository)
+	voucherService := NewVoucherService(mockRepo, 0)
+
+	file := newCSVFile("voucher_code,discount_percent,expiry_date\nNEW1,10,2030-01-01\n")
+
+	// Act
+	result, err := voucherService.ImportVouchers(context.Background(), file, 9, "importer@example.com", entity.UserRoleAdmin)
+
+	// Assert
+	assert.ErrorIs(t, err, domainService.ErrUnsupportedCSVSchema)
+	assert.Nil(t, result)
+}
+
+func TestVoucherService_ImportRecords_WarnsAboutSuspiciousRows(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockVoucherRepository)
+	voucherService := NewVoucherService(mockRepo, 0)
+
+	tomorrow := time.Now().Add(24 * time.Hour).Format("2006-01-02")
+	farAway := time.Now().AddDate(importWarningExpiryYears, 1, 0).Format("2006-01-02")
+	records := [][]string{
+		{"voucher_code", "discount_percent", "expiry_date"},
+		{"NORMAL", "10", tomorrow},
+		{"FREE", "100", tomorrow},
+		{"FOREVER", "10", farAway},
+	}
+
+	mockRepo.On("CheckDuplicateCodes", []string{"NORMAL", "FREE", "FOREVER"}).Return([]string{}, nil)
+	mockRepo.On("BulkCreate", mock.AnythingOfType("[]*entity.Voucher")).Return(nil)
+
+	// Act
+	result, err := voucherService.ImportRecords(context.Background(), records, "importer@example.com", entity.UserRoleAdmin)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, 3, result.Success)
+	assert.Empty(t, result.Errors)
+	if assert.Len(t, result.Warnings, 2) {
+		assert.Equal(t, 3, result.Warnings[0].Row)
+		assert.Contains(t, result.Warnings[0].Warning, "100% discount")
+		assert.Equal(t, 4, result.Warnings[1].Row)
+		assert.Contains(t, result.Warnings[1].Warning, "more than 5 years away")
+	}
+	mockRepo.AssertExpectations(t)
+}
+
+func TestVoucherService_ImportRecords_NoWarningsForSkippedRows(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockVoucherRepository)
+	voucherService := NewVoucherService(mockRepo, 0)
+
+	tomorrow := time.Now().Add(24 * time.Hour).Format("2006-01-02")
+	records := [][]string{
+		{"voucher_code", "discount_percent", "expiry_date"},
+		{"FREE1", "100", tomorrow},
+		{"FREE2", "100", tomorrow},
+	}
+
+	mockRepo.On("CheckDuplicateCodes", []string{"FREE1", "FREE2"}).Return([]string{}, nil)
+	mockRepo.On("BulkCreate", mock.AnythingOfType("[]*entity.Voucher")).Return(gorm.ErrDuplicatedKey)
+	mockRepo.On("BulkCreateSkipConflicts", mock.AnythingOfType("[]*entity.Voucher")).Return([]string{"FREE2"}, nil)
+
+	// Act
+	result, err := voucherService.ImportRecords(context.Background(), records, "importer@example.com", entity.UserRoleAdmin)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, 1, result.Failed)
+	if assert.Len(t, result.Warnings, 1) {
+		assert.Equal(t, 2, result.Warnings[0].Row)
+	}
+}
+
+// Test ImportTemplate
+func TestVoucherService_ImportTemplate_Imports(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockVoucherRepository)
+	voucherService := NewVoucherService(mockRepo, 0)
+
+	mockRepo.On("CheckDuplicateCodes", []string{"SUMMER25"}).Return([]string{}, nil)
+	mockRepo.On("CheckDuplicateExternalIDs", []string{"crm-1001"}).Return([]string{}, nil)
+	mockRepo.On("BulkCreate", mock.AnythingOfType("[]*entity.Voucher")).Return(nil)
+
+	// Act
+	template := voucherService.ImportTemplate()
+	result, err := voucherService.ImportRecords(context.Background(), template, "importer@example.com", entity.UserRoleAdmin)
+
+	// Assert
+	assert.Equal(t, csvSchemas[domainService.CurrentCSVSchemaVersion].columns, template[0])
+	assert.NoError(t, err)
+	assert.Equal(t, domainService.CurrentCSVSchemaVersion, result.SchemaVersion)
+	assert.Equal(t, 1, result.Success)
+	assert.Empty(t, result.Warnings)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestVoucherService_ImportTemplate_EveryColumnHasExample(t *testing.T) {
+	for version, schema := range csvSchemas {
+		for _, column := range schema.columns {
+			assert.Contains(t, csvColumnExamples, column, "schema version %d", version)
+		}
+	}
+}
+
+// Test ImportBatch
+func TestVoucherService_ImportBatch_DuplicateExternalID(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockVoucherRepository)
+	voucherService := NewVoucherService(mockRepo, 0)
+
+	tomorrow := time.Now().Add(24 * time.Hour).Format("2006-01-02")
+	commands := []domainService.CreateVoucherCommand{
+		{VoucherCode: "BATCH1", ExternalID: "crm-1", DiscountPercent: 10, ExpiryDate: tomorrow},
+		{VoucherCode: "BATCH2", ExternalID: "crm-2", DiscountPercent: 10, ExpiryDate: tomorrow},
+		{VoucherCode: "BATCH3", ExternalID: "crm-2", DiscountPercent: 10, ExpiryDate: tomorrow},
+	}
+
+	mockRepo.On("CheckDuplicateCodes", []string{"BATCH1", "BATCH2", "BATCH3"}).Return([]string{}, nil)
+	mockRepo.On("CheckDuplicateExternalIDs", []string{"crm-1", "crm-2", "crm-2"}).Return([]string{"crm-1"}, nil)
+	mockRepo.On("BulkCreate", mock.AnythingOfType("[]*entity.Voucher")).Return(nil)
+
+	// Act
+	result, err := voucherService.ImportBatch(context.Background(), commands)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, 1, result.Inserted)
+	assert.Len(t, result.Errors, 2)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestVoucherService_ImportRecords_DisplayMetadata(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockVoucherRepository)
+	voucherService := NewVoucherService(mockRepo, 0)
+
+	tomorrow := time.Now().Add(24 * time.Hour).Format("2006-01-02")
+	records := [][]string{
+		{"voucher_code", "discount_percent", "expiry_date", "external_id", "display_name", "description", "terms_url"},
+		{"SHEET1", "10", tomorrow, "", "Summer sale", "10% off everything", "https://example.com/terms"},
+	}
+
+	mockRepo.On("CheckDuplicateCodes", []string{"SHEET1"}).Return([]string{}, nil)
+	mockRepo.On("BulkCreate", mock.MatchedBy(func(vouchers []*entity.Voucher) bool {
+		return len(vouchers) == 1 &&
+			vouchers[0].DisplayName == "Summer sale" &&
+			vouchers[0].Description == "10% off everything" &&
+			vouchers[0].TermsURL == "https://example.com/terms" &&
+			vouchers[0].ExternalID == nil
+	})).Return(nil)
+
+	// Act
+	result, err := voucherService.ImportRecords(context.Background(), records, "importer@example.com", entity.UserRoleAdmin)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, 1, result.Success)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestVoucherService_ImportRecords_FixedAmount(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockVoucherRepository)
+	voucherService := NewVoucherService(mockRepo, 0)
+
+	tomorrow := time.Now().Add(24 * time.Hour).Format("2006-01-02")
+	records := [][]string{
+		{"voucher_code", "discount_percent", "expiry_date", "external_id", "display_name", "description", "terms_url", "discount_type", "discount_amount", "currency"},
+		{"RP50K", "", tomorrow, "", "", "", "", "fixed_amount", "50000", "IDR"},
+		{"PCT10", "10", tomorrow, "", "", "", "", "", "", ""},
+		{"NOCUR", "", tomorrow, "", "", "", "", "fixed_amount", "50000", ""},
+		{"BADAMT", "", tomorrow, "", "", "", "", "fixed_amount", "lots", "IDR"},
+	}
+
+	mockRepo.On("CheckDuplicateCodes", []string{"RP50K", "PCT10", "NOCUR", "BADAMT"}).Return([]string{}, nil)
+	mockRepo.On("BulkCreate", mock.MatchedBy(func(vouchers []*entity.Voucher) bool {
+		return len(vouchers) == 2 &&
+			vouchers[0].DiscountType == entity.DiscountTypeFixedAmount && vouchers[0].DiscountAmount == 5000000 && vouchers[0].Currency == "IDR" &&
+			vouchers[1].DiscountType == entity.DiscountTypePercent && vouchers[1].DiscountPercent == 10
+	})).Return(nil)
+
+	// Act
+	result, err := voucherService.ImportRecords(context.Background(), records, "importer@example.com", entity.UserRoleAdmin)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, domainService.CSVSchemaV3, result.SchemaVersion)
+	assert.Equal(t, 2, result.Success)
+	if assert.Len(t, result.Errors, 2) {
+		assert.Equal(t, 4, result.Errors[0].Row)
+		assert.Contains(t, result.Errors[0].Error, "invalid currency")
+		assert.Equal(t, "invalid discount amount 'lots': must be a number", result.Errors[1].Error)
+	}
+	mockRepo.AssertExpectations(t)
+}
+
+func TestVoucherService_ImportBatch_ConflictFallback(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockVoucherRepository)
+	voucherService := NewVoucherService(mockRepo, 0)
+
+	tomorrow := time.Now().Add(24 * time.Hour).Format("2006-01-02")
+	commands := []domainService.CreateVoucherCommand{
+		{VoucherCode: "BATCH1", DiscountPercent: 10, ExpiryDate: tomorrow},
+		{VoucherCode: "BATCH2", DiscountPercent: 10, ExpiryDate: tomorrow},
+	}
+
+	// Another import inserts BATCH2 after the duplicate check
+	mockRepo.On("CheckDuplicateCodes", []string{"BATCH1", "BATCH2"}).Return([]string{}, nil)
+	mockRepo.On("BulkCreate", mock.AnythingOfType("[]*entity.Voucher")).Return(gorm.ErrDuplicatedKey)
+	mockRepo.On("BulkCreateSkipConflicts", mock.AnythingOfType("[]*entity.Voucher")).Return([]string{"BATCH2"}, nil)
+
+	// Act
+	result, err := voucherService.ImportBatch(context.Background(), commands)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, 1, result.Inserted)
+	assert.Equal(t, 1, result.Duplicates)
+	assert.Equal(t, []string{"BATCH2"}, result.DuplicateCodes)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestVoucherService_ImportRecords_ConflictFallback(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockVoucherRepository)
+	voucherService := NewVoucherService(mockRepo, 0)
+
+	tomorrow := time.Now().Add(24 * time.Hour).Format("2006-01-02")
+	records := [][]string{
+		{"voucher_code", "discount_percent", "expiry_date"},
+		{"SHEET1", "10", tomorrow},
+		{"SHEET2", "10", tomorrow},
+	}
+
+	mockRepo.On("CheckDuplicateCodes", []string{"SHEET1", "SHEET2"}).Return([]string{}, nil)
+	mockRepo.On("BulkCreate", mock.AnythingOfType("[]*entity.Voucher")).Return(gorm.ErrDuplicatedKey)
+	mockRepo.On("BulkCreateSkipConflicts", mock.AnythingOfType("[]*entity.Voucher")).Return([]string{"SHEET2"}, nil)
+
+	// Act
+	result, err := voucherService.ImportRecords(context.Background(), records, "importer@example.com", entity.UserRoleAdmin)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, 1, result.Success)
+	assert.Equal(t, 1, result.Failed)
+	assert.Equal(t, 3, result.Errors[0].Row)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestVoucherService_ImportRecords_PublishesEvent(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockVoucherRepository)
+	broker := events.NewBroker()
+	received, cancel := broker.Subscribe()
+	defer cancel()
+	voucherService := NewVoucherService(mockRepo, 0, WithEvents(broker))
+
+	tomorrow := time.Now().Add(24 * time.Hour).Format("2006-01-02")
+	records := [][]string{
+		{"voucher_code", "discount_percent", "expiry_date"},
+		{"EVENT1", "10", tomorrow},
+	}
+
+	mockRepo.On("CheckDuplicateCodes", []string{"EVENT1"}).Return([]string{}, nil)
+	mockRepo.On("BulkCreate", mock.AnythingOfType("[]*entity.Voucher")).Return(nil)
+
+	// Act
+	result, err := voucherService.ImportRecords(context.Background(), records, "importer@example.com", entity.UserRoleAdmin)
+
+	// Assert
+	assert.NoError(t, err)
+	event := <-received
+	assert.Equal(t, domainService.EventImportCompleted, event.Type)
+	assert.Equal(t, result, event.Data)
+}
+
+func TestVoucherService_ImportBatch_InsertErrorNotRetried(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockVoucherRepository)
+	voucherService := NewVoucherService(mockRepo, 0)
+
+	tomorrow := time.Now().Add(24 * time.Hour).Format("2006-01-02")
+	commands := []domainService.CreateVoucherCommand{
+		{VoucherCode: "BATCH1", DiscountPercent: 10, ExpiryDate: tomorrow},
+	}
+
+	mockRepo.On("CheckDuplicateCodes", []string{"BATCH1"}).Return([]string{}, nil)
+	mockRepo.On("BulkCreate", mock.AnythingOfType("[]*entity.Voucher")).Return(errors.New("connection reset"))
+
+	// Act
+	result, err := voucherService.ImportBatch(context.Background(), commands)
 
 	// Assert
 	assert.Error(t, err)
-	assert.Nil(t, vouchers)
-	assert.Equal(t, int64(0), total)
-	assert.Equal(t, expectedError, err)
+	assert.Nil(t, result)
+	mockRepo.AssertNotCalled(t, "BulkCreateSkipConflicts", mock.Anything)
+}
+
+// Test Suggest
+func TestVoucherService_Suggest_UsesLimit(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockVoucherRepository)
+	voucherService := NewVoucherService(mockRepo, 0)
+
+	mockRepo.On("SuggestCodes", "SUM", domainService.MaxSuggestions).Return([]string{"SUMMER24"}, nil)
+
+	// Act
+	codes, err := voucherService.Suggest(context.Background(), " SUM ")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"SUMMER24"}, codes)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestVoucherService_Suggest_EmptyQuery(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockVoucherRepository)
+	voucherService := NewVoucherService(mockRepo, 0)
+
+	// Act
+	codes, err := voucherService.Suggest(context.Background(), "  ")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Empty(t, codes)
+	mockRepo.AssertNotCalled(t, "SuggestCodes", mock.Anything, mock.Anything)
+}
+
+// Test CodeAvailable
+func TestVoucherService_CodeAvailable(t *testing.T) {
+	tests := []struct {
+		name          string
+		existing      *entity.Voucher
+		repoErr       error
+		wantAvailable bool
+		wantErr       bool
+	}{
+		{name: "free", wantAvailable: true},
+		{name: "not found error", repoErr: gorm.ErrRecordNotFound, wantAvailable: true},
+		{name: "taken", existing: &entity.Voucher{ID: 1, VoucherCode: "SUMMER24"}},
+		{name: "database error", repoErr: errors.New("connection refused"), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			mockRepo := new(MockVoucherRepository)
+			voucherService := NewVoucherService(mockRepo, 0)
+			mockRepo.On("FindByVoucherCode", "summer24").Return(tt.existing, tt.repoErr)
+
+			// Act
+			available, err := voucherService.CodeAvailable(context.Background(), "summer24")
+
+			// Assert
+			assert.Equal(t, tt.wantErr, err != nil)
+			assert.Equal(t, tt.wantAvailable, available)
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+// Test ExportVoucherParts
+func TestVoucherService_ExportVoucherParts_SplitsPages(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockVoucherRepository)
+	voucherService := NewVoucherService(mockRepo, 0)
+
+	sort := []utils.SortField{{Field: "created_at"}, {Field: "id"}}
+	expiry := time.Now().Add(24 * time.Hour)
+	firstPage := []*entity.Voucher{
+		{VoucherCode: "A", DiscountPercent: 10, ExpiryDate: expiry},
+		{VoucherCode: "B", DiscountPercent: 10, ExpiryDate: expiry},
+	}
+	secondPage := []*entity.Voucher{
+		{VoucherCode: "C", DiscountPercent: 10, ExpiryDate: expiry},
+	}
+	mockRepo.On("FindAll", 1, 2, "", sort).Return(firstPage, int64(3), nil)
+	mockRepo.On("FindAll", 2, 2, "", sort).Return(secondPage, int64(3), nil)
+
+	var files []string
+
+	// Act
+	err := voucherService.ExportVoucherParts(context.Background(), 2, domainService.CurrentCSVSchemaVersion, func(data []byte) error {
+		files = append(files, string(data))
+		return nil
+	})
+
+	// Assert
+	assert.NoError(t, err)
+	if assert.Len(t, files, 2) {
+		assert.Contains(t, files[0], "\nA,")
+		assert.Contains(t, files[0], "\nB,")
+		assert.Regexp(t, "^voucher_code,", files[1])
+		assert.Contains(t, files[1], "\nC,")
+	}
+	mockRepo.AssertExpectations(t)
+}
+
+func TestVoucherService_ExportVouchers_SchemaV1(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockVoucherRepository)
+	voucherService := NewVoucherService(mockRepo, 0)
+
+	externalID := "crm-1"
+	vouchers := []*entity.Voucher{
+		{VoucherCode: "A", ExternalID: &externalID, DiscountPercent: 10, ExpiryDate: time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC), DisplayName: "Sale"},
+	}
+	mockRepo.On("FindAll", 1, 100000, "", []utils.SortField{{Field: "created_at"}}).Return(vouchers, int64(1), nil)
+
+	// Act
+	data, err := voucherService.ExportVouchers(context.Background(), domainService.CSVSchemaV1)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "voucher_code,discount_percent,expiry_date\nA,10.00,2030-01-01\n", string(data))
+}
+
+func TestVoucherService_ExportVouchers_FixedAmount(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockVoucherRepository)
+	voucherService := NewVoucherService(mockRepo, 0)
+
+	vouchers := []*entity.Voucher{
+		{VoucherCode: "RP50K", DiscountType: entity.DiscountTypeFixedAmount, DiscountAmount: 5000000, Currency: "IDR", ExpiryDate: time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{VoucherCode: "YEN500", DiscountType: entity.DiscountTypeFixedAmount, DiscountAmount: 500, Currency: "JPY", ExpiryDate: time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{VoucherCode: "PCT10", DiscountType: entity.DiscountTypePercent, DiscountPercent: 10, ExpiryDate: time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)},
+	}
+	mockRepo.On("FindAll", 1, 100000, "", []utils.SortField{{Field: "created_at"}}).Return(vouchers, int64(3), nil)
+
+	// Act
+	data, err := voucherService.ExportVouchers(context.Background(), domainService.CSVSchemaV3)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "voucher_code,discount_percent,expiry_date,external_id,display_name,description,terms_url,discount_type,discount_amount,currency\n"+
+		"RP50K,,2030-01-01,,,,,fixed_amount,50000.00,IDR\n"+
+		"YEN500,,2030-01-01,,,,,fixed_amount,500,JPY\n"+
+		"PCT10,10.00,2030-01-01,,,,,percent,,\n", string(data))
+}
+
+func TestVoucherService_ExportVouchers_UnsupportedSchema(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockVoucherRepository)
+	voucherService := NewVoucherService(mockRepo, 0)
+
+	// Act
+	data, err := voucherService.ExportVouchers(context.Background(), 4)
+
+	// Assert
+	assert.ErrorIs(t, err, domainService.ErrUnsupportedCSVSchema)
+	assert.Nil(t, data)
+	mockRepo.AssertNotCalled(t, "FindAll", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+// Test ExportVoucherChanges
+func TestVoucherService_ExportVoucherChanges_Tombstones(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockVoucherRepository)
+	voucherService := NewVoucherService(mockRepo, 0)
+
+	since := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+	expiry := time.Date(2030, 6, 1, 0, 0, 0, 0, time.UTC)
+	updatedAt := time.Date(2030, 1, 2, 0, 0, 0, 0, time.UTC)
+	deletedAt := time.Date(2030, 1, 3, 0, 0, 0, 0, time.UTC)
+	changed := []*entity.Voucher{
+		{ID: 1, VoucherCode: "KEPT", DiscountPercent: 10, ExpiryDate: expiry, UpdatedAt: updatedAt},
+		{ID: 2, VoucherCode: "GONE", DiscountPercent: 10, ExpiryDate: expiry, UpdatedAt: updatedAt,
+			DeletedAt: gorm.DeletedAt{Time: deletedAt, Valid: true}},
+	}
+	mockRepo.On("FindChangedSince", since, uint(0), changeExportChunkSize).Return(changed, nil)
+
+	// Act
+	data, err := voucherService.ExportVoucherChanges(context.Background(), since)
+
+	// Assert
+	assert.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	if assert.Len(t, lines, 3) {
+		assert.True(t, strings.HasSuffix(lines[0], ",changed_at,deleted"))
+		assert.True(t, strings.HasSuffix(lines[1], ",2030-01-02T00:00:00Z,false"))
+		assert.True(t, strings.HasSuffix(lines[2], ",2030-01-03T00:00:00Z,true"))
+	}
+	mockRepo.AssertExpectations(t)
+}
+
+func TestVoucherService_ExportVoucherChanges_PagesByID(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockVoucherRepository)
+	voucherService := NewVoucherService(mockRepo, 0)
+
+	since := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+	fullChunk := make([]*entity.Voucher, changeExportChunkSize)
+	for i := range fullChunk {
+		fullChunk[i] = &entity.Voucher{ID: uint(i + 1), VoucherCode: fmt.Sprintf("CODE%d", i+1)}
+	}
+	mockRepo.On("FindChangedSince", since, uint(0), changeExportChunkSize).Return(fullChunk, nil)
+	mockRepo.On("FindChangedSince", since, uint(changeExportChunkSize), changeExportChunkSize).Return([]*entity.Voucher{}, nil)
+
+	// Act
+	data, err := voucherService.ExportVoucherChanges(context.Background(), since)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, changeExportChunkSize+1, strings.Count(string(data), "\n"))
+	mockRepo.AssertExpectations(t)
+}
+
+func TestVoucherService_RenderPDF_Success(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockVoucherRepository)
+	voucherService := NewVoucherService(mockRepo, 0)
+
+	nextWeek := time.Now().AddDate(0, 0, 7)
+	vouchers := []*entity.Voucher{
+		{ID: 1, VoucherCode: "SUMMER2024", DiscountPercent: 12.5, ExpiryDate: nextWeek, Status: entity.VoucherStatusActive, DisplayName: "Summer (sale)"},
+		{ID: 2, VoucherCode: "WINTER2024", DiscountPercent: 20, ExpiryDate: nextWeek, Status: entity.VoucherStatusActive, TermsURL: "https://example.com/terms"},
+	}
+	mockRepo.On("FindByIDs", []uint{2, 1}).Return(vouchers, nil)
+
+	// Act
+	data, err := voucherService.RenderPDF(context.Background(), []uint{2, 1})
+
+	// Assert
+	assert.NoError(t, err)
+	out := string(data)
+	assert.True(t, strings.HasPrefix(out, "%PDF-1.4"))
+	assert.Contains(t, out, "/Count 2")
+	assert.Contains(t, out, "(12.5% OFF)")
+	assert.Contains(t, out, `(Summer \(sale\))`)
+	assert.Contains(t, out, "(Terms: https://example.com/terms)")
+	assert.Less(t, strings.Index(out, "(WINTER2024)"), strings.Index(out, "(SUMMER2024)"))
+	mockRepo.AssertExpectations(t)
+}
+
+func TestVoucherService_RenderPDF_Refused(t *testing.T) {
+	yesterday := time.Now().AddDate(0, 0, -1)
+	nextWeek := time.Now().AddDate(0, 0, 7)
+	tests := []struct {
+		name    string
+		voucher *entity.Voucher
+		err     error
+	}{
+		{"missing", nil, domainService.ErrVoucherNotFound},
+		{"inactive", &entity.Voucher{ID: 1, VoucherCode: "OLD", ExpiryDate: nextWeek, Status: entity.VoucherStatusInactive}, domainService.ErrVoucherNotPrintable},
+		{"pending approval", &entity.Voucher{ID: 1, VoucherCode: "BIG", ExpiryDate: nextWeek, Status: entity.VoucherStatusPendingApproval}, domainService.ErrVoucherNotPrintable},
+		{"expired", &entity.Voucher{ID: 1, VoucherCode: "GONE", ExpiryDate: yesterday, Status: entity.VoucherStatusActive}, domainService.ErrVoucherNotPrintable},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			mockRepo := new(MockVoucherRepository)
+			voucherService := NewVoucherService(mockRepo, 0)
+
+			found := []*entity.Voucher{}
+			if tt.voucher != nil {
+				found = append(found, tt.voucher)
+			}
+			mockRepo.On("FindByIDs", []uint{1}).Return(found, nil)
+
+			// Act
+			data, err := voucherService.RenderPDF(context.Background(), []uint{1})
+
+			// Assert
+			assert.ErrorIs(t, err, tt.err)
+			assert.Nil(t, data)
+		})
+	}
+}
+
+func TestVoucherService_RenderPreviewImage(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockVoucherRepository)
+	voucherService := NewVoucherService(mockRepo, 0)
+
+	voucher := &entity.Voucher{ID: 1, VoucherCode: "SUMMER2024", DiscountPercent: 12.75, ExpiryDate: time.Now().AddDate(0, 1, 0), Status: entity.VoucherStatusActive, DisplayName: "Summer sale"}
+	mockRepo.On("FindByID", uint(1)).Return(voucher, nil)
+
+	// Act
+	hidden, err := voucherService.RenderPreviewImage(context.Background(), 1, domainService.PreviewImageOptions{})
+	assert.NoError(t, err)
+	full, err := voucherService.RenderPreviewImage(context.Background(), 1, domainService.PreviewImageOptions{ShowCode: true})
+	assert.NoError(t, err)
+	masked, err := voucherService.RenderPreviewImage(context.Background(), 1, domainService.PreviewImageOptions{ShowCode: true, MaskCode: true})
+	assert.NoError(t, err)
+
+	// Assert
+	img, err := png.Decode(bytes.NewReader(hidden))
+	assert.NoError(t, err)
+	assert.Equal(t, image.Rect(0, 0, previewWidth, previewHeight), img.Bounds())
+	assert.Equal(t, color.RGBAModel.Convert(previewAccent), color.RGBAModel.Convert(img.At(0, 0)))
+	assert.NotEqual(t, hidden, full)
+	assert.NotEqual(t, full, masked)
+}
+
+func TestVoucherService_RenderPreviewImage_NotFound(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockVoucherRepository)
+	voucherService := NewVoucherService(mockRepo, 0)
+	mockRepo.On("FindByID", uint(9)).Return(nil, gorm.ErrRecordNotFound)
+
+	// Act
+	data, err := voucherService.RenderPreviewImage(context.Background(), 9, domainService.PreviewImageOptions{})
+
+	// Assert
+	assert.ErrorIs(t, err, domainService.ErrVoucherNotFound)
+	assert.Nil(t, data)
+}
+
+func TestWrapText(t *testing.T) {
+	// Act
+	lines := wrapText("one two three four five six seven", 60, 10, 2)
+
+	// Assert
+	assert.Equal(t, []string{"one two", "three f..."}, lines)
+}
+
+func TestVoucherService_Assign_RelativeExpiry(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockVoucherRepository)
+	mockAssignmentRepo := new(MockVoucherAssignmentRepository)
+	mockCustomerRepo := new(MockCustomerRepository)
+	voucherService := NewVoucherService(mockRepo, 0, WithAssignments(mockAssignmentRepo, NewCustomerService(mockCustomerRepo)))
+
+	now := time.Now()
+	validityDays := 30
+	relative := &entity.Voucher{ID: 1, VoucherCode: "WELCOME", ExpiryDate: now.AddDate(1, 0, 0), ValidityDays: &validityDays, Status: entity.VoucherStatusActive}
+	capped := &entity.Voucher{ID: 2, VoucherCode: "ENDSOON", ExpiryDate: now.AddDate(0, 0, 5), ValidityDays: &validityDays, Status: entity.VoucherStatusActive}
+	mockRepo.On("FindByID", uint(1)).Return(relative, nil)
+	mockRepo.On("FindByID", uint(2)).Return(capped, nil)
+	mockCustomerRepo.On("FindOrCreate", "cust-1").Return(&entity.Customer{ID: 4, ExternalID: "cust-1"}, nil)
+	mockAssignmentRepo.On("Create", mock.AnythingOfType("*entity.VoucherAssignment")).Return(nil)
+
+	// Act
+	assignment, err := voucherService.Assign(context.Background(), 1, " cust-1 ", "admin@example.com")
+	cappedAssignment, cappedErr := voucherService.Assign(context.Background(), 2, "cust-1", "admin@example.com")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, uint(4), assignment.CustomerID)
+	assert.Equal(t, now.AddDate(0, 0, 30).Format("2006-01-02"), assignment.ExpiresAt.Format("2006-01-02"))
+	assert.NoError(t, cappedErr)
+	assert.Equal(t, capped.ExpiryDate.Format("2006-01-02"), cappedAssignment.ExpiresAt.Format("2006-01-02"))
+}
+
+func TestVoucherService_Assign_Refused(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockVoucherRepository)
+	mockAssignmentRepo := new(MockVoucherAssignmentRepository)
+	mockCustomerRepo := new(MockCustomerRepository)
+	voucherService := NewVoucherService(mockRepo, 0, WithAssignments(mockAssignmentRepo, NewCustomerService(mockCustomerRepo)))
+
+	nextWeek := time.Now().AddDate(0, 0, 7)
+	mockRepo.On("FindByID", uint(1)).Return(&entity.Voucher{ID: 1, VoucherCode: "OLD", ExpiryDate: nextWeek, Status: entity.VoucherStatusInactive}, nil)
+	mockRepo.On("FindByID", uint(2)).Return(&entity.Voucher{ID: 2, VoucherCode: "TWICE", ExpiryDate: nextWeek, Status: entity.VoucherStatusActive}, nil)
+	mockCustomerRepo.On("FindOrCreate", "cust-1").Return(&entity.Customer{ID: 4, ExternalID: "cust-1"}, nil)
+	mockAssignmentRepo.On("Create", mock.AnythingOfType("*entity.VoucherAssignment")).Return(gorm.ErrDuplicatedKey)
+
+	// Act
+	_, inactiveErr := voucherService.Assign(context.Background(), 1, "cust-1", "admin@example.com")
+	_, duplicateErr := voucherService.Assign(context.Background(), 2, "cust-1", "admin@example.com")
+	_, blankErr := voucherService.Assign(context.Background(), 2, " ", "admin@example.com")
+
+	// Assert
+	assert.ErrorIs(t, inactiveErr, domainService.ErrVoucherNotAssignable)
+	assert.ErrorIs(t, duplicateErr, domainService.ErrVoucherAlreadyAssigned)
+	assert.Error(t, blankErr)
+	mockAssignmentRepo.AssertNumberOfCalls(t, "Create", 1)
+}
+
+func TestVoucherService_Validate_RelativeExpiry(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockVoucherRepository)
+	mockAssignmentRepo := new(MockVoucherAssignmentRepository)
+	mockCustomerRepo := new(MockCustomerRepository)
+	voucherService := NewVoucherService(mockRepo, 0, WithAssignments(mockAssignmentRepo, NewCustomerService(mockCustomerRepo)))
+
+	now := time.Now()
+	validityDays := 7
+	mockRepo.On("FindByVoucherCode", "WELCOME").Return(&entity.Voucher{ID: 1, VoucherCode: "WELCOME", ExpiryDate: now.AddDate(1, 0, 0), ValidityDays: &validityDays, Status: entity.VoucherStatusActive}, nil)
+	mockCustomerRepo.On("FindByExternalID", "fresh").Return(&entity.Customer{ID: 1, ExternalID: "fresh"}, nil)
+	mockCustomerRepo.On("FindByExternalID", "lapsed").Return(&entity.Customer{ID: 2, ExternalID: "lapsed"}, nil)
+	mockCustomerRepo.On("FindByExternalID", "stranger").Return(&entity.Customer{ID: 3, ExternalID: "stranger"}, nil)
+	mockCustomerRepo.On("FindByExternalID", "unknown").Return(nil, nil)
+	mockAssignmentRepo.On("FindByVoucherAndCustomer", uint(1), uint(1)).Return(&entity.VoucherAssignment{ExpiresAt: now.AddDate(0, 0, 3)}, nil)
+	mockAssignmentRepo.On("FindByVoucherAndCustomer", uint(1), uint(2)).Return(&entity.VoucherAssignment{ExpiresAt: now.AddDate(0, 0, -1)}, nil)
+	mockAssignmentRepo.On("FindByVoucherAndCustomer", uint(1), uint(3)).Return(nil, nil)
+
+	// Act
+	fresh, _ := voucherService.Validate(context.Background(), "WELCOME", rules.Context{CustomerID: "fresh"})
+	lapsed, _ := voucherService.Validate(context.Background(), "WELCOME", rules.Context{CustomerID: "lapsed"})
+	stranger, _ := voucherService.Validate(context.Background(), "WELCOME", rules.Context{CustomerID: "stranger"})
+	unknown, _ := voucherService.Validate(context.Background(), "WELCOME", rules.Context{CustomerID: "unknown"})
+	anonymous, err := voucherService.Validate(context.Background(), "WELCOME", rules.Context{})
+
+	// Assert
+	assert.True(t, fresh.Valid)
+	assert.Equal(t, []string{"voucher has expired for this customer"}, lapsed.Reasons)
+	assert.Equal(t, []string{"voucher is not assigned to this customer"}, stranger.Reasons)
+	assert.Equal(t, []string{"voucher is not assigned to this customer"}, unknown.Reasons)
+	mockCustomerRepo.AssertNotCalled(t, "FindOrCreate", mock.Anything)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"customer id is required for this voucher"}, anonymous.Reasons)
+}
+
+func TestVoucherService_BulkEdit_MergePatchInChunks(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockVoucherRepository)
+	mockAuditRepo := new(MockAuditRepository)
+	voucherService := NewVoucherService(mockRepo, 0, WithAudit(mockAuditRepo))
+
+	partnerID := uint(7)
+	filter := repository.VoucherFilter{PartnerID: &partnerID}
+	firstChunk := make([]uint, bulkEditChunkSize)
+	for i := range firstChunk {
+		firstChunk[i] = uint(i + 1)
+	}
+	fields := map[string]interface{}{"status": entity.VoucherStatusInactive, "updated_by": "admin@example.com"}
+
+	mockRepo.On("FindIDsByFilter", filter, uint(0), bulkEditChunkSize).Return(firstChunk, nil)
+	mockRepo.On("FindIDsByFilter", filter, uint(bulkEditChunkSize), bulkEditChunkSize).Return([]uint{bulkEditChunkSize + 1}, nil)
+	mockRepo.On("UpdateFields", firstChunk, fields).Return(int64(bulkEditChunkSize), nil)
+	mockRepo.On("UpdateFields", []uint{bulkEditChunkSize + 1}, fields).Return(int64(1), nil)
+	mockAuditRepo.On("Create", mock.MatchedBy(func(entry *entity.AuditEntry) bool {
+		return entry.Action == entity.AuditActionVoucherBulkEdit && entry.Actor == "admin@example.com" &&
+			strings.Contains(entry.Details, `"partner_id":7`)
+	})).Return(nil).Twice()
+
+	// Act
+	result, err := voucherService.BulkEdit(context.Background(), &domainService.BulkEditCommand{
+		Filter:   domainService.BulkEditFilter{PartnerID: &partnerID},
+		Patch:    []byte(`{"status": "inactive"}`),
+		EditedBy: "admin@example.com",
+	})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"status"}, result.Fields)
+	assert.Equal(t, int64(bulkEditChunkSize+1), result.Updated)
+	assert.Equal(t, 2, result.Chunks)
+	mockRepo.AssertExpectations(t)
+	mockAuditRepo.AssertExpectations(t)
+}
+
+func TestVoucherService_BulkEdit_JSONPatch(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockVoucherRepository)
+	voucherService := NewVoucherService(mockRepo, 0)
+
+	filter := repository.VoucherFilter{CodePrefix: "SUMM", DiscountType: entity.DiscountTypePercent}
+	fields := map[string]interface{}{"discount_percent": 15.0, "description": "", "validity_days": (*int)(nil), "updated_by": ""}
+
+	mockRepo.On("FindIDsByFilter", filter, uint(0), bulkEditChunkSize).Return([]uint{1, 2}, nil)
+	mockRepo.On("UpdateFields", []uint{1, 2}, fields).Return(int64(2), nil)
+
+	// Act
+	result, err := voucherService.BulkEdit(context.Background(), &domainService.BulkEditCommand{
+		Filter: domainService.BulkEditFilter{CodePrefix: "SUMM"},
+		Patch: []byte(`[
+			{"op": "replace", "path": "/discount_percent", "value": 15},
+			{"op": "add", "path": "/description", "value": ""},
+			{"op": "remove", "path": "/validity_days"}
+		]`),
+	})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"description", "discount_percent", "validity_days"}, result.Fields)
+	assert.Equal(t, int64(2), result.Updated)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestVoucherService_BulkEdit_ActivationSkipsPendingApproval(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockVoucherRepository)
+	voucherService := NewVoucherService(mockRepo, 0)
+
+	filter := repository.VoucherFilter{IDs: []uint{1, 2}, ExcludeStatus: entity.VoucherStatusPendingApproval}
+	mockRepo.On("FindIDsByFilter", filter, uint(0), bulkEditChunkSize).Return([]uint{1}, nil)
+	mockRepo.On("UpdateFields", []uint{1}, map[string]interface{}{"status": entity.VoucherStatusActive, "updated_by": ""}).Return(int64(1), nil)
+
+	// Act
+	result, err := voucherService.BulkEdit(context.Background(), &domainService.BulkEditCommand{
+		Filter: domainService.BulkEditFilter{IDs: []uint{1, 2}},
+		Patch:  []byte(`{"status": "active"}`),
+	})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), result.Updated)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestVoucherService_BulkEdit_DiscountAboveThresholdNeedsApproval(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockVoucherRepository)
+	mockAuditRepo := new(MockAuditRepository)
+	voucherService := NewVoucherService(mockRepo, 50, WithAudit(mockAuditRepo))
+
+	filter := repository.VoucherFilter{DiscountType: entity.DiscountTypePercent, CodePrefix: "SUMM"}
+	fields := map[string]interface{}{"discount_percent": 60.0, "updated_by": "editor@example.com"}
+	mockRepo.On("FindIDsByFilter", filter, uint(0), bulkEditChunkSize).Return([]uint{4, 5}, nil)
+	// Only the active voucher 4 is sent for approval; inactive 5 stays inactive
+	mockRepo.On("UpdateFieldsForApproval", []uint{4, 5}, fields).Return(int64(2), []uint{4}, nil)
+	mockAuditRepo.On("Create", mock.MatchedBy(func(entry *entity.AuditEntry) bool {
+		return entry.Action == entity.AuditActionVoucherBulkEdit
+	})).Return(nil).Once()
+	mockAuditRepo.On("Create", mock.MatchedBy(func(entry *entity.AuditEntry) bool {
+		return entry.Action == entity.AuditActionVoucherApprovalRequest && entry.Actor == "editor@example.com" &&
+			strings.Contains(entry.Details, `"voucher_ids":[4]`)
+	})).Return(nil).Once()
+
+	// Act
+	_, err := voucherService.BulkEdit(context.Background(), &domainService.BulkEditCommand{
+		Filter:   domainService.BulkEditFilter{CodePrefix: "SUMM"},
+		Patch:    []byte(`{"discount_percent": 60}`),
+		EditedBy: "editor@example.com",
+	})
+
+	// Assert
+	assert.NoError(t, err)
 	mockRepo.AssertExpectations(t)
+	mockAuditRepo.AssertExpectations(t)
+	mockRepo.AssertNotCalled(t, "UpdateFields", mock.Anything, mock.Anything)
+}
+
+func TestVoucherService_BulkEdit_DiscountLimitExceeded(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockVoucherRepository)
+	mockLimitRepo := new(MockDiscountLimitRepository)
+	voucherService := NewVoucherService(mockRepo, 0, WithDiscountLimits(mockLimitRepo))
+
+	mockLimitRepo.On("FindByRole", entity.UserRoleMarketing).Return(&entity.DiscountLimit{Role: entity.UserRoleMarketing, MaxDiscountPercent: 20}, nil)
+
+	// Act
+	result, err := voucherService.BulkEdit(context.Background(), &domainService.BulkEditCommand{
+		Filter: domainService.BulkEditFilter{IDs: []uint{1}},
+		Patch:  []byte(`{"discount_percent": 30}`),
+		Role:   entity.UserRoleMarketing,
+	})
+
+	// Assert
+	assert.ErrorIs(t, err, domainService.ErrDiscountLimitExceeded)
+	assert.Nil(t, result)
+	mockRepo.AssertNotCalled(t, "UpdateFields", mock.Anything, mock.Anything)
+}
+
+func TestVoucherService_BulkEdit_RejectsInvalidInput(t *testing.T) {
+	tests := []struct {
+		name   string
+		filter domainService.BulkEditFilter
+		patch  string
+	}{
+		{"empty filter", domainService.BulkEditFilter{}, `{"status": "inactive"}`},
+		{"short prefix", domainService.BulkEditFilter{CodePrefix: "A"}, `{"status": "inactive"}`},
+		{"unknown field", domainService.BulkEditFilter{IDs: []uint{1}}, `{"code": "NEW"}`},
+		{"nested path", domainService.BulkEditFilter{IDs: []uint{1}}, `[{"op": "replace", "path": "/metadata/tier", "value": "gold"}]`},
+		{"unsupported op", domainService.BulkEditFilter{IDs: []uint{1}}, `[{"op": "move", "from": "/description", "path": "/display_name"}]`},
+		{"pending status", domainService.BulkEditFilter{IDs: []uint{1}}, `{"status": "pending_approval"}`},
+		{"past expiry", domainService.BulkEditFilter{IDs: []uint{1}}, `{"expiry_date": "2000-01-01"}`},
+		{"empty patch", domainService.BulkEditFilter{IDs: []uint{1}}, `{}`},
+		{"not a patch", domainService.BulkEditFilter{IDs: []uint{1}}, `"inactive"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			mockRepo := new(MockVoucherRepository)
+			voucherService := NewVoucherService(mockRepo, 0)
+
+			// Act
+			result, err := voucherService.BulkEdit(context.Background(), &domainService.BulkEditCommand{Filter: tt.filter, Patch: []byte(tt.patch)})
+
+			// Assert
+			assert.Error(t, err)
+			assert.Nil(t, result)
+			mockRepo.AssertNotCalled(t, "FindIDsByFilter", mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
 }
@@ -0,0 +1,203 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/shoelfikar/voucher-management-system/internal/domain/entity"
+	"github.com/shoelfikar/voucher-management-system/internal/domain/repository"
+	domainService "github.com/shoelfikar/voucher-management-system/internal/domain/service"
+	"github.com/shoelfikar/voucher-management-system/pkg/rules"
+	"github.com/shoelfikar/voucher-management-system/pkg/utils"
+	"gorm.io/gorm"
+)
+
+// expiryOffsetPattern matches offsets such as "+30d", "2w" or "+3m"
+var expiryOffsetPattern = regexp.MustCompile(`^\+?([1-9][0-9]{0,3})([dwm])$`)
+
+// maxCodePrefixLength leaves room for the random part of generated codes
+const maxCodePrefixLength = 20
+
+// generatedCodeAlphabet leaves out characters easily misread on print: 0, O, 1 and I
+const generatedCodeAlphabet = "ABCDEFGHJKLMNPQRSTUVWXYZ23456789"
+
+// generatedCodeLength is the number of random characters after a template's
+// code prefix; 32^8 codes make collisions with existing ones unlikely
+const generatedCodeLength = 8
+
+// voucherTemplateServiceImpl implements domain service.VoucherTemplateService
+type voucherTemplateServiceImpl struct {
+	templateRepo repository.VoucherTemplateRepository
+}
+
+// NewVoucherTemplateService creates a new voucher template service instance
+func NewVoucherTemplateService(templateRepo repository.VoucherTemplateRepository) domainService.VoucherTemplateService {
+	return &voucherTemplateServiceImpl{templateRepo: templateRepo}
+}
+
+// GetAll retrieves templates with pagination, search, and sorting
+func (s *voucherTemplateServiceImpl) GetAll(ctx context.Context, page, limit int, search string, sort []utils.SortField) ([]*entity.VoucherTemplate, int64, error) {
+	return s.templateRepo.FindAll(ctx, page, limit, search, sort)
+}
+
+// GetByID retrieves a template by ID
+func (s *voucherTemplateServiceImpl) GetByID(ctx context.Context, id uint) (*entity.VoucherTemplate, error) {
+	template, err := s.templateRepo.FindByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domainService.ErrVoucherTemplateNotFound
+		}
+		return nil, err
+	}
+	return template, nil
+}
+
+// Create validates and stores a new template
+func (s *voucherTemplateServiceImpl) Create(ctx context.Context, cmd *domainService.VoucherTemplateCommand) (*entity.VoucherTemplate, error) {
+	template, err := s.validateTemplate(ctx, 0, cmd)
+	if err != nil {
+		return nil, err
+	}
+	template.CreatedBy = cmd.CreatedBy
+
+	if err := s.templateRepo.Create(ctx, template); err != nil {
+		return nil, fmt.Errorf("failed to create voucher template: %w", err)
+	}
+	return template, nil
+}
+
+// Update validates and replaces an existing template
+func (s *voucherTemplateServiceImpl) Update(ctx context.Context, id uint, cmd *domainService.VoucherTemplateCommand) (*entity.VoucherTemplate, error) {
+	template, err := s.validateTemplate(ctx, id, cmd)
+	if err != nil {
+		return nil, err
+	}
+	template.ID = id
+
+	rowsAffected, err := s.templateRepo.Update(ctx, template)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update voucher template: %w", err)
+	}
+	if rowsAffected == 0 {
+		return nil, domainService.ErrVoucherTemplateNotFound
+	}
+
+	return s.GetByID(ctx, id)
+}
+
+// Delete removes a template
+func (s *voucherTemplateServiceImpl) Delete(ctx context.Context, id uint) error {
+	rowsAffected, err := s.templateRepo.Delete(ctx, id)
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return domainService.ErrVoucherTemplateNotFound
+	}
+	return nil
+}
+
+// validateTemplate checks a template command and returns the template it
+// describes, with channels lowercased and deduplicated. id is the template
+// being updated, or 0.
+func (s *voucherTemplateServiceImpl) validateTemplate(ctx context.Context, id uint, cmd *domainService.VoucherTemplateCommand) (*entity.VoucherTemplate, error) {
+	template := &entity.VoucherTemplate{
+		Name:            strings.TrimSpace(cmd.Name),
+		DiscountPercent: cmd.DiscountPercent,
+		ExpiryOffset:    strings.TrimSpace(cmd.ExpiryOffset),
+		CodePrefix:      strings.TrimSpace(cmd.CodePrefix),
+		Channels:        []string{},
+	}
+	if template.Name == "" {
+		return nil, errors.New("template name is required")
+	}
+	if template.DiscountPercent != nil {
+		if err := validateDiscountPercent(*template.DiscountPercent); err != nil {
+			return nil, err
+		}
+	}
+	if template.ExpiryOffset != "" {
+		if _, err := expiryFromOffset(template.ExpiryOffset, time.Now()); err != nil {
+			return nil, err
+		}
+	}
+	if len(template.CodePrefix) > maxCodePrefixLength {
+		return nil, fmt.Errorf("code prefix must be at most %d characters", maxCodePrefixLength)
+	}
+	if strings.ContainsAny(template.CodePrefix, " \t") {
+		return nil, errors.New("code prefix cannot contain spaces")
+	}
+
+	seen := make(map[string]bool)
+	for _, channel := range cmd.Channels {
+		channel = strings.ToLower(strings.TrimSpace(channel))
+		if channel != "" && !seen[channel] {
+			seen[channel] = true
+			template.Channels = append(template.Channels, channel)
+		}
+	}
+
+	existing, err := s.templateRepo.FindByName(ctx, template.Name)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil && existing.ID != id {
+		return nil, domainService.ErrDuplicateVoucherTemplateName
+	}
+
+	return template, nil
+}
+
+// expiryFromOffset returns the expiry date an offset such as "+30d", "+2w"
+// or "+3m" gives a voucher created at from
+func expiryFromOffset(offset string, from time.Time) (time.Time, error) {
+	match := expiryOffsetPattern.FindStringSubmatch(offset)
+	if match == nil {
+		return time.Time{}, fmt.Errorf("invalid expiry offset '%s', expected e.g. +30d, +2w or +3m", offset)
+	}
+
+	n, _ := strconv.Atoi(match[1])
+	date := time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, from.Location())
+	switch match[2] {
+	case "w":
+		return date.AddDate(0, 0, 7*n), nil
+	case "m":
+		return date.AddDate(0, n, 0), nil
+	default:
+		return date.AddDate(0, 0, n), nil
+	}
+}
+
+// generateVoucherCode returns prefix followed by random characters
+func generateVoucherCode(prefix string) (string, error) {
+	buf := make([]byte, generatedCodeLength)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("failed to generate voucher code: %w", err)
+	}
+	for i, b := range buf {
+		buf[i] = generatedCodeAlphabet[int(b)%len(generatedCodeAlphabet)]
+	}
+	return prefix + string(buf), nil
+}
+
+// withChannelCondition adds a condition limiting a voucher to channels to its rules
+func withChannelCondition(raw string, channels []string) (string, error) {
+	conditions, err := rules.Parse(raw)
+	if err != nil {
+		return "", err
+	}
+	conditions = append(conditions, rules.Condition{Type: rules.ConditionChannel, Values: channels})
+
+	encoded, err := json.Marshal(conditions)
+	if err != nil {
+		return "", err
+	}
+	return string(encoded), nil
+}
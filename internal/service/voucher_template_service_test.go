@@ -0,0 +1,328 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/shoelfikar/voucher-management-system/internal/domain/entity"
+	domainService "github.com/shoelfikar/voucher-management-system/internal/domain/service"
+	"github.com/shoelfikar/voucher-management-system/pkg/rules"
+	"github.com/shoelfikar/voucher-management-system/pkg/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+// MockVoucherTemplateRepository is a mock implementation of VoucherTemplateRepository
+type MockVoucherTemplateRepository struct {
+	mock.Mock
+}
+
+func (m *MockVoucherTemplateRepository) FindAll(ctx context.Context, page, limit int, search string, sort []utils.SortField) ([]*entity.VoucherTemplate, int64, error) {
+	args := m.Called(page, limit, search, sort)
+	return args.Get(0).([]*entity.VoucherTemplate), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockVoucherTemplateRepository) FindByID(ctx context.Context, id uint) (*entity.VoucherTemplate, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.VoucherTemplate), args.Error(1)
+}
+
+func (m *MockVoucherTemplateRepository) FindByName(ctx context.Context, name string) (*entity.VoucherTemplate, error) {
+	args := m.Called(name)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.VoucherTemplate), args.Error(1)
+}
+
+func (m *MockVoucherTemplateRepository) Create(ctx context.Context, template *entity.VoucherTemplate) error {
+	args := m.Called(template)
+	return args.Error(0)
+}
+
+func (m *MockVoucherTemplateRepository) Update(ctx context.Context, template *entity.VoucherTemplate) (int64, error) {
+	args := m.Called(template)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockVoucherTemplateRepository) Delete(ctx context.Context, id uint) (int64, error) {
+	args := m.Called(id)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func TestVoucherTemplateService_Create_NormalizesChannels(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockVoucherTemplateRepository)
+	templateService := NewVoucherTemplateService(mockRepo)
+
+	mockRepo.On("FindByName", "Store promo").Return(nil, nil)
+	mockRepo.On("Create", mock.AnythingOfType("*entity.VoucherTemplate")).Return(nil)
+
+	// Act
+	template, err := templateService.Create(context.Background(), &domainService.VoucherTemplateCommand{
+		Name: " Store promo ", ExpiryOffset: "+30d", CodePrefix: "STORE-", Channels: []string{"POS", " web", "pos", ""}, CreatedBy: "admin@example.com",
+	})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "Store promo", template.Name)
+	assert.Equal(t, []string{"pos", "web"}, template.Channels)
+	assert.Equal(t, "admin@example.com", template.CreatedBy)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestVoucherTemplateService_Create_Invalid(t *testing.T) {
+	tooHigh := 120.0
+	testCases := []struct {
+		name string
+		cmd  domainService.VoucherTemplateCommand
+	}{
+		{"missing name", domainService.VoucherTemplateCommand{Name: " "}},
+		{"discount out of range", domainService.VoucherTemplateCommand{Name: "T", DiscountPercent: &tooHigh}},
+		{"bad expiry offset", domainService.VoucherTemplateCommand{Name: "T", ExpiryOffset: "30 days"}},
+		{"zero expiry offset", domainService.VoucherTemplateCommand{Name: "T", ExpiryOffset: "+0d"}},
+		{"prefix with spaces", domainService.VoucherTemplateCommand{Name: "T", CodePrefix: "MY PROMO"}},
+		{"prefix too long", domainService.VoucherTemplateCommand{Name: "T", CodePrefix: strings.Repeat("A", 21)}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			mockRepo := new(MockVoucherTemplateRepository)
+			templateService := NewVoucherTemplateService(mockRepo)
+
+			// Act
+			template, err := templateService.Create(context.Background(), &tc.cmd)
+
+			// Assert
+			assert.Error(t, err)
+			assert.Nil(t, template)
+			mockRepo.AssertNotCalled(t, "Create", mock.Anything)
+		})
+	}
+}
+
+func TestVoucherTemplateService_Update_DuplicateName(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockVoucherTemplateRepository)
+	templateService := NewVoucherTemplateService(mockRepo)
+
+	mockRepo.On("FindByName", "Web promo").Return(&entity.VoucherTemplate{ID: 2, Name: "Web promo"}, nil)
+
+	// Act
+	template, err := templateService.Update(context.Background(), 1, &domainService.VoucherTemplateCommand{Name: "Web promo"})
+
+	// Assert
+	assert.ErrorIs(t, err, domainService.ErrDuplicateVoucherTemplateName)
+	assert.Nil(t, template)
+	mockRepo.AssertNotCalled(t, "Update", mock.Anything)
+}
+
+func TestVoucherTemplateService_GetByID_NotFound(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockVoucherTemplateRepository)
+	templateService := NewVoucherTemplateService(mockRepo)
+
+	mockRepo.On("FindByID", uint(9)).Return(nil, gorm.ErrRecordNotFound)
+
+	// Act
+	template, err := templateService.GetByID(context.Background(), 9)
+
+	// Assert
+	assert.ErrorIs(t, err, domainService.ErrVoucherTemplateNotFound)
+	assert.Nil(t, template)
+}
+
+func TestExpiryFromOffset(t *testing.T) {
+	from := time.Date(2030, 1, 31, 15, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		offset string
+		want   string
+	}{
+		{"+30d", "2030-03-02"},
+		{"7d", "2030-02-07"},
+		{"+2w", "2030-02-14"},
+		{"+1m", "2030-03-03"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.offset, func(t *testing.T) {
+			// Act
+			expiry, err := expiryFromOffset(tc.offset, from)
+
+			// Assert
+			assert.NoError(t, err)
+			assert.Equal(t, tc.want, expiry.Format("2006-01-02"))
+		})
+	}
+}
+
+func TestVoucherService_Create_FromTemplate(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockVoucherRepository)
+	mockTemplateRepo := new(MockVoucherTemplateRepository)
+	voucherService := NewVoucherService(mockRepo, 0, WithTemplates(mockTemplateRepo))
+
+	discount := 15.0
+	templateID := uint(3)
+	mockTemplateRepo.On("FindByID", templateID).Return(&entity.VoucherTemplate{
+		ID: templateID, Name: "Store promo", DiscountPercent: &discount, ExpiryOffset: "+30d", CodePrefix: "STORE-", Channels: []string{"pos"},
+	}, nil)
+	mockRepo.On("FindByVoucherCode", mock.AnythingOfType("string")).Return((*entity.Voucher)(nil), nil)
+	mockRepo.On("Create", mock.AnythingOfType("*entity.Voucher")).Return(nil)
+
+	// Act
+	voucher, err := voucherService.Create(context.Background(), &domainService.CreateVoucherCommand{
+		TemplateID: &templateID,
+		Rules:      `[{"type":"min_items","value":2}]`,
+	})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Regexp(t, `^STORE-[A-HJ-NP-Z2-9]{8}$`, voucher.VoucherCode)
+	assert.Equal(t, 15.0, voucher.DiscountPercent)
+	assert.Equal(t, time.Now().AddDate(0, 0, 30).Format("2006-01-02"), voucher.ExpiryDate.Format("2006-01-02"))
+
+	conditions, err := rules.Parse(voucher.Rules)
+	assert.NoError(t, err)
+	assert.Equal(t, []rules.Condition{
+		{Type: rules.ConditionMinItems, Value: 2},
+		{Type: rules.ConditionChannel, Values: []string{"pos"}},
+	}, conditions)
+}
+
+func TestVoucherService_Create_FromTemplateKeepsExplicitFields(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockVoucherRepository)
+	mockTemplateRepo := new(MockVoucherTemplateRepository)
+	voucherService := NewVoucherService(mockRepo, 0, WithTemplates(mockTemplateRepo))
+
+	discount := 15.0
+	templateID := uint(3)
+	tomorrow := time.Now().Add(24 * time.Hour).Format("2006-01-02")
+	mockTemplateRepo.On("FindByID", templateID).Return(&entity.VoucherTemplate{
+		ID: templateID, Name: "Store promo", DiscountPercent: &discount, ExpiryOffset: "+30d", CodePrefix: "STORE-",
+	}, nil)
+	mockRepo.On("FindByVoucherCode", "store-summer").Return((*entity.Voucher)(nil), nil)
+	mockRepo.On("Create", mock.AnythingOfType("*entity.Voucher")).Return(nil)
+
+	// Act
+	voucher, err := voucherService.Create(context.Background(), &domainService.CreateVoucherCommand{
+		TemplateID: &templateID, VoucherCode: "store-summer", DiscountPercent: 20, ExpiryDate: tomorrow,
+	})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "store-summer", voucher.VoucherCode)
+	assert.Equal(t, 20.0, voucher.DiscountPercent)
+	assert.Equal(t, tomorrow, voucher.ExpiryDate.Format("2006-01-02"))
+	assert.Empty(t, voucher.Rules)
+}
+
+func TestVoucherService_Create_FromTemplateRejected(t *testing.T) {
+	templateID := uint(3)
+	testCases := []struct {
+		name     string
+		template *entity.VoucherTemplate
+		findErr  error
+		cmd      domainService.CreateVoucherCommand
+		wantErr  error
+	}{
+		{
+			name:    "template not found",
+			findErr: gorm.ErrRecordNotFound,
+			cmd:     domainService.CreateVoucherCommand{TemplateID: &templateID, VoucherCode: "X"},
+			wantErr: domainService.ErrVoucherTemplateNotFound,
+		},
+		{
+			name:     "code without prefix",
+			template: &entity.VoucherTemplate{ID: templateID, Name: "Store promo", CodePrefix: "STORE-"},
+			cmd:      domainService.CreateVoucherCommand{TemplateID: &templateID, VoucherCode: "SUMMER"},
+		},
+		{
+			name:     "no code and nothing to generate it from",
+			template: &entity.VoucherTemplate{ID: templateID, Name: "Store promo"},
+			cmd:      domainService.CreateVoucherCommand{TemplateID: &templateID},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			mockRepo := new(MockVoucherRepository)
+			mockTemplateRepo := new(MockVoucherTemplateRepository)
+			voucherService := NewVoucherService(mockRepo, 0, WithTemplates(mockTemplateRepo))
+
+			if tc.findErr != nil {
+				mockTemplateRepo.On("FindByID", templateID).Return(nil, tc.findErr)
+			} else {
+				mockTemplateRepo.On("FindByID", templateID).Return(tc.template, nil)
+			}
+
+			// Act
+			voucher, err := voucherService.Create(context.Background(), &tc.cmd)
+
+			// Assert
+			assert.Error(t, err)
+			if tc.wantErr != nil {
+				assert.ErrorIs(t, err, tc.wantErr)
+			}
+			assert.Nil(t, voucher)
+			mockRepo.AssertNotCalled(t, "Create", mock.Anything)
+		})
+	}
+}
+
+func TestVoucherService_Create_TemplatesNotEnabled(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockVoucherRepository)
+	voucherService := NewVoucherService(mockRepo, 0)
+	templateID := uint(3)
+
+	// Act
+	voucher, err := voucherService.Create(context.Background(), &domainService.CreateVoucherCommand{TemplateID: &templateID, VoucherCode: "X"})
+
+	// Assert
+	assert.EqualError(t, err, "voucher templates are not enabled")
+	assert.Nil(t, voucher)
+}
+
+func TestVoucherService_ImportBatch_AppliesTemplates(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockVoucherRepository)
+	mockTemplateRepo := new(MockVoucherTemplateRepository)
+	voucherService := NewVoucherService(mockRepo, 0, WithTemplates(mockTemplateRepo))
+
+	discount := 15.0
+	templateID := uint(3)
+	missingID := uint(4)
+	tomorrow := time.Now().Add(24 * time.Hour).Format("2006-01-02")
+	mockTemplateRepo.On("FindByID", templateID).Return(&entity.VoucherTemplate{
+		ID: templateID, Name: "Store promo", DiscountPercent: &discount, ExpiryOffset: "+2w", CodePrefix: "STORE-",
+	}, nil)
+	mockTemplateRepo.On("FindByID", missingID).Return(nil, gorm.ErrRecordNotFound)
+	mockRepo.On("CheckDuplicateCodes", []string{"STORE-1", "PLAIN"}).Return([]string{}, nil)
+	mockRepo.On("BulkCreate", mock.AnythingOfType("[]*entity.Voucher")).Return(nil)
+
+	commands := []domainService.CreateVoucherCommand{
+		{TemplateID: &templateID, VoucherCode: "STORE-1"},
+		{TemplateID: &missingID, VoucherCode: "GONE"},
+		{VoucherCode: "PLAIN", DiscountPercent: 10, ExpiryDate: tomorrow},
+	}
+
+	// Act
+	result, err := voucherService.ImportBatch(context.Background(), commands)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, 3, result.TotalReceived)
+	assert.Equal(t, 2, result.Inserted)
+	assert.Equal(t, []string{"Code GONE: voucher template not found"}, result.Errors)
+}
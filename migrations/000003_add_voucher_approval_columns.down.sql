@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS idx_vouchers_status;
+
+ALTER TABLE vouchers
+    DROP COLUMN IF EXISTS approved_at,
+    DROP COLUMN IF EXISTS approved_by,
+    DROP COLUMN IF EXISTS created_by,
+    DROP COLUMN IF EXISTS status;
@@ -0,0 +1,7 @@
+ALTER TABLE vouchers
+    ADD COLUMN status VARCHAR(20) NOT NULL DEFAULT 'active',
+    ADD COLUMN created_by VARCHAR(255),
+    ADD COLUMN approved_by VARCHAR(255),
+    ADD COLUMN approved_at TIMESTAMP NULL;
+
+CREATE INDEX idx_vouchers_status ON vouchers(status);
@@ -0,0 +1,2 @@
+ALTER TABLE vouchers
+    ADD COLUMN rules TEXT;
@@ -0,0 +1,2 @@
+ALTER TABLE vouchers
+    DROP CONSTRAINT IF EXISTS chk_vouchers_status;
@@ -0,0 +1,2 @@
+ALTER TABLE vouchers
+    ADD CONSTRAINT chk_vouchers_status CHECK (status IN ('active', 'pending_approval'));
@@ -0,0 +1,5 @@
+UPDATE vouchers SET status = 'active' WHERE status = 'inactive';
+
+ALTER TABLE vouchers
+    DROP CONSTRAINT IF EXISTS chk_vouchers_status,
+    ADD CONSTRAINT chk_vouchers_status CHECK (status IN ('active', 'pending_approval'));
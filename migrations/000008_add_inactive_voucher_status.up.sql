@@ -0,0 +1,3 @@
+ALTER TABLE vouchers
+    DROP CONSTRAINT IF EXISTS chk_vouchers_status,
+    ADD CONSTRAINT chk_vouchers_status CHECK (status IN ('active', 'pending_approval', 'inactive'));
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_vouchers_external_id;
+
+ALTER TABLE vouchers DROP COLUMN IF EXISTS external_id;
@@ -0,0 +1,3 @@
+ALTER TABLE vouchers ADD COLUMN external_id VARCHAR(100);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_vouchers_external_id ON vouchers (external_id);
@@ -0,0 +1,4 @@
+ALTER TABLE vouchers
+    DROP COLUMN IF EXISTS terms_url,
+    DROP COLUMN IF EXISTS description,
+    DROP COLUMN IF EXISTS display_name;
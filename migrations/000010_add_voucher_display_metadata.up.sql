@@ -0,0 +1,4 @@
+ALTER TABLE vouchers
+    ADD COLUMN display_name VARCHAR(100) NOT NULL DEFAULT '',
+    ADD COLUMN description TEXT NOT NULL DEFAULT '',
+    ADD COLUMN terms_url VARCHAR(500) NOT NULL DEFAULT '';
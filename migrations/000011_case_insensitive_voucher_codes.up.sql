@@ -0,0 +1,21 @@
+-- Refuse to build the index while codes that differ only by case exist,
+-- listing them so they can be renamed or removed first
+DO $$
+DECLARE
+    duplicates TEXT;
+BEGIN
+    SELECT string_agg(variants, '; ')
+    INTO duplicates
+    FROM (
+        SELECT string_agg(voucher_code || ' (id ' || id || ')', ', ' ORDER BY id) AS variants
+        FROM vouchers
+        GROUP BY LOWER(voucher_code)
+        HAVING COUNT(*) > 1
+    ) AS case_variants;
+
+    IF duplicates IS NOT NULL THEN
+        RAISE EXCEPTION 'Resolve case-variant duplicate voucher codes before migrating: %', duplicates;
+    END IF;
+END $$;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_vouchers_voucher_code_lower ON vouchers (LOWER(voucher_code));
@@ -0,0 +1 @@
+DROP INDEX IF EXISTS idx_vouchers_voucher_code_lower_prefix;
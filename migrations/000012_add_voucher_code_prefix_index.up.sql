@@ -0,0 +1,2 @@
+-- Supports case-insensitive prefix LIKE queries used by code autocomplete
+CREATE INDEX IF NOT EXISTS idx_vouchers_voucher_code_lower_prefix ON vouchers (LOWER(voucher_code) text_pattern_ops);
@@ -0,0 +1,2 @@
+ALTER TABLE vouchers
+    DROP COLUMN IF EXISTS metadata;
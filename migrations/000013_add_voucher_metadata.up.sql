@@ -0,0 +1,2 @@
+ALTER TABLE vouchers
+    ADD COLUMN metadata TEXT NOT NULL DEFAULT '';
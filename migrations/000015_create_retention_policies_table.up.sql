@@ -0,0 +1,8 @@
+CREATE TABLE retention_policies (
+    id BIGSERIAL PRIMARY KEY,
+    keep_days INTEGER NOT NULL,
+    action VARCHAR(20) NOT NULL,
+    enabled BOOLEAN NOT NULL DEFAULT FALSE,
+    updated_by VARCHAR(255) NOT NULL DEFAULT '',
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS idx_vouchers_segment_id;
+
+ALTER TABLE vouchers
+    DROP COLUMN IF EXISTS segment_id;
+
+DROP TABLE IF EXISTS segment_members;
+DROP TABLE IF EXISTS segments;
@@ -0,0 +1,23 @@
+CREATE TABLE segments (
+    id BIGSERIAL PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    type VARCHAR(20) NOT NULL,
+    external_ref VARCHAR(255) NOT NULL DEFAULT '',
+    created_by VARCHAR(255) NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE UNIQUE INDEX idx_segments_name ON segments(name);
+
+CREATE TABLE segment_members (
+    segment_id BIGINT NOT NULL REFERENCES segments(id) ON DELETE CASCADE,
+    customer_id VARCHAR(255) NOT NULL,
+    PRIMARY KEY (segment_id, customer_id)
+);
+
+-- A segment still targeted by vouchers cannot be deleted
+ALTER TABLE vouchers
+    ADD COLUMN segment_id BIGINT NULL REFERENCES segments(id) ON DELETE RESTRICT;
+
+CREATE INDEX idx_vouchers_segment_id ON vouchers(segment_id);
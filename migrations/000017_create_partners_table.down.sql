@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS idx_vouchers_partner_id;
+
+ALTER TABLE vouchers
+    DROP COLUMN IF EXISTS partner_id;
+
+DROP TABLE IF EXISTS partners;
@@ -0,0 +1,21 @@
+CREATE TABLE partners (
+    id BIGSERIAL PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    api_key_hash VARCHAR(64) NOT NULL,
+    key_prefix VARCHAR(16) NOT NULL,
+    quota BIGINT NOT NULL CHECK (quota >= 0),
+    issued_count BIGINT NOT NULL DEFAULT 0,
+    active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_by VARCHAR(255) NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE UNIQUE INDEX idx_partners_name ON partners(name);
+CREATE UNIQUE INDEX idx_partners_api_key_hash ON partners(api_key_hash);
+
+-- A partner that issued vouchers cannot be deleted
+ALTER TABLE vouchers
+    ADD COLUMN partner_id BIGINT NULL REFERENCES partners(id) ON DELETE RESTRICT;
+
+CREATE INDEX idx_vouchers_partner_id ON vouchers(partner_id);
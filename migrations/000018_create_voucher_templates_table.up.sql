@@ -0,0 +1,14 @@
+CREATE TABLE voucher_templates (
+    id BIGSERIAL PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    discount_percent DECIMAL(5,2) NULL CHECK (discount_percent IS NULL OR (discount_percent >= 1 AND discount_percent <= 100)),
+    expiry_offset VARCHAR(10) NOT NULL DEFAULT '',
+    code_prefix VARCHAR(20) NOT NULL DEFAULT '',
+    -- JSON array of channel names, e.g. ["pos","web"]
+    channels TEXT NULL,
+    created_by VARCHAR(255) NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE UNIQUE INDEX idx_voucher_templates_name ON voucher_templates(name);
@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS voucher_assignments;
+
+ALTER TABLE vouchers
+    DROP CONSTRAINT IF EXISTS chk_vouchers_validity_days,
+    DROP COLUMN IF EXISTS validity_days;
@@ -0,0 +1,17 @@
+-- Vouchers with validity_days expire that many days after being assigned to a customer
+ALTER TABLE vouchers
+    ADD COLUMN validity_days INTEGER NULL,
+    ADD CONSTRAINT chk_vouchers_validity_days CHECK (validity_days IS NULL OR validity_days > 0);
+
+CREATE TABLE voucher_assignments (
+    id BIGSERIAL PRIMARY KEY,
+    voucher_id BIGINT NOT NULL REFERENCES vouchers(id) ON DELETE CASCADE,
+    customer_id VARCHAR(255) NOT NULL,
+    assigned_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    -- Materialized on assignment so later voucher edits do not move it
+    expires_at DATE NOT NULL,
+    assigned_by VARCHAR(255) NOT NULL DEFAULT ''
+);
+
+CREATE UNIQUE INDEX idx_voucher_assignments_voucher_customer ON voucher_assignments(voucher_id, customer_id);
+CREATE INDEX idx_voucher_assignments_expires_at ON voucher_assignments(expires_at);
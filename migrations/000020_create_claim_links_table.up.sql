@@ -0,0 +1,14 @@
+CREATE TABLE claim_links (
+    id BIGSERIAL PRIMARY KEY,
+    voucher_id BIGINT NOT NULL REFERENCES vouchers(id) ON DELETE CASCADE,
+    -- jti of the signed claim token; the token itself is not stored
+    token_id VARCHAR(32) NOT NULL,
+    expires_at TIMESTAMP NOT NULL,
+    claimed_at TIMESTAMP NULL,
+    claimed_by VARCHAR(255) NOT NULL DEFAULT '',
+    created_by VARCHAR(255) NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE UNIQUE INDEX idx_claim_links_token_id ON claim_links(token_id);
+CREATE INDEX idx_claim_links_voucher_id ON claim_links(voucher_id);
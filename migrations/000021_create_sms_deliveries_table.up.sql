@@ -0,0 +1,21 @@
+CREATE TABLE sms_deliveries (
+    id BIGSERIAL PRIMARY KEY,
+    voucher_id BIGINT NOT NULL REFERENCES vouchers(id) ON DELETE CASCADE,
+    phone_number VARCHAR(20) NOT NULL,
+    customer_id VARCHAR(255) NOT NULL DEFAULT '',
+    content VARCHAR(20) NOT NULL,
+    body TEXT NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending'
+        CONSTRAINT chk_sms_deliveries_status CHECK (status IN ('pending', 'sent', 'failed')),
+    attempts INTEGER NOT NULL DEFAULT 0,
+    last_error TEXT NOT NULL DEFAULT '',
+    provider_message_id VARCHAR(100) NOT NULL DEFAULT '',
+    next_attempt_at TIMESTAMP NOT NULL,
+    sent_at TIMESTAMP NULL,
+    created_by VARCHAR(255) NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_sms_deliveries_voucher_id ON sms_deliveries(voucher_id);
+CREATE INDEX idx_sms_deliveries_next_attempt_at ON sms_deliveries(next_attempt_at);
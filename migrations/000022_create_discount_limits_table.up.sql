@@ -0,0 +1,7 @@
+CREATE TABLE discount_limits (
+    role VARCHAR(20) PRIMARY KEY,
+    max_discount_percent DECIMAL(5,2) NOT NULL
+        CONSTRAINT chk_discount_limits_max_discount_percent CHECK (max_discount_percent >= 1 AND max_discount_percent <= 100),
+    updated_by VARCHAR(255) NOT NULL DEFAULT '',
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
@@ -0,0 +1,10 @@
+CREATE TABLE audit_entries (
+    id BIGSERIAL PRIMARY KEY,
+    action VARCHAR(50) NOT NULL,
+    actor VARCHAR(255) NOT NULL DEFAULT '',
+    details TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_audit_entries_action ON audit_entries(action);
+CREATE INDEX idx_audit_entries_created_at ON audit_entries(created_at);
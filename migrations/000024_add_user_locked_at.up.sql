@@ -0,0 +1,2 @@
+ALTER TABLE users
+    ADD COLUMN locked_at TIMESTAMP NULL;
@@ -0,0 +1,2 @@
+ALTER TABLE partners
+    ADD COLUMN rate_limit INTEGER NOT NULL DEFAULT 0 CHECK (rate_limit >= 0);
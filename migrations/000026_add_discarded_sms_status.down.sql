@@ -0,0 +1,5 @@
+UPDATE sms_deliveries SET status = 'failed' WHERE status = 'discarded';
+
+ALTER TABLE sms_deliveries
+    DROP CONSTRAINT IF EXISTS chk_sms_deliveries_status,
+    ADD CONSTRAINT chk_sms_deliveries_status CHECK (status IN ('pending', 'sent', 'failed'));
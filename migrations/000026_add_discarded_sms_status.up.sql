@@ -0,0 +1,3 @@
+ALTER TABLE sms_deliveries
+    DROP CONSTRAINT IF EXISTS chk_sms_deliveries_status,
+    ADD CONSTRAINT chk_sms_deliveries_status CHECK (status IN ('pending', 'sent', 'failed', 'discarded'));
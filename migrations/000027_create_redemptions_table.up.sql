@@ -0,0 +1,12 @@
+CREATE TABLE redemptions (
+    id BIGSERIAL PRIMARY KEY,
+    voucher_id BIGINT NOT NULL REFERENCES vouchers(id) ON DELETE CASCADE,
+    customer_id VARCHAR(255) NOT NULL,
+    order_id VARCHAR(100) NOT NULL DEFAULT '',
+    -- Discount applied, as it was when the voucher was redeemed
+    discount_percent DECIMAL(5,2) NOT NULL,
+    redeemed_by VARCHAR(255) NOT NULL DEFAULT '',
+    redeemed_at TIMESTAMP NOT NULL
+);
+
+CREATE INDEX idx_redemptions_voucher_customer ON redemptions(voucher_id, customer_id);
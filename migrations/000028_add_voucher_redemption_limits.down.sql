@@ -0,0 +1,6 @@
+ALTER TABLE vouchers
+    DROP CONSTRAINT IF EXISTS chk_vouchers_max_redemptions_per_user,
+    DROP CONSTRAINT IF EXISTS chk_vouchers_max_redemptions,
+    DROP COLUMN IF EXISTS redemption_count,
+    DROP COLUMN IF EXISTS max_redemptions_per_user,
+    DROP COLUMN IF EXISTS max_redemptions;
@@ -0,0 +1,11 @@
+-- Vouchers can cap redemptions in total and per customer; redemption_count
+-- is kept alongside so the total cap is checked without counting rows
+ALTER TABLE vouchers
+    ADD COLUMN max_redemptions INTEGER NULL,
+    ADD COLUMN max_redemptions_per_user INTEGER NOT NULL DEFAULT 1,
+    ADD COLUMN redemption_count INTEGER NOT NULL DEFAULT 0,
+    ADD CONSTRAINT chk_vouchers_max_redemptions CHECK (max_redemptions IS NULL OR max_redemptions > 0),
+    ADD CONSTRAINT chk_vouchers_max_redemptions_per_user CHECK (max_redemptions_per_user > 0);
+
+UPDATE vouchers
+SET redemption_count = (SELECT COUNT(*) FROM redemptions WHERE redemptions.voucher_id = vouchers.id);
@@ -0,0 +1,19 @@
+ALTER TABLE redemptions
+    DROP COLUMN IF EXISTS currency,
+    DROP COLUMN IF EXISTS discount_amount,
+    DROP COLUMN IF EXISTS discount_type;
+
+-- Fixed-amount vouchers cannot be expressed as a percentage, so they are
+-- deactivated and archived with a placeholder 1% discount
+UPDATE vouchers
+SET status = 'inactive', discount_percent = 1, deleted_at = COALESCE(deleted_at, CURRENT_TIMESTAMP)
+WHERE discount_type = 'fixed_amount';
+
+ALTER TABLE vouchers
+    DROP CONSTRAINT IF EXISTS chk_vouchers_discount_amount,
+    DROP CONSTRAINT IF EXISTS chk_vouchers_discount_percent,
+    DROP CONSTRAINT IF EXISTS chk_vouchers_discount_type,
+    ADD CONSTRAINT vouchers_discount_percent_check CHECK (discount_percent >= 1 AND discount_percent <= 100),
+    DROP COLUMN IF EXISTS currency,
+    DROP COLUMN IF EXISTS discount_amount,
+    DROP COLUMN IF EXISTS discount_type;
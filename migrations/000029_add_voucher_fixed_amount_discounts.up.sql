@@ -0,0 +1,17 @@
+-- Vouchers take either a percentage or a fixed amount in a currency off;
+-- fixed-amount vouchers keep discount_percent at 0
+ALTER TABLE vouchers
+    ADD COLUMN discount_type VARCHAR(20) NOT NULL DEFAULT 'percent',
+    ADD COLUMN discount_amount DECIMAL(15,2) NOT NULL DEFAULT 0,
+    ADD COLUMN currency VARCHAR(3) NOT NULL DEFAULT '',
+    DROP CONSTRAINT IF EXISTS vouchers_discount_percent_check,
+    DROP CONSTRAINT IF EXISTS chk_vouchers_discount_percent,
+    ADD CONSTRAINT chk_vouchers_discount_type CHECK (discount_type IN ('percent', 'fixed_amount')),
+    ADD CONSTRAINT chk_vouchers_discount_percent CHECK (discount_type <> 'percent' OR (discount_percent >= 1 AND discount_percent <= 100)),
+    ADD CONSTRAINT chk_vouchers_discount_amount CHECK (discount_type <> 'fixed_amount' OR (discount_amount > 0 AND currency <> ''));
+
+-- Redemptions keep the discount as it was, whichever kind it is
+ALTER TABLE redemptions
+    ADD COLUMN discount_type VARCHAR(20) NOT NULL DEFAULT 'percent',
+    ADD COLUMN discount_amount DECIMAL(15,2) NOT NULL DEFAULT 0,
+    ADD COLUMN currency VARCHAR(3) NOT NULL DEFAULT '';
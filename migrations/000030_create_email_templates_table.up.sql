@@ -0,0 +1,11 @@
+CREATE TABLE email_templates (
+    id BIGSERIAL PRIMARY KEY,
+    key VARCHAR(50) NOT NULL,
+    version INTEGER NOT NULL,
+    subject VARCHAR(255) NOT NULL,
+    body TEXT NOT NULL,
+    created_by VARCHAR(255) NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE UNIQUE INDEX idx_email_templates_key_version ON email_templates(key, version);
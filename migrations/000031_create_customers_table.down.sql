@@ -0,0 +1,26 @@
+-- Customer references go back to external IDs; rows moved by a merge keep
+-- the surviving customer's ID
+ALTER TABLE claim_links ADD COLUMN claimed_by VARCHAR(255) NOT NULL DEFAULT '';
+UPDATE claim_links SET claimed_by = customers.external_id
+FROM customers WHERE customers.id = claim_links.customer_id;
+ALTER TABLE claim_links DROP COLUMN customer_id;
+
+ALTER TABLE redemptions ADD COLUMN customer_ref VARCHAR(255) NULL;
+UPDATE redemptions SET customer_ref = customers.external_id
+FROM customers WHERE customers.id = redemptions.customer_id;
+DROP INDEX IF EXISTS idx_redemptions_voucher_customer;
+ALTER TABLE redemptions DROP COLUMN customer_id;
+ALTER TABLE redemptions RENAME COLUMN customer_ref TO customer_id;
+ALTER TABLE redemptions ALTER COLUMN customer_id SET NOT NULL;
+CREATE INDEX idx_redemptions_voucher_customer ON redemptions(voucher_id, customer_id);
+
+ALTER TABLE voucher_assignments ADD COLUMN customer_ref VARCHAR(255) NULL;
+UPDATE voucher_assignments SET customer_ref = customers.external_id
+FROM customers WHERE customers.id = voucher_assignments.customer_id;
+DROP INDEX IF EXISTS idx_voucher_assignments_voucher_customer;
+ALTER TABLE voucher_assignments DROP COLUMN customer_id;
+ALTER TABLE voucher_assignments RENAME COLUMN customer_ref TO customer_id;
+ALTER TABLE voucher_assignments ALTER COLUMN customer_id SET NOT NULL;
+CREATE UNIQUE INDEX idx_voucher_assignments_voucher_customer ON voucher_assignments(voucher_id, customer_id);
+
+DROP TABLE IF EXISTS customers;
@@ -0,0 +1,52 @@
+CREATE TABLE customers (
+    id BIGSERIAL PRIMARY KEY,
+    -- Identifier the calling system knows the customer by
+    external_id VARCHAR(255) NOT NULL,
+    email VARCHAR(255) NOT NULL DEFAULT '',
+    phone VARCHAR(20) NOT NULL DEFAULT '',
+    -- Set on duplicates merged into another customer
+    merged_into_id BIGINT NULL REFERENCES customers(id),
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE UNIQUE INDEX idx_customers_external_id ON customers(external_id);
+CREATE INDEX idx_customers_email ON customers(email);
+CREATE INDEX idx_customers_merged_into_id ON customers(merged_into_id);
+
+-- Every customer ID stored so far becomes a customer
+INSERT INTO customers (external_id)
+SELECT customer_id FROM voucher_assignments
+UNION
+SELECT customer_id FROM redemptions
+UNION
+SELECT claimed_by FROM claim_links WHERE claimed_by <> '';
+
+-- Assignments, redemptions and claim links now reference the customer
+ALTER TABLE voucher_assignments ADD COLUMN customer_ref BIGINT NULL;
+UPDATE voucher_assignments SET customer_ref = customers.id
+FROM customers WHERE customers.external_id = voucher_assignments.customer_id;
+DROP INDEX IF EXISTS idx_voucher_assignments_voucher_customer;
+ALTER TABLE voucher_assignments DROP COLUMN customer_id;
+ALTER TABLE voucher_assignments RENAME COLUMN customer_ref TO customer_id;
+ALTER TABLE voucher_assignments
+    ALTER COLUMN customer_id SET NOT NULL,
+    ADD CONSTRAINT fk_voucher_assignments_customer FOREIGN KEY (customer_id) REFERENCES customers(id);
+CREATE UNIQUE INDEX idx_voucher_assignments_voucher_customer ON voucher_assignments(voucher_id, customer_id);
+
+ALTER TABLE redemptions ADD COLUMN customer_ref BIGINT NULL;
+UPDATE redemptions SET customer_ref = customers.id
+FROM customers WHERE customers.external_id = redemptions.customer_id;
+DROP INDEX IF EXISTS idx_redemptions_voucher_customer;
+ALTER TABLE redemptions DROP COLUMN customer_id;
+ALTER TABLE redemptions RENAME COLUMN customer_ref TO customer_id;
+ALTER TABLE redemptions
+    ALTER COLUMN customer_id SET NOT NULL,
+    ADD CONSTRAINT fk_redemptions_customer FOREIGN KEY (customer_id) REFERENCES customers(id);
+CREATE INDEX idx_redemptions_voucher_customer ON redemptions(voucher_id, customer_id);
+
+ALTER TABLE claim_links ADD COLUMN customer_id BIGINT NULL REFERENCES customers(id);
+UPDATE claim_links SET customer_id = customers.id
+FROM customers WHERE customers.external_id = claim_links.claimed_by;
+ALTER TABLE claim_links DROP COLUMN claimed_by;
+CREATE INDEX idx_claim_links_customer_id ON claim_links(customer_id);
@@ -0,0 +1 @@
+ALTER TABLE vouchers DROP COLUMN IF EXISTS updated_by;
@@ -0,0 +1,3 @@
+-- Vouchers remember who last changed them, so that user cannot approve the change
+ALTER TABLE vouchers ADD COLUMN updated_by VARCHAR(255) NOT NULL DEFAULT '';
+UPDATE vouchers SET updated_by = COALESCE(created_by, '');
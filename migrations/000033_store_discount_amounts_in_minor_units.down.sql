@@ -0,0 +1,18 @@
+-- Three-decimal amounts are rounded to the two places DECIMAL(15,2) keeps
+ALTER TABLE redemptions
+    ALTER COLUMN discount_amount DROP DEFAULT,
+    ALTER COLUMN discount_amount TYPE DECIMAL(15,2) USING discount_amount / CASE
+        WHEN currency IN ('BIF', 'CLP', 'DJF', 'GNF', 'ISK', 'JPY', 'KMF', 'KRW', 'PYG', 'RWF', 'UGX', 'VND', 'VUV', 'XAF', 'XOF', 'XPF') THEN 1.0
+        WHEN currency IN ('BHD', 'IQD', 'JOD', 'KWD', 'LYD', 'OMR', 'TND') THEN 1000.0
+        ELSE 100.0
+    END,
+    ALTER COLUMN discount_amount SET DEFAULT 0;
+
+ALTER TABLE vouchers
+    ALTER COLUMN discount_amount DROP DEFAULT,
+    ALTER COLUMN discount_amount TYPE DECIMAL(15,2) USING discount_amount / CASE
+        WHEN currency IN ('BIF', 'CLP', 'DJF', 'GNF', 'ISK', 'JPY', 'KMF', 'KRW', 'PYG', 'RWF', 'UGX', 'VND', 'VUV', 'XAF', 'XOF', 'XPF') THEN 1.0
+        WHEN currency IN ('BHD', 'IQD', 'JOD', 'KWD', 'LYD', 'OMR', 'TND') THEN 1000.0
+        ELSE 100.0
+    END,
+    ALTER COLUMN discount_amount SET DEFAULT 0;
@@ -0,0 +1,20 @@
+-- Fixed discount amounts are kept in whole minor units of their currency
+-- (cents, or yen for currencies without a minor unit) rather than as
+-- DECIMAL(15,2), which cannot hold three-decimal currencies such as KWD
+ALTER TABLE vouchers
+    ALTER COLUMN discount_amount DROP DEFAULT,
+    ALTER COLUMN discount_amount TYPE BIGINT USING ROUND(discount_amount * CASE
+        WHEN currency IN ('BIF', 'CLP', 'DJF', 'GNF', 'ISK', 'JPY', 'KMF', 'KRW', 'PYG', 'RWF', 'UGX', 'VND', 'VUV', 'XAF', 'XOF', 'XPF') THEN 1
+        WHEN currency IN ('BHD', 'IQD', 'JOD', 'KWD', 'LYD', 'OMR', 'TND') THEN 1000
+        ELSE 100
+    END),
+    ALTER COLUMN discount_amount SET DEFAULT 0;
+
+ALTER TABLE redemptions
+    ALTER COLUMN discount_amount DROP DEFAULT,
+    ALTER COLUMN discount_amount TYPE BIGINT USING ROUND(discount_amount * CASE
+        WHEN currency IN ('BIF', 'CLP', 'DJF', 'GNF', 'ISK', 'JPY', 'KMF', 'KRW', 'PYG', 'RWF', 'UGX', 'VND', 'VUV', 'XAF', 'XOF', 'XPF') THEN 1
+        WHEN currency IN ('BHD', 'IQD', 'JOD', 'KWD', 'LYD', 'OMR', 'TND') THEN 1000
+        ELSE 100
+    END),
+    ALTER COLUMN discount_amount SET DEFAULT 0;
@@ -0,0 +1 @@
+ALTER TABLE discount_limits DROP COLUMN IF EXISTS max_discount_amounts;
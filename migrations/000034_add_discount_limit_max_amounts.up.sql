@@ -0,0 +1,3 @@
+-- Discount limits also cap fixed amounts, per currency in minor units, e.g.
+-- {"IDR": 10000000}; limited roles cannot give fixed amounts in other currencies
+ALTER TABLE discount_limits ADD COLUMN max_discount_amounts TEXT NOT NULL DEFAULT '{}';
@@ -0,0 +1,63 @@
+// Package buildinfo reports which build of the service is running.
+package buildinfo
+
+import (
+	"runtime"
+	"runtime/debug"
+)
+
+// Version, Commit and BuildDate describe the build. They are meant to be set
+// at link time with -ldflags "-X"; Get falls back to the VCS details Go
+// embeds in the binary when they are left empty.
+var (
+	Version   = ""
+	Commit    = ""
+	BuildDate = ""
+)
+
+// unknown is reported for build details that are not available
+const unknown = "unknown"
+
+// Info describes the running build
+type Info struct {
+	Version   string `json:"version"`
+	Commit    string `json:"commit"`
+	BuildDate string `json:"build_date"`
+	GoVersion string `json:"go_version"`
+}
+
+// Get returns the details of the running build
+func Get() Info {
+	info := Info{
+		Version:   Version,
+		Commit:    Commit,
+		BuildDate: BuildDate,
+		GoVersion: runtime.Version(),
+	}
+
+	if build, ok := debug.ReadBuildInfo(); ok {
+		for _, setting := range build.Settings {
+			switch setting.Key {
+			case "vcs.revision":
+				if info.Commit == "" {
+					info.Commit = setting.Value
+				}
+			case "vcs.time":
+				if info.BuildDate == "" {
+					info.BuildDate = setting.Value
+				}
+			}
+		}
+	}
+
+	if info.Version == "" {
+		info.Version = "dev"
+	}
+	if info.Commit == "" {
+		info.Commit = unknown
+	}
+	if info.BuildDate == "" {
+		info.BuildDate = unknown
+	}
+	return info
+}
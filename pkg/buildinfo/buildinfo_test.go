@@ -0,0 +1,30 @@
+package buildinfo
+
+import (
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGet_UsesLinkedValues(t *testing.T) {
+	// Arrange
+	Version, Commit, BuildDate = "v1.2.0", "abc123", "2026-01-01T00:00:00Z"
+	defer func() { Version, Commit, BuildDate = "", "", "" }()
+
+	// Act
+	info := Get()
+
+	// Assert
+	assert.Equal(t, Info{Version: "v1.2.0", Commit: "abc123", BuildDate: "2026-01-01T00:00:00Z", GoVersion: runtime.Version()}, info)
+}
+
+func TestGet_DefaultsWhenNotLinked(t *testing.T) {
+	// Act
+	info := Get()
+
+	// Assert
+	assert.Equal(t, "dev", info.Version)
+	assert.NotEmpty(t, info.Commit)
+	assert.NotEmpty(t, info.BuildDate)
+}
@@ -0,0 +1,111 @@
+// Package captcha verifies the tokens CAPTCHA widgets hand to browsers, so
+// public endpoints can require proof that a person sent the request.
+package captcha
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Supported CAPTCHA providers
+const (
+	ProviderNone      = "none"
+	ProviderTurnstile = "turnstile"
+	ProviderRecaptcha = "recaptcha"
+)
+
+// Verification endpoints of the hosted providers
+const (
+	turnstileVerifyURL = "https://challenges.cloudflare.com/turnstile/v0/siteverify"
+	recaptchaVerifyURL = "https://www.google.com/recaptcha/api/siteverify"
+)
+
+// Verifier checks CAPTCHA tokens with the provider that issued them
+type Verifier interface {
+	// Verify reports whether token is a valid, unused solution. remoteIP is
+	// the address of the client that solved it and may be empty. An error
+	// means the provider could not be asked, not that the token is bad.
+	Verify(token, remoteIP string) (bool, error)
+}
+
+// Config selects the provider and holds its secret key. MinScore applies to
+// reCAPTCHA v3, which scores each solution from 0.0 (bot) to 1.0 (person);
+// 0 accepts any successful solution.
+type Config struct {
+	Provider string
+	Secret   string
+	MinScore float64
+	Timeout  time.Duration
+}
+
+// NewVerifier creates a verifier for the configured provider; an empty
+// provider or ProviderNone returns nil, meaning no CAPTCHA is required
+func NewVerifier(cfg Config) (Verifier, error) {
+	httpClient := &http.Client{Timeout: cfg.Timeout}
+
+	switch cfg.Provider {
+	case "", ProviderNone:
+		return nil, nil
+	case ProviderTurnstile, ProviderRecaptcha:
+		if cfg.Secret == "" {
+			return nil, fmt.Errorf("%s needs a secret key", cfg.Provider)
+		}
+		verifyURL := turnstileVerifyURL
+		if cfg.Provider == ProviderRecaptcha {
+			verifyURL = recaptchaVerifyURL
+		}
+		return &siteVerifier{provider: cfg.Provider, verifyURL: verifyURL, secret: cfg.Secret, minScore: cfg.MinScore, httpClient: httpClient}, nil
+	default:
+		return nil, fmt.Errorf("unknown CAPTCHA provider %q (expected %s, %s or %s)", cfg.Provider, ProviderNone, ProviderTurnstile, ProviderRecaptcha)
+	}
+}
+
+// siteVerifier implements the siteverify API shared by Turnstile and reCAPTCHA
+type siteVerifier struct {
+	provider   string
+	verifyURL  string
+	secret     string
+	minScore   float64
+	httpClient *http.Client
+}
+
+// Verify posts the token to the provider's siteverify endpoint
+func (v *siteVerifier) Verify(token, remoteIP string) (bool, error) {
+	if strings.TrimSpace(token) == "" {
+		return false, nil
+	}
+
+	form := url.Values{"secret": {v.secret}, "response": {token}}
+	if remoteIP != "" {
+		form.Set("remoteip", remoteIP)
+	}
+
+	resp, err := v.httpClient.PostForm(v.verifyURL, form)
+	if err != nil {
+		return false, fmt.Errorf("%s request failed: %w", v.provider, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return false, fmt.Errorf("%s returned status %d", v.provider, resp.StatusCode)
+	}
+	var result struct {
+		Success bool `json:"success"`
+		// Score is only sent by reCAPTCHA v3
+		Score *float64 `json:"score"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return false, fmt.Errorf("invalid %s response: %w", v.provider, err)
+	}
+	if !result.Success {
+		return false, nil
+	}
+	if result.Score != nil && *result.Score < v.minScore {
+		return false, nil
+	}
+	return true, nil
+}
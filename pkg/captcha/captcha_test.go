@@ -0,0 +1,65 @@
+package captcha
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewVerifier(t *testing.T) {
+	// Act
+	none, noneErr := NewVerifier(Config{})
+	_, unknownErr := NewVerifier(Config{Provider: "hcaptcha", Secret: "s"})
+	_, missingErr := NewVerifier(Config{Provider: ProviderTurnstile})
+	turnstile, err := NewVerifier(Config{Provider: ProviderTurnstile, Secret: "s"})
+
+	// Assert
+	assert.NoError(t, noneErr)
+	assert.Nil(t, none)
+	assert.Error(t, unknownErr)
+	assert.Error(t, missingErr)
+	assert.NoError(t, err)
+	assert.Equal(t, turnstileVerifyURL, turnstile.(*siteVerifier).verifyURL)
+}
+
+func TestSiteVerifier_Verify(t *testing.T) {
+	// Arrange
+	var gotSecret, gotIP string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = r.ParseForm()
+		gotSecret = r.PostForm.Get("secret")
+		switch r.PostForm.Get("response") {
+		case "human":
+			gotIP = r.PostForm.Get("remoteip")
+			_, _ = w.Write([]byte(`{"success":true,"score":0.9}`))
+		case "bot":
+			_, _ = w.Write([]byte(`{"success":true,"score":0.1}`))
+		case "down":
+			w.WriteHeader(http.StatusServiceUnavailable)
+		default:
+			_, _ = w.Write([]byte(`{"success":false,"error-codes":["invalid-input-response"]}`))
+		}
+	}))
+	defer server.Close()
+	verifier := &siteVerifier{provider: ProviderRecaptcha, verifyURL: server.URL, secret: "secret", minScore: 0.5, httpClient: &http.Client{Timeout: time.Second}}
+
+	// Act
+	human, humanErr := verifier.Verify("human", "203.0.113.7")
+	bot, _ := verifier.Verify("bot", "")
+	forged, _ := verifier.Verify("forged", "")
+	empty, _ := verifier.Verify("", "")
+	_, downErr := verifier.Verify("down", "")
+
+	// Assert
+	assert.NoError(t, humanErr)
+	assert.True(t, human)
+	assert.Equal(t, "secret", gotSecret)
+	assert.Equal(t, "203.0.113.7", gotIP)
+	assert.False(t, bot)
+	assert.False(t, forged)
+	assert.False(t, empty)
+	assert.Error(t, downErr)
+}
@@ -0,0 +1,129 @@
+package database
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// CheckConstraint describes the values Go-side validation accepts for a
+// column, so they can be compared against the CHECK constraints in the database
+type CheckConstraint struct {
+	Table  string
+	Column string
+	// Values are the allowed literals of an enum-like column
+	Values []string
+	// Bounds are the numeric limits of a range-checked column
+	Bounds []float64
+}
+
+var (
+	literalPattern = regexp.MustCompile(`'((?:[^']|'')*)'`)
+	numberPattern  = regexp.MustCompile(`-?\d+(?:\.\d+)?`)
+)
+
+// ValidateConstraints compares the expected constraints with the CHECK
+// constraints defined in PostgreSQL and returns a warning for each divergence
+func ValidateConstraints(db *gorm.DB, expected []CheckConstraint) ([]string, error) {
+	definitions := make(map[string][]string)
+
+	for _, constraint := range expected {
+		if _, ok := definitions[constraint.Table]; ok {
+			continue
+		}
+
+		var defs []string
+		err := db.Raw(`SELECT pg_get_constraintdef(c.oid)
+			FROM pg_constraint c
+			JOIN pg_class t ON t.oid = c.conrelid
+			WHERE c.contype = 'c' AND t.relname = ?`, constraint.Table).
+			Scan(&defs).Error
+		if err != nil {
+			return nil, fmt.Errorf("failed to read constraints of %s: %w", constraint.Table, err)
+		}
+		definitions[constraint.Table] = defs
+	}
+
+	var warnings []string
+	for _, constraint := range expected {
+		warnings = append(warnings, CompareConstraint(constraint, definitions[constraint.Table])...)
+	}
+
+	return warnings, nil
+}
+
+// CompareConstraint checks one expected constraint against the CHECK
+// definitions of its table and describes any divergence
+func CompareConstraint(expected CheckConstraint, definitions []string) []string {
+	column := expected.Table + "." + expected.Column
+
+	var definition string
+	for _, def := range definitions {
+		if strings.Contains(def, expected.Column) {
+			definition = def
+			break
+		}
+	}
+	if definition == "" {
+		return []string{fmt.Sprintf("%s: no CHECK constraint in the database, values are only validated in Go", column)}
+	}
+
+	var warnings []string
+
+	if len(expected.Values) > 0 {
+		var dbValues []string
+		for _, match := range literalPattern.FindAllStringSubmatch(definition, -1) {
+			dbValues = append(dbValues, strings.ReplaceAll(match[1], "''", "'"))
+		}
+		if missing := difference(expected.Values, dbValues); len(missing) > 0 {
+			warnings = append(warnings, fmt.Sprintf("%s: database rejects values accepted in Go: %s", column, strings.Join(missing, ", ")))
+		}
+		if extra := difference(dbValues, expected.Values); len(extra) > 0 {
+			warnings = append(warnings, fmt.Sprintf("%s: database accepts values rejected in Go: %s", column, strings.Join(extra, ", ")))
+		}
+	}
+
+	if len(expected.Bounds) > 0 {
+		var dbBounds []string
+		for _, number := range numberPattern.FindAllString(literalPattern.ReplaceAllString(definition, ""), -1) {
+			if value, err := strconv.ParseFloat(number, 64); err == nil {
+				dbBounds = append(dbBounds, strconv.FormatFloat(value, 'f', -1, 64))
+			}
+		}
+		goBounds := make([]string, len(expected.Bounds))
+		for i, bound := range expected.Bounds {
+			goBounds[i] = strconv.FormatFloat(bound, 'f', -1, 64)
+		}
+		if !equalSets(goBounds, dbBounds) {
+			warnings = append(warnings, fmt.Sprintf("%s: database bounds [%s] differ from Go bounds [%s]",
+				column, strings.Join(dbBounds, ", "), strings.Join(goBounds, ", ")))
+		}
+	}
+
+	return warnings
+}
+
+// difference returns the values of a that are not in b, sorted
+func difference(a, b []string) []string {
+	inB := make(map[string]bool, len(b))
+	for _, value := range b {
+		inB[value] = true
+	}
+
+	var result []string
+	for _, value := range a {
+		if !inB[value] {
+			result = append(result, value)
+		}
+	}
+	sort.Strings(result)
+	return result
+}
+
+func equalSets(a, b []string) bool {
+	return len(difference(a, b)) == 0 && len(difference(b, a)) == 0
+}
@@ -0,0 +1,56 @@
+package database
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompareConstraint_Aligned(t *testing.T) {
+	definitions := []string{
+		"CHECK (((discount_percent >= (1)::numeric) AND (discount_percent <= (100)::numeric)))",
+		"CHECK (((status)::text = ANY ((ARRAY['active'::character varying, 'pending_approval'::character varying])::text[])))",
+	}
+
+	statusWarnings := CompareConstraint(CheckConstraint{
+		Table: "vouchers", Column: "status", Values: []string{"active", "pending_approval"},
+	}, definitions)
+	discountWarnings := CompareConstraint(CheckConstraint{
+		Table: "vouchers", Column: "discount_percent", Bounds: []float64{1, 100},
+	}, definitions)
+
+	assert.Empty(t, statusWarnings)
+	assert.Empty(t, discountWarnings)
+}
+
+func TestCompareConstraint_EnumDiverges(t *testing.T) {
+	definitions := []string{"CHECK ((status IN ('active', 'archived')))"}
+
+	warnings := CompareConstraint(CheckConstraint{
+		Table: "vouchers", Column: "status", Values: []string{"active", "pending_approval"},
+	}, definitions)
+
+	assert.Len(t, warnings, 2)
+	assert.Contains(t, warnings[0], "pending_approval")
+	assert.Contains(t, warnings[1], "archived")
+}
+
+func TestCompareConstraint_BoundsDiverge(t *testing.T) {
+	definitions := []string{"CHECK (((discount_percent >= (0)::numeric) AND (discount_percent <= (100)::numeric)))"}
+
+	warnings := CompareConstraint(CheckConstraint{
+		Table: "vouchers", Column: "discount_percent", Bounds: []float64{1, 100},
+	}, definitions)
+
+	assert.Len(t, warnings, 1)
+	assert.Contains(t, warnings[0], "bounds")
+}
+
+func TestCompareConstraint_Missing(t *testing.T) {
+	warnings := CompareConstraint(CheckConstraint{
+		Table: "vouchers", Column: "status", Values: []string{"active"},
+	}, nil)
+
+	assert.Len(t, warnings, 1)
+	assert.Contains(t, warnings[0], "no CHECK constraint")
+}
@@ -0,0 +1,63 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/shoelfikar/voucher-management-system/pkg/metrics"
+)
+
+// PoolMetrics publishes connection pool statistics as gauges. Wait count and
+// wait duration are cumulative since startup; a rising rate means requests
+// queue for a connection because every open one is in use.
+type PoolMetrics struct {
+	db *sql.DB
+
+	maxOpen      *metrics.Gauge
+	open         *metrics.Gauge
+	inUse        *metrics.Gauge
+	idle         *metrics.Gauge
+	waitCount    *metrics.Gauge
+	waitDuration *metrics.Gauge
+}
+
+// NewPoolMetrics registers the pool gauges of db in registry
+func NewPoolMetrics(db *sql.DB, registry *metrics.Registry) *PoolMetrics {
+	return &PoolMetrics{
+		db:           db,
+		maxOpen:      registry.Gauge("db_pool_max_open_connections", "Maximum number of open connections to the database (0 is unlimited)"),
+		open:         registry.Gauge("db_pool_open_connections", "Established connections, both in use and idle"),
+		inUse:        registry.Gauge("db_pool_in_use_connections", "Connections currently in use"),
+		idle:         registry.Gauge("db_pool_idle_connections", "Idle connections"),
+		waitCount:    registry.Gauge("db_pool_wait_count", "Total number of times a query waited for a free connection"),
+		waitDuration: registry.Gauge("db_pool_wait_duration_seconds", "Total time queries spent waiting for a free connection"),
+	}
+}
+
+// Sample copies the current pool statistics into the gauges
+func (p *PoolMetrics) Sample() {
+	stats := p.db.Stats()
+	p.maxOpen.Set(float64(stats.MaxOpenConnections))
+	p.open.Set(float64(stats.OpenConnections))
+	p.inUse.Set(float64(stats.InUse))
+	p.idle.Set(float64(stats.Idle))
+	p.waitCount.Set(float64(stats.WaitCount))
+	p.waitDuration.Set(stats.WaitDuration.Seconds())
+}
+
+// Run samples the pool now and then every interval until ctx is done
+func (p *PoolMetrics) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	p.Sample()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			p.Sample()
+		}
+	}
+}
@@ -0,0 +1,75 @@
+package database
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/shoelfikar/voucher-management-system/pkg/metrics"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func TestPoolMetrics_Sample(t *testing.T) {
+	// Arrange
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	assert.NoError(t, err)
+	sqlDB, err := db.DB()
+	assert.NoError(t, err)
+	sqlDB.SetMaxOpenConns(4)
+
+	registry := metrics.NewRegistry()
+	poolMetrics := NewPoolMetrics(sqlDB, registry)
+
+	// Hold a connection so it counts as in use
+	conn, err := sqlDB.Conn(context.Background())
+	assert.NoError(t, err)
+	defer conn.Close()
+
+	// Act
+	poolMetrics.Sample()
+
+	// Assert
+	assert.Equal(t, 4.0, registry.Gauge("db_pool_max_open_connections", "").Value())
+	assert.Equal(t, 1.0, registry.Gauge("db_pool_in_use_connections", "").Value())
+	assert.Equal(t, registry.Gauge("db_pool_open_connections", "").Value(),
+		registry.Gauge("db_pool_in_use_connections", "").Value()+registry.Gauge("db_pool_idle_connections", "").Value())
+
+	var text bytes.Buffer
+	assert.NoError(t, registry.WriteText(&text))
+	assert.Contains(t, text.String(), "db_pool_wait_count 0\n")
+	assert.Contains(t, text.String(), "db_pool_wait_duration_seconds 0\n")
+}
+
+func TestPoolMetrics_RunSamplesUntilCancelled(t *testing.T) {
+	// Arrange
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	assert.NoError(t, err)
+	sqlDB, err := db.DB()
+	assert.NoError(t, err)
+	sqlDB.SetMaxOpenConns(2)
+
+	registry := metrics.NewRegistry()
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+
+	// Act
+	go func() {
+		NewPoolMetrics(sqlDB, registry).Run(ctx, time.Hour)
+		close(done)
+	}()
+
+	// Assert the first sample is taken without waiting for the interval
+	assert.Eventually(t, func() bool {
+		return registry.Gauge("db_pool_max_open_connections", "").Value() == 2
+	}, time.Second, 5*time.Millisecond)
+
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("sampler did not stop after cancel")
+	}
+}
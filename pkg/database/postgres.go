@@ -10,15 +10,27 @@ import (
 	"gorm.io/gorm/logger"
 )
 
+// NewGormConfig builds the GORM settings for a database connection. With
+// PrepareStmt, the validation and redemption lookups that run the same
+// parameterized queries all day are parsed and planned once per connection.
+func NewGormConfig(cfg *config.DatabaseConfig) *gorm.Config {
+	return &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+		// Report unique violations as gorm.ErrDuplicatedKey so services can tell conflicts apart
+		TranslateError:         true,
+		PrepareStmt:            cfg.PrepareStmt,
+		PrepareStmtMaxSize:     cfg.StatementCacheSize,
+		PrepareStmtTTL:         cfg.StatementCacheTTL,
+		SkipDefaultTransaction: cfg.SkipDefaultTransaction,
+	}
+}
+
 // NewPostgresDatabase creates a new PostgreSQL database connection
 func NewPostgresDatabase(cfg *config.DatabaseConfig) (*gorm.DB, error) {
 	dsn := fmt.Sprintf("host=%s port=%s user=%s password=%s dbname=%s sslmode=%s",
 		cfg.Host, cfg.Port, cfg.User, cfg.Password, cfg.DBName, cfg.SSLMode)
 
-	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{
-		Logger: logger.Default.LogMode(logger.Silent),
-	})
-
+	db, err := gorm.Open(postgres.Open(dsn), NewGormConfig(cfg))
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect to database: %w", err)
 	}
@@ -0,0 +1,116 @@
+package database
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/shoelfikar/voucher-management-system/internal/config"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func TestNewGormConfig_AppliesStatementSettings(t *testing.T) {
+	// Arrange
+	cfg := &config.DatabaseConfig{
+		PrepareStmt:            true,
+		StatementCacheSize:     250,
+		StatementCacheTTL:      30 * time.Minute,
+		SkipDefaultTransaction: true,
+	}
+
+	// Act
+	gormConfig := NewGormConfig(cfg)
+
+	// Assert
+	assert.True(t, gormConfig.TranslateError)
+	assert.True(t, gormConfig.PrepareStmt)
+	assert.Equal(t, 250, gormConfig.PrepareStmtMaxSize)
+	assert.Equal(t, 30*time.Minute, gormConfig.PrepareStmtTTL)
+	assert.True(t, gormConfig.SkipDefaultTransaction)
+}
+
+// benchmarkVoucher mirrors the voucher columns read and written on the hot path
+type benchmarkVoucher struct {
+	ID              uint   `gorm:"primaryKey"`
+	VoucherCode     string `gorm:"uniqueIndex"`
+	DiscountPercent float64
+	Status          string
+	UpdatedAt       time.Time
+}
+
+// benchmarkConfigs are the statement settings compared by the benchmarks
+var benchmarkConfigs = []struct {
+	name string
+	cfg  config.DatabaseConfig
+}{
+	{"default", config.DatabaseConfig{}},
+	{"prepare_stmt", config.DatabaseConfig{PrepareStmt: true, StatementCacheSize: 500}},
+	{"skip_default_transaction", config.DatabaseConfig{SkipDefaultTransaction: true}},
+	{"both", config.DatabaseConfig{PrepareStmt: true, StatementCacheSize: 500, SkipDefaultTransaction: true}},
+}
+
+func setupBenchmarkDB(b *testing.B, cfg *config.DatabaseConfig) *gorm.DB {
+	db, err := gorm.Open(sqlite.Open(":memory:"), NewGormConfig(cfg))
+	if err != nil {
+		b.Fatalf("Failed to connect to benchmark database: %v", err)
+	}
+	// Every connection to :memory: is a separate database
+	sqlDB, err := db.DB()
+	if err != nil {
+		b.Fatalf("Failed to get database instance: %v", err)
+	}
+	sqlDB.SetMaxOpenConns(1)
+
+	if err := db.AutoMigrate(&benchmarkVoucher{}); err != nil {
+		b.Fatalf("Failed to migrate benchmark database: %v", err)
+	}
+	vouchers := make([]benchmarkVoucher, 1000)
+	for i := range vouchers {
+		vouchers[i] = benchmarkVoucher{VoucherCode: fmt.Sprintf("CODE%04d", i), DiscountPercent: 10, Status: "active"}
+	}
+	if err := db.CreateInBatches(vouchers, 500).Error; err != nil {
+		b.Fatalf("Failed to seed benchmark database: %v", err)
+	}
+	return db
+}
+
+// BenchmarkFindByCode measures the lookup run by every validation and redemption
+func BenchmarkFindByCode(b *testing.B) {
+	for _, bc := range benchmarkConfigs {
+		b.Run(bc.name, func(b *testing.B) {
+			db := setupBenchmarkDB(b, &bc.cfg)
+			b.ReportAllocs()
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				var voucher benchmarkVoucher
+				code := fmt.Sprintf("CODE%04d", i%1000)
+				if err := db.Where("voucher_code = ?", code).First(&voucher).Error; err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+// BenchmarkUpdateStatus measures the single-row write that SkipDefaultTransaction affects
+func BenchmarkUpdateStatus(b *testing.B) {
+	for _, bc := range benchmarkConfigs {
+		b.Run(bc.name, func(b *testing.B) {
+			db := setupBenchmarkDB(b, &bc.cfg)
+			b.ReportAllocs()
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				err := db.Model(&benchmarkVoucher{}).
+					Where("id = ?", i%1000+1).
+					Update("status", "inactive").Error
+				if err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
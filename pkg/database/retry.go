@@ -0,0 +1,104 @@
+package database
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"math/rand/v2"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// transientSQLStates are PostgreSQL error codes after which the statement
+// is known to have been rolled back, so running it again is safe
+var transientSQLStates = map[string]bool{
+	"40001": true, // serialization_failure
+	"40P01": true, // deadlock_detected
+	"57P01": true, // admin_shutdown, sent to sessions when a primary is stopped for failover
+	"57P02": true, // crash_shutdown
+	"57P03": true, // cannot_connect_now, while a promoted replica finishes starting up
+}
+
+// IsTransient reports whether err is a serialization failure or a
+// connection error that leaves nothing committed, so the failed operation
+// may succeed when run again. Constraint violations and other errors caused
+// by the data itself are never transient.
+func IsTransient(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		return transientSQLStates[pgErr.Code]
+	}
+
+	var connectErr *pgconn.ConnectError
+	return errors.As(err, &connectErr) || errors.Is(err, driver.ErrBadConn) || pgconn.SafeToRetry(err)
+}
+
+// RetryPolicy runs operations again after transient errors, waiting a
+// random time up to an exponentially growing cap between attempts so that
+// replicas recovering from the same failover do not retry in lockstep
+type RetryPolicy struct {
+	// MaxAttempts counts the first attempt; 1 or less disables retries
+	MaxAttempts int
+	// BaseDelay caps the wait before the first retry; the cap doubles on each retry
+	BaseDelay time.Duration
+	// MaxDelay bounds the cap
+	MaxDelay time.Duration
+
+	// wait is replaced in tests
+	wait func(ctx context.Context, d time.Duration) error
+}
+
+// Do runs fn until it succeeds, fails with an error that is not transient,
+// or has been attempted MaxAttempts times, and returns its last error. When
+// ctx is done while waiting to retry, the last error is returned joined with
+// the context's.
+func (p RetryPolicy) Do(ctx context.Context, fn func() error) error {
+	wait := p.wait
+	if wait == nil {
+		wait = waitFor
+	}
+
+	var err error
+	for attempt := 1; ; attempt++ {
+		err = fn()
+		if err == nil || attempt >= p.MaxAttempts || !IsTransient(err) {
+			return err
+		}
+		if waitErr := wait(ctx, p.backoff(attempt)); waitErr != nil {
+			return errors.Join(err, waitErr)
+		}
+	}
+}
+
+// waitFor waits for d to pass, or returns early with ctx's error once ctx is done
+func waitFor(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// backoff returns a random wait of up to BaseDelay * 2^(attempt-1), capped at MaxDelay
+func (p RetryPolicy) backoff(attempt int) time.Duration {
+	limit := p.BaseDelay
+	for i := 1; i < attempt && limit < p.MaxDelay; i++ {
+		limit *= 2
+	}
+	if p.MaxDelay > 0 && limit > p.MaxDelay {
+		limit = p.MaxDelay
+	}
+	if limit <= 0 {
+		return 0
+	}
+	return rand.N(limit + 1)
+}
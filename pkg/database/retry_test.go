@@ -0,0 +1,131 @@
+package database
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func TestIsTransient(t *testing.T) {
+	tests := []struct {
+		name      string
+		err       error
+		transient bool
+	}{
+		{"serialization failure", &pgconn.PgError{Code: "40001"}, true},
+		{"deadlock", fmt.Errorf("insert: %w", &pgconn.PgError{Code: "40P01"}), true},
+		{"admin shutdown during failover", &pgconn.PgError{Code: "57P01"}, true},
+		{"replica still starting", &pgconn.PgError{Code: "57P03"}, true},
+		{"bad connection", driver.ErrBadConn, true},
+		{"connect failure", &pgconn.ConnectError{}, true},
+		{"unique violation", &pgconn.PgError{Code: "23505"}, false},
+		{"check violation", &pgconn.PgError{Code: "23514"}, false},
+		{"duplicate key", gorm.ErrDuplicatedKey, false},
+		{"cancelled", context.Canceled, false},
+		{"other", errors.New("boom"), false},
+		{"nil", nil, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.transient, IsTransient(tt.err))
+		})
+	}
+}
+
+func TestRetryPolicy_RetriesTransientErrors(t *testing.T) {
+	// Arrange
+	var waits []time.Duration
+	policy := RetryPolicy{MaxAttempts: 3, BaseDelay: 10 * time.Millisecond, MaxDelay: time.Second,
+		wait: func(_ context.Context, d time.Duration) error {
+			waits = append(waits, d)
+			return nil
+		}}
+	attempts := 0
+
+	// Act
+	err := policy.Do(context.Background(), func() error {
+		attempts++
+		if attempts < 3 {
+			return &pgconn.PgError{Code: "40001"}
+		}
+		return nil
+	})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, 3, attempts)
+	assert.Len(t, waits, 2)
+	assert.LessOrEqual(t, waits[0], 10*time.Millisecond)
+	assert.LessOrEqual(t, waits[1], 20*time.Millisecond)
+}
+
+func TestRetryPolicy_GivesUpAfterMaxAttempts(t *testing.T) {
+	// Arrange
+	policy := RetryPolicy{MaxAttempts: 3, wait: func(context.Context, time.Duration) error { return nil }}
+	attempts := 0
+
+	// Act
+	err := policy.Do(context.Background(), func() error {
+		attempts++
+		return driver.ErrBadConn
+	})
+
+	// Assert
+	assert.ErrorIs(t, err, driver.ErrBadConn)
+	assert.Equal(t, 3, attempts)
+}
+
+func TestRetryPolicy_DoesNotRetryPermanentErrors(t *testing.T) {
+	// Arrange
+	policy := RetryPolicy{MaxAttempts: 3, wait: func(context.Context, time.Duration) error { return nil }}
+	attempts := 0
+
+	// Act
+	err := policy.Do(context.Background(), func() error {
+		attempts++
+		return gorm.ErrDuplicatedKey
+	})
+
+	// Assert
+	assert.ErrorIs(t, err, gorm.ErrDuplicatedKey)
+	assert.Equal(t, 1, attempts)
+}
+
+func TestRetryPolicy_StopsWaitingWhenCanceled(t *testing.T) {
+	// Arrange
+	policy := RetryPolicy{MaxAttempts: 3, BaseDelay: time.Hour, MaxDelay: time.Hour}
+	ctx, cancel := context.WithCancel(context.Background())
+	attempts := 0
+
+	// Act
+	start := time.Now()
+	err := policy.Do(ctx, func() error {
+		attempts++
+		cancel()
+		return driver.ErrBadConn
+	})
+
+	// Assert
+	assert.ErrorIs(t, err, driver.ErrBadConn)
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, 1, attempts)
+	assert.Less(t, time.Since(start), time.Second)
+}
+
+func TestRetryPolicy_BackoffIsCapped(t *testing.T) {
+	policy := RetryPolicy{BaseDelay: 100 * time.Millisecond, MaxDelay: 250 * time.Millisecond}
+
+	for i := 0; i < 50; i++ {
+		assert.LessOrEqual(t, policy.backoff(1), 100*time.Millisecond)
+		assert.LessOrEqual(t, policy.backoff(10), 250*time.Millisecond)
+	}
+	assert.Equal(t, time.Duration(0), RetryPolicy{}.backoff(3))
+}
@@ -0,0 +1,38 @@
+package database
+
+import (
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+// PendingSchemaChanges lists the tables and columns of models that do not
+// exist in the database yet, i.e. what AutoMigrate would still have to
+// create. It only reads the schema, so it is safe to run before migrating.
+func PendingSchemaChanges(db *gorm.DB, models ...interface{}) ([]string, error) {
+	migrator := db.Migrator()
+
+	var pending []string
+	for _, model := range models {
+		stmt := &gorm.Statement{DB: db}
+		if err := stmt.Parse(model); err != nil {
+			return nil, fmt.Errorf("failed to parse model %T: %w", model, err)
+		}
+		table := stmt.Schema.Table
+
+		if !migrator.HasTable(model) {
+			pending = append(pending, "table "+table)
+			continue
+		}
+		for _, field := range stmt.Schema.Fields {
+			if field.DBName == "" {
+				continue
+			}
+			if !migrator.HasColumn(model, field.DBName) {
+				pending = append(pending, "column "+table+"."+field.DBName)
+			}
+		}
+	}
+
+	return pending, nil
+}
@@ -0,0 +1,56 @@
+package database
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+type schemaTestItem struct {
+	ID   uint
+	Name string
+}
+
+func (schemaTestItem) TableName() string { return "items" }
+
+type schemaTestItemV2 struct {
+	ID    uint
+	Name  string
+	Notes string
+}
+
+func (schemaTestItemV2) TableName() string { return "items" }
+
+type schemaTestOther struct {
+	ID uint
+}
+
+func setupSchemaTestDB(t *testing.T) *gorm.DB {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("Failed to connect to test database: %v", err)
+	}
+	return db
+}
+
+func TestPendingSchemaChanges_UpToDate(t *testing.T) {
+	db := setupSchemaTestDB(t)
+	assert.NoError(t, db.AutoMigrate(&schemaTestItem{}))
+
+	pending, err := PendingSchemaChanges(db, &schemaTestItem{})
+
+	assert.NoError(t, err)
+	assert.Empty(t, pending)
+}
+
+func TestPendingSchemaChanges_MissingTableAndColumn(t *testing.T) {
+	db := setupSchemaTestDB(t)
+	assert.NoError(t, db.AutoMigrate(&schemaTestItem{}))
+
+	pending, err := PendingSchemaChanges(db, &schemaTestItemV2{}, &schemaTestOther{})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"column items.notes", "table schema_test_others"}, pending)
+}
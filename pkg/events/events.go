@@ -0,0 +1,102 @@
+package events
+
+import (
+	"sync"
+	"time"
+)
+
+// subscriberBuffer is how many events a subscriber may fall behind before
+// further events are dropped for it
+const subscriberBuffer = 32
+
+// Event is a notification about something that happened in the service
+type Event struct {
+	Type string      `json:"type"`
+	Time time.Time   `json:"time"`
+	Data interface{} `json:"data"`
+}
+
+// Publisher publishes events to whoever is listening
+type Publisher interface {
+	Publish(eventType string, data interface{})
+}
+
+// Subscriber hands out subscriptions to published events
+type Subscriber interface {
+	Subscribe() (<-chan Event, func())
+}
+
+// Discard is a Publisher that drops every event
+var Discard Publisher = discard{}
+
+type discard struct{}
+
+func (discard) Publish(string, interface{}) {}
+
+// Broker fans events out to subscribers in this process. Publishing never
+// blocks: a subscriber whose buffer is full misses the event.
+type Broker struct {
+	mu          sync.Mutex
+	subscribers map[chan Event]struct{}
+	closed      bool
+	now         func() time.Time
+}
+
+// NewBroker creates an event broker without subscribers
+func NewBroker() *Broker {
+	return &Broker{
+		subscribers: make(map[chan Event]struct{}),
+		now:         time.Now,
+	}
+}
+
+// Publish sends an event to every current subscriber
+func (b *Broker) Publish(eventType string, data interface{}) {
+	event := Event{Type: eventType, Time: b.now().UTC(), Data: data}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for ch := range b.subscribers {
+		select {
+		case ch <- event:
+		default:
+		}
+	}
+}
+
+// Subscribe returns a channel receiving events published from now on and a
+// function that unsubscribes and closes the channel. After Close the channel
+// is returned already closed.
+func (b *Broker) Subscribe() (<-chan Event, func()) {
+	ch := make(chan Event, subscriberBuffer)
+
+	b.mu.Lock()
+	if b.closed {
+		close(ch)
+	} else {
+		b.subscribers[ch] = struct{}{}
+	}
+	b.mu.Unlock()
+
+	return ch, func() {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		if _, ok := b.subscribers[ch]; ok {
+			delete(b.subscribers, ch)
+			close(ch)
+		}
+	}
+}
+
+// Close closes every subscriber's channel, ending open event streams so a
+// shutting-down server does not wait on them
+func (b *Broker) Close() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.closed = true
+	for ch := range b.subscribers {
+		delete(b.subscribers, ch)
+		close(ch)
+	}
+}
@@ -0,0 +1,64 @@
+package events
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBroker_PublishReachesSubscribers(t *testing.T) {
+	broker := NewBroker()
+	first, cancelFirst := broker.Subscribe()
+	second, cancelSecond := broker.Subscribe()
+	defer cancelFirst()
+	defer cancelSecond()
+
+	broker.Publish("import.completed", map[string]int{"success": 3})
+
+	for _, ch := range []<-chan Event{first, second} {
+		event := <-ch
+		assert.Equal(t, "import.completed", event.Type)
+		assert.Equal(t, map[string]int{"success": 3}, event.Data)
+		assert.False(t, event.Time.IsZero())
+	}
+}
+
+func TestBroker_CancelStopsDelivery(t *testing.T) {
+	broker := NewBroker()
+	ch, cancel := broker.Subscribe()
+
+	cancel()
+	cancel()
+	broker.Publish("import.completed", nil)
+
+	_, open := <-ch
+	assert.False(t, open)
+}
+
+func TestBroker_FullSubscriberDropsEvents(t *testing.T) {
+	broker := NewBroker()
+	ch, cancel := broker.Subscribe()
+	defer cancel()
+
+	for i := 0; i < subscriberBuffer+5; i++ {
+		broker.Publish("tick", i)
+	}
+
+	assert.Len(t, ch, subscriberBuffer)
+	assert.Equal(t, 0, (<-ch).Data)
+}
+
+func TestBroker_CloseEndsSubscriptions(t *testing.T) {
+	broker := NewBroker()
+	ch, cancel := broker.Subscribe()
+
+	broker.Close()
+	cancel()
+	late, lateCancel := broker.Subscribe()
+	defer lateCancel()
+
+	_, open := <-ch
+	assert.False(t, open)
+	_, open = <-late
+	assert.False(t, open)
+}
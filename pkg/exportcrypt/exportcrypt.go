@@ -0,0 +1,92 @@
+package exportcrypt
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"errors"
+	"fmt"
+
+	"golang.org/x/crypto/scrypt"
+)
+
+// MinPassphraseLength is the shortest passphrase accepted for encryption
+const MinPassphraseLength = 8
+
+// magic identifies the file format and version
+var magic = []byte("VEX1")
+
+const (
+	saltSize = 16
+	keySize  = 32
+)
+
+// Encrypt seals data with AES-256-GCM using a key derived from the passphrase
+// with scrypt. The output is magic | salt | nonce | ciphertext.
+func Encrypt(data []byte, passphrase string) ([]byte, error) {
+	if len(passphrase) < MinPassphraseLength {
+		return nil, fmt.Errorf("passphrase must be at least %d characters", MinPassphraseLength)
+	}
+
+	salt := make([]byte, saltSize)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, fmt.Errorf("failed to generate salt: %w", err)
+	}
+
+	gcm, err := newGCM(passphrase, salt)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, fmt.Errorf("failed to generate nonce: %w", err)
+	}
+
+	out := make([]byte, 0, len(magic)+saltSize+len(nonce)+len(data)+gcm.Overhead())
+	out = append(out, magic...)
+	out = append(out, salt...)
+	out = append(out, nonce...)
+	return gcm.Seal(out, nonce, data, magic), nil
+}
+
+// Decrypt opens data produced by Encrypt
+func Decrypt(data []byte, passphrase string) ([]byte, error) {
+	if !bytes.HasPrefix(data, magic) {
+		return nil, errors.New("not an encrypted export")
+	}
+	rest := data[len(magic):]
+	if len(rest) < saltSize {
+		return nil, errors.New("encrypted export is truncated")
+	}
+
+	gcm, err := newGCM(passphrase, rest[:saltSize])
+	if err != nil {
+		return nil, err
+	}
+
+	rest = rest[saltSize:]
+	if len(rest) < gcm.NonceSize() {
+		return nil, errors.New("encrypted export is truncated")
+	}
+
+	plaintext, err := gcm.Open(nil, rest[:gcm.NonceSize()], rest[gcm.NonceSize():], magic)
+	if err != nil {
+		return nil, errors.New("wrong passphrase or corrupted export")
+	}
+	return plaintext, nil
+}
+
+func newGCM(passphrase string, salt []byte) (cipher.AEAD, error) {
+	key, err := scrypt.Key([]byte(passphrase), salt, 1<<15, 8, 1, keySize)
+	if err != nil {
+		return nil, fmt.Errorf("failed to derive key: %w", err)
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
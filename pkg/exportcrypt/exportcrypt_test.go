@@ -0,0 +1,44 @@
+package exportcrypt
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEncryptDecrypt_RoundTrip(t *testing.T) {
+	data := []byte("voucher_code,discount_percent,expiry_date\nSUMMER24,10.00,2030-01-01\n")
+
+	encrypted, err := Encrypt(data, "correct horse battery")
+	assert.NoError(t, err)
+	assert.NotContains(t, string(encrypted), "SUMMER24")
+
+	decrypted, err := Decrypt(encrypted, "correct horse battery")
+	assert.NoError(t, err)
+	assert.Equal(t, data, decrypted)
+}
+
+func TestDecrypt_WrongPassphrase(t *testing.T) {
+	encrypted, err := Encrypt([]byte("secret"), "correct horse battery")
+	assert.NoError(t, err)
+
+	_, err = Decrypt(encrypted, "wrong passphrase")
+
+	assert.Error(t, err)
+}
+
+func TestDecrypt_Tampered(t *testing.T) {
+	encrypted, err := Encrypt([]byte("secret"), "correct horse battery")
+	assert.NoError(t, err)
+	encrypted[len(encrypted)-1] ^= 0xFF
+
+	_, err = Decrypt(encrypted, "correct horse battery")
+
+	assert.Error(t, err)
+}
+
+func TestEncrypt_ShortPassphrase(t *testing.T) {
+	_, err := Encrypt([]byte("secret"), "short")
+
+	assert.Error(t, err)
+}
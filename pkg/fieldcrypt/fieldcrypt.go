@@ -0,0 +1,89 @@
+package fieldcrypt
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// KeySize is the required key length in bytes (AES-256)
+const KeySize = 32
+
+// prefix marks encrypted values and their format version
+const prefix = "enc:v1:"
+
+// Cipher encrypts individual string fields with AES-256-GCM.
+// Encrypted values are text, so they fit in the column the plaintext used.
+type Cipher struct {
+	gcm cipher.AEAD
+}
+
+// NewCipher creates a cipher from a 32-byte key
+func NewCipher(key []byte) (*Cipher, error) {
+	if len(key) != KeySize {
+		return nil, fmt.Errorf("field encryption key must be %d bytes, got %d", KeySize, len(key))
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Cipher{gcm: gcm}, nil
+}
+
+// NewCipherFromBase64 creates a cipher from a base64-encoded 32-byte key, as kept in config
+func NewCipherFromBase64(encodedKey string) (*Cipher, error) {
+	key, err := base64.StdEncoding.DecodeString(encodedKey)
+	if err != nil {
+		return nil, fmt.Errorf("field encryption key is not valid base64: %w", err)
+	}
+	return NewCipher(key)
+}
+
+// IsEncrypted reports whether value was produced by Encrypt
+func IsEncrypted(value string) bool {
+	return strings.HasPrefix(value, prefix)
+}
+
+// Encrypt seals plaintext as "enc:v1:" followed by base64(nonce | ciphertext)
+func (c *Cipher) Encrypt(plaintext string) (string, error) {
+	nonce := make([]byte, c.gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", fmt.Errorf("failed to generate nonce: %w", err)
+	}
+
+	sealed := c.gcm.Seal(nonce, nonce, []byte(plaintext), []byte(prefix))
+	return prefix + base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+// Decrypt opens a value produced by Encrypt. Values without the prefix are
+// returned unchanged, so fields written before encryption was enabled still read.
+func (c *Cipher) Decrypt(value string) (string, error) {
+	if !IsEncrypted(value) {
+		return value, nil
+	}
+
+	sealed, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(value, prefix))
+	if err != nil {
+		return "", errors.New("encrypted field is not valid base64")
+	}
+	if len(sealed) < c.gcm.NonceSize() {
+		return "", errors.New("encrypted field is truncated")
+	}
+
+	nonceSize := c.gcm.NonceSize()
+	plaintext, err := c.gcm.Open(nil, sealed[:nonceSize], sealed[nonceSize:], []byte(prefix))
+	if err != nil {
+		return "", errors.New("wrong key or corrupted encrypted field")
+	}
+	return string(plaintext), nil
+}
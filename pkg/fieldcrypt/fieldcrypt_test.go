@@ -0,0 +1,52 @@
+package fieldcrypt
+
+import (
+	"bytes"
+	"encoding/base64"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func testKey(fill byte) []byte {
+	return bytes.Repeat([]byte{fill}, KeySize)
+}
+
+func TestEncryptDecrypt_RoundTrip(t *testing.T) {
+	c, err := NewCipher(testKey(1))
+	assert.NoError(t, err)
+
+	encrypted, err := c.Encrypt("4111-1111")
+	assert.NoError(t, err)
+	assert.True(t, IsEncrypted(encrypted))
+	assert.NotContains(t, encrypted, "4111")
+
+	decrypted, err := c.Decrypt(encrypted)
+	assert.NoError(t, err)
+	assert.Equal(t, "4111-1111", decrypted)
+}
+
+func TestDecrypt_PlaintextPassesThrough(t *testing.T) {
+	c, _ := NewCipher(testKey(1))
+
+	decrypted, err := c.Decrypt("written before encryption")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "written before encryption", decrypted)
+}
+
+func TestDecrypt_WrongKey(t *testing.T) {
+	c, _ := NewCipher(testKey(1))
+	other, _ := NewCipher(testKey(2))
+	encrypted, _ := c.Encrypt("secret")
+
+	_, err := other.Decrypt(encrypted)
+
+	assert.Error(t, err)
+}
+
+func TestNewCipherFromBase64_RejectsShortKey(t *testing.T) {
+	_, err := NewCipherFromBase64(base64.StdEncoding.EncodeToString([]byte("too short")))
+
+	assert.Error(t, err)
+}
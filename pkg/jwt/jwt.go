@@ -9,13 +9,21 @@ import (
 
 // JWTService defines the interface for JWT operations
 type JWTService interface {
-	GenerateToken(email string) (string, error)
+	GenerateToken(email, role string) (string, error)
 	ValidateToken(token string) (*Claims, error)
+	GenerateActionToken(purpose, email, role string, ttl time.Duration) (string, error)
+	GenerateOneTimeToken(purpose, id string, ttl time.Duration) (string, error)
+	ValidateActionToken(token, purpose string) (*Claims, error)
 }
 
 // Claims represents the JWT claims
 type Claims struct {
 	Email string `json:"email"`
+	// Role is the user's role in access tokens, for clients to adapt their UI;
+	// authorization uses the stored role. Invitations carry the role to assign.
+	Role string `json:"role,omitempty"`
+	// Purpose marks single-purpose action tokens; it is empty for access tokens
+	Purpose string `json:"purpose,omitempty"`
 	jwt.RegisteredClaims
 }
 
@@ -23,24 +31,68 @@ type Claims struct {
 type jwtService struct {
 	secretKey  string
 	expiration time.Duration
+	leeway     time.Duration
+	issuer     string
+	audience   string
+}
+
+// Option configures optional JWT service behavior
+type Option func(*jwtService)
+
+// WithLeeway tolerates clock skew between servers when checking exp, nbf and iat
+func WithLeeway(leeway time.Duration) Option {
+	return func(s *jwtService) {
+		s.leeway = leeway
+	}
+}
+
+// WithIssuer stamps tokens with the issuer and rejects tokens from any other issuer
+func WithIssuer(issuer string) Option {
+	return func(s *jwtService) {
+		s.issuer = issuer
+	}
+}
+
+// WithAudience stamps tokens with the audience and rejects tokens meant for any other audience
+func WithAudience(audience string) Option {
+	return func(s *jwtService) {
+		s.audience = audience
+	}
 }
 
 // NewJWTService creates a new JWT service instance
-func NewJWTService(secretKey string, expiration time.Duration) JWTService {
-	return &jwtService{
+func NewJWTService(secretKey string, expiration time.Duration, opts ...Option) JWTService {
+	s := &jwtService{
 		secretKey:  secretKey,
 		expiration: expiration,
 	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
 }
 
-// GenerateToken generates a new JWT token for the given email
-func (s *jwtService) GenerateToken(email string) (string, error) {
+// registeredClaims returns the standard claims for a token valid for ttl from now
+func (s *jwtService) registeredClaims(ttl time.Duration) jwt.RegisteredClaims {
+	now := time.Now()
+	claims := jwt.RegisteredClaims{
+		Issuer:    s.issuer,
+		ExpiresAt: jwt.NewNumericDate(now.Add(ttl)),
+		NotBefore: jwt.NewNumericDate(now),
+		IssuedAt:  jwt.NewNumericDate(now),
+	}
+	if s.audience != "" {
+		claims.Audience = jwt.ClaimStrings{s.audience}
+	}
+	return claims
+}
+
+// GenerateToken generates a new JWT access token for the given email and role
+func (s *jwtService) GenerateToken(email, role string) (string, error) {
 	claims := Claims{
-		Email: email,
-		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(time.Now().Add(s.expiration)),
-			IssuedAt:  jwt.NewNumericDate(time.Now()),
-		},
+		Email:            email,
+		Role:             role,
+		RegisteredClaims: s.registeredClaims(s.expiration),
 	}
 
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
@@ -52,14 +104,78 @@ func (s *jwtService) GenerateToken(email string) (string, error) {
 	return tokenString, nil
 }
 
-// ValidateToken validates the JWT token and returns the claims
+// ValidateToken validates the JWT access token and returns the claims
 func (s *jwtService) ValidateToken(tokenString string) (*Claims, error) {
+	claims, err := s.parse(tokenString)
+	if err != nil {
+		return nil, err
+	}
+
+	// Action tokens must not grant API access
+	if claims.Purpose != "" {
+		return nil, errors.New("invalid token")
+	}
+
+	return claims, nil
+}
+
+// GenerateActionToken generates a short-lived token that is only valid for the given purpose
+func (s *jwtService) GenerateActionToken(purpose, email, role string, ttl time.Duration) (string, error) {
+	claims := Claims{
+		Email:            email,
+		Role:             role,
+		Purpose:          purpose,
+		RegisteredClaims: s.registeredClaims(ttl),
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	return token.SignedString([]byte(s.secretKey))
+}
+
+// GenerateOneTimeToken generates an action token for the given purpose carrying
+// id as its jti claim. Callers record id to let the token be used only once.
+func (s *jwtService) GenerateOneTimeToken(purpose, id string, ttl time.Duration) (string, error) {
+	claims := Claims{
+		Purpose:          purpose,
+		RegisteredClaims: s.registeredClaims(ttl),
+	}
+	claims.ID = id
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	return token.SignedString([]byte(s.secretKey))
+}
+
+// ValidateActionToken validates a token issued by GenerateActionToken or
+// GenerateOneTimeToken for the given purpose
+func (s *jwtService) ValidateActionToken(tokenString, purpose string) (*Claims, error) {
+	claims, err := s.parse(tokenString)
+	if err != nil {
+		return nil, err
+	}
+
+	if claims.Purpose != purpose {
+		return nil, errors.New("invalid token")
+	}
+
+	return claims, nil
+}
+
+// parse verifies the signature, time claims and, when configured, the issuer and audience of a token
+func (s *jwtService) parse(tokenString string) (*Claims, error) {
+	parserOptions := []jwt.ParserOption{jwt.WithLeeway(s.leeway), jwt.WithIssuedAt()}
+	if s.issuer != "" {
+		parserOptions = append(parserOptions, jwt.WithIssuer(s.issuer))
+	}
+	if s.audience != "" {
+		parserOptions = append(parserOptions, jwt.WithAudience(s.audience))
+	}
+
 	token, err := jwt.ParseWithClaims(tokenString, &Claims{}, func(token *jwt.Token) (interface{}, error) {
 		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
 			return nil, errors.New("invalid signing method")
 		}
 		return []byte(s.secretKey), nil
-	})
+	}, parserOptions...)
 
 	if err != nil {
 		return nil, err
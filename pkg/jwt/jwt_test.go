@@ -0,0 +1,129 @@
+package jwt
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+)
+
+// signFromSkewedClock signs an access token as a server whose clock runs ahead by skew
+func signFromSkewedClock(t *testing.T, secret string, skew time.Duration) string {
+	now := time.Now().Add(skew)
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, Claims{
+		Email: "admin@example.com",
+		RegisteredClaims: jwt.RegisteredClaims{
+			ExpiresAt: jwt.NewNumericDate(now.Add(time.Hour)),
+			NotBefore: jwt.NewNumericDate(now),
+			IssuedAt:  jwt.NewNumericDate(now),
+		},
+	}).SignedString([]byte(secret))
+	if err != nil {
+		t.Fatalf("Failed to sign token: %v", err)
+	}
+	return token
+}
+
+func TestGenerateToken_PopulatesNotBefore(t *testing.T) {
+	// Arrange
+	service := NewJWTService("secret", time.Hour, WithIssuer("voucher-api"), WithAudience("admin-ui"))
+
+	// Act
+	token, err := service.GenerateToken("admin@example.com", "admin")
+	assert.NoError(t, err)
+	claims, err := service.ValidateToken(token)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.NotNil(t, claims.NotBefore)
+	assert.Equal(t, "voucher-api", claims.Issuer)
+	assert.Equal(t, jwt.ClaimStrings{"admin-ui"}, claims.Audience)
+}
+
+func TestGenerateToken_CarriesRole(t *testing.T) {
+	// Arrange
+	service := NewJWTService("secret", time.Hour)
+
+	// Act
+	token, _ := service.GenerateToken("viewer@example.com", "viewer")
+	claims, err := service.ValidateToken(token)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "viewer@example.com", claims.Email)
+	assert.Equal(t, "viewer", claims.Role)
+}
+
+func TestValidateToken_LeewayToleratesClockSkew(t *testing.T) {
+	// Arrange
+	token := signFromSkewedClock(t, "secret", 10*time.Second)
+	strict := NewJWTService("secret", time.Hour)
+	lenient := NewJWTService("secret", time.Hour, WithLeeway(30*time.Second))
+
+	// Act
+	_, strictErr := strict.ValidateToken(token)
+	_, lenientErr := lenient.ValidateToken(token)
+
+	// Assert
+	assert.Error(t, strictErr)
+	assert.NoError(t, lenientErr)
+}
+
+func TestValidateToken_RejectsOtherIssuer(t *testing.T) {
+	// Arrange
+	issuer := NewJWTService("secret", time.Hour, WithIssuer("staging"))
+	validator := NewJWTService("secret", time.Hour, WithIssuer("production"))
+	token, _ := issuer.GenerateToken("admin@example.com", "admin")
+
+	// Act
+	claims, err := validator.ValidateToken(token)
+
+	// Assert
+	assert.Error(t, err)
+	assert.Nil(t, claims)
+}
+
+func TestValidateToken_RejectsOtherAudience(t *testing.T) {
+	// Arrange
+	issuer := NewJWTService("secret", time.Hour, WithAudience("partner-a"))
+	validator := NewJWTService("secret", time.Hour, WithAudience("partner-b"))
+	token, _ := issuer.GenerateToken("admin@example.com", "admin")
+
+	// Act
+	claims, err := validator.ValidateToken(token)
+
+	// Assert
+	assert.Error(t, err)
+	assert.Nil(t, claims)
+}
+
+func TestValidateActionToken_ChecksAudience(t *testing.T) {
+	// Arrange
+	service := NewJWTService("secret", time.Hour, WithAudience("admin-ui"))
+	other := NewJWTService("secret", time.Hour)
+	token, _ := other.GenerateActionToken("invite", "new@example.com", "viewer", time.Hour)
+
+	// Act
+	claims, err := service.ValidateActionToken(token, "invite")
+
+	// Assert
+	assert.Error(t, err)
+	assert.Nil(t, claims)
+}
+
+func TestGenerateOneTimeToken_CarriesID(t *testing.T) {
+	// Arrange
+	service := NewJWTService("secret", time.Hour)
+
+	// Act
+	token, err := service.GenerateOneTimeToken("claim_voucher", "abc123", time.Hour)
+	assert.NoError(t, err)
+	claims, claimErr := service.ValidateActionToken(token, "claim_voucher")
+	_, accessErr := service.ValidateToken(token)
+
+	// Assert
+	assert.NoError(t, claimErr)
+	assert.Equal(t, "abc123", claims.ID)
+	assert.Error(t, accessErr)
+}
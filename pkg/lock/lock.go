@@ -0,0 +1,86 @@
+package lock
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+)
+
+// ErrNotAcquired is returned when the lock is held by someone else
+var ErrNotAcquired = errors.New("lock is held by another instance")
+
+// Lock is a held lock
+type Lock interface {
+	Release(ctx context.Context) error
+}
+
+// Locker hands out named locks that are exclusive across all replicas
+type Locker interface {
+	// TryAcquire takes the named lock without waiting; it returns
+	// ErrNotAcquired when another holder has it
+	TryAcquire(ctx context.Context, name string) (Lock, error)
+}
+
+// RunExclusive runs fn only if the named lock can be taken, so singleton jobs
+// execute once across replicas. It reports whether fn ran.
+func RunExclusive(ctx context.Context, locker Locker, name string, fn func(ctx context.Context) error) (bool, error) {
+	held, err := locker.TryAcquire(ctx, name)
+	if errors.Is(err, ErrNotAcquired) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	fnErr := fn(ctx)
+	if err := held.Release(context.WithoutCancel(ctx)); err != nil && fnErr == nil {
+		fnErr = err
+	}
+	return true, fnErr
+}
+
+// Metrics counts lock outcomes
+type Metrics struct {
+	acquired  atomic.Uint64
+	contended atomic.Uint64
+	failed    atomic.Uint64
+}
+
+// Stats is a point-in-time copy of Metrics
+type Stats struct {
+	Acquired  uint64
+	Contended uint64
+	Failed    uint64
+}
+
+// Snapshot returns the current counter values
+func (m *Metrics) Snapshot() Stats {
+	return Stats{
+		Acquired:  m.acquired.Load(),
+		Contended: m.contended.Load(),
+		Failed:    m.failed.Load(),
+	}
+}
+
+// WithMetrics wraps a locker so every acquisition attempt is counted
+func WithMetrics(locker Locker, metrics *Metrics) Locker {
+	return &instrumentedLocker{locker: locker, metrics: metrics}
+}
+
+type instrumentedLocker struct {
+	locker  Locker
+	metrics *Metrics
+}
+
+func (l *instrumentedLocker) TryAcquire(ctx context.Context, name string) (Lock, error) {
+	held, err := l.locker.TryAcquire(ctx, name)
+	switch {
+	case err == nil:
+		l.metrics.acquired.Add(1)
+	case errors.Is(err, ErrNotAcquired):
+		l.metrics.contended.Add(1)
+	default:
+		l.metrics.failed.Add(1)
+	}
+	return held, err
+}
@@ -0,0 +1,87 @@
+package lock
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMemoryLocker_Contention(t *testing.T) {
+	locker := NewMemoryLocker()
+	ctx := context.Background()
+
+	first, err := locker.TryAcquire(ctx, "expiry-sweeper")
+	assert.NoError(t, err)
+
+	_, err = locker.TryAcquire(ctx, "expiry-sweeper")
+	assert.ErrorIs(t, err, ErrNotAcquired)
+
+	// Other names are independent
+	other, err := locker.TryAcquire(ctx, "scheduled-export")
+	assert.NoError(t, err)
+	assert.NoError(t, other.Release(ctx))
+
+	assert.NoError(t, first.Release(ctx))
+
+	again, err := locker.TryAcquire(ctx, "expiry-sweeper")
+	assert.NoError(t, err)
+	assert.NoError(t, again.Release(ctx))
+}
+
+func TestRunExclusive_SingleExecution(t *testing.T) {
+	locker := NewMemoryLocker()
+	metrics := &Metrics{}
+	instrumented := WithMetrics(locker, metrics)
+
+	var runs atomic.Int32
+	var wg sync.WaitGroup
+	start := make(chan struct{})
+
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			<-start
+			_, err := RunExclusive(context.Background(), instrumented, "outbox-relay", func(ctx context.Context) error {
+				runs.Add(1)
+				time.Sleep(20 * time.Millisecond)
+				return nil
+			})
+			assert.NoError(t, err)
+		}()
+	}
+	close(start)
+	wg.Wait()
+
+	stats := metrics.Snapshot()
+	assert.Equal(t, int32(stats.Acquired), runs.Load())
+	assert.Equal(t, uint64(10), stats.Acquired+stats.Contended)
+	assert.GreaterOrEqual(t, stats.Contended, uint64(1))
+	assert.Zero(t, stats.Failed)
+}
+
+func TestRunExclusive_ReleasesOnError(t *testing.T) {
+	locker := NewMemoryLocker()
+	jobErr := errors.New("job failed")
+
+	ran, err := RunExclusive(context.Background(), locker, "job", func(ctx context.Context) error {
+		return jobErr
+	})
+
+	assert.True(t, ran)
+	assert.ErrorIs(t, err, jobErr)
+
+	held, err := locker.TryAcquire(context.Background(), "job")
+	assert.NoError(t, err)
+	assert.NoError(t, held.Release(context.Background()))
+}
+
+func TestAdvisoryKey_Stable(t *testing.T) {
+	assert.Equal(t, advisoryKey("expiry-sweeper"), advisoryKey("expiry-sweeper"))
+	assert.NotEqual(t, advisoryKey("expiry-sweeper"), advisoryKey("outbox-relay"))
+}
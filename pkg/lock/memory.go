@@ -0,0 +1,45 @@
+package lock
+
+import (
+	"context"
+	"sync"
+)
+
+// memoryLocker guards names within a single process; it is meant for tests
+// and single-instance deployments
+type memoryLocker struct {
+	mu   sync.Mutex
+	held map[string]bool
+}
+
+// NewMemoryLocker creates an in-process locker
+func NewMemoryLocker() Locker {
+	return &memoryLocker{held: make(map[string]bool)}
+}
+
+func (l *memoryLocker) TryAcquire(ctx context.Context, name string) (Lock, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.held[name] {
+		return nil, ErrNotAcquired
+	}
+	l.held[name] = true
+
+	return &memoryLock{locker: l, name: name}, nil
+}
+
+type memoryLock struct {
+	locker *memoryLocker
+	name   string
+	once   sync.Once
+}
+
+func (l *memoryLock) Release(ctx context.Context) error {
+	l.once.Do(func() {
+		l.locker.mu.Lock()
+		defer l.locker.mu.Unlock()
+		delete(l.locker.held, l.name)
+	})
+	return nil
+}
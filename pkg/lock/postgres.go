@@ -0,0 +1,62 @@
+package lock
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"hash/fnv"
+)
+
+// postgresLocker uses session-level advisory locks, held on a dedicated
+// connection until released
+type postgresLocker struct {
+	db *sql.DB
+}
+
+// NewPostgresLocker creates a locker backed by PostgreSQL advisory locks
+func NewPostgresLocker(db *sql.DB) Locker {
+	return &postgresLocker{db: db}
+}
+
+func (l *postgresLocker) TryAcquire(ctx context.Context, name string) (Lock, error) {
+	conn, err := l.db.Conn(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get connection for lock %s: %w", name, err)
+	}
+
+	key := advisoryKey(name)
+
+	var acquired bool
+	if err := conn.QueryRowContext(ctx, "SELECT pg_try_advisory_lock($1)", key).Scan(&acquired); err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("failed to acquire lock %s: %w", name, err)
+	}
+	if !acquired {
+		conn.Close()
+		return nil, ErrNotAcquired
+	}
+
+	return &postgresLock{conn: conn, key: key, name: name}, nil
+}
+
+type postgresLock struct {
+	conn *sql.Conn
+	key  int64
+	name string
+}
+
+func (l *postgresLock) Release(ctx context.Context) error {
+	defer l.conn.Close()
+
+	if _, err := l.conn.ExecContext(ctx, "SELECT pg_advisory_unlock($1)", l.key); err != nil {
+		return fmt.Errorf("failed to release lock %s: %w", l.name, err)
+	}
+	return nil
+}
+
+// advisoryKey maps a lock name to the 64-bit key advisory locks use
+func advisoryKey(name string) int64 {
+	hash := fnv.New64a()
+	hash.Write([]byte(name))
+	return int64(hash.Sum64())
+}
@@ -0,0 +1,76 @@
+// Package logger sets up structured logging with log/slog and carries a
+// request-scoped logger in contexts, so every line logged while handling a
+// request can be correlated with it.
+package logger
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"log/slog"
+	"strings"
+)
+
+// Output formats
+const (
+	FormatJSON = "json"
+	FormatText = "text"
+)
+
+type contextKey struct{}
+
+// New creates a logger writing lines in format at level and above. Levels
+// are debug, info, warn and error.
+func New(w io.Writer, format, level string) (*slog.Logger, error) {
+	var lvl slog.Level
+	if err := lvl.UnmarshalText([]byte(level)); err != nil {
+		return nil, fmt.Errorf("invalid log level %q", level)
+	}
+	options := &slog.HandlerOptions{Level: lvl}
+
+	switch strings.ToLower(format) {
+	case FormatJSON:
+		return slog.New(slog.NewJSONHandler(w, options)), nil
+	case FormatText:
+		return slog.New(slog.NewTextHandler(w, options)), nil
+	default:
+		return nil, fmt.Errorf("invalid log format %q, expected %s or %s", format, FormatJSON, FormatText)
+	}
+}
+
+// Setup makes a logger created by New the default. Lines written through
+// the standard log package then go through it too, at info level.
+func Setup(w io.Writer, format, level string) error {
+	l, err := New(w, format, level)
+	if err != nil {
+		return err
+	}
+	slog.SetDefault(l)
+	return nil
+}
+
+// WithLogger returns a copy of ctx carrying l
+func WithLogger(ctx context.Context, l *slog.Logger) context.Context {
+	return context.WithValue(ctx, contextKey{}, l)
+}
+
+// FromContext returns the logger carried by ctx, or the default logger when
+// there is none, such as outside a request
+func FromContext(ctx context.Context) *slog.Logger {
+	if ctx != nil {
+		if l, ok := ctx.Value(contextKey{}).(*slog.Logger); ok {
+			return l
+		}
+	}
+	return slog.Default()
+}
+
+// NewRequestID returns a random 32 character hex ID for a request
+func NewRequestID() string {
+	id := make([]byte, 16)
+	// crypto/rand.Read never returns an error
+	_, _ = rand.Read(id)
+	return hex.EncodeToString(id)
+}
@@ -0,0 +1,58 @@
+package logger
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNew(t *testing.T) {
+	// Arrange
+	var out bytes.Buffer
+
+	// Act
+	l, err := New(&out, "json", "warn")
+	_, formatErr := New(&out, "xml", "info")
+	_, levelErr := New(&out, "text", "verbose")
+
+	// Assert
+	assert.NoError(t, err)
+	l.Info("dropped")
+	l.Warn("kept", "attempts", 3)
+	var line map[string]interface{}
+	assert.NoError(t, json.Unmarshal(out.Bytes(), &line))
+	assert.Equal(t, "kept", line["msg"])
+	assert.Equal(t, "WARN", line["level"])
+	assert.Equal(t, float64(3), line["attempts"])
+	assert.Error(t, formatErr)
+	assert.Error(t, levelErr)
+}
+
+func TestFromContext(t *testing.T) {
+	// Arrange
+	var out bytes.Buffer
+	base := slog.New(slog.NewTextHandler(&out, nil)).With("request_id", "abc123")
+	ctx := WithLogger(context.Background(), base)
+
+	// Act
+	FromContext(ctx).Info("voucher created")
+	fallback := FromContext(context.Background())
+
+	// Assert
+	assert.Contains(t, out.String(), "request_id=abc123")
+	assert.Contains(t, out.String(), `msg="voucher created"`)
+	assert.Equal(t, slog.Default(), fallback)
+}
+
+func TestNewRequestID(t *testing.T) {
+	// Act
+	first, second := NewRequestID(), NewRequestID()
+
+	// Assert
+	assert.Len(t, first, 32)
+	assert.NotEqual(t, first, second)
+}
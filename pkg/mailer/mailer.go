@@ -0,0 +1,66 @@
+package mailer
+
+import (
+	"fmt"
+	"log/slog"
+	"net"
+	"net/smtp"
+	"strings"
+)
+
+// Mailer sends plain-text emails
+type Mailer interface {
+	Send(to, subject, body string) error
+}
+
+// smtpMailer delivers mail through an SMTP relay
+type smtpMailer struct {
+	addr string
+	auth smtp.Auth
+	from string
+}
+
+// NewSMTPMailer creates a mailer for the given SMTP server; auth is skipped when username is empty
+func NewSMTPMailer(host, port, username, password, from string) Mailer {
+	var auth smtp.Auth
+	if username != "" {
+		auth = smtp.PlainAuth("", username, password, host)
+	}
+
+	return &smtpMailer{
+		addr: net.JoinHostPort(host, port),
+		auth: auth,
+		from: from,
+	}
+}
+
+// Send delivers a plain-text email
+func (m *smtpMailer) Send(to, subject, body string) error {
+	headers := []string{
+		"From: " + m.from,
+		"To: " + to,
+		"Subject: " + subject,
+		"MIME-Version: 1.0",
+		"Content-Type: text/plain; charset=UTF-8",
+	}
+	message := strings.Join(headers, "\r\n") + "\r\n\r\n" + body
+
+	if err := smtp.SendMail(m.addr, m.auth, m.from, []string{to}, []byte(message)); err != nil {
+		return fmt.Errorf("failed to send email: %w", err)
+	}
+	return nil
+}
+
+// logMailer writes emails to the log instead of sending them, for local development
+type logMailer struct{}
+
+// NewLogMailer creates a mailer that only logs messages
+func NewLogMailer() Mailer {
+	return logMailer{}
+}
+
+// Send logs the email
+func (logMailer) Send(to, subject, body string) error {
+	slog.Info("email logged instead of sent", "to", to, "subject", subject, "body", body)
+	return nil
+}
@@ -0,0 +1,107 @@
+// Package metrics keeps gauges in memory and serves them in the Prometheus
+// text exposition format.
+package metrics
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// ContentType is the MIME type of the Prometheus text format
+const ContentType = "text/plain; version=0.0.4; charset=utf-8"
+
+// Gauge is a value that can go up and down, such as connections in use
+type Gauge struct {
+	name string
+	help string
+
+	mu    sync.Mutex
+	value float64
+}
+
+// Set replaces the gauge's value
+func (g *Gauge) Set(value float64) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.value = value
+}
+
+// Value returns the gauge's current value
+func (g *Gauge) Value() float64 {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.value
+}
+
+// Registry holds named gauges and serves them over HTTP
+type Registry struct {
+	mu     sync.Mutex
+	gauges map[string]*Gauge
+}
+
+// NewRegistry creates an empty registry
+func NewRegistry() *Registry {
+	return &Registry{gauges: map[string]*Gauge{}}
+}
+
+// Gauge returns the gauge with the given name, registering it on first use
+func (r *Registry) Gauge(name, help string) *Gauge {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if gauge, ok := r.gauges[name]; ok {
+		return gauge
+	}
+	gauge := &Gauge{name: name, help: help}
+	r.gauges[name] = gauge
+	return gauge
+}
+
+// WriteText writes every gauge, sorted by name, in the Prometheus text format
+func (r *Registry) WriteText(w io.Writer) error {
+	r.mu.Lock()
+	gauges := make([]*Gauge, 0, len(r.gauges))
+	for _, gauge := range r.gauges {
+		gauges = append(gauges, gauge)
+	}
+	r.mu.Unlock()
+
+	sort.Slice(gauges, func(i, j int) bool { return gauges[i].name < gauges[j].name })
+
+	for _, gauge := range gauges {
+		if _, err := fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s gauge\n%s %s\n",
+			gauge.name, escapeHelp(gauge.help), gauge.name, gauge.name, formatValue(gauge.Value())); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ServeHTTP serves the registry to a Prometheus scraper
+func (r *Registry) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", ContentType)
+	_ = r.WriteText(w)
+}
+
+// formatValue renders a sample value, spelling infinities and NaN the way Prometheus expects
+func formatValue(value float64) string {
+	switch {
+	case math.IsInf(value, 1):
+		return "+Inf"
+	case math.IsInf(value, -1):
+		return "-Inf"
+	case math.IsNaN(value):
+		return "NaN"
+	}
+	return strconv.FormatFloat(value, 'g', -1, 64)
+}
+
+func escapeHelp(help string) string {
+	return strings.NewReplacer(`\`, `\\`, "\n", `\n`).Replace(help)
+}
@@ -0,0 +1,48 @@
+package metrics
+
+import (
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRegistry_ServesGaugesSortedByName(t *testing.T) {
+	// Arrange
+	registry := NewRegistry()
+	registry.Gauge("db_pool_in_use_connections", "Connections in use").Set(3)
+	registry.Gauge("db_pool_idle_connections", "Idle connections").Set(7)
+	w := httptest.NewRecorder()
+
+	// Act
+	registry.ServeHTTP(w, httptest.NewRequest("GET", "/metrics", nil))
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, ContentType, w.Header().Get("Content-Type"))
+	assert.Equal(t, "# HELP db_pool_idle_connections Idle connections\n"+
+		"# TYPE db_pool_idle_connections gauge\n"+
+		"db_pool_idle_connections 7\n"+
+		"# HELP db_pool_in_use_connections Connections in use\n"+
+		"# TYPE db_pool_in_use_connections gauge\n"+
+		"db_pool_in_use_connections 3\n", w.Body.String())
+}
+
+func TestRegistry_GaugeReturnsExistingGauge(t *testing.T) {
+	registry := NewRegistry()
+
+	first := registry.Gauge("waits", "Waits")
+	first.Set(1.5)
+
+	assert.Same(t, first, registry.Gauge("waits", "Waits"))
+	assert.Equal(t, 1.5, registry.Gauge("waits", "Waits").Value())
+}
+
+func TestFormatValue(t *testing.T) {
+	assert.Equal(t, "0.25", formatValue(0.25))
+	assert.Equal(t, "100", formatValue(100))
+	assert.Equal(t, "+Inf", formatValue(math.Inf(1)))
+	assert.Equal(t, "NaN", formatValue(math.NaN()))
+}
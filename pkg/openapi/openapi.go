@@ -0,0 +1,339 @@
+package openapi
+
+import (
+	"encoding/json"
+	"reflect"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// Param describes a path or query parameter of an operation
+type Param struct {
+	Name        string
+	In          string
+	Type        string
+	Required    bool
+	Description string
+}
+
+// Operation describes a single documented API operation
+type Operation struct {
+	Method  string
+	Path    string
+	Summary string
+	Tag     string
+	Secured bool
+	// Security names the security scheme of a secured operation; it defaults to BearerAuth
+	Security    string
+	Params      []Param
+	RequestBody interface{}
+	Multipart   bool
+	Response    interface{}
+	Produces    string
+	// NoBody marks operations that answer with headers only, such as HEAD and OPTIONS
+	NoBody bool
+}
+
+// Info holds the top-level metadata of the spec
+type Info struct {
+	Title       string
+	Version     string
+	Description string
+}
+
+var pathParamPattern = regexp.MustCompile(`:([A-Za-z_]+)`)
+
+// ToOpenAPIPath converts a gin route path (/vouchers/:id) to OpenAPI form (/vouchers/{id})
+func ToOpenAPIPath(path string) string {
+	return pathParamPattern.ReplaceAllString(path, "{$1}")
+}
+
+// Build generates an OpenAPI 3 document for the given operations.
+// Request and response schemas are derived from the Go types by reflection,
+// using their json tags, so the spec follows the DTOs as they change.
+func Build(info Info, envelope interface{}, operations []Operation) map[string]interface{} {
+	b := &builder{schemas: map[string]interface{}{}}
+
+	envelopeRef := b.ref(reflect.TypeOf(envelope))
+
+	paths := map[string]interface{}{}
+	for _, op := range operations {
+		path := ToOpenAPIPath(op.Path)
+		item, ok := paths[path].(map[string]interface{})
+		if !ok {
+			item = map[string]interface{}{}
+			paths[path] = item
+		}
+		item[strings.ToLower(op.Method)] = b.operation(op, envelopeRef)
+	}
+
+	return map[string]interface{}{
+		"openapi": "3.0.3",
+		"info": map[string]interface{}{
+			"title":       info.Title,
+			"version":     info.Version,
+			"description": info.Description,
+		},
+		"paths": paths,
+		"components": map[string]interface{}{
+			"schemas": b.schemas,
+			"securitySchemes": map[string]interface{}{
+				"BearerAuth": map[string]interface{}{
+					"type":         "http",
+					"scheme":       "bearer",
+					"bearerFormat": "JWT",
+				},
+				"PartnerAPIKey": map[string]interface{}{
+					"type": "apiKey",
+					"in":   "header",
+					"name": "X-API-Key",
+				},
+			},
+		},
+	}
+}
+
+type builder struct {
+	schemas map[string]interface{}
+}
+
+func (b *builder) operation(op Operation, envelopeRef map[string]interface{}) map[string]interface{} {
+	result := map[string]interface{}{
+		"summary":     op.Summary,
+		"operationId": operationID(op),
+		"tags":        []string{op.Tag},
+	}
+
+	var params []interface{}
+	for _, param := range pathParams(op.Path) {
+		params = append(params, map[string]interface{}{
+			"name":     param,
+			"in":       "path",
+			"required": true,
+			"schema":   map[string]interface{}{"type": "string"},
+		})
+	}
+	for _, param := range op.Params {
+		paramType := param.Type
+		if paramType == "" {
+			paramType = "string"
+		}
+		params = append(params, map[string]interface{}{
+			"name":        param.Name,
+			"in":          param.In,
+			"required":    param.Required,
+			"description": param.Description,
+			"schema":      map[string]interface{}{"type": paramType},
+		})
+	}
+	if len(params) > 0 {
+		result["parameters"] = params
+	}
+
+	if op.Multipart {
+		result["requestBody"] = map[string]interface{}{
+			"required": true,
+			"content": map[string]interface{}{
+				"multipart/form-data": map[string]interface{}{
+					"schema": map[string]interface{}{
+						"type": "object",
+						"properties": map[string]interface{}{
+							"file": map[string]interface{}{"type": "string", "format": "binary"},
+						},
+					},
+				},
+			},
+		}
+	} else if op.RequestBody != nil {
+		result["requestBody"] = map[string]interface{}{
+			"required": true,
+			"content": map[string]interface{}{
+				"application/json": map[string]interface{}{
+					"schema": b.ref(reflect.TypeOf(op.RequestBody)),
+				},
+			},
+		}
+	}
+
+	successSchema := envelopeRef
+	if op.Response != nil {
+		successSchema = map[string]interface{}{
+			"allOf": []interface{}{
+				envelopeRef,
+				map[string]interface{}{
+					"type": "object",
+					"properties": map[string]interface{}{
+						"data": b.schema(reflect.TypeOf(op.Response)),
+					},
+				},
+			},
+		}
+	}
+
+	produces := op.Produces
+	if produces == "" {
+		produces = "application/json"
+	}
+	successContent := map[string]interface{}{"schema": successSchema}
+	if produces != "application/json" {
+		successContent = map[string]interface{}{
+			"schema": map[string]interface{}{"type": "string", "format": "binary"},
+		}
+	}
+
+	success := map[string]interface{}{
+		"description": "Successful response",
+		"content":     map[string]interface{}{produces: successContent},
+	}
+	if op.NoBody {
+		success = map[string]interface{}{"description": "Successful response without a body"}
+	}
+
+	responses := map[string]interface{}{
+		"200": success,
+		"default": map[string]interface{}{
+			"description": "Error response",
+			"content": map[string]interface{}{
+				"application/json": map[string]interface{}{"schema": envelopeRef},
+			},
+		},
+	}
+	if op.Secured {
+		responses["401"] = map[string]interface{}{
+			"description": "Missing or invalid token",
+			"content": map[string]interface{}{
+				"application/json": map[string]interface{}{"schema": envelopeRef},
+			},
+		}
+		scheme := op.Security
+		if scheme == "" {
+			scheme = "BearerAuth"
+		}
+		result["security"] = []interface{}{
+			map[string]interface{}{scheme: []string{}},
+		}
+	}
+	result["responses"] = responses
+
+	return result
+}
+
+// ref registers the named struct type as a component and returns a reference to it
+func (b *builder) ref(t reflect.Type) map[string]interface{} {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct || t.Name() == "" || t == reflect.TypeOf(time.Time{}) {
+		return b.schema(t)
+	}
+
+	name := t.Name()
+	if _, ok := b.schemas[name]; !ok {
+		// Reserve the name first so recursive types terminate
+		b.schemas[name] = map[string]interface{}{}
+		b.schemas[name] = b.structSchema(t)
+	}
+
+	return map[string]interface{}{"$ref": "#/components/schemas/" + name}
+}
+
+// schema returns the inline schema for a Go type
+func (b *builder) schema(t reflect.Type) map[string]interface{} {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t == reflect.TypeOf(json.RawMessage{}) {
+		// Arbitrary embedded JSON
+		return map[string]interface{}{}
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		return map[string]interface{}{"type": "string"}
+	case reflect.Bool:
+		return map[string]interface{}{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]interface{}{"type": "integer"}
+	case reflect.Float32, reflect.Float64:
+		return map[string]interface{}{"type": "number"}
+	case reflect.Slice, reflect.Array:
+		return map[string]interface{}{"type": "array", "items": b.ref(t.Elem())}
+	case reflect.Map:
+		return map[string]interface{}{"type": "object", "additionalProperties": b.ref(t.Elem())}
+	case reflect.Struct:
+		if t == reflect.TypeOf(time.Time{}) {
+			return map[string]interface{}{"type": "string", "format": "date-time"}
+		}
+		if t.Name() != "" {
+			return b.ref(t)
+		}
+		return b.structSchema(t)
+	default:
+		return map[string]interface{}{}
+	}
+}
+
+func (b *builder) structSchema(t reflect.Type) map[string]interface{} {
+	properties := map[string]interface{}{}
+	var required []string
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		name := jsonName(field)
+		if name == "-" {
+			continue
+		}
+
+		properties[name] = b.schema(field.Type)
+		if strings.Contains(field.Tag.Get("binding"), "required") {
+			required = append(required, name)
+		}
+	}
+
+	schema := map[string]interface{}{
+		"type":       "object",
+		"properties": properties,
+	}
+	if len(required) > 0 {
+		schema["required"] = required
+	}
+	return schema
+}
+
+// jsonName returns the JSON property name of a struct field
+func jsonName(field reflect.StructField) string {
+	name := strings.Split(field.Tag.Get("json"), ",")[0]
+	if name == "" {
+		return field.Name
+	}
+	return name
+}
+
+// pathParams returns the names of the :params in a gin route path
+func pathParams(path string) []string {
+	var params []string
+	for _, match := range pathParamPattern.FindAllStringSubmatch(path, -1) {
+		params = append(params, match[1])
+	}
+	return params
+}
+
+// operationID derives a stable operation identifier from the method and path
+func operationID(op Operation) string {
+	var parts []string
+	for _, segment := range strings.Split(op.Path, "/") {
+		if segment == "" || segment == "api" || segment == "v1" {
+			continue
+		}
+		segment = strings.TrimPrefix(segment, ":")
+		segment = strings.ReplaceAll(segment, "-", "_")
+		parts = append(parts, segment)
+	}
+	return strings.ToLower(op.Method) + "_" + strings.Join(parts, "_")
+}
@@ -0,0 +1,188 @@
+package password
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// Supported hashing algorithms
+const (
+	AlgorithmBcrypt   = "bcrypt"
+	AlgorithmArgon2id = "argon2id"
+)
+
+// Argon2id salt and key lengths in bytes
+const (
+	argon2SaltLength = 16
+	argon2KeyLength  = 32
+)
+
+// ErrUnknownFormat is returned when a stored hash was not produced by a supported algorithm
+var ErrUnknownFormat = errors.New("unknown password hash format")
+
+// Hasher hashes passwords with the configured algorithm and verifies hashes
+// produced by any supported algorithm, so stored hashes keep working after
+// the algorithm or its cost changes.
+type Hasher interface {
+	// Hash returns the encoded hash of password
+	Hash(password string) (string, error)
+	// Verify reports whether password matches the encoded hash
+	Verify(password, encoded string) (bool, error)
+	// NeedsRehash reports whether the encoded hash was made with another algorithm or other parameters
+	NeedsRehash(encoded string) bool
+}
+
+// Config selects the algorithm used for new hashes and its parameters
+type Config struct {
+	Algorithm         string
+	BcryptCost        int
+	Argon2Memory      uint32 // KiB
+	Argon2Iterations  uint32
+	Argon2Parallelism uint8
+}
+
+// DefaultConfig returns bcrypt at its default cost, with the OWASP-recommended argon2id parameters
+func DefaultConfig() Config {
+	return Config{
+		Algorithm:         AlgorithmBcrypt,
+		BcryptCost:        bcrypt.DefaultCost,
+		Argon2Memory:      19 * 1024,
+		Argon2Iterations:  2,
+		Argon2Parallelism: 1,
+	}
+}
+
+type hasher struct {
+	cfg Config
+}
+
+// NewHasher creates a hasher from config
+func NewHasher(cfg Config) (Hasher, error) {
+	switch cfg.Algorithm {
+	case AlgorithmBcrypt:
+		if cfg.BcryptCost < bcrypt.MinCost || cfg.BcryptCost > bcrypt.MaxCost {
+			return nil, fmt.Errorf("bcrypt cost must be between %d and %d, got %d", bcrypt.MinCost, bcrypt.MaxCost, cfg.BcryptCost)
+		}
+	case AlgorithmArgon2id:
+		if cfg.Argon2Memory == 0 || cfg.Argon2Iterations == 0 || cfg.Argon2Parallelism == 0 {
+			return nil, errors.New("argon2id memory, iterations and parallelism must be greater than 0")
+		}
+	default:
+		return nil, fmt.Errorf("unsupported password hash algorithm '%s'", cfg.Algorithm)
+	}
+
+	return &hasher{cfg: cfg}, nil
+}
+
+// Hash returns a bcrypt hash, or an argon2id hash in the PHC string format
+// "$argon2id$v=19$m=<memory>,t=<iterations>,p=<parallelism>$<salt>$<key>"
+func (h *hasher) Hash(password string) (string, error) {
+	if h.cfg.Algorithm == AlgorithmBcrypt {
+		hashed, err := bcrypt.GenerateFromPassword([]byte(password), h.cfg.BcryptCost)
+		if err != nil {
+			return "", err
+		}
+		return string(hashed), nil
+	}
+
+	salt := make([]byte, argon2SaltLength)
+	if _, err := rand.Read(salt); err != nil {
+		return "", err
+	}
+	params := argon2Params{
+		memory:      h.cfg.Argon2Memory,
+		iterations:  h.cfg.Argon2Iterations,
+		parallelism: h.cfg.Argon2Parallelism,
+	}
+	key := argon2.IDKey([]byte(password), salt, params.iterations, params.memory, params.parallelism, argon2KeyLength)
+
+	return fmt.Sprintf("$argon2id$v=%d$m=%d,t=%d,p=%d$%s$%s",
+		argon2.Version, params.memory, params.iterations, params.parallelism,
+		base64.RawStdEncoding.EncodeToString(salt), base64.RawStdEncoding.EncodeToString(key)), nil
+}
+
+// Verify checks password against a bcrypt or argon2id hash
+func (h *hasher) Verify(password, encoded string) (bool, error) {
+	if isBcrypt(encoded) {
+		err := bcrypt.CompareHashAndPassword([]byte(encoded), []byte(password))
+		if errors.Is(err, bcrypt.ErrMismatchedHashAndPassword) {
+			return false, nil
+		}
+		return err == nil, err
+	}
+
+	params, salt, key, err := decodeArgon2id(encoded)
+	if err != nil {
+		return false, err
+	}
+	candidate := argon2.IDKey([]byte(password), salt, params.iterations, params.memory, params.parallelism, uint32(len(key)))
+	return subtle.ConstantTimeCompare(candidate, key) == 1, nil
+}
+
+// NeedsRehash reports whether the hash differs from what Hash would produce now.
+// Unreadable hashes are left alone; they fail verification instead.
+func (h *hasher) NeedsRehash(encoded string) bool {
+	if isBcrypt(encoded) {
+		if h.cfg.Algorithm != AlgorithmBcrypt {
+			return true
+		}
+		cost, err := bcrypt.Cost([]byte(encoded))
+		return err == nil && cost != h.cfg.BcryptCost
+	}
+
+	params, _, _, err := decodeArgon2id(encoded)
+	if err != nil {
+		return false
+	}
+	return h.cfg.Algorithm != AlgorithmArgon2id ||
+		params.memory != h.cfg.Argon2Memory ||
+		params.iterations != h.cfg.Argon2Iterations ||
+		params.parallelism != h.cfg.Argon2Parallelism
+}
+
+type argon2Params struct {
+	memory      uint32
+	iterations  uint32
+	parallelism uint8
+}
+
+func isBcrypt(encoded string) bool {
+	return strings.HasPrefix(encoded, "$2a$") || strings.HasPrefix(encoded, "$2b$") || strings.HasPrefix(encoded, "$2y$")
+}
+
+func decodeArgon2id(encoded string) (argon2Params, []byte, []byte, error) {
+	var params argon2Params
+
+	// "", "argon2id", "v=19", "m=..,t=..,p=..", salt, key
+	parts := strings.Split(encoded, "$")
+	if len(parts) != 6 || parts[0] != "" || parts[1] != AlgorithmArgon2id {
+		return params, nil, nil, ErrUnknownFormat
+	}
+
+	var version int
+	if _, err := fmt.Sscanf(parts[2], "v=%d", &version); err != nil || version != argon2.Version {
+		return params, nil, nil, fmt.Errorf("unsupported argon2id version '%s'", parts[2])
+	}
+	_, err := fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &params.memory, &params.iterations, &params.parallelism)
+	if err != nil || params.memory == 0 || params.iterations == 0 || params.parallelism == 0 {
+		return params, nil, nil, fmt.Errorf("invalid argon2id parameters '%s'", parts[3])
+	}
+
+	salt, err := base64.RawStdEncoding.DecodeString(parts[4])
+	if err != nil {
+		return params, nil, nil, fmt.Errorf("invalid argon2id salt: %w", err)
+	}
+	key, err := base64.RawStdEncoding.DecodeString(parts[5])
+	if err != nil || len(key) == 0 {
+		return params, nil, nil, errors.New("invalid argon2id key")
+	}
+
+	return params, salt, key, nil
+}
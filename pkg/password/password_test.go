@@ -0,0 +1,109 @@
+package password
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/crypto/bcrypt"
+)
+
+func bcryptConfig(cost int) Config {
+	cfg := DefaultConfig()
+	cfg.BcryptCost = cost
+	return cfg
+}
+
+func argon2idConfig(iterations uint32) Config {
+	cfg := DefaultConfig()
+	cfg.Algorithm = AlgorithmArgon2id
+	cfg.Argon2Memory = 64
+	cfg.Argon2Iterations = iterations
+	return cfg
+}
+
+func TestNewHasher_InvalidConfig(t *testing.T) {
+	_, err := NewHasher(Config{Algorithm: "md5"})
+	assert.Error(t, err)
+
+	_, err = NewHasher(bcryptConfig(bcrypt.MaxCost + 1))
+	assert.Error(t, err)
+
+	cfg := argon2idConfig(1)
+	cfg.Argon2Parallelism = 0
+	_, err = NewHasher(cfg)
+	assert.Error(t, err)
+}
+
+func TestHasher_Bcrypt_RoundTrip(t *testing.T) {
+	h, err := NewHasher(bcryptConfig(bcrypt.MinCost))
+	assert.NoError(t, err)
+
+	hashed, err := h.Hash("secret123")
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(hashed, "$2a$"))
+
+	ok, err := h.Verify("secret123", hashed)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+
+	ok, err = h.Verify("wrong", hashed)
+	assert.NoError(t, err)
+	assert.False(t, ok)
+	assert.False(t, h.NeedsRehash(hashed))
+}
+
+func TestHasher_Argon2id_RoundTrip(t *testing.T) {
+	h, err := NewHasher(argon2idConfig(1))
+	assert.NoError(t, err)
+
+	hashed, err := h.Hash("secret123")
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(hashed, "$argon2id$v=19$m=64,t=1,p=1$"))
+
+	ok, err := h.Verify("secret123", hashed)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+
+	ok, err = h.Verify("wrong", hashed)
+	assert.NoError(t, err)
+	assert.False(t, ok)
+	assert.False(t, h.NeedsRehash(hashed))
+}
+
+func TestHasher_VerifiesOtherAlgorithm(t *testing.T) {
+	bcryptHasher, _ := NewHasher(bcryptConfig(bcrypt.MinCost))
+	argon2Hasher, _ := NewHasher(argon2idConfig(1))
+	legacy, _ := bcryptHasher.Hash("secret123")
+
+	ok, err := argon2Hasher.Verify("secret123", legacy)
+
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.True(t, argon2Hasher.NeedsRehash(legacy))
+}
+
+func TestHasher_NeedsRehash_ParametersChanged(t *testing.T) {
+	oldBcrypt, _ := NewHasher(bcryptConfig(bcrypt.MinCost))
+	newBcrypt, _ := NewHasher(bcryptConfig(bcrypt.MinCost + 1))
+	bcryptHash, _ := oldBcrypt.Hash("secret123")
+	assert.True(t, newBcrypt.NeedsRehash(bcryptHash))
+
+	oldArgon2, _ := NewHasher(argon2idConfig(1))
+	newArgon2, _ := NewHasher(argon2idConfig(2))
+	argon2Hash, _ := oldArgon2.Hash("secret123")
+	assert.True(t, newArgon2.NeedsRehash(argon2Hash))
+	assert.True(t, oldBcrypt.NeedsRehash(argon2Hash))
+}
+
+func TestHasher_Verify_UnknownFormat(t *testing.T) {
+	h, _ := NewHasher(DefaultConfig())
+
+	ok, err := h.Verify("secret123", "plaintext")
+	assert.ErrorIs(t, err, ErrUnknownFormat)
+	assert.False(t, ok)
+	assert.False(t, h.NeedsRehash("plaintext"))
+
+	_, err = h.Verify("secret123", "$argon2id$v=19$m=0,t=1,p=1$c2FsdA$a2V5")
+	assert.Error(t, err)
+}
@@ -0,0 +1,131 @@
+// Package pdf writes simple PDF documents made of text and filled rectangles.
+//
+// Text is set in the standard Helvetica fonts every PDF reader provides, so
+// nothing is embedded; characters outside Latin-1 are printed as '?'.
+package pdf
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+)
+
+// Fonts available to Page.Text
+const (
+	Regular = "F1"
+	Bold    = "F2"
+)
+
+// Page sizes in points
+const (
+	A4Width  = 595
+	A4Height = 842
+	A6Width  = 298
+	A6Height = 420
+)
+
+// Document is a PDF document being built
+type Document struct {
+	width, height float64
+	pages         []*Page
+}
+
+// Page is one page of a document; coordinates are in points from the bottom left corner
+type Page struct {
+	content bytes.Buffer
+}
+
+// New creates an empty document whose pages are width by height points
+func New(width, height float64) *Document {
+	return &Document{width: width, height: height}
+}
+
+// AddPage appends a blank page and returns it
+func (d *Document) AddPage() *Page {
+	page := &Page{}
+	d.pages = append(d.pages, page)
+	return page
+}
+
+// Text draws a line of text with its baseline starting at x, y
+func (p *Page) Text(x, y float64, font string, size float64, text string) {
+	fmt.Fprintf(&p.content, "BT /%s %s Tf %s %s Td (%s) Tj ET\n", font, number(size), number(x), number(y), escape(text))
+}
+
+// Rect fills a black rectangle with its bottom left corner at x, y
+func (p *Page) Rect(x, y, width, height float64) {
+	fmt.Fprintf(&p.content, "%s %s %s %s re f\n", number(x), number(y), number(width), number(height))
+}
+
+// StrokeRect outlines a rectangle with a line of the given width
+func (p *Page) StrokeRect(x, y, width, height, lineWidth float64) {
+	fmt.Fprintf(&p.content, "%s w %s %s %s %s re S\n", number(lineWidth), number(x), number(y), number(width), number(height))
+}
+
+// Bytes renders the document
+func (d *Document) Bytes() []byte {
+	var out bytes.Buffer
+	var offsets []int
+	object := func(body string) {
+		offsets = append(offsets, out.Len())
+		fmt.Fprintf(&out, "%d 0 obj\n%s\nendobj\n", len(offsets), body)
+	}
+
+	out.WriteString("%PDF-1.4\n%\xe2\xe3\xcf\xd3\n")
+
+	// Objects 1 to 4 are the catalog, page tree and fonts; each page then takes two objects
+	kids := make([]string, len(d.pages))
+	for i := range d.pages {
+		kids[i] = fmt.Sprintf("%d 0 R", 5+2*i)
+	}
+	object("<< /Type /Catalog /Pages 2 0 R >>")
+	object(fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(d.pages)))
+	object("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding >>")
+	object("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica-Bold /Encoding /WinAnsiEncoding >>")
+	for i, page := range d.pages {
+		object(fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %s %s] /Resources << /Font << /%s 3 0 R /%s 4 0 R >> >> /Contents %d 0 R >>",
+			number(d.width), number(d.height), Regular, Bold, 6+2*i))
+		object(fmt.Sprintf("<< /Length %d >>\nstream\n%sendstream", page.content.Len(), page.content.String()))
+	}
+
+	xref := out.Len()
+	fmt.Fprintf(&out, "xref\n0 %d\n0000000000 65535 f \n", len(offsets)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&out, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&out, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(offsets)+1, xref)
+	return out.Bytes()
+}
+
+// TextWidth estimates the width of text in points. Helvetica averages a
+// little over half the font size per character, which is close enough for
+// wrapping and centering short lines.
+func TextWidth(text string, size float64) float64 {
+	return float64(len([]rune(text))) * size * 0.55
+}
+
+// number formats a coordinate without a trailing ".00"
+func number(value float64) string {
+	s := fmt.Sprintf("%.2f", value)
+	s = strings.TrimRight(s, "0")
+	return strings.TrimSuffix(s, ".")
+}
+
+// escape encodes text as the body of a PDF literal string in WinAnsiEncoding
+func escape(text string) string {
+	var b strings.Builder
+	for _, r := range text {
+		switch {
+		case r == '(' || r == ')' || r == '\\':
+			b.WriteByte('\\')
+			b.WriteRune(r)
+		case r >= 0x20 && r < 0x7f:
+			b.WriteRune(r)
+		case r >= 0xa0 && r <= 0xff:
+			fmt.Fprintf(&b, "\\%03o", r)
+		default:
+			b.WriteByte('?')
+		}
+	}
+	return b.String()
+}
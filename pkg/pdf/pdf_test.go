@@ -0,0 +1,60 @@
+package pdf
+
+import (
+	"bytes"
+	"regexp"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBytes_CrossReferenceOffsets(t *testing.T) {
+	// Arrange
+	doc := New(A6Width, A6Height)
+	doc.AddPage().Text(20, 380, Bold, 18, "SUMMER2024")
+	doc.AddPage().Rect(20, 20, 10, 10)
+
+	// Act
+	out := doc.Bytes()
+
+	// Assert
+	assert.True(t, bytes.HasPrefix(out, []byte("%PDF-1.4\n")))
+	assert.True(t, bytes.HasSuffix(out, []byte("%%EOF\n")))
+
+	startxref := regexp.MustCompile(`startxref\n(\d+)\n`).FindSubmatch(out)
+	xref, _ := strconv.Atoi(string(startxref[1]))
+	assert.True(t, bytes.HasPrefix(out[xref:], []byte("xref\n0 9\n")))
+
+	entries := regexp.MustCompile(`(\d{10}) 00000 n \n`).FindAllSubmatch(out[xref:], -1)
+	assert.Len(t, entries, 8)
+	for i, entry := range entries {
+		offset, _ := strconv.Atoi(string(entry[1]))
+		assert.True(t, bytes.HasPrefix(out[offset:], []byte(strconv.Itoa(i+1)+" 0 obj\n")), "object %d", i+1)
+	}
+	assert.Contains(t, string(out), "/Count 2")
+}
+
+func TestText_EscapesSpecialCharacters(t *testing.T) {
+	// Arrange
+	page := &Page{}
+
+	// Act
+	page.Text(0, 0, Regular, 10, `50% (café) \ ok ✓`)
+
+	// Assert
+	assert.Equal(t, "BT /F1 10 Tf 0 0 Td (50% \\(caf\\351\\) \\\\ ok ?) Tj ET\n", page.content.String())
+}
+
+func TestBytes_StreamLength(t *testing.T) {
+	// Arrange
+	doc := New(A4Width, A4Height)
+	page := doc.AddPage()
+	page.Rect(1.5, 2.25, 3, 4)
+
+	// Act
+	out := string(doc.Bytes())
+
+	// Assert
+	assert.Contains(t, out, "<< /Length 18 >>\nstream\n1.5 2.25 3 4 re f\nendstream")
+}
@@ -0,0 +1,51 @@
+package pixelfont
+
+import (
+	"image"
+	"image/color"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGlyphs_AreFiveBySeven(t *testing.T) {
+	for r, glyph := range glyphs {
+		for _, line := range glyph {
+			assert.Len(t, line, GlyphWidth, "glyph %q", r)
+		}
+	}
+}
+
+func TestWidth(t *testing.T) {
+	assert.Equal(t, 0, Width("", 3))
+	assert.Equal(t, 5, Width("A", 1))
+	assert.Equal(t, 33, Width("AB", 3))
+}
+
+func TestDraw_ScalesGlyphPixels(t *testing.T) {
+	// Arrange
+	img := image.NewGray(image.Rect(0, 0, 20, 20))
+
+	// Act
+	Draw(img, 1, 2, 2, color.White, "-")
+
+	// Assert: the bar of '-' is row 3, drawn as rows 8-9 at scale 2
+	assert.Equal(t, uint8(255), img.GrayAt(1, 8).Y)
+	assert.Equal(t, uint8(255), img.GrayAt(10, 9).Y)
+	assert.Equal(t, uint8(0), img.GrayAt(11, 9).Y)
+	assert.Equal(t, uint8(0), img.GrayAt(1, 7).Y)
+}
+
+func TestDraw_LowerCaseAndUnknownCharacters(t *testing.T) {
+	upper := image.NewGray(image.Rect(0, 0, 12, 7))
+	lower := image.NewGray(image.Rect(0, 0, 12, 7))
+	Draw(upper, 0, 0, 1, color.White, "A?")
+	Draw(lower, 0, 0, 1, color.White, "a€")
+
+	assert.Equal(t, upper.Pix, lower.Pix)
+}
+
+func TestFit(t *testing.T) {
+	assert.Equal(t, "SUMMER", Fit("SUMMER", 1, 100))
+	assert.Equal(t, "SUM...", Fit("SUMMER SALE", 1, Width("SUM...", 1)))
+}
@@ -0,0 +1,30 @@
+package preflight
+
+import (
+	"fmt"
+	"io"
+)
+
+// Check is one named startup precondition; Run returns why it does not hold
+type Check struct {
+	Name string
+	Run  func() error
+}
+
+// Run executes the checks in order, writes a PASS or FAIL line for each to w,
+// and reports whether all of them passed. Later checks run even after a
+// failure, so one report lists every problem.
+func Run(w io.Writer, checks []Check) bool {
+	passed := 0
+	for _, check := range checks {
+		if err := check.Run(); err != nil {
+			fmt.Fprintf(w, "FAIL  %s: %v\n", check.Name, err)
+			continue
+		}
+		fmt.Fprintf(w, "PASS  %s\n", check.Name)
+		passed++
+	}
+
+	fmt.Fprintf(w, "%d/%d checks passed\n", passed, len(checks))
+	return passed == len(checks)
+}
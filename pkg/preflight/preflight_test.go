@@ -0,0 +1,35 @@
+package preflight
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRun_AllPass(t *testing.T) {
+	var out bytes.Buffer
+
+	ok := Run(&out, []Check{
+		{Name: "config", Run: func() error { return nil }},
+		{Name: "database", Run: func() error { return nil }},
+	})
+
+	assert.True(t, ok)
+	assert.Equal(t, "PASS  config\nPASS  database\n2/2 checks passed\n", out.String())
+}
+
+func TestRun_FailureDoesNotStopLaterChecks(t *testing.T) {
+	var out bytes.Buffer
+	ran := false
+
+	ok := Run(&out, []Check{
+		{Name: "database", Run: func() error { return errors.New("connection refused") }},
+		{Name: "jwt", Run: func() error { ran = true; return nil }},
+	})
+
+	assert.False(t, ok)
+	assert.True(t, ran)
+	assert.Equal(t, "FAIL  database: connection refused\nPASS  jwt\n1/2 checks passed\n", out.String())
+}
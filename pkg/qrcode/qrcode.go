@@ -0,0 +1,143 @@
+// Package qrcode encodes short text as a QR code symbol.
+//
+// It supports what printed vouchers need and no more: byte mode at error
+// correction level M, versions 1 to 4, i.e. up to 62 bytes of data.
+package qrcode
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrTooLong is returned when the data does not fit the largest supported version
+var ErrTooLong = errors.New("qrcode: data too long")
+
+// version describes the error correction layout of one QR version at level M
+type version struct {
+	number       int
+	ecPerBlock   int
+	blocks       int
+	dataPerBlock int
+	remainder    int
+}
+
+// versions lists the supported versions at error correction level M
+var versions = []version{
+	{number: 1, ecPerBlock: 10, blocks: 1, dataPerBlock: 16, remainder: 0},
+	{number: 2, ecPerBlock: 16, blocks: 1, dataPerBlock: 28, remainder: 7},
+	{number: 3, ecPerBlock: 26, blocks: 1, dataPerBlock: 44, remainder: 7},
+	{number: 4, ecPerBlock: 18, blocks: 2, dataPerBlock: 32, remainder: 7},
+}
+
+// eccLevelM is the format information value of error correction level M
+const eccLevelM = 0
+
+// Code is an encoded QR symbol
+type Code struct {
+	// Size is the number of modules per side, without a quiet zone
+	Size    int
+	modules [][]bool
+}
+
+// Dark reports whether the module at row, column is dark
+func (c *Code) Dark(row, col int) bool {
+	return c.modules[row][col]
+}
+
+// Encode encodes data in the smallest supported version, choosing the mask
+// with the lowest penalty score
+func Encode(data string) (*Code, error) {
+	var v *version
+	for i := range versions {
+		// Byte mode spends 4 bits on the mode and 8 on the length
+		if len(data) <= (versions[i].blocks*versions[i].dataPerBlock*8-12)/8 {
+			v = &versions[i]
+			break
+		}
+	}
+	if v == nil {
+		return nil, fmt.Errorf("%w: %d bytes, at most %d", ErrTooLong, len(data), (versions[len(versions)-1].blocks*versions[len(versions)-1].dataPerBlock*8-12)/8)
+	}
+
+	codewords := addErrorCorrection(encodeData([]byte(data), v), v)
+
+	var best *Code
+	bestPenalty := -1
+	for mask := 0; mask < 8; mask++ {
+		s := newSymbol(v)
+		s.drawCodewords(codewords)
+		s.applyMask(mask)
+		s.drawFormatBits(mask)
+		if penalty := s.penalty(); bestPenalty < 0 || penalty < bestPenalty {
+			best = &Code{Size: s.size, modules: s.modules}
+			bestPenalty = penalty
+		}
+	}
+	return best, nil
+}
+
+// encodeData builds the data codewords: mode, length, data, terminator and padding
+func encodeData(data []byte, v *version) []byte {
+	capacity := v.blocks * v.dataPerBlock * 8
+	var bits bitBuffer
+	bits.append(0x4, 4) // byte mode
+	bits.append(len(data), 8)
+	for _, b := range data {
+		bits.append(int(b), 8)
+	}
+
+	terminator := capacity - len(bits)
+	if terminator > 4 {
+		terminator = 4
+	}
+	bits.append(0, terminator)
+	bits.append(0, (8-len(bits)%8)%8)
+	for pad := 0xEC; len(bits) < capacity; pad ^= 0xEC ^ 0x11 {
+		bits.append(pad, 8)
+	}
+	return bits.bytes()
+}
+
+// addErrorCorrection splits the data into blocks, appends each block's
+// Reed-Solomon codewords and interleaves the result
+func addErrorCorrection(data []byte, v *version) []byte {
+	divisor := reedSolomonDivisor(v.ecPerBlock)
+	blocks := make([][]byte, v.blocks)
+	ecBlocks := make([][]byte, v.blocks)
+	for i := range blocks {
+		blocks[i] = data[i*v.dataPerBlock : (i+1)*v.dataPerBlock]
+		ecBlocks[i] = reedSolomonRemainder(blocks[i], divisor)
+	}
+
+	result := make([]byte, 0, len(data)+v.blocks*v.ecPerBlock)
+	for i := 0; i < v.dataPerBlock; i++ {
+		for _, block := range blocks {
+			result = append(result, block[i])
+		}
+	}
+	for i := 0; i < v.ecPerBlock; i++ {
+		for _, block := range ecBlocks {
+			result = append(result, block[i])
+		}
+	}
+	return result
+}
+
+// bitBuffer is a sequence of bits, most significant first
+type bitBuffer []bool
+
+func (b *bitBuffer) append(value, length int) {
+	for i := length - 1; i >= 0; i-- {
+		*b = append(*b, (value>>i)&1 == 1)
+	}
+}
+
+func (b bitBuffer) bytes() []byte {
+	result := make([]byte, len(b)/8)
+	for i, bit := range b {
+		if bit {
+			result[i/8] |= 1 << (7 - i%8)
+		}
+	}
+	return result
+}
@@ -0,0 +1,140 @@
+package qrcode
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReedSolomonRemainder(t *testing.T) {
+	// Arrange: the data codewords of "HELLO WORLD" as version 1-M, from the specification's worked example
+	data := []byte{32, 91, 11, 120, 209, 114, 220, 77, 67, 64, 236, 17, 236, 17, 236, 17}
+
+	// Act
+	ec := reedSolomonRemainder(data, reedSolomonDivisor(10))
+
+	// Assert
+	assert.Equal(t, []byte{196, 35, 39, 119, 235, 215, 231, 226, 93, 23}, ec)
+}
+
+func TestDrawFormatBits_LevelM(t *testing.T) {
+	// Format strings of level M for masks 0 to 7, from the specification's table
+	expected := []string{
+		"101010000010010", "101000100100101", "101111001111100", "101101101001011",
+		"100010111111001", "100000011001110", "100111110010111", "100101010100000",
+	}
+
+	for mask, want := range expected {
+		// Arrange
+		s := newSymbol(&versions[0])
+
+		// Act
+		s.drawFormatBits(mask)
+
+		// Assert: the first copy runs along row 8 from the left, then up column 8
+		var got strings.Builder
+		for _, pos := range [][2]int{{8, 0}, {8, 1}, {8, 2}, {8, 3}, {8, 4}, {8, 5}, {8, 7}, {8, 8}, {7, 8}, {5, 8}, {4, 8}, {3, 8}, {2, 8}, {1, 8}, {0, 8}} {
+			if s.modules[pos[0]][pos[1]] {
+				got.WriteByte('1')
+			} else {
+				got.WriteByte('0')
+			}
+		}
+		assert.Equal(t, want, got.String(), "mask %d", mask)
+	}
+}
+
+func TestEncode_PicksSmallestVersion(t *testing.T) {
+	testCases := []struct {
+		length int
+		size   int
+	}{
+		{1, 21},
+		{14, 21},
+		{15, 25},
+		{42, 29},
+		{43, 33},
+		{62, 33},
+	}
+
+	for _, tc := range testCases {
+		// Act
+		code, err := Encode(strings.Repeat("A", tc.length))
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, tc.size, code.Size, "length %d", tc.length)
+	}
+}
+
+func TestEncode_DrawsFunctionPatterns(t *testing.T) {
+	// Act
+	code, err := Encode("SUMMER2024")
+
+	// Assert
+	assert.NoError(t, err)
+	for _, corner := range [][2]int{{0, 0}, {0, code.Size - 7}, {code.Size - 7, 0}} {
+		assert.True(t, code.Dark(corner[0], corner[1]))
+		assert.True(t, code.Dark(corner[0]+3, corner[1]+3))
+		assert.False(t, code.Dark(corner[0]+1, corner[1]+1))
+	}
+	for i := 8; i < code.Size-8; i++ {
+		assert.Equal(t, i%2 == 0, code.Dark(6, i))
+		assert.Equal(t, i%2 == 0, code.Dark(i, 6))
+	}
+	assert.True(t, code.Dark(code.Size-8, 8))
+}
+
+func TestEncode_RoundTripsCodewords(t *testing.T) {
+	// Arrange
+	data := "VOUCHER-2026-ABCDEFGHIJKLMNOPQRSTUVWXYZ"
+	v := &versions[2]
+	want := addErrorCorrection(encodeData([]byte(data), v), v)
+
+	// Act
+	code, err := Encode(data)
+
+	// Assert: read the codewords back in placement order after undoing the mask named by the format bits
+	assert.NoError(t, err)
+	s := newSymbol(v)
+	format := 0
+	for i, pos := range [][2]int{{0, 8}, {1, 8}, {2, 8}, {3, 8}, {4, 8}, {5, 8}, {7, 8}, {8, 8}, {8, 7}, {8, 5}, {8, 4}, {8, 3}, {8, 2}, {8, 1}, {8, 0}} {
+		if code.Dark(pos[0], pos[1]) {
+			format |= 1 << i
+		}
+	}
+	format ^= 0x5412
+	assert.Equal(t, eccLevelM, format>>13)
+	mask := (format >> 10) & 7
+
+	var bits bitBuffer
+	for right := s.size - 1; right >= 1; right -= 2 {
+		if right == 6 {
+			right = 5
+		}
+		upward := (right+1)&2 == 0
+		for vert := 0; vert < s.size; vert++ {
+			row := vert
+			if upward {
+				row = s.size - 1 - vert
+			}
+			for j := 0; j < 2; j++ {
+				col := right - j
+				if !s.isFunction[row][col] {
+					bits = append(bits, code.Dark(row, col) != maskBit(mask, row, col))
+				}
+			}
+		}
+	}
+	assert.Equal(t, want, bits[:len(want)*8].bytes())
+}
+
+func TestEncode_TooLong(t *testing.T) {
+	// Act
+	_, err := Encode(strings.Repeat("A", 63))
+
+	// Assert
+	assert.True(t, errors.Is(err, ErrTooLong))
+}
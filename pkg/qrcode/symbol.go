@@ -0,0 +1,239 @@
+package qrcode
+
+// symbol is a QR symbol being drawn; isFunction marks the modules that hold
+// patterns and format information rather than data
+type symbol struct {
+	size       int
+	modules    [][]bool
+	isFunction [][]bool
+}
+
+// newSymbol returns a symbol of the version with its function patterns drawn
+func newSymbol(v *version) *symbol {
+	size := 17 + 4*v.number
+	s := &symbol{size: size, modules: make([][]bool, size), isFunction: make([][]bool, size)}
+	for i := range s.modules {
+		s.modules[i] = make([]bool, size)
+		s.isFunction[i] = make([]bool, size)
+	}
+
+	// Timing patterns
+	for i := 0; i < size; i++ {
+		s.setFunction(6, i, i%2 == 0)
+		s.setFunction(i, 6, i%2 == 0)
+	}
+
+	// Finder patterns with their separators
+	s.drawFinder(3, 3)
+	s.drawFinder(3, size-4)
+	s.drawFinder(size-4, 3)
+
+	// Versions 2 to 4 have a single alignment pattern, near the bottom right corner
+	if v.number > 1 {
+		s.drawAlignment(size-7, size-7)
+	}
+
+	// Reserve the format areas; drawFormatBits fills them once the mask is chosen
+	s.drawFormatBits(0)
+	return s
+}
+
+func (s *symbol) setFunction(row, col int, dark bool) {
+	s.modules[row][col] = dark
+	s.isFunction[row][col] = true
+}
+
+// drawFinder draws a finder pattern and its light separator around the center module
+func (s *symbol) drawFinder(centerRow, centerCol int) {
+	for dr := -4; dr <= 4; dr++ {
+		for dc := -4; dc <= 4; dc++ {
+			row, col := centerRow+dr, centerCol+dc
+			if row < 0 || row >= s.size || col < 0 || col >= s.size {
+				continue
+			}
+			distance := max(abs(dr), abs(dc))
+			s.setFunction(row, col, distance != 2 && distance != 4)
+		}
+	}
+}
+
+// drawAlignment draws a 5x5 alignment pattern around the center module
+func (s *symbol) drawAlignment(centerRow, centerCol int) {
+	for dr := -2; dr <= 2; dr++ {
+		for dc := -2; dc <= 2; dc++ {
+			s.setFunction(centerRow+dr, centerCol+dc, max(abs(dr), abs(dc)) != 1)
+		}
+	}
+}
+
+// drawFormatBits draws both copies of the format information for level M and
+// mask, plus the dark module that always sits next to the second copy
+func (s *symbol) drawFormatBits(mask int) {
+	data := eccLevelM<<3 | mask
+	remainder := data
+	for i := 0; i < 10; i++ {
+		remainder = (remainder << 1) ^ ((remainder >> 9) * 0x537)
+	}
+	bits := (data<<10 | remainder) ^ 0x5412
+	bit := func(i int) bool { return (bits>>i)&1 == 1 }
+
+	// First copy, around the top left finder
+	for i := 0; i <= 5; i++ {
+		s.setFunction(i, 8, bit(i))
+	}
+	s.setFunction(7, 8, bit(6))
+	s.setFunction(8, 8, bit(7))
+	s.setFunction(8, 7, bit(8))
+	for i := 9; i < 15; i++ {
+		s.setFunction(8, 14-i, bit(i))
+	}
+
+	// Second copy, split between the top right and bottom left finders
+	for i := 0; i < 8; i++ {
+		s.setFunction(8, s.size-1-i, bit(i))
+	}
+	for i := 8; i < 15; i++ {
+		s.setFunction(s.size-15+i, 8, bit(i))
+	}
+	s.setFunction(s.size-8, 8, true)
+}
+
+// drawCodewords places the codewords in the two-module-wide zigzag that runs
+// up and down from the bottom right corner, skipping the vertical timing pattern
+func (s *symbol) drawCodewords(codewords []byte) {
+	i := 0
+	for right := s.size - 1; right >= 1; right -= 2 {
+		if right == 6 {
+			right = 5
+		}
+		upward := (right+1)&2 == 0
+		for vert := 0; vert < s.size; vert++ {
+			row := vert
+			if upward {
+				row = s.size - 1 - vert
+			}
+			for j := 0; j < 2; j++ {
+				col := right - j
+				if s.isFunction[row][col] || i >= len(codewords)*8 {
+					continue
+				}
+				s.modules[row][col] = (codewords[i/8]>>(7-i%8))&1 == 1
+				i++
+			}
+		}
+	}
+	// Modules left over are remainder bits, which stay light
+}
+
+// applyMask flips the data modules selected by the mask pattern
+func (s *symbol) applyMask(mask int) {
+	for row := 0; row < s.size; row++ {
+		for col := 0; col < s.size; col++ {
+			if !s.isFunction[row][col] && maskBit(mask, row, col) {
+				s.modules[row][col] = !s.modules[row][col]
+			}
+		}
+	}
+}
+
+// maskBit reports whether the mask pattern flips the module at row, column
+func maskBit(mask, row, col int) bool {
+	switch mask {
+	case 0:
+		return (row+col)%2 == 0
+	case 1:
+		return row%2 == 0
+	case 2:
+		return col%3 == 0
+	case 3:
+		return (row+col)%3 == 0
+	case 4:
+		return (row/2+col/3)%2 == 0
+	case 5:
+		return row*col%2+row*col%3 == 0
+	case 6:
+		return (row*col%2+row*col%3)%2 == 0
+	default:
+		return ((row+col)%2+row*col%3)%2 == 0
+	}
+}
+
+// penalty scores the symbol by the four rules of the specification; the
+// mask giving the lowest score is the easiest to scan
+func (s *symbol) penalty() int {
+	total := 0
+	for i := 0; i < s.size; i++ {
+		row := make([]bool, s.size)
+		col := make([]bool, s.size)
+		for j := 0; j < s.size; j++ {
+			row[j] = s.modules[i][j]
+			col[j] = s.modules[j][i]
+		}
+		total += linePenalty(row) + linePenalty(col)
+	}
+
+	dark := 0
+	for row := 0; row < s.size; row++ {
+		for col := 0; col < s.size; col++ {
+			if s.modules[row][col] {
+				dark++
+			}
+			if row+1 < s.size && col+1 < s.size {
+				color := s.modules[row][col]
+				if s.modules[row][col+1] == color && s.modules[row+1][col] == color && s.modules[row+1][col+1] == color {
+					total += 3
+				}
+			}
+		}
+	}
+
+	percent := dark * 100 / (s.size * s.size)
+	total += abs(percent-50) / 5 * 10
+	return total
+}
+
+// finderLike is the 1:1:3:1:1 pattern scored by rule 3, with four light modules on one side
+var finderLike = [][]bool{
+	{true, false, true, true, true, false, true, false, false, false, false},
+	{false, false, false, false, true, false, true, true, true, false, true},
+}
+
+// linePenalty scores one row or column: runs of five or more modules of one
+// color, and patterns that look like a finder
+func linePenalty(line []bool) int {
+	total := 0
+	run := 1
+	for i := 1; i <= len(line); i++ {
+		if i < len(line) && line[i] == line[i-1] {
+			run++
+			continue
+		}
+		if run >= 5 {
+			total += run - 2
+		}
+		run = 1
+	}
+
+	for i := 0; i+len(finderLike[0]) <= len(line); i++ {
+		for _, pattern := range finderLike {
+			match := true
+			for j, dark := range pattern {
+				if line[i+j] != dark {
+					match = false
+					break
+				}
+			}
+			if match {
+				total += 40
+			}
+		}
+	}
+	return total
+}
+
+func abs(x int) int {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
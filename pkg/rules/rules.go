@@ -0,0 +1,182 @@
+package rules
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Condition types supported by the engine
+const (
+	ConditionSegment       = "segment"
+	ConditionFirstPurchase = "first_purchase"
+	ConditionMinItems      = "min_items"
+	ConditionWeekday       = "weekday"
+	ConditionChannel       = "channel"
+)
+
+// Condition is a single eligibility condition attached to a voucher
+type Condition struct {
+	Type   string   `json:"type"`
+	Value  int      `json:"value,omitempty"`
+	Values []string `json:"values,omitempty"`
+}
+
+// Context describes the purchase a voucher is being applied to
+type Context struct {
+	CustomerID       string    `json:"customer_id"`
+	CustomerSegments []string  `json:"customer_segments"`
+	IsFirstPurchase  bool      `json:"is_first_purchase"`
+	ItemCount        int       `json:"item_count"`
+	Channel          string    `json:"channel"`
+	Time             time.Time `json:"-"`
+}
+
+// evaluator validates a condition definition and checks it against a context
+type evaluator struct {
+	validate func(c Condition) error
+	check    func(c Condition, ctx Context) error
+}
+
+var evaluators = map[string]evaluator{
+	ConditionSegment: {
+		validate: func(c Condition) error {
+			if len(c.Values) == 0 {
+				return errors.New("segment condition requires at least one value")
+			}
+			return nil
+		},
+		check: func(c Condition, ctx Context) error {
+			for _, segment := range ctx.CustomerSegments {
+				for _, allowed := range c.Values {
+					if strings.EqualFold(segment, allowed) {
+						return nil
+					}
+				}
+			}
+			return fmt.Errorf("customer is not in segment %s", strings.Join(c.Values, ", "))
+		},
+	},
+	ConditionFirstPurchase: {
+		validate: func(c Condition) error { return nil },
+		check: func(c Condition, ctx Context) error {
+			if !ctx.IsFirstPurchase {
+				return errors.New("voucher is valid on first purchase only")
+			}
+			return nil
+		},
+	},
+	ConditionMinItems: {
+		validate: func(c Condition) error {
+			if c.Value < 1 {
+				return errors.New("min_items condition requires a value of at least 1")
+			}
+			return nil
+		},
+		check: func(c Condition, ctx Context) error {
+			if ctx.ItemCount < c.Value {
+				return fmt.Errorf("order must contain at least %d items", c.Value)
+			}
+			return nil
+		},
+	},
+	ConditionWeekday: {
+		validate: func(c Condition) error {
+			if len(c.Values) == 0 {
+				return errors.New("weekday condition requires at least one value")
+			}
+			for _, day := range c.Values {
+				if _, ok := parseWeekday(day); !ok {
+					return fmt.Errorf("invalid weekday '%s'", day)
+				}
+			}
+			return nil
+		},
+		check: func(c Condition, ctx Context) error {
+			for _, day := range c.Values {
+				if weekday, _ := parseWeekday(day); weekday == ctx.Time.Weekday() {
+					return nil
+				}
+			}
+			return fmt.Errorf("voucher is only valid on %s", strings.Join(c.Values, ", "))
+		},
+	},
+	ConditionChannel: {
+		validate: func(c Condition) error {
+			if len(c.Values) == 0 {
+				return errors.New("channel condition requires at least one value")
+			}
+			return nil
+		},
+		check: func(c Condition, ctx Context) error {
+			for _, allowed := range c.Values {
+				if strings.EqualFold(ctx.Channel, allowed) {
+					return nil
+				}
+			}
+			return fmt.Errorf("voucher is only valid on channel %s", strings.Join(c.Values, ", "))
+		},
+	},
+}
+
+// Parse decodes and validates a JSON list of conditions. An empty input means no conditions.
+func Parse(raw string) ([]Condition, error) {
+	if strings.TrimSpace(raw) == "" {
+		return nil, nil
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader([]byte(raw)))
+	decoder.DisallowUnknownFields()
+
+	var conditions []Condition
+	if err := decoder.Decode(&conditions); err != nil {
+		return nil, fmt.Errorf("invalid rules: %w", err)
+	}
+
+	for i, condition := range conditions {
+		eval, ok := evaluators[condition.Type]
+		if !ok {
+			return nil, fmt.Errorf("invalid rules: condition %d has unknown type '%s'", i+1, condition.Type)
+		}
+		if err := eval.validate(condition); err != nil {
+			return nil, fmt.Errorf("invalid rules: condition %d: %w", i+1, err)
+		}
+	}
+
+	return conditions, nil
+}
+
+// Evaluate checks every condition against the context and returns the reasons
+// the voucher is not applicable; an empty result means it is eligible
+func Evaluate(conditions []Condition, ctx Context) []string {
+	if ctx.Time.IsZero() {
+		ctx.Time = time.Now()
+	}
+
+	var violations []string
+	for _, condition := range conditions {
+		eval, ok := evaluators[condition.Type]
+		if !ok {
+			violations = append(violations, fmt.Sprintf("unknown condition '%s'", condition.Type))
+			continue
+		}
+		if err := eval.check(condition, ctx); err != nil {
+			violations = append(violations, err.Error())
+		}
+	}
+
+	return violations
+}
+
+// parseWeekday converts an English weekday name to time.Weekday
+func parseWeekday(name string) (time.Weekday, bool) {
+	for day := time.Sunday; day <= time.Saturday; day++ {
+		if strings.EqualFold(day.String(), name) {
+			return day, true
+		}
+	}
+	return 0, false
+}
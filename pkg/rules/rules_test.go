@@ -0,0 +1,97 @@
+package rules
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse_Empty(t *testing.T) {
+	conditions, err := Parse("")
+
+	assert.NoError(t, err)
+	assert.Nil(t, conditions)
+}
+
+func TestParse_Valid(t *testing.T) {
+	raw := `[{"type":"segment","values":["vip"]},{"type":"first_purchase"},{"type":"min_items","value":3},{"type":"weekday","values":["Saturday","sunday"]}]`
+
+	conditions, err := Parse(raw)
+
+	assert.NoError(t, err)
+	assert.Len(t, conditions, 4)
+}
+
+func TestParse_Invalid(t *testing.T) {
+	tests := []struct {
+		name string
+		raw  string
+	}{
+		{"malformed JSON", `[{"type":`},
+		{"unknown type", `[{"type":"country","values":["ID"]}]`},
+		{"unknown field", `[{"type":"first_purchase","foo":1}]`},
+		{"segment without values", `[{"type":"segment"}]`},
+		{"min items below one", `[{"type":"min_items","value":0}]`},
+		{"invalid weekday", `[{"type":"weekday","values":["funday"]}]`},
+		{"channel without values", `[{"type":"channel"}]`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conditions, err := Parse(tt.raw)
+
+			assert.Error(t, err)
+			assert.Nil(t, conditions)
+		})
+	}
+}
+
+func TestEvaluate_Segment(t *testing.T) {
+	conditions := []Condition{{Type: ConditionSegment, Values: []string{"vip"}}}
+
+	assert.Empty(t, Evaluate(conditions, Context{CustomerSegments: []string{"VIP"}}))
+	assert.Len(t, Evaluate(conditions, Context{CustomerSegments: []string{"regular"}}), 1)
+}
+
+func TestEvaluate_FirstPurchase(t *testing.T) {
+	conditions := []Condition{{Type: ConditionFirstPurchase}}
+
+	assert.Empty(t, Evaluate(conditions, Context{IsFirstPurchase: true}))
+	assert.Len(t, Evaluate(conditions, Context{IsFirstPurchase: false}), 1)
+}
+
+func TestEvaluate_MinItems(t *testing.T) {
+	conditions := []Condition{{Type: ConditionMinItems, Value: 3}}
+
+	assert.Empty(t, Evaluate(conditions, Context{ItemCount: 3}))
+	assert.Len(t, Evaluate(conditions, Context{ItemCount: 2}), 1)
+}
+
+func TestEvaluate_Weekday(t *testing.T) {
+	conditions := []Condition{{Type: ConditionWeekday, Values: []string{"saturday"}}}
+	saturday := time.Date(2025, 1, 4, 12, 0, 0, 0, time.UTC)
+	monday := time.Date(2025, 1, 6, 12, 0, 0, 0, time.UTC)
+
+	assert.Empty(t, Evaluate(conditions, Context{Time: saturday}))
+	assert.Len(t, Evaluate(conditions, Context{Time: monday}), 1)
+}
+
+func TestEvaluate_Channel(t *testing.T) {
+	conditions := []Condition{{Type: ConditionChannel, Values: []string{"pos", "web"}}}
+
+	assert.Empty(t, Evaluate(conditions, Context{Channel: "WEB"}))
+	assert.Len(t, Evaluate(conditions, Context{Channel: "app"}), 1)
+	assert.Len(t, Evaluate(conditions, Context{}), 1)
+}
+
+func TestEvaluate_CollectsAllViolations(t *testing.T) {
+	conditions := []Condition{
+		{Type: ConditionFirstPurchase},
+		{Type: ConditionMinItems, Value: 5},
+	}
+
+	violations := Evaluate(conditions, Context{ItemCount: 1})
+
+	assert.Len(t, violations, 2)
+}
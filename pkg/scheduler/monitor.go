@@ -0,0 +1,43 @@
+package scheduler
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/shoelfikar/voucher-management-system/pkg/lock"
+)
+
+// Monitor runs jobs like Every and remembers how each one's last run on this
+// instance ended, so the health of background work can be reported. Ticks
+// skipped because another instance holds the lock are not runs.
+type Monitor struct {
+	mu   sync.Mutex
+	errs map[string]error
+}
+
+// NewMonitor creates a monitor that has seen no runs yet
+func NewMonitor() *Monitor {
+	return &Monitor{errs: make(map[string]error)}
+}
+
+// Every schedules fn like the package-level Every and records the outcome of each run
+func (m *Monitor) Every(ctx context.Context, interval time.Duration, locker lock.Locker, name string, fn func(ctx context.Context) error) {
+	Every(ctx, interval, locker, name, func(ctx context.Context) error {
+		err := fn(ctx)
+		m.mu.Lock()
+		m.errs[name] = err
+		m.mu.Unlock()
+		return err
+	})
+}
+
+// Check returns a health check that fails with the error of the named job's
+// last run. It passes until the job first runs on this instance.
+func (m *Monitor) Check(name string) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		m.mu.Lock()
+		defer m.mu.Unlock()
+		return m.errs[name]
+	}
+}
@@ -0,0 +1,31 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/shoelfikar/voucher-management-system/pkg/lock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMonitor_ChecksLastRun(t *testing.T) {
+	monitor := NewMonitor()
+	check := monitor.Check("sms-dispatch")
+	assert.NoError(t, check(context.Background()), "jobs that have not run yet are healthy")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	var runs atomic.Int32
+	monitor.Every(ctx, 5*time.Millisecond, lock.NewMemoryLocker(), "sms-dispatch", func(ctx context.Context) error {
+		if runs.Add(1) == 2 {
+			cancel()
+			return errors.New("sms gateway unreachable")
+		}
+		return nil
+	})
+
+	assert.EqualError(t, check(context.Background()), "sms gateway unreachable")
+	assert.NoError(t, monitor.Check("expired-voucher-cleanup")(context.Background()))
+}
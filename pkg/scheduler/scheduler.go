@@ -0,0 +1,32 @@
+package scheduler
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/shoelfikar/voucher-management-system/pkg/lock"
+)
+
+// Every runs fn each interval until ctx is done. Every run first takes the
+// named lock, so with several replicas the job executes on one of them;
+// replicas that find the lock held skip that tick. Errors are logged and the
+// schedule continues.
+func Every(ctx context.Context, interval time.Duration, locker lock.Locker, name string, fn func(ctx context.Context) error) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			ran, err := lock.RunExclusive(ctx, locker, name, fn)
+			if err != nil {
+				slog.Error("scheduled job failed", "job", name, "error", err)
+			} else if !ran {
+				slog.Info("scheduled job skipped: running on another instance", "job", name)
+			}
+		}
+	}
+}
@@ -0,0 +1,53 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/shoelfikar/voucher-management-system/pkg/lock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEvery_RunsUntilCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	var runs atomic.Int32
+
+	done := make(chan struct{})
+	go func() {
+		Every(ctx, 5*time.Millisecond, lock.NewMemoryLocker(), "cleanup", func(ctx context.Context) error {
+			if runs.Add(1) == 3 {
+				cancel()
+			}
+			return errors.New("keeps going after errors")
+		})
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("scheduler did not stop after cancel")
+	}
+	assert.GreaterOrEqual(t, runs.Load(), int32(3))
+}
+
+func TestEvery_SkipsWhileLockHeld(t *testing.T) {
+	locker := lock.NewMemoryLocker()
+	held, err := locker.TryAcquire(context.Background(), "cleanup")
+	assert.NoError(t, err)
+	defer held.Release(context.Background())
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Millisecond)
+	defer cancel()
+	var runs atomic.Int32
+
+	Every(ctx, 5*time.Millisecond, locker, "cleanup", func(ctx context.Context) error {
+		runs.Add(1)
+		return nil
+	})
+
+	assert.Zero(t, runs.Load())
+}
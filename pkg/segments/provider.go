@@ -0,0 +1,63 @@
+package segments
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Provider answers membership questions for segments kept by an external
+// system, such as a CRM or customer data platform
+type Provider interface {
+	// IsMember reports whether customerID belongs to the segment named by ref
+	IsMember(ctx context.Context, ref, customerID string) (bool, error)
+}
+
+// httpProvider implements Provider against a simple REST API
+type httpProvider struct {
+	baseURL    string
+	token      string
+	httpClient *http.Client
+}
+
+// NewHTTPProvider creates a provider that asks
+// GET {baseURL}/segments/{ref}/members/{customerID}, treating 200 as a member
+// and 404 as not a member. A non-empty token is sent as a bearer token.
+func NewHTTPProvider(baseURL, token string, timeout time.Duration) Provider {
+	return &httpProvider{
+		baseURL:    strings.TrimRight(baseURL, "/"),
+		token:      token,
+		httpClient: &http.Client{Timeout: timeout},
+	}
+}
+
+// IsMember asks the external API whether the customer is in the segment
+func (p *httpProvider) IsMember(ctx context.Context, ref, customerID string) (bool, error) {
+	endpoint := fmt.Sprintf("%s/segments/%s/members/%s", p.baseURL, url.PathEscape(ref), url.PathEscape(customerID))
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return false, err
+	}
+	if p.token != "" {
+		req.Header.Set("Authorization", "Bearer "+p.token)
+	}
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return false, fmt.Errorf("segment provider request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return true, nil
+	case http.StatusNotFound:
+		return false, nil
+	default:
+		return false, fmt.Errorf("segment provider returned status %d", resp.StatusCode)
+	}
+}
@@ -0,0 +1,43 @@
+package segments
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHTTPProvider_IsMember(t *testing.T) {
+	// Arrange
+	var gotPath, gotAuth string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath, gotAuth = r.URL.EscapedPath(), r.Header.Get("Authorization")
+		switch r.URL.Path {
+		case "/segments/vip/members/cust 1":
+			w.WriteHeader(http.StatusOK)
+		case "/segments/vip/members/cust-2":
+			w.WriteHeader(http.StatusNotFound)
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer server.Close()
+	provider := NewHTTPProvider(server.URL+"/", "secret", time.Second)
+
+	// Act
+	member, memberErr := provider.IsMember(context.Background(), "vip", "cust 1")
+	nonMember, nonMemberErr := provider.IsMember(context.Background(), "vip", "cust-2")
+	_, failureErr := provider.IsMember(context.Background(), "broken", "cust-1")
+
+	// Assert
+	assert.NoError(t, memberErr)
+	assert.True(t, member)
+	assert.NoError(t, nonMemberErr)
+	assert.False(t, nonMember)
+	assert.Error(t, failureErr)
+	assert.Equal(t, "/segments/broken/members/cust-1", gotPath)
+	assert.Equal(t, "Bearer secret", gotAuth)
+}
@@ -0,0 +1,150 @@
+package sheets
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const (
+	readOnlyScope  = "https://www.googleapis.com/auth/spreadsheets.readonly"
+	sheetsEndpoint = "https://sheets.googleapis.com/v4/spreadsheets"
+)
+
+// Client reads rows from a Google Sheet
+type Client interface {
+	FetchRows() ([][]string, error)
+}
+
+// serviceAccount holds the fields used from a Google service-account key file
+type serviceAccount struct {
+	ClientEmail string `json:"client_email"`
+	PrivateKey  string `json:"private_key"`
+	TokenURI    string `json:"token_uri"`
+}
+
+// client implements Client using service-account credentials
+type client struct {
+	account       serviceAccount
+	spreadsheetID string
+	readRange     string
+	httpClient    *http.Client
+}
+
+// NewClient creates a Google Sheets client from a service-account key file
+func NewClient(credentialsFile, spreadsheetID, readRange string) (Client, error) {
+	data, err := os.ReadFile(credentialsFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read credentials file: %w", err)
+	}
+
+	var account serviceAccount
+	if err := json.Unmarshal(data, &account); err != nil {
+		return nil, fmt.Errorf("failed to parse credentials file: %w", err)
+	}
+	if account.ClientEmail == "" || account.PrivateKey == "" || account.TokenURI == "" {
+		return nil, errors.New("credentials file is not a service-account key")
+	}
+
+	return &client{
+		account:       account,
+		spreadsheetID: spreadsheetID,
+		readRange:     readRange,
+		httpClient:    &http.Client{Timeout: 30 * time.Second},
+	}, nil
+}
+
+// FetchRows returns the values of the configured range, header row included
+func (c *client) FetchRows() ([][]string, error) {
+	accessToken, err := c.accessToken()
+	if err != nil {
+		return nil, err
+	}
+
+	endpoint := fmt.Sprintf("%s/%s/values/%s", sheetsEndpoint,
+		url.PathEscape(c.spreadsheetID), url.PathEscape(c.readRange))
+	req, err := http.NewRequest(http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "Bearer "+accessToken)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch sheet: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to fetch sheet: unexpected status %d", resp.StatusCode)
+	}
+
+	var body struct {
+		Values [][]interface{} `json:"values"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return nil, fmt.Errorf("failed to decode sheet values: %w", err)
+	}
+
+	rows := make([][]string, len(body.Values))
+	for i, values := range body.Values {
+		row := make([]string, len(values))
+		for j, value := range values {
+			row[j] = fmt.Sprint(value)
+		}
+		rows[i] = row
+	}
+
+	return rows, nil
+}
+
+// accessToken exchanges a signed service-account assertion for an OAuth token
+func (c *client) accessToken() (string, error) {
+	privateKey, err := jwt.ParseRSAPrivateKeyFromPEM([]byte(c.account.PrivateKey))
+	if err != nil {
+		return "", fmt.Errorf("invalid service-account private key: %w", err)
+	}
+
+	now := time.Now()
+	assertion, err := jwt.NewWithClaims(jwt.SigningMethodRS256, jwt.MapClaims{
+		"iss":   c.account.ClientEmail,
+		"scope": readOnlyScope,
+		"aud":   c.account.TokenURI,
+		"iat":   now.Unix(),
+		"exp":   now.Add(time.Hour).Unix(),
+	}).SignedString(privateKey)
+	if err != nil {
+		return "", fmt.Errorf("failed to sign assertion: %w", err)
+	}
+
+	form := url.Values{
+		"grant_type": {"urn:ietf:params:oauth:grant-type:jwt-bearer"},
+		"assertion":  {assertion},
+	}
+	resp, err := c.httpClient.Post(c.account.TokenURI, "application/x-www-form-urlencoded",
+		strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", fmt.Errorf("failed to request access token: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("failed to request access token: unexpected status %d", resp.StatusCode)
+	}
+
+	var token struct {
+		AccessToken string `json:"access_token"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&token); err != nil {
+		return "", fmt.Errorf("failed to decode access token: %w", err)
+	}
+
+	return token.AccessToken, nil
+}
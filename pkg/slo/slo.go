@@ -0,0 +1,325 @@
+// Package slo tracks the success rate and latency of every endpoint over
+// rolling windows and checks them against service level objectives, so
+// compliance can be alerted on without an external APM.
+package slo
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"sync"
+	"time"
+)
+
+// LatencyPercentile is the share of requests, in percent, that must finish
+// within an endpoint's latency target
+const LatencyPercentile = 95
+
+// latencyBounds are the upper bounds of the latency histogram buckets.
+// Reported p95 latencies are the bound of the bucket the 95th percentile
+// falls in, or the slowest request when it is beyond the last bound.
+var latencyBounds = [...]time.Duration{
+	5 * time.Millisecond,
+	10 * time.Millisecond,
+	25 * time.Millisecond,
+	50 * time.Millisecond,
+	100 * time.Millisecond,
+	250 * time.Millisecond,
+	500 * time.Millisecond,
+	time.Second,
+	2500 * time.Millisecond,
+	5 * time.Second,
+	10 * time.Second,
+	30 * time.Second,
+}
+
+// tolerance absorbs float rounding when rates are compared with objectives,
+// so 999 of 1000 requests meet a 99.9% objective
+const tolerance = 1e-9
+
+// Slot sizing: the shortest window spans at least minSlotsPerWindow slots,
+// and no endpoint keeps more than maxSlots of them
+const (
+	minSlotsPerWindow = 5
+	maxSlots          = 720
+)
+
+// Objective is what every endpoint should achieve in each window: at least
+// SuccessRate percent of its requests succeed, and LatencyPercentile percent
+// finish within Latency
+type Objective struct {
+	Latency     time.Duration
+	SuccessRate float64
+}
+
+// Config sets the windows the tracker reports on and the objectives it
+// checks. LatencyTargets overrides the latency objective of single
+// endpoints, keyed by method and route such as "POST /api/v1/vouchers/:id/redeem".
+type Config struct {
+	Windows        []time.Duration
+	Objective      Objective
+	LatencyTargets map[string]time.Duration
+}
+
+// Tracker records requests per endpoint in time slots and reports on the
+// most recent ones. Records live in memory, so each instance reports on the
+// requests it served.
+type Tracker struct {
+	windows        []time.Duration
+	objective      Objective
+	latencyTargets map[string]time.Duration
+	slotSize       time.Duration
+	slotCount      int64
+	now            func() time.Time
+
+	mu        sync.Mutex
+	endpoints map[string]*series
+}
+
+// series holds the slots of one endpoint
+type series struct {
+	method string
+	route  string
+	target time.Duration
+	slots  []slot
+}
+
+// slot counts the requests an endpoint served in one slot of time
+type slot struct {
+	// index identifies the slot's time span; a ring entry whose index is
+	// not the expected one holds old data and counts as empty
+	index     int64
+	requests  int64
+	failures  int64
+	slow      int64
+	max       time.Duration
+	latencies [len(latencyBounds) + 1]int64
+}
+
+// Report is the compliance of every endpoint with its objectives, per window
+type Report struct {
+	GeneratedAt time.Time       `json:"generated_at"`
+	Objective   ObjectiveReport `json:"objective"`
+	// Compliant is whether every endpoint met its objectives in every window
+	Compliant bool           `json:"compliant"`
+	Windows   []WindowReport `json:"windows"`
+}
+
+// ObjectiveReport is the default objective every endpoint is checked against
+type ObjectiveReport struct {
+	LatencyPercentile int     `json:"latency_percentile"`
+	LatencyTargetMs   float64 `json:"latency_target_ms"`
+	SuccessRate       float64 `json:"success_rate"`
+}
+
+// WindowReport is the compliance of the endpoints that served requests in a window
+type WindowReport struct {
+	Window    string           `json:"window"`
+	Compliant bool             `json:"compliant"`
+	Endpoints []EndpointReport `json:"endpoints"`
+}
+
+// EndpointReport is how one endpoint did in a window. SuccessRate and
+// WithinLatencyTarget are percentages of its requests; LatencyP95Ms is an
+// estimate from a histogram, while LatencyMet is counted exactly.
+type EndpointReport struct {
+	Method              string  `json:"method"`
+	Route               string  `json:"route"`
+	Requests            int64   `json:"requests"`
+	Failures            int64   `json:"failures"`
+	SuccessRate         float64 `json:"success_rate"`
+	SuccessRateMet      bool    `json:"success_rate_met"`
+	LatencyP95Ms        float64 `json:"latency_p95_ms"`
+	LatencyTargetMs     float64 `json:"latency_target_ms"`
+	WithinLatencyTarget float64 `json:"within_latency_target"`
+	LatencyMet          bool    `json:"latency_met"`
+	Compliant           bool    `json:"compliant"`
+}
+
+// NewTracker creates a tracker for config. Requests are counted in slots of
+// at least a fifth of the shortest window, and a window covers the current
+// slot and the ones before it, so it reaches back at least its length less
+// one slot.
+func NewTracker(config Config) (*Tracker, error) {
+	if len(config.Windows) == 0 {
+		return nil, errors.New("at least one SLO window is required")
+	}
+	windows := append([]time.Duration(nil), config.Windows...)
+	sort.Slice(windows, func(i, j int) bool { return windows[i] < windows[j] })
+	if windows[0] < time.Minute {
+		return nil, fmt.Errorf("SLO window %s is shorter than a minute", windows[0])
+	}
+	if config.Objective.Latency <= 0 {
+		return nil, errors.New("SLO latency objective must be positive")
+	}
+	if config.Objective.SuccessRate <= 0 || config.Objective.SuccessRate > 100 {
+		return nil, fmt.Errorf("SLO success rate %g is not a percentage", config.Objective.SuccessRate)
+	}
+	for endpoint, target := range config.LatencyTargets {
+		if target <= 0 {
+			return nil, fmt.Errorf("SLO latency target of %s must be positive", endpoint)
+		}
+	}
+
+	longest := windows[len(windows)-1]
+	slotSize := max(windows[0]/minSlotsPerWindow, longest/maxSlots).Truncate(time.Second)
+
+	return &Tracker{
+		windows:        windows,
+		objective:      config.Objective,
+		latencyTargets: config.LatencyTargets,
+		slotSize:       slotSize,
+		slotCount:      int64((longest + slotSize - 1) / slotSize),
+		now:            time.Now,
+		endpoints:      make(map[string]*series),
+	}, nil
+}
+
+// Record counts a request to route that ended with status after latency.
+// Responses with a 5xx status count as failures.
+func (t *Tracker) Record(method, route string, status int, latency time.Duration) {
+	index := t.now().UnixNano() / int64(t.slotSize)
+	key := method + " " + route
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	s, ok := t.endpoints[key]
+	if !ok {
+		s = &series{method: method, route: route, target: t.latencyTarget(key), slots: make([]slot, t.slotCount)}
+		t.endpoints[key] = s
+	}
+
+	sl := &s.slots[index%t.slotCount]
+	if sl.index != index {
+		*sl = slot{index: index}
+	}
+	sl.requests++
+	if status >= 500 {
+		sl.failures++
+	}
+	if latency > s.target {
+		sl.slow++
+	}
+	sl.max = max(sl.max, latency)
+	sl.latencies[bucket(latency)]++
+}
+
+// Report sums up every endpoint's requests in each window and whether it
+// met its objectives. Endpoints without requests in a window are left out
+// of it and do not affect its compliance.
+func (t *Tracker) Report() *Report {
+	now := t.now()
+	current := now.UnixNano() / int64(t.slotSize)
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	keys := make([]string, 0, len(t.endpoints))
+	for key := range t.endpoints {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	report := &Report{
+		GeneratedAt: now.UTC(),
+		Objective: ObjectiveReport{
+			LatencyPercentile: LatencyPercentile,
+			LatencyTargetMs:   milliseconds(t.objective.Latency),
+			SuccessRate:       t.objective.SuccessRate,
+		},
+		Compliant: true,
+	}
+	for _, window := range t.windows {
+		slots := int64((window + t.slotSize - 1) / t.slotSize)
+		windowReport := WindowReport{Window: window.String(), Compliant: true, Endpoints: []EndpointReport{}}
+		for _, key := range keys {
+			endpoint, ok := t.endpoints[key].sum(current, slots, t.objective.SuccessRate)
+			if !ok {
+				continue
+			}
+			windowReport.Endpoints = append(windowReport.Endpoints, endpoint)
+			windowReport.Compliant = windowReport.Compliant && endpoint.Compliant
+		}
+		report.Windows = append(report.Windows, windowReport)
+		report.Compliant = report.Compliant && windowReport.Compliant
+	}
+	return report
+}
+
+// latencyTarget returns the latency objective of the endpoint with key
+func (t *Tracker) latencyTarget(key string) time.Duration {
+	if target, ok := t.latencyTargets[key]; ok {
+		return target
+	}
+	return t.objective.Latency
+}
+
+// sum adds up the series' last slots up to current into an endpoint report
+// checked against successRate, reporting false when they hold no requests
+func (s *series) sum(current, slots int64, successRate float64) (EndpointReport, bool) {
+	var total slot
+	for index := current - slots + 1; index <= current; index++ {
+		sl := s.slots[index%int64(len(s.slots))]
+		if sl.index != index || sl.requests == 0 {
+			continue
+		}
+		total.requests += sl.requests
+		total.failures += sl.failures
+		total.slow += sl.slow
+		total.max = max(total.max, sl.max)
+		for i, count := range sl.latencies {
+			total.latencies[i] += count
+		}
+	}
+	if total.requests == 0 {
+		return EndpointReport{}, false
+	}
+
+	report := EndpointReport{
+		Method:              s.method,
+		Route:               s.route,
+		Requests:            total.requests,
+		Failures:            total.failures,
+		SuccessRate:         percentage(total.requests-total.failures, total.requests),
+		LatencyP95Ms:        milliseconds(total.percentile(LatencyPercentile)),
+		LatencyTargetMs:     milliseconds(s.target),
+		WithinLatencyTarget: percentage(total.requests-total.slow, total.requests),
+	}
+	report.SuccessRateMet = report.SuccessRate >= successRate-tolerance
+	report.LatencyMet = report.WithinLatencyTarget >= LatencyPercentile-tolerance
+	report.Compliant = report.SuccessRateMet && report.LatencyMet
+	return report, true
+}
+
+// percentile estimates the latency below which percent of the slot's requests finished
+func (s *slot) percentile(percent float64) time.Duration {
+	rank := int64(math.Ceil(float64(s.requests) * percent / 100))
+	var seen int64
+	for i, count := range s.latencies {
+		seen += count
+		if seen >= rank {
+			if i < len(latencyBounds) {
+				return min(latencyBounds[i], s.max)
+			}
+			break
+		}
+	}
+	return s.max
+}
+
+// bucket returns the histogram bucket latency falls in
+func bucket(latency time.Duration) int {
+	return sort.Search(len(latencyBounds), func(i int) bool { return latency <= latencyBounds[i] })
+}
+
+// percentage returns part as a percentage of whole
+func percentage(part, whole int64) float64 {
+	return float64(part) / float64(whole) * 100
+}
+
+// milliseconds converts d to fractional milliseconds
+func milliseconds(d time.Duration) float64 {
+	return float64(d.Microseconds()) / 1000
+}
@@ -0,0 +1,144 @@
+package slo
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestTracker(t *testing.T, now *time.Time) *Tracker {
+	t.Helper()
+	tracker, err := NewTracker(Config{
+		Windows:   []time.Duration{time.Hour, 5 * time.Minute},
+		Objective: Objective{Latency: 500 * time.Millisecond, SuccessRate: 99},
+		LatencyTargets: map[string]time.Duration{
+			"POST /api/v1/vouchers/:id/redeem": 100 * time.Millisecond,
+		},
+	})
+	require.NoError(t, err)
+	tracker.now = func() time.Time { return *now }
+	return tracker
+}
+
+func TestTracker_Report(t *testing.T) {
+	// Arrange
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	tracker := newTestTracker(t, &now)
+
+	// Half an hour ago the redeem endpoint was slow and failing
+	now = now.Add(-30 * time.Minute)
+	for i := 0; i < 10; i++ {
+		tracker.Record("POST", "/api/v1/vouchers/:id/redeem", 500, 2*time.Second)
+	}
+	now = now.Add(30 * time.Minute)
+	for i := 0; i < 90; i++ {
+		tracker.Record("POST", "/api/v1/vouchers/:id/redeem", 201, 40*time.Millisecond)
+	}
+	for i := 0; i < 100; i++ {
+		tracker.Record("GET", "/api/v1/vouchers", 200, 300*time.Millisecond)
+	}
+
+	// Act
+	report := tracker.Report()
+
+	// Assert
+	assert.False(t, report.Compliant)
+	assert.Equal(t, ObjectiveReport{LatencyPercentile: 95, LatencyTargetMs: 500, SuccessRate: 99}, report.Objective)
+	require.Len(t, report.Windows, 2)
+
+	recent := report.Windows[0]
+	assert.Equal(t, "5m0s", recent.Window)
+	assert.True(t, recent.Compliant)
+	require.Len(t, recent.Endpoints, 2)
+	assert.Equal(t, EndpointReport{
+		Method: "GET", Route: "/api/v1/vouchers", Requests: 100,
+		SuccessRate: 100, SuccessRateMet: true,
+		LatencyP95Ms: 300, LatencyTargetMs: 500, WithinLatencyTarget: 100, LatencyMet: true,
+		Compliant: true,
+	}, recent.Endpoints[0])
+	assert.Equal(t, int64(90), recent.Endpoints[1].Requests)
+	assert.Equal(t, 40.0, recent.Endpoints[1].LatencyP95Ms)
+
+	hour := report.Windows[1]
+	assert.Equal(t, "1h0m0s", hour.Window)
+	assert.False(t, hour.Compliant)
+	redeem := hour.Endpoints[1]
+	assert.Equal(t, "POST", redeem.Method)
+	assert.Equal(t, int64(100), redeem.Requests)
+	assert.Equal(t, int64(10), redeem.Failures)
+	assert.Equal(t, 90.0, redeem.SuccessRate)
+	assert.False(t, redeem.SuccessRateMet)
+	assert.Equal(t, 100.0, redeem.LatencyTargetMs)
+	assert.Equal(t, 90.0, redeem.WithinLatencyTarget)
+	assert.False(t, redeem.LatencyMet)
+	assert.Equal(t, 2000.0, redeem.LatencyP95Ms)
+}
+
+func TestTracker_ReportForgetsOldRequests(t *testing.T) {
+	// Arrange
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	tracker := newTestTracker(t, &now)
+	tracker.Record("GET", "/api/v1/vouchers", 500, time.Millisecond)
+
+	// Act
+	now = now.Add(2 * time.Hour)
+	tracker.Record("GET", "/api/v1/vouchers", 200, time.Millisecond)
+	report := tracker.Report()
+
+	// Assert
+	assert.True(t, report.Compliant)
+	for _, window := range report.Windows {
+		require.Len(t, window.Endpoints, 1)
+		assert.Equal(t, int64(1), window.Endpoints[0].Requests)
+		assert.Equal(t, 100.0, window.Endpoints[0].SuccessRate)
+	}
+}
+
+func TestTracker_ReportWithoutRequests(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	tracker := newTestTracker(t, &now)
+
+	report := tracker.Report()
+
+	assert.True(t, report.Compliant)
+	for _, window := range report.Windows {
+		assert.True(t, window.Compliant)
+		assert.Empty(t, window.Endpoints)
+	}
+}
+
+func TestSlot_Percentile(t *testing.T) {
+	var s slot
+	for _, latency := range []time.Duration{3 * time.Millisecond, 8 * time.Millisecond, 45 * time.Second} {
+		s.requests++
+		s.max = max(s.max, latency)
+		s.latencies[bucket(latency)]++
+	}
+
+	assert.Equal(t, 5*time.Millisecond, s.percentile(30))
+	assert.Equal(t, 10*time.Millisecond, s.percentile(60))
+	assert.Equal(t, 45*time.Second, s.percentile(95))
+}
+
+func TestNewTracker_Invalid(t *testing.T) {
+	valid := Objective{Latency: time.Second, SuccessRate: 99.9}
+	tests := []struct {
+		name   string
+		config Config
+	}{
+		{"no windows", Config{Objective: valid}},
+		{"short window", Config{Windows: []time.Duration{30 * time.Second}, Objective: valid}},
+		{"no latency", Config{Windows: []time.Duration{time.Hour}, Objective: Objective{SuccessRate: 99}}},
+		{"success rate over 100", Config{Windows: []time.Duration{time.Hour}, Objective: Objective{Latency: time.Second, SuccessRate: 101}}},
+		{"zero latency target", Config{Windows: []time.Duration{time.Hour}, Objective: valid, LatencyTargets: map[string]time.Duration{"GET /health": 0}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewTracker(tt.config)
+			assert.Error(t, err)
+		})
+	}
+}
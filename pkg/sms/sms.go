@@ -0,0 +1,159 @@
+package sms
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Supported SMS providers
+const (
+	ProviderLog    = "log"
+	ProviderTwilio = "twilio"
+	ProviderVonage = "vonage"
+)
+
+// Default API endpoints of the hosted providers
+const (
+	twilioBaseURL = "https://api.twilio.com"
+	vonageBaseURL = "https://rest.nexmo.com"
+)
+
+// Sender sends text messages
+type Sender interface {
+	// Send delivers body to the E.164 phone number and returns the provider's message ID
+	Send(to, body string) (string, error)
+}
+
+// Config selects the provider and holds its credentials. AccountSID and
+// AuthToken are used by Twilio, APIKey and APISecret by Vonage.
+type Config struct {
+	Provider   string
+	From       string
+	AccountSID string
+	AuthToken  string
+	APIKey     string
+	APISecret  string
+	Timeout    time.Duration
+}
+
+// NewSender creates a sender for the configured provider; an empty provider logs messages instead
+func NewSender(cfg Config) (Sender, error) {
+	httpClient := &http.Client{Timeout: cfg.Timeout}
+
+	switch cfg.Provider {
+	case "", ProviderLog:
+		return logSender{}, nil
+	case ProviderTwilio:
+		if cfg.AccountSID == "" || cfg.AuthToken == "" || cfg.From == "" {
+			return nil, fmt.Errorf("twilio needs an account SID, auth token and sender number")
+		}
+		return &twilioSender{baseURL: twilioBaseURL, accountSID: cfg.AccountSID, authToken: cfg.AuthToken, from: cfg.From, httpClient: httpClient}, nil
+	case ProviderVonage:
+		if cfg.APIKey == "" || cfg.APISecret == "" || cfg.From == "" {
+			return nil, fmt.Errorf("vonage needs an API key, API secret and sender")
+		}
+		return &vonageSender{baseURL: vonageBaseURL, apiKey: cfg.APIKey, apiSecret: cfg.APISecret, from: cfg.From, httpClient: httpClient}, nil
+	default:
+		return nil, fmt.Errorf("unknown SMS provider %q (expected %s, %s or %s)", cfg.Provider, ProviderLog, ProviderTwilio, ProviderVonage)
+	}
+}
+
+// twilioSender sends messages through the Twilio Messages API
+type twilioSender struct {
+	baseURL    string
+	accountSID string
+	authToken  string
+	from       string
+	httpClient *http.Client
+}
+
+// Send creates a Twilio message; Twilio answers 201 with the message SID
+func (s *twilioSender) Send(to, body string) (string, error) {
+	endpoint := fmt.Sprintf("%s/2010-04-01/Accounts/%s/Messages.json", s.baseURL, url.PathEscape(s.accountSID))
+	form := url.Values{"To": {to}, "From": {s.from}, "Body": {body}}
+
+	req, err := http.NewRequest(http.MethodPost, endpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.SetBasicAuth(s.accountSID, s.authToken)
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("twilio request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var result struct {
+		SID     string `json:"sid"`
+		Message string `json:"message"`
+	}
+	_ = json.NewDecoder(resp.Body).Decode(&result)
+	if resp.StatusCode != http.StatusCreated {
+		return "", fmt.Errorf("twilio returned status %d: %s", resp.StatusCode, result.Message)
+	}
+	return result.SID, nil
+}
+
+// vonageSender sends messages through the Vonage SMS API
+type vonageSender struct {
+	baseURL    string
+	apiKey     string
+	apiSecret  string
+	from       string
+	httpClient *http.Client
+}
+
+// Send submits a Vonage message. Vonage answers 200 even when a message is
+// rejected; a status other than "0" on the first message is the failure.
+func (s *vonageSender) Send(to, body string) (string, error) {
+	form := url.Values{
+		"api_key":    {s.apiKey},
+		"api_secret": {s.apiSecret},
+		"from":       {s.from},
+		"to":         {strings.TrimPrefix(to, "+")},
+		"text":       {body},
+	}
+
+	resp, err := s.httpClient.PostForm(s.baseURL+"/sms/json", form)
+	if err != nil {
+		return "", fmt.Errorf("vonage request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("vonage returned status %d", resp.StatusCode)
+	}
+	var result struct {
+		Messages []struct {
+			Status    string `json:"status"`
+			MessageID string `json:"message-id"`
+			ErrorText string `json:"error-text"`
+		} `json:"messages"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return "", fmt.Errorf("invalid vonage response: %w", err)
+	}
+	if len(result.Messages) == 0 {
+		return "", fmt.Errorf("vonage accepted no messages")
+	}
+	if message := result.Messages[0]; message.Status != "0" {
+		return "", fmt.Errorf("vonage rejected the message with status %s: %s", message.Status, message.ErrorText)
+	}
+	return result.Messages[0].MessageID, nil
+}
+
+// logSender writes messages to the log instead of sending them, for local development
+type logSender struct{}
+
+// Send logs the message
+func (logSender) Send(to, body string) (string, error) {
+	slog.Info("SMS logged instead of sent", "to", to, "body", body)
+	return "", nil
+}
@@ -0,0 +1,77 @@
+package sms
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewSender_UnknownProvider(t *testing.T) {
+	// Act
+	sender, err := NewSender(Config{Provider: "carrier-pigeon"})
+	_, missingErr := NewSender(Config{Provider: ProviderTwilio, AccountSID: "AC1"})
+
+	// Assert
+	assert.Error(t, err)
+	assert.Nil(t, sender)
+	assert.Error(t, missingErr)
+}
+
+func TestTwilioSender_Send(t *testing.T) {
+	// Arrange
+	var gotPath, gotTo, gotUser string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		gotUser, _, _ = r.BasicAuth()
+		_ = r.ParseForm()
+		gotTo = r.PostForm.Get("To")
+		if gotTo == "+15550000000" {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"message":"invalid number"}`))
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"sid":"SM123"}`))
+	}))
+	defer server.Close()
+	sender := &twilioSender{baseURL: server.URL, accountSID: "AC1", authToken: "token", from: "+15551234567", httpClient: &http.Client{Timeout: time.Second}}
+
+	// Act
+	messageID, err := sender.Send("+15557654321", "hello")
+	_, rejectedErr := sender.Send("+15550000000", "hello")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "SM123", messageID)
+	assert.Equal(t, "/2010-04-01/Accounts/AC1/Messages.json", gotPath)
+	assert.Equal(t, "AC1", gotUser)
+	assert.ErrorContains(t, rejectedErr, "invalid number")
+}
+
+func TestVonageSender_Send(t *testing.T) {
+	// Arrange
+	var gotTo string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = r.ParseForm()
+		gotTo = r.PostForm.Get("to")
+		if gotTo == "15550000000" {
+			_, _ = w.Write([]byte(`{"messages":[{"status":"3","error-text":"Invalid to number"}]}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"messages":[{"status":"0","message-id":"0A00001"}]}`))
+	}))
+	defer server.Close()
+	sender := &vonageSender{baseURL: server.URL, apiKey: "key", apiSecret: "secret", from: "Vouchers", httpClient: &http.Client{Timeout: time.Second}}
+
+	// Act
+	messageID, err := sender.Send("+15557654321", "hello")
+	_, rejectedErr := sender.Send("+15550000000", "hello")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "0A00001", messageID)
+	assert.ErrorContains(t, rejectedErr, "Invalid to number")
+}
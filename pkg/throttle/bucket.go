@@ -0,0 +1,82 @@
+package throttle
+
+import (
+	"sync"
+	"time"
+)
+
+// RateLimiter decides whether key may make another request under limit,
+// and if not, how long until it may
+type RateLimiter interface {
+	Allow(key string, limit int) (allowed bool, retryAfter time.Duration)
+}
+
+// TokenBucket gives each key a bucket holding up to limit tokens, refilled
+// at limit tokens per window. Every request takes a token, so a key may
+// burst up to limit requests and then keeps going at the refill rate,
+// instead of waiting for a fixed window to reset. Buckets live in memory,
+// so with several instances each one enforces the limit separately.
+type TokenBucket struct {
+	window time.Duration
+	now    func() time.Time
+
+	mu      sync.Mutex
+	buckets map[string]*bucket
+	calls   int
+}
+
+// bucket holds the tokens one key had left when it was last used
+type bucket struct {
+	tokens    float64
+	updatedAt time.Time
+}
+
+// NewTokenBucket creates a limiter whose buckets refill completely in window
+func NewTokenBucket(window time.Duration) *TokenBucket {
+	return &TokenBucket{window: window, now: time.Now, buckets: make(map[string]*bucket)}
+}
+
+// Allow takes a token from key's bucket and reports whether there was one.
+// When there was not, retryAfter is the time until the next token. A limit
+// of 0 or less allows every request without counting it.
+func (b *TokenBucket) Allow(key string, limit int) (allowed bool, retryAfter time.Duration) {
+	if limit <= 0 {
+		return true, 0
+	}
+
+	now := b.now()
+	capacity := float64(limit)
+	perToken := b.window / time.Duration(limit)
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.calls++
+	if b.calls%sweepEvery == 0 {
+		b.sweep(now)
+	}
+
+	bk, ok := b.buckets[key]
+	if !ok {
+		bk = &bucket{tokens: capacity, updatedAt: now}
+		b.buckets[key] = bk
+	}
+	bk.tokens = min(capacity, bk.tokens+float64(now.Sub(bk.updatedAt))/float64(perToken))
+	bk.updatedAt = now
+
+	if bk.tokens < 1 {
+		return false, time.Duration((1 - bk.tokens) * float64(perToken))
+	}
+	bk.tokens--
+	return true, 0
+}
+
+// sweep removes buckets unused for a whole window, which are full again
+// and behave like new ones
+func (b *TokenBucket) sweep(now time.Time) {
+	for key, bk := range b.buckets {
+		if now.Sub(bk.updatedAt) >= b.window {
+			delete(b.buckets, key)
+		}
+	}
+}
@@ -0,0 +1,64 @@
+package throttle
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTokenBucket_Allow(t *testing.T) {
+	// Arrange
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	limiter := NewTokenBucket(time.Minute)
+	limiter.now = func() time.Time { return now }
+
+	// Act
+	first, _ := limiter.Allow("ip:1", 3)
+	second, _ := limiter.Allow("ip:1", 3)
+	third, _ := limiter.Allow("ip:1", 3)
+	now = now.Add(5 * time.Second)
+	emptied, retryAfter := limiter.Allow("ip:1", 3)
+	other, _ := limiter.Allow("ip:2", 3)
+	now = now.Add(15 * time.Second)
+	refilled, _ := limiter.Allow("ip:1", 3)
+	again, _ := limiter.Allow("ip:1", 3)
+
+	// Assert
+	assert.True(t, first)
+	assert.True(t, second)
+	assert.True(t, third)
+	assert.False(t, emptied)
+	assert.Equal(t, 15*time.Second, retryAfter)
+	assert.True(t, other)
+	assert.True(t, refilled)
+	assert.False(t, again)
+}
+
+func TestTokenBucket_Allow_ZeroLimitIsUnlimited(t *testing.T) {
+	limiter := NewTokenBucket(time.Minute)
+
+	for i := 0; i < 10; i++ {
+		allowed, _ := limiter.Allow("user:ana@example.com", 0)
+		assert.True(t, allowed)
+	}
+	assert.Empty(t, limiter.buckets)
+}
+
+func TestTokenBucket_SweepRemovesFullBuckets(t *testing.T) {
+	// Arrange
+	now := time.Now()
+	limiter := NewTokenBucket(time.Minute)
+	limiter.now = func() time.Time { return now }
+	limiter.Allow("old", 1)
+	limiter.Allow("recent", 1)
+	now = now.Add(time.Minute)
+	limiter.buckets["recent"].updatedAt = now
+
+	// Act
+	limiter.sweep(now)
+
+	// Assert
+	assert.NotContains(t, limiter.buckets, "old")
+	assert.Contains(t, limiter.buckets, "recent")
+}
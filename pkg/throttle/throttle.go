@@ -0,0 +1,77 @@
+// Package throttle counts requests per identity in fixed time windows, so a
+// single client or partner cannot flood an endpoint.
+package throttle
+
+import (
+	"sync"
+	"time"
+)
+
+// sweepEvery is how many Allow calls pass between removals of expired windows
+const sweepEvery = 1024
+
+// Limiter allows each key a number of requests per window. Counts live in
+// memory, so with several instances each one enforces the limit separately.
+type Limiter struct {
+	window time.Duration
+	now    func() time.Time
+
+	mu      sync.Mutex
+	windows map[string]*counter
+	calls   int
+}
+
+// counter holds the requests made by one key in its current window
+type counter struct {
+	resetAt time.Time
+	count   int
+}
+
+// NewLimiter creates a limiter counting requests per window
+func NewLimiter(window time.Duration) *Limiter {
+	return &Limiter{window: window, now: time.Now, windows: make(map[string]*counter)}
+}
+
+// Window returns the length of the limiter's windows
+func (l *Limiter) Window() time.Duration {
+	return l.window
+}
+
+// Allow counts a request by key against limit and reports whether it may
+// proceed. When it may not, retryAfter is the time until the window resets.
+// A limit of 0 or less allows every request without counting it.
+func (l *Limiter) Allow(key string, limit int) (allowed bool, retryAfter time.Duration) {
+	if limit <= 0 {
+		return true, 0
+	}
+
+	now := l.now()
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.calls++
+	if l.calls%sweepEvery == 0 {
+		l.sweep(now)
+	}
+
+	c, ok := l.windows[key]
+	if !ok || !now.Before(c.resetAt) {
+		c = &counter{resetAt: now.Add(l.window)}
+		l.windows[key] = c
+	}
+	if c.count >= limit {
+		return false, c.resetAt.Sub(now)
+	}
+	c.count++
+	return true, 0
+}
+
+// sweep removes windows that have ended, so keys seen once do not pile up
+func (l *Limiter) sweep(now time.Time) {
+	for key, c := range l.windows {
+		if !now.Before(c.resetAt) {
+			delete(l.windows, key)
+		}
+	}
+}
@@ -0,0 +1,57 @@
+package throttle
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLimiter_Allow(t *testing.T) {
+	// Arrange
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	limiter := NewLimiter(time.Minute)
+	limiter.now = func() time.Time { return now }
+
+	// Act
+	first, _ := limiter.Allow("ip:1", 2)
+	second, _ := limiter.Allow("ip:1", 2)
+	now = now.Add(20 * time.Second)
+	third, retryAfter := limiter.Allow("ip:1", 2)
+	other, _ := limiter.Allow("ip:2", 2)
+	now = now.Add(40 * time.Second)
+	afterReset, _ := limiter.Allow("ip:1", 2)
+
+	// Assert
+	assert.True(t, first)
+	assert.True(t, second)
+	assert.False(t, third)
+	assert.Equal(t, 40*time.Second, retryAfter)
+	assert.True(t, other)
+	assert.True(t, afterReset)
+}
+
+func TestLimiter_Allow_ZeroLimitIsUnlimited(t *testing.T) {
+	limiter := NewLimiter(time.Minute)
+
+	for i := 0; i < 10; i++ {
+		allowed, _ := limiter.Allow("partner:1", 0)
+		assert.True(t, allowed)
+	}
+	assert.Empty(t, limiter.windows)
+}
+
+func TestLimiter_SweepRemovesEndedWindows(t *testing.T) {
+	// Arrange
+	now := time.Now()
+	limiter := NewLimiter(time.Minute)
+	limiter.now = func() time.Time { return now }
+	limiter.Allow("old", 1)
+	now = now.Add(time.Minute)
+
+	// Act
+	limiter.sweep(now)
+
+	// Assert
+	assert.NotContains(t, limiter.windows, "old")
+}
@@ -0,0 +1,57 @@
+package timing
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const startKey = "timing:start"
+
+// GormPlugin records the duration of every query into the "db" metric of the
+// recorder carried by the statement context (set through db.WithContext).
+type GormPlugin struct{}
+
+// Name implements gorm.Plugin
+func (GormPlugin) Name() string {
+	return "server_timing"
+}
+
+// Initialize implements gorm.Plugin
+func (GormPlugin) Initialize(db *gorm.DB) error {
+	before := func(tx *gorm.DB) {
+		tx.InstanceSet(startKey, time.Now())
+	}
+	after := func(tx *gorm.DB) {
+		recorder, ok := FromContext(tx.Statement.Context)
+		if !ok {
+			return
+		}
+		if start, ok := tx.InstanceGet(startKey); ok {
+			recorder.Add("db", time.Since(start.(time.Time)))
+		}
+	}
+
+	cb := db.Callback()
+	registrations := []error{
+		cb.Create().Before("gorm:create").Register("timing:before_create", before),
+		cb.Create().After("gorm:create").Register("timing:after_create", after),
+		cb.Query().Before("gorm:query").Register("timing:before_query", before),
+		cb.Query().After("gorm:query").Register("timing:after_query", after),
+		cb.Update().Before("gorm:update").Register("timing:before_update", before),
+		cb.Update().After("gorm:update").Register("timing:after_update", after),
+		cb.Delete().Before("gorm:delete").Register("timing:before_delete", before),
+		cb.Delete().After("gorm:delete").Register("timing:after_delete", after),
+		cb.Row().Before("gorm:row").Register("timing:before_row", before),
+		cb.Row().After("gorm:row").Register("timing:after_row", after),
+		cb.Raw().Before("gorm:raw").Register("timing:before_raw", before),
+		cb.Raw().After("gorm:raw").Register("timing:after_raw", after),
+	}
+	for _, err := range registrations {
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
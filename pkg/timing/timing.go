@@ -0,0 +1,71 @@
+package timing
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+type contextKey struct{}
+
+// Recorder accumulates named durations for a single request
+type Recorder struct {
+	mu      sync.Mutex
+	start   time.Time
+	metrics map[string]time.Duration
+	order   []string
+}
+
+// NewRecorder creates a recorder whose total duration starts now
+func NewRecorder() *Recorder {
+	return &Recorder{
+		start:   time.Now(),
+		metrics: map[string]time.Duration{},
+	}
+}
+
+// Add adds d to the named metric
+func (r *Recorder) Add(name string, d time.Duration) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.metrics[name]; !ok {
+		r.order = append(r.order, name)
+	}
+	r.metrics[name] += d
+}
+
+// Header formats the recorded metrics plus the elapsed total as a Server-Timing header value
+func (r *Recorder) Header() string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	parts := make([]string, 0, len(r.order)+1)
+	for _, name := range r.order {
+		parts = append(parts, formatMetric(name, r.metrics[name]))
+	}
+	parts = append(parts, formatMetric("total", time.Since(r.start)))
+
+	return strings.Join(parts, ", ")
+}
+
+// formatMetric renders a metric with its duration in milliseconds
+func formatMetric(name string, d time.Duration) string {
+	return fmt.Sprintf("%s;dur=%.2f", name, float64(d.Microseconds())/1000)
+}
+
+// WithRecorder returns a copy of ctx carrying the recorder
+func WithRecorder(ctx context.Context, r *Recorder) context.Context {
+	return context.WithValue(ctx, contextKey{}, r)
+}
+
+// FromContext returns the recorder carried by ctx, if any
+func FromContext(ctx context.Context) (*Recorder, bool) {
+	if ctx == nil {
+		return nil, false
+	}
+	r, ok := ctx.Value(contextKey{}).(*Recorder)
+	return r, ok
+}
@@ -0,0 +1,60 @@
+package timing
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func TestRecorder_Header(t *testing.T) {
+	// Arrange
+	recorder := NewRecorder()
+	recorder.Add("db", 1500*time.Microsecond)
+	recorder.Add("db", 500*time.Microsecond)
+
+	// Act
+	header := recorder.Header()
+
+	// Assert
+	assert.True(t, strings.HasPrefix(header, "db;dur=2.00, total;dur="))
+}
+
+func TestFromContext(t *testing.T) {
+	// Arrange
+	recorder := NewRecorder()
+	ctx := WithRecorder(context.Background(), recorder)
+
+	// Act
+	found, ok := FromContext(ctx)
+	_, missing := FromContext(context.Background())
+
+	// Assert
+	assert.True(t, ok)
+	assert.Same(t, recorder, found)
+	assert.False(t, missing)
+}
+
+func TestGormPlugin_RecordsQueryDuration(t *testing.T) {
+	// Arrange
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("Failed to connect to test database: %v", err)
+	}
+	assert.NoError(t, db.Use(GormPlugin{}))
+
+	recorder := NewRecorder()
+	ctx := WithRecorder(context.Background(), recorder)
+
+	// Act
+	var result int
+	err = db.WithContext(ctx).Raw("SELECT 1").Scan(&result).Error
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Contains(t, recorder.Header(), "db;dur=")
+}
@@ -0,0 +1,28 @@
+package utils
+
+import "strings"
+
+// Characters of a voucher code MaskCode keeps visible at each end
+const (
+	maskedCodePrefix = 4
+	maskedCodeSuffix = 2
+)
+
+// MaskCode partially redacts a voucher code, e.g. SUMMER2024 becomes
+// SUMM****24. At most 60% of the code stays visible, so short codes reveal
+// fewer characters and a single-character code is hidden entirely.
+func MaskCode(code string) string {
+	runes := []rune(code)
+	visible := len(runes) * 3 / 5
+
+	prefix, suffix := maskedCodePrefix, maskedCodeSuffix
+	for prefix+suffix > visible {
+		if prefix > suffix {
+			prefix--
+		} else {
+			suffix--
+		}
+	}
+
+	return string(runes[:prefix]) + strings.Repeat("*", len(runes)-prefix-suffix) + string(runes[len(runes)-suffix:])
+}
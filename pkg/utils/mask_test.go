@@ -0,0 +1,33 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMaskCode(t *testing.T) {
+	testCases := []struct {
+		code     string
+		expected string
+	}{
+		{"SUMMER2024", "SUMM****24"},
+		{"WELCOME-NEW-CUSTOMER", "WELC**************ER"},
+		{"SAVE2024", "SA****24"},
+		{"ABC", "A**"},
+		{"AB", "A*"},
+		{"A", "*"},
+		{"", ""},
+		{"ÉTÉ-2024", "ÉT****24"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.code, func(t *testing.T) {
+			// Act
+			masked := MaskCode(tc.code)
+
+			// Assert
+			assert.Equal(t, tc.expected, masked)
+		})
+	}
+}
@@ -2,7 +2,9 @@ package utils
 
 import (
 	"math"
+	"net/url"
 	"strconv"
+	"strings"
 )
 
 const (
@@ -75,3 +77,51 @@ func calculateOffset(page, limit int) int {
 func CalculateTotalPages(total int64, limit int) int {
 	return int(math.Ceil(float64(total) / float64(limit)))
 }
+
+// ListParams holds the pagination, search and sort parameters shared by list endpoints
+type ListParams struct {
+	Page   int
+	Limit  int
+	Search string
+	Sort   []SortField
+}
+
+// Offset returns the number of rows skipped before the current page
+func (p ListParams) Offset() int {
+	return calculateOffset(p.Page, p.Limit)
+}
+
+// ParseListParams reads page, limit, search and sort from query values.
+// Page and limit fall back to their defaults and limit is capped at MaxLimit.
+// sort takes "field:direction,..." (see ParseSort); when it is absent the
+// older sort_by and sort_order pair is honored, and otherwise defaultSort.
+func ParseListParams(query url.Values, allowedSort []string, defaultSort string) (ListParams, error) {
+	sortExpr := query.Get("sort")
+	if sortExpr == "" && (query.Get("sort_by") != "" || query.Get("sort_order") != "") {
+		defaultField, _, _ := strings.Cut(defaultSort, ":")
+		field := query.Get("sort_by")
+		if field == "" {
+			field = defaultField
+		}
+		order := query.Get("sort_order")
+		if order == "" {
+			order = "desc"
+		}
+		sortExpr = field + ":" + order
+	}
+	if sortExpr == "" {
+		sortExpr = defaultSort
+	}
+
+	sort, err := ParseSort(sortExpr, allowedSort)
+	if err != nil {
+		return ListParams{}, err
+	}
+
+	return ListParams{
+		Page:   parsePage(query.Get("page")),
+		Limit:  parseLimit(query.Get("limit")),
+		Search: query.Get("search"),
+		Sort:   sort,
+	}, nil
+}
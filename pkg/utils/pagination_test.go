@@ -0,0 +1,56 @@
+package utils
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var testListSortFields = []string{"id", "name", "created_at"}
+
+func TestParseListParams_Defaults(t *testing.T) {
+	params, err := ParseListParams(url.Values{}, testListSortFields, "created_at:desc")
+
+	assert.NoError(t, err)
+	assert.Equal(t, ListParams{
+		Page:  DefaultPage,
+		Limit: DefaultLimit,
+		Sort:  []SortField{{Field: "created_at", Desc: true}},
+	}, params)
+	assert.Equal(t, 0, params.Offset())
+}
+
+func TestParseListParams_ClampsPageAndLimit(t *testing.T) {
+	params, err := ParseListParams(url.Values{"page": {"-2"}, "limit": {"5000"}}, testListSortFields, "id")
+
+	assert.NoError(t, err)
+	assert.Equal(t, DefaultPage, params.Page)
+	assert.Equal(t, MaxLimit, params.Limit)
+
+	params, err = ParseListParams(url.Values{"page": {"3"}, "limit": {"0"}}, testListSortFields, "id")
+
+	assert.NoError(t, err)
+	assert.Equal(t, DefaultLimit, params.Limit)
+	assert.Equal(t, 20, params.Offset())
+}
+
+func TestParseListParams_SortPrecedence(t *testing.T) {
+	params, err := ParseListParams(url.Values{"sort": {"name:asc"}, "sort_by": {"id"}}, testListSortFields, "id")
+	assert.NoError(t, err)
+	assert.Equal(t, []SortField{{Field: "name"}}, params.Sort)
+
+	params, err = ParseListParams(url.Values{"sort_by": {"name"}}, testListSortFields, "id")
+	assert.NoError(t, err)
+	assert.Equal(t, []SortField{{Field: "name", Desc: true}}, params.Sort)
+
+	params, err = ParseListParams(url.Values{"sort_order": {"asc"}}, testListSortFields, "created_at:desc")
+	assert.NoError(t, err)
+	assert.Equal(t, []SortField{{Field: "created_at"}}, params.Sort)
+}
+
+func TestParseListParams_InvalidSort(t *testing.T) {
+	_, err := ParseListParams(url.Values{"sort": {"password:asc"}}, testListSortFields, "id")
+
+	assert.Error(t, err)
+}
@@ -0,0 +1,63 @@
+package utils
+
+import (
+	"fmt"
+	"strings"
+)
+
+// SortField is a single column of a multi-column sort
+type SortField struct {
+	Field string
+	Desc  bool
+}
+
+// ParseSort parses a sort expression such as "expiry_date:asc,discount_percent:desc".
+// Fields must be in the allowed list; the direction defaults to ascending.
+func ParseSort(raw string, allowed []string) ([]SortField, error) {
+	allowedFields := make(map[string]bool, len(allowed))
+	for _, field := range allowed {
+		allowedFields[field] = true
+	}
+
+	var fields []SortField
+	seen := make(map[string]bool)
+	for _, part := range strings.Split(raw, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		name, direction, _ := strings.Cut(part, ":")
+		name = strings.TrimSpace(name)
+		direction = strings.ToLower(strings.TrimSpace(direction))
+
+		if !allowedFields[name] {
+			return nil, fmt.Errorf("cannot sort by '%s'", name)
+		}
+		if seen[name] {
+			return nil, fmt.Errorf("sort field '%s' is listed more than once", name)
+		}
+		if direction != "" && direction != "asc" && direction != "desc" {
+			return nil, fmt.Errorf("invalid sort direction '%s' for '%s', must be asc or desc", direction, name)
+		}
+
+		seen[name] = true
+		fields = append(fields, SortField{Field: name, Desc: direction == "desc"})
+	}
+
+	return fields, nil
+}
+
+// OrderClause renders sort fields as an SQL ORDER BY list.
+// Fields must come from ParseSort so that only whitelisted columns are used.
+func OrderClause(fields []SortField) string {
+	parts := make([]string, len(fields))
+	for i, field := range fields {
+		direction := "asc"
+		if field.Desc {
+			direction = "desc"
+		}
+		parts[i] = field.Field + " " + direction
+	}
+	return strings.Join(parts, ", ")
+}
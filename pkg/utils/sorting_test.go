@@ -0,0 +1,49 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var testSortFields = []string{"voucher_code", "expiry_date", "discount_percent"}
+
+func TestParseSort_MultipleFields(t *testing.T) {
+	fields, err := ParseSort("expiry_date:asc, discount_percent:DESC,voucher_code", testSortFields)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []SortField{
+		{Field: "expiry_date"},
+		{Field: "discount_percent", Desc: true},
+		{Field: "voucher_code"},
+	}, fields)
+	assert.Equal(t, "expiry_date asc, discount_percent desc, voucher_code asc", OrderClause(fields))
+}
+
+func TestParseSort_Empty(t *testing.T) {
+	fields, err := ParseSort("", testSortFields)
+
+	assert.NoError(t, err)
+	assert.Empty(t, fields)
+}
+
+func TestParseSort_Invalid(t *testing.T) {
+	tests := []struct {
+		name string
+		raw  string
+	}{
+		{"unknown field", "password:asc"},
+		{"injection attempt", "expiry_date; DROP TABLE vouchers"},
+		{"bad direction", "expiry_date:up"},
+		{"duplicate field", "expiry_date:asc,expiry_date:desc"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fields, err := ParseSort(tt.raw, testSortFields)
+
+			assert.Error(t, err)
+			assert.Nil(t, fields)
+		})
+	}
+}
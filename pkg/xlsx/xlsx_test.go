@@ -0,0 +1,63 @@
+package xlsx
+
+import (
+	"archive/zip"
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func readPart(t *testing.T, data []byte, name string) string {
+	archive, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		t.Fatalf("Failed to open workbook: %v", err)
+	}
+
+	file, err := archive.Open(name)
+	if err != nil {
+		t.Fatalf("Failed to open %s: %v", name, err)
+	}
+	defer file.Close()
+
+	content, err := io.ReadAll(file)
+	if err != nil {
+		t.Fatalf("Failed to read %s: %v", name, err)
+	}
+	return string(content)
+}
+
+func TestBytes_WritesRowsAsInlineStrings(t *testing.T) {
+	// Act
+	data, err := Bytes("Vouchers", [][]string{
+		{"voucher_code", "discount_percent"},
+		{"SUMMER25", "10.00"},
+	})
+
+	// Assert
+	assert.NoError(t, err)
+	sheet := readPart(t, data, "xl/worksheets/sheet1.xml")
+	assert.Contains(t, sheet, `<c r="A1" t="inlineStr"><is><t xml:space="preserve">voucher_code</t></is></c>`)
+	assert.Contains(t, sheet, `<c r="B2" t="inlineStr"><is><t xml:space="preserve">10.00</t></is></c>`)
+	assert.Contains(t, readPart(t, data, "xl/workbook.xml"), `name="Vouchers"`)
+	assert.Contains(t, readPart(t, data, "[Content_Types].xml"), "/xl/worksheets/sheet1.xml")
+}
+
+func TestBytes_EscapesText(t *testing.T) {
+	// Act
+	data, err := Bytes("A & B", [][]string{{"<10% off> & more"}})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Contains(t, readPart(t, data, "xl/worksheets/sheet1.xml"), "&lt;10% off&gt; &amp; more")
+	assert.Contains(t, readPart(t, data, "xl/workbook.xml"), `name="A &amp; B"`)
+}
+
+func TestColumn(t *testing.T) {
+	assert.Equal(t, "A", column(0))
+	assert.Equal(t, "Z", column(25))
+	assert.Equal(t, "AA", column(26))
+	assert.Equal(t, "AZ", column(51))
+	assert.Equal(t, "BA", column(52))
+}